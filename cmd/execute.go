@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const usage = `Usage: main [command] [flags]
+
+Commands:
+  serve      Start the gRPC server and HTTP gateway (default)
+  reconcile  Check account balances, entries and transfers for drift
+`
+
+// Execute runs the subcommand named by the first argument.
+// The subcommand is removed from os.Args so the remaining flags are parsed by config.Set
+func Execute() {
+	command := "serve"
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		command = os.Args[1]
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+	}
+
+	switch command {
+	case "serve":
+		Serve()
+	case "reconcile":
+		Reconcile()
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
+	}
+}
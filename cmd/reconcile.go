@@ -0,0 +1,13 @@
+package cmd
+
+import (
+	"os"
+
+	"lemfi/simplebank/pkg/bootstrap"
+)
+
+// Reconcile reports drift between account balances, entries and transfers
+// and exits non-zero when the ledger does not balance
+func Reconcile() {
+	os.Exit(bootstrap.Reconcile())
+}
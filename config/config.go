@@ -18,12 +18,69 @@ type Config struct {
 	Cors struct {
 		TrustedOrigins []string
 	}
-	ExchangeRate struct {
+	// IPs and CIDR ranges of the proxies whose X-Forwarded-For headers are trusted for the client IP
+	TrustedProxies []string
+	ExchangeRate   struct {
 		ExpiredTimeInMinutes int
+		QuoteExpiry          time.Duration
+		PivotCurrency        string
+		MarkupBps            int
+		Provider             struct {
+			URL             string
+			Timeout         time.Duration
+			RefreshInterval time.Duration
+			RetryBackoff    time.Duration
+			MaxRetryBackoff time.Duration
+		}
 	}
 	MultiCurrency struct {
 		Fee decimal.Decimal
 	}
+	Currencies struct {
+		RefreshInterval time.Duration
+	}
+	ScheduledTransfers struct {
+		PollInterval time.Duration
+		BatchSize    int
+		MaxAttempts  int
+		RetryBackoff time.Duration
+	}
+	Transfers struct {
+		ReversalRate         string
+		BatchMaxItems        int
+		RequireVerifiedEmail bool
+		// Amounts per currency above which a transfer needs a TOTP code,
+		// currencies without a threshold never need one
+		StepUpThresholds map[string]decimal.Decimal
+	}
+	TransferHolds struct {
+		Expiry       time.Duration
+		PollInterval time.Duration
+		BatchSize    int
+	}
+	Mail struct {
+		SMTPHost     string
+		SMTPPort     int
+		SMTPUsername string
+		SMTPPassword string
+		From         string
+	}
+	EmailVerification struct {
+		TokenExpiry time.Duration
+		URL         string
+	}
+	PasswordReset struct {
+		TokenExpiry time.Duration
+		URL         string
+	}
+	MFA struct {
+		EncryptionKey          string
+		Issuer                 string
+		ChallengeTokenDuration time.Duration
+		// Wrong second factors in a row before a user is locked out, and for how long
+		MaxFailedAttempts int
+		LockoutDuration   time.Duration
+	}
 	TokenSymmetricKey    string
 	AccessTokenDuration  time.Duration
 	RefreshTokenDuration time.Duration
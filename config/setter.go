@@ -1,7 +1,9 @@
 package config
 
 import (
+	"crypto/sha256"
 	"flag"
+	"net/netip"
 	"os"
 	"strconv"
 	"strings"
@@ -10,6 +12,20 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// mfaEncryptionKeyLength is the key size of the XChaCha20-Poly1305 cipher TOTP secrets are encrypted with
+const mfaEncryptionKeyLength = 32
+
+// MaxMarkupBps caps an FX markup at 10%, which catches typos rather than restricting real spreads
+const MaxMarkupBps = 1000
+
+// Exchange rates a cross-currency reversal can be converted at
+const (
+	// ReversalRateOriginal returns the sender what the original transfer took, in proportion to the amount reversed
+	ReversalRateOriginal = "original"
+	// ReversalRateCurrent converts the amount reversed at the current mid-market rate
+	ReversalRateCurrent = "current"
+)
+
 func Set() Config {
 
 	exchangeRateExpiredTimeInMinutes, err := strconv.Atoi(os.Getenv("EXCHANGE_RATE_EXPIRED_TIME_IN_MINUTES"))
@@ -27,6 +43,7 @@ func Set() Config {
 
 	// Create a string variable to hold the fee flag value
 	var feeFlag string
+	var stepUpThresholdsFlag string
 
 	// Set configurations using environment variables or flags
 	flag.IntVar(&configurations.Port, "port", 4000, "API server port")
@@ -36,10 +53,44 @@ func Set() Config {
 	flag.IntVar(&configurations.Db.MaxIdleConns, "db-max-idle-conns", 25, "PostgreSQL max idle connections")
 	flag.DurationVar(&configurations.Db.MaxIdleTime, "db-max-idle-time", 15*time.Minute, "PostgreSQL max connection idle time")
 	flag.IntVar(&configurations.ExchangeRate.ExpiredTimeInMinutes, "exchange-rate-expired-time-in-minutes", exchangeRateExpiredTimeInMinutes, "Exchange rate expired time in minutes")
+	flag.DurationVar(&configurations.ExchangeRate.QuoteExpiry, "exchange-rate-quote-expiry", 2*time.Minute, "How long a quoted exchange rate is honoured by transfers")
+	flag.StringVar(&configurations.ExchangeRate.PivotCurrency, "exchange-rate-pivot-currency", "USD", "Currency rates are derived through when a pair has no direct rate (empty disables it)")
+	flag.IntVar(&configurations.ExchangeRate.MarkupBps, "exchange-rate-markup-bps", 0, "FX markup in basis points for pairs without their own markup")
+	flag.StringVar(&configurations.ExchangeRate.Provider.URL, "exchange-rate-provider-url", os.Getenv("EXCHANGE_RATE_PROVIDER_URL"), "URL of the JSON exchange rate provider (empty disables refreshing)")
+	flag.DurationVar(&configurations.ExchangeRate.Provider.Timeout, "exchange-rate-provider-timeout", 10*time.Second, "Timeout of a request to the exchange rate provider")
+	flag.DurationVar(&configurations.ExchangeRate.Provider.RefreshInterval, "exchange-rate-refresh-interval", time.Minute, "How often rates are refreshed from the provider (0 disables it)")
+	flag.DurationVar(&configurations.ExchangeRate.Provider.RetryBackoff, "exchange-rate-refresh-retry-backoff", 10*time.Second, "Delay before retrying a failed refresh, doubled after each further failure")
+	flag.DurationVar(&configurations.ExchangeRate.Provider.MaxRetryBackoff, "exchange-rate-refresh-max-retry-backoff", 5*time.Minute, "Longest delay between retries of a failed refresh")
 	flag.StringVar(&feeFlag, "multi-currency-fee", multiCurrencyFee.String(), "Multi currency fee")
+	flag.DurationVar(&configurations.Currencies.RefreshInterval, "currencies-refresh-interval", time.Minute, "How often the currency registry is reloaded from the database (0 disables it)")
 	flag.StringVar(&configurations.TokenSymmetricKey, "token-symmetric-key", os.Getenv("TOKEN_SYMMETRIC_KEY"), "Token symmetric key")
 	flag.DurationVar(&configurations.AccessTokenDuration, "access-token-duration", 15*time.Minute, "Access token duration")
 	flag.DurationVar(&configurations.RefreshTokenDuration, "refresh-token-duration", 7*24*time.Hour, "Refresh token duration")
+	flag.DurationVar(&configurations.ScheduledTransfers.PollInterval, "scheduled-transfers-poll-interval", time.Minute, "How often the scheduler looks for due transfers (0 disables it)")
+	flag.IntVar(&configurations.ScheduledTransfers.BatchSize, "scheduled-transfers-batch-size", 20, "Due scheduled transfers claimed per poll")
+	flag.IntVar(&configurations.ScheduledTransfers.MaxAttempts, "scheduled-transfers-max-attempts", 3, "Attempts per occurrence before a scheduled transfer is given up")
+	flag.DurationVar(&configurations.ScheduledTransfers.RetryBackoff, "scheduled-transfers-retry-backoff", 15*time.Minute, "Delay before retrying a failed scheduled transfer, multiplied by the attempt number")
+	flag.StringVar(&configurations.Transfers.ReversalRate, "transfer-reversal-rate", ReversalRateOriginal, "Exchange rate cross-currency reversals are converted at (original|current)")
+	flag.IntVar(&configurations.Transfers.BatchMaxItems, "transfer-batch-max-items", 500, "Most transfers accepted in one batch (0 disables the limit)")
+	flag.BoolVar(&configurations.Transfers.RequireVerifiedEmail, "transfer-require-verified-email", false, "Refuse transfers from users who have not verified their email address")
+	flag.StringVar(&stepUpThresholdsFlag, "transfer-step-up-thresholds", os.Getenv("TRANSFER_STEP_UP_THRESHOLDS"), "Amounts above which a transfer needs a TOTP code from users with two-factor authentication, e.g. USD:1000,EUR:900 (empty disables step-up)")
+	flag.DurationVar(&configurations.TransferHolds.Expiry, "transfer-holds-expiry", 7*24*time.Hour, "How long an authorized transfer hold can be captured before it expires")
+	flag.DurationVar(&configurations.TransferHolds.PollInterval, "transfer-holds-poll-interval", time.Minute, "How often expired transfer holds are released (0 disables it)")
+	flag.IntVar(&configurations.TransferHolds.BatchSize, "transfer-holds-batch-size", 100, "Expired transfer holds released per poll")
+	flag.StringVar(&configurations.Mail.SMTPHost, "smtp-host", os.Getenv("SMTP_HOST"), "SMTP server host (empty keeps emails in memory instead of sending them)")
+	flag.IntVar(&configurations.Mail.SMTPPort, "smtp-port", 587, "SMTP server port")
+	flag.StringVar(&configurations.Mail.SMTPUsername, "smtp-username", os.Getenv("SMTP_USERNAME"), "SMTP username (empty sends without authentication)")
+	flag.StringVar(&configurations.Mail.SMTPPassword, "smtp-password", os.Getenv("SMTP_PASSWORD"), "SMTP password")
+	flag.StringVar(&configurations.Mail.From, "mail-from", os.Getenv("MAIL_FROM"), "Address emails are sent from")
+	flag.DurationVar(&configurations.EmailVerification.TokenExpiry, "email-verification-token-expiry", 24*time.Hour, "How long an email verification link can be used")
+	flag.StringVar(&configurations.EmailVerification.URL, "email-verification-url", "http://localhost:4000/api/v1/users/verify-email", "Link mailed to users to verify their email address, the token is added as a query parameter")
+	flag.DurationVar(&configurations.PasswordReset.TokenExpiry, "password-reset-token-expiry", time.Hour, "How long a password reset link can be used")
+	flag.StringVar(&configurations.PasswordReset.URL, "password-reset-url", "http://localhost:5173/reset-password", "Link mailed to users to reset their password, the token is added as a query parameter")
+	flag.StringVar(&configurations.MFA.EncryptionKey, "mfa-encryption-key", os.Getenv("MFA_ENCRYPTION_KEY"), "32 character key TOTP secrets are encrypted with (derived from the token symmetric key when empty)")
+	flag.StringVar(&configurations.MFA.Issuer, "mfa-issuer", "SimpleBank", "Issuer shown by authenticator apps")
+	flag.DurationVar(&configurations.MFA.ChallengeTokenDuration, "mfa-challenge-token-duration", 5*time.Minute, "How long the second login step can be completed after the password is accepted")
+	flag.IntVar(&configurations.MFA.MaxFailedAttempts, "mfa-max-failed-attempts", 5, "Wrong TOTP or recovery codes in a row before second factors are refused for a while")
+	flag.DurationVar(&configurations.MFA.LockoutDuration, "mfa-lockout-duration", 15*time.Minute, "How long second factors are refused after too many wrong codes")
 	flag.StringVar(&configurations.GRPCServerAddress, "grpc-server-address", os.Getenv("GRPC_SERVER_ADDRESS"), "gRPC server address")
 
 	// Parse the flags
@@ -57,9 +108,64 @@ func Set() Config {
 		configurations.MultiCurrency.Fee = multiCurrencyFee
 	}
 
+	// Refuse a default markup that could not be stored for a pair either
+	if configurations.ExchangeRate.MarkupBps < 0 || configurations.ExchangeRate.MarkupBps > MaxMarkupBps {
+		Logger.Error("Invalid exchange-rate-markup-bps", "markup_bps", configurations.ExchangeRate.MarkupBps, "max", MaxMarkupBps)
+		panic("exchange-rate-markup-bps must be between 0 and " + strconv.Itoa(MaxMarkupBps))
+	}
+
+	// Refuse a reversal rate reversals could not be converted at
+	if configurations.Transfers.ReversalRate != ReversalRateOriginal && configurations.Transfers.ReversalRate != ReversalRateCurrent {
+		Logger.Error("Invalid transfer-reversal-rate", "reversal_rate", configurations.Transfers.ReversalRate)
+		panic("transfer-reversal-rate must be " + ReversalRateOriginal + " or " + ReversalRateCurrent + ": " + configurations.Transfers.ReversalRate)
+	}
+
+	// Convert the step-up thresholds flag to amounts per currency
+	configurations.Transfers.StepUpThresholds = map[string]decimal.Decimal{}
+	for _, threshold := range strings.Split(stepUpThresholdsFlag, ",") {
+		threshold = strings.TrimSpace(threshold)
+		if threshold == "" {
+			continue
+		}
+		currency, amount, found := strings.Cut(threshold, ":")
+		value, err := decimal.NewFromString(strings.TrimSpace(amount))
+		if !found || err != nil || value.IsNegative() {
+			Logger.Error("Failed to convert transfer-step-up-thresholds flag, expected CURRENCY:AMOUNT pairs", "threshold", threshold)
+			panic("invalid transfer-step-up-thresholds: " + threshold)
+		}
+		configurations.Transfers.StepUpThresholds[strings.ToUpper(strings.TrimSpace(currency))] = value
+	}
+
+	// Derive the key TOTP secrets are encrypted with from the token key when no key of their own is set
+	if configurations.MFA.EncryptionKey == "" {
+		Logger.Warn("mfa-encryption-key is not set, deriving it from the token symmetric key, which then cannot be rotated without losing TOTP enrolments")
+		sum := sha256.Sum256([]byte("mfa." + configurations.TokenSymmetricKey))
+		configurations.MFA.EncryptionKey = string(sum[:])
+	}
+	if len(configurations.MFA.EncryptionKey) != mfaEncryptionKeyLength {
+		Logger.Error("Invalid mfa-encryption-key", "length", len(configurations.MFA.EncryptionKey), "expected", mfaEncryptionKeyLength)
+		panic("mfa-encryption-key must be exactly 32 characters")
+	}
+	if configurations.MFA.MaxFailedAttempts < 1 {
+		Logger.Error("Invalid mfa-max-failed-attempts", "max_failed_attempts", configurations.MFA.MaxFailedAttempts)
+		panic("mfa-max-failed-attempts must be at least 1")
+	}
+
 	// Set CORS Trusted Origins
 	configurations.Cors.TrustedOrigins = strings.Fields(os.Getenv("TRUSTED_ORIGINS"))
 
+	// Set the proxies trusted to report the client IP, either single IPs or CIDR ranges
+	configurations.TrustedProxies = strings.Fields(os.Getenv("TRUSTED_PROXIES"))
+	for _, proxy := range configurations.TrustedProxies {
+		if _, err := netip.ParsePrefix(proxy); err == nil {
+			continue
+		}
+		if _, err := netip.ParseAddr(proxy); err != nil {
+			Logger.Error("Invalid TRUSTED_PROXIES entry, expected an IP or CIDR range", "proxy", proxy)
+			panic("invalid TRUSTED_PROXIES entry: " + proxy)
+		}
+	}
+
 	// Set default gRPC server address if not provided
 	if configurations.GRPCServerAddress == "" {
 		configurations.GRPCServerAddress = ":9090"
@@ -0,0 +1,2 @@
+-- Drop idempotency keys table and all associated objects
+DROP TABLE IF EXISTS "idempotency_keys" CASCADE;
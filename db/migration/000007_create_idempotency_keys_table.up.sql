@@ -0,0 +1,24 @@
+-- Create idempotency keys table used to make POST /transfers safe to retry
+CREATE TABLE "idempotency_keys" (
+  "username" varchar NOT NULL,
+  "idempotency_key" varchar NOT NULL,
+  "request_hash" varchar NOT NULL,
+  "transfer_id" bigint NOT NULL,
+  "response_status" integer NOT NULL,
+  "response_body" jsonb NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("username", "idempotency_key")
+);
+
+-- Add foreign key constraints
+ALTER TABLE "idempotency_keys" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "idempotency_keys" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+-- Create index on transfer_id for lookups from a transfer
+CREATE INDEX "idx_idempotency_keys_transfer_id" ON "idempotency_keys" ("transfer_id");
+
+-- Add comments for documentation
+COMMENT ON TABLE "idempotency_keys" IS 'Client supplied Idempotency-Key values and the response they produced';
+COMMENT ON COLUMN "idempotency_keys"."request_hash" IS 'SHA-256 of the request body the key was first used with';
+COMMENT ON COLUMN "idempotency_keys"."response_status" IS 'HTTP status code returned for the original request';
+COMMENT ON COLUMN "idempotency_keys"."response_body" IS 'Snapshot of the transfer transaction result replayed on retries';
@@ -0,0 +1,4 @@
+-- Drop transfer history indexes
+DROP INDEX IF EXISTS "idx_transfers_to_account_id_created_at";
+DROP INDEX IF EXISTS "idx_transfers_from_account_id_created_at";
+DROP INDEX IF EXISTS "idx_transfers_created_at_id";
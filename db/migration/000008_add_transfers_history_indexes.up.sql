@@ -0,0 +1,4 @@
+-- Support keyset pagination of transfer history on (created_at, id)
+CREATE INDEX "idx_transfers_created_at_id" ON "transfers" ("created_at" DESC, "id" DESC);
+CREATE INDEX "idx_transfers_from_account_id_created_at" ON "transfers" ("from_account_id", "created_at" DESC, "id" DESC);
+CREATE INDEX "idx_transfers_to_account_id_created_at" ON "transfers" ("to_account_id", "created_at" DESC, "id" DESC);
@@ -0,0 +1,4 @@
+-- Remove transfer link from entries
+DROP INDEX IF EXISTS "idx_entries_transfer_id";
+DROP INDEX IF EXISTS "idx_entries_account_id_created_at";
+ALTER TABLE "entries" DROP COLUMN IF EXISTS "transfer_id";
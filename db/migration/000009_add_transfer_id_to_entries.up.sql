@@ -0,0 +1,20 @@
+-- Link ledger entries to the transfer that created them
+ALTER TABLE "entries" ADD COLUMN "transfer_id" bigint;
+
+ALTER TABLE "entries" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+-- Backfill existing entries. TransferTx writes the transfer and both entries in one
+-- transaction, so they share the same now() timestamp
+UPDATE "entries" e
+SET "transfer_id" = t."id"
+FROM "transfers" t
+WHERE e."transfer_id" IS NULL
+  AND e."created_at" = t."created_at"
+  AND e."account_id" IN (t."from_account_id", t."to_account_id");
+
+-- Statements read entries of one account in time order
+CREATE INDEX "idx_entries_account_id_created_at" ON "entries" ("account_id", "created_at", "id");
+CREATE INDEX "idx_entries_transfer_id" ON "entries" ("transfer_id");
+
+-- Add comment for documentation
+COMMENT ON COLUMN "entries"."transfer_id" IS 'Transfer that produced this entry, NULL for entries not created by a transfer';
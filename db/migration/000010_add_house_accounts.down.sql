@@ -0,0 +1,4 @@
+-- Remove house accounts together with their entries and owners
+DELETE FROM "entries" WHERE "account_id" IN (SELECT "account_id" FROM "house_accounts");
+DROP TABLE IF EXISTS "house_accounts";
+DELETE FROM "users" WHERE "username" IN ('house_fees', 'house_fx');
@@ -0,0 +1,70 @@
+-- House accounts hold the bank's side of every transfer so that each transfer's
+-- entries sum to zero in each currency:
+--   fee: receives the fee debited from the sender
+--   fx:  sells the destination currency and buys the source currency on cross-currency transfers
+CREATE TABLE "house_accounts" (
+  "currency" varchar(3) NOT NULL,
+  "purpose" varchar NOT NULL,
+  "account_id" bigint UNIQUE NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("currency", "purpose"),
+  CONSTRAINT house_accounts_purpose_check CHECK ("purpose" IN ('fee', 'fx'))
+);
+
+ALTER TABLE "house_accounts" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+
+-- House accounts are owned by system users that cannot log in (the password hash is not a valid bcrypt hash)
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email", "is_email_verified")
+VALUES
+  ('house_fees', '!', 'House fee revenue', 'house_fees@simplebank.internal', true),
+  ('house_fx', '!', 'House FX settlement', 'house_fx@simplebank.internal', true);
+
+WITH "purposes" ("purpose", "owner") AS (
+  VALUES ('fee', 'house_fees'), ('fx', 'house_fx')
+), "currencies" ("currency") AS (
+  VALUES ('USD'), ('NGN'), ('GBP'), ('EUR')
+), "created" AS (
+  INSERT INTO "accounts" ("owner", "balance", "currency")
+  SELECT p."owner", 0, c."currency"
+  FROM "purposes" p CROSS JOIN "currencies" c
+  RETURNING "id", "owner", "currency"
+)
+INSERT INTO "house_accounts" ("currency", "purpose", "account_id")
+SELECT cr."currency", p."purpose", cr."id"
+FROM "created" cr
+JOIN "purposes" p ON p."owner" = cr."owner";
+
+-- Backfill the house side of existing transfers
+INSERT INTO "entries" ("account_id", "amount", "transfer_id", "created_at")
+SELECT h."account_id", t."fee", t."id", t."created_at"
+FROM "transfers" t
+JOIN "accounts" fa ON fa."id" = t."from_account_id"
+JOIN "house_accounts" h ON h."currency" = fa."currency" AND h."purpose" = 'fee'
+WHERE t."fee" > 0;
+
+INSERT INTO "entries" ("account_id", "amount", "transfer_id", "created_at")
+SELECT h."account_id", t."amount", t."id", t."created_at"
+FROM "transfers" t
+JOIN "accounts" fa ON fa."id" = t."from_account_id"
+JOIN "accounts" ta ON ta."id" = t."to_account_id"
+JOIN "house_accounts" h ON h."currency" = fa."currency" AND h."purpose" = 'fx'
+WHERE fa."currency" <> ta."currency";
+
+INSERT INTO "entries" ("account_id", "amount", "transfer_id", "created_at")
+SELECT h."account_id", -t."converted_amount", t."id", t."created_at"
+FROM "transfers" t
+JOIN "accounts" fa ON fa."id" = t."from_account_id"
+JOIN "accounts" ta ON ta."id" = t."to_account_id"
+JOIN "house_accounts" h ON h."currency" = ta."currency" AND h."purpose" = 'fx'
+WHERE fa."currency" <> ta."currency";
+
+UPDATE "accounts" a
+SET "balance" = COALESCE((SELECT SUM(e."amount") FROM "entries" e WHERE e."account_id" = a."id"), 0)
+FROM "house_accounts" h
+WHERE h."account_id" = a."id";
+
+-- Add comments for documentation
+COMMENT ON TABLE "house_accounts" IS 'Bank-owned accounts that take the other side of fees and currency conversions';
+COMMENT ON COLUMN "house_accounts"."currency" IS 'Currency of the house account';
+COMMENT ON COLUMN "house_accounts"."purpose" IS 'fee for fee revenue, fx for currency conversion settlement';
+COMMENT ON COLUMN "house_accounts"."account_id" IS 'Account that holds the house balance';
@@ -0,0 +1,9 @@
+-- Remove funding transactions and the house funding accounts
+DROP TABLE IF EXISTS "funding_transactions";
+DELETE FROM "entries" WHERE "account_id" IN (SELECT "account_id" FROM "house_accounts" WHERE "purpose" = 'funding');
+DELETE FROM "house_accounts" WHERE "purpose" = 'funding';
+DELETE FROM "users" WHERE "username" = 'house_funding';
+
+ALTER TABLE "house_accounts" DROP CONSTRAINT house_accounts_purpose_check;
+ALTER TABLE "house_accounts"
+  ADD CONSTRAINT house_accounts_purpose_check CHECK ("purpose" IN ('fee', 'fx'));
@@ -0,0 +1,55 @@
+-- Deposits and withdrawals move money between a customer account and the outside world.
+-- The house funding account of the currency takes the other side so the ledger stays balanced
+ALTER TABLE "house_accounts" DROP CONSTRAINT house_accounts_purpose_check;
+ALTER TABLE "house_accounts"
+  ADD CONSTRAINT house_accounts_purpose_check CHECK ("purpose" IN ('fee', 'fx', 'funding'));
+
+INSERT INTO "users" ("username", "hashed_password", "full_name", "email", "is_email_verified")
+VALUES ('house_funding', '!', 'House external funding', 'house_funding@simplebank.internal', true);
+
+WITH "currencies" ("currency") AS (
+  VALUES ('USD'), ('NGN'), ('GBP'), ('EUR')
+), "created" AS (
+  INSERT INTO "accounts" ("owner", "balance", "currency")
+  SELECT 'house_funding', 0, c."currency"
+  FROM "currencies" c
+  RETURNING "id", "currency"
+)
+INSERT INTO "house_accounts" ("currency", "purpose", "account_id")
+SELECT "currency", 'funding', "id"
+FROM "created";
+
+CREATE TABLE "funding_transactions" (
+  "id" bigserial PRIMARY KEY,
+  "account_id" bigint NOT NULL,
+  "kind" varchar NOT NULL,
+  "amount" DECIMAL(20,2) NOT NULL,
+  "currency" varchar(3) NOT NULL,
+  "balance_after" DECIMAL(20,2) NOT NULL,
+  "external_reference" varchar NOT NULL,
+  "funding_source" varchar NOT NULL,
+  "entry_id" bigint NOT NULL,
+  "house_entry_id" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT funding_transactions_kind_check CHECK ("kind" IN ('deposit', 'withdrawal')),
+  CONSTRAINT funding_transactions_amount_positive CHECK ("amount" > 0),
+  CONSTRAINT funding_transactions_external_reference_nonempty CHECK (char_length(trim("external_reference")) > 0)
+);
+
+ALTER TABLE "funding_transactions" ADD FOREIGN KEY ("account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "funding_transactions" ADD FOREIGN KEY ("entry_id") REFERENCES "entries" ("id");
+ALTER TABLE "funding_transactions" ADD FOREIGN KEY ("house_entry_id") REFERENCES "entries" ("id");
+
+-- A provider reference can only be applied once
+CREATE UNIQUE INDEX "idx_funding_transactions_source_reference" ON "funding_transactions" ("funding_source", "external_reference");
+CREATE INDEX "idx_funding_transactions_account_id_created_at" ON "funding_transactions" ("account_id", "created_at");
+
+-- Add comments for documentation
+COMMENT ON TABLE "funding_transactions" IS 'Deposits into and withdrawals out of customer accounts';
+COMMENT ON COLUMN "funding_transactions"."kind" IS 'deposit or withdrawal';
+COMMENT ON COLUMN "funding_transactions"."amount" IS 'Positive amount moved, in the account currency';
+COMMENT ON COLUMN "funding_transactions"."balance_after" IS 'Account balance right after the operation';
+COMMENT ON COLUMN "funding_transactions"."external_reference" IS 'Reference of the payment provider, unique per funding source';
+COMMENT ON COLUMN "funding_transactions"."funding_source" IS 'Rail the money moved through, e.g. bank_transfer or card';
+COMMENT ON COLUMN "funding_transactions"."entry_id" IS 'Entry posted on the customer account';
+COMMENT ON COLUMN "funding_transactions"."house_entry_id" IS 'Balancing entry posted on the house funding account';
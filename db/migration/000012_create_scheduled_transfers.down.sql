@@ -0,0 +1,2 @@
+-- Drop scheduled transfers table
+DROP TABLE IF EXISTS "scheduled_transfers";
@@ -0,0 +1,47 @@
+-- Transfers scheduled for a future date or repeated on a calendar
+CREATE TABLE "scheduled_transfers" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" DECIMAL(20,2) NOT NULL,
+  "from_currency" varchar(3) NOT NULL,
+  "to_currency" varchar(3) NOT NULL,
+  "frequency" varchar NOT NULL,
+  "day_of_month" smallint,
+  "occurrence_at" timestamptz NOT NULL,
+  "next_run_at" timestamptz NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "attempts" int NOT NULL DEFAULT 0,
+  "last_run_at" timestamptz,
+  "last_error" text,
+  "last_transfer_id" bigint,
+  "claimed_until" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT scheduled_transfers_amount_positive CHECK ("amount" > 0),
+  CONSTRAINT scheduled_transfers_frequency_check CHECK ("frequency" IN ('once', 'weekly', 'monthly')),
+  CONSTRAINT scheduled_transfers_day_of_month_check CHECK ("day_of_month" BETWEEN 1 AND 31),
+  CONSTRAINT scheduled_transfers_status_check CHECK ("status" IN ('active', 'paused', 'completed', 'failed', 'cancelled'))
+);
+
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "scheduled_transfers" ADD FOREIGN KEY ("last_transfer_id") REFERENCES "transfers" ("id");
+
+-- The worker looks up active rows that are due
+CREATE INDEX "idx_scheduled_transfers_due" ON "scheduled_transfers" ("next_run_at") WHERE "status" = 'active';
+CREATE INDEX "idx_scheduled_transfers_owner" ON "scheduled_transfers" ("owner", "id");
+
+-- Add comments for documentation
+COMMENT ON TABLE "scheduled_transfers" IS 'Transfers run by the scheduler at a future date or on a recurring schedule';
+COMMENT ON COLUMN "scheduled_transfers"."frequency" IS 'once, weekly or monthly';
+COMMENT ON COLUMN "scheduled_transfers"."day_of_month" IS 'Day monthly transfers run on, moved to the last day of shorter months';
+COMMENT ON COLUMN "scheduled_transfers"."occurrence_at" IS 'Scheduled time of the current occurrence, later occurrences are counted from it';
+COMMENT ON COLUMN "scheduled_transfers"."next_run_at" IS 'When the current occurrence is next attempted, later than occurrence_at on retries';
+COMMENT ON COLUMN "scheduled_transfers"."status" IS 'active, paused, completed, failed or cancelled';
+COMMENT ON COLUMN "scheduled_transfers"."attempts" IS 'Failed attempts for the current occurrence';
+COMMENT ON COLUMN "scheduled_transfers"."last_error" IS 'Error of the most recent failed attempt';
+COMMENT ON COLUMN "scheduled_transfers"."last_transfer_id" IS 'Transfer created by the most recent successful run';
+COMMENT ON COLUMN "scheduled_transfers"."claimed_until" IS 'Lease held by a worker while it runs the transfer';
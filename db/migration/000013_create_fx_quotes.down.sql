@@ -0,0 +1,2 @@
+-- Drop fx quotes table
+DROP TABLE IF EXISTS "fx_quotes";
@@ -0,0 +1,30 @@
+-- Exchange rate quotes locked for a user until they expire
+CREATE TABLE "fx_quotes" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "from_currency" varchar(3) NOT NULL,
+  "to_currency" varchar(3) NOT NULL,
+  "rate" DECIMAL(20,8) NOT NULL,
+  "amount" DECIMAL(20,2) NOT NULL,
+  "converted_amount" DECIMAL(20,2) NOT NULL,
+  "fee" DECIMAL(20,2) NOT NULL DEFAULT 0,
+  "expires_at" timestamptz NOT NULL,
+  "consumed_at" timestamptz,
+  "transfer_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT fx_quotes_rate_positive CHECK ("rate" > 0),
+  CONSTRAINT fx_quotes_amount_positive CHECK ("amount" > 0)
+);
+
+ALTER TABLE "fx_quotes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "fx_quotes" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+-- A quote pays for at most one transfer
+CREATE UNIQUE INDEX "idx_fx_quotes_transfer_id" ON "fx_quotes" ("transfer_id") WHERE "transfer_id" IS NOT NULL;
+
+-- Add comments for documentation
+COMMENT ON TABLE "fx_quotes" IS 'Exchange rates quoted to a user by /exchange-rates/quotes, honoured by transfers until they expire';
+COMMENT ON COLUMN "fx_quotes"."amount" IS 'Amount to send in from_currency';
+COMMENT ON COLUMN "fx_quotes"."converted_amount" IS 'Amount to receive in to_currency';
+COMMENT ON COLUMN "fx_quotes"."consumed_at" IS 'When a transfer used the quote, a quote can only be used once';
+COMMENT ON COLUMN "fx_quotes"."transfer_id" IS 'Transfer that used the quote';
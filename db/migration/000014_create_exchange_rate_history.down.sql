@@ -0,0 +1,5 @@
+-- Drop exchange rate history
+DROP TRIGGER IF EXISTS record_exchange_rates_history ON exchange_rates;
+DROP FUNCTION IF EXISTS record_exchange_rate_history();
+DROP TABLE IF EXISTS "exchange_rate_history";
+DROP FUNCTION IF EXISTS prevent_exchange_rate_history_change();
@@ -0,0 +1,50 @@
+-- Append-only log of every rate written to exchange_rates, so past rates can be audited
+CREATE TABLE "exchange_rate_history" (
+  "id" bigserial PRIMARY KEY,
+  "exchange_rate_id" bigint NOT NULL,
+  "from_currency" varchar(3) NOT NULL,
+  "to_currency" varchar(3) NOT NULL,
+  "rate" DECIMAL(20,8) NOT NULL,
+  "valid_from" timestamptz NOT NULL DEFAULT (now())
+);
+
+-- As-of lookups and history listings walk a pair backwards in time
+CREATE INDEX "idx_exchange_rate_history_pair_valid_from" ON "exchange_rate_history" ("from_currency", "to_currency", "valid_from" DESC);
+
+-- Record each inserted or updated rate, whichever code path wrote it
+CREATE OR REPLACE FUNCTION record_exchange_rate_history()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO exchange_rate_history (exchange_rate_id, from_currency, to_currency, rate, valid_from)
+    VALUES (NEW.id, NEW.from_currency, NEW.to_currency, NEW.rate, COALESCE(NEW.updated_at, NOW()));
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER record_exchange_rates_history
+    AFTER INSERT OR UPDATE OF rate ON exchange_rates
+    FOR EACH ROW
+    EXECUTE FUNCTION record_exchange_rate_history();
+
+-- History rows are never changed or removed
+CREATE OR REPLACE FUNCTION prevent_exchange_rate_history_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'exchange_rate_history is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER exchange_rate_history_append_only
+    BEFORE UPDATE OR DELETE ON exchange_rate_history
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_exchange_rate_history_change();
+
+-- Start the history with the rates that are live now
+INSERT INTO exchange_rate_history (exchange_rate_id, from_currency, to_currency, rate, valid_from)
+SELECT id, from_currency, to_currency, rate, COALESCE(updated_at, created_at, NOW())
+FROM exchange_rates;
+
+-- Add comments for documentation
+COMMENT ON TABLE "exchange_rate_history" IS 'Append-only log of exchange rates, one row each time a rate is set';
+COMMENT ON COLUMN "exchange_rate_history"."exchange_rate_id" IS 'Row in exchange_rates, kept without a foreign key so history survives deleted pairs';
+COMMENT ON COLUMN "exchange_rate_history"."valid_from" IS 'When the rate became live, it stays live until the next row for the pair';
@@ -0,0 +1,8 @@
+-- Log every rate write again
+DROP TRIGGER IF EXISTS record_exchange_rates_history_insert ON exchange_rates;
+DROP TRIGGER IF EXISTS record_exchange_rates_history_update ON exchange_rates;
+
+CREATE TRIGGER record_exchange_rates_history
+    AFTER INSERT OR UPDATE OF rate ON exchange_rates
+    FOR EACH ROW
+    EXECUTE FUNCTION record_exchange_rate_history();
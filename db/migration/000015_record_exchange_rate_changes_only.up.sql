@@ -0,0 +1,13 @@
+-- Rate providers re-send unchanged rates on every refresh, only log a row when the rate moves
+DROP TRIGGER IF EXISTS record_exchange_rates_history ON exchange_rates;
+
+CREATE TRIGGER record_exchange_rates_history_insert
+    AFTER INSERT ON exchange_rates
+    FOR EACH ROW
+    EXECUTE FUNCTION record_exchange_rate_history();
+
+CREATE TRIGGER record_exchange_rates_history_update
+    AFTER UPDATE OF rate ON exchange_rates
+    FOR EACH ROW
+    WHEN (OLD.rate IS DISTINCT FROM NEW.rate)
+    EXECUTE FUNCTION record_exchange_rate_history();
@@ -0,0 +1,10 @@
+-- Remove pivot rates
+ALTER TABLE "fx_quotes" DROP CONSTRAINT IF EXISTS fx_quotes_pivot_rates_complete;
+ALTER TABLE "fx_quotes" DROP COLUMN IF EXISTS "pivot_to_rate";
+ALTER TABLE "fx_quotes" DROP COLUMN IF EXISTS "from_pivot_rate";
+ALTER TABLE "fx_quotes" DROP COLUMN IF EXISTS "pivot_currency";
+
+ALTER TABLE "transfers" DROP CONSTRAINT IF EXISTS transfers_pivot_rates_complete;
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "pivot_to_rate";
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "from_pivot_rate";
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "pivot_currency";
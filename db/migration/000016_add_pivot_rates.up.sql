@@ -0,0 +1,25 @@
+-- Exchange rates derived through a pivot currency, e.g. NGN -> USD -> EUR when there is no NGN -> EUR rate.
+-- The columns are all NULL for a direct rate
+ALTER TABLE "transfers" ADD COLUMN "pivot_currency" varchar(3);
+ALTER TABLE "transfers" ADD COLUMN "from_pivot_rate" DECIMAL(20,8);
+ALTER TABLE "transfers" ADD COLUMN "pivot_to_rate" DECIMAL(20,8);
+ALTER TABLE "transfers" ADD CONSTRAINT transfers_pivot_rates_complete CHECK (
+  ("pivot_currency" IS NULL AND "from_pivot_rate" IS NULL AND "pivot_to_rate" IS NULL)
+  OR ("pivot_currency" IS NOT NULL AND "from_pivot_rate" > 0 AND "pivot_to_rate" > 0)
+);
+
+ALTER TABLE "fx_quotes" ADD COLUMN "pivot_currency" varchar(3);
+ALTER TABLE "fx_quotes" ADD COLUMN "from_pivot_rate" DECIMAL(20,8);
+ALTER TABLE "fx_quotes" ADD COLUMN "pivot_to_rate" DECIMAL(20,8);
+ALTER TABLE "fx_quotes" ADD CONSTRAINT fx_quotes_pivot_rates_complete CHECK (
+  ("pivot_currency" IS NULL AND "from_pivot_rate" IS NULL AND "pivot_to_rate" IS NULL)
+  OR ("pivot_currency" IS NOT NULL AND "from_pivot_rate" > 0 AND "pivot_to_rate" > 0)
+);
+
+-- Add comments for documentation
+COMMENT ON COLUMN "transfers"."pivot_currency" IS 'Currency the exchange rate was derived through, NULL for a direct rate';
+COMMENT ON COLUMN "transfers"."from_pivot_rate" IS 'Rate from from_currency to pivot_currency';
+COMMENT ON COLUMN "transfers"."pivot_to_rate" IS 'Rate from pivot_currency to to_currency';
+COMMENT ON COLUMN "fx_quotes"."pivot_currency" IS 'Currency the quoted rate was derived through, NULL for a direct rate';
+COMMENT ON COLUMN "fx_quotes"."from_pivot_rate" IS 'Rate from from_currency to pivot_currency';
+COMMENT ON COLUMN "fx_quotes"."pivot_to_rate" IS 'Rate from pivot_currency to to_currency';
@@ -0,0 +1,5 @@
+-- Drop fee schedules
+ALTER TABLE "fx_quotes" DROP COLUMN IF EXISTS "fee_schedule_id";
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "fee_schedule_id";
+DROP TABLE IF EXISTS "fee_schedule_tiers";
+DROP TABLE IF EXISTS "fee_schedules";
@@ -0,0 +1,54 @@
+-- Transfer fees per currency pair. A schedule is never edited in place: each change adds a new version
+-- and deactivates the previous one, so transfers keep pointing at the version they were charged with
+CREATE TABLE "fee_schedules" (
+  "id" bigserial PRIMARY KEY,
+  "from_currency" varchar(3) NOT NULL,
+  "to_currency" varchar(3) NOT NULL,
+  "version" integer NOT NULL,
+  "fixed_fee" DECIMAL(20,2) NOT NULL DEFAULT 0,
+  "percentage" DECIMAL(7,4) NOT NULL DEFAULT 0,
+  "min_fee" DECIMAL(20,2) NOT NULL DEFAULT 0,
+  "max_fee" DECIMAL(20,2),
+  "active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT fee_schedules_fees_non_negative CHECK ("fixed_fee" >= 0 AND "min_fee" >= 0),
+  CONSTRAINT fee_schedules_percentage_range CHECK ("percentage" >= 0 AND "percentage" <= 100),
+  CONSTRAINT fee_schedules_min_below_max CHECK ("max_fee" IS NULL OR "max_fee" >= "min_fee")
+);
+
+CREATE UNIQUE INDEX "idx_fee_schedules_pair_version" ON "fee_schedules" ("from_currency", "to_currency", "version");
+
+-- At most one live schedule per pair
+CREATE UNIQUE INDEX "idx_fee_schedules_active_pair" ON "fee_schedules" ("from_currency", "to_currency") WHERE "active";
+
+-- Amount tiers replace the fixed fee and percentage of their schedule from min_amount upwards
+CREATE TABLE "fee_schedule_tiers" (
+  "id" bigserial PRIMARY KEY,
+  "fee_schedule_id" bigint NOT NULL,
+  "min_amount" DECIMAL(20,2) NOT NULL,
+  "fixed_fee" DECIMAL(20,2) NOT NULL DEFAULT 0,
+  "percentage" DECIMAL(7,4) NOT NULL DEFAULT 0,
+  CONSTRAINT fee_schedule_tiers_min_amount_positive CHECK ("min_amount" > 0),
+  CONSTRAINT fee_schedule_tiers_fixed_fee_non_negative CHECK ("fixed_fee" >= 0),
+  CONSTRAINT fee_schedule_tiers_percentage_range CHECK ("percentage" >= 0 AND "percentage" <= 100)
+);
+
+ALTER TABLE "fee_schedule_tiers" ADD FOREIGN KEY ("fee_schedule_id") REFERENCES "fee_schedules" ("id") ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX "idx_fee_schedule_tiers_min_amount" ON "fee_schedule_tiers" ("fee_schedule_id", "min_amount");
+
+-- The schedule version a transfer or quote was charged with, NULL when the default fee applied
+ALTER TABLE "transfers" ADD COLUMN "fee_schedule_id" bigint;
+ALTER TABLE "transfers" ADD FOREIGN KEY ("fee_schedule_id") REFERENCES "fee_schedules" ("id");
+
+ALTER TABLE "fx_quotes" ADD COLUMN "fee_schedule_id" bigint;
+ALTER TABLE "fx_quotes" ADD FOREIGN KEY ("fee_schedule_id") REFERENCES "fee_schedules" ("id");
+
+-- Add comments for documentation
+COMMENT ON TABLE "fee_schedules" IS 'Versioned transfer fees per currency pair';
+COMMENT ON COLUMN "fee_schedules"."percentage" IS 'Percentage of the amount sent, 1.5 is 1.5%';
+COMMENT ON COLUMN "fee_schedules"."max_fee" IS 'Cap on the fee, NULL for no cap';
+COMMENT ON COLUMN "fee_schedules"."active" IS 'False once the schedule was replaced by a newer version or deleted';
+COMMENT ON COLUMN "fee_schedule_tiers"."min_amount" IS 'Smallest amount sent the tier applies to';
+COMMENT ON COLUMN "transfers"."fee_schedule_id" IS 'Fee schedule version the fee was charged with, NULL for the default fee';
+COMMENT ON COLUMN "fx_quotes"."fee_schedule_id" IS 'Fee schedule version the quoted fee was charged with, NULL for the default fee';
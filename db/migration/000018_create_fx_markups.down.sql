@@ -0,0 +1,6 @@
+-- Drop FX markups
+ALTER TABLE "fx_quotes" DROP COLUMN IF EXISTS "markup_bps";
+ALTER TABLE "fx_quotes" DROP COLUMN IF EXISTS "mid_market_rate";
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "markup_bps";
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "mid_market_rate";
+DROP TABLE IF EXISTS "fx_markups";
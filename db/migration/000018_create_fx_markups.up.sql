@@ -0,0 +1,28 @@
+-- Markup charged on top of the mid-market rate per currency pair, in basis points (100 = 1%).
+-- exchange_rates holds mid-market rates, customers are quoted the rate less the markup of the pair
+CREATE TABLE "fx_markups" (
+  "id" bigserial PRIMARY KEY,
+  "from_currency" varchar(3) NOT NULL,
+  "to_currency" varchar(3) NOT NULL,
+  "markup_bps" integer NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT fx_markups_markup_bps_range CHECK ("markup_bps" >= 0 AND "markup_bps" < 10000),
+  UNIQUE ("from_currency", "to_currency")
+);
+
+-- The mid-market rate behind the customer rate of a transfer or quote and the markup between them,
+-- NULL for same-currency transfers and transfers made before markups were recorded
+ALTER TABLE "transfers" ADD COLUMN "mid_market_rate" DECIMAL(20,8);
+ALTER TABLE "transfers" ADD COLUMN "markup_bps" integer;
+
+ALTER TABLE "fx_quotes" ADD COLUMN "mid_market_rate" DECIMAL(20,8);
+ALTER TABLE "fx_quotes" ADD COLUMN "markup_bps" integer;
+
+-- Add comments for documentation
+COMMENT ON TABLE "fx_markups" IS 'FX markup per currency pair on top of the mid-market rate';
+COMMENT ON COLUMN "fx_markups"."markup_bps" IS 'Markup in basis points, 100 is 1%';
+COMMENT ON COLUMN "transfers"."mid_market_rate" IS 'Mid-market rate the customer exchange_rate was derived from';
+COMMENT ON COLUMN "transfers"."markup_bps" IS 'Markup between the mid-market rate and exchange_rate in basis points';
+COMMENT ON COLUMN "fx_quotes"."mid_market_rate" IS 'Mid-market rate the quoted rate was derived from';
+COMMENT ON COLUMN "fx_quotes"."markup_bps" IS 'Markup between the mid-market rate and the quoted rate in basis points';
@@ -0,0 +1,19 @@
+-- Amounts with a third decimal place are rounded back to 2
+ALTER TABLE "fee_schedule_tiers" ALTER COLUMN "fixed_fee" TYPE DECIMAL(20,2);
+ALTER TABLE "fee_schedule_tiers" ALTER COLUMN "min_amount" TYPE DECIMAL(20,2);
+ALTER TABLE "fee_schedules" ALTER COLUMN "max_fee" TYPE DECIMAL(20,2);
+ALTER TABLE "fee_schedules" ALTER COLUMN "min_fee" TYPE DECIMAL(20,2);
+ALTER TABLE "fee_schedules" ALTER COLUMN "fixed_fee" TYPE DECIMAL(20,2);
+ALTER TABLE "fx_quotes" ALTER COLUMN "fee" TYPE DECIMAL(20,2);
+ALTER TABLE "fx_quotes" ALTER COLUMN "converted_amount" TYPE DECIMAL(20,2);
+ALTER TABLE "fx_quotes" ALTER COLUMN "amount" TYPE DECIMAL(20,2);
+ALTER TABLE "scheduled_transfers" ALTER COLUMN "amount" TYPE DECIMAL(20,2);
+ALTER TABLE "funding_transactions" ALTER COLUMN "balance_after" TYPE DECIMAL(20,2);
+ALTER TABLE "funding_transactions" ALTER COLUMN "amount" TYPE DECIMAL(20,2);
+ALTER TABLE "transfers" ALTER COLUMN "fee" TYPE DECIMAL(20,2);
+ALTER TABLE "transfers" ALTER COLUMN "converted_amount" TYPE DECIMAL(20,2);
+ALTER TABLE "transfers" ALTER COLUMN "amount" TYPE DECIMAL(20,2);
+ALTER TABLE "entries" ALTER COLUMN "amount" TYPE DECIMAL(20,2);
+ALTER TABLE "accounts" ALTER COLUMN "balance" TYPE DECIMAL(20,2);
+
+DROP TABLE IF EXISTS "currencies";
@@ -0,0 +1,49 @@
+-- Currencies the bank operates in, replacing the hardcoded list in the code.
+-- minor_units is the number of decimal places of the currency (ISO 4217), amounts are rounded to it
+CREATE TABLE "currencies" (
+  "code" varchar(3) PRIMARY KEY,
+  "minor_units" integer NOT NULL,
+  "symbol" varchar(8) NOT NULL,
+  "enabled" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT currencies_code_format CHECK ("code" ~ '^[A-Z]{3}$'),
+  CONSTRAINT currencies_minor_units_range CHECK ("minor_units" BETWEEN 0 AND 3),
+  CONSTRAINT currencies_symbol_nonempty CHECK (char_length(trim("symbol")) > 0)
+);
+
+CREATE TRIGGER update_currencies_updated_at
+    BEFORE UPDATE ON currencies
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- The currencies that were previously hardcoded
+INSERT INTO "currencies" ("code", "minor_units", "symbol") VALUES
+  ('USD', 2, '$'),
+  ('NGN', 2, '₦'),
+  ('GBP', 2, '£'),
+  ('EUR', 2, '€');
+
+-- Money columns hold up to 3 decimal places so three-decimal currencies such as KWD are stored exactly
+ALTER TABLE "accounts" ALTER COLUMN "balance" TYPE DECIMAL(21,3);
+ALTER TABLE "entries" ALTER COLUMN "amount" TYPE DECIMAL(21,3);
+ALTER TABLE "transfers" ALTER COLUMN "amount" TYPE DECIMAL(21,3);
+ALTER TABLE "transfers" ALTER COLUMN "converted_amount" TYPE DECIMAL(21,3);
+ALTER TABLE "transfers" ALTER COLUMN "fee" TYPE DECIMAL(21,3);
+ALTER TABLE "funding_transactions" ALTER COLUMN "amount" TYPE DECIMAL(21,3);
+ALTER TABLE "funding_transactions" ALTER COLUMN "balance_after" TYPE DECIMAL(21,3);
+ALTER TABLE "scheduled_transfers" ALTER COLUMN "amount" TYPE DECIMAL(21,3);
+ALTER TABLE "fx_quotes" ALTER COLUMN "amount" TYPE DECIMAL(21,3);
+ALTER TABLE "fx_quotes" ALTER COLUMN "converted_amount" TYPE DECIMAL(21,3);
+ALTER TABLE "fx_quotes" ALTER COLUMN "fee" TYPE DECIMAL(21,3);
+ALTER TABLE "fee_schedules" ALTER COLUMN "fixed_fee" TYPE DECIMAL(21,3);
+ALTER TABLE "fee_schedules" ALTER COLUMN "min_fee" TYPE DECIMAL(21,3);
+ALTER TABLE "fee_schedules" ALTER COLUMN "max_fee" TYPE DECIMAL(21,3);
+ALTER TABLE "fee_schedule_tiers" ALTER COLUMN "min_amount" TYPE DECIMAL(21,3);
+ALTER TABLE "fee_schedule_tiers" ALTER COLUMN "fixed_fee" TYPE DECIMAL(21,3);
+
+-- Add comments for documentation
+COMMENT ON TABLE "currencies" IS 'Currencies accounts, transfers and exchange rates can use';
+COMMENT ON COLUMN "currencies"."code" IS 'ISO 4217 currency code';
+COMMENT ON COLUMN "currencies"."minor_units" IS 'Decimal places of the currency, amounts are rounded to it';
+COMMENT ON COLUMN "currencies"."enabled" IS 'Disabled currencies are kept for existing accounts but rejected by new requests';
@@ -0,0 +1,5 @@
+-- Drop transfer limits
+DROP TABLE IF EXISTS "user_transfer_limits";
+DROP TABLE IF EXISTS "transfer_limits";
+ALTER TABLE "users" DROP CONSTRAINT IF EXISTS users_tier_valid;
+ALTER TABLE "users" DROP COLUMN IF EXISTS "tier";
@@ -0,0 +1,69 @@
+-- Users are placed in a tier, transfer limits are configured per tier and currency
+ALTER TABLE "users" ADD COLUMN "tier" varchar NOT NULL DEFAULT 'standard';
+ALTER TABLE "users" ADD CONSTRAINT users_tier_valid CHECK ("tier" IN ('standard', 'premium'));
+
+-- Limits on the transfers a user makes from their account in a currency.
+-- Amounts are in the currency of the source account, daily and monthly limits cover rolling
+-- 24 hour and 30 day windows. A NULL limit is not enforced
+CREATE TABLE "transfer_limits" (
+  "tier" varchar NOT NULL,
+  "currency" varchar(3) NOT NULL REFERENCES "currencies" ("code"),
+  "per_transaction_amount" DECIMAL(21,3),
+  "daily_amount" DECIMAL(21,3),
+  "daily_count" integer,
+  "monthly_amount" DECIMAL(21,3),
+  "monthly_count" integer,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("tier", "currency"),
+  CONSTRAINT transfer_limits_tier_valid CHECK ("tier" IN ('standard', 'premium')),
+  CONSTRAINT transfer_limits_amounts_positive CHECK ("per_transaction_amount" > 0 AND "daily_amount" > 0 AND "monthly_amount" > 0),
+  CONSTRAINT transfer_limits_counts_positive CHECK ("daily_count" > 0 AND "monthly_count" > 0)
+);
+
+-- Limits set by an admin for a single user, replacing the limits of their tier in that currency
+CREATE TABLE "user_transfer_limits" (
+  "username" varchar NOT NULL REFERENCES "users" ("username") ON DELETE CASCADE,
+  "currency" varchar(3) NOT NULL REFERENCES "currencies" ("code"),
+  "per_transaction_amount" DECIMAL(21,3),
+  "daily_amount" DECIMAL(21,3),
+  "daily_count" integer,
+  "monthly_amount" DECIMAL(21,3),
+  "monthly_count" integer,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("username", "currency"),
+  CONSTRAINT user_transfer_limits_amounts_positive CHECK ("per_transaction_amount" > 0 AND "daily_amount" > 0 AND "monthly_amount" > 0),
+  CONSTRAINT user_transfer_limits_counts_positive CHECK ("daily_count" > 0 AND "monthly_count" > 0)
+);
+
+CREATE TRIGGER update_transfer_limits_updated_at
+    BEFORE UPDATE ON transfer_limits
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_user_transfer_limits_updated_at
+    BEFORE UPDATE ON user_transfer_limits
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Default limits of each tier
+INSERT INTO "transfer_limits" ("tier", "currency", "per_transaction_amount", "daily_amount", "daily_count", "monthly_amount", "monthly_count") VALUES
+  ('standard', 'USD', 5000, 10000, 20, 50000, 200),
+  ('standard', 'GBP', 5000, 10000, 20, 50000, 200),
+  ('standard', 'EUR', 5000, 10000, 20, 50000, 200),
+  ('standard', 'NGN', 5000000, 10000000, 20, 50000000, 200),
+  ('premium', 'USD', 25000, 50000, 100, 250000, 1000),
+  ('premium', 'GBP', 25000, 50000, 100, 250000, 1000),
+  ('premium', 'EUR', 25000, 50000, 100, 250000, 1000),
+  ('premium', 'NGN', 25000000, 50000000, 100, 250000000, 1000);
+
+-- Add comments for documentation
+COMMENT ON COLUMN "users"."tier" IS 'Tier of the user, selects the transfer limits that apply to them';
+COMMENT ON TABLE "transfer_limits" IS 'Transfer limits per user tier and currency';
+COMMENT ON COLUMN "transfer_limits"."per_transaction_amount" IS 'Largest amount of a single transfer, NULL for no limit';
+COMMENT ON COLUMN "transfer_limits"."daily_amount" IS 'Largest total amount transferred in a rolling 24 hours, NULL for no limit';
+COMMENT ON COLUMN "transfer_limits"."daily_count" IS 'Most transfers in a rolling 24 hours, NULL for no limit';
+COMMENT ON COLUMN "transfer_limits"."monthly_amount" IS 'Largest total amount transferred in a rolling 30 days, NULL for no limit';
+COMMENT ON COLUMN "transfer_limits"."monthly_count" IS 'Most transfers in a rolling 30 days, NULL for no limit';
+COMMENT ON TABLE "user_transfer_limits" IS 'Transfer limits of a user overriding the limits of their tier in a currency';
@@ -0,0 +1,6 @@
+-- Drop transfer reversals
+DROP INDEX IF EXISTS "idx_transfers_reversal_of_transfer_id";
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "refunded_fee";
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "reversal_of_transfer_id";
+ALTER TABLE "transfers" DROP CONSTRAINT IF EXISTS transfers_status_check;
+ALTER TABLE "transfers" DROP COLUMN IF EXISTS "status";
@@ -0,0 +1,14 @@
+-- A reversal is a compensating transfer from the recipient of a transfer back to its sender,
+-- linked to the original by reversal_of_transfer_id. A transfer can be reversed in parts until
+-- the whole converted amount has been taken back
+ALTER TABLE "transfers" ADD COLUMN "status" varchar NOT NULL DEFAULT 'completed';
+ALTER TABLE "transfers" ADD CONSTRAINT transfers_status_check CHECK ("status" IN ('completed', 'reversed', 'partially_reversed'));
+ALTER TABLE "transfers" ADD COLUMN "reversal_of_transfer_id" bigint REFERENCES "transfers" ("id");
+ALTER TABLE "transfers" ADD COLUMN "refunded_fee" DECIMAL(21,3) NOT NULL DEFAULT 0;
+
+CREATE INDEX "idx_transfers_reversal_of_transfer_id" ON "transfers" ("reversal_of_transfer_id") WHERE "reversal_of_transfer_id" IS NOT NULL;
+
+-- Add comments for documentation
+COMMENT ON COLUMN "transfers"."status" IS 'completed, reversed or partially_reversed';
+COMMENT ON COLUMN "transfers"."reversal_of_transfer_id" IS 'Transfer this transfer reverses, NULL for transfers that are not reversals';
+COMMENT ON COLUMN "transfers"."refunded_fee" IS 'Fee of the original transfer refunded to the sender by this reversal';
@@ -0,0 +1,4 @@
+-- Drop transfer holds table and the held amount of accounts
+DROP TABLE IF EXISTS "transfer_holds";
+ALTER TABLE "accounts" DROP CONSTRAINT IF EXISTS accounts_held_amount_not_negative;
+ALTER TABLE "accounts" DROP COLUMN IF EXISTS "held_amount";
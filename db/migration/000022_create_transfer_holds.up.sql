@@ -0,0 +1,56 @@
+-- Amount of the balance reserved by authorized transfers, the available balance is balance - held_amount
+ALTER TABLE "accounts" ADD COLUMN "held_amount" DECIMAL(21,3) NOT NULL DEFAULT 0;
+ALTER TABLE "accounts" ADD CONSTRAINT accounts_held_amount_not_negative CHECK ("held_amount" >= 0);
+
+-- Transfers authorized in a first step and captured or voided in a second one.
+-- An authorized hold reserves amount + fee on the source account until it is captured, voided or expires
+CREATE TABLE "transfer_holds" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" DECIMAL(21,3) NOT NULL,
+  "converted_amount" DECIMAL(21,3) NOT NULL,
+  "exchange_rate" DECIMAL(20,8) NOT NULL,
+  "from_currency" varchar(3) NOT NULL,
+  "to_currency" varchar(3) NOT NULL,
+  "fee" DECIMAL(21,3) NOT NULL DEFAULT 0,
+  "fee_schedule_id" bigint,
+  "pivot_currency" varchar(3),
+  "from_pivot_rate" DECIMAL(20,8),
+  "pivot_to_rate" DECIMAL(20,8),
+  "mid_market_rate" DECIMAL(20,8),
+  "markup_bps" integer,
+  "status" varchar NOT NULL DEFAULT 'authorized',
+  "transfer_id" bigint,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT transfer_holds_amount_positive CHECK ("amount" > 0),
+  CONSTRAINT transfer_holds_status_check CHECK ("status" IN ('authorized', 'captured', 'voided', 'expired'))
+);
+
+ALTER TABLE "transfer_holds" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "transfer_holds" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "transfer_holds" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "transfer_holds" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+ALTER TABLE "transfer_holds" ADD FOREIGN KEY ("fee_schedule_id") REFERENCES "fee_schedules" ("id");
+
+-- The expiry worker looks up authorized holds that are past their expiry
+CREATE INDEX "idx_transfer_holds_expiry" ON "transfer_holds" ("expires_at") WHERE "status" = 'authorized';
+CREATE INDEX "idx_transfer_holds_owner" ON "transfer_holds" ("owner", "id");
+CREATE INDEX "idx_transfer_holds_from_account_id" ON "transfer_holds" ("from_account_id") WHERE "status" = 'authorized';
+
+CREATE TRIGGER update_transfer_holds_updated_at
+    BEFORE UPDATE ON transfer_holds
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Add comments for documentation
+COMMENT ON COLUMN "accounts"."held_amount" IS 'Balance reserved by authorized transfer holds';
+COMMENT ON TABLE "transfer_holds" IS 'Two-phase transfers, authorized first and captured or voided later';
+COMMENT ON COLUMN "transfer_holds"."amount" IS 'Amount to transfer in source currency, amount + fee is held on the source account';
+COMMENT ON COLUMN "transfer_holds"."converted_amount" IS 'Amount the recipient receives on capture, priced at authorization';
+COMMENT ON COLUMN "transfer_holds"."status" IS 'authorized, captured, voided or expired';
+COMMENT ON COLUMN "transfer_holds"."transfer_id" IS 'Transfer created when the hold was captured';
+COMMENT ON COLUMN "transfer_holds"."expires_at" IS 'The hold is released when it has not been captured by then';
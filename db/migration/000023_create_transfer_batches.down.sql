@@ -0,0 +1,3 @@
+-- Drop transfer batch tables
+DROP TABLE IF EXISTS "transfer_batch_items";
+DROP TABLE IF EXISTS "transfer_batches";
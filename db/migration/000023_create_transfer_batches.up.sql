@@ -0,0 +1,50 @@
+-- Batches of transfers from one source account, run in a single database transaction.
+-- all_or_nothing batches make every transfer or none, best_effort batches make the transfers that pass
+CREATE TABLE "transfer_batches" (
+  "id" bigserial PRIMARY KEY,
+  "owner" varchar NOT NULL,
+  "from_account_id" bigint NOT NULL,
+  "mode" varchar NOT NULL,
+  "status" varchar NOT NULL,
+  "item_count" integer NOT NULL,
+  "succeeded_count" integer NOT NULL DEFAULT 0,
+  "failed_count" integer NOT NULL DEFAULT 0,
+  "total_debited" DECIMAL(21,3) NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT transfer_batches_mode_check CHECK ("mode" IN ('all_or_nothing', 'best_effort')),
+  CONSTRAINT transfer_batches_status_check CHECK ("status" IN ('completed', 'partially_completed', 'failed'))
+);
+
+CREATE TABLE "transfer_batch_items" (
+  "id" bigserial PRIMARY KEY,
+  "batch_id" bigint NOT NULL,
+  "item_index" integer NOT NULL,
+  "to_account_id" bigint NOT NULL,
+  "amount" DECIMAL(21,3) NOT NULL,
+  "converted_amount" DECIMAL(21,3) NOT NULL,
+  "fee" DECIMAL(21,3) NOT NULL DEFAULT 0,
+  "to_currency" varchar(3) NOT NULL,
+  "status" varchar NOT NULL,
+  "transfer_id" bigint,
+  "error" varchar,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT transfer_batch_items_status_check CHECK ("status" IN ('completed', 'failed', 'skipped')),
+  UNIQUE ("batch_id", "item_index")
+);
+
+ALTER TABLE "transfer_batches" ADD FOREIGN KEY ("owner") REFERENCES "users" ("username") ON DELETE CASCADE;
+ALTER TABLE "transfer_batches" ADD FOREIGN KEY ("from_account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "transfer_batch_items" ADD FOREIGN KEY ("batch_id") REFERENCES "transfer_batches" ("id") ON DELETE CASCADE;
+ALTER TABLE "transfer_batch_items" ADD FOREIGN KEY ("to_account_id") REFERENCES "accounts" ("id");
+ALTER TABLE "transfer_batch_items" ADD FOREIGN KEY ("transfer_id") REFERENCES "transfers" ("id");
+
+CREATE INDEX "idx_transfer_batches_owner" ON "transfer_batches" ("owner", "id");
+
+-- Add comments for documentation
+COMMENT ON TABLE "transfer_batches" IS 'Batches of transfers from one source account';
+COMMENT ON COLUMN "transfer_batches"."mode" IS 'all_or_nothing or best_effort';
+COMMENT ON COLUMN "transfer_batches"."status" IS 'completed when every item succeeded, partially_completed when some did, failed when none did';
+COMMENT ON COLUMN "transfer_batches"."total_debited" IS 'Sum of amount + fee of the items that succeeded';
+COMMENT ON COLUMN "transfer_batch_items"."item_index" IS 'Position of the item in the batch request, from 0';
+COMMENT ON COLUMN "transfer_batch_items"."status" IS 'completed, failed, or skipped when an all_or_nothing batch was rolled back by another item';
+COMMENT ON COLUMN "transfer_batch_items"."error" IS 'Why the transfer of a failed item was refused';
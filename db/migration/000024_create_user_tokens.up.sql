@@ -0,0 +1,24 @@
+-- Single-use tokens mailed to users, such as the link that verifies their email address.
+-- Only a hash of each token is stored, the token itself is signed with the server key
+CREATE TABLE "user_tokens" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "purpose" varchar NOT NULL,
+  "token_hash" bytea UNIQUE NOT NULL,
+  "email" varchar NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT user_tokens_purpose_check CHECK ("purpose" IN ('email_verification'))
+);
+
+ALTER TABLE "user_tokens" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+CREATE INDEX "idx_user_tokens_username" ON "user_tokens" ("username", "purpose");
+
+-- Add comments for documentation
+COMMENT ON TABLE "user_tokens" IS 'Single-use tokens mailed to users';
+COMMENT ON COLUMN "user_tokens"."purpose" IS 'What the token is for, email_verification';
+COMMENT ON COLUMN "user_tokens"."token_hash" IS 'SHA-256 of the token, the token itself is never stored';
+COMMENT ON COLUMN "user_tokens"."email" IS 'Address the token was sent to, a token no longer applies once the user changes email';
+COMMENT ON COLUMN "user_tokens"."used_at" IS 'When the token was used, a token can only be used once';
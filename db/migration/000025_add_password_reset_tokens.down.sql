@@ -0,0 +1,6 @@
+-- Remove password reset tokens and restore the original purposes
+DELETE FROM "user_tokens" WHERE "purpose" = 'password_reset';
+ALTER TABLE "user_tokens" DROP CONSTRAINT user_tokens_purpose_check;
+ALTER TABLE "user_tokens" ADD CONSTRAINT user_tokens_purpose_check CHECK ("purpose" IN ('email_verification'));
+
+COMMENT ON COLUMN "user_tokens"."purpose" IS 'What the token is for, email_verification';
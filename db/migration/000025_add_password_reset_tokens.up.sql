@@ -0,0 +1,5 @@
+-- Password reset links are single-use user tokens too
+ALTER TABLE "user_tokens" DROP CONSTRAINT user_tokens_purpose_check;
+ALTER TABLE "user_tokens" ADD CONSTRAINT user_tokens_purpose_check CHECK ("purpose" IN ('email_verification', 'password_reset'));
+
+COMMENT ON COLUMN "user_tokens"."purpose" IS 'What the token is for, email_verification or password_reset';
@@ -0,0 +1,9 @@
+-- Sessions rotated from the same login share a family, so that reusing a
+-- refresh token that was already rotated can revoke every session it led to
+ALTER TABLE "sessions" ADD COLUMN "family_id" uuid;
+UPDATE "sessions" SET "family_id" = "id";
+ALTER TABLE "sessions" ALTER COLUMN "family_id" SET NOT NULL;
+
+CREATE INDEX "idx_sessions_family_id" ON "sessions" ("family_id");
+
+COMMENT ON COLUMN "sessions"."family_id" IS 'ID of the session the login started with, shared by every session rotated from it';
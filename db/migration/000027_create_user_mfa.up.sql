@@ -0,0 +1,32 @@
+-- TOTP two-factor authentication of users, at most one authenticator per user.
+-- The secret is encrypted with the server MFA key, the row is pending until the user confirms a first code
+CREATE TABLE "user_mfa" (
+  "username" varchar PRIMARY KEY,
+  "encrypted_secret" bytea NOT NULL,
+  "enabled_at" timestamptz,
+  "last_used_step" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "user_mfa" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+-- Single-use codes that stand in for a TOTP code when the authenticator is lost
+CREATE TABLE "mfa_recovery_codes" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "code_hash" bytea NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT mfa_recovery_codes_username_code_hash_key UNIQUE ("username", "code_hash")
+);
+
+ALTER TABLE "mfa_recovery_codes" ADD FOREIGN KEY ("username") REFERENCES "user_mfa" ("username") ON DELETE CASCADE;
+
+-- Add comments for documentation
+COMMENT ON TABLE "user_mfa" IS 'TOTP two-factor authentication of users';
+COMMENT ON COLUMN "user_mfa"."encrypted_secret" IS 'TOTP secret encrypted with XChaCha20-Poly1305, bound to the username';
+COMMENT ON COLUMN "user_mfa"."enabled_at" IS 'When the user confirmed enrolment with a first code, null while pending';
+COMMENT ON COLUMN "user_mfa"."last_used_step" IS 'Time step of the last accepted code, codes of earlier steps are refused';
+COMMENT ON TABLE "mfa_recovery_codes" IS 'Single-use recovery codes of users with two-factor authentication';
+COMMENT ON COLUMN "mfa_recovery_codes"."code_hash" IS 'SHA-256 of the normalized code, the code itself is never stored';
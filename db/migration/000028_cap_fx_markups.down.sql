@@ -0,0 +1,3 @@
+-- Restore the previous markup range
+ALTER TABLE "fx_markups" DROP CONSTRAINT fx_markups_markup_bps_range;
+ALTER TABLE "fx_markups" ADD CONSTRAINT fx_markups_markup_bps_range CHECK ("markup_bps" >= 0 AND "markup_bps" < 10000);
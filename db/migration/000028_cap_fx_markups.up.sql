@@ -0,0 +1,3 @@
+-- Cap markups at 1000 basis points (10%), the same limit the API accepts
+ALTER TABLE "fx_markups" DROP CONSTRAINT fx_markups_markup_bps_range;
+ALTER TABLE "fx_markups" ADD CONSTRAINT fx_markups_markup_bps_range CHECK ("markup_bps" >= 0 AND "markup_bps" <= 1000);
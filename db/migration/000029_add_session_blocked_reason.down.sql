@@ -0,0 +1,2 @@
+-- Remove session block reasons
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "blocked_reason";
@@ -0,0 +1,16 @@
+-- Record why a session was blocked, so that only refresh tokens that were already exchanged
+-- count as reused when they are presented again
+ALTER TABLE "sessions" ADD COLUMN "blocked_reason" varchar;
+
+-- A blocked session with a later session in its family was rotated, any other was signed out
+UPDATE "sessions" AS s
+SET "blocked_reason" = CASE
+  WHEN EXISTS (
+    SELECT 1 FROM "sessions" AS n
+    WHERE n."family_id" = s."family_id" AND n."created_at" > s."created_at"
+  ) THEN 'rotated'
+  ELSE 'signed_out'
+END
+WHERE s."is_blocked" = true;
+
+COMMENT ON COLUMN "sessions"."blocked_reason" IS 'Why the session was blocked: rotated, signed_out or revoked, NULL while it is not blocked';
@@ -0,0 +1,4 @@
+-- Remove MFA attempt limits
+DROP TABLE IF EXISTS "mfa_challenges";
+ALTER TABLE "user_mfa" DROP COLUMN IF EXISTS "locked_until";
+ALTER TABLE "user_mfa" DROP COLUMN IF EXISTS "failed_attempts";
@@ -0,0 +1,20 @@
+-- Count attempts at a second factor so that too many wrong codes in a row lock the user out
+ALTER TABLE "user_mfa" ADD COLUMN "failed_attempts" integer NOT NULL DEFAULT 0;
+ALTER TABLE "user_mfa" ADD COLUMN "locked_until" timestamptz;
+
+-- MFA tokens of logins waiting for their second factor, each completes one login
+CREATE TABLE "mfa_challenges" (
+  "id" uuid PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "mfa_challenges" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+CREATE INDEX "idx_mfa_challenges_username" ON "mfa_challenges" ("username");
+
+COMMENT ON COLUMN "user_mfa"."failed_attempts" IS 'Attempts at a second factor since the last accepted one, reset when the user is locked out';
+COMMENT ON COLUMN "user_mfa"."locked_until" IS 'Second factors are refused until then after too many failed attempts';
+COMMENT ON TABLE "mfa_challenges" IS 'MFA tokens of logins waiting for their second factor, deleted when the login completes';
+COMMENT ON COLUMN "mfa_challenges"."id" IS 'ID of the MFA token';
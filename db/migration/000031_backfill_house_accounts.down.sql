@@ -0,0 +1 @@
+-- The backfilled house accounts are kept, they may already hold entries
@@ -0,0 +1,21 @@
+-- Currencies added through the API before house accounts were created with them
+-- have no fee, fx or funding account, so transfers and deposits in them fail
+WITH "purposes" ("purpose", "owner") AS (
+  VALUES ('fee', 'house_fees'), ('fx', 'house_fx'), ('funding', 'house_funding')
+), "missing" AS (
+  SELECT p."purpose", p."owner", c."code" AS "currency"
+  FROM "purposes" p CROSS JOIN "currencies" c
+  WHERE NOT EXISTS (
+    SELECT 1 FROM "house_accounts" h
+    WHERE h."currency" = c."code" AND h."purpose" = p."purpose"
+  )
+), "created" AS (
+  INSERT INTO "accounts" ("owner", "balance", "currency")
+  SELECT "owner", 0, "currency"
+  FROM "missing"
+  RETURNING "id", "owner", "currency"
+)
+INSERT INTO "house_accounts" ("currency", "purpose", "account_id")
+SELECT cr."currency", p."purpose", cr."id"
+FROM "created" cr
+JOIN "purposes" p ON p."owner" = cr."owner";
@@ -13,8 +13,10 @@ import (
 	context "context"
 	db "lemfi/simplebank/db/sqlc"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
+	pgtype "github.com/jackc/pgx/v5/pgtype"
 	decimal "github.com/shopspring/decimal"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -43,6 +45,21 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AccountStatementTx mocks base method.
+func (m *MockStore) AccountStatementTx(ctx context.Context, arg db.AccountStatementTxParams) (db.AccountStatementTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccountStatementTx", ctx, arg)
+	ret0, _ := ret[0].(db.AccountStatementTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccountStatementTx indicates an expected call of AccountStatementTx.
+func (mr *MockStoreMockRecorder) AccountStatementTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountStatementTx", reflect.TypeOf((*MockStore)(nil).AccountStatementTx), ctx, arg)
+}
+
 // AddAccountBalance mocks base method.
 func (m *MockStore) AddAccountBalance(ctx context.Context, arg db.AddAccountBalanceParams) (decimal.Decimal, error) {
 	m.ctrl.T.Helper()
@@ -58,390 +75,1925 @@ func (mr *MockStoreMockRecorder) AddAccountBalance(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccountBalance", reflect.TypeOf((*MockStore)(nil).AddAccountBalance), ctx, arg)
 }
 
-// CreateAccount mocks base method.
-func (m *MockStore) CreateAccount(ctx context.Context, arg db.CreateAccountParams) (db.Account, error) {
+// AddAccountHeldAmount mocks base method.
+func (m *MockStore) AddAccountHeldAmount(ctx context.Context, arg db.AddAccountHeldAmountParams) (decimal.Decimal, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAccount", ctx, arg)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "AddAccountHeldAmount", ctx, arg)
+	ret0, _ := ret[0].(decimal.Decimal)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateAccount indicates an expected call of CreateAccount.
-func (mr *MockStoreMockRecorder) CreateAccount(ctx, arg any) *gomock.Call {
+// AddAccountHeldAmount indicates an expected call of AddAccountHeldAmount.
+func (mr *MockStoreMockRecorder) AddAccountHeldAmount(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccountHeldAmount", reflect.TypeOf((*MockStore)(nil).AddAccountHeldAmount), ctx, arg)
 }
 
-// CreateEntry mocks base method.
-func (m *MockStore) CreateEntry(ctx context.Context, arg db.CreateEntryParams) (db.Entry, error) {
+// AuthorizeTransferTx mocks base method.
+func (m *MockStore) AuthorizeTransferTx(ctx context.Context, arg db.AuthorizeTransferTxParams) (db.AuthorizeTransferTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateEntry", ctx, arg)
-	ret0, _ := ret[0].(db.Entry)
+	ret := m.ctrl.Call(m, "AuthorizeTransferTx", ctx, arg)
+	ret0, _ := ret[0].(db.AuthorizeTransferTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateEntry indicates an expected call of CreateEntry.
-func (mr *MockStoreMockRecorder) CreateEntry(ctx, arg any) *gomock.Call {
+// AuthorizeTransferTx indicates an expected call of AuthorizeTransferTx.
+func (mr *MockStoreMockRecorder) AuthorizeTransferTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockStore)(nil).CreateEntry), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeTransferTx", reflect.TypeOf((*MockStore)(nil).AuthorizeTransferTx), ctx, arg)
 }
 
-// CreateExchangeRate mocks base method.
-func (m *MockStore) CreateExchangeRate(ctx context.Context, arg db.CreateExchangeRateParams) (db.ExchangeRate, error) {
+// BatchTransferTx mocks base method.
+func (m *MockStore) BatchTransferTx(ctx context.Context, arg db.BatchTransferTxParams) (db.BatchTransferTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateExchangeRate", ctx, arg)
-	ret0, _ := ret[0].(db.ExchangeRate)
+	ret := m.ctrl.Call(m, "BatchTransferTx", ctx, arg)
+	ret0, _ := ret[0].(db.BatchTransferTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateExchangeRate indicates an expected call of CreateExchangeRate.
-func (mr *MockStoreMockRecorder) CreateExchangeRate(ctx, arg any) *gomock.Call {
+// BatchTransferTx indicates an expected call of BatchTransferTx.
+func (mr *MockStoreMockRecorder) BatchTransferTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExchangeRate", reflect.TypeOf((*MockStore)(nil).CreateExchangeRate), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchTransferTx", reflect.TypeOf((*MockStore)(nil).BatchTransferTx), ctx, arg)
 }
 
-// CreateSession mocks base method.
-func (m *MockStore) CreateSession(ctx context.Context, arg db.CreateSessionParams) (db.Session, error) {
+// BlockActiveSession mocks base method.
+func (m *MockStore) BlockActiveSession(ctx context.Context, id uuid.UUID) (db.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSession", ctx, arg)
+	ret := m.ctrl.Call(m, "BlockActiveSession", ctx, id)
 	ret0, _ := ret[0].(db.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSession indicates an expected call of CreateSession.
-func (mr *MockStoreMockRecorder) CreateSession(ctx, arg any) *gomock.Call {
+// BlockActiveSession indicates an expected call of BlockActiveSession.
+func (mr *MockStoreMockRecorder) BlockActiveSession(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockActiveSession", reflect.TypeOf((*MockStore)(nil).BlockActiveSession), ctx, id)
 }
 
-// CreateTransfer mocks base method.
-func (m *MockStore) CreateTransfer(ctx context.Context, arg db.CreateTransferParams) (db.CreateTransferRow, error) {
+// BlockSession mocks base method.
+func (m *MockStore) BlockSession(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTransfer", ctx, arg)
-	ret0, _ := ret[0].(db.CreateTransferRow)
+	ret := m.ctrl.Call(m, "BlockSession", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockSession indicates an expected call of BlockSession.
+func (mr *MockStoreMockRecorder) BlockSession(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSession", reflect.TypeOf((*MockStore)(nil).BlockSession), ctx, id)
+}
+
+// BlockSessionFamily mocks base method.
+func (m *MockStore) BlockSessionFamily(ctx context.Context, familyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockSessionFamily", ctx, familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockSessionFamily indicates an expected call of BlockSessionFamily.
+func (mr *MockStoreMockRecorder) BlockSessionFamily(ctx, familyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSessionFamily", reflect.TypeOf((*MockStore)(nil).BlockSessionFamily), ctx, familyID)
+}
+
+// BlockUserSessions mocks base method.
+func (m *MockStore) BlockUserSessions(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockUserSessions", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockUserSessions indicates an expected call of BlockUserSessions.
+func (mr *MockStoreMockRecorder) BlockUserSessions(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockUserSessions", reflect.TypeOf((*MockStore)(nil).BlockUserSessions), ctx, username)
+}
+
+// CaptureTransferHoldTx mocks base method.
+func (m *MockStore) CaptureTransferHoldTx(ctx context.Context, holdID int64) (db.CaptureTransferHoldTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureTransferHoldTx", ctx, holdID)
+	ret0, _ := ret[0].(db.CaptureTransferHoldTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateTransfer indicates an expected call of CreateTransfer.
-func (mr *MockStoreMockRecorder) CreateTransfer(ctx, arg any) *gomock.Call {
+// CaptureTransferHoldTx indicates an expected call of CaptureTransferHoldTx.
+func (mr *MockStoreMockRecorder) CaptureTransferHoldTx(ctx, holdID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStore)(nil).CreateTransfer), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureTransferHoldTx", reflect.TypeOf((*MockStore)(nil).CaptureTransferHoldTx), ctx, holdID)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.CreateUserRow, error) {
+// ChangePasswordTx mocks base method.
+func (m *MockStore) ChangePasswordTx(ctx context.Context, arg db.ChangePasswordTxParams) (db.ChangePasswordTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
-	ret0, _ := ret[0].(db.CreateUserRow)
+	ret := m.ctrl.Call(m, "ChangePasswordTx", ctx, arg)
+	ret0, _ := ret[0].(db.ChangePasswordTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
+// ChangePasswordTx indicates an expected call of ChangePasswordTx.
+func (mr *MockStoreMockRecorder) ChangePasswordTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePasswordTx", reflect.TypeOf((*MockStore)(nil).ChangePasswordTx), ctx, arg)
 }
 
-// DeleteAccount mocks base method.
-func (m *MockStore) DeleteAccount(ctx context.Context, id int64) error {
+// ClaimDueScheduledTransfers mocks base method.
+func (m *MockStore) ClaimDueScheduledTransfers(ctx context.Context, arg db.ClaimDueScheduledTransfersParams) ([]db.ScheduledTransfer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAccount", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ClaimDueScheduledTransfers", ctx, arg)
+	ret0, _ := ret[0].([]db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteAccount indicates an expected call of DeleteAccount.
-func (mr *MockStoreMockRecorder) DeleteAccount(ctx, id any) *gomock.Call {
+// ClaimDueScheduledTransfers indicates an expected call of ClaimDueScheduledTransfers.
+func (mr *MockStoreMockRecorder) ClaimDueScheduledTransfers(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueScheduledTransfers", reflect.TypeOf((*MockStore)(nil).ClaimDueScheduledTransfers), ctx, arg)
 }
 
-// DeleteUser mocks base method.
-func (m *MockStore) DeleteUser(ctx context.Context, username string) error {
+// ConsumeFxQuote mocks base method.
+func (m *MockStore) ConsumeFxQuote(ctx context.Context, arg db.ConsumeFxQuoteParams) (db.FxQuote, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", ctx, username)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ConsumeFxQuote", ctx, arg)
+	ret0, _ := ret[0].(db.FxQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockStoreMockRecorder) DeleteUser(ctx, username any) *gomock.Call {
+// ConsumeFxQuote indicates an expected call of ConsumeFxQuote.
+func (mr *MockStoreMockRecorder) ConsumeFxQuote(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStore)(nil).DeleteUser), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeFxQuote", reflect.TypeOf((*MockStore)(nil).ConsumeFxQuote), ctx, arg)
 }
 
-// GetAccount mocks base method.
-func (m *MockStore) GetAccount(ctx context.Context, id int64) (db.Account, error) {
+// CountMfaAttempt mocks base method.
+func (m *MockStore) CountMfaAttempt(ctx context.Context, arg db.CountMfaAttemptParams) (db.UserMfa, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccount", ctx, id)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "CountMfaAttempt", ctx, arg)
+	ret0, _ := ret[0].(db.UserMfa)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccount indicates an expected call of GetAccount.
-func (mr *MockStoreMockRecorder) GetAccount(ctx, id any) *gomock.Call {
+// CountMfaAttempt indicates an expected call of CountMfaAttempt.
+func (mr *MockStoreMockRecorder) CountMfaAttempt(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMfaAttempt", reflect.TypeOf((*MockStore)(nil).CountMfaAttempt), ctx, arg)
 }
 
-// GetAccountForUpdate mocks base method.
-func (m *MockStore) GetAccountForUpdate(ctx context.Context, id int64) (db.Account, error) {
+// CountUnusedMfaRecoveryCodes mocks base method.
+func (m *MockStore) CountUnusedMfaRecoveryCodes(ctx context.Context, username string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountForUpdate", ctx, id)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "CountUnusedMfaRecoveryCodes", ctx, username)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
-func (mr *MockStoreMockRecorder) GetAccountForUpdate(ctx, id any) *gomock.Call {
+// CountUnusedMfaRecoveryCodes indicates an expected call of CountUnusedMfaRecoveryCodes.
+func (mr *MockStoreMockRecorder) CountUnusedMfaRecoveryCodes(ctx, username any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnusedMfaRecoveryCodes", reflect.TypeOf((*MockStore)(nil).CountUnusedMfaRecoveryCodes), ctx, username)
 }
 
-// GetEntry mocks base method.
-func (m *MockStore) GetEntry(ctx context.Context, id int64) (db.Entry, error) {
+// CreateAccount mocks base method.
+func (m *MockStore) CreateAccount(ctx context.Context, arg db.CreateAccountParams) (db.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEntry", ctx, id)
-	ret0, _ := ret[0].(db.Entry)
+	ret := m.ctrl.Call(m, "CreateAccount", ctx, arg)
+	ret0, _ := ret[0].(db.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEntry indicates an expected call of GetEntry.
-func (mr *MockStoreMockRecorder) GetEntry(ctx, id any) *gomock.Call {
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockStoreMockRecorder) CreateAccount(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), ctx, arg)
 }
 
-// GetExchangeRate mocks base method.
-func (m *MockStore) GetExchangeRate(ctx context.Context, arg db.GetExchangeRateParams) (db.ExchangeRate, error) {
+// CreateCurrency mocks base method.
+func (m *MockStore) CreateCurrency(ctx context.Context, arg db.CreateCurrencyParams) (db.Currency, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetExchangeRate", ctx, arg)
-	ret0, _ := ret[0].(db.ExchangeRate)
+	ret := m.ctrl.Call(m, "CreateCurrency", ctx, arg)
+	ret0, _ := ret[0].(db.Currency)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetExchangeRate indicates an expected call of GetExchangeRate.
-func (mr *MockStoreMockRecorder) GetExchangeRate(ctx, arg any) *gomock.Call {
+// CreateCurrency indicates an expected call of CreateCurrency.
+func (mr *MockStoreMockRecorder) CreateCurrency(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRate", reflect.TypeOf((*MockStore)(nil).GetExchangeRate), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCurrency", reflect.TypeOf((*MockStore)(nil).CreateCurrency), ctx, arg)
 }
 
-// GetSession mocks base method.
-func (m *MockStore) GetSession(ctx context.Context, id uuid.UUID) (db.GetSessionRow, error) {
+// CreateCurrencyTx mocks base method.
+func (m *MockStore) CreateCurrencyTx(ctx context.Context, arg db.CreateCurrencyParams) (db.Currency, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSession", ctx, id)
-	ret0, _ := ret[0].(db.GetSessionRow)
+	ret := m.ctrl.Call(m, "CreateCurrencyTx", ctx, arg)
+	ret0, _ := ret[0].(db.Currency)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSession indicates an expected call of GetSession.
-func (mr *MockStoreMockRecorder) GetSession(ctx, id any) *gomock.Call {
+// CreateCurrencyTx indicates an expected call of CreateCurrencyTx.
+func (mr *MockStoreMockRecorder) CreateCurrencyTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCurrencyTx", reflect.TypeOf((*MockStore)(nil).CreateCurrencyTx), ctx, arg)
 }
 
-// GetTransfer mocks base method.
-func (m *MockStore) GetTransfer(ctx context.Context, id int64) (db.GetTransferRow, error) {
+// CreateEntry mocks base method.
+func (m *MockStore) CreateEntry(ctx context.Context, arg db.CreateEntryParams) (db.Entry, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransfer", ctx, id)
-	ret0, _ := ret[0].(db.GetTransferRow)
+	ret := m.ctrl.Call(m, "CreateEntry", ctx, arg)
+	ret0, _ := ret[0].(db.Entry)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTransfer indicates an expected call of GetTransfer.
-func (mr *MockStoreMockRecorder) GetTransfer(ctx, id any) *gomock.Call {
+// CreateEntry indicates an expected call of CreateEntry.
+func (mr *MockStoreMockRecorder) CreateEntry(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockStore)(nil).CreateEntry), ctx, arg)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(ctx context.Context, username string) (db.GetUserRow, error) {
+// CreateExchangeRate mocks base method.
+func (m *MockStore) CreateExchangeRate(ctx context.Context, arg db.CreateExchangeRateParams) (db.ExchangeRate, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", ctx, username)
-	ret0, _ := ret[0].(db.GetUserRow)
+	ret := m.ctrl.Call(m, "CreateExchangeRate", ctx, arg)
+	ret0, _ := ret[0].(db.ExchangeRate)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(ctx, username any) *gomock.Call {
+// CreateExchangeRate indicates an expected call of CreateExchangeRate.
+func (mr *MockStoreMockRecorder) CreateExchangeRate(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExchangeRate", reflect.TypeOf((*MockStore)(nil).CreateExchangeRate), ctx, arg)
 }
 
-// GetUserHashedPassword mocks base method.
-func (m *MockStore) GetUserHashedPassword(ctx context.Context, username string) (string, error) {
+// CreateExchangeRateTx mocks base method.
+func (m *MockStore) CreateExchangeRateTx(ctx context.Context, arg db.ExchangeRatePairTxParams) (db.ExchangeRatePairTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserHashedPassword", ctx, username)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "CreateExchangeRateTx", ctx, arg)
+	ret0, _ := ret[0].(db.ExchangeRatePairTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserHashedPassword indicates an expected call of GetUserHashedPassword.
-func (mr *MockStoreMockRecorder) GetUserHashedPassword(ctx, username any) *gomock.Call {
+// CreateExchangeRateTx indicates an expected call of CreateExchangeRateTx.
+func (mr *MockStoreMockRecorder) CreateExchangeRateTx(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHashedPassword", reflect.TypeOf((*MockStore)(nil).GetUserHashedPassword), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExchangeRateTx", reflect.TypeOf((*MockStore)(nil).CreateExchangeRateTx), ctx, arg)
 }
 
-// ListAccounts mocks base method.
-func (m *MockStore) ListAccounts(ctx context.Context, arg db.ListAccountsParams) ([]db.Account, error) {
+// CreateFeeSchedule mocks base method.
+func (m *MockStore) CreateFeeSchedule(ctx context.Context, arg db.CreateFeeScheduleParams) (db.FeeSchedule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAccounts", ctx, arg)
-	ret0, _ := ret[0].([]db.Account)
+	ret := m.ctrl.Call(m, "CreateFeeSchedule", ctx, arg)
+	ret0, _ := ret[0].(db.FeeSchedule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAccounts indicates an expected call of ListAccounts.
-func (mr *MockStoreMockRecorder) ListAccounts(ctx, arg any) *gomock.Call {
+// CreateFeeSchedule indicates an expected call of CreateFeeSchedule.
+func (mr *MockStoreMockRecorder) CreateFeeSchedule(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFeeSchedule", reflect.TypeOf((*MockStore)(nil).CreateFeeSchedule), ctx, arg)
 }
 
-// ListAllAccounts mocks base method.
-func (m *MockStore) ListAllAccounts(ctx context.Context, arg db.ListAllAccountsParams) ([]db.Account, error) {
+// CreateFeeScheduleTier mocks base method.
+func (m *MockStore) CreateFeeScheduleTier(ctx context.Context, arg db.CreateFeeScheduleTierParams) (db.FeeScheduleTier, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAllAccounts", ctx, arg)
-	ret0, _ := ret[0].([]db.Account)
+	ret := m.ctrl.Call(m, "CreateFeeScheduleTier", ctx, arg)
+	ret0, _ := ret[0].(db.FeeScheduleTier)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAllAccounts indicates an expected call of ListAllAccounts.
-func (mr *MockStoreMockRecorder) ListAllAccounts(ctx, arg any) *gomock.Call {
+// CreateFeeScheduleTier indicates an expected call of CreateFeeScheduleTier.
+func (mr *MockStoreMockRecorder) CreateFeeScheduleTier(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllAccounts", reflect.TypeOf((*MockStore)(nil).ListAllAccounts), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFeeScheduleTier", reflect.TypeOf((*MockStore)(nil).CreateFeeScheduleTier), ctx, arg)
 }
 
-// ListEntries mocks base method.
-func (m *MockStore) ListEntries(ctx context.Context, arg db.ListEntriesParams) ([]db.Entry, error) {
+// CreateFundingTransaction mocks base method.
+func (m *MockStore) CreateFundingTransaction(ctx context.Context, arg db.CreateFundingTransactionParams) (db.FundingTransaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListEntries", ctx, arg)
-	ret0, _ := ret[0].([]db.Entry)
+	ret := m.ctrl.Call(m, "CreateFundingTransaction", ctx, arg)
+	ret0, _ := ret[0].(db.FundingTransaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListEntries indicates an expected call of ListEntries.
-func (mr *MockStoreMockRecorder) ListEntries(ctx, arg any) *gomock.Call {
+// CreateFundingTransaction indicates an expected call of CreateFundingTransaction.
+func (mr *MockStoreMockRecorder) CreateFundingTransaction(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockStore)(nil).ListEntries), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFundingTransaction", reflect.TypeOf((*MockStore)(nil).CreateFundingTransaction), ctx, arg)
 }
 
-// ListExchangeRates mocks base method.
-func (m *MockStore) ListExchangeRates(ctx context.Context) ([]db.ExchangeRate, error) {
+// CreateFxQuote mocks base method.
+func (m *MockStore) CreateFxQuote(ctx context.Context, arg db.CreateFxQuoteParams) (db.FxQuote, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListExchangeRates", ctx)
-	ret0, _ := ret[0].([]db.ExchangeRate)
+	ret := m.ctrl.Call(m, "CreateFxQuote", ctx, arg)
+	ret0, _ := ret[0].(db.FxQuote)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListExchangeRates indicates an expected call of ListExchangeRates.
-func (mr *MockStoreMockRecorder) ListExchangeRates(ctx any) *gomock.Call {
+// CreateFxQuote indicates an expected call of CreateFxQuote.
+func (mr *MockStoreMockRecorder) CreateFxQuote(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExchangeRates", reflect.TypeOf((*MockStore)(nil).ListExchangeRates), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFxQuote", reflect.TypeOf((*MockStore)(nil).CreateFxQuote), ctx, arg)
 }
 
-// ListTransfers mocks base method.
-func (m *MockStore) ListTransfers(ctx context.Context, arg db.ListTransfersParams) ([]db.ListTransfersRow, error) {
+// CreateHouseAccount mocks base method.
+func (m *MockStore) CreateHouseAccount(ctx context.Context, arg db.CreateHouseAccountParams) (db.HouseAccount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTransfers", ctx, arg)
-	ret0, _ := ret[0].([]db.ListTransfersRow)
+	ret := m.ctrl.Call(m, "CreateHouseAccount", ctx, arg)
+	ret0, _ := ret[0].(db.HouseAccount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListTransfers indicates an expected call of ListTransfers.
-func (mr *MockStoreMockRecorder) ListTransfers(ctx, arg any) *gomock.Call {
+// CreateHouseAccount indicates an expected call of CreateHouseAccount.
+func (mr *MockStoreMockRecorder) CreateHouseAccount(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHouseAccount", reflect.TypeOf((*MockStore)(nil).CreateHouseAccount), ctx, arg)
 }
 
-// ListUsers mocks base method.
-func (m *MockStore) ListUsers(ctx context.Context) ([]db.ListUsersRow, error) {
+// CreateIdempotencyKey mocks base method.
+func (m *MockStore) CreateIdempotencyKey(ctx context.Context, arg db.CreateIdempotencyKeyParams) (db.IdempotencyKey, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", ctx)
-	ret0, _ := ret[0].([]db.ListUsersRow)
+	ret := m.ctrl.Call(m, "CreateIdempotencyKey", ctx, arg)
+	ret0, _ := ret[0].(db.IdempotencyKey)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUsers indicates an expected call of ListUsers.
-func (mr *MockStoreMockRecorder) ListUsers(ctx any) *gomock.Call {
+// CreateIdempotencyKey indicates an expected call of CreateIdempotencyKey.
+func (mr *MockStoreMockRecorder) CreateIdempotencyKey(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdempotencyKey", reflect.TypeOf((*MockStore)(nil).CreateIdempotencyKey), ctx, arg)
 }
 
-// TransferTx mocks base method.
-func (m *MockStore) TransferTx(ctx context.Context, arg db.TransferTxParams) (db.TransferTxResult, error) {
+// CreateMfaChallenge mocks base method.
+func (m *MockStore) CreateMfaChallenge(ctx context.Context, arg db.CreateMfaChallengeParams) (db.MfaChallenge, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TransferTx", ctx, arg)
-	ret0, _ := ret[0].(db.TransferTxResult)
+	ret := m.ctrl.Call(m, "CreateMfaChallenge", ctx, arg)
+	ret0, _ := ret[0].(db.MfaChallenge)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// TransferTx indicates an expected call of TransferTx.
-func (mr *MockStoreMockRecorder) TransferTx(ctx, arg any) *gomock.Call {
+// CreateMfaChallenge indicates an expected call of CreateMfaChallenge.
+func (mr *MockStoreMockRecorder) CreateMfaChallenge(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferTx", reflect.TypeOf((*MockStore)(nil).TransferTx), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMfaChallenge", reflect.TypeOf((*MockStore)(nil).CreateMfaChallenge), ctx, arg)
 }
 
-// UpdateAccount mocks base method.
-func (m *MockStore) UpdateAccount(ctx context.Context, arg db.UpdateAccountParams) (db.Account, error) {
+// CreateMfaRecoveryCode mocks base method.
+func (m *MockStore) CreateMfaRecoveryCode(ctx context.Context, arg db.CreateMfaRecoveryCodeParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateAccount", ctx, arg)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "CreateMfaRecoveryCode", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMfaRecoveryCode indicates an expected call of CreateMfaRecoveryCode.
+func (mr *MockStoreMockRecorder) CreateMfaRecoveryCode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMfaRecoveryCode", reflect.TypeOf((*MockStore)(nil).CreateMfaRecoveryCode), ctx, arg)
+}
+
+// CreatePendingUserMfa mocks base method.
+func (m *MockStore) CreatePendingUserMfa(ctx context.Context, arg db.CreatePendingUserMfaParams) (db.UserMfa, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePendingUserMfa", ctx, arg)
+	ret0, _ := ret[0].(db.UserMfa)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateAccount indicates an expected call of UpdateAccount.
-func (mr *MockStoreMockRecorder) UpdateAccount(ctx, arg any) *gomock.Call {
+// CreatePendingUserMfa indicates an expected call of CreatePendingUserMfa.
+func (mr *MockStoreMockRecorder) CreatePendingUserMfa(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockStore)(nil).UpdateAccount), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePendingUserMfa", reflect.TypeOf((*MockStore)(nil).CreatePendingUserMfa), ctx, arg)
 }
 
-// UpdateExchangeRate mocks base method.
-func (m *MockStore) UpdateExchangeRate(ctx context.Context, arg db.UpdateExchangeRateParams) (db.ExchangeRate, error) {
+// CreateScheduledTransfer mocks base method.
+func (m *MockStore) CreateScheduledTransfer(ctx context.Context, arg db.CreateScheduledTransferParams) (db.ScheduledTransfer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateExchangeRate", ctx, arg)
-	ret0, _ := ret[0].(db.ExchangeRate)
+	ret := m.ctrl.Call(m, "CreateScheduledTransfer", ctx, arg)
+	ret0, _ := ret[0].(db.ScheduledTransfer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateExchangeRate indicates an expected call of UpdateExchangeRate.
-func (mr *MockStoreMockRecorder) UpdateExchangeRate(ctx, arg any) *gomock.Call {
+// CreateScheduledTransfer indicates an expected call of CreateScheduledTransfer.
+func (mr *MockStoreMockRecorder) CreateScheduledTransfer(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExchangeRate", reflect.TypeOf((*MockStore)(nil).UpdateExchangeRate), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateScheduledTransfer", reflect.TypeOf((*MockStore)(nil).CreateScheduledTransfer), ctx, arg)
 }
 
-// UpdateUser mocks base method.
-func (m *MockStore) UpdateUser(ctx context.Context, arg db.UpdateUserParams) (db.UpdateUserRow, error) {
+// CreateSession mocks base method.
+func (m *MockStore) CreateSession(ctx context.Context, arg db.CreateSessionParams) (db.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", ctx, arg)
-	ret0, _ := ret[0].(db.UpdateUserRow)
+	ret := m.ctrl.Call(m, "CreateSession", ctx, arg)
+	ret0, _ := ret[0].(db.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockStoreMockRecorder) UpdateUser(ctx, arg any) *gomock.Call {
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockStoreMockRecorder) CreateSession(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), ctx, arg)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockStore) CreateTransfer(ctx context.Context, arg db.CreateTransferParams) (db.CreateTransferRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", ctx, arg)
+	ret0, _ := ret[0].(db.CreateTransferRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockStoreMockRecorder) CreateTransfer(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStore)(nil).CreateTransfer), ctx, arg)
+}
+
+// CreateTransferBatch mocks base method.
+func (m *MockStore) CreateTransferBatch(ctx context.Context, arg db.CreateTransferBatchParams) (db.TransferBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferBatch", ctx, arg)
+	ret0, _ := ret[0].(db.TransferBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferBatch indicates an expected call of CreateTransferBatch.
+func (mr *MockStoreMockRecorder) CreateTransferBatch(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferBatch", reflect.TypeOf((*MockStore)(nil).CreateTransferBatch), ctx, arg)
+}
+
+// CreateTransferBatchItem mocks base method.
+func (m *MockStore) CreateTransferBatchItem(ctx context.Context, arg db.CreateTransferBatchItemParams) (db.TransferBatchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferBatchItem", ctx, arg)
+	ret0, _ := ret[0].(db.TransferBatchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferBatchItem indicates an expected call of CreateTransferBatchItem.
+func (mr *MockStoreMockRecorder) CreateTransferBatchItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferBatchItem", reflect.TypeOf((*MockStore)(nil).CreateTransferBatchItem), ctx, arg)
+}
+
+// CreateTransferHold mocks base method.
+func (m *MockStore) CreateTransferHold(ctx context.Context, arg db.CreateTransferHoldParams) (db.TransferHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferHold", ctx, arg)
+	ret0, _ := ret[0].(db.TransferHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferHold indicates an expected call of CreateTransferHold.
+func (mr *MockStoreMockRecorder) CreateTransferHold(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferHold", reflect.TypeOf((*MockStore)(nil).CreateTransferHold), ctx, arg)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.CreateUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, arg)
+	ret0, _ := ret[0].(db.CreateUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), ctx, arg)
+}
+
+// CreateUserToken mocks base method.
+func (m *MockStore) CreateUserToken(ctx context.Context, arg db.CreateUserTokenParams) (db.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserToken", ctx, arg)
+	ret0, _ := ret[0].(db.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserToken indicates an expected call of CreateUserToken.
+func (mr *MockStoreMockRecorder) CreateUserToken(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserToken", reflect.TypeOf((*MockStore)(nil).CreateUserToken), ctx, arg)
+}
+
+// DeactivateFeeSchedule mocks base method.
+func (m *MockStore) DeactivateFeeSchedule(ctx context.Context, arg db.DeactivateFeeScheduleParams) (db.FeeSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateFeeSchedule", ctx, arg)
+	ret0, _ := ret[0].(db.FeeSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateFeeSchedule indicates an expected call of DeactivateFeeSchedule.
+func (mr *MockStoreMockRecorder) DeactivateFeeSchedule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateFeeSchedule", reflect.TypeOf((*MockStore)(nil).DeactivateFeeSchedule), ctx, arg)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockStore) DeleteAccount(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockStoreMockRecorder) DeleteAccount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), ctx, id)
+}
+
+// DeleteExchangeRate mocks base method.
+func (m *MockStore) DeleteExchangeRate(ctx context.Context, arg db.DeleteExchangeRateParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExchangeRate", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExchangeRate indicates an expected call of DeleteExchangeRate.
+func (mr *MockStoreMockRecorder) DeleteExchangeRate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExchangeRate", reflect.TypeOf((*MockStore)(nil).DeleteExchangeRate), ctx, arg)
+}
+
+// DeleteExchangeRateTx mocks base method.
+func (m *MockStore) DeleteExchangeRateTx(ctx context.Context, arg db.DeleteExchangeRateParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExchangeRateTx", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExchangeRateTx indicates an expected call of DeleteExchangeRateTx.
+func (mr *MockStoreMockRecorder) DeleteExchangeRateTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExchangeRateTx", reflect.TypeOf((*MockStore)(nil).DeleteExchangeRateTx), ctx, arg)
+}
+
+// DeleteFxMarkup mocks base method.
+func (m *MockStore) DeleteFxMarkup(ctx context.Context, arg db.DeleteFxMarkupParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFxMarkup", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFxMarkup indicates an expected call of DeleteFxMarkup.
+func (mr *MockStoreMockRecorder) DeleteFxMarkup(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFxMarkup", reflect.TypeOf((*MockStore)(nil).DeleteFxMarkup), ctx, arg)
+}
+
+// DeleteMfaRecoveryCodes mocks base method.
+func (m *MockStore) DeleteMfaRecoveryCodes(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMfaRecoveryCodes", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMfaRecoveryCodes indicates an expected call of DeleteMfaRecoveryCodes.
+func (mr *MockStoreMockRecorder) DeleteMfaRecoveryCodes(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMfaRecoveryCodes", reflect.TypeOf((*MockStore)(nil).DeleteMfaRecoveryCodes), ctx, username)
+}
+
+// DeleteUser mocks base method.
+func (m *MockStore) DeleteUser(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockStoreMockRecorder) DeleteUser(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStore)(nil).DeleteUser), ctx, username)
+}
+
+// DeleteUserMfa mocks base method.
+func (m *MockStore) DeleteUserMfa(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserMfa", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserMfa indicates an expected call of DeleteUserMfa.
+func (mr *MockStoreMockRecorder) DeleteUserMfa(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserMfa", reflect.TypeOf((*MockStore)(nil).DeleteUserMfa), ctx, username)
+}
+
+// DeleteUserTransferLimit mocks base method.
+func (m *MockStore) DeleteUserTransferLimit(ctx context.Context, arg db.DeleteUserTransferLimitParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserTransferLimit", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserTransferLimit indicates an expected call of DeleteUserTransferLimit.
+func (mr *MockStoreMockRecorder) DeleteUserTransferLimit(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTransferLimit", reflect.TypeOf((*MockStore)(nil).DeleteUserTransferLimit), ctx, arg)
+}
+
+// DepositTx mocks base method.
+func (m *MockStore) DepositTx(ctx context.Context, arg db.FundingTxParams) (db.FundingTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DepositTx", ctx, arg)
+	ret0, _ := ret[0].(db.FundingTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DepositTx indicates an expected call of DepositTx.
+func (mr *MockStoreMockRecorder) DepositTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DepositTx", reflect.TypeOf((*MockStore)(nil).DepositTx), ctx, arg)
+}
+
+// EnableMfaTx mocks base method.
+func (m *MockStore) EnableMfaTx(ctx context.Context, arg db.EnableMfaTxParams) (db.UserMfa, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableMfaTx", ctx, arg)
+	ret0, _ := ret[0].(db.UserMfa)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableMfaTx indicates an expected call of EnableMfaTx.
+func (mr *MockStoreMockRecorder) EnableMfaTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableMfaTx", reflect.TypeOf((*MockStore)(nil).EnableMfaTx), ctx, arg)
+}
+
+// EnableUserMfa mocks base method.
+func (m *MockStore) EnableUserMfa(ctx context.Context, arg db.EnableUserMfaParams) (db.UserMfa, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableUserMfa", ctx, arg)
+	ret0, _ := ret[0].(db.UserMfa)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableUserMfa indicates an expected call of EnableUserMfa.
+func (mr *MockStoreMockRecorder) EnableUserMfa(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableUserMfa", reflect.TypeOf((*MockStore)(nil).EnableUserMfa), ctx, arg)
+}
+
+// ExpireTransferHoldsTx mocks base method.
+func (m *MockStore) ExpireTransferHoldsTx(ctx context.Context, batchSize int32) ([]db.TransferHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireTransferHoldsTx", ctx, batchSize)
+	ret0, _ := ret[0].([]db.TransferHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireTransferHoldsTx indicates an expected call of ExpireTransferHoldsTx.
+func (mr *MockStoreMockRecorder) ExpireTransferHoldsTx(ctx, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireTransferHoldsTx", reflect.TypeOf((*MockStore)(nil).ExpireTransferHoldsTx), ctx, batchSize)
+}
+
+// GetAccount mocks base method.
+func (m *MockStore) GetAccount(ctx context.Context, id int64) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, id)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockStoreMockRecorder) GetAccount(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), ctx, id)
+}
+
+// GetAccountForUpdate mocks base method.
+func (m *MockStore) GetAccountForUpdate(ctx context.Context, id int64) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
+func (mr *MockStoreMockRecorder) GetAccountForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), ctx, id)
+}
+
+// GetActiveFeeSchedule mocks base method.
+func (m *MockStore) GetActiveFeeSchedule(ctx context.Context, arg db.GetActiveFeeScheduleParams) (db.FeeSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveFeeSchedule", ctx, arg)
+	ret0, _ := ret[0].(db.FeeSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveFeeSchedule indicates an expected call of GetActiveFeeSchedule.
+func (mr *MockStoreMockRecorder) GetActiveFeeSchedule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveFeeSchedule", reflect.TypeOf((*MockStore)(nil).GetActiveFeeSchedule), ctx, arg)
+}
+
+// GetCurrency mocks base method.
+func (m *MockStore) GetCurrency(ctx context.Context, code string) (db.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrency", ctx, code)
+	ret0, _ := ret[0].(db.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrency indicates an expected call of GetCurrency.
+func (mr *MockStoreMockRecorder) GetCurrency(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrency", reflect.TypeOf((*MockStore)(nil).GetCurrency), ctx, code)
+}
+
+// GetEntry mocks base method.
+func (m *MockStore) GetEntry(ctx context.Context, id int64) (db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntry", ctx, id)
+	ret0, _ := ret[0].(db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntry indicates an expected call of GetEntry.
+func (mr *MockStoreMockRecorder) GetEntry(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), ctx, id)
+}
+
+// GetExchangeRate mocks base method.
+func (m *MockStore) GetExchangeRate(ctx context.Context, arg db.GetExchangeRateParams) (db.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRate", ctx, arg)
+	ret0, _ := ret[0].(db.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRate indicates an expected call of GetExchangeRate.
+func (mr *MockStoreMockRecorder) GetExchangeRate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRate", reflect.TypeOf((*MockStore)(nil).GetExchangeRate), ctx, arg)
+}
+
+// GetExchangeRateAsOf mocks base method.
+func (m *MockStore) GetExchangeRateAsOf(ctx context.Context, arg db.GetExchangeRateAsOfParams) (db.ExchangeRateHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExchangeRateAsOf", ctx, arg)
+	ret0, _ := ret[0].(db.ExchangeRateHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExchangeRateAsOf indicates an expected call of GetExchangeRateAsOf.
+func (mr *MockStoreMockRecorder) GetExchangeRateAsOf(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExchangeRateAsOf", reflect.TypeOf((*MockStore)(nil).GetExchangeRateAsOf), ctx, arg)
+}
+
+// GetFundingTransactionByReference mocks base method.
+func (m *MockStore) GetFundingTransactionByReference(ctx context.Context, arg db.GetFundingTransactionByReferenceParams) (db.FundingTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFundingTransactionByReference", ctx, arg)
+	ret0, _ := ret[0].(db.FundingTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFundingTransactionByReference indicates an expected call of GetFundingTransactionByReference.
+func (mr *MockStoreMockRecorder) GetFundingTransactionByReference(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFundingTransactionByReference", reflect.TypeOf((*MockStore)(nil).GetFundingTransactionByReference), ctx, arg)
+}
+
+// GetFxMarkup mocks base method.
+func (m *MockStore) GetFxMarkup(ctx context.Context, arg db.GetFxMarkupParams) (db.FxMarkup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFxMarkup", ctx, arg)
+	ret0, _ := ret[0].(db.FxMarkup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFxMarkup indicates an expected call of GetFxMarkup.
+func (mr *MockStoreMockRecorder) GetFxMarkup(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFxMarkup", reflect.TypeOf((*MockStore)(nil).GetFxMarkup), ctx, arg)
+}
+
+// GetFxQuote mocks base method.
+func (m *MockStore) GetFxQuote(ctx context.Context, id int64) (db.FxQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFxQuote", ctx, id)
+	ret0, _ := ret[0].(db.FxQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFxQuote indicates an expected call of GetFxQuote.
+func (mr *MockStoreMockRecorder) GetFxQuote(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFxQuote", reflect.TypeOf((*MockStore)(nil).GetFxQuote), ctx, id)
+}
+
+// GetHouseAccountID mocks base method.
+func (m *MockStore) GetHouseAccountID(ctx context.Context, arg db.GetHouseAccountIDParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHouseAccountID", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHouseAccountID indicates an expected call of GetHouseAccountID.
+func (mr *MockStoreMockRecorder) GetHouseAccountID(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHouseAccountID", reflect.TypeOf((*MockStore)(nil).GetHouseAccountID), ctx, arg)
+}
+
+// GetIdempotencyKey mocks base method.
+func (m *MockStore) GetIdempotencyKey(ctx context.Context, arg db.GetIdempotencyKeyParams) (db.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyKey", ctx, arg)
+	ret0, _ := ret[0].(db.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyKey indicates an expected call of GetIdempotencyKey.
+func (mr *MockStoreMockRecorder) GetIdempotencyKey(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyKey", reflect.TypeOf((*MockStore)(nil).GetIdempotencyKey), ctx, arg)
+}
+
+// GetScheduledTransfer mocks base method.
+func (m *MockStore) GetScheduledTransfer(ctx context.Context, id int64) (db.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledTransfer", ctx, id)
+	ret0, _ := ret[0].(db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledTransfer indicates an expected call of GetScheduledTransfer.
+func (mr *MockStoreMockRecorder) GetScheduledTransfer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledTransfer", reflect.TypeOf((*MockStore)(nil).GetScheduledTransfer), ctx, id)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(ctx context.Context, id uuid.UUID) (db.GetSessionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, id)
+	ret0, _ := ret[0].(db.GetSessionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), ctx, id)
+}
+
+// GetTierTransferLimit mocks base method.
+func (m *MockStore) GetTierTransferLimit(ctx context.Context, arg db.GetTierTransferLimitParams) (db.TransferLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTierTransferLimit", ctx, arg)
+	ret0, _ := ret[0].(db.TransferLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTierTransferLimit indicates an expected call of GetTierTransferLimit.
+func (mr *MockStoreMockRecorder) GetTierTransferLimit(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTierTransferLimit", reflect.TypeOf((*MockStore)(nil).GetTierTransferLimit), ctx, arg)
+}
+
+// GetTransfer mocks base method.
+func (m *MockStore) GetTransfer(ctx context.Context, id int64) (db.GetTransferRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfer", ctx, id)
+	ret0, _ := ret[0].(db.GetTransferRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockStoreMockRecorder) GetTransfer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), ctx, id)
+}
+
+// GetTransferBatch mocks base method.
+func (m *MockStore) GetTransferBatch(ctx context.Context, id int64) (db.TransferBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferBatch", ctx, id)
+	ret0, _ := ret[0].(db.TransferBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferBatch indicates an expected call of GetTransferBatch.
+func (mr *MockStoreMockRecorder) GetTransferBatch(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferBatch", reflect.TypeOf((*MockStore)(nil).GetTransferBatch), ctx, id)
+}
+
+// GetTransferForUpdate mocks base method.
+func (m *MockStore) GetTransferForUpdate(ctx context.Context, id int64) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferForUpdate indicates an expected call of GetTransferForUpdate.
+func (mr *MockStoreMockRecorder) GetTransferForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferForUpdate", reflect.TypeOf((*MockStore)(nil).GetTransferForUpdate), ctx, id)
+}
+
+// GetTransferHold mocks base method.
+func (m *MockStore) GetTransferHold(ctx context.Context, id int64) (db.TransferHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferHold", ctx, id)
+	ret0, _ := ret[0].(db.TransferHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferHold indicates an expected call of GetTransferHold.
+func (mr *MockStoreMockRecorder) GetTransferHold(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferHold", reflect.TypeOf((*MockStore)(nil).GetTransferHold), ctx, id)
+}
+
+// GetTransferHoldForUpdate mocks base method.
+func (m *MockStore) GetTransferHoldForUpdate(ctx context.Context, id int64) (db.TransferHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferHoldForUpdate", ctx, id)
+	ret0, _ := ret[0].(db.TransferHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferHoldForUpdate indicates an expected call of GetTransferHoldForUpdate.
+func (mr *MockStoreMockRecorder) GetTransferHoldForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferHoldForUpdate", reflect.TypeOf((*MockStore)(nil).GetTransferHoldForUpdate), ctx, id)
+}
+
+// GetTransferReversalTotals mocks base method.
+func (m *MockStore) GetTransferReversalTotals(ctx context.Context, reversalOfTransferID pgtype.Int8) (db.GetTransferReversalTotalsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferReversalTotals", ctx, reversalOfTransferID)
+	ret0, _ := ret[0].(db.GetTransferReversalTotalsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferReversalTotals indicates an expected call of GetTransferReversalTotals.
+func (mr *MockStoreMockRecorder) GetTransferReversalTotals(ctx, reversalOfTransferID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferReversalTotals", reflect.TypeOf((*MockStore)(nil).GetTransferReversalTotals), ctx, reversalOfTransferID)
+}
+
+// GetTransferUsage mocks base method.
+func (m *MockStore) GetTransferUsage(ctx context.Context, arg db.GetTransferUsageParams) (db.GetTransferUsageRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferUsage", ctx, arg)
+	ret0, _ := ret[0].(db.GetTransferUsageRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferUsage indicates an expected call of GetTransferUsage.
+func (mr *MockStoreMockRecorder) GetTransferUsage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferUsage", reflect.TypeOf((*MockStore)(nil).GetTransferUsage), ctx, arg)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(ctx context.Context, username string) (db.GetUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, username)
+	ret0, _ := ret[0].(db.GetUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), ctx, username)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockStore) GetUserByEmail(ctx context.Context, email string) (db.GetUserByEmailRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(db.GetUserByEmailRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockStoreMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), ctx, email)
+}
+
+// GetUserHashedPassword mocks base method.
+func (m *MockStore) GetUserHashedPassword(ctx context.Context, username string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserHashedPassword", ctx, username)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserHashedPassword indicates an expected call of GetUserHashedPassword.
+func (mr *MockStoreMockRecorder) GetUserHashedPassword(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHashedPassword", reflect.TypeOf((*MockStore)(nil).GetUserHashedPassword), ctx, username)
+}
+
+// GetUserMfa mocks base method.
+func (m *MockStore) GetUserMfa(ctx context.Context, username string) (db.UserMfa, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserMfa", ctx, username)
+	ret0, _ := ret[0].(db.UserMfa)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserMfa indicates an expected call of GetUserMfa.
+func (mr *MockStoreMockRecorder) GetUserMfa(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserMfa", reflect.TypeOf((*MockStore)(nil).GetUserMfa), ctx, username)
+}
+
+// GetUserPasswordChangedAt mocks base method.
+func (m *MockStore) GetUserPasswordChangedAt(ctx context.Context, username string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPasswordChangedAt", ctx, username)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPasswordChangedAt indicates an expected call of GetUserPasswordChangedAt.
+func (mr *MockStoreMockRecorder) GetUserPasswordChangedAt(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPasswordChangedAt", reflect.TypeOf((*MockStore)(nil).GetUserPasswordChangedAt), ctx, username)
+}
+
+// GetUserTier mocks base method.
+func (m *MockStore) GetUserTier(ctx context.Context, username string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTier", ctx, username)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTier indicates an expected call of GetUserTier.
+func (mr *MockStoreMockRecorder) GetUserTier(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTier", reflect.TypeOf((*MockStore)(nil).GetUserTier), ctx, username)
+}
+
+// GetUserTransferLimit mocks base method.
+func (m *MockStore) GetUserTransferLimit(ctx context.Context, arg db.GetUserTransferLimitParams) (db.UserTransferLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTransferLimit", ctx, arg)
+	ret0, _ := ret[0].(db.UserTransferLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTransferLimit indicates an expected call of GetUserTransferLimit.
+func (mr *MockStoreMockRecorder) GetUserTransferLimit(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTransferLimit", reflect.TypeOf((*MockStore)(nil).GetUserTransferLimit), ctx, arg)
+}
+
+// ListAccountBalanceDrift mocks base method.
+func (m *MockStore) ListAccountBalanceDrift(ctx context.Context) ([]db.ListAccountBalanceDriftRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountBalanceDrift", ctx)
+	ret0, _ := ret[0].([]db.ListAccountBalanceDriftRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountBalanceDrift indicates an expected call of ListAccountBalanceDrift.
+func (mr *MockStoreMockRecorder) ListAccountBalanceDrift(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountBalanceDrift", reflect.TypeOf((*MockStore)(nil).ListAccountBalanceDrift), ctx)
+}
+
+// ListAccountStatementEntries mocks base method.
+func (m *MockStore) ListAccountStatementEntries(ctx context.Context, arg db.ListAccountStatementEntriesParams) ([]db.ListAccountStatementEntriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountStatementEntries", ctx, arg)
+	ret0, _ := ret[0].([]db.ListAccountStatementEntriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountStatementEntries indicates an expected call of ListAccountStatementEntries.
+func (mr *MockStoreMockRecorder) ListAccountStatementEntries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountStatementEntries", reflect.TypeOf((*MockStore)(nil).ListAccountStatementEntries), ctx, arg)
+}
+
+// ListAccounts mocks base method.
+func (m *MockStore) ListAccounts(ctx context.Context, arg db.ListAccountsParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", ctx, arg)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockStoreMockRecorder) ListAccounts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), ctx, arg)
+}
+
+// ListActiveFeeSchedules mocks base method.
+func (m *MockStore) ListActiveFeeSchedules(ctx context.Context) ([]db.FeeSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveFeeSchedules", ctx)
+	ret0, _ := ret[0].([]db.FeeSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveFeeSchedules indicates an expected call of ListActiveFeeSchedules.
+func (mr *MockStoreMockRecorder) ListActiveFeeSchedules(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveFeeSchedules", reflect.TypeOf((*MockStore)(nil).ListActiveFeeSchedules), ctx)
+}
+
+// ListAllAccounts mocks base method.
+func (m *MockStore) ListAllAccounts(ctx context.Context, arg db.ListAllAccountsParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllAccounts", ctx, arg)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllAccounts indicates an expected call of ListAllAccounts.
+func (mr *MockStoreMockRecorder) ListAllAccounts(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllAccounts", reflect.TypeOf((*MockStore)(nil).ListAllAccounts), ctx, arg)
+}
+
+// ListCurrencies mocks base method.
+func (m *MockStore) ListCurrencies(ctx context.Context) ([]db.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCurrencies", ctx)
+	ret0, _ := ret[0].([]db.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCurrencies indicates an expected call of ListCurrencies.
+func (mr *MockStoreMockRecorder) ListCurrencies(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCurrencies", reflect.TypeOf((*MockStore)(nil).ListCurrencies), ctx)
+}
+
+// ListEntries mocks base method.
+func (m *MockStore) ListEntries(ctx context.Context, arg db.ListEntriesParams) ([]db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", ctx, arg)
+	ret0, _ := ret[0].([]db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockStoreMockRecorder) ListEntries(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockStore)(nil).ListEntries), ctx, arg)
+}
+
+// ListExchangeRateHistory mocks base method.
+func (m *MockStore) ListExchangeRateHistory(ctx context.Context, arg db.ListExchangeRateHistoryParams) ([]db.ExchangeRateHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExchangeRateHistory", ctx, arg)
+	ret0, _ := ret[0].([]db.ExchangeRateHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExchangeRateHistory indicates an expected call of ListExchangeRateHistory.
+func (mr *MockStoreMockRecorder) ListExchangeRateHistory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExchangeRateHistory", reflect.TypeOf((*MockStore)(nil).ListExchangeRateHistory), ctx, arg)
+}
+
+// ListExchangeRates mocks base method.
+func (m *MockStore) ListExchangeRates(ctx context.Context) ([]db.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExchangeRates", ctx)
+	ret0, _ := ret[0].([]db.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExchangeRates indicates an expected call of ListExchangeRates.
+func (mr *MockStoreMockRecorder) ListExchangeRates(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExchangeRates", reflect.TypeOf((*MockStore)(nil).ListExchangeRates), ctx)
+}
+
+// ListExpiredTransferHolds mocks base method.
+func (m *MockStore) ListExpiredTransferHolds(ctx context.Context, limit int32) ([]db.TransferHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredTransferHolds", ctx, limit)
+	ret0, _ := ret[0].([]db.TransferHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredTransferHolds indicates an expected call of ListExpiredTransferHolds.
+func (mr *MockStoreMockRecorder) ListExpiredTransferHolds(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredTransferHolds", reflect.TypeOf((*MockStore)(nil).ListExpiredTransferHolds), ctx, limit)
+}
+
+// ListFeeScheduleTiers mocks base method.
+func (m *MockStore) ListFeeScheduleTiers(ctx context.Context, feeScheduleIds []int64) ([]db.FeeScheduleTier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFeeScheduleTiers", ctx, feeScheduleIds)
+	ret0, _ := ret[0].([]db.FeeScheduleTier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFeeScheduleTiers indicates an expected call of ListFeeScheduleTiers.
+func (mr *MockStoreMockRecorder) ListFeeScheduleTiers(ctx, feeScheduleIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeeScheduleTiers", reflect.TypeOf((*MockStore)(nil).ListFeeScheduleTiers), ctx, feeScheduleIds)
+}
+
+// ListFundingEntryMismatches mocks base method.
+func (m *MockStore) ListFundingEntryMismatches(ctx context.Context) ([]db.ListFundingEntryMismatchesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFundingEntryMismatches", ctx)
+	ret0, _ := ret[0].([]db.ListFundingEntryMismatchesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFundingEntryMismatches indicates an expected call of ListFundingEntryMismatches.
+func (mr *MockStoreMockRecorder) ListFundingEntryMismatches(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFundingEntryMismatches", reflect.TypeOf((*MockStore)(nil).ListFundingEntryMismatches), ctx)
+}
+
+// ListFxMarkups mocks base method.
+func (m *MockStore) ListFxMarkups(ctx context.Context) ([]db.FxMarkup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFxMarkups", ctx)
+	ret0, _ := ret[0].([]db.FxMarkup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFxMarkups indicates an expected call of ListFxMarkups.
+func (mr *MockStoreMockRecorder) ListFxMarkups(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFxMarkups", reflect.TypeOf((*MockStore)(nil).ListFxMarkups), ctx)
+}
+
+// ListScheduledTransfers mocks base method.
+func (m *MockStore) ListScheduledTransfers(ctx context.Context, owner string) ([]db.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduledTransfers", ctx, owner)
+	ret0, _ := ret[0].([]db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduledTransfers indicates an expected call of ListScheduledTransfers.
+func (mr *MockStoreMockRecorder) ListScheduledTransfers(ctx, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduledTransfers", reflect.TypeOf((*MockStore)(nil).ListScheduledTransfers), ctx, owner)
+}
+
+// ListTransferBatchItems mocks base method.
+func (m *MockStore) ListTransferBatchItems(ctx context.Context, batchID int64) ([]db.TransferBatchItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferBatchItems", ctx, batchID)
+	ret0, _ := ret[0].([]db.TransferBatchItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferBatchItems indicates an expected call of ListTransferBatchItems.
+func (mr *MockStoreMockRecorder) ListTransferBatchItems(ctx, batchID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferBatchItems", reflect.TypeOf((*MockStore)(nil).ListTransferBatchItems), ctx, batchID)
+}
+
+// ListTransferBatches mocks base method.
+func (m *MockStore) ListTransferBatches(ctx context.Context, owner string) ([]db.TransferBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferBatches", ctx, owner)
+	ret0, _ := ret[0].([]db.TransferBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferBatches indicates an expected call of ListTransferBatches.
+func (mr *MockStoreMockRecorder) ListTransferBatches(ctx, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferBatches", reflect.TypeOf((*MockStore)(nil).ListTransferBatches), ctx, owner)
+}
+
+// ListTransferEntryMismatches mocks base method.
+func (m *MockStore) ListTransferEntryMismatches(ctx context.Context) ([]db.ListTransferEntryMismatchesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferEntryMismatches", ctx)
+	ret0, _ := ret[0].([]db.ListTransferEntryMismatchesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferEntryMismatches indicates an expected call of ListTransferEntryMismatches.
+func (mr *MockStoreMockRecorder) ListTransferEntryMismatches(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferEntryMismatches", reflect.TypeOf((*MockStore)(nil).ListTransferEntryMismatches), ctx)
+}
+
+// ListTransferHolds mocks base method.
+func (m *MockStore) ListTransferHolds(ctx context.Context, owner string) ([]db.TransferHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferHolds", ctx, owner)
+	ret0, _ := ret[0].([]db.TransferHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferHolds indicates an expected call of ListTransferHolds.
+func (mr *MockStoreMockRecorder) ListTransferHolds(ctx, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferHolds", reflect.TypeOf((*MockStore)(nil).ListTransferHolds), ctx, owner)
+}
+
+// ListTransfers mocks base method.
+func (m *MockStore) ListTransfers(ctx context.Context, arg db.ListTransfersParams) ([]db.ListTransfersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransfers", ctx, arg)
+	ret0, _ := ret[0].([]db.ListTransfersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransfers indicates an expected call of ListTransfers.
+func (mr *MockStoreMockRecorder) ListTransfers(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), ctx, arg)
+}
+
+// ListUnbalancedTransfers mocks base method.
+func (m *MockStore) ListUnbalancedTransfers(ctx context.Context) ([]db.ListUnbalancedTransfersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnbalancedTransfers", ctx)
+	ret0, _ := ret[0].([]db.ListUnbalancedTransfersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnbalancedTransfers indicates an expected call of ListUnbalancedTransfers.
+func (mr *MockStoreMockRecorder) ListUnbalancedTransfers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnbalancedTransfers", reflect.TypeOf((*MockStore)(nil).ListUnbalancedTransfers), ctx)
+}
+
+// ListUserSessions mocks base method.
+func (m *MockStore) ListUserSessions(ctx context.Context, username string) ([]db.ListUserSessionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserSessions", ctx, username)
+	ret0, _ := ret[0].([]db.ListUserSessionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserSessions indicates an expected call of ListUserSessions.
+func (mr *MockStoreMockRecorder) ListUserSessions(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserSessions", reflect.TypeOf((*MockStore)(nil).ListUserSessions), ctx, username)
+}
+
+// ListUsers mocks base method.
+func (m *MockStore) ListUsers(ctx context.Context) ([]db.ListUsersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx)
+	ret0, _ := ret[0].([]db.ListUsersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockStoreMockRecorder) ListUsers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), ctx)
+}
+
+// LockFeeSchedulePair mocks base method.
+func (m *MockStore) LockFeeSchedulePair(ctx context.Context, arg db.LockFeeSchedulePairParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockFeeSchedulePair", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockFeeSchedulePair indicates an expected call of LockFeeSchedulePair.
+func (mr *MockStoreMockRecorder) LockFeeSchedulePair(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockFeeSchedulePair", reflect.TypeOf((*MockStore)(nil).LockFeeSchedulePair), ctx, arg)
+}
+
+// ReconcileTx mocks base method.
+func (m *MockStore) ReconcileTx(ctx context.Context) (db.ReconcileTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileTx", ctx)
+	ret0, _ := ret[0].(db.ReconcileTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileTx indicates an expected call of ReconcileTx.
+func (mr *MockStoreMockRecorder) ReconcileTx(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileTx", reflect.TypeOf((*MockStore)(nil).ReconcileTx), ctx)
+}
+
+// RecordScheduledTransferRun mocks base method.
+func (m *MockStore) RecordScheduledTransferRun(ctx context.Context, arg db.RecordScheduledTransferRunParams) (db.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordScheduledTransferRun", ctx, arg)
+	ret0, _ := ret[0].(db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordScheduledTransferRun indicates an expected call of RecordScheduledTransferRun.
+func (mr *MockStoreMockRecorder) RecordScheduledTransferRun(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordScheduledTransferRun", reflect.TypeOf((*MockStore)(nil).RecordScheduledTransferRun), ctx, arg)
+}
+
+// RefreshExchangeRatesTx mocks base method.
+func (m *MockStore) RefreshExchangeRatesTx(ctx context.Context, arg []db.UpsertExchangeRateParams) ([]db.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshExchangeRatesTx", ctx, arg)
+	ret0, _ := ret[0].([]db.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshExchangeRatesTx indicates an expected call of RefreshExchangeRatesTx.
+func (mr *MockStoreMockRecorder) RefreshExchangeRatesTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshExchangeRatesTx", reflect.TypeOf((*MockStore)(nil).RefreshExchangeRatesTx), ctx, arg)
+}
+
+// ResetMfaAttempts mocks base method.
+func (m *MockStore) ResetMfaAttempts(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetMfaAttempts", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetMfaAttempts indicates an expected call of ResetMfaAttempts.
+func (mr *MockStoreMockRecorder) ResetMfaAttempts(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetMfaAttempts", reflect.TypeOf((*MockStore)(nil).ResetMfaAttempts), ctx, username)
+}
+
+// ReverseTransferTx mocks base method.
+func (m *MockStore) ReverseTransferTx(ctx context.Context, arg db.ReverseTransferTxParams) (db.ReverseTransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReverseTransferTx", ctx, arg)
+	ret0, _ := ret[0].(db.ReverseTransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReverseTransferTx indicates an expected call of ReverseTransferTx.
+func (mr *MockStoreMockRecorder) ReverseTransferTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReverseTransferTx", reflect.TypeOf((*MockStore)(nil).ReverseTransferTx), ctx, arg)
+}
+
+// RevokeUserTokens mocks base method.
+func (m *MockStore) RevokeUserTokens(ctx context.Context, arg db.RevokeUserTokensParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserTokens", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserTokens indicates an expected call of RevokeUserTokens.
+func (mr *MockStoreMockRecorder) RevokeUserTokens(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserTokens", reflect.TypeOf((*MockStore)(nil).RevokeUserTokens), ctx, arg)
+}
+
+// RotateSessionTx mocks base method.
+func (m *MockStore) RotateSessionTx(ctx context.Context, arg db.RotateSessionTxParams) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSessionTx", ctx, arg)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSessionTx indicates an expected call of RotateSessionTx.
+func (mr *MockStoreMockRecorder) RotateSessionTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSessionTx", reflect.TypeOf((*MockStore)(nil).RotateSessionTx), ctx, arg)
+}
+
+// SetFeeScheduleTx mocks base method.
+func (m *MockStore) SetFeeScheduleTx(ctx context.Context, arg db.SetFeeScheduleTxParams) (db.FeeScheduleTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFeeScheduleTx", ctx, arg)
+	ret0, _ := ret[0].(db.FeeScheduleTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetFeeScheduleTx indicates an expected call of SetFeeScheduleTx.
+func (mr *MockStoreMockRecorder) SetFeeScheduleTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeeScheduleTx", reflect.TypeOf((*MockStore)(nil).SetFeeScheduleTx), ctx, arg)
+}
+
+// SumAccountEntriesSince mocks base method.
+func (m *MockStore) SumAccountEntriesSince(ctx context.Context, arg db.SumAccountEntriesSinceParams) (pgtype.Numeric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumAccountEntriesSince", ctx, arg)
+	ret0, _ := ret[0].(pgtype.Numeric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumAccountEntriesSince indicates an expected call of SumAccountEntriesSince.
+func (mr *MockStoreMockRecorder) SumAccountEntriesSince(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumAccountEntriesSince", reflect.TypeOf((*MockStore)(nil).SumAccountEntriesSince), ctx, arg)
+}
+
+// TransferTx mocks base method.
+func (m *MockStore) TransferTx(ctx context.Context, arg db.TransferTxParams) (db.TransferTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferTx", ctx, arg)
+	ret0, _ := ret[0].(db.TransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferTx indicates an expected call of TransferTx.
+func (mr *MockStoreMockRecorder) TransferTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferTx", reflect.TypeOf((*MockStore)(nil).TransferTx), ctx, arg)
+}
+
+// UpdateAccount mocks base method.
+func (m *MockStore) UpdateAccount(ctx context.Context, arg db.UpdateAccountParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccount", ctx, arg)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccount indicates an expected call of UpdateAccount.
+func (mr *MockStoreMockRecorder) UpdateAccount(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockStore)(nil).UpdateAccount), ctx, arg)
+}
+
+// UpdateCurrency mocks base method.
+func (m *MockStore) UpdateCurrency(ctx context.Context, arg db.UpdateCurrencyParams) (db.Currency, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCurrency", ctx, arg)
+	ret0, _ := ret[0].(db.Currency)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCurrency indicates an expected call of UpdateCurrency.
+func (mr *MockStoreMockRecorder) UpdateCurrency(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCurrency", reflect.TypeOf((*MockStore)(nil).UpdateCurrency), ctx, arg)
+}
+
+// UpdateExchangeRate mocks base method.
+func (m *MockStore) UpdateExchangeRate(ctx context.Context, arg db.UpdateExchangeRateParams) (db.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExchangeRate", ctx, arg)
+	ret0, _ := ret[0].(db.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateExchangeRate indicates an expected call of UpdateExchangeRate.
+func (mr *MockStoreMockRecorder) UpdateExchangeRate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExchangeRate", reflect.TypeOf((*MockStore)(nil).UpdateExchangeRate), ctx, arg)
+}
+
+// UpdateExchangeRateTx mocks base method.
+func (m *MockStore) UpdateExchangeRateTx(ctx context.Context, arg db.ExchangeRatePairTxParams) (db.ExchangeRatePairTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateExchangeRateTx", ctx, arg)
+	ret0, _ := ret[0].(db.ExchangeRatePairTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateExchangeRateTx indicates an expected call of UpdateExchangeRateTx.
+func (mr *MockStoreMockRecorder) UpdateExchangeRateTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExchangeRateTx", reflect.TypeOf((*MockStore)(nil).UpdateExchangeRateTx), ctx, arg)
+}
+
+// UpdateScheduledTransfer mocks base method.
+func (m *MockStore) UpdateScheduledTransfer(ctx context.Context, arg db.UpdateScheduledTransferParams) (db.ScheduledTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduledTransfer", ctx, arg)
+	ret0, _ := ret[0].(db.ScheduledTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateScheduledTransfer indicates an expected call of UpdateScheduledTransfer.
+func (mr *MockStoreMockRecorder) UpdateScheduledTransfer(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledTransfer", reflect.TypeOf((*MockStore)(nil).UpdateScheduledTransfer), ctx, arg)
+}
+
+// UpdateTransferHoldStatus mocks base method.
+func (m *MockStore) UpdateTransferHoldStatus(ctx context.Context, arg db.UpdateTransferHoldStatusParams) (db.TransferHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransferHoldStatus", ctx, arg)
+	ret0, _ := ret[0].(db.TransferHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransferHoldStatus indicates an expected call of UpdateTransferHoldStatus.
+func (mr *MockStoreMockRecorder) UpdateTransferHoldStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransferHoldStatus", reflect.TypeOf((*MockStore)(nil).UpdateTransferHoldStatus), ctx, arg)
+}
+
+// UpdateTransferStatus mocks base method.
+func (m *MockStore) UpdateTransferStatus(ctx context.Context, arg db.UpdateTransferStatusParams) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransferStatus", ctx, arg)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransferStatus indicates an expected call of UpdateTransferStatus.
+func (mr *MockStoreMockRecorder) UpdateTransferStatus(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransferStatus", reflect.TypeOf((*MockStore)(nil).UpdateTransferStatus), ctx, arg)
+}
+
+// UpdateUser mocks base method.
+func (m *MockStore) UpdateUser(ctx context.Context, arg db.UpdateUserParams) (db.UpdateUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, arg)
+	ret0, _ := ret[0].(db.UpdateUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockStoreMockRecorder) UpdateUser(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), ctx, arg)
+}
+
+// UpsertExchangeRate mocks base method.
+func (m *MockStore) UpsertExchangeRate(ctx context.Context, arg db.UpsertExchangeRateParams) (db.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertExchangeRate", ctx, arg)
+	ret0, _ := ret[0].(db.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertExchangeRate indicates an expected call of UpsertExchangeRate.
+func (mr *MockStoreMockRecorder) UpsertExchangeRate(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertExchangeRate", reflect.TypeOf((*MockStore)(nil).UpsertExchangeRate), ctx, arg)
+}
+
+// UpsertFxMarkup mocks base method.
+func (m *MockStore) UpsertFxMarkup(ctx context.Context, arg db.UpsertFxMarkupParams) (db.FxMarkup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertFxMarkup", ctx, arg)
+	ret0, _ := ret[0].(db.FxMarkup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertFxMarkup indicates an expected call of UpsertFxMarkup.
+func (mr *MockStoreMockRecorder) UpsertFxMarkup(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertFxMarkup", reflect.TypeOf((*MockStore)(nil).UpsertFxMarkup), ctx, arg)
+}
+
+// UpsertUserTransferLimit mocks base method.
+func (m *MockStore) UpsertUserTransferLimit(ctx context.Context, arg db.UpsertUserTransferLimitParams) (db.UserTransferLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserTransferLimit", ctx, arg)
+	ret0, _ := ret[0].(db.UserTransferLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertUserTransferLimit indicates an expected call of UpsertUserTransferLimit.
+func (mr *MockStoreMockRecorder) UpsertUserTransferLimit(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserTransferLimit", reflect.TypeOf((*MockStore)(nil).UpsertUserTransferLimit), ctx, arg)
+}
+
+// UseMfaChallenge mocks base method.
+func (m *MockStore) UseMfaChallenge(ctx context.Context, id uuid.UUID) (db.MfaChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseMfaChallenge", ctx, id)
+	ret0, _ := ret[0].(db.MfaChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseMfaChallenge indicates an expected call of UseMfaChallenge.
+func (mr *MockStoreMockRecorder) UseMfaChallenge(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseMfaChallenge", reflect.TypeOf((*MockStore)(nil).UseMfaChallenge), ctx, id)
+}
+
+// UseMfaRecoveryCode mocks base method.
+func (m *MockStore) UseMfaRecoveryCode(ctx context.Context, arg db.UseMfaRecoveryCodeParams) (db.MfaRecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseMfaRecoveryCode", ctx, arg)
+	ret0, _ := ret[0].(db.MfaRecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseMfaRecoveryCode indicates an expected call of UseMfaRecoveryCode.
+func (mr *MockStoreMockRecorder) UseMfaRecoveryCode(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseMfaRecoveryCode", reflect.TypeOf((*MockStore)(nil).UseMfaRecoveryCode), ctx, arg)
+}
+
+// UseTotpStep mocks base method.
+func (m *MockStore) UseTotpStep(ctx context.Context, arg db.UseTotpStepParams) (db.UserMfa, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTotpStep", ctx, arg)
+	ret0, _ := ret[0].(db.UserMfa)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTotpStep indicates an expected call of UseTotpStep.
+func (mr *MockStoreMockRecorder) UseTotpStep(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTotpStep", reflect.TypeOf((*MockStore)(nil).UseTotpStep), ctx, arg)
+}
+
+// UseUserToken mocks base method.
+func (m *MockStore) UseUserToken(ctx context.Context, arg db.UseUserTokenParams) (db.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseUserToken", ctx, arg)
+	ret0, _ := ret[0].(db.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseUserToken indicates an expected call of UseUserToken.
+func (mr *MockStoreMockRecorder) UseUserToken(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseUserToken", reflect.TypeOf((*MockStore)(nil).UseUserToken), ctx, arg)
+}
+
+// VerifyUserEmail mocks base method.
+func (m *MockStore) VerifyUserEmail(ctx context.Context, tokenHash []byte) (db.VerifyUserEmailRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyUserEmail", ctx, tokenHash)
+	ret0, _ := ret[0].(db.VerifyUserEmailRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyUserEmail indicates an expected call of VerifyUserEmail.
+func (mr *MockStoreMockRecorder) VerifyUserEmail(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUserEmail", reflect.TypeOf((*MockStore)(nil).VerifyUserEmail), ctx, tokenHash)
+}
+
+// VoidTransferHoldTx mocks base method.
+func (m *MockStore) VoidTransferHoldTx(ctx context.Context, holdID int64) (db.TransferHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidTransferHoldTx", ctx, holdID)
+	ret0, _ := ret[0].(db.TransferHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoidTransferHoldTx indicates an expected call of VoidTransferHoldTx.
+func (mr *MockStoreMockRecorder) VoidTransferHoldTx(ctx, holdID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidTransferHoldTx", reflect.TypeOf((*MockStore)(nil).VoidTransferHoldTx), ctx, holdID)
+}
+
+// WithdrawTx mocks base method.
+func (m *MockStore) WithdrawTx(ctx context.Context, arg db.FundingTxParams) (db.FundingTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithdrawTx", ctx, arg)
+	ret0, _ := ret[0].(db.FundingTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WithdrawTx indicates an expected call of WithdrawTx.
+func (mr *MockStoreMockRecorder) WithdrawTx(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawTx", reflect.TypeOf((*MockStore)(nil).WithdrawTx), ctx, arg)
 }
@@ -5,4 +5,4 @@ INSERT INTO accounts (
   currency
 ) VALUES (
   $1, $2, $3
-) RETURNING id, owner, balance, currency, created_at; 
\ No newline at end of file
+) RETURNING id, owner, balance, currency, created_at, held_amount; 
\ No newline at end of file
@@ -1,3 +1,3 @@
 -- name: GetAccount :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, held_amount FROM accounts
 WHERE id = $1 LIMIT 1; 
\ No newline at end of file
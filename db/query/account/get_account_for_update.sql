@@ -1,4 +1,4 @@
 -- name: GetAccountForUpdate :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, held_amount FROM accounts
 WHERE id = $1 LIMIT 1
 FOR NO KEY UPDATE; 
\ No newline at end of file
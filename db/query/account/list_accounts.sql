@@ -1,5 +1,5 @@
 -- name: ListAccounts :many
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, held_amount FROM accounts
 WHERE owner = $1
 ORDER BY id
 LIMIT $2
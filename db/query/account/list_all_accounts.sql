@@ -1,5 +1,5 @@
 -- name: ListAllAccounts :many
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, held_amount FROM accounts
 ORDER BY id
 LIMIT $1
 OFFSET $2; 
\ No newline at end of file
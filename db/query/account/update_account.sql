@@ -2,4 +2,4 @@
 UPDATE accounts
 SET balance = $2
 WHERE id = $1
-RETURNING id, owner, balance, currency, created_at; 
\ No newline at end of file
+RETURNING id, owner, balance, currency, created_at, held_amount; 
\ No newline at end of file
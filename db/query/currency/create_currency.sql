@@ -0,0 +1,4 @@
+-- name: CreateCurrency :one
+INSERT INTO currencies (code, minor_units, symbol, enabled)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- name: GetCurrency :one
+SELECT * FROM currencies
+WHERE code = $1 LIMIT 1;
\ No newline at end of file
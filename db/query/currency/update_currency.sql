@@ -0,0 +1,8 @@
+-- name: UpdateCurrency :one
+UPDATE currencies
+SET
+  symbol = COALESCE(sqlc.narg(symbol), symbol),
+  enabled = COALESCE(sqlc.narg(enabled), enabled)
+WHERE
+  code = sqlc.arg(code)
+RETURNING *;
\ No newline at end of file
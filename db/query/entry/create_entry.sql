@@ -1,7 +1,8 @@
 -- name: CreateEntry :one
 INSERT INTO entries (
   account_id,
-  amount
+  amount,
+  transfer_id
 ) VALUES (
-  $1, $2
-) RETURNING id, account_id, amount, created_at; 
\ No newline at end of file
+  $1, $2, $3
+) RETURNING id, account_id, amount, created_at, transfer_id;
\ No newline at end of file
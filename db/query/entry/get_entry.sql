@@ -1,3 +1,3 @@
 -- name: GetEntry :one
-SELECT id, account_id, amount, created_at FROM entries
-WHERE id = $1 LIMIT 1; 
\ No newline at end of file
+SELECT id, account_id, amount, created_at, transfer_id FROM entries
+WHERE id = $1 LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,29 @@
+-- name: ListAccountStatementEntries :many
+SELECT
+  e.id,
+  e.account_id,
+  e.amount,
+  e.transfer_id,
+  e.created_at,
+  t.from_account_id,
+  t.to_account_id,
+  t.from_currency,
+  t.to_currency,
+  ca.id AS counterparty_account_id,
+  ca.owner AS counterparty_owner,
+  ca.currency AS counterparty_currency,
+  f.id AS funding_transaction_id,
+  f.kind AS funding_kind,
+  f.external_reference AS funding_external_reference,
+  f.funding_source
+FROM entries e
+LEFT JOIN transfers t ON t.id = e.transfer_id
+LEFT JOIN funding_transactions f ON f.entry_id = e.id
+LEFT JOIN accounts ca ON ca.id = CASE
+  WHEN t.from_account_id = e.account_id THEN t.to_account_id
+  ELSE t.from_account_id
+END
+WHERE e.account_id = sqlc.arg(account_id)
+AND e.created_at >= sqlc.arg(start_date)::timestamptz
+AND e.created_at < sqlc.arg(end_date)::timestamptz
+ORDER BY e.created_at, e.id;
\ No newline at end of file
@@ -1,6 +1,6 @@
 -- name: ListEntries :many
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, transfer_id FROM entries
 WHERE account_id = $1
 ORDER BY id
 LIMIT $2
-OFFSET $3; 
\ No newline at end of file
+OFFSET $3;
\ No newline at end of file
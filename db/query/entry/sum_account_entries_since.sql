@@ -0,0 +1,4 @@
+-- name: SumAccountEntriesSince :one
+SELECT COALESCE(SUM(amount), 0)::decimal AS total FROM entries
+WHERE account_id = $1
+AND created_at >= $2;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- name: DeleteExchangeRate :execrows
+DELETE FROM exchange_rates
+WHERE from_currency = $1 AND to_currency = $2;
\ No newline at end of file
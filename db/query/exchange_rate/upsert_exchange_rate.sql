@@ -0,0 +1,6 @@
+-- name: UpsertExchangeRate :one
+INSERT INTO exchange_rates (from_currency, to_currency, rate)
+VALUES ($1, $2, $3)
+ON CONFLICT (from_currency, to_currency) DO UPDATE
+SET rate = EXCLUDED.rate, created_at = NOW()
+RETURNING id, from_currency, to_currency, rate, created_at, updated_at;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- name: GetExchangeRateAsOf :one
+SELECT * FROM exchange_rate_history
+WHERE from_currency = sqlc.arg(from_currency)
+  AND to_currency = sqlc.arg(to_currency)
+  AND valid_from <= sqlc.arg(as_of)
+ORDER BY valid_from DESC, id DESC
+LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,8 @@
+-- name: ListExchangeRateHistory :many
+SELECT * FROM exchange_rate_history
+WHERE from_currency = sqlc.arg(from_currency)
+  AND to_currency = sqlc.arg(to_currency)
+  AND (sqlc.narg(start_date)::timestamptz IS NULL OR valid_from >= sqlc.narg(start_date)::timestamptz)
+  AND (sqlc.narg(end_date)::timestamptz IS NULL OR valid_from < sqlc.narg(end_date)::timestamptz)
+ORDER BY valid_from DESC, id DESC
+LIMIT sqlc.arg(page_limit)::int;
\ No newline at end of file
@@ -0,0 +1,18 @@
+-- name: CreateFeeSchedule :one
+INSERT INTO fee_schedules (
+  from_currency,
+  to_currency,
+  version,
+  fixed_fee,
+  percentage,
+  min_fee,
+  max_fee
+) VALUES (
+  sqlc.arg(from_currency),
+  sqlc.arg(to_currency),
+  (SELECT COALESCE(MAX(version), 0) + 1 FROM fee_schedules WHERE from_currency = sqlc.arg(from_currency) AND to_currency = sqlc.arg(to_currency)),
+  sqlc.arg(fixed_fee),
+  sqlc.arg(percentage),
+  sqlc.arg(min_fee),
+  sqlc.arg(max_fee)
+) RETURNING *;
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- name: CreateFeeScheduleTier :one
+INSERT INTO fee_schedule_tiers (
+  fee_schedule_id,
+  min_amount,
+  fixed_fee,
+  percentage
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
\ No newline at end of file
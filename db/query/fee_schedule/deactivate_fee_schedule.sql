@@ -0,0 +1,5 @@
+-- name: DeactivateFeeSchedule :one
+UPDATE fee_schedules
+SET active = false
+WHERE from_currency = $1 AND to_currency = $2 AND active
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: GetActiveFeeSchedule :one
+SELECT * FROM fee_schedules
+WHERE from_currency = $1 AND to_currency = $2 AND active
+LIMIT 1;
\ No newline at end of file
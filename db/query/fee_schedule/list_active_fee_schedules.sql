@@ -0,0 +1,4 @@
+-- name: ListActiveFeeSchedules :many
+SELECT * FROM fee_schedules
+WHERE active
+ORDER BY from_currency, to_currency;
\ No newline at end of file
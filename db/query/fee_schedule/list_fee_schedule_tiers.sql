@@ -0,0 +1,4 @@
+-- name: ListFeeScheduleTiers :many
+SELECT * FROM fee_schedule_tiers
+WHERE fee_schedule_id = ANY(sqlc.arg(fee_schedule_ids)::bigint[])
+ORDER BY fee_schedule_id, min_amount;
\ No newline at end of file
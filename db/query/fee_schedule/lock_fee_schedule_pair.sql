@@ -0,0 +1,2 @@
+-- name: LockFeeSchedulePair :exec
+SELECT pg_advisory_xact_lock(hashtext('fee_schedules:' || sqlc.arg(from_currency)::text || ':' || sqlc.arg(to_currency)::text));
\ No newline at end of file
@@ -0,0 +1,16 @@
+-- name: CreateFundingTransaction :one
+INSERT INTO funding_transactions (
+  account_id,
+  kind,
+  amount,
+  currency,
+  balance_after,
+  external_reference,
+  funding_source,
+  entry_id,
+  house_entry_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+ON CONFLICT (funding_source, external_reference) DO NOTHING
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: GetFundingTransactionByReference :one
+SELECT * FROM funding_transactions
+WHERE funding_source = $1 AND external_reference = $2
+LIMIT 1;
\ No newline at end of file
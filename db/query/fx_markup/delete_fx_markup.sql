@@ -0,0 +1,3 @@
+-- name: DeleteFxMarkup :execrows
+DELETE FROM fx_markups
+WHERE from_currency = $1 AND to_currency = $2;
\ No newline at end of file
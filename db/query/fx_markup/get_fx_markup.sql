@@ -0,0 +1,4 @@
+-- name: GetFxMarkup :one
+SELECT * FROM fx_markups
+WHERE from_currency = $1 AND to_currency = $2
+LIMIT 1;
\ No newline at end of file
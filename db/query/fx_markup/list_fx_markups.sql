@@ -0,0 +1,3 @@
+-- name: ListFxMarkups :many
+SELECT * FROM fx_markups
+ORDER BY from_currency, to_currency;
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- name: UpsertFxMarkup :one
+INSERT INTO fx_markups (from_currency, to_currency, markup_bps)
+VALUES ($1, $2, $3)
+ON CONFLICT (from_currency, to_currency) DO UPDATE
+SET markup_bps = EXCLUDED.markup_bps, updated_at = NOW()
+RETURNING *;
\ No newline at end of file
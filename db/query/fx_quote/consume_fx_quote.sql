@@ -0,0 +1,8 @@
+-- name: ConsumeFxQuote :one
+UPDATE fx_quotes
+SET consumed_at = now(), transfer_id = sqlc.arg(transfer_id)
+WHERE id = sqlc.arg(id)
+AND username = sqlc.arg(username)
+AND consumed_at IS NULL
+AND expires_at > now()
+RETURNING *;
\ No newline at end of file
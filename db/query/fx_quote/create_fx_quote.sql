@@ -0,0 +1,19 @@
+-- name: CreateFxQuote :one
+INSERT INTO fx_quotes (
+  username,
+  from_currency,
+  to_currency,
+  rate,
+  amount,
+  converted_amount,
+  fee,
+  expires_at,
+  pivot_currency,
+  from_pivot_rate,
+  pivot_to_rate,
+  fee_schedule_id,
+  mid_market_rate,
+  markup_bps
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+) RETURNING *;
\ No newline at end of file
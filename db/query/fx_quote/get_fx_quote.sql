@@ -0,0 +1,3 @@
+-- name: GetFxQuote :one
+SELECT * FROM fx_quotes
+WHERE id = $1 LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: CreateHouseAccount :one
+INSERT INTO house_accounts (currency, purpose, account_id)
+VALUES ($1, $2, $3)
+RETURNING currency, purpose, account_id, created_at;
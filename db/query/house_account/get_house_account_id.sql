@@ -0,0 +1,4 @@
+-- name: GetHouseAccountID :one
+SELECT account_id FROM house_accounts
+WHERE currency = $1 AND purpose = $2
+LIMIT 1;
\ No newline at end of file
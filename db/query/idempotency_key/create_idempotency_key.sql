@@ -0,0 +1,13 @@
+-- name: CreateIdempotencyKey :one
+INSERT INTO idempotency_keys (
+  username,
+  idempotency_key,
+  request_hash,
+  transfer_id,
+  response_status,
+  response_body
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (username, idempotency_key) DO NOTHING
+RETURNING *;
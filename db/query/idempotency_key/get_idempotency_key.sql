@@ -0,0 +1,4 @@
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE username = $1 AND idempotency_key = $2
+LIMIT 1;
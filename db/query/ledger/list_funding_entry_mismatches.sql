@@ -0,0 +1,16 @@
+-- name: ListFundingEntryMismatches :many
+SELECT
+  f.id,
+  f.account_id,
+  f.kind,
+  f.amount,
+  ce.account_id AS entry_account_id,
+  ce.amount AS entry_amount,
+  he.amount AS house_entry_amount
+FROM funding_transactions f
+JOIN entries ce ON ce.id = f.entry_id
+JOIN entries he ON he.id = f.house_entry_id
+WHERE ce.account_id <> f.account_id
+OR ce.amount <> CASE WHEN f.kind = 'deposit' THEN f.amount ELSE -f.amount END
+OR he.amount <> -ce.amount
+ORDER BY f.id;
\ No newline at end of file
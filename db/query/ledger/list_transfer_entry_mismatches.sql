@@ -0,0 +1,17 @@
+-- name: ListTransferEntryMismatches :many
+SELECT
+  t.id,
+  t.from_account_id,
+  t.to_account_id,
+  t.amount,
+  t.converted_amount,
+  t.fee,
+  t.refunded_fee,
+  COALESCE(SUM(e.amount) FILTER (WHERE e.account_id = t.from_account_id), 0)::decimal AS debited,
+  COALESCE(SUM(e.amount) FILTER (WHERE e.account_id = t.to_account_id), 0)::decimal AS credited
+FROM transfers t
+LEFT JOIN entries e ON e.transfer_id = t.id
+GROUP BY t.id
+HAVING COALESCE(SUM(e.amount) FILTER (WHERE e.account_id = t.from_account_id), 0) <> -(t.amount + COALESCE(t.fee, 0))
+OR COALESCE(SUM(e.amount) FILTER (WHERE e.account_id = t.to_account_id), 0) <> COALESCE(t.converted_amount, t.amount) + t.refunded_fee
+ORDER BY t.id;
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- name: ListUnbalancedTransfers :many
+SELECT
+  e.transfer_id,
+  a.currency,
+  SUM(e.amount)::decimal AS total
+FROM entries e
+JOIN accounts a ON a.id = e.account_id
+WHERE e.transfer_id IS NOT NULL
+GROUP BY e.transfer_id, a.currency
+HAVING SUM(e.amount) <> 0
+ORDER BY e.transfer_id, a.currency;
\ No newline at end of file
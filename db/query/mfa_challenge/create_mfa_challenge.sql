@@ -0,0 +1,8 @@
+-- name: CreateMfaChallenge :one
+INSERT INTO mfa_challenges (
+  id,
+  username,
+  expires_at
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
@@ -0,0 +1,5 @@
+-- name: UseMfaChallenge :one
+DELETE FROM mfa_challenges
+WHERE id = $1
+  AND expires_at > now()
+RETURNING *;
@@ -0,0 +1,13 @@
+-- name: ClaimDueScheduledTransfers :many
+UPDATE scheduled_transfers
+SET claimed_until = sqlc.arg(claimed_until)::timestamptz
+WHERE id IN (
+  SELECT id FROM scheduled_transfers
+  WHERE status = 'active'
+  AND next_run_at <= now()
+  AND (claimed_until IS NULL OR claimed_until < now())
+  ORDER BY next_run_at, id
+  LIMIT sqlc.arg(batch_size)::int
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
\ No newline at end of file
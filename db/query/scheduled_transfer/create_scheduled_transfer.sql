@@ -0,0 +1,15 @@
+-- name: CreateScheduledTransfer :one
+INSERT INTO scheduled_transfers (
+  owner,
+  from_account_id,
+  to_account_id,
+  amount,
+  from_currency,
+  to_currency,
+  frequency,
+  day_of_month,
+  occurrence_at,
+  next_run_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $9
+) RETURNING *;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- name: GetScheduledTransfer :one
+SELECT * FROM scheduled_transfers
+WHERE id = $1 LIMIT 1;
\ No newline at end of file
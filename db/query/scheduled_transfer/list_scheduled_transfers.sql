@@ -0,0 +1,4 @@
+-- name: ListScheduledTransfers :many
+SELECT * FROM scheduled_transfers
+WHERE owner = $1
+ORDER BY id DESC;
\ No newline at end of file
@@ -0,0 +1,15 @@
+-- name: RecordScheduledTransferRun :one
+UPDATE scheduled_transfers
+SET
+  -- A schedule paused or cancelled while the run was in flight keeps its status
+  status = CASE WHEN status = 'active' THEN sqlc.arg(status) ELSE status END,
+  occurrence_at = sqlc.arg(occurrence_at),
+  next_run_at = sqlc.arg(next_run_at),
+  attempts = sqlc.arg(attempts),
+  last_run_at = now(),
+  last_error = sqlc.narg(last_error),
+  last_transfer_id = COALESCE(sqlc.narg(last_transfer_id), last_transfer_id),
+  claimed_until = NULL,
+  updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- name: UpdateScheduledTransfer :one
+UPDATE scheduled_transfers
+SET
+  amount = $2,
+  frequency = $3,
+  day_of_month = $4,
+  occurrence_at = $5,
+  next_run_at = $5,
+  status = $6,
+  attempts = $7,
+  updated_at = now()
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
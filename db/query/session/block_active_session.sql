@@ -0,0 +1,5 @@
+-- name: BlockActiveSession :one
+UPDATE sessions
+SET is_blocked = true, blocked_reason = 'rotated', updated_at = now()
+WHERE id = $1 AND is_blocked = false
+RETURNING *;
\ No newline at end of file
@@ -1 +1,4 @@
- 
\ No newline at end of file
+-- name: BlockSession :exec
+UPDATE sessions 
+SET is_blocked = true, blocked_reason = 'signed_out', updated_at = now()
+WHERE id = $1;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: BlockSessionFamily :exec
+UPDATE sessions
+SET is_blocked = true, blocked_reason = 'revoked', updated_at = now()
+WHERE family_id = $1 AND is_blocked = false;
\ No newline at end of file
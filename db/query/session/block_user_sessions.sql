@@ -0,0 +1,4 @@
+-- name: BlockUserSessions :exec
+UPDATE sessions
+SET is_blocked = true, blocked_reason = 'signed_out', updated_at = now()
+WHERE username = $1 AND is_blocked = false;
\ No newline at end of file
@@ -6,7 +6,8 @@ INSERT INTO sessions (
   user_agent,
   client_ip,
   is_blocked,
-  expires_at
+  expires_at,
+  family_id
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7
-) RETURNING *; 
\ No newline at end of file
+  $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
\ No newline at end of file
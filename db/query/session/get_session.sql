@@ -1,4 +1,4 @@
 -- name: GetSession :one
-SELECT id, username, client_ip, user_agent, is_blocked, expires_at, created_at
+SELECT id, username, client_ip, user_agent, is_blocked, expires_at, created_at, family_id, blocked_reason
 FROM sessions
-WHERE id = $1 LIMIT 1; 
\ No newline at end of file
+WHERE id = $1 LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- name: ListUserSessions :many
+SELECT id, username, user_agent, client_ip, is_blocked, expires_at, created_at
+FROM sessions
+WHERE username = $1
+  AND is_blocked = false
+  AND expires_at > now()
+ORDER BY created_at DESC;
\ No newline at end of file
@@ -7,7 +7,15 @@ INSERT INTO transfers (
   exchange_rate,
   from_currency,
   to_currency,
-  fee
+  fee,
+  pivot_currency,
+  from_pivot_rate,
+  pivot_to_rate,
+  fee_schedule_id,
+  mid_market_rate,
+  markup_bps,
+  reversal_of_transfer_id,
+  refunded_fee
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8
-) RETURNING id, from_account_id, to_account_id, amount, converted_amount, exchange_rate, from_currency, to_currency, fee, created_at; 
\ No newline at end of file
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+) RETURNING id, from_account_id, to_account_id, amount, converted_amount, exchange_rate, from_currency, to_currency, fee, created_at, pivot_currency, from_pivot_rate, pivot_to_rate, fee_schedule_id, mid_market_rate, markup_bps, status, reversal_of_transfer_id, refunded_fee; 
\ No newline at end of file
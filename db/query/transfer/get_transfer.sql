@@ -1,3 +1,3 @@
 -- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
-WHERE id = $1 LIMIT 1; 
\ No newline at end of file
+SELECT id, from_account_id, to_account_id, amount, converted_amount, from_currency, to_currency, status, reversal_of_transfer_id, created_at FROM transfers
+WHERE id = $1 LIMIT 1;
\ No newline at end of file
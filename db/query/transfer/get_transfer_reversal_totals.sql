@@ -0,0 +1,7 @@
+-- name: GetTransferReversalTotals :one
+SELECT
+  COALESCE(SUM(amount), 0)::decimal AS reversed_amount,
+  COALESCE(SUM(converted_amount), 0)::decimal AS returned_amount,
+  COALESCE(SUM(refunded_fee), 0)::decimal AS refunded_fee
+FROM transfers
+WHERE reversal_of_transfer_id = $1;
\ No newline at end of file
@@ -1,8 +1,44 @@
 -- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
-WHERE 
-    from_account_id = $1 OR
-    to_account_id = $2
-ORDER BY id
-LIMIT $3
-OFFSET $4; 
\ No newline at end of file
+SELECT
+  t.id,
+  t.from_account_id,
+  t.to_account_id,
+  t.amount,
+  t.converted_amount,
+  t.exchange_rate,
+  t.from_currency,
+  t.to_currency,
+  t.fee,
+  t.created_at,
+  t.pivot_currency,
+  t.from_pivot_rate,
+  t.pivot_to_rate,
+  t.fee_schedule_id,
+  t.mid_market_rate,
+  t.markup_bps,
+  t.status,
+  t.reversal_of_transfer_id,
+  t.refunded_fee,
+  fa.owner AS from_owner,
+  ta.owner AS to_owner
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+JOIN accounts ta ON ta.id = t.to_account_id
+WHERE
+  (
+    (sqlc.arg(include_sent)::boolean AND (fa.id = sqlc.narg(account_id)::bigint OR fa.owner = sqlc.narg(owner)::varchar))
+    OR
+    (sqlc.arg(include_received)::boolean AND (ta.id = sqlc.narg(account_id)::bigint OR ta.owner = sqlc.narg(owner)::varchar))
+  )
+  AND (sqlc.narg(from_currency)::varchar IS NULL OR t.from_currency = sqlc.narg(from_currency)::varchar)
+  AND (sqlc.narg(to_currency)::varchar IS NULL OR t.to_currency = sqlc.narg(to_currency)::varchar)
+  AND (sqlc.narg(start_date)::timestamptz IS NULL OR t.created_at >= sqlc.narg(start_date)::timestamptz)
+  AND (sqlc.narg(end_date)::timestamptz IS NULL OR t.created_at < sqlc.narg(end_date)::timestamptz)
+  AND (sqlc.narg(min_amount)::decimal IS NULL OR t.amount >= sqlc.narg(min_amount)::decimal)
+  AND (sqlc.narg(max_amount)::decimal IS NULL OR t.amount <= sqlc.narg(max_amount)::decimal)
+  AND (
+    sqlc.narg(cursor_created_at)::timestamptz IS NULL
+    OR (t.created_at, t.id) < (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::bigint)
+  )
+ORDER BY t.created_at DESC, t.id DESC
+LIMIT sqlc.arg(page_limit)::int;
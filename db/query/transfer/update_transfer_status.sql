@@ -0,0 +1,5 @@
+-- name: UpdateTransferStatus :one
+UPDATE transfers
+SET status = $2
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- name: CreateTransferBatch :one
+INSERT INTO transfer_batches (
+  owner,
+  from_account_id,
+  mode,
+  status,
+  item_count,
+  succeeded_count,
+  failed_count,
+  total_debited
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
\ No newline at end of file
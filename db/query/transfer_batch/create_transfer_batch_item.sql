@@ -0,0 +1,15 @@
+-- name: CreateTransferBatchItem :one
+INSERT INTO transfer_batch_items (
+  batch_id,
+  item_index,
+  to_account_id,
+  amount,
+  converted_amount,
+  fee,
+  to_currency,
+  status,
+  transfer_id,
+  error
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- name: GetTransferBatch :one
+SELECT * FROM transfer_batches
+WHERE id = $1 LIMIT 1;
\ No newline at end of file
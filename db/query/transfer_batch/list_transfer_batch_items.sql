@@ -0,0 +1,4 @@
+-- name: ListTransferBatchItems :many
+SELECT * FROM transfer_batch_items
+WHERE batch_id = $1
+ORDER BY item_index;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: ListTransferBatches :many
+SELECT * FROM transfer_batches
+WHERE owner = $1
+ORDER BY id DESC;
\ No newline at end of file
@@ -0,0 +1,21 @@
+-- name: CreateTransferHold :one
+INSERT INTO transfer_holds (
+  owner,
+  from_account_id,
+  to_account_id,
+  amount,
+  converted_amount,
+  exchange_rate,
+  from_currency,
+  to_currency,
+  fee,
+  fee_schedule_id,
+  pivot_currency,
+  from_pivot_rate,
+  pivot_to_rate,
+  mid_market_rate,
+  markup_bps,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+) RETURNING *;
\ No newline at end of file
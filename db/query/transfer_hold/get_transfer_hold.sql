@@ -0,0 +1,3 @@
+-- name: GetTransferHold :one
+SELECT * FROM transfer_holds
+WHERE id = $1 LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: GetTransferHoldForUpdate :one
+SELECT * FROM transfer_holds
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
\ No newline at end of file
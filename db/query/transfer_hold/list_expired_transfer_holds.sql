@@ -0,0 +1,7 @@
+-- name: ListExpiredTransferHolds :many
+SELECT * FROM transfer_holds
+WHERE status = 'authorized'
+AND expires_at <= now()
+ORDER BY expires_at, id
+LIMIT $1
+FOR NO KEY UPDATE SKIP LOCKED;
\ No newline at end of file
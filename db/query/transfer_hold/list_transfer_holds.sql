@@ -0,0 +1,4 @@
+-- name: ListTransferHolds :many
+SELECT * FROM transfer_holds
+WHERE owner = $1
+ORDER BY id DESC;
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- name: UpdateTransferHoldStatus :one
+UPDATE transfer_holds
+SET status = $2,
+    transfer_id = $3
+WHERE id = $1
+RETURNING *;
\ No newline at end of file
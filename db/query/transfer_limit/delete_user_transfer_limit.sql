@@ -0,0 +1,3 @@
+-- name: DeleteUserTransferLimit :execrows
+DELETE FROM user_transfer_limits
+WHERE username = $1 AND currency = $2;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- name: GetTierTransferLimit :one
+SELECT * FROM transfer_limits
+WHERE tier = $1 AND currency = $2
+LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,24 @@
+-- name: GetTransferUsage :one
+SELECT
+  COUNT(*) FILTER (WHERE t.created_at >= sqlc.arg(daily_since))::bigint AS daily_count,
+  COALESCE(SUM(t.amount) FILTER (WHERE t.created_at >= sqlc.arg(daily_since)), 0)::decimal AS daily_amount,
+  COUNT(*)::bigint AS monthly_count,
+  COALESCE(SUM(t.amount), 0)::decimal AS monthly_amount
+FROM (
+  -- Authorized holds count towards the limits until they are captured, voided or expire
+  SELECT t.amount, t.created_at
+  FROM transfers t
+  JOIN accounts a ON a.id = t.from_account_id
+  WHERE a.owner = sqlc.arg(owner)
+  AND a.currency = sqlc.arg(currency)
+  AND t.created_at >= sqlc.arg(monthly_since)
+  AND t.reversal_of_transfer_id IS NULL
+  UNION ALL
+  SELECT h.amount, h.created_at
+  FROM transfer_holds h
+  JOIN accounts a ON a.id = h.from_account_id
+  WHERE a.owner = sqlc.arg(owner)
+  AND a.currency = sqlc.arg(currency)
+  AND h.created_at >= sqlc.arg(monthly_since)
+  AND h.status = 'authorized'
+) t;
\ No newline at end of file
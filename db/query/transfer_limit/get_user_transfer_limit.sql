@@ -0,0 +1,4 @@
+-- name: GetUserTransferLimit :one
+SELECT * FROM user_transfer_limits
+WHERE username = $1 AND currency = $2
+LIMIT 1;
\ No newline at end of file
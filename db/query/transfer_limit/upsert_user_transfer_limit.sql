@@ -0,0 +1,20 @@
+-- name: UpsertUserTransferLimit :one
+INSERT INTO user_transfer_limits (
+  username,
+  currency,
+  per_transaction_amount,
+  daily_amount,
+  daily_count,
+  monthly_amount,
+  monthly_count
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (username, currency) DO UPDATE
+SET
+  per_transaction_amount = EXCLUDED.per_transaction_amount,
+  daily_amount = EXCLUDED.daily_amount,
+  daily_count = EXCLUDED.daily_count,
+  monthly_amount = EXCLUDED.monthly_amount,
+  monthly_count = EXCLUDED.monthly_count
+RETURNING *;
\ No newline at end of file
@@ -1,3 +1,3 @@
 -- name: GetUser :one
-SELECT username, full_name, email, created_at FROM users
+SELECT username, full_name, email, is_email_verified, created_at FROM users
 WHERE username = $1 LIMIT 1; 
\ No newline at end of file
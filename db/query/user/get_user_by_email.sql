@@ -0,0 +1,3 @@
+-- name: GetUserByEmail :one
+SELECT username, full_name, email, is_email_verified, created_at FROM users
+WHERE email = $1 LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- name: GetUserPasswordChangedAt :one
+SELECT password_changed_at FROM users
+WHERE username = $1 LIMIT 1;
\ No newline at end of file
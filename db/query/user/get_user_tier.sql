@@ -0,0 +1,3 @@
+-- name: GetUserTier :one
+SELECT tier FROM users
+WHERE username = $1 LIMIT 1;
\ No newline at end of file
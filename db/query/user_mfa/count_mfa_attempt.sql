@@ -0,0 +1,8 @@
+-- name: CountMfaAttempt :one
+UPDATE user_mfa
+SET failed_attempts = CASE WHEN failed_attempts + 1 >= sqlc.arg(max_failed_attempts)::int THEN 0 ELSE failed_attempts + 1 END,
+    locked_until = CASE WHEN failed_attempts + 1 >= sqlc.arg(max_failed_attempts)::int THEN sqlc.arg(locked_until)::timestamptz ELSE NULL END,
+    updated_at = now()
+WHERE username = sqlc.arg(username)
+  AND (locked_until IS NULL OR locked_until <= now())
+RETURNING *;
@@ -0,0 +1,4 @@
+-- name: CountUnusedMfaRecoveryCodes :one
+SELECT count(*) FROM mfa_recovery_codes
+WHERE username = $1
+  AND used_at IS NULL;
\ No newline at end of file
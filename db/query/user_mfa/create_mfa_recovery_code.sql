@@ -0,0 +1,7 @@
+-- name: CreateMfaRecoveryCode :exec
+INSERT INTO mfa_recovery_codes (
+  username,
+  code_hash
+) VALUES (
+  $1, $2
+);
\ No newline at end of file
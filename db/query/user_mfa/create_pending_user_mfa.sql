@@ -0,0 +1,13 @@
+-- name: CreatePendingUserMfa :one
+INSERT INTO user_mfa (
+  username,
+  encrypted_secret
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (username) DO UPDATE
+SET encrypted_secret = EXCLUDED.encrypted_secret,
+    last_used_step = 0,
+    updated_at = now()
+WHERE user_mfa.enabled_at IS NULL
+RETURNING *;
\ No newline at end of file
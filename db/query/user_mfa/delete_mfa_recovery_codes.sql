@@ -0,0 +1,3 @@
+-- name: DeleteMfaRecoveryCodes :exec
+DELETE FROM mfa_recovery_codes
+WHERE username = $1;
\ No newline at end of file
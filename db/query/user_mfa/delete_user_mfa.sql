@@ -0,0 +1,3 @@
+-- name: DeleteUserMfa :exec
+DELETE FROM user_mfa
+WHERE username = $1;
\ No newline at end of file
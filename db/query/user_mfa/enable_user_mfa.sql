@@ -0,0 +1,9 @@
+-- name: EnableUserMfa :one
+UPDATE user_mfa
+SET enabled_at = now(),
+    last_used_step = $2,
+    updated_at = now()
+WHERE username = $1
+  AND enabled_at IS NULL
+  AND last_used_step < $2
+RETURNING *;
\ No newline at end of file
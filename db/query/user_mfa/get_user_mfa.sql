@@ -0,0 +1,3 @@
+-- name: GetUserMfa :one
+SELECT * FROM user_mfa
+WHERE username = $1 LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- name: ResetMfaAttempts :exec
+UPDATE user_mfa
+SET failed_attempts = 0,
+    locked_until = NULL,
+    updated_at = now()
+WHERE username = $1;
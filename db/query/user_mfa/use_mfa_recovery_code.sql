@@ -0,0 +1,7 @@
+-- name: UseMfaRecoveryCode :one
+UPDATE mfa_recovery_codes
+SET used_at = now()
+WHERE username = $1
+  AND code_hash = $2
+  AND used_at IS NULL
+RETURNING *;
\ No newline at end of file
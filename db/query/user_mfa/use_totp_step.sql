@@ -0,0 +1,8 @@
+-- name: UseTotpStep :one
+UPDATE user_mfa
+SET last_used_step = $2,
+    updated_at = now()
+WHERE username = $1
+  AND enabled_at IS NOT NULL
+  AND last_used_step < $2
+RETURNING *;
\ No newline at end of file
@@ -0,0 +1,10 @@
+-- name: CreateUserToken :one
+INSERT INTO user_tokens (
+  username,
+  purpose,
+  token_hash,
+  email,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
\ No newline at end of file
@@ -0,0 +1,6 @@
+-- name: RevokeUserTokens :exec
+UPDATE user_tokens
+SET used_at = now()
+WHERE username = $1
+  AND purpose = $2
+  AND used_at IS NULL;
\ No newline at end of file
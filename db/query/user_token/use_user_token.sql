@@ -0,0 +1,8 @@
+-- name: UseUserToken :one
+UPDATE user_tokens
+SET used_at = now()
+WHERE token_hash = $1
+  AND purpose = $2
+  AND used_at IS NULL
+  AND expires_at > now()
+RETURNING *;
\ No newline at end of file
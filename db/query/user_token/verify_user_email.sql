@@ -0,0 +1,16 @@
+-- name: VerifyUserEmail :one
+WITH used_token AS (
+  UPDATE user_tokens
+  SET used_at = now()
+  WHERE token_hash = $1
+    AND purpose = 'email_verification'
+    AND used_at IS NULL
+    AND expires_at > now()
+  RETURNING username, email
+)
+UPDATE users
+SET is_email_verified = true
+FROM used_token
+WHERE users.username = used_token.username
+  AND users.email = used_token.email
+RETURNING users.username, users.email;
\ No newline at end of file
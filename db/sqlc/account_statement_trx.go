@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// AccountStatementTxParams contains the input parameters of the account statement transaction
+type AccountStatementTxParams struct {
+	AccountID int64     `json:"account_id"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+}
+
+// AccountStatementTxResult is the result of the account statement transaction
+type AccountStatementTxResult struct {
+	Account        Account                          `json:"account"`
+	OpeningBalance decimal.Decimal                  `json:"opening_balance"`
+	Entries        []ListAccountStatementEntriesRow `json:"entries"`
+}
+
+// AccountStatementTx reads an account and its entries between From and To from one snapshot.
+// The opening balance is the current balance minus every entry posted since From, so it stays
+// correct for accounts whose starting balance was not written as an entry
+func (store *SQLStore) AccountStatementTx(ctx context.Context, arg AccountStatementTxParams) (AccountStatementTxResult, error) {
+	var result AccountStatementTxResult
+
+	txOptions := pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+	err := store.execTxWithOptions(ctx, txOptions, func(q *Queries) error {
+		var err error
+
+		result.Account, err = q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		postedSince, err := q.SumAccountEntriesSince(ctx, SumAccountEntriesSinceParams{
+			AccountID: arg.AccountID,
+			CreatedAt: arg.From,
+		})
+		if err != nil {
+			return err
+		}
+		result.OpeningBalance = result.Account.Balance.Sub(decimal.NewFromBigInt(postedSince.Int, postedSince.Exp))
+
+		result.Entries, err = q.ListAccountStatementEntries(ctx, ListAccountStatementEntriesParams{
+			AccountID: arg.AccountID,
+			StartDate: arg.From,
+			EndDate:   arg.To,
+		})
+		return err
+	})
+
+	return result, err
+}
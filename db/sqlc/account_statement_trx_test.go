@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountStatementTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, "USD")
+	account2 := createAccountWithCurrency(t, "USD")
+
+	from := time.Now().Add(-time.Minute)
+	amount := decimal.NewFromInt(10)
+
+	// Two transfers out of account1 and one back in
+	for _, arg := range []TransferTxParams{
+		{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: amount, ConvertedAmount: amount, ExchangeRate: decimal.NewFromInt(1)},
+		{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: amount, ConvertedAmount: amount, ExchangeRate: decimal.NewFromInt(1)},
+		{FromAccountID: account2.ID, ToAccountID: account1.ID, Amount: amount, ConvertedAmount: amount, ExchangeRate: decimal.NewFromInt(1)},
+	} {
+		result, err := store.TransferTx(context.Background(), arg)
+		require.NoError(t, err)
+		require.Equal(t, result.Transfer.ID, result.FromEntry.TransferID.Int64)
+		require.Equal(t, result.Transfer.ID, result.ToEntry.TransferID.Int64)
+	}
+
+	statement, err := store.AccountStatementTx(context.Background(), AccountStatementTxParams{
+		AccountID: account1.ID,
+		From:      from,
+		To:        time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, account1.ID, statement.Account.ID)
+	require.True(t, account1.Balance.Equal(statement.OpeningBalance))
+	require.Len(t, statement.Entries, 3)
+
+	closingBalance := statement.OpeningBalance
+	for _, entry := range statement.Entries {
+		require.True(t, entry.TransferID.Valid)
+		require.Equal(t, account2.ID, entry.CounterpartyAccountID.Int64)
+		require.Equal(t, account2.Owner, entry.CounterpartyOwner.String)
+		closingBalance = closingBalance.Add(entry.Amount)
+	}
+	require.True(t, statement.Account.Balance.Equal(closingBalance))
+}
@@ -0,0 +1,292 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// Modes of a transfer batch, see the transfer_batches table
+const (
+	TransferBatchModeAllOrNothing = "all_or_nothing"
+	TransferBatchModeBestEffort   = "best_effort"
+)
+
+// Statuses of a transfer batch and of its items
+const (
+	TransferBatchStatusCompleted          = "completed"
+	TransferBatchStatusPartiallyCompleted = "partially_completed"
+	TransferBatchStatusFailed             = "failed"
+
+	TransferBatchItemStatusCompleted = "completed"
+	TransferBatchItemStatusFailed    = "failed"
+	TransferBatchItemStatusSkipped   = "skipped"
+)
+
+// batchItemRefusals are the errors that refuse a single transfer of a batch,
+// any other error fails the whole batch and nothing is recorded
+var batchItemRefusals = []error{
+	ErrInsufficientFunds,
+	ErrQuoteUnavailable,
+	ErrPerTransactionLimitExceeded,
+	ErrDailyAmountLimitExceeded,
+	ErrDailyCountLimitExceeded,
+	ErrMonthlyAmountLimitExceeded,
+	ErrMonthlyCountLimitExceeded,
+}
+
+// BatchTransferTxParams contains the input parameters of the batch transfer transaction
+type BatchTransferTxParams struct {
+	Username      string `json:"username"`
+	FromAccountID int64  `json:"from_account_id"`
+	Mode          string `json:"mode"`
+	// Transfers of the batch in request order, all from FromAccountID
+	Items []TransferTxParams `json:"items"`
+}
+
+// BatchTransferTxResult is the result of the batch transfer transaction
+type BatchTransferTxResult struct {
+	Batch TransferBatch       `json:"batch"`
+	Items []TransferBatchItem `json:"items"`
+	// The source account after the batch
+	FromAccount Account `json:"from_account"`
+}
+
+// batchItemOutcome is what happened to one transfer of a batch before it is recorded
+type batchItemOutcome struct {
+	status     string
+	transferID int64
+	err        error
+}
+
+// BatchTransferTx makes the transfers of a batch from one source account in a single transaction.
+// The source account and every recipient are locked once, in ID order, before the first transfer.
+// An all_or_nothing batch rolls back every transfer when one is refused, a best_effort batch
+// rolls back only the refused transfer. Either way the batch and the outcome of each item are recorded
+func (store *SQLStore) BatchTransferTx(ctx context.Context, arg BatchTransferTxParams) (BatchTransferTxResult, error) {
+	var result BatchTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		accounts, err := lockBatchAccounts(ctx, q, arg)
+		if err != nil {
+			return err
+		}
+
+		outcomes := make([]batchItemOutcome, len(arg.Items))
+		if arg.Mode == TransferBatchModeAllOrNothing {
+			err = runAllOrNothingBatch(ctx, q, arg, accounts, outcomes)
+		} else {
+			err = runBestEffortBatch(ctx, q, arg, accounts, outcomes)
+		}
+		if err != nil {
+			return err
+		}
+
+		result, err = recordTransferBatch(ctx, q, arg, outcomes)
+		if err != nil {
+			return err
+		}
+
+		result.FromAccount, err = q.GetAccount(ctx, arg.FromAccountID)
+		return err
+	})
+
+	return result, err
+}
+
+// lockBatchAccounts locks the source account and the recipients of a batch in ID order,
+// the same order TransferTx locks accounts in, and returns them by ID
+func lockBatchAccounts(ctx context.Context, q *Queries, arg BatchTransferTxParams) (map[int64]Account, error) {
+	ids := []int64{arg.FromAccountID}
+	seen := map[int64]bool{arg.FromAccountID: true}
+	for i, item := range arg.Items {
+		if item.FromAccountID != arg.FromAccountID {
+			return nil, fmt.Errorf("batch item %d is not from account %d", i, arg.FromAccountID)
+		}
+		if item.ToAccountID == arg.FromAccountID {
+			return nil, fmt.Errorf("batch item %d transfers to its source account", i)
+		}
+		if !seen[item.ToAccountID] {
+			seen[item.ToAccountID] = true
+			ids = append(ids, item.ToAccountID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	accounts := make(map[int64]Account, len(ids))
+	for _, id := range ids {
+		account, err := q.GetAccountForUpdate(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("account %d not found: %w", id, err)
+		}
+		accounts[id] = account
+	}
+
+	return accounts, nil
+}
+
+// runAllOrNothingBatch makes every transfer of the batch, or none of them when one is refused
+func runAllOrNothingBatch(ctx context.Context, q *Queries, arg BatchTransferTxParams, accounts map[int64]Account, outcomes []batchItemOutcome) error {
+	failedIndex := -1
+
+	err := withSavepoint(ctx, q, "transfer_batch", func() error {
+		for i, item := range arg.Items {
+			transfer, err := postBatchTransfer(ctx, q, item, accounts)
+			if err != nil {
+				failedIndex = i
+				return err
+			}
+			outcomes[i] = batchItemOutcome{status: TransferBatchItemStatusCompleted, transferID: transfer.Transfer.ID}
+		}
+		return nil
+	})
+	if err == nil {
+		return nil
+	}
+	if failedIndex < 0 || !isBatchItemRefusal(err) {
+		return err
+	}
+
+	// The transfers made before the refused one were rolled back with it
+	for i := range outcomes {
+		outcomes[i] = batchItemOutcome{status: TransferBatchItemStatusSkipped}
+	}
+	outcomes[failedIndex] = batchItemOutcome{status: TransferBatchItemStatusFailed, err: err}
+
+	return nil
+}
+
+// runBestEffortBatch makes each transfer of the batch that is not refused
+func runBestEffortBatch(ctx context.Context, q *Queries, arg BatchTransferTxParams, accounts map[int64]Account, outcomes []batchItemOutcome) error {
+	for i, item := range arg.Items {
+		var transfer TransferTxResult
+
+		err := withSavepoint(ctx, q, "transfer_batch_item", func() error {
+			var err error
+			transfer, err = postBatchTransfer(ctx, q, item, accounts)
+			return err
+		})
+		if err != nil {
+			if !isBatchItemRefusal(err) {
+				return err
+			}
+			outcomes[i] = batchItemOutcome{status: TransferBatchItemStatusFailed, err: err}
+			continue
+		}
+
+		outcomes[i] = batchItemOutcome{status: TransferBatchItemStatusCompleted, transferID: transfer.Transfer.ID}
+	}
+
+	return nil
+}
+
+// postBatchTransfer makes one transfer of a batch between locked accounts and keeps their balances up to date
+func postBatchTransfer(ctx context.Context, q *Queries, item TransferTxParams, accounts map[int64]Account) (TransferTxResult, error) {
+	result, err := postTransfer(ctx, q, item, accounts[item.FromAccountID], accounts[item.ToAccountID], nil)
+	if err != nil {
+		return result, err
+	}
+
+	accounts[result.FromAccount.ID] = result.FromAccount
+	accounts[result.ToAccount.ID] = result.ToAccount
+
+	return result, nil
+}
+
+// recordTransferBatch stores the batch with the outcome of each of its items
+func recordTransferBatch(ctx context.Context, q *Queries, arg BatchTransferTxParams, outcomes []batchItemOutcome) (BatchTransferTxResult, error) {
+	var result BatchTransferTxResult
+
+	var succeeded, failed int32
+	totalDebited := decimal.Zero
+	for i, outcome := range outcomes {
+		if outcome.status == TransferBatchItemStatusCompleted {
+			succeeded++
+			totalDebited = totalDebited.Add(arg.Items[i].Amount).Add(arg.Items[i].Fee)
+		} else {
+			failed++
+		}
+	}
+
+	status := TransferBatchStatusPartiallyCompleted
+	switch {
+	case failed == 0:
+		status = TransferBatchStatusCompleted
+	case succeeded == 0:
+		status = TransferBatchStatusFailed
+	}
+
+	var err error
+	result.Batch, err = q.CreateTransferBatch(ctx, CreateTransferBatchParams{
+		Owner:          arg.Username,
+		FromAccountID:  arg.FromAccountID,
+		Mode:           arg.Mode,
+		Status:         status,
+		ItemCount:      int32(len(outcomes)),
+		SucceededCount: succeeded,
+		FailedCount:    failed,
+		TotalDebited:   totalDebited,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Items = make([]TransferBatchItem, 0, len(outcomes))
+	for i, outcome := range outcomes {
+		params := CreateTransferBatchItemParams{
+			BatchID:         result.Batch.ID,
+			ItemIndex:       int32(i),
+			ToAccountID:     arg.Items[i].ToAccountID,
+			Amount:          arg.Items[i].Amount,
+			ConvertedAmount: arg.Items[i].ConvertedAmount,
+			Fee:             arg.Items[i].Fee,
+			ToCurrency:      arg.Items[i].ToCurrency,
+			Status:          outcome.status,
+			TransferID:      pgtype.Int8{Int64: outcome.transferID, Valid: outcome.transferID != 0},
+		}
+		if outcome.err != nil {
+			params.Error = pgtype.Text{String: outcome.err.Error(), Valid: true}
+		}
+
+		item, err := q.CreateTransferBatchItem(ctx, params)
+		if err != nil {
+			return result, err
+		}
+		result.Items = append(result.Items, item)
+	}
+
+	return result, nil
+}
+
+func isBatchItemRefusal(err error) bool {
+	for _, refusal := range batchItemRefusals {
+		if errors.Is(err, refusal) {
+			return true
+		}
+	}
+	return false
+}
+
+// withSavepoint runs fn inside a savepoint of the transaction of q, so an error
+// rolls back what fn wrote without aborting the rest of the transaction
+func withSavepoint(ctx context.Context, q *Queries, name string, fn func() error) error {
+	_, err := q.db.Exec(ctx, "SAVEPOINT "+name)
+	if err != nil {
+		return err
+	}
+
+	err = fn()
+	if err != nil {
+		if _, rbErr := q.db.Exec(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+			return fmt.Errorf("savepoint err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	_, err = q.db.Exec(ctx, "RELEASE SAVEPOINT "+name)
+	return err
+}
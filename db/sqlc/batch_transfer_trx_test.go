@@ -0,0 +1,98 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchTransferTxBestEffort(t *testing.T) {
+	store := NewStore(testDB)
+
+	source := createAccountWithCurrency(t, "USD")
+	recipient1 := createAccountWithCurrency(t, "USD")
+	recipient2 := createAccountWithCurrency(t, "USD")
+
+	amount := decimal.NewFromInt(5).Round(2)
+	tooMuch := source.Balance.Add(decimal.NewFromInt(1))
+
+	result, err := store.BatchTransferTx(context.Background(), BatchTransferTxParams{
+		Username:      source.Owner,
+		FromAccountID: source.ID,
+		Mode:          TransferBatchModeBestEffort,
+		Items: []TransferTxParams{
+			newTransferTxParams(source, recipient1, amount),
+			newTransferTxParams(source, recipient2, tooMuch),
+			newTransferTxParams(source, recipient2, amount),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferBatchStatusPartiallyCompleted, result.Batch.Status)
+	require.Equal(t, int32(3), result.Batch.ItemCount)
+	require.Equal(t, int32(2), result.Batch.SucceededCount)
+	require.Equal(t, int32(1), result.Batch.FailedCount)
+	require.True(t, amount.Mul(decimal.NewFromInt(2)).Equal(result.Batch.TotalDebited))
+
+	require.Len(t, result.Items, 3)
+	require.Equal(t, TransferBatchItemStatusCompleted, result.Items[0].Status)
+	require.True(t, result.Items[0].TransferID.Valid)
+	require.Equal(t, TransferBatchItemStatusFailed, result.Items[1].Status)
+	require.False(t, result.Items[1].TransferID.Valid)
+	require.True(t, result.Items[1].Error.Valid)
+	require.Equal(t, TransferBatchItemStatusCompleted, result.Items[2].Status)
+
+	require.True(t, source.Balance.Sub(amount.Mul(decimal.NewFromInt(2))).Equal(result.FromAccount.Balance))
+
+	stored, err := store.ListTransferBatchItems(context.Background(), result.Batch.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.Items, stored)
+}
+
+func TestBatchTransferTxAllOrNothing(t *testing.T) {
+	store := NewStore(testDB)
+
+	source := createAccountWithCurrency(t, "USD")
+	recipient1 := createAccountWithCurrency(t, "USD")
+	recipient2 := createAccountWithCurrency(t, "USD")
+
+	amount := decimal.NewFromInt(5).Round(2)
+
+	// The second transfer is refused, so the first one is rolled back with it
+	result, err := store.BatchTransferTx(context.Background(), BatchTransferTxParams{
+		Username:      source.Owner,
+		FromAccountID: source.ID,
+		Mode:          TransferBatchModeAllOrNothing,
+		Items: []TransferTxParams{
+			newTransferTxParams(source, recipient1, amount),
+			newTransferTxParams(source, recipient2, source.Balance),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferBatchStatusFailed, result.Batch.Status)
+	require.Equal(t, int32(0), result.Batch.SucceededCount)
+	require.True(t, result.Batch.TotalDebited.IsZero())
+	require.Equal(t, TransferBatchItemStatusSkipped, result.Items[0].Status)
+	require.False(t, result.Items[0].TransferID.Valid)
+	require.Equal(t, TransferBatchItemStatusFailed, result.Items[1].Status)
+	require.True(t, source.Balance.Equal(result.FromAccount.Balance))
+
+	account, err := store.GetAccount(context.Background(), recipient1.ID)
+	require.NoError(t, err)
+	require.True(t, recipient1.Balance.Equal(account.Balance))
+
+	result, err = store.BatchTransferTx(context.Background(), BatchTransferTxParams{
+		Username:      source.Owner,
+		FromAccountID: source.ID,
+		Mode:          TransferBatchModeAllOrNothing,
+		Items: []TransferTxParams{
+			newTransferTxParams(source, recipient1, amount),
+			newTransferTxParams(source, recipient2, amount),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferBatchStatusCompleted, result.Batch.Status)
+	require.Equal(t, int32(2), result.Batch.SucceededCount)
+	require.True(t, source.Balance.Sub(amount.Mul(decimal.NewFromInt(2))).Equal(result.FromAccount.Balance))
+}
@@ -13,7 +13,7 @@ import (
 
 const blockSession = `-- name: BlockSession :exec
 UPDATE sessions 
-SET is_blocked = true, updated_at = now()
+SET is_blocked = true, blocked_reason = 'signed_out', updated_at = now()
 WHERE id = $1
 `
 
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Purposes of a user token, see the user_tokens table
+const (
+	UserTokenPurposeEmailVerification = "email_verification"
+	UserTokenPurposePasswordReset     = "password_reset"
+)
+
+// ChangePasswordTxParams contains the input parameters of the change password transaction
+type ChangePasswordTxParams struct {
+	// User whose password changes, ignored when ResetTokenHash is set
+	Username          string    `json:"username"`
+	HashedPassword    string    `json:"hashed_password"`
+	PasswordChangedAt time.Time `json:"password_changed_at"`
+	// Hash of the password reset token the change is made with,
+	// nil when the user changes their own password
+	ResetTokenHash []byte `json:"reset_token_hash"`
+}
+
+// ChangePasswordTxResult is the result of the change password transaction
+type ChangePasswordTxResult struct {
+	User UpdateUserRow `json:"user"`
+}
+
+// ChangePasswordTx changes the password of a user, blocks every session of the user and revokes
+// any password reset token still outstanding. With a ResetTokenHash the token is used up first
+// and names the user, pgx.ErrNoRows is returned when it is unknown, used or expired
+func (store *SQLStore) ChangePasswordTx(ctx context.Context, arg ChangePasswordTxParams) (ChangePasswordTxResult, error) {
+	var result ChangePasswordTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		username := arg.Username
+		if arg.ResetTokenHash != nil {
+			resetToken, err := q.UseUserToken(ctx, UseUserTokenParams{
+				TokenHash: arg.ResetTokenHash,
+				Purpose:   UserTokenPurposePasswordReset,
+			})
+			if err != nil {
+				return err
+			}
+			username = resetToken.Username
+		}
+
+		var err error
+		result.User, err = q.UpdateUser(ctx, UpdateUserParams{
+			HashedPassword:    pgtype.Text{String: arg.HashedPassword, Valid: true},
+			PasswordChangedAt: pgtype.Timestamptz{Time: arg.PasswordChangedAt, Valid: true},
+			Username:          username,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Refresh tokens stop working, access tokens are rejected by their issue time
+		err = q.BlockUserSessions(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		return q.RevokeUserTokens(ctx, RevokeUserTokensParams{
+			Username: username,
+			Purpose:  UserTokenPurposePasswordReset,
+		})
+	})
+
+	return result, err
+}
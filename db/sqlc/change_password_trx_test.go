@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangePasswordTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Username)
+	resetTokenHash := createRandomResetToken(t, user, time.Now().Add(time.Hour))
+
+	changedAt := time.Now().UTC().Truncate(time.Microsecond)
+	result, err := store.ChangePasswordTx(context.Background(), ChangePasswordTxParams{
+		Username:          user.Username,
+		HashedPassword:    "newhashedpassword",
+		PasswordChangedAt: changedAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.Username, result.User.Username)
+
+	hashedPassword, err := testQueries.GetUserHashedPassword(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, "newhashedpassword", hashedPassword)
+
+	passwordChangedAt, err := testQueries.GetUserPasswordChangedAt(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.WithinDuration(t, changedAt, passwordChangedAt, time.Second)
+
+	blocked, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, blocked.IsBlocked)
+
+	// The outstanding reset token was revoked by the change
+	_, err = store.ChangePasswordTx(context.Background(), ChangePasswordTxParams{
+		HashedPassword:    "otherhashedpassword",
+		PasswordChangedAt: time.Now(),
+		ResetTokenHash:    resetTokenHash,
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestChangePasswordTxWithResetToken(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Username)
+	resetTokenHash := createRandomResetToken(t, user, time.Now().Add(time.Hour))
+
+	result, err := store.ChangePasswordTx(context.Background(), ChangePasswordTxParams{
+		HashedPassword:    "resethashedpassword",
+		PasswordChangedAt: time.Now(),
+		ResetTokenHash:    resetTokenHash,
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.Username, result.User.Username)
+
+	blocked, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, blocked.IsBlocked)
+
+	// A reset token can only be used once
+	_, err = store.ChangePasswordTx(context.Background(), ChangePasswordTxParams{
+		HashedPassword:    "otherhashedpassword",
+		PasswordChangedAt: time.Now(),
+		ResetTokenHash:    resetTokenHash,
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestChangePasswordTxExpiredResetToken(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	resetTokenHash := createRandomResetToken(t, user, time.Now().Add(-time.Minute))
+
+	_, err := store.ChangePasswordTx(context.Background(), ChangePasswordTxParams{
+		HashedPassword:    "resethashedpassword",
+		PasswordChangedAt: time.Now(),
+		ResetTokenHash:    resetTokenHash,
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	hashedPassword, err := testQueries.GetUserHashedPassword(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, "hashedpassword123", hashedPassword)
+}
@@ -18,7 +18,7 @@ INSERT INTO accounts (
   currency
 ) VALUES (
   $1, $2, $3
-) RETURNING id, owner, balance, currency, created_at
+) RETURNING id, owner, balance, currency, created_at, held_amount
 `
 
 type CreateAccountParams struct {
@@ -36,6 +36,7 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (A
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.HeldAmount,
 	)
 	return i, err
 }
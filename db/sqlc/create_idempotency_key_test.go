@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+
+	"lemfi/simplebank/util"
+)
+
+func TestCreateIdempotencyKey(t *testing.T) {
+	transfer := createRandomTransfer(t)
+	user := createRandomUser(t)
+
+	body, err := json.Marshal(TransferTxResult{Transfer: transfer})
+	require.NoError(t, err)
+
+	arg := CreateIdempotencyKeyParams{
+		Username:       user.Username,
+		IdempotencyKey: util.RandomString(16),
+		RequestHash:    util.RandomString(64),
+		TransferID:     transfer.ID,
+		ResponseStatus: http.StatusCreated,
+		ResponseBody:   body,
+	}
+
+	storedKey, err := testQueries.CreateIdempotencyKey(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Username, storedKey.Username)
+	require.Equal(t, arg.IdempotencyKey, storedKey.IdempotencyKey)
+	require.Equal(t, arg.RequestHash, storedKey.RequestHash)
+	require.Equal(t, arg.TransferID, storedKey.TransferID)
+	require.Equal(t, arg.ResponseStatus, storedKey.ResponseStatus)
+	require.NotZero(t, storedKey.CreatedAt)
+
+	// Reusing the key is a no-op
+	_, err = testQueries.CreateIdempotencyKey(context.Background(), arg)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	fetchedKey, err := testQueries.GetIdempotencyKey(context.Background(), GetIdempotencyKeyParams{
+		Username:       arg.Username,
+		IdempotencyKey: arg.IdempotencyKey,
+	})
+	require.NoError(t, err)
+	require.Equal(t, storedKey.RequestHash, fetchedKey.RequestHash)
+	require.JSONEq(t, string(storedKey.ResponseBody), string(fetchedKey.ResponseBody))
+}
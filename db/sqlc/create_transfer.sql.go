@@ -22,34 +22,59 @@ INSERT INTO transfers (
   exchange_rate,
   from_currency,
   to_currency,
-  fee
+  fee,
+  pivot_currency,
+  from_pivot_rate,
+  pivot_to_rate,
+  fee_schedule_id,
+  mid_market_rate,
+  markup_bps,
+  reversal_of_transfer_id,
+  refunded_fee
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8
-) RETURNING id, from_account_id, to_account_id, amount, converted_amount, exchange_rate, from_currency, to_currency, fee, created_at
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+) RETURNING id, from_account_id, to_account_id, amount, converted_amount, exchange_rate, from_currency, to_currency, fee, created_at, pivot_currency, from_pivot_rate, pivot_to_rate, fee_schedule_id, mid_market_rate, markup_bps, status, reversal_of_transfer_id, refunded_fee
 `
 
 type CreateTransferParams struct {
-	FromAccountID   int64           `json:"from_account_id"`
-	ToAccountID     int64           `json:"to_account_id"`
-	Amount          decimal.Decimal `json:"amount"`
-	ConvertedAmount decimal.Decimal `json:"converted_amount"`
-	ExchangeRate    decimal.Decimal `json:"exchange_rate"`
-	FromCurrency    pgtype.Text     `json:"from_currency"`
-	ToCurrency      pgtype.Text     `json:"to_currency"`
-	Fee             decimal.Decimal `json:"fee"`
+	FromAccountID        int64               `json:"from_account_id"`
+	ToAccountID          int64               `json:"to_account_id"`
+	Amount               decimal.Decimal     `json:"amount"`
+	ConvertedAmount      decimal.Decimal     `json:"converted_amount"`
+	ExchangeRate         decimal.Decimal     `json:"exchange_rate"`
+	FromCurrency         pgtype.Text         `json:"from_currency"`
+	ToCurrency           pgtype.Text         `json:"to_currency"`
+	Fee                  decimal.Decimal     `json:"fee"`
+	PivotCurrency        pgtype.Text         `json:"pivot_currency"`
+	FromPivotRate        decimal.NullDecimal `json:"from_pivot_rate"`
+	PivotToRate          decimal.NullDecimal `json:"pivot_to_rate"`
+	FeeScheduleID        pgtype.Int8         `json:"fee_schedule_id"`
+	MidMarketRate        decimal.NullDecimal `json:"mid_market_rate"`
+	MarkupBps            pgtype.Int4         `json:"markup_bps"`
+	ReversalOfTransferID pgtype.Int8         `json:"reversal_of_transfer_id"`
+	RefundedFee          decimal.Decimal     `json:"refunded_fee"`
 }
 
 type CreateTransferRow struct {
-	ID              int64           `json:"id"`
-	FromAccountID   int64           `json:"from_account_id"`
-	ToAccountID     int64           `json:"to_account_id"`
-	Amount          decimal.Decimal `json:"amount"`
-	ConvertedAmount decimal.Decimal `json:"converted_amount"`
-	ExchangeRate    decimal.Decimal `json:"exchange_rate"`
-	FromCurrency    pgtype.Text     `json:"from_currency"`
-	ToCurrency      pgtype.Text     `json:"to_currency"`
-	Fee             decimal.Decimal `json:"fee"`
-	CreatedAt       time.Time       `json:"created_at"`
+	ID                   int64               `json:"id"`
+	FromAccountID        int64               `json:"from_account_id"`
+	ToAccountID          int64               `json:"to_account_id"`
+	Amount               decimal.Decimal     `json:"amount"`
+	ConvertedAmount      decimal.Decimal     `json:"converted_amount"`
+	ExchangeRate         decimal.Decimal     `json:"exchange_rate"`
+	FromCurrency         pgtype.Text         `json:"from_currency"`
+	ToCurrency           pgtype.Text         `json:"to_currency"`
+	Fee                  decimal.Decimal     `json:"fee"`
+	CreatedAt            time.Time           `json:"created_at"`
+	PivotCurrency        pgtype.Text         `json:"pivot_currency"`
+	FromPivotRate        decimal.NullDecimal `json:"from_pivot_rate"`
+	PivotToRate          decimal.NullDecimal `json:"pivot_to_rate"`
+	FeeScheduleID        pgtype.Int8         `json:"fee_schedule_id"`
+	MidMarketRate        decimal.NullDecimal `json:"mid_market_rate"`
+	MarkupBps            pgtype.Int4         `json:"markup_bps"`
+	Status               string              `json:"status"`
+	ReversalOfTransferID pgtype.Int8         `json:"reversal_of_transfer_id"`
+	RefundedFee          decimal.Decimal     `json:"refunded_fee"`
 }
 
 func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (CreateTransferRow, error) {
@@ -62,6 +87,14 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		arg.FromCurrency,
 		arg.ToCurrency,
 		arg.Fee,
+		arg.PivotCurrency,
+		arg.FromPivotRate,
+		arg.PivotToRate,
+		arg.FeeScheduleID,
+		arg.MidMarketRate,
+		arg.MarkupBps,
+		arg.ReversalOfTransferID,
+		arg.RefundedFee,
 	)
 	var i CreateTransferRow
 	err := row.Scan(
@@ -75,6 +108,15 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ToCurrency,
 		&i.Fee,
 		&i.CreatedAt,
+		&i.PivotCurrency,
+		&i.FromPivotRate,
+		&i.PivotToRate,
+		&i.FeeScheduleID,
+		&i.MidMarketRate,
+		&i.MarkupBps,
+		&i.Status,
+		&i.ReversalOfTransferID,
+		&i.RefundedFee,
 	)
 	return i, err
 }
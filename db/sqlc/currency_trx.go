@@ -0,0 +1,46 @@
+package db
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+// CreateCurrencyTx adds a currency together with its fee, fx and funding house accounts,
+// which transfers and deposits in the currency post the bank's side to
+func (store *SQLStore) CreateCurrencyTx(ctx context.Context, arg CreateCurrencyParams) (Currency, error) {
+	var currency Currency
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		currency, err = q.CreateCurrency(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		for _, houseAccount := range houseAccountOwners {
+			account, err := q.CreateAccount(ctx, CreateAccountParams{
+				Owner:    houseAccount.owner,
+				Balance:  decimal.Zero,
+				Currency: currency.Code,
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = q.CreateHouseAccount(ctx, CreateHouseAccountParams{
+				Currency:  currency.Code,
+				Purpose:   houseAccount.purpose,
+				AccountID: account.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return currency, err
+}
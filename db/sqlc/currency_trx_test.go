@@ -0,0 +1,67 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCurrencyTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	currency := createRandomCurrency(t, store)
+
+	for _, purpose := range []string{HouseAccountPurposeFee, HouseAccountPurposeFX, HouseAccountPurposeFunding} {
+		accountID, err := store.GetHouseAccountID(context.Background(), GetHouseAccountIDParams{
+			Currency: currency.Code,
+			Purpose:  purpose,
+		})
+		require.NoError(t, err)
+
+		account, err := store.GetAccount(context.Background(), accountID)
+		require.NoError(t, err)
+		require.Equal(t, currency.Code, account.Currency)
+		require.True(t, account.Balance.IsZero())
+	}
+
+	// A fee-bearing FX transfer out of the new currency posts to its house accounts
+	account1 := createAccountWithCurrency(t, currency.Code)
+	account2 := createAccountWithCurrency(t, "USD")
+
+	fee := decimal.NewFromInt(1).Round(2)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID:   account1.ID,
+		ToAccountID:     account2.ID,
+		Amount:          decimal.NewFromInt(10).Round(2),
+		ConvertedAmount: decimal.NewFromInt(5).Round(2),
+		ExchangeRate:    decimal.RequireFromString("0.5").Round(8),
+		FromCurrency:    account1.Currency,
+		ToCurrency:      account2.Currency,
+		Fee:             fee,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.HouseEntries, 3)
+
+	feeAccountID, err := store.GetHouseAccountID(context.Background(), GetHouseAccountIDParams{
+		Currency: currency.Code,
+		Purpose:  HouseAccountPurposeFee,
+	})
+	require.NoError(t, err)
+	feeEntry := entryWithAccount(t, result.HouseEntries, feeAccountID)
+	require.True(t, fee.Equal(feeEntry.Amount))
+}
+
+func TestCreateCurrencyTxDuplicate(t *testing.T) {
+	store := NewStore(testDB)
+
+	_, err := store.CreateCurrencyTx(context.Background(), CreateCurrencyParams{
+		Code:       "USD",
+		MinorUnits: 2,
+		Symbol:     "$",
+		Enabled:    true,
+	})
+	require.ErrorContains(t, err, "duplicate key value violates unique constraint")
+}
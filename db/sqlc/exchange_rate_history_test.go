@@ -0,0 +1,65 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"lemfi/simplebank/util"
+)
+
+func TestExchangeRateHistory(t *testing.T) {
+	// Random codes keep the test away from the pairs used by the other tests
+	fromCurrency := strings.ToUpper(util.RandomString(3))
+	toCurrency := strings.ToUpper(util.RandomString(3))
+	for toCurrency == fromCurrency {
+		toCurrency = strings.ToUpper(util.RandomString(3))
+	}
+
+	_, err := testQueries.CreateExchangeRate(context.Background(), CreateExchangeRateParams{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		Rate:         decimal.RequireFromString("1.5"),
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.UpdateExchangeRate(context.Background(), UpdateExchangeRateParams{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		Rate:         decimal.RequireFromString("1.75"),
+	})
+	require.NoError(t, err)
+
+	history, err := testQueries.ListExchangeRateHistory(context.Background(), ListExchangeRateHistoryParams{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		PageLimit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
+	// Newest first
+	require.True(t, history[0].Rate.Equal(decimal.RequireFromString("1.75")))
+	require.True(t, history[1].Rate.Equal(decimal.RequireFromString("1.5")))
+	require.False(t, history[0].ValidFrom.Before(history[1].ValidFrom))
+
+	asOf, err := testQueries.GetExchangeRateAsOf(context.Background(), GetExchangeRateAsOfParams{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		AsOf:         history[0].ValidFrom,
+	})
+	require.NoError(t, err)
+	require.Equal(t, history[0].ID, asOf.ID)
+
+	_, err = testQueries.GetExchangeRateAsOf(context.Background(), GetExchangeRateAsOfParams{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		AsOf:         history[1].ValidFrom.Add(-time.Second),
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
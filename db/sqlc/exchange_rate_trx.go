@@ -0,0 +1,120 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// ExchangeRatePairTxParams contains a rate and the rate of its inverse pair
+type ExchangeRatePairTxParams struct {
+	FromCurrency string          `json:"from_currency"`
+	ToCurrency   string          `json:"to_currency"`
+	Rate         decimal.Decimal `json:"rate"`
+	InverseRate  decimal.Decimal `json:"inverse_rate"`
+}
+
+// ExchangeRatePairTxResult is the result of the exchange rate pair transactions
+type ExchangeRatePairTxResult struct {
+	ExchangeRate        ExchangeRate `json:"exchange_rate"`
+	InverseExchangeRate ExchangeRate `json:"inverse_exchange_rate"`
+}
+
+// CreateExchangeRateTx creates a new exchange rate and creates or replaces the inverse pair.
+// Creating a pair that already exists fails with the unique constraint violation
+func (store *SQLStore) CreateExchangeRateTx(ctx context.Context, arg ExchangeRatePairTxParams) (ExchangeRatePairTxResult, error) {
+	var result ExchangeRatePairTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.ExchangeRate, err = q.CreateExchangeRate(ctx, CreateExchangeRateParams{
+			FromCurrency: arg.FromCurrency,
+			ToCurrency:   arg.ToCurrency,
+			Rate:         arg.Rate,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.InverseExchangeRate, err = upsertInverseExchangeRate(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// UpdateExchangeRateTx updates an existing exchange rate and creates or replaces the inverse pair.
+// Updating a pair that does not exist returns pgx.ErrNoRows
+func (store *SQLStore) UpdateExchangeRateTx(ctx context.Context, arg ExchangeRatePairTxParams) (ExchangeRatePairTxResult, error) {
+	var result ExchangeRatePairTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.ExchangeRate, err = q.UpdateExchangeRate(ctx, UpdateExchangeRateParams{
+			FromCurrency: arg.FromCurrency,
+			ToCurrency:   arg.ToCurrency,
+			Rate:         arg.Rate,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.InverseExchangeRate, err = upsertInverseExchangeRate(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// DeleteExchangeRateTx deletes an exchange rate together with its inverse pair.
+// Returns pgx.ErrNoRows when the pair does not exist
+func (store *SQLStore) DeleteExchangeRateTx(ctx context.Context, arg DeleteExchangeRateParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		deleted, err := q.DeleteExchangeRate(ctx, arg)
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			return pgx.ErrNoRows
+		}
+
+		_, err = q.DeleteExchangeRate(ctx, DeleteExchangeRateParams{
+			FromCurrency: arg.ToCurrency,
+			ToCurrency:   arg.FromCurrency,
+		})
+		return err
+	})
+}
+
+// RefreshExchangeRatesTx creates or replaces a set of exchange rates atomically,
+// so a refresh from a rate provider is applied completely or not at all
+func (store *SQLStore) RefreshExchangeRatesTx(ctx context.Context, arg []UpsertExchangeRateParams) ([]ExchangeRate, error) {
+	result := make([]ExchangeRate, 0, len(arg))
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		for _, rate := range arg {
+			exchangeRate, err := q.UpsertExchangeRate(ctx, rate)
+			if err != nil {
+				return err
+			}
+			result = append(result, exchangeRate)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func upsertInverseExchangeRate(ctx context.Context, q *Queries, arg ExchangeRatePairTxParams) (ExchangeRate, error) {
+	return q.UpsertExchangeRate(ctx, UpsertExchangeRateParams{
+		FromCurrency: arg.ToCurrency,
+		ToCurrency:   arg.FromCurrency,
+		Rate:         arg.InverseRate,
+	})
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"lemfi/simplebank/util"
+)
+
+func TestExchangeRatePairTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	// Random codes keep the test away from the pairs used by the other tests
+	fromCurrency := strings.ToUpper(util.RandomString(3))
+	toCurrency := strings.ToUpper(util.RandomString(3))
+	for toCurrency == fromCurrency {
+		toCurrency = strings.ToUpper(util.RandomString(3))
+	}
+
+	created, err := store.CreateExchangeRateTx(context.Background(), ExchangeRatePairTxParams{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		Rate:         decimal.RequireFromString("2.00000000"),
+		InverseRate:  decimal.RequireFromString("0.50000000"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, fromCurrency, created.ExchangeRate.FromCurrency)
+	require.Equal(t, toCurrency, created.InverseExchangeRate.FromCurrency)
+	require.True(t, created.InverseExchangeRate.Rate.Equal(decimal.RequireFromString("0.5")))
+
+	updated, err := store.UpdateExchangeRateTx(context.Background(), ExchangeRatePairTxParams{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		Rate:         decimal.RequireFromString("4.00000000"),
+		InverseRate:  decimal.RequireFromString("0.25000000"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, created.ExchangeRate.ID, updated.ExchangeRate.ID)
+	require.Equal(t, created.InverseExchangeRate.ID, updated.InverseExchangeRate.ID)
+	require.True(t, updated.InverseExchangeRate.Rate.Equal(decimal.RequireFromString("0.25")))
+
+	err = store.DeleteExchangeRateTx(context.Background(), DeleteExchangeRateParams{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+	})
+	require.NoError(t, err)
+
+	_, err = store.GetExchangeRate(context.Background(), GetExchangeRateParams{
+		FromCurrency: toCurrency,
+		ToCurrency:   fromCurrency,
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	err = store.DeleteExchangeRateTx(context.Background(), DeleteExchangeRateParams{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestRefreshExchangeRatesTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	fromCurrency := strings.ToUpper(util.RandomString(3))
+	toCurrency := strings.ToUpper(util.RandomString(3))
+	for toCurrency == fromCurrency {
+		toCurrency = strings.ToUpper(util.RandomString(3))
+	}
+
+	arg := []UpsertExchangeRateParams{
+		{FromCurrency: fromCurrency, ToCurrency: toCurrency, Rate: decimal.RequireFromString("3")},
+		{FromCurrency: toCurrency, ToCurrency: fromCurrency, Rate: decimal.RequireFromString("0.33333333")},
+	}
+
+	rates, err := store.RefreshExchangeRatesTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+
+	// Refreshing with the same rates only renews them, the history keeps one row per pair
+	_, err = store.RefreshExchangeRatesTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	history, err := store.ListExchangeRateHistory(context.Background(), ListExchangeRateHistoryParams{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		PageLimit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// SetFeeScheduleTxParams contains a new version of the fee schedule of a currency pair
+type SetFeeScheduleTxParams struct {
+	CreateFeeScheduleParams
+	Tiers []FeeScheduleTierParams `json:"tiers"`
+}
+
+// FeeScheduleTierParams contains an amount tier of a fee schedule
+type FeeScheduleTierParams struct {
+	MinAmount  decimal.Decimal `json:"min_amount"`
+	FixedFee   decimal.Decimal `json:"fixed_fee"`
+	Percentage decimal.Decimal `json:"percentage"`
+}
+
+// FeeScheduleTxResult is a fee schedule version with its amount tiers
+type FeeScheduleTxResult struct {
+	FeeSchedule FeeSchedule       `json:"fee_schedule"`
+	Tiers       []FeeScheduleTier `json:"tiers"`
+}
+
+// SetFeeScheduleTx deactivates the live fee schedule of the pair, if any, and creates the next version with its tiers.
+// Previous versions are kept for the transfers that were charged with them. Changes to the same pair are serialized
+// with an advisory lock, so concurrent requests get consecutive versions instead of computing the same one
+func (store *SQLStore) SetFeeScheduleTx(ctx context.Context, arg SetFeeScheduleTxParams) (FeeScheduleTxResult, error) {
+	var result FeeScheduleTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		err := q.LockFeeSchedulePair(ctx, LockFeeSchedulePairParams{
+			FromCurrency: arg.FromCurrency,
+			ToCurrency:   arg.ToCurrency,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.DeactivateFeeSchedule(ctx, DeactivateFeeScheduleParams{
+			FromCurrency: arg.FromCurrency,
+			ToCurrency:   arg.ToCurrency,
+		})
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		result.FeeSchedule, err = q.CreateFeeSchedule(ctx, arg.CreateFeeScheduleParams)
+		if err != nil {
+			return err
+		}
+
+		result.Tiers = make([]FeeScheduleTier, 0, len(arg.Tiers))
+		for _, tier := range arg.Tiers {
+			createdTier, err := q.CreateFeeScheduleTier(ctx, CreateFeeScheduleTierParams{
+				FeeScheduleID: result.FeeSchedule.ID,
+				MinAmount:     tier.MinAmount,
+				FixedFee:      tier.FixedFee,
+				Percentage:    tier.Percentage,
+			})
+			if err != nil {
+				return err
+			}
+			result.Tiers = append(result.Tiers, createdTier)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"lemfi/simplebank/util"
+)
+
+func TestSetFeeScheduleTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	// Random codes keep the test away from the pairs used by the other tests
+	fromCurrency := strings.ToUpper(util.RandomString(3))
+	toCurrency := strings.ToUpper(util.RandomString(3))
+
+	first, err := store.SetFeeScheduleTx(context.Background(), SetFeeScheduleTxParams{
+		CreateFeeScheduleParams: CreateFeeScheduleParams{
+			FromCurrency: fromCurrency,
+			ToCurrency:   toCurrency,
+			FixedFee:     decimal.RequireFromString("1.50"),
+			Percentage:   decimal.RequireFromString("0.5"),
+			MinFee:       decimal.RequireFromString("2"),
+			MaxFee:       decimal.NewNullDecimal(decimal.RequireFromString("25")),
+		},
+		Tiers: []FeeScheduleTierParams{
+			{MinAmount: decimal.RequireFromString("1000"), FixedFee: decimal.RequireFromString("1"), Percentage: decimal.RequireFromString("0.25")},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), first.FeeSchedule.Version)
+	require.True(t, first.FeeSchedule.Active)
+	require.Len(t, first.Tiers, 1)
+	require.Equal(t, first.FeeSchedule.ID, first.Tiers[0].FeeScheduleID)
+
+	second, err := store.SetFeeScheduleTx(context.Background(), SetFeeScheduleTxParams{
+		CreateFeeScheduleParams: CreateFeeScheduleParams{
+			FromCurrency: fromCurrency,
+			ToCurrency:   toCurrency,
+			FixedFee:     decimal.RequireFromString("0.99"),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), second.FeeSchedule.Version)
+	require.False(t, second.FeeSchedule.MaxFee.Valid)
+	require.Empty(t, second.Tiers)
+
+	// Only the new version is live, the first one keeps its tiers for the transfers charged with it
+	active, err := store.GetActiveFeeSchedule(context.Background(), GetActiveFeeScheduleParams{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+	})
+	require.NoError(t, err)
+	require.Equal(t, second.FeeSchedule.ID, active.ID)
+
+	tiers, err := store.ListFeeScheduleTiers(context.Background(), []int64{first.FeeSchedule.ID, second.FeeSchedule.ID})
+	require.NoError(t, err)
+	require.Len(t, tiers, 1)
+	require.Equal(t, first.Tiers[0].ID, tiers[0].ID)
+
+	deactivated, err := store.DeactivateFeeSchedule(context.Background(), DeactivateFeeScheduleParams{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+	})
+	require.NoError(t, err)
+	require.Equal(t, second.FeeSchedule.ID, deactivated.ID)
+	require.False(t, deactivated.Active)
+
+	_, err = store.GetActiveFeeSchedule(context.Background(), GetActiveFeeScheduleParams{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestSetFeeScheduleTxConcurrent(t *testing.T) {
+	store := NewStore(testDB)
+
+	// A new pair has no live schedule whose row lock would serialize the requests
+	fromCurrency := strings.ToUpper(util.RandomString(3))
+	toCurrency := strings.ToUpper(util.RandomString(3))
+
+	n := 5
+	errs := make(chan error)
+	versions := make(chan int32)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			result, err := store.SetFeeScheduleTx(context.Background(), SetFeeScheduleTxParams{
+				CreateFeeScheduleParams: CreateFeeScheduleParams{
+					FromCurrency: fromCurrency,
+					ToCurrency:   toCurrency,
+					FixedFee:     decimal.RequireFromString("1"),
+				},
+			})
+			errs <- err
+			versions <- result.FeeSchedule.Version
+		}()
+	}
+
+	seen := make(map[int32]bool)
+	for i := 0; i < n; i++ {
+		require.NoError(t, <-errs)
+		version := <-versions
+		require.False(t, seen[version])
+		seen[version] = true
+	}
+
+	for version := int32(1); version <= int32(n); version++ {
+		require.True(t, seen[version])
+	}
+}
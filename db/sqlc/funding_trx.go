@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// Funding transaction kinds, see the funding_transactions table
+const (
+	FundingKindDeposit    = "deposit"
+	FundingKindWithdrawal = "withdrawal"
+)
+
+// ErrExternalReferenceConflict is returned by DepositTx and WithdrawTx when the external reference
+// was already stored by another transaction for the same funding source
+var ErrExternalReferenceConflict = errors.New("external reference already used")
+
+// ErrInsufficientFunds is returned by WithdrawTx, TransferTx and AuthorizeTransferTx when the available balance
+// of the locked account, its balance less the amount held by authorized transfers, does not cover the amount
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// FundingTxParams contains the input parameters of the deposit and withdrawal transactions
+type FundingTxParams struct {
+	AccountID         int64           `json:"account_id"`
+	Amount            decimal.Decimal `json:"amount"`
+	Currency          string          `json:"currency"`
+	ExternalReference string          `json:"external_reference"`
+	FundingSource     string          `json:"funding_source"`
+}
+
+// FundingTxResult is the result of the deposit and withdrawal transactions
+type FundingTxResult struct {
+	FundingTransaction FundingTransaction `json:"funding_transaction"`
+	Account            Account            `json:"account"`
+	Entry              Entry              `json:"entry"`
+	HouseEntry         Entry              `json:"house_entry"`
+}
+
+// DepositTx credits an account with money received from outside the bank.
+// The house funding account of the currency is debited so the ledger stays balanced
+func (store *SQLStore) DepositTx(ctx context.Context, arg FundingTxParams) (FundingTxResult, error) {
+	return store.fundingTx(ctx, FundingKindDeposit, arg)
+}
+
+// WithdrawTx debits an account with money paid out of the bank.
+// The house funding account of the currency is credited so the ledger stays balanced
+func (store *SQLStore) WithdrawTx(ctx context.Context, arg FundingTxParams) (FundingTxResult, error) {
+	return store.fundingTx(ctx, FundingKindWithdrawal, arg)
+}
+
+// fundingTx locks the customer account before the house account, the same order TransferTx uses,
+// and records the external reference last so a concurrent duplicate rolls the whole operation back
+func (store *SQLStore) fundingTx(ctx context.Context, kind string, arg FundingTxParams) (FundingTxResult, error) {
+	var result FundingTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		account, err := q.GetAccountForUpdate(ctx, arg.AccountID)
+		if err != nil {
+			return fmt.Errorf("account not found: %w", err)
+		}
+
+		if arg.Currency != "" && account.Currency != arg.Currency {
+			return fmt.Errorf("account currency mismatch: expected %s, got %s", account.Currency, arg.Currency)
+		}
+
+		amount := arg.Amount
+		if kind == FundingKindWithdrawal {
+			if account.Balance.Sub(account.HeldAmount).LessThan(arg.Amount) {
+				return ErrInsufficientFunds
+			}
+			amount = arg.Amount.Neg()
+		}
+
+		houseAccountID, err := lookupHouseAccountID(ctx, q, account.Currency, HouseAccountPurposeFunding)
+		if err != nil {
+			return err
+		}
+
+		result.Entry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID: arg.AccountID,
+			Amount:    amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
+			ID:     arg.AccountID,
+			Amount: amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		houseEntries, err := postHouseLegs(ctx, q, pgtype.Int8{}, []houseLeg{
+			{accountID: houseAccountID, amount: amount.Neg()},
+		})
+		if err != nil {
+			return err
+		}
+		result.HouseEntry = houseEntries[0]
+
+		result.Account, err = q.GetAccount(ctx, arg.AccountID)
+		if err != nil {
+			return err
+		}
+
+		result.FundingTransaction, err = q.CreateFundingTransaction(ctx, CreateFundingTransactionParams{
+			AccountID:         arg.AccountID,
+			Kind:              kind,
+			Amount:            arg.Amount,
+			Currency:          account.Currency,
+			BalanceAfter:      result.Account.Balance,
+			ExternalReference: arg.ExternalReference,
+			FundingSource:     arg.FundingSource,
+			EntryID:           result.Entry.ID,
+			HouseEntryID:      result.HouseEntry.ID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrExternalReferenceConflict
+		}
+
+		return err
+	})
+
+	return result, err
+}
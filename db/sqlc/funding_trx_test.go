@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"lemfi/simplebank/util"
+)
+
+func TestDepositAndWithdrawTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account := createAccountWithCurrency(t, "USD")
+	amount := decimal.NewFromInt(40).Round(2)
+
+	deposit, err := store.DepositTx(context.Background(), FundingTxParams{
+		AccountID:         account.ID,
+		Amount:            amount,
+		Currency:          account.Currency,
+		ExternalReference: util.RandomString(12),
+		FundingSource:     "bank_transfer",
+	})
+	require.NoError(t, err)
+	require.Equal(t, FundingKindDeposit, deposit.FundingTransaction.Kind)
+	require.Equal(t, account.Balance.Add(amount), deposit.Account.Balance)
+	require.Equal(t, deposit.Account.Balance, deposit.FundingTransaction.BalanceAfter)
+	require.Equal(t, amount, deposit.Entry.Amount)
+	require.Equal(t, amount.Neg(), deposit.HouseEntry.Amount)
+	require.Equal(t, deposit.Entry.ID, deposit.FundingTransaction.EntryID)
+
+	withdrawal, err := store.WithdrawTx(context.Background(), FundingTxParams{
+		AccountID:         account.ID,
+		Amount:            amount,
+		Currency:          account.Currency,
+		ExternalReference: util.RandomString(12),
+		FundingSource:     "bank_transfer",
+	})
+	require.NoError(t, err)
+	require.Equal(t, FundingKindWithdrawal, withdrawal.FundingTransaction.Kind)
+	require.Equal(t, account.Balance, withdrawal.Account.Balance)
+	require.Equal(t, amount.Neg(), withdrawal.Entry.Amount)
+	require.Equal(t, amount, withdrawal.HouseEntry.Amount)
+}
+
+func TestWithdrawTxInsufficientFunds(t *testing.T) {
+	store := NewStore(testDB)
+
+	account := createAccountWithCurrency(t, "USD")
+
+	_, err := store.WithdrawTx(context.Background(), FundingTxParams{
+		AccountID:         account.ID,
+		Amount:            account.Balance.Add(decimal.NewFromInt(1)),
+		Currency:          account.Currency,
+		ExternalReference: util.RandomString(12),
+		FundingSource:     "card",
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	unchanged, err := store.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account.Balance, unchanged.Balance)
+}
+
+func TestDepositTxExternalReferenceConflict(t *testing.T) {
+	store := NewStore(testDB)
+
+	account := createAccountWithCurrency(t, "USD")
+	arg := FundingTxParams{
+		AccountID:         account.ID,
+		Amount:            decimal.NewFromInt(10).Round(2),
+		Currency:          account.Currency,
+		ExternalReference: util.RandomString(12),
+		FundingSource:     "card",
+	}
+
+	first, err := store.DepositTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	// Applying the same reference again rolls the second deposit back
+	_, err = store.DepositTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrExternalReferenceConflict)
+
+	stored, err := store.GetFundingTransactionByReference(context.Background(), GetFundingTransactionByReferenceParams{
+		FundingSource:     arg.FundingSource,
+		ExternalReference: arg.ExternalReference,
+	})
+	require.NoError(t, err)
+	require.Equal(t, first.FundingTransaction.ID, stored.ID)
+
+	updated, err := store.GetAccount(context.Background(), account.ID)
+	require.NoError(t, err)
+	require.Equal(t, first.Account.Balance, updated.Balance)
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferTxConsumesQuote(t *testing.T) {
+	store := NewStore(testDB)
+
+	fromAccount := createAccountWithCurrency(t, "USD")
+	toAccount := createAccountWithCurrency(t, "EUR")
+	quote := createFxQuoteForUser(t, fromAccount.Owner, time.Now().Add(time.Minute))
+
+	arg := TransferTxParams{
+		FromAccountID:   fromAccount.ID,
+		ToAccountID:     toAccount.ID,
+		Amount:          quote.Amount,
+		ConvertedAmount: quote.ConvertedAmount,
+		ExchangeRate:    quote.Rate,
+		FromCurrency:    "USD",
+		ToCurrency:      "EUR",
+		Fee:             quote.Fee,
+		QuoteID:         quote.ID,
+		Username:        fromAccount.Owner,
+	}
+
+	result, err := store.TransferTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	consumed, err := testQueries.GetFxQuote(context.Background(), quote.ID)
+	require.NoError(t, err)
+	require.True(t, consumed.ConsumedAt.Valid)
+	require.Equal(t, result.Transfer.ID, consumed.TransferID.Int64)
+
+	// A quote pays for one transfer only, and the second transfer is rolled back
+	_, err = store.TransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrQuoteUnavailable)
+
+	account, err := testQueries.GetAccount(context.Background(), fromAccount.ID)
+	require.NoError(t, err)
+	require.True(t, result.FromAccount.Balance.Equal(account.Balance))
+}
+
+func TestTransferTxRejectsExpiredQuote(t *testing.T) {
+	store := NewStore(testDB)
+
+	fromAccount := createAccountWithCurrency(t, "USD")
+	toAccount := createAccountWithCurrency(t, "EUR")
+	quote := createFxQuoteForUser(t, fromAccount.Owner, time.Now().Add(-time.Second))
+
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID:   fromAccount.ID,
+		ToAccountID:     toAccount.ID,
+		Amount:          quote.Amount,
+		ConvertedAmount: quote.ConvertedAmount,
+		ExchangeRate:    quote.Rate,
+		FromCurrency:    "USD",
+		ToCurrency:      "EUR",
+		Fee:             quote.Fee,
+		QuoteID:         quote.ID,
+		Username:        fromAccount.Owner,
+	})
+	require.ErrorIs(t, err, ErrQuoteUnavailable)
+}
@@ -10,22 +10,25 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const getSession = `-- name: GetSession :one
-SELECT id, username, client_ip, user_agent, is_blocked, expires_at, created_at
+SELECT id, username, client_ip, user_agent, is_blocked, expires_at, created_at, family_id, blocked_reason
 FROM sessions
 WHERE id = $1 LIMIT 1
 `
 
 type GetSessionRow struct {
-	ID        uuid.UUID `json:"id"`
-	Username  string    `json:"username"`
-	ClientIp  string    `json:"client_ip"`
-	UserAgent string    `json:"user_agent"`
-	IsBlocked bool      `json:"is_blocked"`
-	ExpiresAt time.Time `json:"expires_at"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            uuid.UUID   `json:"id"`
+	Username      string      `json:"username"`
+	ClientIp      string      `json:"client_ip"`
+	UserAgent     string      `json:"user_agent"`
+	IsBlocked     bool        `json:"is_blocked"`
+	ExpiresAt     time.Time   `json:"expires_at"`
+	CreatedAt     time.Time   `json:"created_at"`
+	FamilyID      uuid.UUID   `json:"family_id"`
+	BlockedReason pgtype.Text `json:"blocked_reason"`
 }
 
 func (q *Queries) GetSession(ctx context.Context, id uuid.UUID) (GetSessionRow, error) {
@@ -39,6 +42,8 @@ func (q *Queries) GetSession(ctx context.Context, id uuid.UUID) (GetSessionRow,
 		&i.IsBlocked,
 		&i.ExpiresAt,
 		&i.CreatedAt,
+		&i.FamilyID,
+		&i.BlockedReason,
 	)
 	return i, err
 }
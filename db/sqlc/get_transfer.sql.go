@@ -9,20 +9,26 @@ import (
 	"context"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 )
 
 const getTransfer = `-- name: GetTransfer :one
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, converted_amount, from_currency, to_currency, status, reversal_of_transfer_id, created_at FROM transfers
 WHERE id = $1 LIMIT 1
 `
 
 type GetTransferRow struct {
-	ID            int64           `json:"id"`
-	FromAccountID int64           `json:"from_account_id"`
-	ToAccountID   int64           `json:"to_account_id"`
-	Amount        decimal.Decimal `json:"amount"`
-	CreatedAt     time.Time       `json:"created_at"`
+	ID                   int64           `json:"id"`
+	FromAccountID        int64           `json:"from_account_id"`
+	ToAccountID          int64           `json:"to_account_id"`
+	Amount               decimal.Decimal `json:"amount"`
+	ConvertedAmount      decimal.Decimal `json:"converted_amount"`
+	FromCurrency         pgtype.Text     `json:"from_currency"`
+	ToCurrency           pgtype.Text     `json:"to_currency"`
+	Status               string          `json:"status"`
+	ReversalOfTransferID pgtype.Int8     `json:"reversal_of_transfer_id"`
+	CreatedAt            time.Time       `json:"created_at"`
 }
 
 func (q *Queries) GetTransfer(ctx context.Context, id int64) (GetTransferRow, error) {
@@ -33,6 +39,11 @@ func (q *Queries) GetTransfer(ctx context.Context, id int64) (GetTransferRow, er
 		&i.FromAccountID,
 		&i.ToAccountID,
 		&i.Amount,
+		&i.ConvertedAmount,
+		&i.FromCurrency,
+		&i.ToCurrency,
+		&i.Status,
+		&i.ReversalOfTransferID,
 		&i.CreatedAt,
 	)
 	return i, err
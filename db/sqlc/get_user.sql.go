@@ -11,15 +11,16 @@ import (
 )
 
 const getUser = `-- name: GetUser :one
-SELECT username, full_name, email, created_at FROM users
+SELECT username, full_name, email, is_email_verified, created_at FROM users
 WHERE username = $1 LIMIT 1
 `
 
 type GetUserRow struct {
-	Username  string    `json:"username"`
-	FullName  string    `json:"full_name"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
+	Username        string    `json:"username"`
+	FullName        string    `json:"full_name"`
+	Email           string    `json:"email"`
+	IsEmailVerified bool      `json:"is_email_verified"`
+	CreatedAt       time.Time `json:"created_at"`
 }
 
 func (q *Queries) GetUser(ctx context.Context, username string) (GetUserRow, error) {
@@ -29,6 +30,7 @@ func (q *Queries) GetUser(ctx context.Context, username string) (GetUserRow, err
 		&i.Username,
 		&i.FullName,
 		&i.Email,
+		&i.IsEmailVerified,
 		&i.CreatedAt,
 	)
 	return i, err
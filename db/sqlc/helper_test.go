@@ -2,8 +2,12 @@ package db
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
@@ -211,3 +215,132 @@ func createRandomExchangeRate(t *testing.T) ExchangeRate {
 
 	return exchangeRate
 }
+
+func createDueScheduledTransfer(t *testing.T) ScheduledTransfer {
+	fromAccount := createAccountWithCurrency(t, "USD")
+	toAccount := createAccountWithCurrency(t, "USD")
+
+	scheduledTransfer, err := testQueries.CreateScheduledTransfer(context.Background(), CreateScheduledTransferParams{
+		Owner:         fromAccount.Owner,
+		FromAccountID: fromAccount.ID,
+		ToAccountID:   toAccount.ID,
+		Amount:        decimal.NewFromInt(10),
+		FromCurrency:  "USD",
+		ToCurrency:    "USD",
+		Frequency:     "monthly",
+		DayOfMonth:    pgtype.Int2{Int16: 31, Valid: true},
+		NextRunAt:     time.Now().Add(-time.Minute).UTC().Truncate(time.Second),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "active", scheduledTransfer.Status)
+	require.Equal(t, scheduledTransfer.NextRunAt, scheduledTransfer.OccurrenceAt)
+	require.False(t, scheduledTransfer.ClaimedUntil.Valid)
+
+	return scheduledTransfer
+}
+
+func createFxQuoteForUser(t *testing.T, username string, expiresAt time.Time) FxQuote {
+	quote, err := testQueries.CreateFxQuote(context.Background(), CreateFxQuoteParams{
+		Username:        username,
+		FromCurrency:    "USD",
+		ToCurrency:      "EUR",
+		Rate:            decimal.RequireFromString("0.85"),
+		Amount:          decimal.NewFromInt(5),
+		ConvertedAmount: decimal.RequireFromString("4.25"),
+		Fee:             decimal.RequireFromString("0.50"),
+		ExpiresAt:       expiresAt,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, quote.ID)
+	require.False(t, quote.ConsumedAt.Valid)
+
+	return quote
+}
+
+func authorizeTransferHold(t *testing.T, store Store, from Account, to Account, amount decimal.Decimal, fee decimal.Decimal, expiresAt time.Time) AuthorizeTransferTxResult {
+	result, err := store.AuthorizeTransferTx(context.Background(), AuthorizeTransferTxParams{
+		Username:        from.Owner,
+		FromAccountID:   from.ID,
+		ToAccountID:     to.ID,
+		Amount:          amount,
+		ConvertedAmount: amount,
+		ExchangeRate:    decimal.NewFromInt(1).Round(8),
+		FromCurrency:    from.Currency,
+		ToCurrency:      to.Currency,
+		Fee:             fee,
+		ExpiresAt:       expiresAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferHoldStatusAuthorized, result.Hold.Status)
+
+	return result
+}
+
+func newTransferTxParams(from Account, to Account, amount decimal.Decimal) TransferTxParams {
+	return TransferTxParams{
+		FromAccountID:   from.ID,
+		ToAccountID:     to.ID,
+		Amount:          amount,
+		ConvertedAmount: amount,
+		ExchangeRate:    decimal.NewFromInt(1).Round(8),
+		FromCurrency:    from.Currency,
+		ToCurrency:      to.Currency,
+		Username:        from.Owner,
+	}
+}
+
+func randomSessionParams(username string) CreateSessionParams {
+	// A session that was not rotated from another one starts its own family
+	sessionID := uuid.New()
+	return CreateSessionParams{
+		ID:           sessionID,
+		Username:     username,
+		RefreshToken: util.RandomString(32),
+		UserAgent:    "test",
+		ClientIp:     "127.0.0.1",
+		ExpiresAt:    time.Now().Add(time.Hour),
+		FamilyID:     sessionID,
+	}
+}
+
+func createRandomSession(t *testing.T, username string) Session {
+	session, err := testQueries.CreateSession(context.Background(), randomSessionParams(username))
+	require.NoError(t, err)
+	return session
+}
+
+func createRandomResetToken(t *testing.T, user CreateUserRow, expiresAt time.Time) []byte {
+	tokenHash := []byte(util.RandomString(32))
+	_, err := testQueries.CreateUserToken(context.Background(), CreateUserTokenParams{
+		Username:  user.Username,
+		Purpose:   UserTokenPurposePasswordReset,
+		TokenHash: tokenHash,
+		Email:     user.Email,
+		ExpiresAt: expiresAt,
+	})
+	require.NoError(t, err)
+	return tokenHash
+}
+
+func createRandomCurrency(t *testing.T, store Store) Currency {
+	// Codes only have three letters, so skip the ones an earlier run already added
+	for {
+		code := strings.ToUpper(util.RandomString(3))
+		_, err := testQueries.GetCurrency(context.Background(), code)
+		if err == nil {
+			continue
+		}
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+
+		currency, err := store.CreateCurrencyTx(context.Background(), CreateCurrencyParams{
+			Code:       code,
+			MinorUnits: 2,
+			Symbol:     code,
+			Enabled:    true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, code, currency.Code)
+
+		return currency
+	}
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// House account purposes, see the house_accounts table
+const (
+	HouseAccountPurposeFee     = "fee"
+	HouseAccountPurposeFX      = "fx"
+	HouseAccountPurposeFunding = "funding"
+)
+
+// houseAccountOwners are the system users that own the house accounts of each purpose
+var houseAccountOwners = []struct {
+	purpose string
+	owner   string
+}{
+	{purpose: HouseAccountPurposeFee, owner: "house_fees"},
+	{purpose: HouseAccountPurposeFX, owner: "house_fx"},
+	{purpose: HouseAccountPurposeFunding, owner: "house_funding"},
+}
+
+// houseLeg is one posting to a house account
+type houseLeg struct {
+	accountID int64
+	amount    decimal.Decimal
+}
+
+// transferHouseLegs returns the house postings that balance a transfer in each currency.
+// The fee account of the source currency receives the fee, and on cross-currency transfers the
+// FX accounts buy the source amount and sell the converted amount
+func transferHouseLegs(ctx context.Context, q *Queries, fromAccount Account, toAccount Account, arg TransferTxParams) ([]houseLeg, error) {
+	var legs []houseLeg
+
+	if arg.Fee.IsPositive() {
+		feeAccountID, err := lookupHouseAccountID(ctx, q, fromAccount.Currency, HouseAccountPurposeFee)
+		if err != nil {
+			return nil, err
+		}
+		legs = append(legs, houseLeg{accountID: feeAccountID, amount: arg.Fee})
+	}
+
+	if fromAccount.Currency != toAccount.Currency {
+		fxFromAccountID, err := lookupHouseAccountID(ctx, q, fromAccount.Currency, HouseAccountPurposeFX)
+		if err != nil {
+			return nil, err
+		}
+		fxToAccountID, err := lookupHouseAccountID(ctx, q, toAccount.Currency, HouseAccountPurposeFX)
+		if err != nil {
+			return nil, err
+		}
+		legs = append(legs,
+			houseLeg{accountID: fxFromAccountID, amount: arg.Amount},
+			houseLeg{accountID: fxToAccountID, amount: arg.ConvertedAmount.Neg()},
+		)
+	}
+
+	return legs, nil
+}
+
+func lookupHouseAccountID(ctx context.Context, q *Queries, currency string, purpose string) (int64, error) {
+	accountID, err := q.GetHouseAccountID(ctx, GetHouseAccountIDParams{
+		Currency: currency,
+		Purpose:  purpose,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s house account not found for %s: %w", purpose, currency, err)
+	}
+	return accountID, nil
+}
+
+// postHouseLegs writes the house entries and balances of a transfer.
+// Customer accounts are always locked first and house accounts after them in ID order,
+// so concurrent transfers sharing a house account cannot deadlock
+func postHouseLegs(ctx context.Context, q *Queries, transferID pgtype.Int8, legs []houseLeg) ([]Entry, error) {
+	sort.Slice(legs, func(i, j int) bool {
+		return legs[i].accountID < legs[j].accountID
+	})
+
+	entries := make([]Entry, 0, len(legs))
+	for _, leg := range legs {
+		_, err := q.GetAccountForUpdate(ctx, leg.accountID)
+		if err != nil {
+			return nil, fmt.Errorf("house account %d not found: %w", leg.accountID, err)
+		}
+
+		entry, err := q.CreateEntry(ctx, CreateEntryParams{
+			AccountID:  leg.accountID,
+			Amount:     leg.amount,
+			TransferID: transferID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = q.AddAccountBalance(ctx, AddAccountBalanceParams{
+			ID:     leg.accountID,
+			Amount: leg.amount,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -10,7 +10,7 @@ import (
 )
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, held_amount FROM accounts
 WHERE owner = $1
 ORDER BY id
 LIMIT $2
@@ -38,6 +38,7 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.HeldAmount,
 		); err != nil {
 			return nil, err
 		}
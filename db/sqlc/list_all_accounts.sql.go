@@ -10,7 +10,7 @@ import (
 )
 
 const listAllAccounts = `-- name: ListAllAccounts :many
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, held_amount FROM accounts
 ORDER BY id
 LIMIT $1
 OFFSET $2
@@ -36,6 +36,7 @@ func (q *Queries) ListAllAccounts(ctx context.Context, arg ListAllAccountsParams
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.HeldAmount,
 		); err != nil {
 			return nil, err
 		}
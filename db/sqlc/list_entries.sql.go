@@ -10,7 +10,7 @@ import (
 )
 
 const listEntries = `-- name: ListEntries :many
-SELECT id, account_id, amount, created_at FROM entries
+SELECT id, account_id, amount, created_at, transfer_id FROM entries
 WHERE account_id = $1
 ORDER BY id
 LIMIT $2
@@ -37,6 +37,7 @@ func (q *Queries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Ent
 			&i.AccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.TransferID,
 		); err != nil {
 			return nil, err
 		}
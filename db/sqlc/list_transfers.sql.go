@@ -9,40 +9,111 @@ import (
 	"context"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 )
 
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
-WHERE 
-    from_account_id = $1 OR
-    to_account_id = $2
-ORDER BY id
-LIMIT $3
-OFFSET $4
+SELECT
+  t.id,
+  t.from_account_id,
+  t.to_account_id,
+  t.amount,
+  t.converted_amount,
+  t.exchange_rate,
+  t.from_currency,
+  t.to_currency,
+  t.fee,
+  t.created_at,
+  t.pivot_currency,
+  t.from_pivot_rate,
+  t.pivot_to_rate,
+  t.fee_schedule_id,
+  t.mid_market_rate,
+  t.markup_bps,
+  t.status,
+  t.reversal_of_transfer_id,
+  t.refunded_fee,
+  fa.owner AS from_owner,
+  ta.owner AS to_owner
+FROM transfers t
+JOIN accounts fa ON fa.id = t.from_account_id
+JOIN accounts ta ON ta.id = t.to_account_id
+WHERE
+  (
+    ($1::boolean AND (fa.id = $2::bigint OR fa.owner = $3::varchar))
+    OR
+    ($4::boolean AND (ta.id = $2::bigint OR ta.owner = $3::varchar))
+  )
+  AND ($5::varchar IS NULL OR t.from_currency = $5::varchar)
+  AND ($6::varchar IS NULL OR t.to_currency = $6::varchar)
+  AND ($7::timestamptz IS NULL OR t.created_at >= $7::timestamptz)
+  AND ($8::timestamptz IS NULL OR t.created_at < $8::timestamptz)
+  AND ($9::decimal IS NULL OR t.amount >= $9::decimal)
+  AND ($10::decimal IS NULL OR t.amount <= $10::decimal)
+  AND (
+    $11::timestamptz IS NULL
+    OR (t.created_at, t.id) < ($11::timestamptz, $12::bigint)
+  )
+ORDER BY t.created_at DESC, t.id DESC
+LIMIT $13::int
 `
 
 type ListTransfersParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Limit         int32 `json:"limit"`
-	Offset        int32 `json:"offset"`
+	IncludeSent     bool               `json:"include_sent"`
+	AccountID       pgtype.Int8        `json:"account_id"`
+	Owner           pgtype.Text        `json:"owner"`
+	IncludeReceived bool               `json:"include_received"`
+	FromCurrency    pgtype.Text        `json:"from_currency"`
+	ToCurrency      pgtype.Text        `json:"to_currency"`
+	StartDate       pgtype.Timestamptz `json:"start_date"`
+	EndDate         pgtype.Timestamptz `json:"end_date"`
+	MinAmount       pgtype.Numeric     `json:"min_amount"`
+	MaxAmount       pgtype.Numeric     `json:"max_amount"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        pgtype.Int8        `json:"cursor_id"`
+	PageLimit       int32              `json:"page_limit"`
 }
 
 type ListTransfersRow struct {
-	ID            int64           `json:"id"`
-	FromAccountID int64           `json:"from_account_id"`
-	ToAccountID   int64           `json:"to_account_id"`
-	Amount        decimal.Decimal `json:"amount"`
-	CreatedAt     time.Time       `json:"created_at"`
+	ID                   int64               `json:"id"`
+	FromAccountID        int64               `json:"from_account_id"`
+	ToAccountID          int64               `json:"to_account_id"`
+	Amount               decimal.Decimal     `json:"amount"`
+	ConvertedAmount      decimal.Decimal     `json:"converted_amount"`
+	ExchangeRate         decimal.Decimal     `json:"exchange_rate"`
+	FromCurrency         pgtype.Text         `json:"from_currency"`
+	ToCurrency           pgtype.Text         `json:"to_currency"`
+	Fee                  decimal.Decimal     `json:"fee"`
+	CreatedAt            time.Time           `json:"created_at"`
+	PivotCurrency        pgtype.Text         `json:"pivot_currency"`
+	FromPivotRate        decimal.NullDecimal `json:"from_pivot_rate"`
+	PivotToRate          decimal.NullDecimal `json:"pivot_to_rate"`
+	FeeScheduleID        pgtype.Int8         `json:"fee_schedule_id"`
+	MidMarketRate        decimal.NullDecimal `json:"mid_market_rate"`
+	MarkupBps            pgtype.Int4         `json:"markup_bps"`
+	Status               string              `json:"status"`
+	ReversalOfTransferID pgtype.Int8         `json:"reversal_of_transfer_id"`
+	RefundedFee          decimal.Decimal     `json:"refunded_fee"`
+	FromOwner            string              `json:"from_owner"`
+	ToOwner              string              `json:"to_owner"`
 }
 
 func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]ListTransfersRow, error) {
 	rows, err := q.db.Query(ctx, listTransfers,
-		arg.FromAccountID,
-		arg.ToAccountID,
-		arg.Limit,
-		arg.Offset,
+		arg.IncludeSent,
+		arg.AccountID,
+		arg.Owner,
+		arg.IncludeReceived,
+		arg.FromCurrency,
+		arg.ToCurrency,
+		arg.StartDate,
+		arg.EndDate,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.PageLimit,
 	)
 	if err != nil {
 		return nil, err
@@ -56,7 +127,23 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.FromAccountID,
 			&i.ToAccountID,
 			&i.Amount,
+			&i.ConvertedAmount,
+			&i.ExchangeRate,
+			&i.FromCurrency,
+			&i.ToCurrency,
+			&i.Fee,
 			&i.CreatedAt,
+			&i.PivotCurrency,
+			&i.FromPivotRate,
+			&i.PivotToRate,
+			&i.FeeScheduleID,
+			&i.MidMarketRate,
+			&i.MarkupBps,
+			&i.Status,
+			&i.ReversalOfTransferID,
+			&i.RefundedFee,
+			&i.FromOwner,
+			&i.ToOwner,
 		); err != nil {
 			return nil, err
 		}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"math/big"
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgtype"
@@ -51,19 +52,83 @@ func TestListTransfers(t *testing.T) {
 
 	// List transfers for account1 (both sent and received)
 	arg := ListTransfersParams{
-		FromAccountID: account1.ID,
-		ToAccountID:   account1.ID,
-		Limit:         10,
-		Offset:        0,
+		IncludeSent:     true,
+		IncludeReceived: true,
+		AccountID:       pgtype.Int8{Int64: account1.ID, Valid: true},
+		PageLimit:       10,
 	}
 
 	transfers, err := testQueries.ListTransfers(context.Background(), arg)
 	require.NoError(t, err)
-	require.NotEmpty(t, transfers)
+	require.Len(t, transfers, 8)
 
 	// Verify all transfers involve account1
 	for _, transfer := range transfers {
 		require.NotEmpty(t, transfer)
 		require.True(t, transfer.FromAccountID == account1.ID || transfer.ToAccountID == account1.ID)
+		require.False(t, transfer.ExchangeRate.IsZero())
 	}
+
+	// Only sent transfers
+	arg.IncludeReceived = false
+	transfers, err = testQueries.ListTransfers(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, transfers, 5)
+	for _, transfer := range transfers {
+		require.Equal(t, account1.ID, transfer.FromAccountID)
+		require.Equal(t, account1.Owner, transfer.FromOwner)
+	}
+}
+
+func TestListTransfersKeysetPagination(t *testing.T) {
+	account1 := createRandomAccount(t)
+	account2 := createRandomAccount(t)
+
+	for i := 0; i < 5; i++ {
+		arg := CreateTransferParams{
+			FromAccountID:   account1.ID,
+			ToAccountID:     account2.ID,
+			Amount:          decimal.NewFromInt(int64(10 * (i + 1))),
+			ConvertedAmount: decimal.NewFromInt(int64(10 * (i + 1))),
+			ExchangeRate:    decimal.NewFromFloat(1.0).Round(8),
+		}
+		_, err := testQueries.CreateTransfer(context.Background(), arg)
+		require.NoError(t, err)
+	}
+
+	arg := ListTransfersParams{
+		IncludeSent:     true,
+		IncludeReceived: true,
+		Owner:           pgtype.Text{String: account1.Owner, Valid: true},
+		PageLimit:       2,
+	}
+
+	seen := map[int64]bool{}
+	for page := 0; page < 3; page++ {
+		transfers, err := testQueries.ListTransfers(context.Background(), arg)
+		require.NoError(t, err)
+		require.NotEmpty(t, transfers)
+
+		for _, transfer := range transfers {
+			require.False(t, seen[transfer.ID])
+			seen[transfer.ID] = true
+		}
+
+		last := transfers[len(transfers)-1]
+		arg.CursorCreatedAt = pgtype.Timestamptz{Time: last.CreatedAt, Valid: true}
+		arg.CursorID = pgtype.Int8{Int64: last.ID, Valid: true}
+	}
+	require.Len(t, seen, 5)
+
+	// Amount range filter
+	arg = ListTransfersParams{
+		IncludeSent: true,
+		AccountID:   pgtype.Int8{Int64: account1.ID, Valid: true},
+		MinAmount:   pgtype.Numeric{Int: big.NewInt(20), Valid: true},
+		MaxAmount:   pgtype.Numeric{Int: big.NewInt(40), Valid: true},
+		PageLimit:   10,
+	}
+	transfers, err := testQueries.ListTransfers(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, transfers, 3)
 }
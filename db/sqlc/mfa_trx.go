@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+)
+
+// EnableMfaTxParams contains the input parameters of the enable MFA transaction
+type EnableMfaTxParams struct {
+	Username string `json:"username"`
+	// Time step of the TOTP code that confirms the enrolment, it cannot be used again
+	TotpStep int64 `json:"totp_step"`
+	// Hashes of the recovery codes issued with the enrolment
+	RecoveryCodeHashes [][]byte `json:"recovery_code_hashes"`
+}
+
+// EnableMfaTx turns on the pending TOTP enrolment of a user and replaces their recovery codes.
+// pgx.ErrNoRows is returned when there is no pending enrolment or the code's time step was used
+func (store *SQLStore) EnableMfaTx(ctx context.Context, arg EnableMfaTxParams) (UserMfa, error) {
+	var result UserMfa
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = q.EnableUserMfa(ctx, EnableUserMfaParams{
+			Username:     arg.Username,
+			LastUsedStep: arg.TotpStep,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteMfaRecoveryCodes(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		for _, codeHash := range arg.RecoveryCodeHashes {
+			err = q.CreateMfaRecoveryCode(ctx, CreateMfaRecoveryCodeParams{
+				Username: arg.Username,
+				CodeHash: codeHash,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnableMfaTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	pending, err := testQueries.CreatePendingUserMfa(context.Background(), CreatePendingUserMfaParams{
+		Username:        user.Username,
+		EncryptedSecret: []byte("encrypted secret"),
+	})
+	require.NoError(t, err)
+	require.False(t, pending.EnabledAt.Valid)
+
+	enabled, err := store.EnableMfaTx(context.Background(), EnableMfaTxParams{
+		Username:           user.Username,
+		TotpStep:           100,
+		RecoveryCodeHashes: [][]byte{[]byte("code one"), []byte("code two")},
+	})
+	require.NoError(t, err)
+	require.True(t, enabled.EnabledAt.Valid)
+	require.Equal(t, int64(100), enabled.LastUsedStep)
+
+	count, err := testQueries.CountUnusedMfaRecoveryCodes(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	// An enabled enrolment cannot be confirmed again
+	_, err = store.EnableMfaTx(context.Background(), EnableMfaTxParams{Username: user.Username, TotpStep: 101})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// A time step is only accepted once
+	_, err = testQueries.UseTotpStep(context.Background(), UseTotpStepParams{Username: user.Username, LastUsedStep: 100})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+	_, err = testQueries.UseTotpStep(context.Background(), UseTotpStepParams{Username: user.Username, LastUsedStep: 101})
+	require.NoError(t, err)
+
+	// A recovery code is only accepted once
+	_, err = testQueries.UseMfaRecoveryCode(context.Background(), UseMfaRecoveryCodeParams{Username: user.Username, CodeHash: []byte("code one")})
+	require.NoError(t, err)
+	_, err = testQueries.UseMfaRecoveryCode(context.Background(), UseMfaRecoveryCodeParams{Username: user.Username, CodeHash: []byte("code one")})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	err = testQueries.DeleteUserMfa(context.Background(), user.Username)
+	require.NoError(t, err)
+	_, err = testQueries.GetUserMfa(context.Background(), user.Username)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+}
@@ -20,6 +20,21 @@ type Account struct {
 	Balance   decimal.Decimal `json:"balance"`
 	Currency  string          `json:"currency"`
 	CreatedAt time.Time       `json:"created_at"`
+	// Balance reserved by authorized transfer holds
+	HeldAmount decimal.Decimal `json:"held_amount"`
+}
+
+// Currencies accounts, transfers and exchange rates can use
+type Currency struct {
+	// ISO 4217 currency code
+	Code string `json:"code"`
+	// Decimal places of the currency, amounts are rounded to it
+	MinorUnits int32  `json:"minor_units"`
+	Symbol     string `json:"symbol"`
+	// Disabled currencies are kept for existing accounts but rejected by new requests
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type Entry struct {
@@ -28,6 +43,8 @@ type Entry struct {
 	// Entry amount (positive for credits, negative for debits)
 	Amount    decimal.Decimal `json:"amount"`
 	CreatedAt time.Time       `json:"created_at"`
+	// Transfer that produced this entry, NULL for entries not created by a transfer
+	TransferID pgtype.Int8 `json:"transfer_id"`
 }
 
 type ExchangeRate struct {
@@ -39,6 +56,185 @@ type ExchangeRate struct {
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
 }
 
+// Append-only log of exchange rates, one row each time a rate is set
+type ExchangeRateHistory struct {
+	ID int64 `json:"id"`
+	// Row in exchange_rates, kept without a foreign key so history survives deleted pairs
+	ExchangeRateID int64           `json:"exchange_rate_id"`
+	FromCurrency   string          `json:"from_currency"`
+	ToCurrency     string          `json:"to_currency"`
+	Rate           decimal.Decimal `json:"rate"`
+	// When the rate became live, it stays live until the next row for the pair
+	ValidFrom time.Time `json:"valid_from"`
+}
+
+// Versioned transfer fees per currency pair
+type FeeSchedule struct {
+	ID           int64           `json:"id"`
+	FromCurrency string          `json:"from_currency"`
+	ToCurrency   string          `json:"to_currency"`
+	Version      int32           `json:"version"`
+	FixedFee     decimal.Decimal `json:"fixed_fee"`
+	// Percentage of the amount sent, 1.5 is 1.5%
+	Percentage decimal.Decimal `json:"percentage"`
+	MinFee     decimal.Decimal `json:"min_fee"`
+	// Cap on the fee, NULL for no cap
+	MaxFee decimal.NullDecimal `json:"max_fee"`
+	// False once the schedule was replaced by a newer version or deleted
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FeeScheduleTier struct {
+	ID            int64 `json:"id"`
+	FeeScheduleID int64 `json:"fee_schedule_id"`
+	// Smallest amount sent the tier applies to
+	MinAmount  decimal.Decimal `json:"min_amount"`
+	FixedFee   decimal.Decimal `json:"fixed_fee"`
+	Percentage decimal.Decimal `json:"percentage"`
+}
+
+// Deposits into and withdrawals out of customer accounts
+type FundingTransaction struct {
+	ID        int64 `json:"id"`
+	AccountID int64 `json:"account_id"`
+	// deposit or withdrawal
+	Kind string `json:"kind"`
+	// Positive amount moved, in the account currency
+	Amount   decimal.Decimal `json:"amount"`
+	Currency string          `json:"currency"`
+	// Account balance right after the operation
+	BalanceAfter decimal.Decimal `json:"balance_after"`
+	// Reference of the payment provider, unique per funding source
+	ExternalReference string `json:"external_reference"`
+	// Rail the money moved through, e.g. bank_transfer or card
+	FundingSource string `json:"funding_source"`
+	// Entry posted on the customer account
+	EntryID int64 `json:"entry_id"`
+	// Balancing entry posted on the house funding account
+	HouseEntryID int64     `json:"house_entry_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// FX markup per currency pair on top of the mid-market rate
+type FxMarkup struct {
+	ID           int64  `json:"id"`
+	FromCurrency string `json:"from_currency"`
+	ToCurrency   string `json:"to_currency"`
+	// Markup in basis points, 100 is 1%
+	MarkupBps int32     `json:"markup_bps"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Exchange rates quoted to a user by /exchange-rates/quotes, honoured by transfers until they expire
+type FxQuote struct {
+	ID           int64           `json:"id"`
+	Username     string          `json:"username"`
+	FromCurrency string          `json:"from_currency"`
+	ToCurrency   string          `json:"to_currency"`
+	Rate         decimal.Decimal `json:"rate"`
+	// Amount to send in from_currency
+	Amount decimal.Decimal `json:"amount"`
+	// Amount to receive in to_currency
+	ConvertedAmount decimal.Decimal `json:"converted_amount"`
+	Fee             decimal.Decimal `json:"fee"`
+	ExpiresAt       time.Time       `json:"expires_at"`
+	// When a transfer used the quote, a quote can only be used once
+	ConsumedAt pgtype.Timestamptz `json:"consumed_at"`
+	// Transfer that used the quote
+	TransferID pgtype.Int8 `json:"transfer_id"`
+	CreatedAt  time.Time   `json:"created_at"`
+	// Currency the quoted rate was derived through, NULL for a direct rate
+	PivotCurrency pgtype.Text `json:"pivot_currency"`
+	// Rate from from_currency to pivot_currency
+	FromPivotRate decimal.NullDecimal `json:"from_pivot_rate"`
+	// Rate from pivot_currency to to_currency
+	PivotToRate decimal.NullDecimal `json:"pivot_to_rate"`
+	// Fee schedule version the quoted fee was charged with, NULL for the default fee
+	FeeScheduleID pgtype.Int8 `json:"fee_schedule_id"`
+	// Mid-market rate the quoted rate was derived from
+	MidMarketRate decimal.NullDecimal `json:"mid_market_rate"`
+	// Markup between the mid-market rate and the quoted rate in basis points
+	MarkupBps pgtype.Int4 `json:"markup_bps"`
+}
+
+// Bank-owned accounts that take the other side of fees and currency conversions
+type HouseAccount struct {
+	// Currency of the house account
+	Currency string `json:"currency"`
+	// fee for fee revenue, fx for currency conversion settlement
+	Purpose string `json:"purpose"`
+	// Account that holds the house balance
+	AccountID int64     `json:"account_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Client supplied Idempotency-Key values and the response they produced
+type IdempotencyKey struct {
+	Username       string `json:"username"`
+	IdempotencyKey string `json:"idempotency_key"`
+	// SHA-256 of the request body the key was first used with
+	RequestHash string `json:"request_hash"`
+	TransferID  int64  `json:"transfer_id"`
+	// HTTP status code returned for the original request
+	ResponseStatus int32 `json:"response_status"`
+	// Snapshot of the transfer transaction result replayed on retries
+	ResponseBody []byte    `json:"response_body"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// MFA tokens of logins waiting for their second factor, deleted when the login completes
+type MfaChallenge struct {
+	// ID of the MFA token
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Single-use recovery codes of users with two-factor authentication
+type MfaRecoveryCode struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// SHA-256 of the normalized code, the code itself is never stored
+	CodeHash  []byte             `json:"code_hash"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+// Transfers run by the scheduler at a future date or on a recurring schedule
+type ScheduledTransfer struct {
+	ID            int64           `json:"id"`
+	Owner         string          `json:"owner"`
+	FromAccountID int64           `json:"from_account_id"`
+	ToAccountID   int64           `json:"to_account_id"`
+	Amount        decimal.Decimal `json:"amount"`
+	FromCurrency  string          `json:"from_currency"`
+	ToCurrency    string          `json:"to_currency"`
+	// once, weekly or monthly
+	Frequency string `json:"frequency"`
+	// Day monthly transfers run on, moved to the last day of shorter months
+	DayOfMonth pgtype.Int2 `json:"day_of_month"`
+	// Scheduled time of the current occurrence, later occurrences are counted from it
+	OccurrenceAt time.Time `json:"occurrence_at"`
+	// When the current occurrence is next attempted, later than occurrence_at on retries
+	NextRunAt time.Time `json:"next_run_at"`
+	// active, paused, completed, failed or cancelled
+	Status string `json:"status"`
+	// Failed attempts for the current occurrence
+	Attempts  int32              `json:"attempts"`
+	LastRunAt pgtype.Timestamptz `json:"last_run_at"`
+	// Error of the most recent failed attempt
+	LastError pgtype.Text `json:"last_error"`
+	// Transfer created by the most recent successful run
+	LastTransferID pgtype.Int8 `json:"last_transfer_id"`
+	// Lease held by a worker while it runs the transfer
+	ClaimedUntil pgtype.Timestamptz `json:"claimed_until"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+}
+
 type Session struct {
 	ID           uuid.UUID `json:"id"`
 	Username     string    `json:"username"`
@@ -49,6 +245,10 @@ type Session struct {
 	ExpiresAt    time.Time `json:"expires_at"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+	// ID of the session the login started with, shared by every session rotated from it
+	FamilyID uuid.UUID `json:"family_id"`
+	// Why the session was blocked: rotated, signed_out or revoked, NULL while it is not blocked
+	BlockedReason pgtype.Text `json:"blocked_reason"`
 }
 
 type Transfer struct {
@@ -65,6 +265,107 @@ type Transfer struct {
 	CreatedAt       time.Time       `json:"created_at"`
 	// Transaction fee amount in source currency
 	Fee decimal.Decimal `json:"fee"`
+	// Currency the exchange rate was derived through, NULL for a direct rate
+	PivotCurrency pgtype.Text `json:"pivot_currency"`
+	// Rate from from_currency to pivot_currency
+	FromPivotRate decimal.NullDecimal `json:"from_pivot_rate"`
+	// Rate from pivot_currency to to_currency
+	PivotToRate decimal.NullDecimal `json:"pivot_to_rate"`
+	// Fee schedule version the fee was charged with, NULL for the default fee
+	FeeScheduleID pgtype.Int8 `json:"fee_schedule_id"`
+	// Mid-market rate the customer exchange_rate was derived from
+	MidMarketRate decimal.NullDecimal `json:"mid_market_rate"`
+	// Markup between the mid-market rate and exchange_rate in basis points
+	MarkupBps pgtype.Int4 `json:"markup_bps"`
+	// completed, reversed or partially_reversed
+	Status string `json:"status"`
+	// Transfer this transfer reverses, NULL for transfers that are not reversals
+	ReversalOfTransferID pgtype.Int8 `json:"reversal_of_transfer_id"`
+	// Fee of the original transfer refunded to the sender by this reversal
+	RefundedFee decimal.Decimal `json:"refunded_fee"`
+}
+
+// Batches of transfers from one source account
+type TransferBatch struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	// all_or_nothing or best_effort
+	Mode string `json:"mode"`
+	// completed when every item succeeded, partially_completed when some did, failed when none did
+	Status         string `json:"status"`
+	ItemCount      int32  `json:"item_count"`
+	SucceededCount int32  `json:"succeeded_count"`
+	FailedCount    int32  `json:"failed_count"`
+	// Sum of amount + fee of the items that succeeded
+	TotalDebited decimal.Decimal `json:"total_debited"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+type TransferBatchItem struct {
+	ID      int64 `json:"id"`
+	BatchID int64 `json:"batch_id"`
+	// Position of the item in the batch request, from 0
+	ItemIndex       int32           `json:"item_index"`
+	ToAccountID     int64           `json:"to_account_id"`
+	Amount          decimal.Decimal `json:"amount"`
+	ConvertedAmount decimal.Decimal `json:"converted_amount"`
+	Fee             decimal.Decimal `json:"fee"`
+	ToCurrency      string          `json:"to_currency"`
+	// completed, failed, or skipped when an all_or_nothing batch was rolled back by another item
+	Status     string      `json:"status"`
+	TransferID pgtype.Int8 `json:"transfer_id"`
+	// Why the transfer of a failed item was refused
+	Error     pgtype.Text `json:"error"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// Two-phase transfers, authorized first and captured or voided later
+type TransferHold struct {
+	ID            int64  `json:"id"`
+	Owner         string `json:"owner"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	// Amount to transfer in source currency, amount + fee is held on the source account
+	Amount decimal.Decimal `json:"amount"`
+	// Amount the recipient receives on capture, priced at authorization
+	ConvertedAmount decimal.Decimal     `json:"converted_amount"`
+	ExchangeRate    decimal.Decimal     `json:"exchange_rate"`
+	FromCurrency    string              `json:"from_currency"`
+	ToCurrency      string              `json:"to_currency"`
+	Fee             decimal.Decimal     `json:"fee"`
+	FeeScheduleID   pgtype.Int8         `json:"fee_schedule_id"`
+	PivotCurrency   pgtype.Text         `json:"pivot_currency"`
+	FromPivotRate   decimal.NullDecimal `json:"from_pivot_rate"`
+	PivotToRate     decimal.NullDecimal `json:"pivot_to_rate"`
+	MidMarketRate   decimal.NullDecimal `json:"mid_market_rate"`
+	MarkupBps       pgtype.Int4         `json:"markup_bps"`
+	// authorized, captured, voided or expired
+	Status string `json:"status"`
+	// Transfer created when the hold was captured
+	TransferID pgtype.Int8 `json:"transfer_id"`
+	// The hold is released when it has not been captured by then
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Transfer limits per user tier and currency
+type TransferLimit struct {
+	Tier     string `json:"tier"`
+	Currency string `json:"currency"`
+	// Largest amount of a single transfer, NULL for no limit
+	PerTransactionAmount decimal.NullDecimal `json:"per_transaction_amount"`
+	// Largest total amount transferred in a rolling 24 hours, NULL for no limit
+	DailyAmount decimal.NullDecimal `json:"daily_amount"`
+	// Most transfers in a rolling 24 hours, NULL for no limit
+	DailyCount pgtype.Int4 `json:"daily_count"`
+	// Largest total amount transferred in a rolling 30 days, NULL for no limit
+	MonthlyAmount decimal.NullDecimal `json:"monthly_amount"`
+	// Most transfers in a rolling 30 days, NULL for no limit
+	MonthlyCount pgtype.Int4 `json:"monthly_count"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
 }
 
 // User authentication and profile information
@@ -83,4 +384,52 @@ type User struct {
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	// User account creation timestamp
 	CreatedAt time.Time `json:"created_at"`
+	// Tier of the user, selects the transfer limits that apply to them
+	Tier string `json:"tier"`
+}
+
+// TOTP two-factor authentication of users
+type UserMfa struct {
+	Username string `json:"username"`
+	// TOTP secret encrypted with XChaCha20-Poly1305, bound to the username
+	EncryptedSecret []byte `json:"encrypted_secret"`
+	// When the user confirmed enrolment with a first code, null while pending
+	EnabledAt pgtype.Timestamptz `json:"enabled_at"`
+	// Time step of the last accepted code, codes of earlier steps are refused
+	LastUsedStep int64     `json:"last_used_step"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	// Attempts at a second factor since the last accepted one, reset when the user is locked out
+	FailedAttempts int32 `json:"failed_attempts"`
+	// Second factors are refused until then after too many failed attempts
+	LockedUntil pgtype.Timestamptz `json:"locked_until"`
+}
+
+// Single-use tokens mailed to users
+type UserToken struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// What the token is for, email_verification or password_reset
+	Purpose string `json:"purpose"`
+	// SHA-256 of the token, the token itself is never stored
+	TokenHash []byte `json:"token_hash"`
+	// Address the token was sent to, a token no longer applies once the user changes email
+	Email     string    `json:"email"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// When the token was used, a token can only be used once
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+// Transfer limits of a user overriding the limits of their tier in a currency
+type UserTransferLimit struct {
+	Username             string              `json:"username"`
+	Currency             string              `json:"currency"`
+	PerTransactionAmount decimal.NullDecimal `json:"per_transaction_amount"`
+	DailyAmount          decimal.NullDecimal `json:"daily_amount"`
+	DailyCount           pgtype.Int4         `json:"daily_count"`
+	MonthlyAmount        decimal.NullDecimal `json:"monthly_amount"`
+	MonthlyCount         pgtype.Int4         `json:"monthly_count"`
+	CreatedAt            time.Time           `json:"created_at"`
+	UpdatedAt            time.Time           `json:"updated_at"`
 }
@@ -6,38 +6,125 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 )
 
 type Querier interface {
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (decimal.Decimal, error)
+	AddAccountHeldAmount(ctx context.Context, arg AddAccountHeldAmountParams) (decimal.Decimal, error)
+	BlockActiveSession(ctx context.Context, id uuid.UUID) (Session, error)
+	BlockSession(ctx context.Context, id uuid.UUID) error
+	BlockSessionFamily(ctx context.Context, familyID uuid.UUID) error
+	BlockUserSessions(ctx context.Context, username string) error
+	ClaimDueScheduledTransfers(ctx context.Context, arg ClaimDueScheduledTransfersParams) ([]ScheduledTransfer, error)
+	ConsumeFxQuote(ctx context.Context, arg ConsumeFxQuoteParams) (FxQuote, error)
+	CountMfaAttempt(ctx context.Context, arg CountMfaAttemptParams) (UserMfa, error)
+	CountUnusedMfaRecoveryCodes(ctx context.Context, username string) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateCurrency(ctx context.Context, arg CreateCurrencyParams) (Currency, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
 	CreateExchangeRate(ctx context.Context, arg CreateExchangeRateParams) (ExchangeRate, error)
+	CreateFeeSchedule(ctx context.Context, arg CreateFeeScheduleParams) (FeeSchedule, error)
+	CreateFeeScheduleTier(ctx context.Context, arg CreateFeeScheduleTierParams) (FeeScheduleTier, error)
+	CreateFundingTransaction(ctx context.Context, arg CreateFundingTransactionParams) (FundingTransaction, error)
+	CreateFxQuote(ctx context.Context, arg CreateFxQuoteParams) (FxQuote, error)
+	CreateHouseAccount(ctx context.Context, arg CreateHouseAccountParams) (HouseAccount, error)
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (IdempotencyKey, error)
+	CreateMfaChallenge(ctx context.Context, arg CreateMfaChallengeParams) (MfaChallenge, error)
+	CreateMfaRecoveryCode(ctx context.Context, arg CreateMfaRecoveryCodeParams) error
+	CreatePendingUserMfa(ctx context.Context, arg CreatePendingUserMfaParams) (UserMfa, error)
+	CreateScheduledTransfer(ctx context.Context, arg CreateScheduledTransferParams) (ScheduledTransfer, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (CreateTransferRow, error)
+	CreateTransferBatch(ctx context.Context, arg CreateTransferBatchParams) (TransferBatch, error)
+	CreateTransferBatchItem(ctx context.Context, arg CreateTransferBatchItemParams) (TransferBatchItem, error)
+	CreateTransferHold(ctx context.Context, arg CreateTransferHoldParams) (TransferHold, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error)
+	CreateUserToken(ctx context.Context, arg CreateUserTokenParams) (UserToken, error)
+	DeactivateFeeSchedule(ctx context.Context, arg DeactivateFeeScheduleParams) (FeeSchedule, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteExchangeRate(ctx context.Context, arg DeleteExchangeRateParams) (int64, error)
+	DeleteFxMarkup(ctx context.Context, arg DeleteFxMarkupParams) (int64, error)
+	DeleteMfaRecoveryCodes(ctx context.Context, username string) error
 	DeleteUser(ctx context.Context, username string) error
+	DeleteUserMfa(ctx context.Context, username string) error
+	DeleteUserTransferLimit(ctx context.Context, arg DeleteUserTransferLimitParams) (int64, error)
+	EnableUserMfa(ctx context.Context, arg EnableUserMfaParams) (UserMfa, error)
 	GetAccount(ctx context.Context, id int64) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetActiveFeeSchedule(ctx context.Context, arg GetActiveFeeScheduleParams) (FeeSchedule, error)
+	GetCurrency(ctx context.Context, code string) (Currency, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
 	GetExchangeRate(ctx context.Context, arg GetExchangeRateParams) (ExchangeRate, error)
+	GetExchangeRateAsOf(ctx context.Context, arg GetExchangeRateAsOfParams) (ExchangeRateHistory, error)
+	GetFundingTransactionByReference(ctx context.Context, arg GetFundingTransactionByReferenceParams) (FundingTransaction, error)
+	GetFxMarkup(ctx context.Context, arg GetFxMarkupParams) (FxMarkup, error)
+	GetFxQuote(ctx context.Context, id int64) (FxQuote, error)
+	GetHouseAccountID(ctx context.Context, arg GetHouseAccountIDParams) (int64, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetScheduledTransfer(ctx context.Context, id int64) (ScheduledTransfer, error)
 	GetSession(ctx context.Context, id uuid.UUID) (GetSessionRow, error)
+	GetTierTransferLimit(ctx context.Context, arg GetTierTransferLimitParams) (TransferLimit, error)
 	GetTransfer(ctx context.Context, id int64) (GetTransferRow, error)
+	GetTransferBatch(ctx context.Context, id int64) (TransferBatch, error)
+	GetTransferForUpdate(ctx context.Context, id int64) (Transfer, error)
+	GetTransferHold(ctx context.Context, id int64) (TransferHold, error)
+	GetTransferHoldForUpdate(ctx context.Context, id int64) (TransferHold, error)
+	GetTransferReversalTotals(ctx context.Context, reversalOfTransferID pgtype.Int8) (GetTransferReversalTotalsRow, error)
+	GetTransferUsage(ctx context.Context, arg GetTransferUsageParams) (GetTransferUsageRow, error)
 	GetUser(ctx context.Context, username string) (GetUserRow, error)
+	GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error)
 	GetUserHashedPassword(ctx context.Context, username string) (string, error)
+	GetUserMfa(ctx context.Context, username string) (UserMfa, error)
+	GetUserPasswordChangedAt(ctx context.Context, username string) (time.Time, error)
+	GetUserTier(ctx context.Context, username string) (string, error)
+	GetUserTransferLimit(ctx context.Context, arg GetUserTransferLimitParams) (UserTransferLimit, error)
+	ListAccountBalanceDrift(ctx context.Context) ([]ListAccountBalanceDriftRow, error)
+	ListAccountStatementEntries(ctx context.Context, arg ListAccountStatementEntriesParams) ([]ListAccountStatementEntriesRow, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListActiveFeeSchedules(ctx context.Context) ([]FeeSchedule, error)
 	ListAllAccounts(ctx context.Context, arg ListAllAccountsParams) ([]Account, error)
+	ListCurrencies(ctx context.Context) ([]Currency, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListExchangeRateHistory(ctx context.Context, arg ListExchangeRateHistoryParams) ([]ExchangeRateHistory, error)
 	ListExchangeRates(ctx context.Context) ([]ExchangeRate, error)
+	ListExpiredTransferHolds(ctx context.Context, limit int32) ([]TransferHold, error)
+	ListFeeScheduleTiers(ctx context.Context, feeScheduleIds []int64) ([]FeeScheduleTier, error)
+	ListFundingEntryMismatches(ctx context.Context) ([]ListFundingEntryMismatchesRow, error)
+	ListFxMarkups(ctx context.Context) ([]FxMarkup, error)
+	ListScheduledTransfers(ctx context.Context, owner string) ([]ScheduledTransfer, error)
+	ListTransferBatchItems(ctx context.Context, batchID int64) ([]TransferBatchItem, error)
+	ListTransferBatches(ctx context.Context, owner string) ([]TransferBatch, error)
+	ListTransferEntryMismatches(ctx context.Context) ([]ListTransferEntryMismatchesRow, error)
+	ListTransferHolds(ctx context.Context, owner string) ([]TransferHold, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]ListTransfersRow, error)
+	ListUnbalancedTransfers(ctx context.Context) ([]ListUnbalancedTransfersRow, error)
+	ListUserSessions(ctx context.Context, username string) ([]ListUserSessionsRow, error)
 	ListUsers(ctx context.Context) ([]ListUsersRow, error)
+	LockFeeSchedulePair(ctx context.Context, arg LockFeeSchedulePairParams) error
+	RecordScheduledTransferRun(ctx context.Context, arg RecordScheduledTransferRunParams) (ScheduledTransfer, error)
+	ResetMfaAttempts(ctx context.Context, username string) error
+	RevokeUserTokens(ctx context.Context, arg RevokeUserTokensParams) error
+	SumAccountEntriesSince(ctx context.Context, arg SumAccountEntriesSinceParams) (pgtype.Numeric, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateCurrency(ctx context.Context, arg UpdateCurrencyParams) (Currency, error)
 	UpdateExchangeRate(ctx context.Context, arg UpdateExchangeRateParams) (ExchangeRate, error)
+	UpdateScheduledTransfer(ctx context.Context, arg UpdateScheduledTransferParams) (ScheduledTransfer, error)
+	UpdateTransferHoldStatus(ctx context.Context, arg UpdateTransferHoldStatusParams) (TransferHold, error)
+	UpdateTransferStatus(ctx context.Context, arg UpdateTransferStatusParams) (Transfer, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateUserRow, error)
+	UpsertExchangeRate(ctx context.Context, arg UpsertExchangeRateParams) (ExchangeRate, error)
+	UpsertFxMarkup(ctx context.Context, arg UpsertFxMarkupParams) (FxMarkup, error)
+	UpsertUserTransferLimit(ctx context.Context, arg UpsertUserTransferLimitParams) (UserTransferLimit, error)
+	UseMfaChallenge(ctx context.Context, id uuid.UUID) (MfaChallenge, error)
+	UseMfaRecoveryCode(ctx context.Context, arg UseMfaRecoveryCodeParams) (MfaRecoveryCode, error)
+	UseTotpStep(ctx context.Context, arg UseTotpStepParams) (UserMfa, error)
+	UseUserToken(ctx context.Context, arg UseUserTokenParams) (UserToken, error)
+	VerifyUserEmail(ctx context.Context, tokenHash []byte) (VerifyUserEmailRow, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ReconcileTxResult lists every ledger invariant violation found by ReconcileTx
+type ReconcileTxResult struct {
+	// Accounts whose balance differs from the sum of their entries
+	AccountDrift []ListAccountBalanceDriftRow `json:"account_drift"`
+	// Transfers whose entries do not sum to zero in a currency
+	UnbalancedTransfers []ListUnbalancedTransfersRow `json:"unbalanced_transfers"`
+	// Transfers whose sender or receiver entries do not match the transfer amounts
+	TransferMismatches []ListTransferEntryMismatchesRow `json:"transfer_mismatches"`
+	// Deposits and withdrawals whose entries do not match the recorded amount
+	FundingMismatches []ListFundingEntryMismatchesRow `json:"funding_mismatches"`
+}
+
+// ReconcileTx checks balances, entries and transfers against each other from one snapshot,
+// so transfers committed while it runs cannot show up as drift
+func (store *SQLStore) ReconcileTx(ctx context.Context) (ReconcileTxResult, error) {
+	var result ReconcileTxResult
+
+	txOptions := pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+	err := store.execTxWithOptions(ctx, txOptions, func(q *Queries) error {
+		var err error
+
+		result.AccountDrift, err = q.ListAccountBalanceDrift(ctx)
+		if err != nil {
+			return err
+		}
+
+		result.UnbalancedTransfers, err = q.ListUnbalancedTransfers(ctx)
+		if err != nil {
+			return err
+		}
+
+		result.TransferMismatches, err = q.ListTransferEntryMismatches(ctx)
+		if err != nil {
+			return err
+		}
+
+		result.FundingMismatches, err = q.ListFundingEntryMismatches(ctx)
+		return err
+	})
+
+	return result, err
+}
@@ -0,0 +1,46 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, "USD")
+	account2 := createAccountWithCurrency(t, "USD")
+	amount := decimal.NewFromInt(10).Round(2)
+
+	balanced, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID:   account1.ID,
+		ToAccountID:     account2.ID,
+		Amount:          amount,
+		ConvertedAmount: amount,
+		ExchangeRate:    decimal.NewFromInt(1).Round(8),
+		FromCurrency:    account1.Currency,
+		ToCurrency:      account2.Currency,
+		Fee:             decimal.NewFromInt(1).Round(2),
+	})
+	require.NoError(t, err)
+
+	// A transfer written without entries must be reported
+	orphan := createRandomTransfer(t)
+
+	result, err := store.ReconcileTx(context.Background())
+	require.NoError(t, err)
+
+	for _, row := range result.UnbalancedTransfers {
+		require.NotEqual(t, balanced.Transfer.ID, row.TransferID.Int64)
+	}
+
+	mismatched := map[int64]bool{}
+	for _, row := range result.TransferMismatches {
+		mismatched[row.ID] = true
+	}
+	require.False(t, mismatched[balanced.Transfer.ID])
+	require.True(t, mismatched[orphan.ID])
+}
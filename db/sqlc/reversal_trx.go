@@ -0,0 +1,271 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// Statuses of a transfer, see the transfers table
+const (
+	TransferStatusCompleted         = "completed"
+	TransferStatusReversed          = "reversed"
+	TransferStatusPartiallyReversed = "partially_reversed"
+)
+
+// Errors returned by ReverseTransferTx when a reversal is not allowed
+var (
+	ErrTransferAlreadyReversed     = errors.New("transfer has already been fully reversed")
+	ErrReversalOfReversal          = errors.New("a reversal cannot be reversed")
+	ErrReversalExceedsTransfer     = errors.New("reversal amount exceeds the amount left to reverse")
+	ErrFeeAlreadyRefunded          = errors.New("transfer fee has already been refunded")
+	ErrReversalInsufficientBalance = errors.New("recipient account has insufficient balance for the reversal")
+	ErrReversalInvalidRate         = errors.New("reversal exchange rate must be positive")
+	ErrReversalAmountRoundsToZero  = errors.New("reversal amount is too small to return to the sender")
+)
+
+// ReverseTransferTxParams contains the input parameters of the reversal transaction
+type ReverseTransferTxParams struct {
+	TransferID int64 `json:"transfer_id"`
+	// Amount taken back from the recipient in the currency of the original to account,
+	// zero to reverse everything that is left
+	Amount decimal.Decimal `json:"amount"`
+	// Rate from the original to currency back to the original from currency,
+	// zero to convert at the rate of the original transfer
+	ExchangeRate decimal.Decimal `json:"exchange_rate"`
+	// Refund the fee of the original transfer to the sender, it can only be refunded once
+	RefundFee bool `json:"refund_fee"`
+}
+
+// ReverseTransferTxResult is the result of the reversal transaction
+type ReverseTransferTxResult struct {
+	// The original transfer with its new status
+	Transfer Transfer `json:"transfer"`
+	// The compensating transfer from the recipient back to the sender
+	Reversal    Transfer `json:"reversal"`
+	FromAccount Account  `json:"from_account"`
+	ToAccount   Account  `json:"to_account"`
+	FromEntry   Entry    `json:"from_entry"`
+	ToEntry     Entry    `json:"to_entry"`
+	// Fee refund and FX postings on house accounts that balance the reversal in each currency
+	HouseEntries []Entry `json:"house_entries,omitempty"`
+}
+
+// ReverseTransferTx takes an amount back from the recipient of a transfer and returns it to the sender
+// with a compensating transfer linked to the original. A transfer can be reversed in parts until its whole
+// converted amount has been taken back. The last part returns exactly what is left of the original amount
+// when the original rate is used, so a transfer reversed in parts returns the same as a full reversal.
+// The original transfer is locked for the whole transaction, so concurrent reversals of it run one after the other
+func (store *SQLStore) ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error) {
+	var result ReverseTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		original, err := q.GetTransferForUpdate(ctx, arg.TransferID)
+		if err != nil {
+			return err
+		}
+
+		if original.ReversalOfTransferID.Valid {
+			return ErrReversalOfReversal
+		}
+		if original.Status == TransferStatusReversed {
+			return ErrTransferAlreadyReversed
+		}
+
+		totals, err := q.GetTransferReversalTotals(ctx, pgtype.Int8{Int64: original.ID, Valid: true})
+		if err != nil {
+			return err
+		}
+		reversedAmount := numericToDecimal(totals.ReversedAmount)
+		returnedAmount := numericToDecimal(totals.ReturnedAmount)
+
+		remaining := original.ConvertedAmount.Sub(reversedAmount)
+		amount := arg.Amount
+		if amount.IsZero() {
+			amount = remaining
+		}
+		if amount.GreaterThan(remaining) {
+			return ErrReversalExceedsTransfer
+		}
+		fullyReversed := amount.Equal(remaining)
+
+		refundedFee := decimal.Zero
+		if arg.RefundFee {
+			if numericToDecimal(totals.RefundedFee).IsPositive() {
+				return ErrFeeAlreadyRefunded
+			}
+			refundedFee = original.Fee
+		}
+
+		// Convert the amount back to the currency of the sender
+		fromCurrency, err := q.GetCurrency(ctx, original.FromCurrency.String)
+		if err != nil {
+			return err
+		}
+
+		var returned, exchangeRate decimal.Decimal
+		switch {
+		case arg.ExchangeRate.IsPositive():
+			exchangeRate = arg.ExchangeRate
+			returned = amount.Mul(exchangeRate).Round(fromCurrency.MinorUnits)
+		case arg.ExchangeRate.IsZero() && fullyReversed:
+			exchangeRate = decimal.NewFromInt(1).Div(original.ExchangeRate).Round(8)
+			returned = original.Amount.Sub(returnedAmount)
+		case arg.ExchangeRate.IsZero():
+			exchangeRate = decimal.NewFromInt(1).Div(original.ExchangeRate).Round(8)
+			returned = amount.Div(original.ExchangeRate).Round(fromCurrency.MinorUnits)
+		default:
+			return ErrReversalInvalidRate
+		}
+		if !returned.IsPositive() {
+			return ErrReversalAmountRoundsToZero
+		}
+
+		// The reversal moves money from the original recipient back to the sender,
+		// lock both accounts in the same order as TransferTx to avoid deadlocks
+		var recipient, sender Account
+		if original.ToAccountID < original.FromAccountID {
+			recipient, err = q.GetAccountForUpdate(ctx, original.ToAccountID)
+			if err == nil {
+				sender, err = q.GetAccountForUpdate(ctx, original.FromAccountID)
+			}
+		} else {
+			sender, err = q.GetAccountForUpdate(ctx, original.FromAccountID)
+			if err == nil {
+				recipient, err = q.GetAccountForUpdate(ctx, original.ToAccountID)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		if recipient.Balance.Sub(recipient.HeldAmount).LessThan(amount) {
+			return ErrReversalInsufficientBalance
+		}
+
+		houseLegs, err := reversalHouseLegs(ctx, q, recipient, sender, amount, returned, refundedFee)
+		if err != nil {
+			return err
+		}
+
+		reversal, err := q.CreateTransfer(ctx, CreateTransferParams{
+			FromAccountID:        recipient.ID,
+			ToAccountID:          sender.ID,
+			Amount:               amount,
+			ConvertedAmount:      returned,
+			ExchangeRate:         exchangeRate,
+			FromCurrency:         original.ToCurrency,
+			ToCurrency:           original.FromCurrency,
+			Fee:                  decimal.Zero,
+			ReversalOfTransferID: pgtype.Int8{Int64: original.ID, Valid: true},
+			RefundedFee:          refundedFee,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Reversal = Transfer{
+			ID:                   reversal.ID,
+			FromAccountID:        reversal.FromAccountID,
+			ToAccountID:          reversal.ToAccountID,
+			Amount:               reversal.Amount,
+			ConvertedAmount:      reversal.ConvertedAmount,
+			ExchangeRate:         reversal.ExchangeRate,
+			FromCurrency:         reversal.FromCurrency,
+			ToCurrency:           reversal.ToCurrency,
+			Fee:                  reversal.Fee,
+			CreatedAt:            reversal.CreatedAt,
+			Status:               reversal.Status,
+			ReversalOfTransferID: reversal.ReversalOfTransferID,
+			RefundedFee:          reversal.RefundedFee,
+		}
+
+		transferID := pgtype.Int8{Int64: reversal.ID, Valid: true}
+
+		// Debit the recipient the amount taken back, credit the sender the returned amount and refunded fee
+		fromEntryAmount := amount.Neg()
+		toEntryAmount := returned.Add(refundedFee)
+
+		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID:  recipient.ID,
+			Amount:     fromEntryAmount,
+			TransferID: transferID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+			AccountID:  sender.ID,
+			Amount:     toEntryAmount,
+			TransferID: transferID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if recipient.ID < sender.ID {
+			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, recipient.ID, fromEntryAmount, sender.ID, toEntryAmount)
+		} else {
+			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, sender.ID, toEntryAmount, recipient.ID, fromEntryAmount)
+		}
+		if err != nil {
+			return err
+		}
+
+		result.HouseEntries, err = postHouseLegs(ctx, q, transferID, houseLegs)
+		if err != nil {
+			return err
+		}
+
+		status := TransferStatusPartiallyReversed
+		if fullyReversed {
+			status = TransferStatusReversed
+		}
+
+		result.Transfer, err = q.UpdateTransferStatus(ctx, UpdateTransferStatusParams{
+			ID:     original.ID,
+			Status: status,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// reversalHouseLegs returns the house postings that balance a reversal in each currency.
+// The fee account of the sender's currency pays back the refunded fee, and across currencies the
+// FX accounts sell back the returned amount and buy the amount taken from the recipient
+func reversalHouseLegs(ctx context.Context, q *Queries, recipient Account, sender Account, amount decimal.Decimal, returned decimal.Decimal, refundedFee decimal.Decimal) ([]houseLeg, error) {
+	var legs []houseLeg
+
+	if refundedFee.IsPositive() {
+		feeAccountID, err := lookupHouseAccountID(ctx, q, sender.Currency, HouseAccountPurposeFee)
+		if err != nil {
+			return nil, err
+		}
+		legs = append(legs, houseLeg{accountID: feeAccountID, amount: refundedFee.Neg()})
+	}
+
+	if recipient.Currency != sender.Currency {
+		fxRecipientAccountID, err := lookupHouseAccountID(ctx, q, recipient.Currency, HouseAccountPurposeFX)
+		if err != nil {
+			return nil, err
+		}
+		fxSenderAccountID, err := lookupHouseAccountID(ctx, q, sender.Currency, HouseAccountPurposeFX)
+		if err != nil {
+			return nil, err
+		}
+		legs = append(legs,
+			houseLeg{accountID: fxRecipientAccountID, amount: amount},
+			houseLeg{accountID: fxSenderAccountID, amount: returned.Neg()},
+		)
+	} else if !amount.Equal(returned) {
+		// Same currency transfers return what is taken, rates only apply across currencies
+		return nil, ErrReversalInvalidRate
+	}
+
+	return legs, nil
+}
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReverseTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, "GBP")
+	account2 := createAccountWithCurrency(t, "NGN")
+
+	amount := decimal.NewFromInt(10).Round(2)
+	fee := decimal.NewFromInt(1).Round(2)
+
+	transfer, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID:   account1.ID,
+		ToAccountID:     account2.ID,
+		Amount:          amount,
+		ConvertedAmount: decimal.NewFromInt(20000).Round(2),
+		ExchangeRate:    decimal.NewFromInt(2000).Round(8),
+		FromCurrency:    account1.Currency,
+		ToCurrency:      account2.Currency,
+		Fee:             fee,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusCompleted, transfer.Transfer.Status)
+
+	// A partial reversal at the original rate returns the matching part of the amount
+	partial, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Amount:     decimal.NewFromInt(5000),
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusPartiallyReversed, partial.Transfer.Status)
+	require.Equal(t, transfer.Transfer.ID, partial.Reversal.ReversalOfTransferID.Int64)
+	require.Equal(t, account2.ID, partial.Reversal.FromAccountID)
+	require.Equal(t, account1.ID, partial.Reversal.ToAccountID)
+	require.True(t, decimal.NewFromFloat(2.5).Equal(partial.Reversal.ConvertedAmount))
+	require.True(t, partial.Reversal.RefundedFee.IsZero())
+
+	requireReversalBalanced(t, store, partial)
+
+	// Reversing more than is left is refused
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Amount:     decimal.NewFromInt(15001),
+	})
+	require.ErrorIs(t, err, ErrReversalExceedsTransfer)
+
+	// The rest is reversed with the fee, returning the sender everything the transfer took
+	full, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		RefundFee:  true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferStatusReversed, full.Transfer.Status)
+	require.True(t, decimal.NewFromInt(15000).Equal(full.Reversal.Amount))
+	require.True(t, decimal.NewFromFloat(7.5).Equal(full.Reversal.ConvertedAmount))
+	require.True(t, fee.Equal(full.Reversal.RefundedFee))
+	require.True(t, account1.Balance.Equal(full.ToAccount.Balance))
+	require.True(t, account2.Balance.Equal(full.FromAccount.Balance))
+
+	requireReversalBalanced(t, store, full)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+	})
+	require.ErrorIs(t, err, ErrTransferAlreadyReversed)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: full.Reversal.ID,
+	})
+	require.ErrorIs(t, err, ErrReversalOfReversal)
+}
+
+func TestReverseTransferTxFeeRefundedOnce(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, "USD")
+	account2 := createAccountWithCurrency(t, "USD")
+
+	amount := decimal.NewFromInt(10).Round(2)
+	transfer, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID:   account1.ID,
+		ToAccountID:     account2.ID,
+		Amount:          amount,
+		ConvertedAmount: amount,
+		ExchangeRate:    decimal.NewFromInt(1).Round(8),
+		FromCurrency:    account1.Currency,
+		ToCurrency:      account2.Currency,
+		Fee:             decimal.NewFromInt(1).Round(2),
+	})
+	require.NoError(t, err)
+
+	reversal, err := store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		Amount:     decimal.NewFromInt(4),
+		RefundFee:  true,
+	})
+	require.NoError(t, err)
+	require.True(t, decimal.NewFromInt(5).Equal(reversal.ToEntry.Amount))
+	requireReversalBalanced(t, store, reversal)
+
+	_, err = store.ReverseTransferTx(context.Background(), ReverseTransferTxParams{
+		TransferID: transfer.Transfer.ID,
+		RefundFee:  true,
+	})
+	require.ErrorIs(t, err, ErrFeeAlreadyRefunded)
+}
+
+// requireReversalBalanced checks the entries of a reversal sum to zero in each currency
+func requireReversalBalanced(t *testing.T, store Store, result ReverseTransferTxResult) {
+	totals := map[string]decimal.Decimal{}
+	entries := append([]Entry{result.FromEntry, result.ToEntry}, result.HouseEntries...)
+	for _, entry := range entries {
+		require.Equal(t, result.Reversal.ID, entry.TransferID.Int64)
+
+		account, err := store.GetAccount(context.Background(), entry.AccountID)
+		require.NoError(t, err)
+		totals[account.Currency] = totals[account.Currency].Add(entry.Amount)
+	}
+
+	for currency, total := range totals {
+		require.Truef(t, total.IsZero(), "entries in %s sum to %s", currency, total.String())
+	}
+}
@@ -0,0 +1,46 @@
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Reasons a session was blocked, see the sessions table
+const (
+	// SessionBlockedReasonRotated is set when the refresh token of the session was exchanged for a new one
+	SessionBlockedReasonRotated = "rotated"
+	// SessionBlockedReasonSignedOut is set when the user signed out of the session or changed their password
+	SessionBlockedReasonSignedOut = "signed_out"
+	// SessionBlockedReasonRevoked is set when the session family was revoked after a refresh token was reused
+	SessionBlockedReasonRevoked = "revoked"
+)
+
+// RotateSessionTxParams contains the input parameters of the rotate session transaction
+type RotateSessionTxParams struct {
+	// Session of the refresh token being exchanged
+	SessionID uuid.UUID `json:"session_id"`
+	// Session of the new refresh token, its family is taken from the old session
+	NewSession CreateSessionParams `json:"new_session"`
+}
+
+// RotateSessionTx blocks the session of a refresh token and creates the session of the refresh
+// token it is exchanged for, in the same family. Only one exchange of a refresh token can succeed,
+// pgx.ErrNoRows is returned when the session was already blocked
+func (store *SQLStore) RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (Session, error) {
+	var result Session
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		oldSession, err := q.BlockActiveSession(ctx, arg.SessionID)
+		if err != nil {
+			return err
+		}
+
+		newSession := arg.NewSession
+		newSession.FamilyID = oldSession.FamilyID
+		result, err = q.CreateSession(ctx, newSession)
+		return err
+	})
+
+	return result, err
+}
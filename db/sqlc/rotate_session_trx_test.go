@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateSessionTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Username)
+
+	rotated, err := store.RotateSessionTx(context.Background(), RotateSessionTxParams{
+		SessionID:  session.ID,
+		NewSession: randomSessionParams(user.Username),
+	})
+	require.NoError(t, err)
+	require.Equal(t, session.FamilyID, rotated.FamilyID)
+	require.False(t, rotated.IsBlocked)
+
+	old, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, old.IsBlocked)
+	require.Equal(t, SessionBlockedReasonRotated, old.BlockedReason.String)
+
+	// A refresh token can only be exchanged once
+	_, err = store.RotateSessionTx(context.Background(), RotateSessionTxParams{
+		SessionID:  session.ID,
+		NewSession: randomSessionParams(user.Username),
+	})
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// Blocking the family signs out the rotated session too
+	err = testQueries.BlockSessionFamily(context.Background(), session.FamilyID)
+	require.NoError(t, err)
+
+	blocked, err := testQueries.GetSession(context.Background(), rotated.ID)
+	require.NoError(t, err)
+	require.True(t, blocked.IsBlocked)
+	require.Equal(t, SessionBlockedReasonRevoked, blocked.BlockedReason.String)
+
+	// Sessions blocked before the family was revoked keep their reason
+	old, err = testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.Equal(t, SessionBlockedReasonRotated, old.BlockedReason.String)
+}
+
+func TestBlockSessionSignsOut(t *testing.T) {
+	user := createRandomUser(t)
+	session := createRandomSession(t, user.Username)
+
+	err := testQueries.BlockSession(context.Background(), session.ID)
+	require.NoError(t, err)
+
+	blocked, err := testQueries.GetSession(context.Background(), session.ID)
+	require.NoError(t, err)
+	require.True(t, blocked.IsBlocked)
+	require.Equal(t, SessionBlockedReasonSignedOut, blocked.BlockedReason.String)
+}
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func containsScheduledTransfer(scheduledTransfers []ScheduledTransfer, id int64) bool {
+	for _, scheduledTransfer := range scheduledTransfers {
+		if scheduledTransfer.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestClaimDueScheduledTransfers(t *testing.T) {
+	scheduledTransfer := createDueScheduledTransfer(t)
+	claimedUntil := time.Now().Add(time.Minute)
+
+	claimed, err := testQueries.ClaimDueScheduledTransfers(context.Background(), ClaimDueScheduledTransfersParams{
+		ClaimedUntil: claimedUntil,
+		BatchSize:    1000,
+	})
+	require.NoError(t, err)
+	require.True(t, containsScheduledTransfer(claimed, scheduledTransfer.ID))
+
+	// A second worker does not get rows that are already claimed
+	claimedAgain, err := testQueries.ClaimDueScheduledTransfers(context.Background(), ClaimDueScheduledTransfersParams{
+		ClaimedUntil: claimedUntil,
+		BatchSize:    1000,
+	})
+	require.NoError(t, err)
+	require.False(t, containsScheduledTransfer(claimedAgain, scheduledTransfer.ID))
+}
+
+func TestRecordScheduledTransferRun(t *testing.T) {
+	scheduledTransfer := createDueScheduledTransfer(t)
+
+	nextRunAt := scheduledTransfer.NextRunAt.Add(time.Hour)
+	recorded, err := testQueries.RecordScheduledTransferRun(context.Background(), RecordScheduledTransferRunParams{
+		ID:           scheduledTransfer.ID,
+		Status:       "active",
+		OccurrenceAt: scheduledTransfer.OccurrenceAt,
+		NextRunAt:    nextRunAt,
+		Attempts:     1,
+		LastError:    pgtype.Text{String: "insufficient balance", Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), recorded.Attempts)
+	require.Equal(t, "insufficient balance", recorded.LastError.String)
+	require.Equal(t, scheduledTransfer.OccurrenceAt, recorded.OccurrenceAt)
+	require.WithinDuration(t, nextRunAt, recorded.NextRunAt, time.Second)
+	require.True(t, recorded.LastRunAt.Valid)
+	require.False(t, recorded.LastTransferID.Valid)
+	require.False(t, recorded.ClaimedUntil.Valid)
+}
+
+func TestRecordScheduledTransferRunKeepsCancelledStatus(t *testing.T) {
+	scheduledTransfer := createDueScheduledTransfer(t)
+
+	// The owner cancels the schedule while a worker has its run claimed
+	_, err := testQueries.UpdateScheduledTransfer(context.Background(), UpdateScheduledTransferParams{
+		ID:         scheduledTransfer.ID,
+		Amount:     scheduledTransfer.Amount,
+		Frequency:  scheduledTransfer.Frequency,
+		DayOfMonth: scheduledTransfer.DayOfMonth,
+		NextRunAt:  scheduledTransfer.NextRunAt,
+		Status:     "cancelled",
+	})
+	require.NoError(t, err)
+
+	recorded, err := testQueries.RecordScheduledTransferRun(context.Background(), RecordScheduledTransferRunParams{
+		ID:           scheduledTransfer.ID,
+		Status:       "active",
+		OccurrenceAt: scheduledTransfer.OccurrenceAt.AddDate(0, 1, 0),
+		NextRunAt:    scheduledTransfer.NextRunAt.AddDate(0, 1, 0),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "cancelled", recorded.Status)
+	require.True(t, recorded.LastRunAt.Valid)
+	require.False(t, recorded.ClaimedUntil.Valid)
+}
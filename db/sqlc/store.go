@@ -22,7 +22,13 @@ func NewStore(connPool *pgxpool.Pool) Store {
 	}
 }
 func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
-	tx, err := store.connPool.BeginTx(ctx, pgx.TxOptions{})
+	return store.execTxWithOptions(ctx, pgx.TxOptions{}, fn)
+}
+
+// execTxWithOptions runs fn in a transaction started with the given options,
+// e.g. a read-only snapshot for consistent reports
+func (store *SQLStore) execTxWithOptions(ctx context.Context, txOptions pgx.TxOptions, fn func(*Queries) error) error {
+	tx, err := store.connPool.BeginTx(ctx, txOptions)
 	if err != nil {
 		return err
 	}
@@ -7,4 +7,23 @@ import (
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	ReverseTransferTx(ctx context.Context, arg ReverseTransferTxParams) (ReverseTransferTxResult, error)
+	AuthorizeTransferTx(ctx context.Context, arg AuthorizeTransferTxParams) (AuthorizeTransferTxResult, error)
+	CaptureTransferHoldTx(ctx context.Context, holdID int64) (CaptureTransferHoldTxResult, error)
+	VoidTransferHoldTx(ctx context.Context, holdID int64) (TransferHold, error)
+	ExpireTransferHoldsTx(ctx context.Context, batchSize int32) ([]TransferHold, error)
+	BatchTransferTx(ctx context.Context, arg BatchTransferTxParams) (BatchTransferTxResult, error)
+	AccountStatementTx(ctx context.Context, arg AccountStatementTxParams) (AccountStatementTxResult, error)
+	DepositTx(ctx context.Context, arg FundingTxParams) (FundingTxResult, error)
+	WithdrawTx(ctx context.Context, arg FundingTxParams) (FundingTxResult, error)
+	ReconcileTx(ctx context.Context) (ReconcileTxResult, error)
+	CreateCurrencyTx(ctx context.Context, arg CreateCurrencyParams) (Currency, error)
+	CreateExchangeRateTx(ctx context.Context, arg ExchangeRatePairTxParams) (ExchangeRatePairTxResult, error)
+	UpdateExchangeRateTx(ctx context.Context, arg ExchangeRatePairTxParams) (ExchangeRatePairTxResult, error)
+	DeleteExchangeRateTx(ctx context.Context, arg DeleteExchangeRateParams) error
+	RefreshExchangeRatesTx(ctx context.Context, arg []UpsertExchangeRateParams) ([]ExchangeRate, error)
+	SetFeeScheduleTx(ctx context.Context, arg SetFeeScheduleTxParams) (FeeScheduleTxResult, error)
+	ChangePasswordTx(ctx context.Context, arg ChangePasswordTxParams) (ChangePasswordTxResult, error)
+	RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (Session, error)
+	EnableMfaTx(ctx context.Context, arg EnableMfaTxParams) (UserMfa, error)
 }
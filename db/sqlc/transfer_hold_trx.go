@@ -0,0 +1,293 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// Statuses of a transfer hold, see the transfer_holds table
+const (
+	TransferHoldStatusAuthorized = "authorized"
+	TransferHoldStatusCaptured   = "captured"
+	TransferHoldStatusVoided     = "voided"
+	TransferHoldStatusExpired    = "expired"
+)
+
+// Errors returned when a transfer hold cannot be captured or voided
+var (
+	ErrTransferHoldNotAuthorized = errors.New("transfer hold is no longer authorized")
+	ErrTransferHoldExpired       = errors.New("transfer hold has expired")
+)
+
+// AuthorizeTransferTxParams contains the input parameters of the authorize transaction,
+// the pricing is kept on the hold and used as is when it is captured
+type AuthorizeTransferTxParams struct {
+	Username        string          `json:"username"`
+	FromAccountID   int64           `json:"from_account_id"`
+	ToAccountID     int64           `json:"to_account_id"`
+	Amount          decimal.Decimal `json:"amount"`
+	ConvertedAmount decimal.Decimal `json:"converted_amount"`
+	ExchangeRate    decimal.Decimal `json:"exchange_rate"`
+	FromCurrency    string          `json:"from_currency"`
+	ToCurrency      string          `json:"to_currency"`
+	Fee             decimal.Decimal `json:"fee"`
+	FeeScheduleID   int64           `json:"fee_schedule_id,omitempty"`
+	PivotRate       *PivotRate      `json:"pivot_rate,omitempty"`
+	MidMarketRate   decimal.Decimal `json:"mid_market_rate,omitempty"`
+	MarkupBps       int32           `json:"markup_bps,omitempty"`
+	ExpiresAt       time.Time       `json:"expires_at"`
+}
+
+// AuthorizeTransferTxResult is the result of the authorize transaction
+type AuthorizeTransferTxResult struct {
+	Hold        TransferHold `json:"hold"`
+	FromAccount Account      `json:"from_account"`
+}
+
+// CaptureTransferHoldTxResult is the result of the capture transaction
+type CaptureTransferHoldTxResult struct {
+	Hold     TransferHold     `json:"hold"`
+	Transfer TransferTxResult `json:"transfer"`
+}
+
+// AuthorizeTransferTx places a hold of amount + fee on the source account. The hold reduces the available
+// balance of the account but not its balance, and no entries are posted until the hold is captured.
+// The available balance and the transfer limits of the sender are checked after the source account is locked
+func (store *SQLStore) AuthorizeTransferTx(ctx context.Context, arg AuthorizeTransferTxParams) (AuthorizeTransferTxResult, error) {
+	var result AuthorizeTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		fromAccount, err := q.GetAccountForUpdate(ctx, arg.FromAccountID)
+		if err != nil {
+			return fmt.Errorf("from account not found: %w", err)
+		}
+
+		toAccount, err := q.GetAccount(ctx, arg.ToAccountID)
+		if err != nil {
+			return fmt.Errorf("to account not found: %w", err)
+		}
+
+		if fromAccount.Currency != arg.FromCurrency {
+			return fmt.Errorf("from account currency mismatch: expected %s, got %s", fromAccount.Currency, arg.FromCurrency)
+		}
+
+		if toAccount.Currency != arg.ToCurrency {
+			return fmt.Errorf("to account currency mismatch: expected %s, got %s", toAccount.Currency, arg.ToCurrency)
+		}
+
+		totalAmount := arg.Amount.Add(arg.Fee)
+		availableBalance := fromAccount.Balance.Sub(fromAccount.HeldAmount)
+		if availableBalance.LessThan(totalAmount) {
+			return fmt.Errorf("%w: account has %s available, hold requires %s (amount: %s + fee: %s)", ErrInsufficientFunds, availableBalance.String(), totalAmount.String(), arg.Amount.String(), arg.Fee.String())
+		}
+
+		err = checkTransferLimits(ctx, q, fromAccount, arg.Amount)
+		if err != nil {
+			return err
+		}
+
+		holdParams := CreateTransferHoldParams{
+			Owner:           arg.Username,
+			FromAccountID:   arg.FromAccountID,
+			ToAccountID:     arg.ToAccountID,
+			Amount:          arg.Amount,
+			ConvertedAmount: arg.ConvertedAmount,
+			ExchangeRate:    arg.ExchangeRate,
+			FromCurrency:    arg.FromCurrency,
+			ToCurrency:      arg.ToCurrency,
+			Fee:             arg.Fee,
+			FeeScheduleID:   pgtype.Int8{Int64: arg.FeeScheduleID, Valid: arg.FeeScheduleID != 0},
+			ExpiresAt:       arg.ExpiresAt,
+		}
+		if arg.PivotRate != nil {
+			holdParams.PivotCurrency = pgtype.Text{String: arg.PivotRate.Currency, Valid: true}
+			holdParams.FromPivotRate = decimal.NewNullDecimal(arg.PivotRate.FromRate)
+			holdParams.PivotToRate = decimal.NewNullDecimal(arg.PivotRate.ToRate)
+		}
+		if !arg.MidMarketRate.IsZero() {
+			holdParams.MidMarketRate = decimal.NewNullDecimal(arg.MidMarketRate)
+			holdParams.MarkupBps = pgtype.Int4{Int32: arg.MarkupBps, Valid: true}
+		}
+
+		result.Hold, err = q.CreateTransferHold(ctx, holdParams)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			ID:     arg.FromAccountID,
+			Amount: totalAmount,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.FromAccount, err = q.GetAccount(ctx, arg.FromAccountID)
+		return err
+	})
+
+	return result, err
+}
+
+// CaptureTransferHoldTx releases an authorized hold and makes the transfer at the pricing of the hold.
+// The hold is locked before the accounts, so it cannot be captured twice or voided while it is captured
+func (store *SQLStore) CaptureTransferHoldTx(ctx context.Context, holdID int64) (CaptureTransferHoldTxResult, error) {
+	var result CaptureTransferHoldTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		hold, err := lockAuthorizedTransferHold(ctx, q, holdID)
+		if err != nil {
+			return err
+		}
+
+		if !hold.ExpiresAt.After(time.Now()) {
+			return ErrTransferHoldExpired
+		}
+
+		result.Transfer, err = transferTx(ctx, q, hold.transferTxParams(), &hold)
+		if err != nil {
+			return err
+		}
+
+		result.Hold, err = q.UpdateTransferHoldStatus(ctx, UpdateTransferHoldStatusParams{
+			ID:         hold.ID,
+			Status:     TransferHoldStatusCaptured,
+			TransferID: pgtype.Int8{Int64: result.Transfer.Transfer.ID, Valid: true},
+		})
+		return err
+	})
+
+	return result, err
+}
+
+// VoidTransferHoldTx releases an authorized hold without making the transfer
+func (store *SQLStore) VoidTransferHoldTx(ctx context.Context, holdID int64) (TransferHold, error) {
+	var result TransferHold
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		hold, err := lockAuthorizedTransferHold(ctx, q, holdID)
+		if err != nil {
+			return err
+		}
+
+		result, err = releaseTransferHold(ctx, q, hold, TransferHoldStatusVoided)
+		return err
+	})
+
+	return result, err
+}
+
+// ExpireTransferHoldsTx releases up to batchSize authorized holds that are past their expiry.
+// Holds locked by a concurrent capture or void are skipped and left for them
+func (store *SQLStore) ExpireTransferHoldsTx(ctx context.Context, batchSize int32) ([]TransferHold, error) {
+	var result []TransferHold
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		holds, err := q.ListExpiredTransferHolds(ctx, batchSize)
+		if err != nil {
+			return err
+		}
+
+		// The holds come in expiry order, so their accounts are released in ascending id order instead,
+		// the order transfers lock accounts in, to not deadlock with a transfer between two of them
+		heldAmounts := make(map[int64]decimal.Decimal)
+		for _, hold := range holds {
+			heldAmounts[hold.FromAccountID] = heldAmounts[hold.FromAccountID].Add(hold.Amount.Add(hold.Fee))
+		}
+
+		accountIDs := make([]int64, 0, len(heldAmounts))
+		for id := range heldAmounts {
+			accountIDs = append(accountIDs, id)
+		}
+		sort.Slice(accountIDs, func(i, j int) bool { return accountIDs[i] < accountIDs[j] })
+
+		for _, id := range accountIDs {
+			_, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+				ID:     id,
+				Amount: heldAmounts[id].Neg(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		result = make([]TransferHold, 0, len(holds))
+		for _, hold := range holds {
+			expired, err := q.UpdateTransferHoldStatus(ctx, UpdateTransferHoldStatusParams{
+				ID:     hold.ID,
+				Status: TransferHoldStatusExpired,
+			})
+			if err != nil {
+				return err
+			}
+			result = append(result, expired)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+func lockAuthorizedTransferHold(ctx context.Context, q *Queries, holdID int64) (TransferHold, error) {
+	hold, err := q.GetTransferHoldForUpdate(ctx, holdID)
+	if err != nil {
+		return TransferHold{}, err
+	}
+
+	if hold.Status != TransferHoldStatusAuthorized {
+		return TransferHold{}, ErrTransferHoldNotAuthorized
+	}
+
+	return hold, nil
+}
+
+// releaseTransferHold gives the held amount back to the available balance of the source account
+func releaseTransferHold(ctx context.Context, q *Queries, hold TransferHold, status string) (TransferHold, error) {
+	_, err := q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+		ID:     hold.FromAccountID,
+		Amount: hold.Amount.Add(hold.Fee).Neg(),
+	})
+	if err != nil {
+		return TransferHold{}, err
+	}
+
+	return q.UpdateTransferHoldStatus(ctx, UpdateTransferHoldStatusParams{
+		ID:     hold.ID,
+		Status: status,
+	})
+}
+
+// transferTxParams returns the transfer a hold makes when it is captured
+func (hold TransferHold) transferTxParams() TransferTxParams {
+	arg := TransferTxParams{
+		FromAccountID:   hold.FromAccountID,
+		ToAccountID:     hold.ToAccountID,
+		Amount:          hold.Amount,
+		ConvertedAmount: hold.ConvertedAmount,
+		ExchangeRate:    hold.ExchangeRate,
+		FromCurrency:    hold.FromCurrency,
+		ToCurrency:      hold.ToCurrency,
+		Fee:             hold.Fee,
+		FeeScheduleID:   hold.FeeScheduleID.Int64,
+		MidMarketRate:   hold.MidMarketRate.Decimal,
+		MarkupBps:       hold.MarkupBps.Int32,
+		Username:        hold.Owner,
+	}
+
+	if hold.PivotCurrency.Valid {
+		arg.PivotRate = &PivotRate{
+			Currency: hold.PivotCurrency.String,
+			FromRate: hold.FromPivotRate.Decimal,
+			ToRate:   hold.PivotToRate.Decimal,
+		}
+	}
+
+	return arg
+}
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizeAndCaptureTransferHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, "USD")
+	account2 := createAccountWithCurrency(t, "USD")
+
+	amount := decimal.NewFromInt(10).Round(2)
+	fee := decimal.NewFromInt(1).Round(2)
+
+	// The hold reduces the available balance but not the balance
+	authorized := authorizeTransferHold(t, store, account1, account2, amount, fee, time.Now().Add(time.Hour))
+	require.True(t, account1.Balance.Equal(authorized.FromAccount.Balance))
+	require.True(t, amount.Add(fee).Equal(authorized.FromAccount.HeldAmount))
+
+	// Spending more than the available balance is refused while the hold is in place
+	available := account1.Balance.Sub(amount).Sub(fee)
+	_, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID:   account1.ID,
+		ToAccountID:     account2.ID,
+		Amount:          available.Add(decimal.NewFromInt(1)),
+		ConvertedAmount: available.Add(decimal.NewFromInt(1)),
+		ExchangeRate:    decimal.NewFromInt(1).Round(8),
+		FromCurrency:    account1.Currency,
+		ToCurrency:      account2.Currency,
+	})
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	captured, err := store.CaptureTransferHoldTx(context.Background(), authorized.Hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, TransferHoldStatusCaptured, captured.Hold.Status)
+	require.Equal(t, captured.Transfer.Transfer.ID, captured.Hold.TransferID.Int64)
+	require.True(t, captured.Transfer.FromAccount.HeldAmount.IsZero())
+	require.True(t, account1.Balance.Sub(amount).Sub(fee).Equal(captured.Transfer.FromAccount.Balance))
+	require.True(t, account2.Balance.Add(amount).Equal(captured.Transfer.ToAccount.Balance))
+
+	// A hold is captured once
+	_, err = store.CaptureTransferHoldTx(context.Background(), authorized.Hold.ID)
+	require.ErrorIs(t, err, ErrTransferHoldNotAuthorized)
+	_, err = store.VoidTransferHoldTx(context.Background(), authorized.Hold.ID)
+	require.ErrorIs(t, err, ErrTransferHoldNotAuthorized)
+}
+
+func TestVoidTransferHoldTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, "USD")
+	account2 := createAccountWithCurrency(t, "USD")
+
+	authorized := authorizeTransferHold(t, store, account1, account2, decimal.NewFromInt(10).Round(2), decimal.Zero, time.Now().Add(time.Hour))
+
+	voided, err := store.VoidTransferHoldTx(context.Background(), authorized.Hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, TransferHoldStatusVoided, voided.Status)
+	require.False(t, voided.TransferID.Valid)
+
+	account, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.True(t, account1.Balance.Equal(account.Balance))
+	require.True(t, account.HeldAmount.IsZero())
+
+	_, err = store.CaptureTransferHoldTx(context.Background(), authorized.Hold.ID)
+	require.ErrorIs(t, err, ErrTransferHoldNotAuthorized)
+}
+
+func TestExpireTransferHoldsTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, "USD")
+	account2 := createAccountWithCurrency(t, "USD")
+
+	authorized := authorizeTransferHold(t, store, account1, account2, decimal.NewFromInt(10).Round(2), decimal.Zero, time.Now().Add(-time.Minute))
+
+	// An expired hold cannot be captured and is released by the expiry
+	_, err := store.CaptureTransferHoldTx(context.Background(), authorized.Hold.ID)
+	require.ErrorIs(t, err, ErrTransferHoldExpired)
+
+	for {
+		expired, err := store.ExpireTransferHoldsTx(context.Background(), 100)
+		require.NoError(t, err)
+		if len(expired) < 100 {
+			break
+		}
+	}
+
+	hold, err := store.GetTransferHold(context.Background(), authorized.Hold.ID)
+	require.NoError(t, err)
+	require.Equal(t, TransferHoldStatusExpired, hold.Status)
+
+	account, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.True(t, account.HeldAmount.IsZero())
+}
+
+func TestExpireTransferHoldsTxConcurrentTransfers(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, "USD")
+	account2 := createAccountWithCurrency(t, "USD")
+
+	// The hold of the higher account id expires first, so expiry order is the reverse of the account lock order
+	amount := decimal.NewFromInt(1).Round(2)
+	authorizeTransferHold(t, store, account2, account1, amount, decimal.Zero, time.Now().Add(-2*time.Minute))
+	authorizeTransferHold(t, store, account1, account2, amount, decimal.Zero, time.Now().Add(-time.Minute))
+
+	n := 10
+	errs := make(chan error)
+
+	go func() {
+		for {
+			expired, err := store.ExpireTransferHoldsTx(context.Background(), 100)
+			if err != nil || len(expired) < 100 {
+				errs <- err
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < n; i++ {
+		fromAccount, toAccount := account1, account2
+		if i%2 == 1 {
+			fromAccount, toAccount = account2, account1
+		}
+
+		go func() {
+			_, err := store.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID:   fromAccount.ID,
+				ToAccountID:     toAccount.ID,
+				Amount:          amount,
+				ConvertedAmount: amount,
+				ExchangeRate:    decimal.NewFromInt(1).Round(8),
+				FromCurrency:    fromAccount.Currency,
+				ToCurrency:      toAccount.Currency,
+			})
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n+1; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	for _, account := range []Account{account1, account2} {
+		updated, err := store.GetAccount(context.Background(), account.ID)
+		require.NoError(t, err)
+		require.True(t, updated.HeldAmount.IsZero())
+		require.True(t, account.Balance.Equal(updated.Balance))
+	}
+}
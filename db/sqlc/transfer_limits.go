@@ -0,0 +1,177 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// Rolling windows the daily and monthly transfer limits are checked over
+const (
+	DailyLimitWindow   = 24 * time.Hour
+	MonthlyLimitWindow = 30 * 24 * time.Hour
+)
+
+// Sources of the transfer limits of a user, see TransferLimits
+const (
+	TransferLimitSourceTier     = "tier"
+	TransferLimitSourceOverride = "override"
+	TransferLimitSourceNone     = "none"
+)
+
+// Errors returned by TransferTx when a transfer would break a limit of the user
+var (
+	ErrPerTransactionLimitExceeded = errors.New("transfer amount exceeds the per-transaction limit")
+	ErrDailyAmountLimitExceeded    = errors.New("transfer would exceed the daily amount limit")
+	ErrDailyCountLimitExceeded     = errors.New("transfer would exceed the daily transfer count limit")
+	ErrMonthlyAmountLimitExceeded  = errors.New("transfer would exceed the monthly amount limit")
+	ErrMonthlyCountLimitExceeded   = errors.New("transfer would exceed the monthly transfer count limit")
+)
+
+// TransferLimits are the limits that apply to the transfers of a user in one currency.
+// An override set for the user replaces the limits of their tier, and a limit that is not valid is not enforced
+type TransferLimits struct {
+	Username             string              `json:"username"`
+	Tier                 string              `json:"tier"`
+	Currency             string              `json:"currency"`
+	Source               string              `json:"source"`
+	PerTransactionAmount decimal.NullDecimal `json:"per_transaction_amount"`
+	DailyAmount          decimal.NullDecimal `json:"daily_amount"`
+	DailyCount           pgtype.Int4         `json:"daily_count"`
+	MonthlyAmount        decimal.NullDecimal `json:"monthly_amount"`
+	MonthlyCount         pgtype.Int4         `json:"monthly_count"`
+}
+
+// TransferUsage is what a user has transferred in one currency over the limit windows
+type TransferUsage struct {
+	DailyAmount   decimal.Decimal `json:"daily_amount"`
+	DailyCount    int64           `json:"daily_count"`
+	MonthlyAmount decimal.Decimal `json:"monthly_amount"`
+	MonthlyCount  int64           `json:"monthly_count"`
+}
+
+// GetTransferLimits resolves the limits of a user in a currency, returning pgx.ErrNoRows when the user does not exist
+func GetTransferLimits(ctx context.Context, q Querier, username string, currency string) (TransferLimits, error) {
+	tier, err := q.GetUserTier(ctx, username)
+	if err != nil {
+		return TransferLimits{}, err
+	}
+
+	limits := TransferLimits{
+		Username: username,
+		Tier:     tier,
+		Currency: currency,
+		Source:   TransferLimitSourceNone,
+	}
+
+	override, err := q.GetUserTransferLimit(ctx, GetUserTransferLimitParams{
+		Username: username,
+		Currency: currency,
+	})
+	if err == nil {
+		limits.Source = TransferLimitSourceOverride
+		limits.PerTransactionAmount = override.PerTransactionAmount
+		limits.DailyAmount = override.DailyAmount
+		limits.DailyCount = override.DailyCount
+		limits.MonthlyAmount = override.MonthlyAmount
+		limits.MonthlyCount = override.MonthlyCount
+		return limits, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return TransferLimits{}, err
+	}
+
+	tierLimit, err := q.GetTierTransferLimit(ctx, GetTierTransferLimitParams{
+		Tier:     tier,
+		Currency: currency,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// No limits are configured for the tier in this currency
+		return limits, nil
+	}
+	if err != nil {
+		return TransferLimits{}, err
+	}
+
+	limits.Source = TransferLimitSourceTier
+	limits.PerTransactionAmount = tierLimit.PerTransactionAmount
+	limits.DailyAmount = tierLimit.DailyAmount
+	limits.DailyCount = tierLimit.DailyCount
+	limits.MonthlyAmount = tierLimit.MonthlyAmount
+	limits.MonthlyCount = tierLimit.MonthlyCount
+
+	return limits, nil
+}
+
+// GetTransferUsage returns what a user has transferred in a currency over the windows ending at now
+func GetTransferUsage(ctx context.Context, q Querier, username string, currency string, now time.Time) (TransferUsage, error) {
+	row, err := q.GetTransferUsage(ctx, GetTransferUsageParams{
+		DailySince:   now.Add(-DailyLimitWindow),
+		Owner:        username,
+		Currency:     currency,
+		MonthlySince: now.Add(-MonthlyLimitWindow),
+	})
+	if err != nil {
+		return TransferUsage{}, err
+	}
+
+	return TransferUsage{
+		DailyAmount:   numericToDecimal(row.DailyAmount),
+		DailyCount:    row.DailyCount,
+		MonthlyAmount: numericToDecimal(row.MonthlyAmount),
+		MonthlyCount:  row.MonthlyCount,
+	}, nil
+}
+
+func numericToDecimal(n pgtype.Numeric) decimal.Decimal {
+	if !n.Valid || n.Int == nil {
+		return decimal.Zero
+	}
+	return decimal.NewFromBigInt(n.Int, n.Exp)
+}
+
+// checkTransferLimits returns an error when a transfer of amount from the account would break a limit of its owner.
+// It runs after the account row is locked, and each user has one account per currency, so concurrent transfers
+// of the same user and currency are checked one after the other
+func checkTransferLimits(ctx context.Context, q *Queries, fromAccount Account, amount decimal.Decimal) error {
+	limits, err := GetTransferLimits(ctx, q, fromAccount.Owner, fromAccount.Currency)
+	if err != nil {
+		return err
+	}
+
+	if limits.Source == TransferLimitSourceNone {
+		return nil
+	}
+
+	if limits.PerTransactionAmount.Valid && amount.GreaterThan(limits.PerTransactionAmount.Decimal) {
+		return ErrPerTransactionLimitExceeded
+	}
+
+	if !limits.DailyAmount.Valid && !limits.DailyCount.Valid && !limits.MonthlyAmount.Valid && !limits.MonthlyCount.Valid {
+		return nil
+	}
+
+	usage, err := GetTransferUsage(ctx, q, fromAccount.Owner, fromAccount.Currency, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if limits.DailyCount.Valid && usage.DailyCount+1 > int64(limits.DailyCount.Int32) {
+		return ErrDailyCountLimitExceeded
+	}
+	if limits.DailyAmount.Valid && usage.DailyAmount.Add(amount).GreaterThan(limits.DailyAmount.Decimal) {
+		return ErrDailyAmountLimitExceeded
+	}
+	if limits.MonthlyCount.Valid && usage.MonthlyCount+1 > int64(limits.MonthlyCount.Int32) {
+		return ErrMonthlyCountLimitExceeded
+	}
+	if limits.MonthlyAmount.Valid && usage.MonthlyAmount.Add(amount).GreaterThan(limits.MonthlyAmount.Decimal) {
+		return ErrMonthlyAmountLimitExceeded
+	}
+
+	return nil
+}
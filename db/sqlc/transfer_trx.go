@@ -2,12 +2,31 @@ package db
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 )
 
+// ErrIdempotencyKeyConflict is returned by TransferTx when the idempotency key
+// was already stored by another transaction for the same user
+var ErrIdempotencyKeyConflict = errors.New("idempotency key already used")
+
+// ErrQuoteUnavailable is returned by TransferTx when the quote has expired,
+// was used by another transfer, or belongs to another user
+var ErrQuoteUnavailable = errors.New("quote is no longer available")
+
+// PivotRate records how an exchange rate was derived through a pivot currency,
+// from_currency -> Currency at FromRate, then Currency -> to_currency at ToRate
+type PivotRate struct {
+	Currency string          `json:"currency"`
+	FromRate decimal.Decimal `json:"from_rate"`
+	ToRate   decimal.Decimal `json:"to_rate"`
+}
+
 // TransferTxParams contains the input parameters of the transfer transaction
 type TransferTxParams struct {
 	FromAccountID   int64           `json:"from_account_id"`
@@ -18,6 +37,22 @@ type TransferTxParams struct {
 	FromCurrency    string          `json:"from_currency,omitempty"`
 	ToCurrency      string          `json:"to_currency,omitempty"`
 	Fee             decimal.Decimal `json:"fee,omitempty"`
+	// Set when the exchange rate was derived through a pivot currency
+	PivotRate *PivotRate `json:"pivot_rate,omitempty"`
+	// Fee schedule version the fee was charged with, 0 for the default fee
+	FeeScheduleID int64 `json:"fee_schedule_id,omitempty"`
+	// Mid-market rate ExchangeRate was derived from and the markup between them in basis points,
+	// recorded on cross-currency transfers when MidMarketRate is set
+	MidMarketRate decimal.Decimal `json:"mid_market_rate,omitempty"`
+	MarkupBps     int32           `json:"markup_bps,omitempty"`
+	// Quote the rate, converted amount and fee were taken from, consumed by the transfer when set
+	QuoteID int64 `json:"quote_id,omitempty"`
+	// Idempotency data, only persisted when IdempotencyKey is set
+	Username       string `json:"username,omitempty"`
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+	RequestHash    string `json:"request_hash,omitempty"`
+	// Status code replayed to retries of the request, chosen by the caller
+	ResponseStatus int32 `json:"response_status,omitempty"`
 }
 
 // TransferTxResult is the result of the transfer transaction
@@ -27,126 +62,239 @@ type TransferTxResult struct {
 	ToAccount   Account  `json:"to_account"`
 	FromEntry   Entry    `json:"from_entry"`
 	ToEntry     Entry    `json:"to_entry"`
+	// Fee and FX postings on house accounts that balance the transfer in each currency
+	HouseEntries []Entry `json:"house_entries,omitempty"`
 }
 
 // TransferTx performs a money transfer from one account to the other.
-// It creates the transfer, add account entries, and update accounts' balance within a database transaction
+// It creates the transfer, add account entries, and update accounts' balance within a database transaction.
+// The fee and any currency conversion are posted to house accounts so the entries sum to zero per currency.
+// When a quote is given it is consumed, so it cannot pay for a second transfer.
+// The balance check leaves out the amount held by authorized transfers of the source account.
+// The transfer limits of the sender are checked in the same transaction, after the source account is locked.
+// When an idempotency key is given, the result is stored against it in the same transaction
 func (store *SQLStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
 	var result TransferTxResult
 
 	err := store.execTx(ctx, func(q *Queries) error {
 		var err error
+		result, err = transferTx(ctx, q, arg, nil)
+		return err
+	})
 
-		// Validate that accounts exist with consistent locking order (smaller ID first)
-		var fromAccount, toAccount Account
-
-		if arg.FromAccountID < arg.ToAccountID {
-			// Lock fromAccount first, then toAccount
-			fromAccount, err = q.GetAccountForUpdate(ctx, arg.FromAccountID)
-			if err != nil {
-				return fmt.Errorf("from account not found: %w", err)
-			}
-
-			toAccount, err = q.GetAccountForUpdate(ctx, arg.ToAccountID)
-			if err != nil {
-				return fmt.Errorf("to account not found: %w", err)
-			}
-		} else {
-			// Lock toAccount first, then fromAccount
-			toAccount, err = q.GetAccountForUpdate(ctx, arg.ToAccountID)
-			if err != nil {
-				return fmt.Errorf("to account not found: %w", err)
-			}
-
-			fromAccount, err = q.GetAccountForUpdate(ctx, arg.FromAccountID)
-			if err != nil {
-				return fmt.Errorf("from account not found: %w", err)
-			}
-		}
+	return result, err
+}
 
-		// Validate currencies match if provided
-		if arg.FromCurrency != "" && fromAccount.Currency != arg.FromCurrency {
-			return fmt.Errorf("from account currency mismatch: expected %s, got %s", fromAccount.Currency, arg.FromCurrency)
-		}
+// transferTx runs a transfer in the transaction of q. hold is the authorized hold the transfer captures,
+// its held amount is released before the balance check and the limits it was authorized under are not checked again
+func transferTx(ctx context.Context, q *Queries, arg TransferTxParams, hold *TransferHold) (TransferTxResult, error) {
+	var result TransferTxResult
+	var err error
 
-		if arg.ToCurrency != "" && toAccount.Currency != arg.ToCurrency {
-			return fmt.Errorf("to account currency mismatch: expected %s, got %s", toAccount.Currency, arg.ToCurrency)
-		}
+	// Validate that accounts exist with consistent locking order (smaller ID first)
+	var fromAccount, toAccount Account
 
-		// Validate sufficient balance (including fee)
-		totalAmount := arg.Amount.Add(arg.Fee)
-		if fromAccount.Balance.LessThan(totalAmount) {
-			return fmt.Errorf("insufficient balance: account has %s, transfer requires %s (amount: %s + fee: %s)", fromAccount.Balance.String(), totalAmount.String(), arg.Amount.String(), arg.Fee.String())
+	if arg.FromAccountID < arg.ToAccountID {
+		// Lock fromAccount first, then toAccount
+		fromAccount, err = q.GetAccountForUpdate(ctx, arg.FromAccountID)
+		if err != nil {
+			return result, fmt.Errorf("from account not found: %w", err)
 		}
 
-		// Prepare currency data
-		var fromCurrency pgtype.Text
-		var toCurrency pgtype.Text
-
-		if arg.FromCurrency != "" {
-			fromCurrency.Scan(arg.FromCurrency)
+		toAccount, err = q.GetAccountForUpdate(ctx, arg.ToAccountID)
+		if err != nil {
+			return result, fmt.Errorf("to account not found: %w", err)
 		}
-		if arg.ToCurrency != "" {
-			toCurrency.Scan(arg.ToCurrency)
+	} else {
+		// Lock toAccount first, then fromAccount
+		toAccount, err = q.GetAccountForUpdate(ctx, arg.ToAccountID)
+		if err != nil {
+			return result, fmt.Errorf("to account not found: %w", err)
 		}
 
-		createTransferResult, err := q.CreateTransfer(ctx, CreateTransferParams{
-			FromAccountID:   arg.FromAccountID,
-			ToAccountID:     arg.ToAccountID,
-			Amount:          arg.Amount,
-			ConvertedAmount: arg.ConvertedAmount,
-			ExchangeRate:    arg.ExchangeRate,
-			FromCurrency:    fromCurrency,
-			ToCurrency:      toCurrency,
-			Fee:             arg.Fee,
-		})
+		fromAccount, err = q.GetAccountForUpdate(ctx, arg.FromAccountID)
 		if err != nil {
-			return err
+			return result, fmt.Errorf("from account not found: %w", err)
 		}
+	}
 
-		// Convert CreateTransferRow to Transfer
-		result.Transfer = Transfer{
-			ID:              createTransferResult.ID,
-			FromAccountID:   createTransferResult.FromAccountID,
-			ToAccountID:     createTransferResult.ToAccountID,
-			Amount:          createTransferResult.Amount,
-			ConvertedAmount: createTransferResult.ConvertedAmount,
-			ExchangeRate:    createTransferResult.ExchangeRate,
-			FromCurrency:    createTransferResult.FromCurrency,
-			ToCurrency:      createTransferResult.ToCurrency,
-			Fee:             createTransferResult.Fee,
-			CreatedAt:       createTransferResult.CreatedAt,
-		}
+	return postTransfer(ctx, q, arg, fromAccount, toAccount, hold)
+}
 
-		// Create entries with original amount + fee (from account) and converted amount (to account)
-		fromEntryAmount := arg.Amount.Add(arg.Fee).Neg() // Debit original amount + fee (negative)
-		toEntryAmount := arg.ConvertedAmount             // Credit converted amount
+// postTransfer makes a transfer between accounts the transaction of q has already locked
+func postTransfer(ctx context.Context, q *Queries, arg TransferTxParams, fromAccount Account, toAccount Account, hold *TransferHold) (TransferTxResult, error) {
+	var result TransferTxResult
+	var err error
+
+	// Validate currencies match if provided
+	if arg.FromCurrency != "" && fromAccount.Currency != arg.FromCurrency {
+		return result, fmt.Errorf("from account currency mismatch: expected %s, got %s", fromAccount.Currency, arg.FromCurrency)
+	}
+
+	if arg.ToCurrency != "" && toAccount.Currency != arg.ToCurrency {
+		return result, fmt.Errorf("to account currency mismatch: expected %s, got %s", toAccount.Currency, arg.ToCurrency)
+	}
 
-		result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.FromAccountID,
-			Amount:    fromEntryAmount,
+	// A captured hold reserved its amount and fee on the source account, release them before the balance check
+	if hold != nil {
+		fromAccount.HeldAmount, err = q.AddAccountHeldAmount(ctx, AddAccountHeldAmountParams{
+			ID:     fromAccount.ID,
+			Amount: hold.Amount.Add(hold.Fee).Neg(),
 		})
 		if err != nil {
-			return err
+			return result, err
 		}
+	}
 
-		result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
-			AccountID: arg.ToAccountID,
-			Amount:    toEntryAmount,
-		})
+	// Validate sufficient available balance (including fee), the held amount is reserved for authorized transfers
+	totalAmount := arg.Amount.Add(arg.Fee)
+	availableBalance := fromAccount.Balance.Sub(fromAccount.HeldAmount)
+	if availableBalance.LessThan(totalAmount) {
+		return result, fmt.Errorf("%w: account has %s available, transfer requires %s (amount: %s + fee: %s)", ErrInsufficientFunds, availableBalance.String(), totalAmount.String(), arg.Amount.String(), arg.Fee.String())
+	}
+
+	// Check the limits of the sender while the source account is locked,
+	// a captured hold was checked against them when it was authorized
+	if hold == nil {
+		err = checkTransferLimits(ctx, q, fromAccount, arg.Amount)
 		if err != nil {
-			return err
+			return result, err
 		}
+	}
+
+	// Resolve the house postings before writing anything
+	houseLegs, err := transferHouseLegs(ctx, q, fromAccount, toAccount, arg)
+	if err != nil {
+		return result, err
+	}
+
+	// Prepare currency data
+	var fromCurrency pgtype.Text
+	var toCurrency pgtype.Text
+
+	if arg.FromCurrency != "" {
+		fromCurrency.Scan(arg.FromCurrency)
+	}
+	if arg.ToCurrency != "" {
+		toCurrency.Scan(arg.ToCurrency)
+	}
+
+	var pivotCurrency pgtype.Text
+	var fromPivotRate, pivotToRate decimal.NullDecimal
+
+	if arg.PivotRate != nil {
+		pivotCurrency.Scan(arg.PivotRate.Currency)
+		fromPivotRate = decimal.NewNullDecimal(arg.PivotRate.FromRate)
+		pivotToRate = decimal.NewNullDecimal(arg.PivotRate.ToRate)
+	}
 
-		// Update account balances with appropriate amounts
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, fromEntryAmount, arg.ToAccountID, toEntryAmount)
-		} else {
-			result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, toEntryAmount, arg.FromAccountID, fromEntryAmount)
+	var midMarketRate decimal.NullDecimal
+	var markupBps pgtype.Int4
+
+	if !arg.MidMarketRate.IsZero() {
+		midMarketRate = decimal.NewNullDecimal(arg.MidMarketRate)
+		markupBps = pgtype.Int4{Int32: arg.MarkupBps, Valid: true}
+	}
+
+	createTransferResult, err := q.CreateTransfer(ctx, CreateTransferParams{
+		FromAccountID:   arg.FromAccountID,
+		ToAccountID:     arg.ToAccountID,
+		Amount:          arg.Amount,
+		ConvertedAmount: arg.ConvertedAmount,
+		ExchangeRate:    arg.ExchangeRate,
+		FromCurrency:    fromCurrency,
+		ToCurrency:      toCurrency,
+		Fee:             arg.Fee,
+		PivotCurrency:   pivotCurrency,
+		FromPivotRate:   fromPivotRate,
+		PivotToRate:     pivotToRate,
+		FeeScheduleID:   pgtype.Int8{Int64: arg.FeeScheduleID, Valid: arg.FeeScheduleID != 0},
+		MidMarketRate:   midMarketRate,
+		MarkupBps:       markupBps,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	// Convert CreateTransferRow to Transfer
+	result.Transfer = Transfer{
+		ID:                   createTransferResult.ID,
+		FromAccountID:        createTransferResult.FromAccountID,
+		ToAccountID:          createTransferResult.ToAccountID,
+		Amount:               createTransferResult.Amount,
+		ConvertedAmount:      createTransferResult.ConvertedAmount,
+		ExchangeRate:         createTransferResult.ExchangeRate,
+		FromCurrency:         createTransferResult.FromCurrency,
+		ToCurrency:           createTransferResult.ToCurrency,
+		Fee:                  createTransferResult.Fee,
+		CreatedAt:            createTransferResult.CreatedAt,
+		PivotCurrency:        createTransferResult.PivotCurrency,
+		FromPivotRate:        createTransferResult.FromPivotRate,
+		PivotToRate:          createTransferResult.PivotToRate,
+		FeeScheduleID:        createTransferResult.FeeScheduleID,
+		MidMarketRate:        createTransferResult.MidMarketRate,
+		MarkupBps:            createTransferResult.MarkupBps,
+		Status:               createTransferResult.Status,
+		ReversalOfTransferID: createTransferResult.ReversalOfTransferID,
+		RefundedFee:          createTransferResult.RefundedFee,
+	}
+
+	// Create entries with original amount + fee (from account) and converted amount (to account)
+	fromEntryAmount := arg.Amount.Add(arg.Fee).Neg() // Debit original amount + fee (negative)
+	toEntryAmount := arg.ConvertedAmount             // Credit converted amount
+
+	transferID := pgtype.Int8{Int64: result.Transfer.ID, Valid: true}
+
+	if arg.QuoteID != 0 {
+		_, err = q.ConsumeFxQuote(ctx, ConsumeFxQuoteParams{
+			TransferID: transferID,
+			ID:         arg.QuoteID,
+			Username:   arg.Username,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return result, ErrQuoteUnavailable
 		}
+		if err != nil {
+			return result, err
+		}
+	}
 
-		return err
+	result.FromEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  arg.FromAccountID,
+		Amount:     fromEntryAmount,
+		TransferID: transferID,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.ToEntry, err = q.CreateEntry(ctx, CreateEntryParams{
+		AccountID:  arg.ToAccountID,
+		Amount:     toEntryAmount,
+		TransferID: transferID,
 	})
+	if err != nil {
+		return result, err
+	}
+
+	// Update account balances with appropriate amounts
+	if arg.FromAccountID < arg.ToAccountID {
+		result.FromAccount, result.ToAccount, err = addMoney(ctx, q, arg.FromAccountID, fromEntryAmount, arg.ToAccountID, toEntryAmount)
+	} else {
+		result.ToAccount, result.FromAccount, err = addMoney(ctx, q, arg.ToAccountID, toEntryAmount, arg.FromAccountID, fromEntryAmount)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	result.HouseEntries, err = postHouseLegs(ctx, q, transferID, houseLegs)
+	if err != nil {
+		return result, err
+	}
+
+	if arg.IdempotencyKey != "" {
+		err = storeIdempotencyKey(ctx, q, arg, result)
+	}
 
 	return result, err
 }
@@ -184,3 +332,27 @@ func addMoney(
 	account2, err = q.GetAccount(ctx, accountID2)
 	return
 }
+
+// storeIdempotencyKey saves the transfer result against the idempotency key.
+// A concurrent transaction holding the same key makes the insert a no-op, in which case
+// the whole transfer is rolled back with ErrIdempotencyKeyConflict
+func storeIdempotencyKey(ctx context.Context, q *Queries, arg TransferTxParams, result TransferTxResult) error {
+	responseBody, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.CreateIdempotencyKey(ctx, CreateIdempotencyKeyParams{
+		Username:       arg.Username,
+		IdempotencyKey: arg.IdempotencyKey,
+		RequestHash:    arg.RequestHash,
+		TransferID:     result.Transfer.ID,
+		ResponseStatus: arg.ResponseStatus,
+		ResponseBody:   responseBody,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrIdempotencyKeyConflict
+	}
+
+	return err
+}
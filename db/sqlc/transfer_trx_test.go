@@ -3,8 +3,11 @@ package db
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 
@@ -376,3 +379,241 @@ func TestTransferTxMultiCurrency(t *testing.T) {
 	require.Equal(t, account1.Currency, updatedAccount1.Currency)
 	require.Equal(t, account2.Currency, updatedAccount2.Currency)
 }
+
+func TestTransferTxIdempotencyKey(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createRandomAccount(t)
+	account2 := createAccountWithCurrency(t, account1.Currency)
+	amount := decimal.NewFromInt(10).Round(2)
+
+	arg := TransferTxParams{
+		FromAccountID:   account1.ID,
+		ToAccountID:     account2.ID,
+		Amount:          amount,
+		ConvertedAmount: amount,
+		ExchangeRate:    decimal.NewFromInt(1).Round(8),
+		FromCurrency:    account1.Currency,
+		ToCurrency:      account2.Currency,
+		Username:        account1.Owner,
+		IdempotencyKey:  util.RandomString(16),
+		RequestHash:     util.RandomString(64),
+		ResponseStatus:  http.StatusCreated,
+	}
+
+	result, err := store.TransferTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	storedKey, err := store.GetIdempotencyKey(context.Background(), GetIdempotencyKeyParams{
+		Username:       arg.Username,
+		IdempotencyKey: arg.IdempotencyKey,
+	})
+	require.NoError(t, err)
+	require.Equal(t, result.Transfer.ID, storedKey.TransferID)
+	require.Equal(t, arg.RequestHash, storedKey.RequestHash)
+	require.Equal(t, arg.ResponseStatus, storedKey.ResponseStatus)
+
+	// Running the same key again rolls the second transfer back
+	_, err = store.TransferTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrIdempotencyKeyConflict)
+
+	updatedAccount1, err := store.GetAccount(context.Background(), account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance.Sub(amount), updatedAccount1.Balance)
+}
+
+func TestTransferTxHouseEntries(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, "GBP")
+	account2 := createAccountWithCurrency(t, "NGN")
+
+	amount := decimal.NewFromInt(10).Round(2)
+	fee := decimal.NewFromInt(1).Round(2)
+	convertedAmount := decimal.NewFromInt(20000).Round(2)
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID:   account1.ID,
+		ToAccountID:     account2.ID,
+		Amount:          amount,
+		ConvertedAmount: convertedAmount,
+		ExchangeRate:    decimal.NewFromInt(2000).Round(8),
+		FromCurrency:    account1.Currency,
+		ToCurrency:      account2.Currency,
+		Fee:             fee,
+	})
+	require.NoError(t, err)
+
+	// Fee credit plus the FX legs in both currencies
+	require.Len(t, result.HouseEntries, 3)
+
+	totals := map[string]decimal.Decimal{}
+	entries := append([]Entry{result.FromEntry, result.ToEntry}, result.HouseEntries...)
+	for _, entry := range entries {
+		require.Equal(t, result.Transfer.ID, entry.TransferID.Int64)
+
+		account, err := store.GetAccount(context.Background(), entry.AccountID)
+		require.NoError(t, err)
+		totals[account.Currency] = totals[account.Currency].Add(entry.Amount)
+	}
+
+	require.Len(t, totals, 2)
+	for currency, total := range totals {
+		require.Truef(t, total.IsZero(), "entries in %s sum to %s", currency, total.String())
+	}
+
+	feeAccountID, err := store.GetHouseAccountID(context.Background(), GetHouseAccountIDParams{
+		Currency: "GBP",
+		Purpose:  HouseAccountPurposeFee,
+	})
+	require.NoError(t, err)
+	feeEntry := entryWithAccount(t, result.HouseEntries, feeAccountID)
+	require.True(t, fee.Equal(feeEntry.Amount))
+}
+
+func TestTransferTxPivotRate(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, "NGN")
+	account2 := createAccountWithCurrency(t, "EUR")
+
+	pivotRate := &PivotRate{
+		Currency: "USD",
+		FromRate: decimal.RequireFromString("0.00066667"),
+		ToRate:   decimal.RequireFromString("0.85"),
+	}
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID:   account1.ID,
+		ToAccountID:     account2.ID,
+		Amount:          decimal.NewFromInt(10).Round(2),
+		ConvertedAmount: decimal.RequireFromString("0.01"),
+		ExchangeRate:    decimal.RequireFromString("0.00056667"),
+		FromCurrency:    account1.Currency,
+		ToCurrency:      account2.Currency,
+		PivotRate:       pivotRate,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "USD", result.Transfer.PivotCurrency.String)
+	require.True(t, result.Transfer.FromPivotRate.Valid)
+	require.True(t, pivotRate.FromRate.Equal(result.Transfer.FromPivotRate.Decimal))
+	require.True(t, pivotRate.ToRate.Equal(result.Transfer.PivotToRate.Decimal))
+
+	// Direct rates leave the pivot columns empty
+	account3 := createAccountWithCurrency(t, "NGN")
+	result, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID:   account1.ID,
+		ToAccountID:     account3.ID,
+		Amount:          decimal.NewFromInt(1).Round(2),
+		ConvertedAmount: decimal.NewFromInt(1).Round(2),
+		ExchangeRate:    decimal.NewFromInt(1).Round(8),
+		FromCurrency:    account1.Currency,
+		ToCurrency:      account3.Currency,
+	})
+	require.NoError(t, err)
+	require.False(t, result.Transfer.PivotCurrency.Valid)
+	require.False(t, result.Transfer.FromPivotRate.Valid)
+	require.False(t, result.Transfer.PivotToRate.Valid)
+}
+
+func TestTransferTxMidMarketRate(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, "USD")
+	account2 := createAccountWithCurrency(t, "EUR")
+
+	result, err := store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID:   account1.ID,
+		ToAccountID:     account2.ID,
+		Amount:          decimal.NewFromInt(100).Round(2),
+		ConvertedAmount: decimal.RequireFromString("84.58"),
+		ExchangeRate:    decimal.RequireFromString("0.84575"),
+		FromCurrency:    account1.Currency,
+		ToCurrency:      account2.Currency,
+		MidMarketRate:   decimal.RequireFromString("0.85"),
+		MarkupBps:       50,
+	})
+	require.NoError(t, err)
+	require.True(t, result.Transfer.MidMarketRate.Valid)
+	require.True(t, decimal.RequireFromString("0.85").Equal(result.Transfer.MidMarketRate.Decimal))
+	require.True(t, result.Transfer.MarkupBps.Valid)
+	require.Equal(t, int32(50), result.Transfer.MarkupBps.Int32)
+
+	// Without a mid-market rate nothing is recorded, as for same-currency transfers
+	account3 := createAccountWithCurrency(t, "USD")
+	result, err = store.TransferTx(context.Background(), TransferTxParams{
+		FromAccountID:   account1.ID,
+		ToAccountID:     account3.ID,
+		Amount:          decimal.NewFromInt(1).Round(2),
+		ConvertedAmount: decimal.NewFromInt(1).Round(2),
+		ExchangeRate:    decimal.NewFromInt(1).Round(8),
+		FromCurrency:    account1.Currency,
+		ToCurrency:      account3.Currency,
+	})
+	require.NoError(t, err)
+	require.False(t, result.Transfer.MidMarketRate.Valid)
+	require.False(t, result.Transfer.MarkupBps.Valid)
+}
+
+func TestTransferTxLimits(t *testing.T) {
+	store := NewStore(testDB)
+
+	account1 := createAccountWithCurrency(t, "USD")
+	account2 := createAccountWithCurrency(t, "USD")
+
+	// Users start on the limits of the standard tier
+	limits, err := GetTransferLimits(context.Background(), store, account1.Owner, "USD")
+	require.NoError(t, err)
+	require.Equal(t, "standard", limits.Tier)
+	require.Equal(t, TransferLimitSourceTier, limits.Source)
+
+	_, err = store.UpsertUserTransferLimit(context.Background(), UpsertUserTransferLimitParams{
+		Username:             account1.Owner,
+		Currency:             "USD",
+		PerTransactionAmount: decimal.NewNullDecimal(decimal.NewFromInt(5)),
+		DailyCount:           pgtype.Int4{Int32: 1, Valid: true},
+	})
+	require.NoError(t, err)
+
+	transfer := func(amount decimal.Decimal) error {
+		_, err := store.TransferTx(context.Background(), TransferTxParams{
+			FromAccountID:   account1.ID,
+			ToAccountID:     account2.ID,
+			Amount:          amount,
+			ConvertedAmount: amount,
+			ExchangeRate:    decimal.NewFromInt(1).Round(8),
+			FromCurrency:    account1.Currency,
+			ToCurrency:      account2.Currency,
+		})
+		return err
+	}
+
+	require.ErrorIs(t, transfer(decimal.NewFromInt(6)), ErrPerTransactionLimitExceeded)
+	require.NoError(t, transfer(decimal.NewFromInt(5)))
+	require.ErrorIs(t, transfer(decimal.NewFromInt(1)), ErrDailyCountLimitExceeded)
+
+	usage, err := GetTransferUsage(context.Background(), store, account1.Owner, "USD", time.Now())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), usage.DailyCount)
+	require.True(t, decimal.NewFromInt(5).Equal(usage.DailyAmount))
+
+	// Removing the override puts the user back on their tier limits
+	deleted, err := store.DeleteUserTransferLimit(context.Background(), DeleteUserTransferLimitParams{
+		Username: account1.Owner,
+		Currency: "USD",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+	require.NoError(t, transfer(decimal.NewFromInt(1)))
+}
+
+func entryWithAccount(t *testing.T, entries []Entry, accountID int64) Entry {
+	for _, entry := range entries {
+		if entry.AccountID == accountID {
+			return entry
+		}
+	}
+	t.Fatalf("no entry for account %d", accountID)
+	return Entry{}
+}
@@ -3,6 +3,7 @@ package accounts
 import (
 	"lemfi/simplebank/config"
 	"lemfi/simplebank/internal/apps/core"
+	"lemfi/simplebank/internal/middleware"
 	"net/http"
 
 	errorResponse "lemfi/simplebank/pkg/errorResponse"
@@ -27,6 +28,8 @@ func (accountController *AccountController) CreateAccountController(c *gin.Conte
 		return
 	}
 
+	req.Owner = middleware.ContextGetUser(c).Username
+
 	config.Logger.Info("Account request validated successfully", "owner", req.Owner, "currency", req.Currency)
 
 	account, err := accountController.accountService.CreateAccount(req)
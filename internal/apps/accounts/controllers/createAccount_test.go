@@ -26,7 +26,6 @@ func TestCreateAccountHTTP_Success(t *testing.T) {
 
 	// Create test data
 	createRequest := map[string]interface{}{
-		"owner":    "test_owner",
 		"currency": "USD",
 	}
 
@@ -38,13 +37,17 @@ func TestCreateAccountHTTP_Success(t *testing.T) {
 	}
 
 	// Expect account creation
-	store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Return(expectedAccount, nil).Times(1)
+	store.EXPECT().CreateAccount(gomock.Any(), db.CreateAccountParams{
+		Owner:    "test_owner",
+		Currency: "USD",
+	}).Return(expectedAccount, nil).Times(1)
 
 	mockRepo := testhelpers.NewMockAccountRepository(store)
 	accountService := services.NewAccountService(mockRepo)
 	accountController := NewAccountController(accountService)
 
 	router := gin.New()
+	router.Use(testhelpers.AuthenticateAs("test_owner"))
 	router.POST("/accounts", accountController.CreateAccountController)
 
 	// Create request body
@@ -72,12 +75,12 @@ func TestCreateAccountHTTP_InvalidRequest(t *testing.T) {
 	accountController := NewAccountController(accountService)
 
 	router := gin.New()
+	router.Use(testhelpers.AuthenticateAs("test_owner"))
 	router.POST("/accounts", accountController.CreateAccountController)
 
 	// Create invalid request (missing required fields)
 	invalidRequest := map[string]interface{}{
-		"currency": "USD",
-		// missing "owner" field
+		// missing "currency" field
 	}
 
 	requestBody, err := json.Marshal(invalidRequest)
@@ -100,7 +103,6 @@ func TestCreateAccountHTTP_DatabaseError(t *testing.T) {
 	store := mockdb.NewMockStore(ctrl)
 
 	createRequest := map[string]interface{}{
-		"owner":    "test_owner",
 		"currency": "USD",
 	}
 
@@ -112,6 +114,7 @@ func TestCreateAccountHTTP_DatabaseError(t *testing.T) {
 	accountController := NewAccountController(accountService)
 
 	router := gin.New()
+	router.Use(testhelpers.AuthenticateAs("test_owner"))
 	router.POST("/accounts", accountController.CreateAccountController)
 
 	requestBody, err := json.Marshal(createRequest)
@@ -126,3 +129,80 @@ func TestCreateAccountHTTP_DatabaseError(t *testing.T) {
 
 	require.Equal(t, http.StatusInternalServerError, recorder.Code)
 }
+
+func TestCreateAccountHTTP_Ownership(t *testing.T) {
+	testCases := []struct {
+		name          string
+		username      string
+		body          map[string]interface{}
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OwnerFromToken",
+			username: "token_owner",
+			body:     map[string]interface{}{"currency": "EUR"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccount(gomock.Any(), db.CreateAccountParams{
+					Owner:    "token_owner",
+					Currency: "EUR",
+				}).Return(db.Account{ID: 1, Owner: "token_owner", Currency: "EUR"}, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "token_owner")
+			},
+		},
+		{
+			name:     "OwnerInBodyRejected",
+			username: "token_owner",
+			body:     map[string]interface{}{"owner": "someone_else", "currency": "EUR"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "NoAuthenticatedUser",
+			username: "",
+			body:     map[string]interface{}{"currency": "EUR"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			accountController := NewAccountController(services.NewAccountService(testhelpers.NewMockAccountRepository(store)))
+
+			router := gin.New()
+			if tc.username != "" {
+				router.Use(testhelpers.AuthenticateAs(tc.username))
+			}
+			router.POST("/accounts", accountController.CreateAccountController)
+
+			requestBody, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, "/accounts", bytes.NewBuffer(requestBody))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
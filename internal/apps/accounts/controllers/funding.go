@@ -0,0 +1,89 @@
+package accounts
+
+import (
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/internal/apps/core"
+	"lemfi/simplebank/internal/middleware"
+	"net/http"
+
+	errorResponse "lemfi/simplebank/pkg/errorResponse"
+	"lemfi/simplebank/pkg/requestHandler"
+	"lemfi/simplebank/pkg/responseHandler"
+
+	requests "lemfi/simplebank/internal/apps/accounts/requests"
+	responses "lemfi/simplebank/internal/apps/accounts/responses"
+	accountValidation "lemfi/simplebank/internal/apps/accounts/validationMessages"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (accountController *AccountController) DepositController(c *gin.Context) {
+	config.Logger.Info("Making deposit", "method", "POST", "endpoint", "/accounts/:id/deposits")
+
+	accountController.fundingController(c, "deposit", accountController.accountService.Deposit)
+}
+
+func (accountController *AccountController) WithdrawController(c *gin.Context) {
+	config.Logger.Info("Making withdrawal", "method", "POST", "endpoint", "/accounts/:id/withdrawals")
+
+	accountController.fundingController(c, "withdrawal", accountController.accountService.Withdraw)
+}
+
+// fundingController reads a deposit or withdrawal request and writes the funding transaction under the given envelope key
+func (accountController *AccountController) fundingController(
+	c *gin.Context,
+	envelopeKey string,
+	fund func(payload requests.FundingRequest) (responses.FundingTransactionResponse, error),
+) {
+	accountID, err := requestHandler.ReadIDParamGin(c, "id")
+	if err != nil {
+		config.Logger.Error("Invalid account id", "id", c.Param("id"))
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	var req requests.FundingRequest
+
+	err = requestHandler.ReadJSONGin(c, &req, accountValidation.FundingValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read funding request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	req.AccountID = accountID
+	req.Owner = middleware.ContextGetUser(c).Username
+
+	config.Logger.Info("Funding request validated successfully", "type", envelopeKey, "accountID", accountID, "amount", req.Amount, "currency", req.Currency)
+
+	fundingTransaction, err := fund(req)
+	if err != nil {
+		config.Logger.Error("Failed to record funding transaction", "error", err.Error(), "type", envelopeKey, "accountID", accountID)
+		if clientErr, isClient := core.IsClientError(err); isClient && clientErr.Status == http.StatusForbidden {
+			errorResponse.ForbiddenResponse(c, clientErr)
+		} else if isClient {
+			errorResponse.BadRequestResponse(c, clientErr)
+		} else {
+			errorResponse.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	response := responseHandler.Envelope{
+		envelopeKey: fundingTransaction,
+	}
+
+	var headers http.Header
+	if fundingTransaction.Replayed {
+		headers = http.Header{"Idempotent-Replayed": []string{"true"}}
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusCreated, response, headers)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Funding transaction response written successfully", "type", envelopeKey, "fundingTransactionID", fundingTransaction.ID, "accountID", accountID)
+}
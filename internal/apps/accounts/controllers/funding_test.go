@@ -0,0 +1,282 @@
+package accounts
+
+import (
+	"bytes"
+	"encoding/json"
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	services "lemfi/simplebank/internal/apps/accounts/services"
+	testhelpers "lemfi/simplebank/internal/apps/accounts/testHelpers"
+	"lemfi/simplebank/internal/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFundingHTTP(t *testing.T) {
+	ownAccount := db.Account{ID: 1, Owner: "test_owner", Balance: decimal.NewFromInt(100), Currency: "USD"}
+	referenceLookup := db.GetFundingTransactionByReferenceParams{FundingSource: "card", ExternalReference: "psp-123"}
+	fundingParams := db.FundingTxParams{
+		AccountID:         1,
+		Amount:            decimal.RequireFromString("25.50"),
+		Currency:          "USD",
+		ExternalReference: "psp-123",
+		FundingSource:     "card",
+	}
+
+	testCases := []struct {
+		name          string
+		url           string
+		role          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Deposit",
+			url:  "/accounts/1/deposits",
+			role: "admin",
+			body: gin.H{"amount": "25.50", "currency": "USD", "external_reference": "psp-123", "funding_source": "card"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetFundingTransactionByReference(gomock.Any(), referenceLookup).Return(db.FundingTransaction{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(ownAccount, nil).Times(1)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ any, arg db.FundingTxParams) (db.FundingTxResult, error) {
+						require.True(t, fundingParams.Amount.Equal(arg.Amount))
+						arg.Amount = fundingParams.Amount
+						require.Equal(t, fundingParams, arg)
+						return db.FundingTxResult{FundingTransaction: newFundingTransaction(db.FundingKindDeposit)}, nil
+					}).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Empty(t, recorder.Header().Get("Idempotent-Replayed"))
+
+				var body struct {
+					Deposit struct {
+						ID           int64           `json:"id"`
+						Type         string          `json:"type"`
+						BalanceAfter decimal.Decimal `json:"balance_after"`
+					} `json:"deposit"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				require.Equal(t, int64(3), body.Deposit.ID)
+				require.Equal(t, "deposit", body.Deposit.Type)
+				require.True(t, decimal.RequireFromString("125.50").Equal(body.Deposit.BalanceAfter))
+			},
+		},
+		{
+			name: "Withdrawal",
+			url:  "/accounts/1/withdrawals",
+			body: gin.H{"amount": "25.50", "currency": "USD", "external_reference": "psp-123", "funding_source": "card"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetFundingTransactionByReference(gomock.Any(), referenceLookup).Return(db.FundingTransaction{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(ownAccount, nil).Times(1)
+				store.EXPECT().WithdrawTx(gomock.Any(), gomock.Any()).
+					Return(db.FundingTxResult{FundingTransaction: newFundingTransaction(db.FundingKindWithdrawal)}, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Contains(t, recorder.Body.String(), `"withdrawal"`)
+			},
+		},
+		{
+			name: "InsufficientBalance",
+			url:  "/accounts/1/withdrawals",
+			body: gin.H{"amount": "25.50", "currency": "USD", "external_reference": "psp-123", "funding_source": "card"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetFundingTransactionByReference(gomock.Any(), gomock.Any()).Return(db.FundingTransaction{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(ownAccount, nil).Times(1)
+				store.EXPECT().WithdrawTx(gomock.Any(), gomock.Any()).Return(db.FundingTxResult{}, db.ErrInsufficientFunds).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "insufficient balance")
+			},
+		},
+		{
+			name: "ReplayedReference",
+			url:  "/accounts/1/deposits",
+			role: "admin",
+			body: gin.H{"amount": "25.50", "currency": "USD", "external_reference": "psp-123", "funding_source": "card"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetFundingTransactionByReference(gomock.Any(), referenceLookup).
+					Return(newFundingTransaction(db.FundingKindDeposit), nil).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(ownAccount, nil).Times(1)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Equal(t, "true", recorder.Header().Get("Idempotent-Replayed"))
+			},
+		},
+		{
+			name: "ReferenceUsedForDifferentRequest",
+			url:  "/accounts/1/withdrawals",
+			body: gin.H{"amount": "25.50", "currency": "USD", "external_reference": "psp-123", "funding_source": "card"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetFundingTransactionByReference(gomock.Any(), referenceLookup).
+					Return(newFundingTransaction(db.FundingKindDeposit), nil).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(ownAccount, nil).Times(1)
+				store.EXPECT().WithdrawTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "external_reference has already been used")
+			},
+		},
+		{
+			name: "ConcurrentReference",
+			url:  "/accounts/1/deposits",
+			role: "admin",
+			body: gin.H{"amount": "25.50", "currency": "USD", "external_reference": "psp-123", "funding_source": "card"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(ownAccount, nil).Times(2)
+				gomock.InOrder(
+					store.EXPECT().GetFundingTransactionByReference(gomock.Any(), referenceLookup).Return(db.FundingTransaction{}, pgx.ErrNoRows),
+					store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Return(db.FundingTxResult{}, db.ErrExternalReferenceConflict),
+					store.EXPECT().GetFundingTransactionByReference(gomock.Any(), referenceLookup).Return(newFundingTransaction(db.FundingKindDeposit), nil),
+				)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+				require.Equal(t, "true", recorder.Header().Get("Idempotent-Replayed"))
+			},
+		},
+		{
+			name: "OwnerCannotDeposit",
+			url:  "/accounts/1/deposits",
+			role: "user",
+			body: gin.H{"amount": "25.50", "currency": "USD", "external_reference": "psp-123", "funding_source": "card"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetFundingTransactionByReference(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "AdminDepositsToAnyAccount",
+			url:  "/accounts/1/deposits",
+			role: "admin",
+			body: gin.H{"amount": "25.50", "currency": "USD", "external_reference": "psp-123", "funding_source": "card"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetFundingTransactionByReference(gomock.Any(), gomock.Any()).Return(db.FundingTransaction{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).
+					Return(db.Account{ID: 1, Owner: "other_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).
+					Return(db.FundingTxResult{FundingTransaction: newFundingTransaction(db.FundingKindDeposit)}, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+			},
+		},
+		{
+			name: "OtherUsersAccount",
+			url:  "/accounts/1/withdrawals",
+			body: gin.H{"amount": "25.50", "currency": "USD", "external_reference": "psp-123", "funding_source": "card"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetFundingTransactionByReference(gomock.Any(), gomock.Any()).Return(db.FundingTransaction{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).
+					Return(db.Account{ID: 1, Owner: "other_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().WithdrawTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			url:  "/accounts/1/deposits",
+			role: "admin",
+			body: gin.H{"amount": "25.50", "currency": "EUR", "external_reference": "psp-123", "funding_source": "card"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetFundingTransactionByReference(gomock.Any(), gomock.Any()).Return(db.FundingTransaction{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(ownAccount, nil).Times(1)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "currency does not match")
+			},
+		},
+		{
+			name: "TooManyDecimalPlaces",
+			url:  "/accounts/1/deposits",
+			role: "admin",
+			body: gin.H{"amount": "25.505", "currency": "USD", "external_reference": "psp-123", "funding_source": "card"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetFundingTransactionByReference(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NegativeAmount",
+			url:  "/accounts/1/withdrawals",
+			body: gin.H{"amount": "-5", "currency": "USD", "external_reference": "psp-123", "funding_source": "card"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().WithdrawTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "greater than zero")
+			},
+		},
+		{
+			name: "UnknownFundingSource",
+			url:  "/accounts/1/deposits",
+			role: "admin",
+			body: gin.H{"amount": "25.50", "currency": "USD", "external_reference": "psp-123", "funding_source": "cash"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DepositTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			accountController := NewAccountController(services.NewAccountService(testhelpers.NewMockAccountRepository(store)))
+
+			role := tc.role
+			if role == "" {
+				role = "user"
+			}
+
+			// Deposits sit behind the same role check as in Routes
+			router := gin.New()
+			router.Use(testhelpers.AuthenticateAsRole("test_owner", role))
+			router.POST("/accounts/:id/deposits", middleware.RequireAuthenticatedUserWithRole("admin"), accountController.DepositController)
+			router.POST("/accounts/:id/withdrawals", accountController.WithdrawController)
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, tc.url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
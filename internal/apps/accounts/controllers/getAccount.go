@@ -2,6 +2,8 @@ package accounts
 
 import (
 	"lemfi/simplebank/config"
+	"lemfi/simplebank/internal/apps/core"
+	"lemfi/simplebank/internal/middleware"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -11,12 +13,18 @@ import (
 )
 
 func (accountController *AccountController) GetAccountsController(c *gin.Context) {
-	config.Logger.Info("Fetching all accounts", "method", "GET", "endpoint", "/accounts")
+	config.Logger.Info("Fetching accounts", "method", "GET", "endpoint", "/accounts")
 
-	accounts, err := accountController.accountService.GetAccounts()
+	owner := middleware.ContextGetUser(c).Username
+
+	accounts, err := accountController.accountService.GetAccounts(owner)
 	if err != nil {
-		config.Logger.Error("Failed to fetch accounts", "error", err.Error())
-		errorResponse.ServerErrorResponse(c, err)
+		config.Logger.Error("Failed to fetch accounts", "error", err.Error(), "owner", owner)
+		if clientErr, isClient := core.IsClientError(err); isClient {
+			errorResponse.BadRequestResponse(c, clientErr)
+		} else {
+			errorResponse.ServerErrorResponse(c, err)
+		}
 		return
 	}
 
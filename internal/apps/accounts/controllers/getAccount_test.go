@@ -32,13 +32,13 @@ func TestGetAccountHTTP(t *testing.T) {
 		},
 		{
 			ID:       2,
-			Owner:    "test_owner_2",
+			Owner:    "test_owner_1",
 			Balance:  decimal.NewFromInt(2000),
 			Currency: "EUR",
 		},
 	}
 
-	store.EXPECT().ListAllAccounts(gomock.Any(), gomock.Any()).Return(testAccounts, nil).Times(1)
+	store.EXPECT().ListAccounts(gomock.Any(), db.ListAccountsParams{Owner: "test_owner_1", Limit: 10}).Return(testAccounts, nil).Times(1)
 
 	// Create mock repository that wraps the mock store
 	mockRepo := testhelpers.NewMockAccountRepository(store)
@@ -48,6 +48,7 @@ func TestGetAccountHTTP(t *testing.T) {
 
 	// Create a new Gin router for testing
 	router := gin.New()
+	router.Use(testhelpers.AuthenticateAs("test_owner_1"))
 
 	// Set up the route
 	router.GET("/accounts", accountController.GetAccountsController)
@@ -74,13 +75,14 @@ func TestGetAccountHTTP_EmptyList(t *testing.T) {
 	store := mockdb.NewMockStore(ctrl)
 
 	// Expect empty list
-	store.EXPECT().ListAllAccounts(gomock.Any(), gomock.Any()).Return([]db.Account{}, nil).Times(1)
+	store.EXPECT().ListAccounts(gomock.Any(), db.ListAccountsParams{Owner: "test_owner_1", Limit: 10}).Return([]db.Account{}, nil).Times(1)
 
 	mockRepo := testhelpers.NewMockAccountRepository(store)
 	accountService := services.NewAccountService(mockRepo)
 	accountController := NewAccountController(accountService)
 
 	router := gin.New()
+	router.Use(testhelpers.AuthenticateAs("test_owner_1"))
 	router.GET("/accounts", accountController.GetAccountsController)
 
 	recorder := httptest.NewRecorder()
@@ -99,13 +101,14 @@ func TestGetAccountHTTP_DatabaseError(t *testing.T) {
 	store := mockdb.NewMockStore(ctrl)
 
 	// Expect database error
-	store.EXPECT().ListAllAccounts(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("database connection failed")).Times(1)
+	store.EXPECT().ListAccounts(gomock.Any(), db.ListAccountsParams{Owner: "test_owner_1", Limit: 10}).Return(nil, fmt.Errorf("database connection failed")).Times(1)
 
 	mockRepo := testhelpers.NewMockAccountRepository(store)
 	accountService := services.NewAccountService(mockRepo)
 	accountController := NewAccountController(accountService)
 
 	router := gin.New()
+	router.Use(testhelpers.AuthenticateAs("test_owner_1"))
 	router.GET("/accounts", accountController.GetAccountsController)
 
 	recorder := httptest.NewRecorder()
@@ -127,6 +130,7 @@ func TestGetAccountHTTP_WrongMethod(t *testing.T) {
 	accountController := NewAccountController(accountService)
 
 	router := gin.New()
+	router.Use(testhelpers.AuthenticateAs("test_owner_1"))
 	router.GET("/accounts", accountController.GetAccountsController)
 
 	recorder := httptest.NewRecorder()
@@ -153,19 +157,20 @@ func TestGetAccountHTTP_ResponseBody(t *testing.T) {
 		},
 		{
 			ID:       2,
-			Owner:    "test_owner_2",
+			Owner:    "test_owner_1",
 			Balance:  decimal.NewFromInt(2000),
 			Currency: "EUR",
 		},
 	}
 
-	store.EXPECT().ListAllAccounts(gomock.Any(), gomock.Any()).Return(testAccounts, nil).Times(1)
+	store.EXPECT().ListAccounts(gomock.Any(), db.ListAccountsParams{Owner: "test_owner_1", Limit: 10}).Return(testAccounts, nil).Times(1)
 
 	mockRepo := testhelpers.NewMockAccountRepository(store)
 	accountService := services.NewAccountService(mockRepo)
 	accountController := NewAccountController(accountService)
 
 	router := gin.New()
+	router.Use(testhelpers.AuthenticateAs("test_owner_1"))
 	router.GET("/accounts", accountController.GetAccountsController)
 
 	recorder := httptest.NewRecorder()
@@ -180,7 +185,78 @@ func TestGetAccountHTTP_ResponseBody(t *testing.T) {
 	// Verify response body contains expected data
 	responseBody := recorder.Body.String()
 	require.Contains(t, responseBody, "test_owner_1")
-	require.Contains(t, responseBody, "test_owner_2")
 	require.Contains(t, responseBody, "USD")
 	require.Contains(t, responseBody, "EUR")
 }
+
+func TestGetAccountHTTP_OnlyOwnAccounts(t *testing.T) {
+	testCases := []struct {
+		name          string
+		username      string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "ListsOwnAccounts",
+			username: "alice",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAllAccounts(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListAccounts(gomock.Any(), db.ListAccountsParams{Owner: "alice", Limit: 10}).
+					Return([]db.Account{{ID: 1, Owner: "alice", Balance: decimal.NewFromInt(10), Currency: "USD"}}, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "alice")
+			},
+		},
+		{
+			name:     "OtherUserSeesOnlyTheirs",
+			username: "bob",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAllAccounts(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListAccounts(gomock.Any(), db.ListAccountsParams{Owner: "bob", Limit: 10}).
+					Return([]db.Account{}, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.NotContains(t, recorder.Body.String(), "alice")
+			},
+		},
+		{
+			name:     "NoAuthenticatedUser",
+			username: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAllAccounts(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			accountController := NewAccountController(services.NewAccountService(testhelpers.NewMockAccountRepository(store)))
+
+			router := gin.New()
+			if tc.username != "" {
+				router.Use(testhelpers.AuthenticateAs(tc.username))
+			}
+			router.GET("/accounts", accountController.GetAccountsController)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/accounts", nil)
+			require.NoError(t, err)
+
+			router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
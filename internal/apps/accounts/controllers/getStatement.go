@@ -0,0 +1,87 @@
+package accounts
+
+import (
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/internal/apps/core"
+	"lemfi/simplebank/internal/middleware"
+	"net/http"
+	"time"
+
+	errorResponse "lemfi/simplebank/pkg/errorResponse"
+	"lemfi/simplebank/pkg/requestHandler"
+	"lemfi/simplebank/pkg/responseHandler"
+
+	requests "lemfi/simplebank/internal/apps/accounts/requests"
+	responses "lemfi/simplebank/internal/apps/accounts/responses"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (accountController *AccountController) GetStatementController(c *gin.Context) {
+	config.Logger.Info("Fetching account statement", "method", "GET", "endpoint", "/accounts/:id/statement")
+
+	// Statements can be downloaded as CSV or PDF using the Accept header
+	offers := []string{responseHandler.ContentTypeJSON, responseHandler.ContentTypeCSV, responseHandler.ContentTypePDF}
+	contentType := responseHandler.NegotiateContentType(c.Request, offers...)
+	if contentType == "" {
+		config.Logger.Error("Unsupported statement format requested", "accept", c.GetHeader("Accept"))
+		errorResponse.NotAcceptableResponse(c, offers)
+		return
+	}
+
+	accountID, err := requestHandler.ReadIDParamGin(c, "id")
+	if err != nil {
+		config.Logger.Error("Invalid account id", "id", c.Param("id"))
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	var req requests.GetStatementRequest
+
+	err = requestHandler.ReadQueryGin(c, &req, nil)
+	if err != nil {
+		config.Logger.Error("Failed to read statement request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	req.AccountID = accountID
+	req.Owner = middleware.ContextGetUser(c).Username
+
+	statement, err := accountController.accountService.GetStatement(req)
+	if err != nil {
+		config.Logger.Error("Failed to fetch account statement", "error", err.Error(), "accountID", accountID)
+		if clientErr, isClient := core.IsClientError(err); isClient && clientErr.Status == http.StatusForbidden {
+			errorResponse.ForbiddenResponse(c, clientErr)
+		} else if isClient {
+			errorResponse.BadRequestResponse(c, clientErr)
+		} else {
+			errorResponse.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	config.Logger.Info("Account statement fetched successfully", "accountID", accountID, "entries", len(statement.Entries))
+
+	switch contentType {
+	case responseHandler.ContentTypeCSV:
+		err = responseHandler.WriteCSV(c.Writer, http.StatusOK, responses.StatementFilename(statement, "csv"), responses.StatementCSVRecords(statement), nil)
+	case responseHandler.ContentTypePDF:
+		err = responseHandler.WritePDF(c.Writer, http.StatusOK, responses.StatementFilename(statement, "pdf"), responses.StatementPDF(statement, time.Now()), nil)
+	default:
+		response := responseHandler.Envelope{
+			"statement": statement,
+		}
+		err = responseHandler.WriteJSON(c.Writer, http.StatusOK, response, nil)
+	}
+	if err != nil {
+		config.Logger.Error("Failed to write statement response", "error", err.Error(), "contentType", contentType)
+		// The status line is already sent when the body fails to write, usually because the client went away
+		if !c.Writer.Written() {
+			errorResponse.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	config.Logger.Info("Account statement request completed successfully", "accountID", accountID, "contentType", contentType)
+}
@@ -0,0 +1,220 @@
+package accounts
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	services "lemfi/simplebank/internal/apps/accounts/services"
+	testhelpers "lemfi/simplebank/internal/apps/accounts/testHelpers"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetStatementHTTP(t *testing.T) {
+	testCases := []struct {
+		name          string
+		url           string
+		accept        string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			url:  "/accounts/1/statement?from=2025-08-01T00:00:00Z&to=2025-09-01T00:00:00Z",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).
+					Return(db.Account{ID: 1, Owner: "test_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().AccountStatementTx(gomock.Any(), db.AccountStatementTxParams{
+					AccountID: 1,
+					From:      time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+					To:        time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+				}).Return(newAccountStatement(), nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var body struct {
+					Statement struct {
+						OpeningBalance decimal.Decimal `json:"opening_balance"`
+						ClosingBalance decimal.Decimal `json:"closing_balance"`
+						Entries        []struct {
+							RunningBalance decimal.Decimal `json:"running_balance"`
+							Transfer       struct {
+								ID        int64  `json:"id"`
+								Direction string `json:"direction"`
+							} `json:"transfer"`
+							Counterparty struct {
+								Owner string `json:"owner"`
+							} `json:"counterparty"`
+						} `json:"entries"`
+					} `json:"statement"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+
+				require.True(t, body.Statement.OpeningBalance.Equal(decimal.NewFromInt(100)))
+				require.True(t, body.Statement.ClosingBalance.Equal(decimal.NewFromInt(120)))
+				require.Len(t, body.Statement.Entries, 2)
+				require.True(t, body.Statement.Entries[0].RunningBalance.Equal(decimal.NewFromInt(70)))
+				require.Equal(t, "sent", body.Statement.Entries[0].Transfer.Direction)
+				require.Equal(t, "received", body.Statement.Entries[1].Transfer.Direction)
+				require.Equal(t, "other_owner", body.Statement.Entries[1].Counterparty.Owner)
+			},
+		},
+		{
+			name:   "CSV",
+			url:    "/accounts/1/statement?from=2025-08-01T00:00:00Z&to=2025-09-01T00:00:00Z",
+			accept: "text/csv",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).
+					Return(db.Account{ID: 1, Owner: "test_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().AccountStatementTx(gomock.Any(), gomock.Any()).Return(newAccountStatement(), nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+				require.Equal(t, `attachment; filename="statement-1-2025-08-01-2025-09-01.csv"`, recorder.Header().Get("Content-Disposition"))
+
+				require.Contains(t, recorder.Body.String(), "\r\n")
+
+				records, err := csv.NewReader(recorder.Body).ReadAll()
+				require.NoError(t, err)
+				require.Len(t, records, 5)
+				require.Equal(t, []string{"date", "description", "transfer_id", "counterparty_account_id", "counterparty_owner", "amount", "balance", "currency"}, records[0])
+				require.Equal(t, []string{"2025-08-01T00:00:00Z", "Opening balance", "", "", "", "", "100.00", "USD"}, records[1])
+				require.Equal(t, []string{"2025-08-02T10:00:00Z", "Transfer 5 to #2 other_owner", "5", "2", "other_owner", "-30.00", "70.00", "USD"}, records[2])
+				require.Equal(t, []string{"2025-09-01T00:00:00Z", "Closing balance", "", "", "", "", "120.00", "USD"}, records[4])
+			},
+		},
+		{
+			name:   "PDF",
+			url:    "/accounts/1/statement",
+			accept: "application/pdf",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).
+					Return(db.Account{ID: 1, Owner: "test_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().AccountStatementTx(gomock.Any(), gomock.Any()).Return(newAccountStatement(), nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+				require.True(t, strings.HasPrefix(recorder.Body.String(), "%PDF-"))
+				require.Contains(t, recorder.Body.String(), "Opening balance: 100.00")
+				require.Contains(t, recorder.Body.String(), "Closing balance: 120.00")
+			},
+		},
+		{
+			name:   "NotAcceptable",
+			url:    "/accounts/1/statement",
+			accept: "image/png",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotAcceptable, recorder.Code)
+			},
+		},
+		{
+			name: "NotOwner",
+			url:  "/accounts/1/statement",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).
+					Return(db.Account{ID: 1, Owner: "other_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().AccountStatementTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "AccountNotFound",
+			url:  "/accounts/1/statement",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(db.Account{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().AccountStatementTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidAccountID",
+			url:  "/accounts/abc/statement",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidDate",
+			url:  "/accounts/1/statement?from=yesterday",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "FromAfterTo",
+			url:  "/accounts/1/statement?from=2025-09-01T00:00:00Z&to=2025-08-01T00:00:00Z",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DatabaseError",
+			url:  "/accounts/1/statement",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).
+					Return(db.Account{ID: 1, Owner: "test_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().AccountStatementTx(gomock.Any(), gomock.Any()).
+					Return(db.AccountStatementTxResult{}, fmt.Errorf("database error")).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			accountController := NewAccountController(services.NewAccountService(testhelpers.NewMockAccountRepository(store)))
+
+			router := gin.New()
+			router.Use(testhelpers.AuthenticateAs("test_owner"))
+			router.GET("/accounts/:id/statement", accountController.GetStatementController)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+			if tc.accept != "" {
+				request.Header.Set("Accept", tc.accept)
+			}
+
+			router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package accounts
+
+import (
+	"time"
+
+	db "lemfi/simplebank/db/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// newAccountStatement is the statement of account 1 with a transfer out and a transfer in
+func newAccountStatement() db.AccountStatementTxResult {
+	createdAt := time.Date(2025, 8, 2, 10, 0, 0, 0, time.UTC)
+	return db.AccountStatementTxResult{
+		Account:        db.Account{ID: 1, Owner: "test_owner", Balance: decimal.NewFromInt(120), Currency: "USD"},
+		OpeningBalance: decimal.NewFromInt(100),
+		Entries: []db.ListAccountStatementEntriesRow{
+			{
+				ID:                    11,
+				AccountID:             1,
+				Amount:                decimal.NewFromInt(-30),
+				TransferID:            pgtype.Int8{Int64: 5, Valid: true},
+				CreatedAt:             createdAt,
+				FromAccountID:         pgtype.Int8{Int64: 1, Valid: true},
+				ToAccountID:           pgtype.Int8{Int64: 2, Valid: true},
+				FromCurrency:          pgtype.Text{String: "USD", Valid: true},
+				ToCurrency:            pgtype.Text{String: "EUR", Valid: true},
+				CounterpartyAccountID: pgtype.Int8{Int64: 2, Valid: true},
+				CounterpartyOwner:     pgtype.Text{String: "other_owner", Valid: true},
+				CounterpartyCurrency:  pgtype.Text{String: "EUR", Valid: true},
+			},
+			{
+				ID:                    12,
+				AccountID:             1,
+				Amount:                decimal.NewFromInt(50),
+				TransferID:            pgtype.Int8{Int64: 6, Valid: true},
+				CreatedAt:             createdAt.Add(time.Hour),
+				FromAccountID:         pgtype.Int8{Int64: 2, Valid: true},
+				ToAccountID:           pgtype.Int8{Int64: 1, Valid: true},
+				CounterpartyAccountID: pgtype.Int8{Int64: 2, Valid: true},
+				CounterpartyOwner:     pgtype.Text{String: "other_owner", Valid: true},
+				CounterpartyCurrency:  pgtype.Text{String: "EUR", Valid: true},
+			},
+		},
+	}
+}
+
+// newFundingTransaction is a funding transaction of 25.50 USD of kind on account 1
+func newFundingTransaction(kind string) db.FundingTransaction {
+	return db.FundingTransaction{
+		ID:                3,
+		AccountID:         1,
+		Kind:              kind,
+		Amount:            decimal.RequireFromString("25.50"),
+		Currency:          "USD",
+		BalanceAfter:      decimal.RequireFromString("125.50"),
+		ExternalReference: "psp-123",
+		FundingSource:     "card",
+		EntryID:           40,
+		HouseEntryID:      41,
+		CreatedAt:         time.Date(2025, 8, 2, 10, 0, 0, 0, time.UTC),
+	}
+}
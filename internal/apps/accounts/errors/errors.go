@@ -8,8 +8,46 @@ var (
 		Message: "account already exists for this owner and currency",
 		Status:  400,
 	}
+	ErrOwnerRequired = core.ClientError{
+		Message: "account owner is required",
+		Status:  400,
+	}
 	ErrAccountNotFound = core.ClientError{
 		Message: "account not found",
 		Status:  404,
 	}
+	ErrAccountForbidden = core.ClientError{
+		Message: "account does not belong to the authenticated user",
+		Status:  403,
+	}
+	ErrInvalidStatementRange = core.ClientError{
+		Message: "from must be before to",
+		Status:  400,
+	}
+	ErrStatementRangeTooLong = core.ClientError{
+		Message: "statement period must not be longer than 366 days",
+		Status:  400,
+	}
+
+	// Deposits and withdrawals
+	ErrInvalidFundingAmount = core.ClientError{
+		Message: "amount must be greater than zero",
+		Status:  400,
+	}
+	ErrFundingAmountPrecision = core.ClientError{
+		Message: "amount has more decimal places than the currency allows",
+		Status:  400,
+	}
+	ErrAccountCurrencyMismatch = core.ClientError{
+		Message: "currency does not match the account currency",
+		Status:  400,
+	}
+	ErrInsufficientBalance = core.ClientError{
+		Message: "insufficient balance",
+		Status:  400,
+	}
+	ErrExternalReferenceMismatch = core.ClientError{
+		Message: "external_reference has already been used for a different deposit or withdrawal",
+		Status:  422,
+	}
 )
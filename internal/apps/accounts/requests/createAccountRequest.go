@@ -1,6 +1,6 @@
 package accounts
 
 type CreateAccountRequest struct {
-	Owner    string `json:"owner" validate:"required"`
 	Currency string `json:"currency" validate:"required"`
+	Owner    string `json:"-"` // Set from the authenticated user, not exposed in JSON
 }
@@ -0,0 +1,13 @@
+package accounts
+
+import "github.com/shopspring/decimal"
+
+// FundingRequest is the body of both deposit and withdrawal requests
+type FundingRequest struct {
+	Amount            decimal.Decimal `json:"amount" validate:"required"`
+	Currency          string          `json:"currency" validate:"required,len=3"`
+	ExternalReference string          `json:"external_reference" validate:"required,max=255"`
+	FundingSource     string          `json:"funding_source" validate:"required,oneof=bank_transfer card mobile_money"`
+	AccountID         int64           `json:"-"` // Set from the :id path parameter
+	Owner             string          `json:"-"` // Set from the authenticated user
+}
@@ -0,0 +1,10 @@
+package accounts
+
+import "time"
+
+type GetStatementRequest struct {
+	From      time.Time `form:"from"` // RFC 3339, inclusive. Defaults to 30 days before To
+	To        time.Time `form:"to"`   // RFC 3339, exclusive. Defaults to now
+	AccountID int64     `form:"-"`    // Set from the :id path parameter
+	Owner     string    `form:"-"`    // Set from the authenticated user
+}
@@ -0,0 +1,36 @@
+package accounts
+
+import (
+	"time"
+
+	db "lemfi/simplebank/db/sqlc"
+
+	"github.com/shopspring/decimal"
+)
+
+type FundingTransactionResponse struct {
+	ID                int64           `json:"id"`
+	AccountID         int64           `json:"account_id"`
+	Type              string          `json:"type"` // "deposit" or "withdrawal"
+	Amount            decimal.Decimal `json:"amount"`
+	Currency          string          `json:"currency"`
+	BalanceAfter      decimal.Decimal `json:"balance_after"`
+	ExternalReference string          `json:"external_reference"`
+	FundingSource     string          `json:"funding_source"`
+	CreatedAt         time.Time       `json:"created_at"`
+	Replayed          bool            `json:"-"` // True when the external reference was already applied
+}
+
+func NewFundingTransactionResponse(fundingTransaction db.FundingTransaction) FundingTransactionResponse {
+	return FundingTransactionResponse{
+		ID:                fundingTransaction.ID,
+		AccountID:         fundingTransaction.AccountID,
+		Type:              fundingTransaction.Kind,
+		Amount:            fundingTransaction.Amount,
+		Currency:          fundingTransaction.Currency,
+		BalanceAfter:      fundingTransaction.BalanceAfter,
+		ExternalReference: fundingTransaction.ExternalReference,
+		FundingSource:     fundingTransaction.FundingSource,
+		CreatedAt:         fundingTransaction.CreatedAt,
+	}
+}
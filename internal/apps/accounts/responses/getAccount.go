@@ -12,4 +12,6 @@ type GetAccountResponse struct {
 	Balance   decimal.Decimal `json:"balance"`
 	Currency  string          `json:"currency"`
 	CreatedAt time.Time       `json:"created_at"`
+	// Balance less the amount held by authorized transfers, what can be sent or withdrawn
+	AvailableBalance decimal.Decimal `json:"available_balance"`
 }
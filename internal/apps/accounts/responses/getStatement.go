@@ -0,0 +1,50 @@
+package accounts
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type StatementResponse struct {
+	AccountID      int64            `json:"account_id"`
+	Owner          string           `json:"owner"`
+	Currency       string           `json:"currency"`
+	From           time.Time        `json:"from"`
+	To             time.Time        `json:"to"`
+	OpeningBalance decimal.Decimal  `json:"opening_balance"`
+	ClosingBalance decimal.Decimal  `json:"closing_balance"`
+	Entries        []StatementEntry `json:"entries"`
+}
+
+type StatementEntry struct {
+	ID             int64                  `json:"id"`
+	Amount         decimal.Decimal        `json:"amount"`
+	RunningBalance decimal.Decimal        `json:"running_balance"`
+	CreatedAt      time.Time              `json:"created_at"`
+	Transfer       *StatementTransfer     `json:"transfer,omitempty"`
+	Counterparty   *StatementCounterparty `json:"counterparty,omitempty"`
+	Funding        *StatementFunding      `json:"funding,omitempty"`
+}
+
+type StatementTransfer struct {
+	ID            int64  `json:"id"`
+	FromAccountID int64  `json:"from_account_id"`
+	ToAccountID   int64  `json:"to_account_id"`
+	FromCurrency  string `json:"from_currency,omitempty"`
+	ToCurrency    string `json:"to_currency,omitempty"`
+	Direction     string `json:"direction"` // "sent" or "received"
+}
+
+type StatementCounterparty struct {
+	AccountID int64  `json:"account_id"`
+	Owner     string `json:"owner"`
+	Currency  string `json:"currency"`
+}
+
+type StatementFunding struct {
+	ID                int64  `json:"id"`
+	Type              string `json:"type"` // "deposit" or "withdrawal"
+	ExternalReference string `json:"external_reference"`
+	FundingSource     string `json:"funding_source"`
+}
@@ -0,0 +1,157 @@
+package accounts
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"lemfi/simplebank/internal/apps/currencies"
+	"lemfi/simplebank/pkg/pdf"
+
+	"github.com/shopspring/decimal"
+)
+
+const statementTimeLayout = "2006-01-02 15:04"
+
+var statementCSVHeader = []string{
+	"date",
+	"description",
+	"transfer_id",
+	"counterparty_account_id",
+	"counterparty_owner",
+	"amount",
+	"balance",
+	"currency",
+}
+
+// StatementFilename names a downloaded statement, e.g. statement-1-2025-08-01-2025-09-01.csv
+func StatementFilename(statement StatementResponse, extension string) string {
+	return fmt.Sprintf("statement-%d-%s-%s.%s",
+		statement.AccountID,
+		statement.From.UTC().Format("2006-01-02"),
+		statement.To.UTC().Format("2006-01-02"),
+		extension,
+	)
+}
+
+// StatementCSVRecords flattens a statement into CSV rows, framed by opening and closing balance rows
+func StatementCSVRecords(statement StatementResponse) [][]string {
+	currency := currencies.Currency(statement.Currency)
+	formatAmount := func(amount decimal.Decimal) string {
+		return currencies.FormatAmount(amount, currency)
+	}
+
+	records := [][]string{statementCSVHeader}
+	records = append(records, []string{
+		statement.From.UTC().Format(time.RFC3339), "Opening balance", "", "", "", "", formatAmount(statement.OpeningBalance), statement.Currency,
+	})
+
+	for _, entry := range statement.Entries {
+		transferID := ""
+		if entry.Transfer != nil {
+			transferID = strconv.FormatInt(entry.Transfer.ID, 10)
+		}
+
+		counterpartyAccountID, counterpartyOwner := "", ""
+		if entry.Counterparty != nil {
+			counterpartyAccountID = strconv.FormatInt(entry.Counterparty.AccountID, 10)
+			counterpartyOwner = csvSafe(entry.Counterparty.Owner)
+		}
+
+		records = append(records, []string{
+			entry.CreatedAt.UTC().Format(time.RFC3339),
+			statementEntryDescription(entry),
+			transferID,
+			counterpartyAccountID,
+			counterpartyOwner,
+			formatAmount(entry.Amount),
+			formatAmount(entry.RunningBalance),
+			statement.Currency,
+		})
+	}
+
+	records = append(records, []string{
+		statement.To.UTC().Format(time.RFC3339), "Closing balance", "", "", "", "", formatAmount(statement.ClosingBalance), statement.Currency,
+	})
+
+	return records
+}
+
+// StatementPDF renders a statement as a printable PDF
+func StatementPDF(statement StatementResponse, generatedAt time.Time) []byte {
+	currency := currencies.Currency(statement.Currency)
+	formatAmount := func(amount decimal.Decimal) string {
+		return currencies.FormatAmount(amount, currency)
+	}
+
+	doc := pdf.NewDocument()
+	doc.AddLines(
+		"Account statement",
+		"",
+		fmt.Sprintf("Account:  %d (%s)", statement.AccountID, statement.Owner),
+		fmt.Sprintf("Currency: %s", statement.Currency),
+		fmt.Sprintf("Period:   %s UTC to %s UTC", statement.From.UTC().Format(statementTimeLayout), statement.To.UTC().Format(statementTimeLayout)),
+		"",
+		fmt.Sprintf("Opening balance: %s", formatAmount(statement.OpeningBalance)),
+		"",
+		fmt.Sprintf("%-16s  %-36s  %16s  %16s", "Date (UTC)", "Description", "Amount", "Balance"),
+		strings.Repeat("-", 90),
+	)
+
+	for _, entry := range statement.Entries {
+		description := statementEntryDescription(entry)
+		if len(description) > 36 {
+			description = description[:36]
+		}
+
+		doc.AddLine(fmt.Sprintf("%-16s  %-36s  %16s  %16s",
+			entry.CreatedAt.UTC().Format(statementTimeLayout),
+			description,
+			formatAmount(entry.Amount),
+			formatAmount(entry.RunningBalance),
+		))
+	}
+
+	doc.AddLines(
+		strings.Repeat("-", 90),
+		fmt.Sprintf("Closing balance: %s", formatAmount(statement.ClosingBalance)),
+		"",
+		fmt.Sprintf("Generated %s UTC", generatedAt.UTC().Format(statementTimeLayout)),
+	)
+
+	return doc.Bytes()
+}
+
+// statementEntryDescription describes what an entry was for
+func statementEntryDescription(entry StatementEntry) string {
+	if entry.Funding != nil {
+		kind := "Deposit"
+		if entry.Funding.Type == "withdrawal" {
+			kind = "Withdrawal"
+		}
+		return fmt.Sprintf("%s via %s ref %s", kind, entry.Funding.FundingSource, entry.Funding.ExternalReference)
+	}
+
+	if entry.Transfer == nil {
+		return "Ledger entry"
+	}
+
+	counterparty := ""
+	if entry.Counterparty != nil {
+		counterparty = fmt.Sprintf(" #%d %s", entry.Counterparty.AccountID, entry.Counterparty.Owner)
+	}
+
+	if entry.Transfer.Direction == "sent" {
+		return fmt.Sprintf("Transfer %d to%s", entry.Transfer.ID, counterparty)
+	}
+	return fmt.Sprintf("Transfer %d from%s", entry.Transfer.ID, counterparty)
+}
+
+// csvSafe stops spreadsheet applications from evaluating free-text fields as formulas
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsAny(value[:1], "=+-@\t\r") {
+		return "'" + value
+	}
+	return value
+}
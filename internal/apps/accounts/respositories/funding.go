@@ -0,0 +1,77 @@
+package accounts
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	accountErrors "lemfi/simplebank/internal/apps/accounts/errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (accountRespository *AccountRespository) Deposit(params db.FundingTxParams) (db.FundingTxResult, error) {
+	config.Logger.Info("Recording deposit in database", "accountID", params.AccountID, "amount", params.Amount, "external_reference", params.ExternalReference)
+
+	result, err := accountRespository.queries.DepositTx(accountRespository.context, params)
+	if err != nil {
+		return db.FundingTxResult{}, fundingTxError(err, params)
+	}
+
+	config.Logger.Info("Successfully recorded deposit in database", "accountID", params.AccountID, "funding_transaction_id", result.FundingTransaction.ID)
+
+	return result, nil
+}
+
+func (accountRespository *AccountRespository) Withdraw(params db.FundingTxParams) (db.FundingTxResult, error) {
+	config.Logger.Info("Recording withdrawal in database", "accountID", params.AccountID, "amount", params.Amount, "external_reference", params.ExternalReference)
+
+	result, err := accountRespository.queries.WithdrawTx(accountRespository.context, params)
+	if err != nil {
+		return db.FundingTxResult{}, fundingTxError(err, params)
+	}
+
+	config.Logger.Info("Successfully recorded withdrawal in database", "accountID", params.AccountID, "funding_transaction_id", result.FundingTransaction.ID)
+
+	return result, nil
+}
+
+// GetFundingTransaction returns the deposit or withdrawal stored for an external reference, reporting whether it exists
+func (accountRespository *AccountRespository) GetFundingTransaction(fundingSource string, externalReference string) (db.FundingTransaction, bool, error) {
+	config.Logger.Info("Fetching funding transaction from database", "funding_source", fundingSource, "external_reference", externalReference)
+
+	fundingTransaction, err := accountRespository.queries.GetFundingTransactionByReference(accountRespository.context, db.GetFundingTransactionByReferenceParams{
+		FundingSource:     fundingSource,
+		ExternalReference: externalReference,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			config.Logger.Info("External reference not used before", "funding_source", fundingSource, "external_reference", externalReference)
+			return db.FundingTransaction{}, false, nil
+		}
+
+		config.Logger.Error("Failed to fetch funding transaction from database", "error", err.Error(), "external_reference", externalReference)
+		return db.FundingTransaction{}, false, err
+	}
+
+	config.Logger.Info("Found funding transaction in database", "funding_transaction_id", fundingTransaction.ID, "external_reference", externalReference)
+
+	return fundingTransaction, true, nil
+}
+
+// fundingTxError maps store errors to client errors, keeping ErrExternalReferenceConflict for the service to replay
+func fundingTxError(err error, params db.FundingTxParams) error {
+	switch {
+	case errors.Is(err, db.ErrInsufficientFunds):
+		config.Logger.Error("Insufficient balance for withdrawal", "accountID", params.AccountID, "amount", params.Amount)
+		return accountErrors.ErrInsufficientBalance
+	case errors.Is(err, pgx.ErrNoRows):
+		config.Logger.Error("Account not found", "accountID", params.AccountID)
+		return accountErrors.ErrAccountNotFound
+	case errors.Is(err, db.ErrExternalReferenceConflict):
+		return err
+	}
+
+	config.Logger.Error("Failed to record funding transaction in database", "error", err.Error(), "accountID", params.AccountID)
+	return err
+}
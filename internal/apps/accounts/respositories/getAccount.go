@@ -5,10 +5,11 @@ import (
 	db "lemfi/simplebank/db/sqlc"
 )
 
-func (accountRespository *AccountRespository) GetAccounts() ([]db.Account, error) {
-	config.Logger.Info("Fetching accounts from database", "limit", 10, "offset", 0)
+func (accountRespository *AccountRespository) GetAccounts(owner string) ([]db.Account, error) {
+	config.Logger.Info("Fetching accounts from database", "owner", owner, "limit", 10, "offset", 0)
 
-	accounts, err := accountRespository.queries.ListAllAccounts(accountRespository.context, db.ListAllAccountsParams{
+	accounts, err := accountRespository.queries.ListAccounts(accountRespository.context, db.ListAccountsParams{
+		Owner:  owner,
 		Limit:  10,
 		Offset: 0,
 	})
@@ -0,0 +1,28 @@
+package accounts
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	accountErrors "lemfi/simplebank/internal/apps/accounts/errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (accountRespository *AccountRespository) GetAccount(accountID int64) (db.Account, error) {
+	config.Logger.Info("Fetching account from database", "accountID", accountID)
+
+	account, err := accountRespository.queries.GetAccount(accountRespository.context, accountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			config.Logger.Error("Account not found", "accountID", accountID)
+			return db.Account{}, accountErrors.ErrAccountNotFound
+		}
+
+		config.Logger.Error("Failed to fetch account from database", "error", err.Error(), "accountID", accountID)
+		return db.Account{}, err
+	}
+
+	return account, nil
+}
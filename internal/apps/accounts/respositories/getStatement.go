@@ -0,0 +1,35 @@
+package accounts
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	accountErrors "lemfi/simplebank/internal/apps/accounts/errors"
+	requests "lemfi/simplebank/internal/apps/accounts/requests"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (accountRespository *AccountRespository) GetStatement(payload requests.GetStatementRequest) (db.AccountStatementTxResult, error) {
+	config.Logger.Info("Fetching account statement from database", "accountID", payload.AccountID, "from", payload.From, "to", payload.To)
+
+	statement, err := accountRespository.queries.AccountStatementTx(accountRespository.context, db.AccountStatementTxParams{
+		AccountID: payload.AccountID,
+		From:      payload.From,
+		To:        payload.To,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			config.Logger.Error("Account not found", "accountID", payload.AccountID)
+			return db.AccountStatementTxResult{}, accountErrors.ErrAccountNotFound
+		}
+
+		config.Logger.Error("Failed to fetch account statement from database", "error", err.Error(), "accountID", payload.AccountID)
+		return db.AccountStatementTxResult{}, err
+	}
+
+	config.Logger.Info("Successfully fetched account statement from database", "accountID", payload.AccountID, "entries", len(statement.Entries))
+
+	return statement, nil
+}
@@ -7,5 +7,10 @@ import (
 
 type AccountRespositoryInterface interface {
 	CreateAccount(payload requests.CreateAccountRequest) (db.Account, error)
-	GetAccounts() ([]db.Account, error)
+	GetAccounts(owner string) ([]db.Account, error)
+	GetAccount(accountID int64) (db.Account, error)
+	GetStatement(payload requests.GetStatementRequest) (db.AccountStatementTxResult, error)
+	Deposit(params db.FundingTxParams) (db.FundingTxResult, error)
+	Withdraw(params db.FundingTxParams) (db.FundingTxResult, error)
+	GetFundingTransaction(fundingSource string, externalReference string) (db.FundingTransaction, bool, error)
 }
@@ -10,7 +10,7 @@ import (
 )
 
 // Routes defines the health check route for the API.
-func Routes(router *gin.Engine) {
+func Routes(router *gin.Engine, authUsers middleware.PasswordChangeGetter) {
 	accountRespository := respositories.NewAccountRespository()
 	accountService := services.NewAccountService(accountRespository)
 	accountController := accounts.NewAccountController(accountService)
@@ -18,11 +18,22 @@ func Routes(router *gin.Engine) {
 	// Group accounts routes with common middleware
 	accountsGroup := router.Group("/api/v1/accounts")
 	accountsGroup.Use(
-		middleware.ValidateAuth(),
+		middleware.ValidateAuth(authUsers),
 		middleware.RequireAuthenticatedUser(),
 	)
 
 	// Register routes without repeating middleware
 	accountsGroup.POST("", accountController.CreateAccountController)
 	accountsGroup.GET("", accountController.GetAccountsController)
+	accountsGroup.GET("/:id/statement", accountController.GetStatementController)
+	accountsGroup.POST("/:id/withdrawals", accountController.WithdrawController)
+
+	// Deposits credit money received outside the bank, so only admins can record them
+	adminGroup := router.Group("/api/v1/accounts")
+	adminGroup.Use(
+		middleware.ValidateAuth(authUsers),
+		middleware.RequireAuthenticatedUserWithRole("admin"),
+	)
+
+	adminGroup.POST("/:id/deposits", accountController.DepositController)
 }
@@ -0,0 +1,22 @@
+package accounts
+
+import (
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	accountErrors "lemfi/simplebank/internal/apps/accounts/errors"
+)
+
+// authorizeAccountOwner loads an account and checks that it belongs to the authenticated user
+func (accountService *AccountService) authorizeAccountOwner(accountID int64, owner string) (db.Account, error) {
+	account, err := accountService.accountRespository.GetAccount(accountID)
+	if err != nil {
+		return db.Account{}, err
+	}
+
+	if owner == "" || account.Owner != owner {
+		config.Logger.Error("Account does not belong to user", "accountID", accountID, "owner", owner)
+		return db.Account{}, accountErrors.ErrAccountForbidden
+	}
+
+	return account, nil
+}
@@ -2,6 +2,7 @@ package accounts
 
 import (
 	"lemfi/simplebank/config"
+	accountErrors "lemfi/simplebank/internal/apps/accounts/errors"
 	requests "lemfi/simplebank/internal/apps/accounts/requests"
 	responses "lemfi/simplebank/internal/apps/accounts/responses"
 	"lemfi/simplebank/internal/apps/currencies"
@@ -10,6 +11,12 @@ import (
 func (accountService *AccountService) CreateAccount(payload requests.CreateAccountRequest) (responses.CreateAccountResponse, error) {
 	config.Logger.Info("Processing account creation in service layer", "owner", payload.Owner, "currency", payload.Currency)
 
+	// The owner always comes from the authenticated user
+	if payload.Owner == "" {
+		config.Logger.Error("Missing account owner")
+		return responses.CreateAccountResponse{}, accountErrors.ErrOwnerRequired
+	}
+
 	if !currencies.IsSupportedCurrency(currencies.Currency(payload.Currency)) {
 		config.Logger.Error("Currency is not supported", "currency", payload.Currency)
 		return responses.CreateAccountResponse{}, currencies.ErrCurrencyNotSupported
@@ -29,6 +36,8 @@ func (accountService *AccountService) CreateAccount(payload requests.CreateAccou
 		Balance:   account.Balance,
 		Currency:  account.Currency,
 		CreatedAt: account.CreatedAt,
+
+		AvailableBalance: account.Balance.Sub(account.HeldAmount),
 	}
 
 	config.Logger.Info("Account creation service completed", "accountID", response.ID)
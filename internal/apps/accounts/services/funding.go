@@ -0,0 +1,151 @@
+package accounts
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	accountErrors "lemfi/simplebank/internal/apps/accounts/errors"
+	requests "lemfi/simplebank/internal/apps/accounts/requests"
+	responses "lemfi/simplebank/internal/apps/accounts/responses"
+	"lemfi/simplebank/internal/apps/currencies"
+)
+
+// Deposit credits an account with money received through an external funding source.
+// Deposits are recorded by admins once the money has arrived, so any account can be credited
+func (accountService *AccountService) Deposit(payload requests.FundingRequest) (responses.FundingTransactionResponse, error) {
+	return accountService.fund(db.FundingKindDeposit, payload)
+}
+
+// Withdraw debits an owned account with money paid out through an external funding source
+func (accountService *AccountService) Withdraw(payload requests.FundingRequest) (responses.FundingTransactionResponse, error) {
+	return accountService.fund(db.FundingKindWithdrawal, payload)
+}
+
+// fund validates and records a deposit or withdrawal.
+// An external reference that was already applied returns the original result instead of moving money twice
+func (accountService *AccountService) fund(kind string, payload requests.FundingRequest) (responses.FundingTransactionResponse, error) {
+	config.Logger.Info("Processing funding request",
+		"kind", kind,
+		"accountID", payload.AccountID,
+		"amount", payload.Amount,
+		"currency", payload.Currency,
+		"funding_source", payload.FundingSource,
+		"external_reference", payload.ExternalReference,
+	)
+
+	currency := currencies.Currency(payload.Currency)
+	if !currencies.IsSupportedCurrency(currency) {
+		config.Logger.Error("Currency is not supported", "currency", payload.Currency)
+		return responses.FundingTransactionResponse{}, currencies.ErrCurrencyNotSupported
+	}
+
+	if !payload.Amount.IsPositive() {
+		config.Logger.Error("Invalid funding amount", "amount", payload.Amount)
+		return responses.FundingTransactionResponse{}, accountErrors.ErrInvalidFundingAmount
+	}
+
+	if !currencies.HasValidPrecision(payload.Amount, currency) {
+		config.Logger.Error("Funding amount has too many decimal places", "amount", payload.Amount, "currency", payload.Currency)
+		return responses.FundingTransactionResponse{}, accountErrors.ErrFundingAmountPrecision
+	}
+
+	response, replayed, err := accountService.replayFundingTransaction(kind, payload)
+	if err != nil || replayed {
+		return response, err
+	}
+
+	account, err := accountService.authorizeFundingAccount(kind, payload)
+	if err != nil {
+		return responses.FundingTransactionResponse{}, err
+	}
+
+	if account.Currency != payload.Currency {
+		config.Logger.Error("Funding currency does not match account", "accountID", account.ID, "account_currency", account.Currency, "currency", payload.Currency)
+		return responses.FundingTransactionResponse{}, accountErrors.ErrAccountCurrencyMismatch
+	}
+
+	var result db.FundingTxResult
+	if kind == db.FundingKindDeposit {
+		result, err = accountService.accountRespository.Deposit(newFundingTxParams(payload))
+	} else {
+		result, err = accountService.accountRespository.Withdraw(newFundingTxParams(payload))
+	}
+	if errors.Is(err, db.ErrExternalReferenceConflict) {
+		// A concurrent request with the same reference committed first
+		config.Logger.Info("External reference committed by a concurrent request", "external_reference", payload.ExternalReference)
+		response, replayed, replayErr := accountService.replayFundingTransaction(kind, payload)
+		if replayErr != nil || replayed {
+			return response, replayErr
+		}
+	}
+	if err != nil {
+		config.Logger.Error("Funding transaction failed", "error", err.Error(), "kind", kind, "accountID", payload.AccountID)
+		return responses.FundingTransactionResponse{}, err
+	}
+
+	config.Logger.Info("Funding transaction completed successfully",
+		"funding_transaction_id", result.FundingTransaction.ID,
+		"kind", kind,
+		"accountID", payload.AccountID,
+		"balance", result.Account.Balance,
+	)
+
+	return responses.NewFundingTransactionResponse(result.FundingTransaction), nil
+}
+
+// replayFundingTransaction looks up a previously applied external reference.
+// It returns the original result when the request matches, and an error when the reference was used for something else
+func (accountService *AccountService) replayFundingTransaction(kind string, payload requests.FundingRequest) (responses.FundingTransactionResponse, bool, error) {
+	existing, found, err := accountService.accountRespository.GetFundingTransaction(payload.FundingSource, payload.ExternalReference)
+	if err != nil || !found {
+		return responses.FundingTransactionResponse{}, false, err
+	}
+
+	// Only those who may fund the account may see or reuse the reference
+	_, err = accountService.authorizeFundingAccount(kind, payload)
+	if err != nil {
+		return responses.FundingTransactionResponse{}, false, err
+	}
+
+	if existing.Kind != kind ||
+		existing.AccountID != payload.AccountID ||
+		existing.Currency != payload.Currency ||
+		!existing.Amount.Equal(payload.Amount) {
+		config.Logger.Error("External reference reused for a different request",
+			"external_reference", payload.ExternalReference,
+			"funding_transaction_id", existing.ID,
+		)
+		return responses.FundingTransactionResponse{}, false, accountErrors.ErrExternalReferenceMismatch
+	}
+
+	config.Logger.Info("Replaying funding transaction for external reference",
+		"external_reference", payload.ExternalReference,
+		"funding_transaction_id", existing.ID,
+	)
+
+	response := responses.NewFundingTransactionResponse(existing)
+	response.Replayed = true
+
+	return response, true, nil
+}
+
+// authorizeFundingAccount loads the account money moves in or out of. The deposit route is restricted to admins,
+// who may credit any account, while withdrawals are only allowed from the authenticated user's own accounts
+func (accountService *AccountService) authorizeFundingAccount(kind string, payload requests.FundingRequest) (db.Account, error) {
+	if kind == db.FundingKindDeposit {
+		return accountService.accountRespository.GetAccount(payload.AccountID)
+	}
+
+	return accountService.authorizeAccountOwner(payload.AccountID, payload.Owner)
+}
+
+func newFundingTxParams(payload requests.FundingRequest) db.FundingTxParams {
+	return db.FundingTxParams{
+		AccountID:         payload.AccountID,
+		Amount:            payload.Amount,
+		Currency:          payload.Currency,
+		ExternalReference: payload.ExternalReference,
+		FundingSource:     payload.FundingSource,
+	}
+}
@@ -2,13 +2,20 @@ package accounts
 
 import (
 	"lemfi/simplebank/config"
+	accountErrors "lemfi/simplebank/internal/apps/accounts/errors"
 	responses "lemfi/simplebank/internal/apps/accounts/responses"
 )
 
-func (accountService *AccountService) GetAccounts() ([]responses.GetAccountResponse, error) {
-	config.Logger.Info("Processing get accounts request in service layer")
+// GetAccounts lists the accounts owned by the authenticated user
+func (accountService *AccountService) GetAccounts(owner string) ([]responses.GetAccountResponse, error) {
+	config.Logger.Info("Processing get accounts request in service layer", "owner", owner)
 
-	accounts, err := accountService.accountRespository.GetAccounts()
+	if owner == "" {
+		config.Logger.Error("Missing account owner")
+		return []responses.GetAccountResponse{}, accountErrors.ErrOwnerRequired
+	}
+
+	accounts, err := accountService.accountRespository.GetAccounts(owner)
 	if err != nil {
 		config.Logger.Error("Failed to get accounts in service layer", "error", err.Error())
 		return []responses.GetAccountResponse{}, err
@@ -24,6 +31,8 @@ func (accountService *AccountService) GetAccounts() ([]responses.GetAccountRespo
 			Balance:   account.Balance,
 			Currency:  account.Currency,
 			CreatedAt: account.CreatedAt,
+
+			AvailableBalance: account.Balance.Sub(account.HeldAmount),
 		}
 	}
 
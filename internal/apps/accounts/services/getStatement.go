@@ -0,0 +1,109 @@
+package accounts
+
+import (
+	"time"
+
+	"lemfi/simplebank/config"
+	accountErrors "lemfi/simplebank/internal/apps/accounts/errors"
+	requests "lemfi/simplebank/internal/apps/accounts/requests"
+	responses "lemfi/simplebank/internal/apps/accounts/responses"
+)
+
+const (
+	defaultStatementPeriod = 30 * 24 * time.Hour
+	maxStatementPeriod     = 366 * 24 * time.Hour
+)
+
+// GetStatement builds an account statement from the entries ledger
+func (accountService *AccountService) GetStatement(payload requests.GetStatementRequest) (responses.StatementResponse, error) {
+	config.Logger.Info("Processing account statement request in service layer", "accountID", payload.AccountID, "owner", payload.Owner)
+
+	if payload.To.IsZero() {
+		payload.To = time.Now()
+	}
+	if payload.From.IsZero() {
+		payload.From = payload.To.Add(-defaultStatementPeriod)
+	}
+
+	if !payload.From.Before(payload.To) {
+		config.Logger.Error("Invalid statement range", "from", payload.From, "to", payload.To)
+		return responses.StatementResponse{}, accountErrors.ErrInvalidStatementRange
+	}
+	if payload.To.Sub(payload.From) > maxStatementPeriod {
+		config.Logger.Error("Statement range too long", "from", payload.From, "to", payload.To)
+		return responses.StatementResponse{}, accountErrors.ErrStatementRangeTooLong
+	}
+
+	_, err := accountService.authorizeAccountOwner(payload.AccountID, payload.Owner)
+	if err != nil {
+		return responses.StatementResponse{}, err
+	}
+
+	statement, err := accountService.accountRespository.GetStatement(payload)
+	if err != nil {
+		config.Logger.Error("Failed to get account statement in service layer", "error", err.Error(), "accountID", payload.AccountID)
+		return responses.StatementResponse{}, err
+	}
+
+	response := responses.StatementResponse{
+		AccountID:      statement.Account.ID,
+		Owner:          statement.Account.Owner,
+		Currency:       statement.Account.Currency,
+		From:           payload.From,
+		To:             payload.To,
+		OpeningBalance: statement.OpeningBalance,
+		Entries:        make([]responses.StatementEntry, 0, len(statement.Entries)),
+	}
+
+	runningBalance := statement.OpeningBalance
+	for _, entry := range statement.Entries {
+		runningBalance = runningBalance.Add(entry.Amount)
+
+		statementEntry := responses.StatementEntry{
+			ID:             entry.ID,
+			Amount:         entry.Amount,
+			RunningBalance: runningBalance,
+			CreatedAt:      entry.CreatedAt,
+		}
+
+		if entry.TransferID.Valid {
+			direction := "received"
+			if entry.FromAccountID.Int64 == entry.AccountID {
+				direction = "sent"
+			}
+
+			statementEntry.Transfer = &responses.StatementTransfer{
+				ID:            entry.TransferID.Int64,
+				FromAccountID: entry.FromAccountID.Int64,
+				ToAccountID:   entry.ToAccountID.Int64,
+				FromCurrency:  entry.FromCurrency.String,
+				ToCurrency:    entry.ToCurrency.String,
+				Direction:     direction,
+			}
+		}
+
+		if entry.CounterpartyAccountID.Valid {
+			statementEntry.Counterparty = &responses.StatementCounterparty{
+				AccountID: entry.CounterpartyAccountID.Int64,
+				Owner:     entry.CounterpartyOwner.String,
+				Currency:  entry.CounterpartyCurrency.String,
+			}
+		}
+
+		if entry.FundingTransactionID.Valid {
+			statementEntry.Funding = &responses.StatementFunding{
+				ID:                entry.FundingTransactionID.Int64,
+				Type:              entry.FundingKind.String,
+				ExternalReference: entry.FundingExternalReference.String,
+				FundingSource:     entry.FundingSource.String,
+			}
+		}
+
+		response.Entries = append(response.Entries, statementEntry)
+	}
+	response.ClosingBalance = runningBalance
+
+	config.Logger.Info("Account statement service completed successfully", "accountID", response.AccountID, "entries", len(response.Entries))
+
+	return response, nil
+}
@@ -7,5 +7,8 @@ import (
 
 type AccountServiceInterface interface {
 	CreateAccount(payload requests.CreateAccountRequest) (responses.CreateAccountResponse, error)
-	GetAccounts() ([]responses.GetAccountResponse, error)
+	GetAccounts(owner string) ([]responses.GetAccountResponse, error)
+	GetStatement(payload requests.GetStatementRequest) (responses.StatementResponse, error)
+	Deposit(payload requests.FundingRequest) (responses.FundingTransactionResponse, error)
+	Withdraw(payload requests.FundingRequest) (responses.FundingTransactionResponse, error)
 }
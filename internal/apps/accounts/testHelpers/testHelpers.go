@@ -2,8 +2,14 @@ package accounts
 
 import (
 	"context"
+	"errors"
 	db "lemfi/simplebank/db/sqlc"
+	accountErrors "lemfi/simplebank/internal/apps/accounts/errors"
 	requests "lemfi/simplebank/internal/apps/accounts/requests"
+	"lemfi/simplebank/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 )
 
 // MockAccountRepository implements AccountRespositoryInterface for testing
@@ -18,13 +24,70 @@ func (m *MockAccountRepository) CreateAccount(payload requests.CreateAccountRequ
 	})
 }
 
-func (m *MockAccountRepository) GetAccounts() ([]db.Account, error) {
-	return m.store.ListAllAccounts(context.Background(), db.ListAllAccountsParams{
+func (m *MockAccountRepository) GetAccounts(owner string) ([]db.Account, error) {
+	return m.store.ListAccounts(context.Background(), db.ListAccountsParams{
+		Owner:  owner,
 		Limit:  10,
 		Offset: 0,
 	})
 }
 
+func (m *MockAccountRepository) GetAccount(accountID int64) (db.Account, error) {
+	account, err := m.store.GetAccount(context.Background(), accountID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.Account{}, accountErrors.ErrAccountNotFound
+	}
+
+	return account, err
+}
+
+func (m *MockAccountRepository) GetStatement(payload requests.GetStatementRequest) (db.AccountStatementTxResult, error) {
+	return m.store.AccountStatementTx(context.Background(), db.AccountStatementTxParams{
+		AccountID: payload.AccountID,
+		From:      payload.From,
+		To:        payload.To,
+	})
+}
+
+func (m *MockAccountRepository) Deposit(params db.FundingTxParams) (db.FundingTxResult, error) {
+	return m.store.DepositTx(context.Background(), params)
+}
+
+func (m *MockAccountRepository) Withdraw(params db.FundingTxParams) (db.FundingTxResult, error) {
+	result, err := m.store.WithdrawTx(context.Background(), params)
+	if errors.Is(err, db.ErrInsufficientFunds) {
+		return db.FundingTxResult{}, accountErrors.ErrInsufficientBalance
+	}
+	return result, err
+}
+
+func (m *MockAccountRepository) GetFundingTransaction(fundingSource string, externalReference string) (db.FundingTransaction, bool, error) {
+	fundingTransaction, err := m.store.GetFundingTransactionByReference(context.Background(), db.GetFundingTransactionByReferenceParams{
+		FundingSource:     fundingSource,
+		ExternalReference: externalReference,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.FundingTransaction{}, false, nil
+	}
+	if err != nil {
+		return db.FundingTransaction{}, false, err
+	}
+	return fundingTransaction, true, nil
+}
+
+// AuthenticateAs stands in for the auth middleware, setting the user on the request context
+func AuthenticateAs(username string) gin.HandlerFunc {
+	return AuthenticateAsRole(username, "user")
+}
+
+// AuthenticateAsRole stands in for the auth middleware, setting a user with the given role on the request context
+func AuthenticateAsRole(username string, role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		middleware.ContextSetUser(c, &middleware.UserClaimsData{Username: username, Role: role})
+		c.Next()
+	}
+}
+
 // NewMockAccountRepository creates a new mock repository that wraps a store
 func NewMockAccountRepository(store db.Store) *MockAccountRepository {
 	return &MockAccountRepository{store: store}
@@ -1,6 +1,5 @@
 package accounts
 
 var CreateAccountValidationMessages = map[string]string{
-	"Owner.required":    "owner is required.",
 	"Currency.required": "currency is required.",
 }
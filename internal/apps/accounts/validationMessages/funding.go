@@ -0,0 +1,11 @@
+package accounts
+
+var FundingValidationMessages = map[string]string{
+	"Amount.required":            "amount is required.",
+	"Currency.required":          "currency is required.",
+	"Currency.len":               "currency must be a 3 letter ISO code.",
+	"ExternalReference.required": "external_reference is required.",
+	"ExternalReference.max":      "external_reference must not be longer than 255 characters.",
+	"FundingSource.required":     "funding_source is required.",
+	"FundingSource.oneof":        "funding_source must be one of bank_transfer, card or mobile_money.",
+}
@@ -0,0 +1,15 @@
+package currencies
+
+import (
+	services "lemfi/simplebank/internal/apps/currencies/services"
+)
+
+type CurrencyController struct {
+	currencyService services.CurrencyServiceInterface
+}
+
+func NewCurrencyController(currencyService services.CurrencyServiceInterface) *CurrencyController {
+	return &CurrencyController{
+		currencyService: currencyService,
+	}
+}
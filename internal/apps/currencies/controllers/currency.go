@@ -0,0 +1,114 @@
+package currencies
+
+import (
+	"net/http"
+
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/internal/apps/core"
+	requests "lemfi/simplebank/internal/apps/currencies/requests"
+	responses "lemfi/simplebank/internal/apps/currencies/responses"
+	currencyValidation "lemfi/simplebank/internal/apps/currencies/validationMessages"
+	"lemfi/simplebank/pkg/errorResponse"
+	"lemfi/simplebank/pkg/requestHandler"
+	"lemfi/simplebank/pkg/responseHandler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListCurrenciesController returns every currency with its minor units and whether it is enabled
+func (currencyController *CurrencyController) ListCurrenciesController(c *gin.Context) {
+	config.Logger.Info("Listing currencies", "method", "GET", "endpoint", "/currencies")
+
+	result, err := currencyController.currencyService.ListCurrencies(c.Request.Context())
+	if err != nil {
+		writeCurrencyError(c, err)
+		return
+	}
+
+	response := responseHandler.Envelope{
+		"currencies": result,
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Currencies response written successfully", "total", result.Total)
+}
+
+// CreateCurrencyController adds a currency to the registry (admin only)
+func (currencyController *CurrencyController) CreateCurrencyController(c *gin.Context) {
+	config.Logger.Info("Creating currency", "method", "POST", "endpoint", "/currencies")
+
+	var req requests.CreateCurrencyRequest
+
+	err := requestHandler.ReadJSONGin(c, &req, currencyValidation.CreateCurrencyValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read currency request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	result, err := currencyController.currencyService.CreateCurrency(c.Request.Context(), req)
+	if err != nil {
+		writeCurrencyError(c, err)
+		return
+	}
+
+	writeCurrencyResponse(c, http.StatusCreated, result)
+}
+
+// UpdateCurrencyController changes the symbol of a currency or enables and disables it (admin only)
+func (currencyController *CurrencyController) UpdateCurrencyController(c *gin.Context) {
+	config.Logger.Info("Updating currency", "method", "PATCH", "endpoint", "/currencies/:code")
+
+	var req requests.UpdateCurrencyRequest
+
+	err := requestHandler.ReadJSONGin(c, &req, currencyValidation.UpdateCurrencyValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read currency request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	req.Code = c.Param("code")
+
+	result, err := currencyController.currencyService.UpdateCurrency(c.Request.Context(), req)
+	if err != nil {
+		writeCurrencyError(c, err)
+		return
+	}
+
+	writeCurrencyResponse(c, http.StatusOK, result)
+}
+
+func writeCurrencyResponse(c *gin.Context, status int, currency responses.CurrencyResponse) {
+	response := responseHandler.Envelope{
+		"currency": currency,
+	}
+
+	err := responseHandler.WriteJSON(c.Writer, status, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Currency response written successfully",
+		"code", currency.Code,
+		"minor_units", currency.MinorUnits,
+		"enabled", currency.Enabled,
+	)
+}
+
+func writeCurrencyError(c *gin.Context, err error) {
+	config.Logger.Error("Currency request failed", "error", err.Error())
+	if clientErr, isClient := core.IsClientError(err); isClient {
+		errorResponse.BadRequestResponse(c, clientErr)
+	} else {
+		errorResponse.ServerErrorResponse(c, err)
+	}
+}
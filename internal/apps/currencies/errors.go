@@ -3,8 +3,29 @@ package currencies
 import "lemfi/simplebank/internal/apps/core"
 
 var (
+	// The supported currencies change at runtime, clients can list them with GET /api/v1/currencies
 	ErrCurrencyNotSupported = core.ClientError{
-		Message: "currency is not supported. Supported currencies are: " + GetSupportedCurrenciesString(),
+		Message: "currency is not supported, see /api/v1/currencies for the supported currencies",
+		Status:  400,
+	}
+	ErrCurrencyNotFound = core.ClientError{
+		Message: "currency not found",
+		Status:  404,
+	}
+	ErrCurrencyExists = core.ClientError{
+		Message: "currency already exists",
+		Status:  400,
+	}
+	ErrInvalidCurrencyCode = core.ClientError{
+		Message: "code must be an ISO 4217 code of 3 uppercase letters",
+		Status:  400,
+	}
+	ErrInvalidMinorUnits = core.ClientError{
+		Message: "minor_units must be between 0 and 3",
+		Status:  400,
+	}
+	ErrInvalidCurrencySymbol = core.ClientError{
+		Message: "symbol must be between 1 and 8 characters",
 		Status:  400,
 	}
 )
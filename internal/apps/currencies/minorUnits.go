@@ -0,0 +1,29 @@
+package currencies
+
+import "github.com/shopspring/decimal"
+
+// defaultMinorUnits is used for currencies missing from the registry
+const defaultMinorUnits = 2
+
+// MinorUnits returns the number of decimal places used by the currency, defaulting to 2
+func MinorUnits(currency Currency) int32 {
+	if definition, ok := Lookup(currency); ok {
+		return definition.MinorUnits
+	}
+	return defaultMinorUnits
+}
+
+// Round rounds an amount to the currency's number of decimal places
+func Round(amount decimal.Decimal, currency Currency) decimal.Decimal {
+	return amount.Round(MinorUnits(currency))
+}
+
+// HasValidPrecision reports whether the amount has no more decimal places than the currency allows
+func HasValidPrecision(amount decimal.Decimal, currency Currency) bool {
+	return amount.Equal(Round(amount, currency))
+}
+
+// FormatAmount renders an amount with exactly the currency's number of decimal places
+func FormatAmount(amount decimal.Decimal, currency Currency) string {
+	return amount.StringFixed(MinorUnits(currency))
+}
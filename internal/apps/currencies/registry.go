@@ -0,0 +1,68 @@
+package currencies
+
+import (
+	"sort"
+	"sync"
+)
+
+// Definition is a currency of the registry, loaded from the currencies table
+type Definition struct {
+	Code       Currency
+	MinorUnits int32 // Decimal places of the currency (ISO 4217)
+	Symbol     string
+	Enabled    bool // Disabled currencies keep their minor units for existing accounts but are not supported
+}
+
+// registry caches the currencies table in memory, every request validates currencies against it
+type registry struct {
+	mu         sync.RWMutex
+	currencies map[Currency]Definition
+}
+
+var currencyRegistry = newRegistry(defaultCurrencies)
+
+func newRegistry(definitions []Definition) *registry {
+	r := &registry{}
+	r.set(definitions)
+	return r
+}
+
+func (r *registry) set(definitions []Definition) {
+	currencies := make(map[Currency]Definition, len(definitions))
+	for _, definition := range definitions {
+		currencies[definition.Code] = definition
+	}
+
+	r.mu.Lock()
+	r.currencies = currencies
+	r.mu.Unlock()
+}
+
+// Load replaces the cached currencies, usually with the rows of the currencies table
+func Load(definitions []Definition) {
+	currencyRegistry.set(definitions)
+}
+
+// Lookup returns the cached definition of the currency, enabled or not
+func Lookup(currency Currency) (Definition, bool) {
+	currencyRegistry.mu.RLock()
+	defer currencyRegistry.mu.RUnlock()
+
+	definition, ok := currencyRegistry.currencies[currency]
+	return definition, ok
+}
+
+// List returns every cached currency sorted by code
+func List() []Definition {
+	currencyRegistry.mu.RLock()
+	definitions := make([]Definition, 0, len(currencyRegistry.currencies))
+	for _, definition := range currencyRegistry.currencies {
+		definitions = append(definitions, definition)
+	}
+	currencyRegistry.mu.RUnlock()
+
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Code < definitions[j].Code
+	})
+	return definitions
+}
@@ -0,0 +1,74 @@
+package currencies
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTestCurrencies(t *testing.T, definitions ...Definition) {
+	previous := List()
+	t.Cleanup(func() { Load(previous) })
+
+	Load(definitions)
+}
+
+func TestRegistry(t *testing.T) {
+	loadTestCurrencies(t,
+		Definition{Code: USD, MinorUnits: 2, Symbol: "$", Enabled: true},
+		Definition{Code: "JPY", MinorUnits: 0, Symbol: "¥", Enabled: true},
+		Definition{Code: "KWD", MinorUnits: 3, Symbol: "KD", Enabled: true},
+		Definition{Code: NGN, MinorUnits: 2, Symbol: "₦", Enabled: false},
+	)
+
+	require.True(t, IsSupportedCurrency("JPY"))
+	require.True(t, IsSupportedCurrency("KWD"))
+	// Disabled and unknown currencies are not supported
+	require.False(t, IsSupportedCurrency(NGN))
+	require.False(t, IsSupportedCurrency(GBP))
+
+	require.Equal(t, []Currency{"JPY", "KWD", USD}, SupportedCurrencies())
+	require.Equal(t, "JPY, KWD, USD", GetSupportedCurrenciesString())
+
+	// Disabled currencies keep their minor units for existing accounts, unknown ones default to 2
+	require.Equal(t, int32(0), MinorUnits("JPY"))
+	require.Equal(t, int32(3), MinorUnits("KWD"))
+	require.Equal(t, int32(2), MinorUnits(NGN))
+	require.Equal(t, int32(2), MinorUnits("XYZ"))
+}
+
+func TestRound(t *testing.T) {
+	loadTestCurrencies(t,
+		Definition{Code: USD, MinorUnits: 2, Symbol: "$", Enabled: true},
+		Definition{Code: "JPY", MinorUnits: 0, Symbol: "¥", Enabled: true},
+		Definition{Code: "KWD", MinorUnits: 3, Symbol: "KD", Enabled: true},
+	)
+
+	testCases := []struct {
+		currency       Currency
+		amount         string
+		expectedRound  string
+		expectedFormat string
+		validPrecision bool
+	}{
+		{currency: USD, amount: "10.125", expectedRound: "10.13", expectedFormat: "10.125", validPrecision: false},
+		{currency: USD, amount: "10.1", expectedRound: "10.1", expectedFormat: "10.10", validPrecision: true},
+		{currency: "JPY", amount: "1500.5", expectedRound: "1501", expectedFormat: "1500.5", validPrecision: false},
+		{currency: "JPY", amount: "1500", expectedRound: "1500", expectedFormat: "1500", validPrecision: true},
+		{currency: "KWD", amount: "3.0715", expectedRound: "3.072", expectedFormat: "3.0715", validPrecision: false},
+		{currency: "KWD", amount: "3.5", expectedRound: "3.5", expectedFormat: "3.500", validPrecision: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.currency)+"_"+tc.amount, func(t *testing.T) {
+			amount := decimal.RequireFromString(tc.amount)
+
+			require.True(t, decimal.RequireFromString(tc.expectedRound).Equal(Round(amount, tc.currency)))
+			require.Equal(t, tc.validPrecision, HasValidPrecision(amount, tc.currency))
+			if tc.validPrecision {
+				require.Equal(t, tc.expectedFormat, FormatAmount(amount, tc.currency))
+			}
+		})
+	}
+}
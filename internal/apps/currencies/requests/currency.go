@@ -0,0 +1,16 @@
+package currencies
+
+type CreateCurrencyRequest struct {
+	Code       string `json:"code" validate:"required"`        // ISO 4217 code, e.g. JPY
+	MinorUnits *int32 `json:"minor_units" validate:"required"` // Decimal places, 0 for JPY and 3 for KWD
+	Symbol     string `json:"symbol" validate:"required"`
+	Enabled    *bool  `json:"enabled"` // Defaults to true
+}
+
+// UpdateCurrencyRequest changes a currency, only the fields that are sent are updated.
+// Minor units cannot change since existing amounts were rounded to them
+type UpdateCurrencyRequest struct {
+	Symbol  *string `json:"symbol"`
+	Enabled *bool   `json:"enabled"`
+	Code    string  `json:"-"` // Set from the :code path parameter
+}
@@ -0,0 +1,29 @@
+package responses
+
+import (
+	db "lemfi/simplebank/db/sqlc"
+	"time"
+)
+
+type CurrencyResponse struct {
+	Code       string    `json:"code"`
+	MinorUnits int32     `json:"minor_units"`
+	Symbol     string    `json:"symbol"`
+	Enabled    bool      `json:"enabled"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type ListCurrenciesResponse struct {
+	Currencies []CurrencyResponse `json:"currencies"`
+	Total      int                `json:"total"`
+}
+
+func NewCurrencyResponse(currency db.Currency) CurrencyResponse {
+	return CurrencyResponse{
+		Code:       currency.Code,
+		MinorUnits: currency.MinorUnits,
+		Symbol:     currency.Symbol,
+		Enabled:    currency.Enabled,
+		UpdatedAt:  currency.UpdatedAt,
+	}
+}
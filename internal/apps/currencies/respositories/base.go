@@ -0,0 +1,20 @@
+package currencies
+
+import (
+	"context"
+
+	dbConnection "lemfi/simplebank/db"
+	db "lemfi/simplebank/db/sqlc"
+)
+
+type CurrencyRepository struct {
+	context context.Context
+	queries db.Store
+}
+
+func NewCurrencyRepository() *CurrencyRepository {
+	return &CurrencyRepository{
+		context: context.Background(),
+		queries: db.NewStore(dbConnection.GetPostgresDBConnection()),
+	}
+}
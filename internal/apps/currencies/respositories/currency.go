@@ -0,0 +1,56 @@
+package currencies
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	"lemfi/simplebank/internal/apps/currencies"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (currencyRepository *CurrencyRepository) ListCurrencies(ctx context.Context) ([]db.Currency, error) {
+	rows, err := currencyRepository.queries.ListCurrencies(ctx)
+	if err != nil {
+		config.Logger.Error("Failed to list currencies", "error", err.Error())
+		return []db.Currency{}, err
+	}
+
+	return rows, nil
+}
+
+func (currencyRepository *CurrencyRepository) CreateCurrency(ctx context.Context, params db.CreateCurrencyParams) (db.Currency, error) {
+	config.Logger.Info("Creating currency", "code", params.Code, "minor_units", params.MinorUnits)
+
+	currency, err := currencyRepository.queries.CreateCurrencyTx(ctx, params)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
+			config.Logger.Error("Duplicate currency creation attempted", "code", params.Code)
+			return db.Currency{}, currencies.ErrCurrencyExists
+		}
+
+		config.Logger.Error("Failed to create currency", "error", err.Error())
+		return db.Currency{}, err
+	}
+
+	return currency, nil
+}
+
+func (currencyRepository *CurrencyRepository) UpdateCurrency(ctx context.Context, params db.UpdateCurrencyParams) (db.Currency, error) {
+	config.Logger.Info("Updating currency", "code", params.Code)
+
+	currency, err := currencyRepository.queries.UpdateCurrency(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Currency{}, currencies.ErrCurrencyNotFound
+		}
+
+		config.Logger.Error("Failed to update currency", "error", err.Error())
+		return db.Currency{}, err
+	}
+
+	return currency, nil
+}
@@ -0,0 +1,13 @@
+package currencies
+
+import (
+	"context"
+
+	db "lemfi/simplebank/db/sqlc"
+)
+
+type CurrencyRepositoryInterface interface {
+	ListCurrencies(ctx context.Context) ([]db.Currency, error)
+	CreateCurrency(ctx context.Context, params db.CreateCurrencyParams) (db.Currency, error)
+	UpdateCurrency(ctx context.Context, params db.UpdateCurrencyParams) (db.Currency, error)
+}
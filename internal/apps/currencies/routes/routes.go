@@ -0,0 +1,29 @@
+package currencies
+
+import (
+	controllers "lemfi/simplebank/internal/apps/currencies/controllers"
+	respositories "lemfi/simplebank/internal/apps/currencies/respositories"
+	services "lemfi/simplebank/internal/apps/currencies/services"
+	"lemfi/simplebank/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Routes defines the currency routes for the API.
+func Routes(router *gin.Engine, authUsers middleware.PasswordChangeGetter) {
+	currencyRepository := respositories.NewCurrencyRepository()
+	currencyService := services.NewCurrencyService(currencyRepository)
+	currencyController := controllers.NewCurrencyController(currencyService)
+
+	router.GET("/api/v1/currencies", currencyController.ListCurrenciesController)
+
+	// Changes are applied to the in-memory registry of this instance at once, other instances reload it periodically
+	adminGroup := router.Group("/api/v1/currencies")
+	adminGroup.Use(
+		middleware.ValidateAuth(authUsers),
+		middleware.RequireAuthenticatedUserWithRole("admin"),
+	)
+
+	adminGroup.POST("", currencyController.CreateCurrencyController)
+	adminGroup.PATCH("/:code", currencyController.UpdateCurrencyController)
+}
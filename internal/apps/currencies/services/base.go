@@ -0,0 +1,15 @@
+package currencies
+
+import (
+	respositories "lemfi/simplebank/internal/apps/currencies/respositories"
+)
+
+type CurrencyService struct {
+	currencyRepository respositories.CurrencyRepositoryInterface
+}
+
+func NewCurrencyService(currencyRepository respositories.CurrencyRepositoryInterface) *CurrencyService {
+	return &CurrencyService{
+		currencyRepository: currencyRepository,
+	}
+}
@@ -0,0 +1,153 @@
+package currencies
+
+import (
+	"context"
+	"regexp"
+	"unicode/utf8"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	"lemfi/simplebank/internal/apps/currencies"
+	requests "lemfi/simplebank/internal/apps/currencies/requests"
+	responses "lemfi/simplebank/internal/apps/currencies/responses"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxMinorUnits matches the precision of the money columns
+const maxMinorUnits = 3
+
+const maxSymbolLength = 8
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+func (currencyService *CurrencyService) ListCurrencies(ctx context.Context) (responses.ListCurrenciesResponse, error) {
+	config.Logger.Info("Service: Listing currencies")
+
+	rows, err := currencyService.currencyRepository.ListCurrencies(ctx)
+	if err != nil {
+		config.Logger.Error("Service: Failed to list currencies", "error", err.Error())
+		return responses.ListCurrenciesResponse{}, err
+	}
+
+	response := responses.ListCurrenciesResponse{
+		Currencies: make([]responses.CurrencyResponse, 0, len(rows)),
+		Total:      len(rows),
+	}
+
+	for _, row := range rows {
+		response.Currencies = append(response.Currencies, responses.NewCurrencyResponse(row))
+	}
+
+	return response, nil
+}
+
+func (currencyService *CurrencyService) CreateCurrency(ctx context.Context, payload requests.CreateCurrencyRequest) (responses.CurrencyResponse, error) {
+	config.Logger.Info("Service: Creating currency", "code", payload.Code)
+
+	if !currencyCodePattern.MatchString(payload.Code) {
+		config.Logger.Error("Invalid currency code", "code", payload.Code)
+		return responses.CurrencyResponse{}, currencies.ErrInvalidCurrencyCode
+	}
+
+	if payload.MinorUnits == nil || *payload.MinorUnits < 0 || *payload.MinorUnits > maxMinorUnits {
+		config.Logger.Error("Invalid currency minor units", "code", payload.Code, "minor_units", payload.MinorUnits)
+		return responses.CurrencyResponse{}, currencies.ErrInvalidMinorUnits
+	}
+
+	err := validateCurrencySymbol(payload.Symbol)
+	if err != nil {
+		return responses.CurrencyResponse{}, err
+	}
+
+	enabled := true
+	if payload.Enabled != nil {
+		enabled = *payload.Enabled
+	}
+
+	currency, err := currencyService.currencyRepository.CreateCurrency(ctx, db.CreateCurrencyParams{
+		Code:       payload.Code,
+		MinorUnits: *payload.MinorUnits,
+		Symbol:     payload.Symbol,
+		Enabled:    enabled,
+	})
+	if err != nil {
+		config.Logger.Error("Service: Failed to create currency", "error", err.Error())
+		return responses.CurrencyResponse{}, err
+	}
+
+	currencyService.refreshRegistryAfterChange(ctx)
+
+	return responses.NewCurrencyResponse(currency), nil
+}
+
+func (currencyService *CurrencyService) UpdateCurrency(ctx context.Context, payload requests.UpdateCurrencyRequest) (responses.CurrencyResponse, error) {
+	config.Logger.Info("Service: Updating currency", "code", payload.Code)
+
+	params := db.UpdateCurrencyParams{Code: payload.Code}
+
+	if payload.Symbol != nil {
+		err := validateCurrencySymbol(*payload.Symbol)
+		if err != nil {
+			return responses.CurrencyResponse{}, err
+		}
+		params.Symbol = pgtype.Text{String: *payload.Symbol, Valid: true}
+	}
+
+	if payload.Enabled != nil {
+		params.Enabled = pgtype.Bool{Bool: *payload.Enabled, Valid: true}
+	}
+
+	currency, err := currencyService.currencyRepository.UpdateCurrency(ctx, params)
+	if err != nil {
+		config.Logger.Error("Service: Failed to update currency", "error", err.Error())
+		return responses.CurrencyResponse{}, err
+	}
+
+	currencyService.refreshRegistryAfterChange(ctx)
+
+	return responses.NewCurrencyResponse(currency), nil
+}
+
+// RefreshRegistry reloads the in-memory currency registry from the currencies table
+func (currencyService *CurrencyService) RefreshRegistry(ctx context.Context) error {
+	rows, err := currencyService.currencyRepository.ListCurrencies(ctx)
+	if err != nil {
+		config.Logger.Error("Service: Failed to load currencies", "error", err.Error())
+		return err
+	}
+
+	definitions := make([]currencies.Definition, 0, len(rows))
+	for _, row := range rows {
+		definitions = append(definitions, currencies.Definition{
+			Code:       currencies.Currency(row.Code),
+			MinorUnits: row.MinorUnits,
+			Symbol:     row.Symbol,
+			Enabled:    row.Enabled,
+		})
+	}
+
+	currencies.Load(definitions)
+
+	config.Logger.Info("Service: Loaded currencies", "total", len(definitions))
+
+	return nil
+}
+
+// refreshRegistryAfterChange applies a change to this instance right away, other instances pick it up on their next refresh.
+// The change is already saved, so a failed reload is only logged
+func (currencyService *CurrencyService) refreshRegistryAfterChange(ctx context.Context) {
+	if err := currencyService.RefreshRegistry(ctx); err != nil {
+		config.Logger.Warn("Currency registry not reloaded after change", "error", err.Error())
+	}
+}
+
+func validateCurrencySymbol(symbol string) error {
+	length := utf8.RuneCountInString(symbol)
+	if length == 0 || length > maxSymbolLength {
+		config.Logger.Error("Invalid currency symbol", "symbol", symbol)
+		return currencies.ErrInvalidCurrencySymbol
+	}
+
+	return nil
+}
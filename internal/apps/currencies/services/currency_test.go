@@ -0,0 +1,166 @@
+package currencies
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	"lemfi/simplebank/internal/apps/currencies"
+	requests "lemfi/simplebank/internal/apps/currencies/requests"
+	testhelpers "lemfi/simplebank/internal/apps/currencies/testHelpers"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// restoreRegistry puts back the currencies a test replaced by refreshing the registry
+func restoreRegistry(t *testing.T) {
+	previous := currencies.List()
+	t.Cleanup(func() { currencies.Load(previous) })
+}
+
+func TestCreateCurrencyService(t *testing.T) {
+	minorUnits := func(units int32) *int32 {
+		return &units
+	}
+
+	testCases := []struct {
+		name        string
+		request     requests.CreateCurrencyRequest
+		buildStubs  func(store *mockdb.MockStore)
+		expectedErr error
+	}{
+		{
+			name:    "OK",
+			request: requests.CreateCurrencyRequest{Code: "JPY", MinorUnits: minorUnits(0), Symbol: "¥"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateCurrencyTx(gomock.Any(), db.CreateCurrencyParams{Code: "JPY", MinorUnits: 0, Symbol: "¥", Enabled: true}).
+					Return(db.Currency{Code: "JPY", MinorUnits: 0, Symbol: "¥", Enabled: true}, nil).
+					Times(1)
+				// The registry is reloaded so the new currency is supported right away
+				store.EXPECT().
+					ListCurrencies(gomock.Any()).
+					Return([]db.Currency{
+						{Code: "JPY", MinorUnits: 0, Symbol: "¥", Enabled: true},
+						{Code: "USD", MinorUnits: 2, Symbol: "$", Enabled: true},
+					}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:    "Duplicate",
+			request: requests.CreateCurrencyRequest{Code: "USD", MinorUnits: minorUnits(2), Symbol: "$"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateCurrencyTx(gomock.Any(), gomock.Any()).
+					Return(db.Currency{}, errors.New(`duplicate key value violates unique constraint "currencies_pkey"`)).
+					Times(1)
+				store.EXPECT().ListCurrencies(gomock.Any()).Times(0)
+			},
+			expectedErr: currencies.ErrCurrencyExists,
+		},
+		{
+			name:        "LowercaseCode",
+			request:     requests.CreateCurrencyRequest{Code: "jpy", MinorUnits: minorUnits(0), Symbol: "¥"},
+			buildStubs:  func(store *mockdb.MockStore) {},
+			expectedErr: currencies.ErrInvalidCurrencyCode,
+		},
+		{
+			name:        "TooManyMinorUnits",
+			request:     requests.CreateCurrencyRequest{Code: "CLF", MinorUnits: minorUnits(4), Symbol: "UF"},
+			buildStubs:  func(store *mockdb.MockStore) {},
+			expectedErr: currencies.ErrInvalidMinorUnits,
+		},
+		{
+			name:        "SymbolTooLong",
+			request:     requests.CreateCurrencyRequest{Code: "KWD", MinorUnits: minorUnits(3), Symbol: "KuwaitiDinar"},
+			buildStubs:  func(store *mockdb.MockStore) {},
+			expectedErr: currencies.ErrInvalidCurrencySymbol,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			restoreRegistry(t)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			currencyService := NewCurrencyService(testhelpers.NewMockCurrencyRepository(store))
+			response, err := currencyService.CreateCurrency(context.Background(), tc.request)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "JPY", response.Code)
+			require.True(t, currencies.IsSupportedCurrency("JPY"))
+			require.Equal(t, int32(0), currencies.MinorUnits("JPY"))
+		})
+	}
+}
+
+func TestUpdateCurrencyService(t *testing.T) {
+	t.Run("DisableCurrency", func(t *testing.T) {
+		restoreRegistry(t)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().
+			UpdateCurrency(gomock.Any(), db.UpdateCurrencyParams{Code: "NGN", Enabled: pgtype.Bool{Bool: false, Valid: true}}).
+			Return(db.Currency{Code: "NGN", MinorUnits: 2, Symbol: "₦", Enabled: false}, nil).
+			Times(1)
+		store.EXPECT().
+			ListCurrencies(gomock.Any()).
+			Return([]db.Currency{{Code: "NGN", MinorUnits: 2, Symbol: "₦", Enabled: false}}, nil).
+			Times(1)
+
+		enabled := false
+		currencyService := NewCurrencyService(testhelpers.NewMockCurrencyRepository(store))
+		response, err := currencyService.UpdateCurrency(context.Background(), requests.UpdateCurrencyRequest{Code: "NGN", Enabled: &enabled})
+
+		require.NoError(t, err)
+		require.False(t, response.Enabled)
+		require.False(t, currencies.IsSupportedCurrency(currencies.NGN))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockdb.NewMockStore(ctrl)
+		store.EXPECT().UpdateCurrency(gomock.Any(), gomock.Any()).Return(db.Currency{}, pgx.ErrNoRows).Times(1)
+		store.EXPECT().ListCurrencies(gomock.Any()).Times(0)
+
+		symbol := "¥"
+		currencyService := NewCurrencyService(testhelpers.NewMockCurrencyRepository(store))
+		_, err := currencyService.UpdateCurrency(context.Background(), requests.UpdateCurrencyRequest{Code: "JPY", Symbol: &symbol})
+
+		require.ErrorIs(t, err, currencies.ErrCurrencyNotFound)
+	})
+}
+
+func TestRefreshRegistryService_KeepsCurrenciesOnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListCurrencies(gomock.Any()).Return(nil, errors.New("database error")).Times(1)
+
+	currencyService := NewCurrencyService(testhelpers.NewMockCurrencyRepository(store))
+	err := currencyService.RefreshRegistry(context.Background())
+
+	require.Error(t, err)
+	require.True(t, currencies.IsSupportedCurrency(currencies.USD))
+}
@@ -0,0 +1,15 @@
+package currencies
+
+import (
+	"context"
+
+	requests "lemfi/simplebank/internal/apps/currencies/requests"
+	responses "lemfi/simplebank/internal/apps/currencies/responses"
+)
+
+type CurrencyServiceInterface interface {
+	ListCurrencies(ctx context.Context) (responses.ListCurrenciesResponse, error)
+	CreateCurrency(ctx context.Context, payload requests.CreateCurrencyRequest) (responses.CurrencyResponse, error)
+	UpdateCurrency(ctx context.Context, payload requests.UpdateCurrencyRequest) (responses.CurrencyResponse, error)
+	RefreshRegistry(ctx context.Context) error
+}
@@ -0,0 +1,33 @@
+package currencies
+
+import (
+	"context"
+	"time"
+
+	"lemfi/simplebank/config"
+)
+
+// StartRegistryRefresher reloads the currency registry every interval until the context is cancelled,
+// so changes made through another instance are picked up
+func (currencyService *CurrencyService) StartRegistryRefresher(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		config.Logger.Info("Currency registry refresher disabled")
+		return
+	}
+
+	config.Logger.Info("Currency registry refresher started", "refresh_interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			config.Logger.Info("Currency registry refresher stopped")
+			return
+		case <-ticker.C:
+			// Errors are logged by RefreshRegistry, the cached currencies are kept until the next refresh
+			_ = currencyService.RefreshRegistry(ctx)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package currencies
 
+import "strings"
+
 type Currency string
 
 const (
@@ -9,30 +11,46 @@ const (
 	EUR Currency = "EUR"
 )
 
-var SupportedCurrencies = []Currency{USD, NGN, GBP, EUR}
+// defaultCurrencies are supported until the registry is loaded from the currencies table
+var defaultCurrencies = []Definition{
+	{Code: USD, MinorUnits: 2, Symbol: "$", Enabled: true},
+	{Code: NGN, MinorUnits: 2, Symbol: "₦", Enabled: true},
+	{Code: GBP, MinorUnits: 2, Symbol: "£", Enabled: true},
+	{Code: EUR, MinorUnits: 2, Symbol: "€", Enabled: true},
+}
 
-// GetSupportedCurrenciesString returns supported currencies as a comma-separated string
-func GetSupportedCurrenciesString() string {
-	currencies := SupportedCurrencies
-	result := ""
-	for i, currency := range currencies {
-		if i > 0 {
-			result += ", "
+// IsSupportedCurrency reports whether the currency is in the registry and enabled
+func IsSupportedCurrency(currency Currency) bool {
+	definition, ok := Lookup(currency)
+	return ok && definition.Enabled
+}
+
+// SupportedCurrencies returns the enabled currencies sorted by code
+func SupportedCurrencies() []Currency {
+	definitions := List()
+	supported := make([]Currency, 0, len(definitions))
+	for _, definition := range definitions {
+		if definition.Enabled {
+			supported = append(supported, definition.Code)
 		}
-		result += string(currency)
 	}
-	return result
+	return supported
+}
+
+// GetSupportedCurrenciesString returns supported currencies as a comma-separated string
+func GetSupportedCurrenciesString() string {
+	return joinCurrencies(SupportedCurrencies(), ", ")
 }
 
 // GetSupportedCurrenciesForValidation returns supported currencies as a space-separated string for validation tags
 func GetSupportedCurrenciesForValidation() string {
-	currencies := SupportedCurrencies
-	result := ""
+	return joinCurrencies(SupportedCurrencies(), " ")
+}
+
+func joinCurrencies(currencies []Currency, separator string) string {
+	codes := make([]string, len(currencies))
 	for i, currency := range currencies {
-		if i > 0 {
-			result += " "
-		}
-		result += string(currency)
+		codes[i] = string(currency)
 	}
-	return result
+	return strings.Join(codes, separator)
 }
@@ -0,0 +1,48 @@
+package currencies
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	db "lemfi/simplebank/db/sqlc"
+	"lemfi/simplebank/internal/apps/currencies"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// MockCurrencyRepository implements CurrencyRepositoryInterface for testing
+type MockCurrencyRepository struct {
+	store db.Store
+}
+
+func (m *MockCurrencyRepository) ListCurrencies(ctx context.Context) ([]db.Currency, error) {
+	rows, err := m.store.ListCurrencies(ctx)
+	if err != nil {
+		return []db.Currency{}, err
+	}
+
+	return rows, nil
+}
+
+func (m *MockCurrencyRepository) CreateCurrency(ctx context.Context, params db.CreateCurrencyParams) (db.Currency, error) {
+	currency, err := m.store.CreateCurrencyTx(ctx, params)
+	if err != nil && strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
+		return db.Currency{}, currencies.ErrCurrencyExists
+	}
+
+	return currency, err
+}
+
+func (m *MockCurrencyRepository) UpdateCurrency(ctx context.Context, params db.UpdateCurrencyParams) (db.Currency, error) {
+	currency, err := m.store.UpdateCurrency(ctx, params)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.Currency{}, currencies.ErrCurrencyNotFound
+	}
+
+	return currency, err
+}
+
+func NewMockCurrencyRepository(store db.Store) *MockCurrencyRepository {
+	return &MockCurrencyRepository{store: store}
+}
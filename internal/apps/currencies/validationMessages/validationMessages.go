@@ -0,0 +1,9 @@
+package validationMessages
+
+var CreateCurrencyValidationMessages = map[string]string{
+	"Code.required":       "code is required",
+	"MinorUnits.required": "minor_units is required",
+	"Symbol.required":     "symbol is required",
+}
+
+var UpdateCurrencyValidationMessages = map[string]string{}
@@ -0,0 +1,53 @@
+package exchangeRates
+
+import (
+	"net/http"
+
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/internal/apps/core"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	exchangeRateValidation "lemfi/simplebank/internal/apps/exchangeRates/validationMessages"
+	"lemfi/simplebank/pkg/errorResponse"
+	"lemfi/simplebank/pkg/requestHandler"
+	"lemfi/simplebank/pkg/responseHandler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListExchangeRateHistoryController returns the rates a currency pair had over time, newest first
+func (exchangeRateController *ExchangeRateController) ListExchangeRateHistoryController(c *gin.Context) {
+	config.Logger.Info("Listing exchange rate history", "method", "GET", "endpoint", "/exchange-rates/history")
+
+	var req requests.ListExchangeRateHistoryRequest
+
+	err := requestHandler.ReadQueryGin(c, &req, exchangeRateValidation.ListExchangeRateHistoryValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read exchange rate history request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	result, err := exchangeRateController.exchangeRateService.ListExchangeRateHistory(c.Request.Context(), req)
+	if err != nil {
+		config.Logger.Error("Failed to list exchange rate history", "error", err.Error())
+		if clientErr, isClient := core.IsClientError(err); isClient {
+			errorResponse.BadRequestResponse(c, clientErr)
+		} else {
+			errorResponse.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	response := responseHandler.Envelope{
+		"exchange_rate_history": result,
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Exchange rate history response written successfully", "pair", req.Pair, "total", result.Total)
+}
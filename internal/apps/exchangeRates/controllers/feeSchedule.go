@@ -0,0 +1,140 @@
+package exchangeRates
+
+import (
+	"net/http"
+
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/internal/apps/core"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	responses "lemfi/simplebank/internal/apps/exchangeRates/responses"
+	exchangeRateValidation "lemfi/simplebank/internal/apps/exchangeRates/validationMessages"
+	"lemfi/simplebank/pkg/errorResponse"
+	"lemfi/simplebank/pkg/requestHandler"
+	"lemfi/simplebank/pkg/responseHandler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListFeeSchedulesController returns the live fee schedule of every currency pair (admin only)
+func (exchangeRateController *ExchangeRateController) ListFeeSchedulesController(c *gin.Context) {
+	config.Logger.Info("Listing fee schedules", "method", "GET", "endpoint", "/fee-schedules")
+
+	result, err := exchangeRateController.exchangeRateService.ListFeeSchedules(c.Request.Context())
+	if err != nil {
+		writeFeeScheduleError(c, err)
+		return
+	}
+
+	response := responseHandler.Envelope{
+		"fee_schedules": result,
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Fee schedules response written successfully", "total", result.Total)
+}
+
+// GetFeeScheduleController returns the live fee schedule of a currency pair (admin only)
+func (exchangeRateController *ExchangeRateController) GetFeeScheduleController(c *gin.Context) {
+	config.Logger.Info("Getting fee schedule", "method", "GET", "endpoint", "/fee-schedules/:from_currency/:to_currency")
+
+	pair := requests.CurrencyPair{
+		FromCurrency: c.Param("from_currency"),
+		ToCurrency:   c.Param("to_currency"),
+	}
+
+	result, err := exchangeRateController.exchangeRateService.GetFeeSchedule(c.Request.Context(), pair)
+	if err != nil {
+		writeFeeScheduleError(c, err)
+		return
+	}
+
+	writeFeeScheduleResponse(c, result)
+}
+
+// SetFeeScheduleController replaces the fee schedule of a currency pair with a new version (admin only)
+func (exchangeRateController *ExchangeRateController) SetFeeScheduleController(c *gin.Context) {
+	config.Logger.Info("Setting fee schedule", "method", "PUT", "endpoint", "/fee-schedules/:from_currency/:to_currency")
+
+	var req requests.SetFeeScheduleRequest
+
+	err := requestHandler.ReadJSONGin(c, &req, exchangeRateValidation.SetFeeScheduleValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read fee schedule request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	req.FromCurrency = c.Param("from_currency")
+	req.ToCurrency = c.Param("to_currency")
+
+	result, err := exchangeRateController.exchangeRateService.SetFeeSchedule(c.Request.Context(), req)
+	if err != nil {
+		writeFeeScheduleError(c, err)
+		return
+	}
+
+	writeFeeScheduleResponse(c, result)
+}
+
+// DeleteFeeScheduleController retires the fee schedule of a currency pair (admin only)
+func (exchangeRateController *ExchangeRateController) DeleteFeeScheduleController(c *gin.Context) {
+	config.Logger.Info("Deleting fee schedule", "method", "DELETE", "endpoint", "/fee-schedules/:from_currency/:to_currency")
+
+	pair := requests.CurrencyPair{
+		FromCurrency: c.Param("from_currency"),
+		ToCurrency:   c.Param("to_currency"),
+	}
+
+	err := exchangeRateController.exchangeRateService.DeleteFeeSchedule(c.Request.Context(), pair)
+	if err != nil {
+		writeFeeScheduleError(c, err)
+		return
+	}
+
+	response := responseHandler.Envelope{
+		"message": "fee schedule deleted",
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Fee schedule deleted successfully", "from_currency", pair.FromCurrency, "to_currency", pair.ToCurrency)
+}
+
+func writeFeeScheduleResponse(c *gin.Context, schedule responses.FeeScheduleResponse) {
+	response := responseHandler.Envelope{
+		"fee_schedule": schedule,
+	}
+
+	err := responseHandler.WriteJSON(c.Writer, http.StatusOK, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Fee schedule response written successfully",
+		"from_currency", schedule.FromCurrency,
+		"to_currency", schedule.ToCurrency,
+		"version", schedule.Version,
+	)
+}
+
+func writeFeeScheduleError(c *gin.Context, err error) {
+	config.Logger.Error("Fee schedule request failed", "error", err.Error())
+	if clientErr, isClient := core.IsClientError(err); isClient {
+		errorResponse.BadRequestResponse(c, clientErr)
+	} else {
+		errorResponse.ServerErrorResponse(c, err)
+	}
+}
@@ -0,0 +1,117 @@
+package exchangeRates
+
+import (
+	"net/http"
+
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/internal/apps/core"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	exchangeRateValidation "lemfi/simplebank/internal/apps/exchangeRates/validationMessages"
+	"lemfi/simplebank/pkg/errorResponse"
+	"lemfi/simplebank/pkg/requestHandler"
+	"lemfi/simplebank/pkg/responseHandler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListFxMarkupsController returns the markup of every currency pair with its own markup (admin only)
+func (exchangeRateController *ExchangeRateController) ListFxMarkupsController(c *gin.Context) {
+	config.Logger.Info("Listing fx markups", "method", "GET", "endpoint", "/fx-markups")
+
+	result, err := exchangeRateController.exchangeRateService.ListFxMarkups(c.Request.Context())
+	if err != nil {
+		writeFxMarkupError(c, err)
+		return
+	}
+
+	response := responseHandler.Envelope{
+		"fx_markups": result,
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Fx markups response written successfully", "total", result.Total)
+}
+
+// SetFxMarkupController creates or replaces the markup of a currency pair (admin only)
+func (exchangeRateController *ExchangeRateController) SetFxMarkupController(c *gin.Context) {
+	config.Logger.Info("Setting fx markup", "method", "PUT", "endpoint", "/fx-markups/:from_currency/:to_currency")
+
+	var req requests.SetFxMarkupRequest
+
+	err := requestHandler.ReadJSONGin(c, &req, exchangeRateValidation.SetFxMarkupValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read fx markup request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	req.FromCurrency = c.Param("from_currency")
+	req.ToCurrency = c.Param("to_currency")
+
+	result, err := exchangeRateController.exchangeRateService.SetFxMarkup(c.Request.Context(), req)
+	if err != nil {
+		writeFxMarkupError(c, err)
+		return
+	}
+
+	response := responseHandler.Envelope{
+		"fx_markup": result,
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Fx markup set successfully",
+		"from_currency", result.FromCurrency,
+		"to_currency", result.ToCurrency,
+		"markup_bps", result.MarkupBps,
+	)
+}
+
+// DeleteFxMarkupController removes the markup of a currency pair so the default markup applies (admin only)
+func (exchangeRateController *ExchangeRateController) DeleteFxMarkupController(c *gin.Context) {
+	config.Logger.Info("Deleting fx markup", "method", "DELETE", "endpoint", "/fx-markups/:from_currency/:to_currency")
+
+	pair := requests.CurrencyPair{
+		FromCurrency: c.Param("from_currency"),
+		ToCurrency:   c.Param("to_currency"),
+	}
+
+	err := exchangeRateController.exchangeRateService.DeleteFxMarkup(c.Request.Context(), pair)
+	if err != nil {
+		writeFxMarkupError(c, err)
+		return
+	}
+
+	response := responseHandler.Envelope{
+		"message": "fx markup deleted",
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Fx markup deleted successfully", "from_currency", pair.FromCurrency, "to_currency", pair.ToCurrency)
+}
+
+func writeFxMarkupError(c *gin.Context, err error) {
+	config.Logger.Error("Fx markup request failed", "error", err.Error())
+	if clientErr, isClient := core.IsClientError(err); isClient {
+		errorResponse.BadRequestResponse(c, clientErr)
+	} else {
+		errorResponse.ServerErrorResponse(c, err)
+	}
+}
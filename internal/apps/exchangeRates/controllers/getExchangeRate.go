@@ -1,12 +1,15 @@
 package exchangeRates
 
 import (
+	"context"
 	"net/http"
 
 	"lemfi/simplebank/config"
 	"lemfi/simplebank/internal/apps/core"
 	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	responses "lemfi/simplebank/internal/apps/exchangeRates/responses"
 	exchangeRateValidation "lemfi/simplebank/internal/apps/exchangeRates/validationMessages"
+	"lemfi/simplebank/internal/middleware"
 	"lemfi/simplebank/pkg/errorResponse"
 	"lemfi/simplebank/pkg/requestHandler"
 	"lemfi/simplebank/pkg/responseHandler"
@@ -18,6 +21,22 @@ import (
 func (exchangeRateController *ExchangeRateController) GetExchangeRateController(c *gin.Context) {
 	config.Logger.Info("Getting exchange rate for currency pair", "method", "POST", "endpoint", "/exchange-rates/calculate")
 
+	exchangeRateController.priceExchangeRate(c, exchangeRateController.exchangeRateService.GetExchangeRate)
+}
+
+// QuoteExchangeRateController prices a currency pair like GetExchangeRateController and saves the price
+// as a quote for the authenticated user, which a transfer can lock with quote_id
+func (exchangeRateController *ExchangeRateController) QuoteExchangeRateController(c *gin.Context) {
+	config.Logger.Info("Quoting exchange rate for currency pair", "method", "POST", "endpoint", "/exchange-rates/quotes")
+
+	exchangeRateController.priceExchangeRate(c, exchangeRateController.exchangeRateService.QuoteExchangeRate)
+}
+
+// priceExchangeRate reads a conversion request and writes the price returned by the given service method
+func (exchangeRateController *ExchangeRateController) priceExchangeRate(
+	c *gin.Context,
+	price func(ctx context.Context, payload requests.GetExchangeRateRequest) (responses.GetExchangeRateResponse, error),
+) {
 	var req requests.GetExchangeRateRequest
 
 	err := requestHandler.ReadJSONGin(c, &req, exchangeRateValidation.GetExchangeRateValidationMessages)
@@ -27,13 +46,15 @@ func (exchangeRateController *ExchangeRateController) GetExchangeRateController(
 		return
 	}
 
+	req.Username = middleware.ContextGetUser(c).Username
+
 	config.Logger.Info("Exchange rate request validated successfully",
 		"from_currency", req.FromCurrency,
 		"to_currency", req.ToCurrency,
 		"amount", req.Amount.String(),
 	)
 
-	result, err := exchangeRateController.exchangeRateService.GetExchangeRate(c.Request.Context(), req)
+	result, err := price(c.Request.Context(), req)
 	if err != nil {
 		config.Logger.Error("Failed to get exchange rate", "error", err.Error(),
 			"from_currency", req.FromCurrency,
@@ -12,12 +12,14 @@ import (
 	respositories "lemfi/simplebank/internal/apps/exchangeRates/respositories"
 	services "lemfi/simplebank/internal/apps/exchangeRates/services"
 	testhelpers "lemfi/simplebank/internal/apps/exchangeRates/testHelpers"
+	"lemfi/simplebank/internal/middleware"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
@@ -73,6 +75,10 @@ func (m *MockExchangeRateService) GetExchangeRate(ctx context.Context, payload r
 	}, nil
 }
 
+func (m *MockExchangeRateService) QuoteExchangeRate(ctx context.Context, payload requests.GetExchangeRateRequest) (responses.GetExchangeRateResponse, error) {
+	return m.GetExchangeRate(ctx, payload)
+}
+
 func (m *MockExchangeRateService) ListExchangeRates(ctx context.Context) (responses.ListExchangeRatesResponse, error) {
 	rates, err := m.repo.ListExchangeRates(ctx)
 	if err != nil {
@@ -90,6 +96,59 @@ func (m *MockExchangeRateService) ListExchangeRates(ctx context.Context) (respon
 	}, nil
 }
 
+// The methods below do not depend on expiry, so they use the real service logic
+func (m *MockExchangeRateService) CreateExchangeRate(ctx context.Context, payload requests.CreateExchangeRateRequest) (responses.ExchangeRatePairResponse, error) {
+	return services.NewExchangeRateService(m.repo).CreateExchangeRate(ctx, payload)
+}
+
+func (m *MockExchangeRateService) UpdateExchangeRate(ctx context.Context, payload requests.UpdateExchangeRateRequest) (responses.ExchangeRatePairResponse, error) {
+	return services.NewExchangeRateService(m.repo).UpdateExchangeRate(ctx, payload)
+}
+
+func (m *MockExchangeRateService) DeleteExchangeRate(ctx context.Context, payload requests.DeleteExchangeRateRequest) error {
+	return services.NewExchangeRateService(m.repo).DeleteExchangeRate(ctx, payload)
+}
+
+func (m *MockExchangeRateService) ListExchangeRateHistory(ctx context.Context, payload requests.ListExchangeRateHistoryRequest) (responses.ListExchangeRateHistoryResponse, error) {
+	return services.NewExchangeRateService(m.repo).ListExchangeRateHistory(ctx, payload)
+}
+
+func (m *MockExchangeRateService) GetRateRefreshStatus() responses.RateRefreshStatusResponse {
+	return services.NewExchangeRateService(m.repo).GetRateRefreshStatus()
+}
+
+func (m *MockExchangeRateService) CalculateFee(ctx context.Context, fromCurrency, toCurrency string, amount decimal.Decimal) (responses.TransferFee, error) {
+	return services.NewExchangeRateService(m.repo).CalculateFee(ctx, fromCurrency, toCurrency, amount)
+}
+
+func (m *MockExchangeRateService) ListFeeSchedules(ctx context.Context) (responses.ListFeeSchedulesResponse, error) {
+	return services.NewExchangeRateService(m.repo).ListFeeSchedules(ctx)
+}
+
+func (m *MockExchangeRateService) GetFeeSchedule(ctx context.Context, pair requests.CurrencyPair) (responses.FeeScheduleResponse, error) {
+	return services.NewExchangeRateService(m.repo).GetFeeSchedule(ctx, pair)
+}
+
+func (m *MockExchangeRateService) SetFeeSchedule(ctx context.Context, payload requests.SetFeeScheduleRequest) (responses.FeeScheduleResponse, error) {
+	return services.NewExchangeRateService(m.repo).SetFeeSchedule(ctx, payload)
+}
+
+func (m *MockExchangeRateService) DeleteFeeSchedule(ctx context.Context, pair requests.CurrencyPair) error {
+	return services.NewExchangeRateService(m.repo).DeleteFeeSchedule(ctx, pair)
+}
+
+func (m *MockExchangeRateService) ListFxMarkups(ctx context.Context) (responses.ListFxMarkupsResponse, error) {
+	return services.NewExchangeRateService(m.repo).ListFxMarkups(ctx)
+}
+
+func (m *MockExchangeRateService) SetFxMarkup(ctx context.Context, payload requests.SetFxMarkupRequest) (responses.FxMarkupResponse, error) {
+	return services.NewExchangeRateService(m.repo).SetFxMarkup(ctx, payload)
+}
+
+func (m *MockExchangeRateService) DeleteFxMarkup(ctx context.Context, pair requests.CurrencyPair) error {
+	return services.NewExchangeRateService(m.repo).DeleteFxMarkup(ctx, pair)
+}
+
 func TestGetExchangeRateHTTP_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -323,6 +382,8 @@ func TestGetExchangeRateHTTP_ResponseBody(t *testing.T) {
 	}
 
 	store.EXPECT().GetExchangeRate(gomock.Any(), gomock.Any()).Return(expectedRate, nil).Times(1)
+	store.EXPECT().GetActiveFeeSchedule(gomock.Any(), gomock.Any()).Return(db.FeeSchedule{}, pgx.ErrNoRows).Times(1)
+	store.EXPECT().GetFxMarkup(gomock.Any(), gomock.Any()).Return(db.FxMarkup{}, pgx.ErrNoRows).Times(1)
 
 	mockRepo := testhelpers.NewMockExchangeRateRepository(store)
 	exchangeRateService := services.NewExchangeRateService(mockRepo)
@@ -382,3 +443,81 @@ func TestGetExchangeRateHTTP_ResponseBody(t *testing.T) {
 	require.Equal(t, "EUR", exchangeRate["to_currency"])
 	require.Equal(t, "0.85", exchangeRate["rate"])
 }
+
+func TestQuoteExchangeRateHTTP(t *testing.T) {
+	testCases := []struct {
+		name          string
+		url           string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, exchangeRate map[string]interface{})
+	}{
+		{
+			name: "CalculateSavesNoQuote",
+			url:  "/exchange-rates/calculate",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateFxQuote(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, exchangeRate map[string]interface{}) {
+				require.Equal(t, true, exchangeRate["can_transact"])
+				require.NotContains(t, exchangeRate, "quote_id")
+			},
+		},
+		{
+			name: "QuoteIsSavedForTheUser",
+			url:  "/exchange-rates/quotes",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateFxQuote(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ any, arg db.CreateFxQuoteParams) (db.FxQuote, error) {
+						require.Equal(t, "test_owner", arg.Username)
+						return db.FxQuote{ID: 42, ExpiresAt: arg.ExpiresAt}, nil
+					}).Times(1)
+			},
+			checkResponse: func(t *testing.T, exchangeRate map[string]interface{}) {
+				require.Equal(t, float64(42), exchangeRate["quote_id"])
+				require.NotEmpty(t, exchangeRate["quote_expires_at"])
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			// Updated in the future so the rate has not expired whatever the configured expiry
+			store.EXPECT().GetExchangeRate(gomock.Any(), gomock.Any()).Return(db.ExchangeRate{
+				ID:           1,
+				FromCurrency: "USD",
+				ToCurrency:   "EUR",
+				Rate:         decimal.RequireFromString("0.85"),
+				UpdatedAt:    pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+			}, nil).Times(1)
+			store.EXPECT().GetActiveFeeSchedule(gomock.Any(), gomock.Any()).Return(db.FeeSchedule{}, pgx.ErrNoRows).Times(1)
+			store.EXPECT().GetFxMarkup(gomock.Any(), gomock.Any()).Return(db.FxMarkup{}, pgx.ErrNoRows).Times(1)
+			tc.buildStubs(store)
+
+			exchangeRateController := NewExchangeRateController(services.NewExchangeRateService(testhelpers.NewMockExchangeRateRepository(store)))
+
+			router := gin.New()
+			router.POST("/exchange-rates/calculate", exchangeRateController.GetExchangeRateController)
+			router.POST("/exchange-rates/quotes", func(c *gin.Context) {
+				middleware.ContextSetUser(c, &middleware.UserClaimsData{Username: "test_owner"})
+			}, exchangeRateController.QuoteExchangeRateController)
+
+			body, err := json.Marshal(gin.H{"from_currency": "USD", "to_currency": "EUR", "amount": "100.00"})
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodPost, tc.url, bytes.NewBuffer(body))
+			require.NoError(t, err)
+
+			router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			var response map[string]map[string]interface{}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			tc.checkResponse(t, response["exchange_rate"])
+		})
+	}
+}
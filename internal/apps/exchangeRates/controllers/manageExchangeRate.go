@@ -0,0 +1,117 @@
+package exchangeRates
+
+import (
+	"net/http"
+
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/internal/apps/core"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	responses "lemfi/simplebank/internal/apps/exchangeRates/responses"
+	exchangeRateValidation "lemfi/simplebank/internal/apps/exchangeRates/validationMessages"
+	"lemfi/simplebank/pkg/errorResponse"
+	"lemfi/simplebank/pkg/requestHandler"
+	"lemfi/simplebank/pkg/responseHandler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateExchangeRateController creates an exchange rate and its inverse pair (admin only)
+func (exchangeRateController *ExchangeRateController) CreateExchangeRateController(c *gin.Context) {
+	config.Logger.Info("Creating exchange rate", "method", "POST", "endpoint", "/exchange-rates")
+
+	var req requests.CreateExchangeRateRequest
+
+	err := requestHandler.ReadJSONGin(c, &req, exchangeRateValidation.CreateExchangeRateValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read exchange rate request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	result, err := exchangeRateController.exchangeRateService.CreateExchangeRate(c.Request.Context(), req)
+	writeExchangeRatePairResponse(c, http.StatusCreated, result, err)
+}
+
+// UpdateExchangeRateController updates the rate of a currency pair and its inverse pair (admin only)
+func (exchangeRateController *ExchangeRateController) UpdateExchangeRateController(c *gin.Context) {
+	config.Logger.Info("Updating exchange rate", "method", "PUT", "endpoint", "/exchange-rates/:from_currency/:to_currency")
+
+	var req requests.UpdateExchangeRateRequest
+
+	err := requestHandler.ReadJSONGin(c, &req, exchangeRateValidation.UpdateExchangeRateValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read exchange rate request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	req.FromCurrency = c.Param("from_currency")
+	req.ToCurrency = c.Param("to_currency")
+
+	result, err := exchangeRateController.exchangeRateService.UpdateExchangeRate(c.Request.Context(), req)
+	writeExchangeRatePairResponse(c, http.StatusOK, result, err)
+}
+
+// DeleteExchangeRateController deletes a currency pair and its inverse pair (admin only)
+func (exchangeRateController *ExchangeRateController) DeleteExchangeRateController(c *gin.Context) {
+	config.Logger.Info("Deleting exchange rate", "method", "DELETE", "endpoint", "/exchange-rates/:from_currency/:to_currency")
+
+	req := requests.DeleteExchangeRateRequest{
+		FromCurrency: c.Param("from_currency"),
+		ToCurrency:   c.Param("to_currency"),
+	}
+
+	err := exchangeRateController.exchangeRateService.DeleteExchangeRate(c.Request.Context(), req)
+	if err != nil {
+		config.Logger.Error("Failed to delete exchange rate", "error", err.Error())
+		if clientErr, isClient := core.IsClientError(err); isClient {
+			errorResponse.BadRequestResponse(c, clientErr)
+		} else {
+			errorResponse.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	response := responseHandler.Envelope{
+		"message": "exchange rate deleted",
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Exchange rate deleted successfully", "from_currency", req.FromCurrency, "to_currency", req.ToCurrency)
+}
+
+func writeExchangeRatePairResponse(c *gin.Context, status int, pair responses.ExchangeRatePairResponse, err error) {
+	if err != nil {
+		config.Logger.Error("Exchange rate request failed", "error", err.Error())
+		if clientErr, isClient := core.IsClientError(err); isClient {
+			errorResponse.BadRequestResponse(c, clientErr)
+		} else {
+			errorResponse.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	response := responseHandler.Envelope{
+		"exchange_rate":         pair.ExchangeRate,
+		"inverse_exchange_rate": pair.InverseExchangeRate,
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, status, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Exchange rate response written successfully",
+		"from_currency", pair.ExchangeRate.FromCurrency,
+		"to_currency", pair.ExchangeRate.ToCurrency,
+		"rate", pair.ExchangeRate.Rate.String(),
+	)
+}
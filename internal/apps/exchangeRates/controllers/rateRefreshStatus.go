@@ -0,0 +1,29 @@
+package exchangeRates
+
+import (
+	"net/http"
+
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/pkg/errorResponse"
+	"lemfi/simplebank/pkg/responseHandler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetRateRefreshStatusController reports when rates were last refreshed from the rate provider (admin only)
+func (exchangeRateController *ExchangeRateController) GetRateRefreshStatusController(c *gin.Context) {
+	config.Logger.Info("Getting exchange rate refresh status", "method", "GET", "endpoint", "/exchange-rates/refresh-status")
+
+	status := exchangeRateController.exchangeRateService.GetRateRefreshStatus()
+
+	response := responseHandler.Envelope{
+		"refresh_status": status,
+	}
+
+	err := responseHandler.WriteJSON(c.Writer, http.StatusOK, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+}
@@ -23,6 +23,11 @@ var (
 		Status:  400,
 	}
 
+	ErrAmountPrecision = core.ClientError{
+		Message: "amount has more decimal places than the currency allows",
+		Status:  400,
+	}
+
 	ErrExchangeRateExpired = core.ClientError{
 		Message: "exchange rate expired",
 		Status:  400,
@@ -38,3 +43,118 @@ var (
 		Status:  400,
 	}
 )
+
+// Quote errors
+var (
+	ErrQuoteNotFound = core.ClientError{
+		Message: "quote not found",
+		Status:  404,
+	}
+
+	ErrQuoteExpired = core.ClientError{
+		Message: "quote has expired",
+		Status:  400,
+	}
+
+	ErrQuoteAlreadyUsed = core.ClientError{
+		Message: "quote has already been used",
+		Status:  400,
+	}
+
+	ErrQuoteUnavailable = core.ClientError{
+		Message: "quote has expired or has already been used",
+		Status:  400,
+	}
+
+	ErrQuoteMismatch = core.ClientError{
+		Message: "quote does not match the transfer currencies and amount",
+		Status:  400,
+	}
+)
+
+// Exchange rate management errors
+var (
+	ErrExchangeRateExists = core.ClientError{
+		Message: "exchange rate already exists for currency pair",
+		Status:  400,
+	}
+
+	ErrInvalidExchangeRate = core.ClientError{
+		Message: "exchange rate must be positive",
+		Status:  400,
+	}
+
+	ErrExchangeRateOutOfRange = core.ClientError{
+		Message: "exchange rate must be between 0.000001 and 1000000",
+		Status:  400,
+	}
+
+	ErrExchangeRatePrecision = core.ClientError{
+		Message: "exchange rate must not have more than 8 decimal places",
+		Status:  400,
+	}
+)
+
+// Exchange rate history errors
+var (
+	ErrInvalidPairFormat = core.ClientError{
+		Message: "pair must be two currency codes separated by a hyphen, e.g. USD-NGN",
+		Status:  400,
+	}
+
+	ErrInvalidDateRange = core.ClientError{
+		Message: "from must be before to",
+		Status:  400,
+	}
+)
+
+// Fee schedule errors
+var (
+	ErrFeeScheduleNotFound = core.ClientError{
+		Message: "fee schedule not found for currency pair",
+		Status:  404,
+	}
+
+	ErrNegativeFee = core.ClientError{
+		Message: "fees and tier amounts must not be negative",
+		Status:  400,
+	}
+
+	ErrInvalidFeePercentage = core.ClientError{
+		Message: "fee percentage must be between 0 and 100",
+		Status:  400,
+	}
+
+	ErrFeePrecision = core.ClientError{
+		Message: "fees and tier amounts must not have more decimal places than the from currency allows, percentages more than 4",
+		Status:  400,
+	}
+
+	ErrMinFeeAboveMaxFee = core.ClientError{
+		Message: "min_fee must not be greater than max_fee",
+		Status:  400,
+	}
+
+	ErrInvalidFeeTiers = core.ClientError{
+		Message: "fee tiers must have distinct positive min_amount values",
+		Status:  400,
+	}
+
+	ErrTooManyFeeTiers = core.ClientError{
+		Message: "a fee schedule can have at most 20 tiers",
+		Status:  400,
+	}
+)
+
+// FX markup errors
+var (
+	ErrFxMarkupNotFound = core.ClientError{
+		Message: "fx markup not found for currency pair",
+		Status:  404,
+	}
+
+	ErrInvalidMarkup = core.ClientError{
+		Message: "markup_bps must be between 0 and 1000",
+		Status:  400,
+	}
+)
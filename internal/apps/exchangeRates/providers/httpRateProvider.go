@@ -0,0 +1,77 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// maxRateResponseSize caps how much of a provider response is read
+const maxRateResponseSize = 1 << 20
+
+// HTTPRateProvider fetches rates from a JSON endpoint called as GET <url>?base=USD, answering
+//
+//	{"base": "USD", "rates": {"EUR": "0.85", "NGN": 1500}}
+//
+// Rates may be JSON strings or numbers
+type HTTPRateProvider struct {
+	url    string
+	client *http.Client
+}
+
+type httpRatesResponse struct {
+	Base  string                     `json:"base"`
+	Rates map[string]decimal.Decimal `json:"rates"`
+}
+
+func NewHTTPRateProvider(providerURL string, timeout time.Duration) *HTTPRateProvider {
+	return &HTTPRateProvider{
+		url:    providerURL,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (provider *HTTPRateProvider) FetchRates(ctx context.Context, base string) (map[string]decimal.Decimal, error) {
+	requestURL, err := url.Parse(provider.url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate provider url: %w", err)
+	}
+
+	query := requestURL.Query()
+	query.Set("base", base)
+	requestURL.RawQuery = query.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, err := provider.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("rate provider request failed: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rate provider returned status %d for base %s", response.StatusCode, base)
+	}
+
+	var body httpRatesResponse
+	err = json.NewDecoder(io.LimitReader(response.Body, maxRateResponseSize)).Decode(&body)
+	if err != nil {
+		return nil, fmt.Errorf("rate provider returned an invalid body: %w", err)
+	}
+
+	if body.Base != base {
+		return nil, fmt.Errorf("rate provider returned rates for base %q, expected %q", body.Base, base)
+	}
+
+	return body.Rates, nil
+}
@@ -0,0 +1,82 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+// newStubRateServer answers like a rate provider, quoting every base against EUR and NGN
+func newStubRateServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/latest" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"base": %q, "rates": {"EUR": "0.85", "NGN": 1500.5}}`, r.URL.Query().Get("base"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPRateProviderFetchRates(t *testing.T) {
+	server := newStubRateServer(t)
+	provider := NewHTTPRateProvider(server.URL+"/latest", time.Second)
+
+	rates, err := provider.FetchRates(context.Background(), "USD")
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	require.True(t, rates["EUR"].Equal(decimal.RequireFromString("0.85")))
+	require.True(t, rates["NGN"].Equal(decimal.RequireFromString("1500.5")))
+}
+
+func TestHTTPRateProviderErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			name: "ServerError",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+		},
+		{
+			name: "InvalidBody",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"base": "USD", "rates": {"EUR": "not-a-rate"}}`)
+			},
+		},
+		{
+			name: "WrongBase",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"base": "GBP", "rates": {"EUR": "1.17"}}`)
+			},
+		},
+		{
+			name: "Timeout",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(200 * time.Millisecond)
+				fmt.Fprint(w, `{"base": "USD", "rates": {}}`)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+
+			provider := NewHTTPRateProvider(server.URL, 50*time.Millisecond)
+
+			_, err := provider.FetchRates(context.Background(), "USD")
+			require.Error(t, err)
+		})
+	}
+}
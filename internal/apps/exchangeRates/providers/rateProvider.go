@@ -0,0 +1,13 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+// RateProvider fetches live exchange rates from a source outside the bank
+type RateProvider interface {
+	// FetchRates returns how much of each quoted currency one unit of the base currency buys
+	FetchRates(ctx context.Context, base string) (map[string]decimal.Decimal, error)
+}
@@ -0,0 +1,16 @@
+package exchangeRates
+
+import "time"
+
+type ListExchangeRateHistoryRequest struct {
+	Pair  string    `form:"pair" validate:"required"` // FROM-TO, e.g. USD-NGN
+	From  time.Time `form:"from"`                     // RFC 3339, inclusive
+	To    time.Time `form:"to"`                       // RFC 3339, exclusive
+	Limit int32     `form:"limit" validate:"omitempty,min=1,max=1000"`
+}
+
+// CurrencyPair identifies the direction of an exchange rate
+type CurrencyPair struct {
+	FromCurrency string
+	ToCurrency   string
+}
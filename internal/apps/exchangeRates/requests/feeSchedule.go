@@ -0,0 +1,22 @@
+package exchangeRates
+
+import "github.com/shopspring/decimal"
+
+// SetFeeScheduleRequest replaces the fee schedule of a currency pair with a new version.
+// The fee is fixed_fee plus percentage of the amount sent, kept between min_fee and max_fee.
+// A tier replaces fixed_fee and percentage for amounts from its min_amount upwards
+type SetFeeScheduleRequest struct {
+	FromCurrency string           `json:"-"` // Set from the path
+	ToCurrency   string           `json:"-"` // Set from the path
+	FixedFee     decimal.Decimal  `json:"fixed_fee"`
+	Percentage   decimal.Decimal  `json:"percentage"` // 1.5 is 1.5%
+	MinFee       decimal.Decimal  `json:"min_fee"`
+	MaxFee       *decimal.Decimal `json:"max_fee"` // Omit for no cap
+	Tiers        []FeeTierRequest `json:"tiers" validate:"max=20"`
+}
+
+type FeeTierRequest struct {
+	MinAmount  decimal.Decimal `json:"min_amount"`
+	FixedFee   decimal.Decimal `json:"fixed_fee"`
+	Percentage decimal.Decimal `json:"percentage"`
+}
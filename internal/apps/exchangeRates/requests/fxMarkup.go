@@ -0,0 +1,8 @@
+package exchangeRates
+
+// SetFxMarkupRequest sets the markup customers pay on top of the mid-market rate of a currency pair
+type SetFxMarkupRequest struct {
+	FromCurrency string `json:"-"`                              // Set from the path
+	ToCurrency   string `json:"-"`                              // Set from the path
+	MarkupBps    *int32 `json:"markup_bps" validate:"required"` // Basis points, 100 is 1%
+}
@@ -6,4 +6,5 @@ type GetExchangeRateRequest struct {
 	FromCurrency string          `json:"from_currency" validate:"required"`
 	ToCurrency   string          `json:"to_currency" validate:"required"`
 	Amount       decimal.Decimal `json:"amount" validate:"required"`
+	Username     string          `json:"-"` // Set from the authenticated user when a quote is requested, the quote is issued to them
 }
@@ -0,0 +1,20 @@
+package exchangeRates
+
+import "github.com/shopspring/decimal"
+
+type CreateExchangeRateRequest struct {
+	FromCurrency string          `json:"from_currency" validate:"required"`
+	ToCurrency   string          `json:"to_currency" validate:"required"`
+	Rate         decimal.Decimal `json:"rate" validate:"required"`
+}
+
+type UpdateExchangeRateRequest struct {
+	FromCurrency string          `json:"-"` // Set from the path
+	ToCurrency   string          `json:"-"` // Set from the path
+	Rate         decimal.Decimal `json:"rate" validate:"required"`
+}
+
+type DeleteExchangeRateRequest struct {
+	FromCurrency string
+	ToCurrency   string
+}
@@ -16,6 +16,9 @@ type ExchangeRateResponse struct {
 	CreatedAt    time.Time       `json:"created_at"`
 	UpdatedAt    time.Time       `json:"updated_at"`
 	ExpiredAt    time.Time       `json:"expired_at"`
+	// Derived rates have no direct pair, they are composed from the Legs through the pivot currency
+	Derived bool                   `json:"derived"`
+	Legs    []ExchangeRateResponse `json:"legs,omitempty"`
 }
 
 type ListExchangeRatesResponse struct {
@@ -24,13 +27,20 @@ type ListExchangeRatesResponse struct {
 }
 
 type GetExchangeRateResponse struct {
-	ExchangeRate    ExchangeRateResponse `json:"exchange_rate"`
+	ExchangeRate    ExchangeRateResponse `json:"exchange_rate"` // Mid-market rate
+	CustomerRate    decimal.Decimal      `json:"customer_rate"` // Mid-market rate less the markup, amount_to_receive is priced with it
+	MarkupBps       int32                `json:"markup_bps"`    // Markup in basis points, 100 is 1%
 	AmountToSend    decimal.Decimal      `json:"amount_to_send"`
 	AmountToReceive decimal.Decimal      `json:"amount_to_receive"`
 	Fee             decimal.Decimal      `json:"fee"`
 	TotalAmount     decimal.Decimal      `json:"total_amount"` // amount_to_send + fee
 	CanTransact     bool                 `json:"can_transact"`
 	Message         string               `json:"message"`
+	QuoteID         int64                `json:"quote_id,omitempty"`         // Pass as quote_id to POST /transfers to lock the rate
+	QuoteExpiresAt  *time.Time           `json:"quote_expires_at,omitempty"` // The quote is honoured until then
+	// Fee schedule version the fee was priced with, omitted when the default fee applies
+	FeeScheduleID      int64 `json:"fee_schedule_id,omitempty"`
+	FeeScheduleVersion int32 `json:"fee_schedule_version,omitempty"`
 }
 
 // NewExchangeRateResponse creates a new ExchangeRateResponse with calculated expired time
@@ -48,3 +58,64 @@ func NewExchangeRateResponse(dbExchangeRate db.ExchangeRate) ExchangeRateRespons
 		ExpiredAt:    expiredAt,
 	}
 }
+
+// PivotRate returns the legs of a derived rate as stored on quotes and transfers, nil for a direct rate
+func (exchangeRate ExchangeRateResponse) PivotRate() *db.PivotRate {
+	if !exchangeRate.Derived || len(exchangeRate.Legs) != 2 {
+		return nil
+	}
+
+	return &db.PivotRate{
+		Currency: exchangeRate.Legs[0].ToCurrency,
+		FromRate: exchangeRate.Legs[0].Rate,
+		ToRate:   exchangeRate.Legs[1].Rate,
+	}
+}
+
+// ExchangeRatePairResponse is returned when an admin sets a rate, the inverse pair is kept in step
+type ExchangeRatePairResponse struct {
+	ExchangeRate        ExchangeRateResponse `json:"exchange_rate"`
+	InverseExchangeRate ExchangeRateResponse `json:"inverse_exchange_rate"`
+}
+
+// NewExchangeRatePairResponse creates an ExchangeRatePairResponse from the stored pair
+func NewExchangeRatePairResponse(pair db.ExchangeRatePairTxResult) ExchangeRatePairResponse {
+	return ExchangeRatePairResponse{
+		ExchangeRate:        NewExchangeRateResponse(pair.ExchangeRate),
+		InverseExchangeRate: NewExchangeRateResponse(pair.InverseExchangeRate),
+	}
+}
+
+// ExchangeRateHistoryResponse is a rate that was live from ValidFrom until the next entry for the pair
+type ExchangeRateHistoryResponse struct {
+	ID           int64           `json:"id"`
+	FromCurrency string          `json:"from_currency"`
+	ToCurrency   string          `json:"to_currency"`
+	Rate         decimal.Decimal `json:"rate"`
+	ValidFrom    time.Time       `json:"valid_from"`
+}
+
+type ListExchangeRateHistoryResponse struct {
+	History []ExchangeRateHistoryResponse `json:"history"`
+	Total   int                           `json:"total"`
+}
+
+// NewExchangeRateHistoryResponse creates an ExchangeRateHistoryResponse from a history row
+func NewExchangeRateHistoryResponse(history db.ExchangeRateHistory) ExchangeRateHistoryResponse {
+	return ExchangeRateHistoryResponse{
+		ID:           history.ID,
+		FromCurrency: history.FromCurrency,
+		ToCurrency:   history.ToCurrency,
+		Rate:         history.Rate,
+		ValidFrom:    history.ValidFrom,
+	}
+}
+
+// RateRefreshStatusResponse describes the background refresh of rates from the rate provider
+type RateRefreshStatusResponse struct {
+	Enabled                 bool       `json:"enabled"`
+	RefreshInterval         string     `json:"refresh_interval,omitempty"`
+	LastSuccessfulRefreshAt *time.Time `json:"last_successful_refresh_at"`
+	LastError               string     `json:"last_error,omitempty"`
+	ConsecutiveFailures     int        `json:"consecutive_failures"`
+}
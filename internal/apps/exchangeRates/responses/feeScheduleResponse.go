@@ -0,0 +1,69 @@
+package responses
+
+import (
+	db "lemfi/simplebank/db/sqlc"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type FeeScheduleResponse struct {
+	ID           int64             `json:"id"`
+	FromCurrency string            `json:"from_currency"`
+	ToCurrency   string            `json:"to_currency"`
+	Version      int32             `json:"version"`
+	FixedFee     decimal.Decimal   `json:"fixed_fee"`
+	Percentage   decimal.Decimal   `json:"percentage"`
+	MinFee       decimal.Decimal   `json:"min_fee"`
+	MaxFee       *decimal.Decimal  `json:"max_fee"` // null when the fee is not capped
+	Tiers        []FeeTierResponse `json:"tiers"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+type FeeTierResponse struct {
+	MinAmount  decimal.Decimal `json:"min_amount"`
+	FixedFee   decimal.Decimal `json:"fixed_fee"`
+	Percentage decimal.Decimal `json:"percentage"`
+}
+
+type ListFeeSchedulesResponse struct {
+	FeeSchedules []FeeScheduleResponse `json:"fee_schedules"`
+	Total        int                   `json:"total"`
+}
+
+// TransferFee is the fee charged for sending an amount and the fee schedule version it was priced with
+type TransferFee struct {
+	Fee                decimal.Decimal `json:"fee"`
+	FeeScheduleID      int64           `json:"fee_schedule_id,omitempty"`      // 0 when the default fee applies
+	FeeScheduleVersion int32           `json:"fee_schedule_version,omitempty"` // 0 when the default fee applies
+}
+
+// NewFeeScheduleResponse creates a FeeScheduleResponse from a schedule version and its tiers
+func NewFeeScheduleResponse(schedule db.FeeScheduleTxResult) FeeScheduleResponse {
+	response := FeeScheduleResponse{
+		ID:           schedule.FeeSchedule.ID,
+		FromCurrency: schedule.FeeSchedule.FromCurrency,
+		ToCurrency:   schedule.FeeSchedule.ToCurrency,
+		Version:      schedule.FeeSchedule.Version,
+		FixedFee:     schedule.FeeSchedule.FixedFee,
+		Percentage:   schedule.FeeSchedule.Percentage,
+		MinFee:       schedule.FeeSchedule.MinFee,
+		Tiers:        make([]FeeTierResponse, 0, len(schedule.Tiers)),
+		CreatedAt:    schedule.FeeSchedule.CreatedAt,
+	}
+
+	if schedule.FeeSchedule.MaxFee.Valid {
+		maxFee := schedule.FeeSchedule.MaxFee.Decimal
+		response.MaxFee = &maxFee
+	}
+
+	for _, tier := range schedule.Tiers {
+		response.Tiers = append(response.Tiers, FeeTierResponse{
+			MinAmount:  tier.MinAmount,
+			FixedFee:   tier.FixedFee,
+			Percentage: tier.Percentage,
+		})
+	}
+
+	return response
+}
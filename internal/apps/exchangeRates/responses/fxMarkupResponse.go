@@ -0,0 +1,28 @@
+package responses
+
+import (
+	db "lemfi/simplebank/db/sqlc"
+	"time"
+)
+
+type FxMarkupResponse struct {
+	FromCurrency string    `json:"from_currency"`
+	ToCurrency   string    `json:"to_currency"`
+	MarkupBps    int32     `json:"markup_bps"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type ListFxMarkupsResponse struct {
+	FxMarkups        []FxMarkupResponse `json:"fx_markups"`
+	Total            int                `json:"total"`
+	DefaultMarkupBps int32              `json:"default_markup_bps"` // Applies to pairs without their own markup
+}
+
+func NewFxMarkupResponse(markup db.FxMarkup) FxMarkupResponse {
+	return FxMarkupResponse{
+		FromCurrency: markup.FromCurrency,
+		ToCurrency:   markup.ToCurrency,
+		MarkupBps:    markup.MarkupBps,
+		UpdatedAt:    markup.UpdatedAt,
+	}
+}
@@ -0,0 +1,26 @@
+package exchangeRates
+
+import (
+	"context"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+)
+
+func (exchangeRateRepository *ExchangeRateRepository) CreateQuote(ctx context.Context, params db.CreateFxQuoteParams) (db.FxQuote, error) {
+	config.Logger.Info("Creating exchange rate quote",
+		"username", params.Username,
+		"from_currency", params.FromCurrency,
+		"to_currency", params.ToCurrency,
+		"rate", params.Rate.String(),
+		"expires_at", params.ExpiresAt,
+	)
+
+	quote, err := exchangeRateRepository.queries.CreateFxQuote(ctx, params)
+	if err != nil {
+		config.Logger.Error("Failed to create exchange rate quote", "error", err.Error(), "username", params.Username)
+		return db.FxQuote{}, err
+	}
+
+	return quote, nil
+}
@@ -0,0 +1,55 @@
+package exchangeRates
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (exchangeRateRepository *ExchangeRateRepository) ListExchangeRateHistory(ctx context.Context, params db.ListExchangeRateHistoryParams) ([]db.ExchangeRateHistory, error) {
+	config.Logger.Info("Listing exchange rate history",
+		"from_currency", params.FromCurrency,
+		"to_currency", params.ToCurrency,
+		"start_date", params.StartDate.Time,
+		"end_date", params.EndDate.Time,
+	)
+
+	history, err := exchangeRateRepository.queries.ListExchangeRateHistory(ctx, params)
+	if err != nil {
+		config.Logger.Error("Failed to list exchange rate history", "error", err.Error())
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// GetRateAsOf returns the rate of the pair that was live at the given time
+func (exchangeRateRepository *ExchangeRateRepository) GetRateAsOf(ctx context.Context, pair requests.CurrencyPair, asOf time.Time) (db.ExchangeRateHistory, error) {
+	history, err := exchangeRateRepository.queries.GetExchangeRateAsOf(ctx, db.GetExchangeRateAsOfParams{
+		FromCurrency: pair.FromCurrency,
+		ToCurrency:   pair.ToCurrency,
+		AsOf:         asOf,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.ExchangeRateHistory{}, exchangeRateErrors.ErrExchangeRateNotFound
+		}
+
+		config.Logger.Error("Failed to get exchange rate as of time",
+			"from_currency", pair.FromCurrency,
+			"to_currency", pair.ToCurrency,
+			"as_of", asOf,
+			"error", err.Error(),
+		)
+		return db.ExchangeRateHistory{}, err
+	}
+
+	return history, nil
+}
@@ -0,0 +1,59 @@
+package exchangeRates
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetRateAsOf(t *testing.T) {
+	asOf := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	pair := requests.CurrencyPair{FromCurrency: "GBP", ToCurrency: "NGN"}
+
+	testCases := []struct {
+		name        string
+		stubErr     error
+		expectedErr error
+	}{
+		{name: "OK"},
+		{name: "NoRateYet", stubErr: pgx.ErrNoRows, expectedErr: exchangeRateErrors.ErrExchangeRateNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetExchangeRateAsOf(gomock.Any(), gomock.Eq(db.GetExchangeRateAsOfParams{
+					FromCurrency: "GBP",
+					ToCurrency:   "NGN",
+					AsOf:         asOf,
+				})).
+				Return(db.ExchangeRateHistory{ID: 7, Rate: decimal.RequireFromString("2000")}, tc.stubErr).
+				Times(1)
+
+			repository := &ExchangeRateRepository{context: context.Background(), queries: store}
+
+			history, err := repository.GetRateAsOf(context.Background(), pair, asOf)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, int64(7), history.ID)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package exchangeRates
+
+import (
+	"context"
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetActiveFeeSchedule returns the live fee schedule version of the pair with its tiers
+func (exchangeRateRepository *ExchangeRateRepository) GetActiveFeeSchedule(ctx context.Context, pair requests.CurrencyPair) (db.FeeScheduleTxResult, error) {
+	schedule, err := exchangeRateRepository.queries.GetActiveFeeSchedule(ctx, db.GetActiveFeeScheduleParams{
+		FromCurrency: pair.FromCurrency,
+		ToCurrency:   pair.ToCurrency,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.FeeScheduleTxResult{}, exchangeRateErrors.ErrFeeScheduleNotFound
+		}
+
+		config.Logger.Error("Failed to get fee schedule",
+			"from_currency", pair.FromCurrency,
+			"to_currency", pair.ToCurrency,
+			"error", err.Error(),
+		)
+		return db.FeeScheduleTxResult{}, err
+	}
+
+	tiers, err := exchangeRateRepository.queries.ListFeeScheduleTiers(ctx, []int64{schedule.ID})
+	if err != nil {
+		config.Logger.Error("Failed to list fee schedule tiers", "fee_schedule_id", schedule.ID, "error", err.Error())
+		return db.FeeScheduleTxResult{}, err
+	}
+
+	return db.FeeScheduleTxResult{FeeSchedule: schedule, Tiers: tiers}, nil
+}
+
+// ListFeeSchedules returns the live fee schedule version of every pair with its tiers
+func (exchangeRateRepository *ExchangeRateRepository) ListFeeSchedules(ctx context.Context) ([]db.FeeScheduleTxResult, error) {
+	config.Logger.Info("Listing fee schedules")
+
+	schedules, err := exchangeRateRepository.queries.ListActiveFeeSchedules(ctx)
+	if err != nil {
+		config.Logger.Error("Failed to list fee schedules", "error", err.Error())
+		return nil, err
+	}
+
+	return exchangeRateRepository.withFeeScheduleTiers(ctx, schedules)
+}
+
+// withFeeScheduleTiers loads the tiers of all the schedules in one query
+func (exchangeRateRepository *ExchangeRateRepository) withFeeScheduleTiers(ctx context.Context, schedules []db.FeeSchedule) ([]db.FeeScheduleTxResult, error) {
+	results := make([]db.FeeScheduleTxResult, 0, len(schedules))
+	if len(schedules) == 0 {
+		return results, nil
+	}
+
+	ids := make([]int64, 0, len(schedules))
+	for _, schedule := range schedules {
+		ids = append(ids, schedule.ID)
+	}
+
+	tiers, err := exchangeRateRepository.queries.ListFeeScheduleTiers(ctx, ids)
+	if err != nil {
+		config.Logger.Error("Failed to list fee schedule tiers", "error", err.Error())
+		return nil, err
+	}
+
+	tiersBySchedule := make(map[int64][]db.FeeScheduleTier, len(schedules))
+	for _, tier := range tiers {
+		tiersBySchedule[tier.FeeScheduleID] = append(tiersBySchedule[tier.FeeScheduleID], tier)
+	}
+
+	for _, schedule := range schedules {
+		results = append(results, db.FeeScheduleTxResult{
+			FeeSchedule: schedule,
+			Tiers:       tiersBySchedule[schedule.ID],
+		})
+	}
+
+	return results, nil
+}
+
+func (exchangeRateRepository *ExchangeRateRepository) SetFeeSchedule(ctx context.Context, params db.SetFeeScheduleTxParams) (db.FeeScheduleTxResult, error) {
+	config.Logger.Info("Setting fee schedule",
+		"from_currency", params.FromCurrency,
+		"to_currency", params.ToCurrency,
+		"fixed_fee", params.FixedFee.String(),
+		"percentage", params.Percentage.String(),
+		"tiers", len(params.Tiers),
+	)
+
+	result, err := exchangeRateRepository.queries.SetFeeScheduleTx(ctx, params)
+	if err != nil {
+		config.Logger.Error("Failed to set fee schedule", "error", err.Error())
+		return db.FeeScheduleTxResult{}, err
+	}
+
+	return result, nil
+}
+
+// DeleteFeeSchedule retires the live fee schedule of the pair, past versions are kept for the transfers priced with them
+func (exchangeRateRepository *ExchangeRateRepository) DeleteFeeSchedule(ctx context.Context, pair requests.CurrencyPair) error {
+	config.Logger.Info("Deleting fee schedule",
+		"from_currency", pair.FromCurrency,
+		"to_currency", pair.ToCurrency,
+	)
+
+	_, err := exchangeRateRepository.queries.DeactivateFeeSchedule(ctx, db.DeactivateFeeScheduleParams{
+		FromCurrency: pair.FromCurrency,
+		ToCurrency:   pair.ToCurrency,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return exchangeRateErrors.ErrFeeScheduleNotFound
+		}
+
+		config.Logger.Error("Failed to delete fee schedule", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
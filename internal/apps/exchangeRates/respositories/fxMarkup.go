@@ -0,0 +1,84 @@
+package exchangeRates
+
+import (
+	"context"
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (exchangeRateRepository *ExchangeRateRepository) GetFxMarkup(ctx context.Context, pair requests.CurrencyPair) (db.FxMarkup, error) {
+	markup, err := exchangeRateRepository.queries.GetFxMarkup(ctx, db.GetFxMarkupParams{
+		FromCurrency: pair.FromCurrency,
+		ToCurrency:   pair.ToCurrency,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.FxMarkup{}, exchangeRateErrors.ErrFxMarkupNotFound
+		}
+
+		config.Logger.Error("Failed to get fx markup",
+			"from_currency", pair.FromCurrency,
+			"to_currency", pair.ToCurrency,
+			"error", err.Error(),
+		)
+		return db.FxMarkup{}, err
+	}
+
+	return markup, nil
+}
+
+func (exchangeRateRepository *ExchangeRateRepository) ListFxMarkups(ctx context.Context) ([]db.FxMarkup, error) {
+	config.Logger.Info("Listing fx markups")
+
+	markups, err := exchangeRateRepository.queries.ListFxMarkups(ctx)
+	if err != nil {
+		config.Logger.Error("Failed to list fx markups", "error", err.Error())
+		return nil, err
+	}
+
+	return markups, nil
+}
+
+func (exchangeRateRepository *ExchangeRateRepository) SetFxMarkup(ctx context.Context, params db.UpsertFxMarkupParams) (db.FxMarkup, error) {
+	config.Logger.Info("Setting fx markup",
+		"from_currency", params.FromCurrency,
+		"to_currency", params.ToCurrency,
+		"markup_bps", params.MarkupBps,
+	)
+
+	markup, err := exchangeRateRepository.queries.UpsertFxMarkup(ctx, params)
+	if err != nil {
+		config.Logger.Error("Failed to set fx markup", "error", err.Error())
+		return db.FxMarkup{}, err
+	}
+
+	return markup, nil
+}
+
+func (exchangeRateRepository *ExchangeRateRepository) DeleteFxMarkup(ctx context.Context, pair requests.CurrencyPair) error {
+	config.Logger.Info("Deleting fx markup",
+		"from_currency", pair.FromCurrency,
+		"to_currency", pair.ToCurrency,
+	)
+
+	deleted, err := exchangeRateRepository.queries.DeleteFxMarkup(ctx, db.DeleteFxMarkupParams{
+		FromCurrency: pair.FromCurrency,
+		ToCurrency:   pair.ToCurrency,
+	})
+	if err != nil {
+		config.Logger.Error("Failed to delete fx markup", "error", err.Error())
+		return err
+	}
+
+	if deleted == 0 {
+		return exchangeRateErrors.ErrFxMarkupNotFound
+	}
+
+	return nil
+}
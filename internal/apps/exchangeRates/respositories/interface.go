@@ -2,6 +2,7 @@ package exchangeRates
 
 import (
 	"context"
+	"time"
 
 	db "lemfi/simplebank/db/sqlc"
 	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
@@ -10,4 +11,19 @@ import (
 type ExchangeRateRepositoryInterface interface {
 	ListExchangeRates(ctx context.Context) ([]db.ExchangeRate, error)
 	GetExchangeRate(ctx context.Context, payload requests.GetExchangeRateRequest) (db.ExchangeRate, error)
+	CreateQuote(ctx context.Context, params db.CreateFxQuoteParams) (db.FxQuote, error)
+	CreateExchangeRate(ctx context.Context, params db.ExchangeRatePairTxParams) (db.ExchangeRatePairTxResult, error)
+	UpdateExchangeRate(ctx context.Context, params db.ExchangeRatePairTxParams) (db.ExchangeRatePairTxResult, error)
+	DeleteExchangeRate(ctx context.Context, params db.DeleteExchangeRateParams) error
+	RefreshExchangeRates(ctx context.Context, params []db.UpsertExchangeRateParams) ([]db.ExchangeRate, error)
+	ListExchangeRateHistory(ctx context.Context, params db.ListExchangeRateHistoryParams) ([]db.ExchangeRateHistory, error)
+	GetRateAsOf(ctx context.Context, pair requests.CurrencyPair, asOf time.Time) (db.ExchangeRateHistory, error)
+	GetActiveFeeSchedule(ctx context.Context, pair requests.CurrencyPair) (db.FeeScheduleTxResult, error)
+	ListFeeSchedules(ctx context.Context) ([]db.FeeScheduleTxResult, error)
+	SetFeeSchedule(ctx context.Context, params db.SetFeeScheduleTxParams) (db.FeeScheduleTxResult, error)
+	DeleteFeeSchedule(ctx context.Context, pair requests.CurrencyPair) error
+	GetFxMarkup(ctx context.Context, pair requests.CurrencyPair) (db.FxMarkup, error)
+	ListFxMarkups(ctx context.Context) ([]db.FxMarkup, error)
+	SetFxMarkup(ctx context.Context, params db.UpsertFxMarkupParams) (db.FxMarkup, error)
+	DeleteFxMarkup(ctx context.Context, pair requests.CurrencyPair) error
 }
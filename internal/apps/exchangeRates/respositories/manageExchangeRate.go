@@ -0,0 +1,90 @@
+package exchangeRates
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (exchangeRateRepository *ExchangeRateRepository) CreateExchangeRate(ctx context.Context, params db.ExchangeRatePairTxParams) (db.ExchangeRatePairTxResult, error) {
+	config.Logger.Info("Creating exchange rate and its inverse pair",
+		"from_currency", params.FromCurrency,
+		"to_currency", params.ToCurrency,
+		"rate", params.Rate.String(),
+		"inverse_rate", params.InverseRate.String(),
+	)
+
+	result, err := exchangeRateRepository.queries.CreateExchangeRateTx(ctx, params)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
+			config.Logger.Error("Duplicate exchange rate creation attempted",
+				"from_currency", params.FromCurrency,
+				"to_currency", params.ToCurrency,
+			)
+			return db.ExchangeRatePairTxResult{}, exchangeRateErrors.ErrExchangeRateExists
+		}
+
+		config.Logger.Error("Failed to create exchange rate", "error", err.Error())
+		return db.ExchangeRatePairTxResult{}, err
+	}
+
+	return result, nil
+}
+
+func (exchangeRateRepository *ExchangeRateRepository) UpdateExchangeRate(ctx context.Context, params db.ExchangeRatePairTxParams) (db.ExchangeRatePairTxResult, error) {
+	config.Logger.Info("Updating exchange rate and its inverse pair",
+		"from_currency", params.FromCurrency,
+		"to_currency", params.ToCurrency,
+		"rate", params.Rate.String(),
+		"inverse_rate", params.InverseRate.String(),
+	)
+
+	result, err := exchangeRateRepository.queries.UpdateExchangeRateTx(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.ExchangeRatePairTxResult{}, exchangeRateErrors.ErrExchangeRateNotFound
+		}
+
+		config.Logger.Error("Failed to update exchange rate", "error", err.Error())
+		return db.ExchangeRatePairTxResult{}, err
+	}
+
+	return result, nil
+}
+
+func (exchangeRateRepository *ExchangeRateRepository) DeleteExchangeRate(ctx context.Context, params db.DeleteExchangeRateParams) error {
+	config.Logger.Info("Deleting exchange rate and its inverse pair",
+		"from_currency", params.FromCurrency,
+		"to_currency", params.ToCurrency,
+	)
+
+	err := exchangeRateRepository.queries.DeleteExchangeRateTx(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return exchangeRateErrors.ErrExchangeRateNotFound
+		}
+
+		config.Logger.Error("Failed to delete exchange rate", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (exchangeRateRepository *ExchangeRateRepository) RefreshExchangeRates(ctx context.Context, params []db.UpsertExchangeRateParams) ([]db.ExchangeRate, error) {
+	config.Logger.Info("Refreshing exchange rates", "pairs", len(params))
+
+	rates, err := exchangeRateRepository.queries.RefreshExchangeRatesTx(ctx, params)
+	if err != nil {
+		config.Logger.Error("Failed to refresh exchange rates", "error", err.Error())
+		return nil, err
+	}
+
+	return rates, nil
+}
@@ -4,16 +4,61 @@ import (
 	controllers "lemfi/simplebank/internal/apps/exchangeRates/controllers"
 	respositories "lemfi/simplebank/internal/apps/exchangeRates/respositories"
 	services "lemfi/simplebank/internal/apps/exchangeRates/services"
+	"lemfi/simplebank/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Routes defines the exchange rate routes for the API.
-func Routes(router *gin.Engine) {
+func Routes(router *gin.Engine, authUsers middleware.PasswordChangeGetter) {
 	exchangeRateRepository := respositories.NewExchangeRateRepository()
 	exchangeRateService := services.NewExchangeRateService(exchangeRateRepository)
 	exchangeRateController := controllers.NewExchangeRateController(exchangeRateService)
 
 	router.GET("/api/v1/exchange-rates", exchangeRateController.ListExchangeRatesController)
+	router.GET("/api/v1/exchange-rates/history", exchangeRateController.ListExchangeRateHistoryController)
+
 	router.POST("/api/v1/exchange-rates/calculate", exchangeRateController.GetExchangeRateController)
+
+	// A quote is saved for the user who asked for it, so it requires authentication
+	router.POST("/api/v1/exchange-rates/quotes",
+		middleware.ValidateAuth(authUsers),
+		middleware.RequireAuthenticatedUser(),
+		exchangeRateController.QuoteExchangeRateController,
+	)
+
+	// Setting rates is restricted to admins, each change also maintains the inverse pair
+	adminGroup := router.Group("/api/v1/exchange-rates")
+	adminGroup.Use(
+		middleware.ValidateAuth(authUsers),
+		middleware.RequireAuthenticatedUserWithRole("admin"),
+	)
+
+	adminGroup.POST("", exchangeRateController.CreateExchangeRateController)
+	adminGroup.PUT("/:from_currency/:to_currency", exchangeRateController.UpdateExchangeRateController)
+	adminGroup.DELETE("/:from_currency/:to_currency", exchangeRateController.DeleteExchangeRateController)
+	adminGroup.GET("/refresh-status", exchangeRateController.GetRateRefreshStatusController)
+
+	// Each change to a fee schedule creates a new version, transfers keep the version they were charged with
+	feeScheduleGroup := router.Group("/api/v1/fee-schedules")
+	feeScheduleGroup.Use(
+		middleware.ValidateAuth(authUsers),
+		middleware.RequireAuthenticatedUserWithRole("admin"),
+	)
+
+	feeScheduleGroup.GET("", exchangeRateController.ListFeeSchedulesController)
+	feeScheduleGroup.GET("/:from_currency/:to_currency", exchangeRateController.GetFeeScheduleController)
+	feeScheduleGroup.PUT("/:from_currency/:to_currency", exchangeRateController.SetFeeScheduleController)
+	feeScheduleGroup.DELETE("/:from_currency/:to_currency", exchangeRateController.DeleteFeeScheduleController)
+
+	// Stored rates are mid-market rates, customers are priced at the rate less the markup of the pair
+	fxMarkupGroup := router.Group("/api/v1/fx-markups")
+	fxMarkupGroup.Use(
+		middleware.ValidateAuth(authUsers),
+		middleware.RequireAuthenticatedUserWithRole("admin"),
+	)
+
+	fxMarkupGroup.GET("", exchangeRateController.ListFxMarkupsController)
+	fxMarkupGroup.PUT("/:from_currency/:to_currency", exchangeRateController.SetFxMarkupController)
+	fxMarkupGroup.DELETE("/:from_currency/:to_currency", exchangeRateController.DeleteFxMarkupController)
 }
@@ -1,15 +1,27 @@
 package exchangeRates
 
 import (
+	"lemfi/simplebank/config"
 	respositories "lemfi/simplebank/internal/apps/exchangeRates/respositories"
+
+	"github.com/shopspring/decimal"
 )
 
 type ExchangeRateService struct {
 	exchangeRateRepository respositories.ExchangeRateRepositoryInterface
+	// pivotCurrency rates are derived through when a pair has no direct rate, empty disables it
+	pivotCurrency string
+	// defaultFee is charged on cross-currency transfers whose pair has no fee schedule
+	defaultFee decimal.Decimal
+	// defaultMarkupBps is charged on top of the mid-market rate of pairs without their own markup
+	defaultMarkupBps int32
 }
 
 func NewExchangeRateService(exchangeRateRepository respositories.ExchangeRateRepositoryInterface) *ExchangeRateService {
 	return &ExchangeRateService{
 		exchangeRateRepository: exchangeRateRepository,
+		pivotCurrency:          config.Get().ExchangeRate.PivotCurrency,
+		defaultFee:             config.Get().MultiCurrency.Fee,
+		defaultMarkupBps:       int32(config.Get().ExchangeRate.MarkupBps),
 	}
 }
@@ -0,0 +1,88 @@
+package exchangeRates
+
+import (
+	"context"
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	responses "lemfi/simplebank/internal/apps/exchangeRates/responses"
+)
+
+// resolveExchangeRate returns the direct rate of the pair or, when there is none, a rate derived
+// through the configured pivot currency, e.g. NGN -> USD -> EUR for a missing NGN -> EUR
+func (exchangeRateService *ExchangeRateService) resolveExchangeRate(ctx context.Context, payload requests.GetExchangeRateRequest) (responses.ExchangeRateResponse, db.ExchangeRate, error) {
+	dbExchangeRate, err := exchangeRateService.exchangeRateRepository.GetExchangeRate(ctx, payload)
+	if err == nil {
+		return responses.NewExchangeRateResponse(dbExchangeRate), dbExchangeRate, nil
+	}
+
+	pivotCurrency := exchangeRateService.pivotCurrency
+	if !errors.Is(err, exchangeRateErrors.ErrExchangeRateNotFound) ||
+		pivotCurrency == "" || pivotCurrency == payload.FromCurrency || pivotCurrency == payload.ToCurrency {
+		return responses.ExchangeRateResponse{}, db.ExchangeRate{}, err
+	}
+
+	config.Logger.Info("Service: No direct exchange rate, deriving it through the pivot currency",
+		"from_currency", payload.FromCurrency,
+		"to_currency", payload.ToCurrency,
+		"pivot_currency", pivotCurrency,
+	)
+
+	fromLeg, err := exchangeRateService.exchangeRateRepository.GetExchangeRate(ctx, requests.GetExchangeRateRequest{
+		FromCurrency: payload.FromCurrency,
+		ToCurrency:   pivotCurrency,
+		Amount:       payload.Amount,
+	})
+	if err != nil {
+		return responses.ExchangeRateResponse{}, db.ExchangeRate{}, err
+	}
+
+	toLeg, err := exchangeRateService.exchangeRateRepository.GetExchangeRate(ctx, requests.GetExchangeRateRequest{
+		FromCurrency: pivotCurrency,
+		ToCurrency:   payload.ToCurrency,
+		Amount:       payload.Amount,
+	})
+	if err != nil {
+		return responses.ExchangeRateResponse{}, db.ExchangeRate{}, err
+	}
+
+	dbExchangeRate = deriveCrossRate(fromLeg, toLeg)
+	if dbExchangeRate.Rate.IsZero() {
+		// Both legs are tiny enough that the product rounds away
+		config.Logger.Error("Derived exchange rate rounds to zero",
+			"from_rate", fromLeg.Rate.String(),
+			"to_rate", toLeg.Rate.String(),
+		)
+		return responses.ExchangeRateResponse{}, db.ExchangeRate{}, exchangeRateErrors.ErrExchangeRateNotFound
+	}
+
+	exchangeRate := responses.NewExchangeRateResponse(dbExchangeRate)
+	exchangeRate.Derived = true
+	exchangeRate.Legs = []responses.ExchangeRateResponse{
+		responses.NewExchangeRateResponse(fromLeg),
+		responses.NewExchangeRateResponse(toLeg),
+	}
+
+	return exchangeRate, dbExchangeRate, nil
+}
+
+// deriveCrossRate composes two legs that meet in the pivot currency. The product is taken at full
+// precision and rounded once to the precision rates are stored with, so a transfer made at the
+// derived rate reproduces the quoted amount. The derived rate is only as fresh as its older leg
+func deriveCrossRate(fromLeg, toLeg db.ExchangeRate) db.ExchangeRate {
+	olderLeg := fromLeg
+	if toLeg.UpdatedAt.Time.Before(fromLeg.UpdatedAt.Time) {
+		olderLeg = toLeg
+	}
+
+	return db.ExchangeRate{
+		FromCurrency: fromLeg.FromCurrency,
+		ToCurrency:   toLeg.ToCurrency,
+		Rate:         fromLeg.Rate.Mul(toLeg.Rate).Round(exchangeRatePlaces),
+		CreatedAt:    olderLeg.CreatedAt,
+		UpdatedAt:    olderLeg.UpdatedAt,
+	}
+}
@@ -0,0 +1,144 @@
+package exchangeRates
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	responses "lemfi/simplebank/internal/apps/exchangeRates/responses"
+	testhelpers "lemfi/simplebank/internal/apps/exchangeRates/testHelpers"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func expectExchangeRate(store *mockdb.MockStore, fromCurrency, toCurrency string, rate db.ExchangeRate, err error) {
+	store.EXPECT().
+		GetExchangeRate(gomock.Any(), db.GetExchangeRateParams{FromCurrency: fromCurrency, ToCurrency: toCurrency}).
+		Return(rate, err).
+		Times(1)
+}
+
+func TestGetExchangeRateService_CrossRate(t *testing.T) {
+	olderUpdate := time.Now().Add(-time.Minute).Truncate(time.Second)
+	newerUpdate := time.Now().Truncate(time.Second)
+
+	testCases := []struct {
+		name          string
+		pivotCurrency string
+		request       requests.GetExchangeRateRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, response responses.GetExchangeRateResponse, err error)
+	}{
+		{
+			name:          "DirectRateIsPreferred",
+			pivotCurrency: "USD",
+			request:       requests.GetExchangeRateRequest{FromCurrency: "NGN", ToCurrency: "EUR", Amount: decimal.NewFromInt(10000)},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExchangeRate(store, "NGN", "EUR", newExchangeRate(1, "NGN", "EUR", "0.00057", newerUpdate), nil)
+				expectNoFxMarkup(store)
+				expectNoFeeSchedule(store)
+			},
+			checkResponse: func(t *testing.T, response responses.GetExchangeRateResponse, err error) {
+				require.NoError(t, err)
+				require.False(t, response.ExchangeRate.Derived)
+				require.Empty(t, response.ExchangeRate.Legs)
+				require.Nil(t, response.ExchangeRate.PivotRate())
+				require.True(t, decimal.RequireFromString("5.7").Equal(response.AmountToReceive))
+			},
+		},
+		{
+			name:          "DerivedThroughPivot",
+			pivotCurrency: "USD",
+			request:       requests.GetExchangeRateRequest{FromCurrency: "NGN", ToCurrency: "EUR", Amount: decimal.NewFromInt(10000)},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExchangeRate(store, "NGN", "EUR", db.ExchangeRate{}, pgx.ErrNoRows)
+				expectExchangeRate(store, "NGN", "USD", newExchangeRate(2, "NGN", "USD", "0.00066667", olderUpdate), nil)
+				expectExchangeRate(store, "USD", "EUR", newExchangeRate(3, "USD", "EUR", "0.85", newerUpdate), nil)
+				expectNoFxMarkup(store)
+				expectNoFeeSchedule(store)
+			},
+			checkResponse: func(t *testing.T, response responses.GetExchangeRateResponse, err error) {
+				require.NoError(t, err)
+
+				// 0.00066667 * 0.85 = 0.0005666695, rounded to the 8 places rates are stored with
+				exchangeRate := response.ExchangeRate
+				require.True(t, exchangeRate.Derived)
+				require.Equal(t, "NGN", exchangeRate.FromCurrency)
+				require.Equal(t, "EUR", exchangeRate.ToCurrency)
+				require.True(t, decimal.RequireFromString("0.00056667").Equal(exchangeRate.Rate))
+				require.True(t, decimal.RequireFromString("5.67").Equal(response.AmountToReceive))
+
+				// The derived rate expires with its older leg
+				require.True(t, olderUpdate.Equal(exchangeRate.UpdatedAt))
+
+				require.Len(t, exchangeRate.Legs, 2)
+				require.Equal(t, int64(2), exchangeRate.Legs[0].ID)
+				require.Equal(t, int64(3), exchangeRate.Legs[1].ID)
+
+				pivotRate := exchangeRate.PivotRate()
+				require.NotNil(t, pivotRate)
+				require.Equal(t, "USD", pivotRate.Currency)
+				require.True(t, decimal.RequireFromString("0.00066667").Equal(pivotRate.FromRate))
+				require.True(t, decimal.RequireFromString("0.85").Equal(pivotRate.ToRate))
+			},
+		},
+		{
+			name:          "MissingLeg",
+			pivotCurrency: "USD",
+			request:       requests.GetExchangeRateRequest{FromCurrency: "NGN", ToCurrency: "EUR", Amount: decimal.NewFromInt(10000)},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExchangeRate(store, "NGN", "EUR", db.ExchangeRate{}, pgx.ErrNoRows)
+				expectExchangeRate(store, "NGN", "USD", newExchangeRate(2, "NGN", "USD", "0.00066667", olderUpdate), nil)
+				expectExchangeRate(store, "USD", "EUR", db.ExchangeRate{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, response responses.GetExchangeRateResponse, err error) {
+				require.ErrorIs(t, err, exchangeRateErrors.ErrExchangeRateNotFound)
+			},
+		},
+		{
+			name:          "PivotDisabled",
+			pivotCurrency: "",
+			request:       requests.GetExchangeRateRequest{FromCurrency: "NGN", ToCurrency: "EUR", Amount: decimal.NewFromInt(10000)},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExchangeRate(store, "NGN", "EUR", db.ExchangeRate{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, response responses.GetExchangeRateResponse, err error) {
+				require.ErrorIs(t, err, exchangeRateErrors.ErrExchangeRateNotFound)
+			},
+		},
+		{
+			name:          "PairIncludesPivot",
+			pivotCurrency: "USD",
+			request:       requests.GetExchangeRateRequest{FromCurrency: "USD", ToCurrency: "NGN", Amount: decimal.NewFromInt(10)},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectExchangeRate(store, "USD", "NGN", db.ExchangeRate{}, pgx.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, response responses.GetExchangeRateResponse, err error) {
+				require.ErrorIs(t, err, exchangeRateErrors.ErrExchangeRateNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			exchangeRateService := NewExchangeRateService(testhelpers.NewMockExchangeRateRepository(store))
+			exchangeRateService.pivotCurrency = tc.pivotCurrency
+
+			response, err := exchangeRateService.GetExchangeRate(context.Background(), tc.request)
+			tc.checkResponse(t, response, err)
+		})
+	}
+}
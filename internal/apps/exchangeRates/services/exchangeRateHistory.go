@@ -0,0 +1,84 @@
+package exchangeRates
+
+import (
+	"context"
+	"strings"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	responses "lemfi/simplebank/internal/apps/exchangeRates/responses"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const defaultExchangeRateHistoryPageSize = 100
+
+func (exchangeRateService *ExchangeRateService) ListExchangeRateHistory(ctx context.Context, payload requests.ListExchangeRateHistoryRequest) (responses.ListExchangeRateHistoryResponse, error) {
+	config.Logger.Info("Service: Listing exchange rate history", "pair", payload.Pair, "from", payload.From, "to", payload.To)
+
+	pair, err := parseCurrencyPair(payload.Pair)
+	if err != nil {
+		return responses.ListExchangeRateHistoryResponse{}, err
+	}
+
+	err = validateCurrencyPair(pair.FromCurrency, pair.ToCurrency)
+	if err != nil {
+		return responses.ListExchangeRateHistoryResponse{}, err
+	}
+
+	if !payload.From.IsZero() && !payload.To.IsZero() && !payload.From.Before(payload.To) {
+		config.Logger.Error("Invalid date range", "from", payload.From, "to", payload.To)
+		return responses.ListExchangeRateHistoryResponse{}, exchangeRateErrors.ErrInvalidDateRange
+	}
+
+	limit := payload.Limit
+	if limit == 0 {
+		limit = defaultExchangeRateHistoryPageSize
+	}
+
+	params := db.ListExchangeRateHistoryParams{
+		FromCurrency: pair.FromCurrency,
+		ToCurrency:   pair.ToCurrency,
+		PageLimit:    limit,
+	}
+	if !payload.From.IsZero() {
+		params.StartDate = pgtype.Timestamptz{Time: payload.From, Valid: true}
+	}
+	if !payload.To.IsZero() {
+		params.EndDate = pgtype.Timestamptz{Time: payload.To, Valid: true}
+	}
+
+	rows, err := exchangeRateService.exchangeRateRepository.ListExchangeRateHistory(ctx, params)
+	if err != nil {
+		config.Logger.Error("Service: Failed to list exchange rate history", "error", err.Error())
+		return responses.ListExchangeRateHistoryResponse{}, err
+	}
+
+	history := make([]responses.ExchangeRateHistoryResponse, len(rows))
+	for i, row := range rows {
+		history[i] = responses.NewExchangeRateHistoryResponse(row)
+	}
+
+	config.Logger.Info("Service: Successfully listed exchange rate history", "pair", payload.Pair, "total", len(history))
+
+	return responses.ListExchangeRateHistoryResponse{
+		History: history,
+		Total:   len(history),
+	}, nil
+}
+
+// parseCurrencyPair splits a FROM-TO pair such as USD-NGN
+func parseCurrencyPair(pair string) (requests.CurrencyPair, error) {
+	fromCurrency, toCurrency, found := strings.Cut(pair, "-")
+	if !found || fromCurrency == "" || toCurrency == "" {
+		config.Logger.Error("Invalid currency pair format", "pair", pair)
+		return requests.CurrencyPair{}, exchangeRateErrors.ErrInvalidPairFormat
+	}
+
+	return requests.CurrencyPair{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package exchangeRates
+
+import (
+	"context"
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	testhelpers "lemfi/simplebank/internal/apps/exchangeRates/testHelpers"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListExchangeRateHistoryService(t *testing.T) {
+	from := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	testCases := []struct {
+		name        string
+		request     requests.ListExchangeRateHistoryRequest
+		buildStubs  func(store *mockdb.MockStore)
+		expectedErr error
+	}{
+		{
+			name:    "OK",
+			request: requests.ListExchangeRateHistoryRequest{Pair: "USD-NGN", From: from, To: to},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListExchangeRateHistory(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, arg db.ListExchangeRateHistoryParams) ([]db.ExchangeRateHistory, error) {
+						require.Equal(t, "USD", arg.FromCurrency)
+						require.Equal(t, "NGN", arg.ToCurrency)
+						require.Equal(t, from, arg.StartDate.Time)
+						require.Equal(t, to, arg.EndDate.Time)
+						require.Equal(t, int32(defaultExchangeRateHistoryPageSize), arg.PageLimit)
+						return []db.ExchangeRateHistory{
+							{ID: 2, FromCurrency: "USD", ToCurrency: "NGN", Rate: decimal.RequireFromString("1520"), ValidFrom: from.Add(2 * time.Hour)},
+							{ID: 1, FromCurrency: "USD", ToCurrency: "NGN", Rate: decimal.RequireFromString("1500"), ValidFrom: from.Add(time.Hour)},
+						}, nil
+					}).
+					Times(1)
+			},
+		},
+		{
+			name:    "InvalidPairFormat",
+			request: requests.ListExchangeRateHistoryRequest{Pair: "USDNGN"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListExchangeRateHistory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: exchangeRateErrors.ErrInvalidPairFormat,
+		},
+		{
+			name:    "UnsupportedCurrency",
+			request: requests.ListExchangeRateHistoryRequest{Pair: "USD-JPY"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListExchangeRateHistory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: exchangeRateErrors.ErrUnsupportedCurrency,
+		},
+		{
+			name:    "InvalidDateRange",
+			request: requests.ListExchangeRateHistoryRequest{Pair: "USD-NGN", From: to, To: from},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListExchangeRateHistory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: exchangeRateErrors.ErrInvalidDateRange,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			exchangeRateService := NewExchangeRateService(testhelpers.NewMockExchangeRateRepository(store))
+
+			result, err := exchangeRateService.ListExchangeRateHistory(context.Background(), tc.request)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, 2, result.Total)
+			require.True(t, result.History[0].Rate.Equal(decimal.RequireFromString("1520")))
+		})
+	}
+}
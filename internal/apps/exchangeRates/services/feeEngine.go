@@ -0,0 +1,83 @@
+package exchangeRates
+
+import (
+	"context"
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	"lemfi/simplebank/internal/apps/currencies"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	responses "lemfi/simplebank/internal/apps/exchangeRates/responses"
+
+	"github.com/shopspring/decimal"
+)
+
+var oneHundred = decimal.NewFromInt(100)
+
+// CalculateFee prices sending amount from one currency to another with the live fee schedule of the pair.
+// Pairs without a schedule pay the default fee when the currencies differ and nothing otherwise
+func (exchangeRateService *ExchangeRateService) CalculateFee(ctx context.Context, fromCurrency, toCurrency string, amount decimal.Decimal) (responses.TransferFee, error) {
+	schedule, err := exchangeRateService.exchangeRateRepository.GetActiveFeeSchedule(ctx, requests.CurrencyPair{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+	})
+	if err != nil {
+		if !errors.Is(err, exchangeRateErrors.ErrFeeScheduleNotFound) {
+			config.Logger.Error("Service: Failed to get fee schedule", "error", err.Error())
+			return responses.TransferFee{}, err
+		}
+
+		if fromCurrency == toCurrency {
+			return responses.TransferFee{Fee: decimal.Zero}, nil
+		}
+
+		// The default fee is configured once for every currency, so it is rounded to the one it is charged in
+		return responses.TransferFee{Fee: currencies.Round(exchangeRateService.defaultFee, currencies.Currency(fromCurrency))}, nil
+	}
+
+	fee := calculateScheduledFee(schedule, amount)
+
+	config.Logger.Info("Service: Calculated fee from fee schedule",
+		"fee_schedule_id", schedule.FeeSchedule.ID,
+		"version", schedule.FeeSchedule.Version,
+		"amount", amount.String(),
+		"fee", fee.String(),
+	)
+
+	return responses.TransferFee{
+		Fee:                fee,
+		FeeScheduleID:      schedule.FeeSchedule.ID,
+		FeeScheduleVersion: schedule.FeeSchedule.Version,
+	}, nil
+}
+
+// calculateScheduledFee charges the fixed fee plus the percentage of the amount, kept between the minimum and maximum fee.
+// The tier with the highest min_amount the amount reaches replaces the fixed fee and percentage of the schedule.
+// Fees are charged in the from currency and rounded to its minor units
+func calculateScheduledFee(schedule db.FeeScheduleTxResult, amount decimal.Decimal) decimal.Decimal {
+	fixedFee := schedule.FeeSchedule.FixedFee
+	percentage := schedule.FeeSchedule.Percentage
+
+	var tierMinAmount decimal.Decimal
+	for _, tier := range schedule.Tiers {
+		if amount.GreaterThanOrEqual(tier.MinAmount) && tier.MinAmount.GreaterThan(tierMinAmount) {
+			tierMinAmount = tier.MinAmount
+			fixedFee = tier.FixedFee
+			percentage = tier.Percentage
+		}
+	}
+
+	fee := currencies.Round(fixedFee.Add(amount.Mul(percentage).Div(oneHundred)), currencies.Currency(schedule.FeeSchedule.FromCurrency))
+
+	if fee.LessThan(schedule.FeeSchedule.MinFee) {
+		fee = schedule.FeeSchedule.MinFee
+	}
+
+	if schedule.FeeSchedule.MaxFee.Valid && fee.GreaterThan(schedule.FeeSchedule.MaxFee.Decimal) {
+		fee = schedule.FeeSchedule.MaxFee.Decimal
+	}
+
+	return fee
+}
@@ -0,0 +1,140 @@
+package exchangeRates
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	testhelpers "lemfi/simplebank/internal/apps/exchangeRates/testHelpers"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// expectNoFeeSchedule stubs a pair without a fee schedule, so the default fee applies
+func expectNoFeeSchedule(store *mockdb.MockStore) {
+	store.EXPECT().
+		GetActiveFeeSchedule(gomock.Any(), gomock.Any()).
+		Return(db.FeeSchedule{}, pgx.ErrNoRows).
+		Times(1)
+}
+
+func TestCalculateScheduledFee(t *testing.T) {
+	tiered := newFeeSchedule("2", "1", "0", "",
+		newFeeTier("1000", "1", "0.5"),
+		newFeeTier("100", "1.5", "0.75"),
+	)
+
+	testCases := []struct {
+		name        string
+		schedule    db.FeeScheduleTxResult
+		amount      string
+		expectedFee string
+	}{
+		{name: "FixedOnly", schedule: newFeeSchedule("1.99", "0", "0", ""), amount: "500", expectedFee: "1.99"},
+		{name: "FixedPlusPercentage", schedule: newFeeSchedule("1", "1.5", "0", ""), amount: "200", expectedFee: "4"},
+		{name: "RoundedToCents", schedule: newFeeSchedule("0", "0.333", "0", ""), amount: "100.01", expectedFee: "0.33"},
+		{name: "RaisedToMinimum", schedule: newFeeSchedule("0", "1", "2.5", ""), amount: "100", expectedFee: "2.5"},
+		{name: "CappedAtMaximum", schedule: newFeeSchedule("0", "2", "0", "25"), amount: "5000", expectedFee: "25"},
+		{name: "BelowFirstTier", schedule: tiered, amount: "99.99", expectedFee: "3"},
+		{name: "TierStartsAtMinAmount", schedule: tiered, amount: "100", expectedFee: "2.25"},
+		{name: "HighestReachedTierApplies", schedule: tiered, amount: "2000", expectedFee: "11"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fee := calculateScheduledFee(tc.schedule, decimal.RequireFromString(tc.amount))
+			require.True(t, decimal.RequireFromString(tc.expectedFee).Equal(fee), "expected %s, got %s", tc.expectedFee, fee)
+		})
+	}
+}
+
+func TestCalculateFee(t *testing.T) {
+	defaultFee := decimal.RequireFromString("1.99")
+
+	testCases := []struct {
+		name          string
+		fromCurrency  string
+		toCurrency    string
+		buildStubs    func(store *mockdb.MockStore)
+		expectedFee   string
+		expectedID    int64
+		expectedError bool
+	}{
+		{
+			name:         "ScheduledPair",
+			fromCurrency: "GBP",
+			toCurrency:   "NGN",
+			buildStubs: func(store *mockdb.MockStore) {
+				schedule := newFeeSchedule("1", "1", "0", "")
+				store.EXPECT().
+					GetActiveFeeSchedule(gomock.Any(), gomock.Eq(db.GetActiveFeeScheduleParams{FromCurrency: "GBP", ToCurrency: "NGN"})).
+					Return(schedule.FeeSchedule, nil).
+					Times(1)
+				store.EXPECT().
+					ListFeeScheduleTiers(gomock.Any(), gomock.Eq([]int64{3})).
+					Return([]db.FeeScheduleTier{newFeeTier("100", "0.5", "0.5")}, nil).
+					Times(1)
+			},
+			// The tier replaces the schedule's 1 + 1%
+			expectedFee: "1",
+			expectedID:  3,
+		},
+		{
+			name:         "CrossCurrencyDefault",
+			fromCurrency: "GBP",
+			toCurrency:   "NGN",
+			buildStubs:   expectNoFeeSchedule,
+			expectedFee:  "1.99",
+		},
+		{
+			name:         "SameCurrencyIsFreeByDefault",
+			fromCurrency: "USD",
+			toCurrency:   "USD",
+			buildStubs:   expectNoFeeSchedule,
+			expectedFee:  "0",
+		},
+		{
+			name:         "DatabaseError",
+			fromCurrency: "GBP",
+			toCurrency:   "NGN",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetActiveFeeSchedule(gomock.Any(), gomock.Any()).
+					Return(db.FeeSchedule{}, errors.New("database error")).
+					Times(1)
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			service := NewExchangeRateService(testhelpers.NewMockExchangeRateRepository(store))
+			service.defaultFee = defaultFee
+
+			transferFee, err := service.CalculateFee(context.Background(), tc.fromCurrency, tc.toCurrency, decimal.NewFromInt(100))
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, decimal.RequireFromString(tc.expectedFee).Equal(transferFee.Fee), "expected %s, got %s", tc.expectedFee, transferFee.Fee)
+			require.Equal(t, tc.expectedID, transferFee.FeeScheduleID)
+			if tc.expectedID != 0 {
+				require.Equal(t, int32(2), transferFee.FeeScheduleVersion)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package exchangeRates
+
+import (
+	"context"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	"lemfi/simplebank/internal/apps/currencies"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	responses "lemfi/simplebank/internal/apps/exchangeRates/responses"
+
+	"github.com/shopspring/decimal"
+)
+
+// percentagePlaces matches the precision of the fee percentage columns
+const percentagePlaces = 4
+
+const maxFeeTiers = 20
+
+func (exchangeRateService *ExchangeRateService) ListFeeSchedules(ctx context.Context) (responses.ListFeeSchedulesResponse, error) {
+	config.Logger.Info("Service: Listing fee schedules")
+
+	schedules, err := exchangeRateService.exchangeRateRepository.ListFeeSchedules(ctx)
+	if err != nil {
+		config.Logger.Error("Service: Failed to list fee schedules", "error", err.Error())
+		return responses.ListFeeSchedulesResponse{}, err
+	}
+
+	response := responses.ListFeeSchedulesResponse{
+		FeeSchedules: make([]responses.FeeScheduleResponse, 0, len(schedules)),
+		Total:        len(schedules),
+	}
+
+	for _, schedule := range schedules {
+		response.FeeSchedules = append(response.FeeSchedules, responses.NewFeeScheduleResponse(schedule))
+	}
+
+	return response, nil
+}
+
+func (exchangeRateService *ExchangeRateService) GetFeeSchedule(ctx context.Context, pair requests.CurrencyPair) (responses.FeeScheduleResponse, error) {
+	config.Logger.Info("Service: Getting fee schedule",
+		"from_currency", pair.FromCurrency,
+		"to_currency", pair.ToCurrency,
+	)
+
+	err := validateFeeSchedulePair(pair.FromCurrency, pair.ToCurrency)
+	if err != nil {
+		return responses.FeeScheduleResponse{}, err
+	}
+
+	schedule, err := exchangeRateService.exchangeRateRepository.GetActiveFeeSchedule(ctx, pair)
+	if err != nil {
+		config.Logger.Error("Service: Failed to get fee schedule", "error", err.Error())
+		return responses.FeeScheduleResponse{}, err
+	}
+
+	return responses.NewFeeScheduleResponse(schedule), nil
+}
+
+// SetFeeSchedule creates the next version of the fee schedule of a pair and retires the previous one.
+// Versions are never edited in place so a transfer always points at the rules it was charged with
+func (exchangeRateService *ExchangeRateService) SetFeeSchedule(ctx context.Context, payload requests.SetFeeScheduleRequest) (responses.FeeScheduleResponse, error) {
+	config.Logger.Info("Service: Setting fee schedule",
+		"from_currency", payload.FromCurrency,
+		"to_currency", payload.ToCurrency,
+		"fixed_fee", payload.FixedFee.String(),
+		"percentage", payload.Percentage.String(),
+		"tiers", len(payload.Tiers),
+	)
+
+	params, err := newFeeScheduleParams(payload)
+	if err != nil {
+		return responses.FeeScheduleResponse{}, err
+	}
+
+	schedule, err := exchangeRateService.exchangeRateRepository.SetFeeSchedule(ctx, params)
+	if err != nil {
+		config.Logger.Error("Service: Failed to set fee schedule", "error", err.Error())
+		return responses.FeeScheduleResponse{}, err
+	}
+
+	config.Logger.Info("Service: Successfully set fee schedule",
+		"fee_schedule_id", schedule.FeeSchedule.ID,
+		"version", schedule.FeeSchedule.Version,
+	)
+
+	return responses.NewFeeScheduleResponse(schedule), nil
+}
+
+// DeleteFeeSchedule retires the fee schedule of a pair, which then pays the default fee again
+func (exchangeRateService *ExchangeRateService) DeleteFeeSchedule(ctx context.Context, pair requests.CurrencyPair) error {
+	config.Logger.Info("Service: Deleting fee schedule",
+		"from_currency", pair.FromCurrency,
+		"to_currency", pair.ToCurrency,
+	)
+
+	err := validateFeeSchedulePair(pair.FromCurrency, pair.ToCurrency)
+	if err != nil {
+		return err
+	}
+
+	err = exchangeRateService.exchangeRateRepository.DeleteFeeSchedule(ctx, pair)
+	if err != nil {
+		config.Logger.Error("Service: Failed to delete fee schedule", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// newFeeScheduleParams validates a fee schedule set by an admin
+func newFeeScheduleParams(payload requests.SetFeeScheduleRequest) (db.SetFeeScheduleTxParams, error) {
+	err := validateFeeSchedulePair(payload.FromCurrency, payload.ToCurrency)
+	if err != nil {
+		return db.SetFeeScheduleTxParams{}, err
+	}
+
+	// Fees are charged in the from currency, as are the amounts tiers start at
+	feeCurrency := currencies.Currency(payload.FromCurrency)
+
+	err = validateFeeRule(payload.FixedFee, payload.Percentage, feeCurrency)
+	if err != nil {
+		return db.SetFeeScheduleTxParams{}, err
+	}
+
+	err = validateFeeAmount(payload.MinFee, feeCurrency)
+	if err != nil {
+		return db.SetFeeScheduleTxParams{}, err
+	}
+
+	params := db.SetFeeScheduleTxParams{
+		CreateFeeScheduleParams: db.CreateFeeScheduleParams{
+			FromCurrency: payload.FromCurrency,
+			ToCurrency:   payload.ToCurrency,
+			FixedFee:     payload.FixedFee,
+			Percentage:   payload.Percentage,
+			MinFee:       payload.MinFee,
+		},
+		Tiers: make([]db.FeeScheduleTierParams, 0, len(payload.Tiers)),
+	}
+
+	if payload.MaxFee != nil {
+		err = validateFeeAmount(*payload.MaxFee, feeCurrency)
+		if err != nil {
+			return db.SetFeeScheduleTxParams{}, err
+		}
+
+		if payload.MinFee.GreaterThan(*payload.MaxFee) {
+			config.Logger.Error("Minimum fee is above maximum fee", "min_fee", payload.MinFee.String(), "max_fee", payload.MaxFee.String())
+			return db.SetFeeScheduleTxParams{}, exchangeRateErrors.ErrMinFeeAboveMaxFee
+		}
+
+		params.MaxFee = decimal.NewNullDecimal(*payload.MaxFee)
+	}
+
+	if len(payload.Tiers) > maxFeeTiers {
+		config.Logger.Error("Too many fee tiers", "tiers", len(payload.Tiers))
+		return db.SetFeeScheduleTxParams{}, exchangeRateErrors.ErrTooManyFeeTiers
+	}
+
+	seen := make(map[string]bool, len(payload.Tiers))
+	for _, tier := range payload.Tiers {
+		err = validateFeeRule(tier.FixedFee, tier.Percentage, feeCurrency)
+		if err != nil {
+			return db.SetFeeScheduleTxParams{}, err
+		}
+
+		err = validateFeeAmount(tier.MinAmount, feeCurrency)
+		if err != nil {
+			return db.SetFeeScheduleTxParams{}, err
+		}
+
+		// Equal amounts with different scales such as 100 and 100.00 are the same tier
+		key := tier.MinAmount.String()
+		if tier.MinAmount.IsZero() || seen[key] {
+			config.Logger.Error("Invalid fee tier min amount", "min_amount", key)
+			return db.SetFeeScheduleTxParams{}, exchangeRateErrors.ErrInvalidFeeTiers
+		}
+		seen[key] = true
+
+		params.Tiers = append(params.Tiers, db.FeeScheduleTierParams{
+			MinAmount:  tier.MinAmount,
+			FixedFee:   tier.FixedFee,
+			Percentage: tier.Percentage,
+		})
+	}
+
+	return params, nil
+}
+
+// validateFeeRule checks the fixed fee and percentage of a schedule or one of its tiers
+func validateFeeRule(fixedFee, percentage decimal.Decimal, currency currencies.Currency) error {
+	err := validateFeeAmount(fixedFee, currency)
+	if err != nil {
+		return err
+	}
+
+	if percentage.IsNegative() || percentage.GreaterThan(oneHundred) {
+		config.Logger.Error("Invalid fee percentage", "percentage", percentage.String())
+		return exchangeRateErrors.ErrInvalidFeePercentage
+	}
+
+	// The columns would round silently, so reject values they cannot store exactly
+	if !percentage.Equal(percentage.Round(percentagePlaces)) {
+		config.Logger.Error("Fee percentage has too many decimal places", "percentage", percentage.String())
+		return exchangeRateErrors.ErrFeePrecision
+	}
+
+	return nil
+}
+
+// validateFeeAmount checks a fee or tier amount, which are in the from currency of the schedule
+func validateFeeAmount(amount decimal.Decimal, currency currencies.Currency) error {
+	if amount.IsNegative() {
+		config.Logger.Error("Negative fee amount", "amount", amount.String())
+		return exchangeRateErrors.ErrNegativeFee
+	}
+
+	if !currencies.HasValidPrecision(amount, currency) {
+		config.Logger.Error("Fee amount has too many decimal places", "amount", amount.String(), "currency", currency)
+		return exchangeRateErrors.ErrFeePrecision
+	}
+
+	return nil
+}
+
+// validateFeeSchedulePair accepts any pair of supported currencies. Unlike exchange rates,
+// a pair of the same currency is valid so same-currency transfers can be charged too
+func validateFeeSchedulePair(fromCurrency, toCurrency string) error {
+	if !currencies.IsSupportedCurrency(currencies.Currency(fromCurrency)) {
+		config.Logger.Error("From currency is not supported", "currency", fromCurrency)
+		return exchangeRateErrors.ErrUnsupportedCurrency
+	}
+
+	if !currencies.IsSupportedCurrency(currencies.Currency(toCurrency)) {
+		config.Logger.Error("To currency is not supported", "currency", toCurrency)
+		return exchangeRateErrors.ErrUnsupportedCurrency
+	}
+
+	return nil
+}
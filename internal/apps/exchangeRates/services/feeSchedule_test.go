@@ -0,0 +1,183 @@
+package exchangeRates
+
+import (
+	"context"
+	"testing"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	testhelpers "lemfi/simplebank/internal/apps/exchangeRates/testHelpers"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSetFeeScheduleService(t *testing.T) {
+	tier := func(minAmount string) requests.FeeTierRequest {
+		return requests.FeeTierRequest{
+			MinAmount:  decimal.RequireFromString(minAmount),
+			FixedFee:   decimal.RequireFromString("1"),
+			Percentage: decimal.RequireFromString("0.5"),
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		request     func() requests.SetFeeScheduleRequest
+		buildStubs  func(store *mockdb.MockStore)
+		expectedErr error
+	}{
+		{
+			name: "OK",
+			request: func() requests.SetFeeScheduleRequest {
+				return newFeeScheduleRequest(tier("1000"))
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetFeeScheduleTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, arg db.SetFeeScheduleTxParams) (db.FeeScheduleTxResult, error) {
+						require.Equal(t, "GBP", arg.FromCurrency)
+						require.Equal(t, "NGN", arg.ToCurrency)
+						require.True(t, arg.MaxFee.Valid)
+						require.True(t, decimal.RequireFromString("50").Equal(arg.MaxFee.Decimal))
+						require.Len(t, arg.Tiers, 1)
+						return db.FeeScheduleTxResult{
+							FeeSchedule: db.FeeSchedule{ID: 4, FromCurrency: arg.FromCurrency, ToCurrency: arg.ToCurrency, Version: 3, MaxFee: arg.MaxFee},
+							Tiers:       []db.FeeScheduleTier{{ID: 1, FeeScheduleID: 4, MinAmount: arg.Tiers[0].MinAmount}},
+						}, nil
+					}).
+					Times(1)
+			},
+		},
+		{
+			name: "SameCurrencyPair",
+			request: func() requests.SetFeeScheduleRequest {
+				request := newFeeScheduleRequest()
+				request.ToCurrency = "GBP"
+				return request
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetFeeScheduleTx(gomock.Any(), gomock.Any()).Return(db.FeeScheduleTxResult{}, nil).Times(1)
+			},
+		},
+		{
+			name: "UnsupportedCurrency",
+			request: func() requests.SetFeeScheduleRequest {
+				request := newFeeScheduleRequest()
+				request.ToCurrency = "JPY"
+				return request
+			},
+			expectedErr: exchangeRateErrors.ErrUnsupportedCurrency,
+		},
+		{
+			name: "NegativeFixedFee",
+			request: func() requests.SetFeeScheduleRequest {
+				request := newFeeScheduleRequest()
+				request.FixedFee = decimal.RequireFromString("-1")
+				return request
+			},
+			expectedErr: exchangeRateErrors.ErrNegativeFee,
+		},
+		{
+			name: "PercentageAboveHundred",
+			request: func() requests.SetFeeScheduleRequest {
+				request := newFeeScheduleRequest()
+				request.Percentage = decimal.RequireFromString("100.01")
+				return request
+			},
+			expectedErr: exchangeRateErrors.ErrInvalidFeePercentage,
+		},
+		{
+			name: "FeeWithTooManyPlaces",
+			request: func() requests.SetFeeScheduleRequest {
+				request := newFeeScheduleRequest()
+				request.MinFee = decimal.RequireFromString("0.001")
+				return request
+			},
+			expectedErr: exchangeRateErrors.ErrFeePrecision,
+		},
+		{
+			name: "MinFeeAboveMaxFee",
+			request: func() requests.SetFeeScheduleRequest {
+				request := newFeeScheduleRequest()
+				request.MinFee = decimal.RequireFromString("60")
+				return request
+			},
+			expectedErr: exchangeRateErrors.ErrMinFeeAboveMaxFee,
+		},
+		{
+			name: "DuplicateTier",
+			request: func() requests.SetFeeScheduleRequest {
+				return newFeeScheduleRequest(tier("100"), tier("100.00"))
+			},
+			expectedErr: exchangeRateErrors.ErrInvalidFeeTiers,
+		},
+		{
+			name: "ZeroTier",
+			request: func() requests.SetFeeScheduleRequest {
+				return newFeeScheduleRequest(tier("0"))
+			},
+			expectedErr: exchangeRateErrors.ErrInvalidFeeTiers,
+		},
+		{
+			name: "TooManyTiers",
+			request: func() requests.SetFeeScheduleRequest {
+				tiers := make([]requests.FeeTierRequest, 0, maxFeeTiers+1)
+				for i := 1; i <= maxFeeTiers+1; i++ {
+					tiers = append(tiers, tier(decimal.NewFromInt(int64(i*100)).String()))
+				}
+				return newFeeScheduleRequest(tiers...)
+			},
+			expectedErr: exchangeRateErrors.ErrTooManyFeeTiers,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			if tc.buildStubs != nil {
+				tc.buildStubs(store)
+			} else {
+				store.EXPECT().SetFeeScheduleTx(gomock.Any(), gomock.Any()).Times(0)
+			}
+
+			service := NewExchangeRateService(testhelpers.NewMockExchangeRateRepository(store))
+			response, err := service.SetFeeSchedule(context.Background(), tc.request())
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			if tc.name == "OK" {
+				require.Equal(t, int32(3), response.Version)
+				require.NotNil(t, response.MaxFee)
+				require.Len(t, response.Tiers, 1)
+			}
+		})
+	}
+}
+
+func TestDeleteFeeScheduleService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		DeactivateFeeSchedule(gomock.Any(), gomock.Eq(db.DeactivateFeeScheduleParams{FromCurrency: "GBP", ToCurrency: "NGN"})).
+		Return(db.FeeSchedule{}, pgx.ErrNoRows).
+		Times(1)
+
+	service := NewExchangeRateService(testhelpers.NewMockExchangeRateRepository(store))
+	err := service.DeleteFeeSchedule(context.Background(), requests.CurrencyPair{FromCurrency: "GBP", ToCurrency: "NGN"})
+
+	require.ErrorIs(t, err, exchangeRateErrors.ErrFeeScheduleNotFound)
+}
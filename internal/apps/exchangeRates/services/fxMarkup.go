@@ -0,0 +1,114 @@
+package exchangeRates
+
+import (
+	"context"
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	responses "lemfi/simplebank/internal/apps/exchangeRates/responses"
+
+	"github.com/shopspring/decimal"
+)
+
+var basisPointsPerUnit = decimal.NewFromInt(10_000)
+
+// markupBps returns the markup of the pair in basis points, or the default markup when the pair has none
+func (exchangeRateService *ExchangeRateService) markupBps(ctx context.Context, fromCurrency, toCurrency string) (int32, error) {
+	markup, err := exchangeRateService.exchangeRateRepository.GetFxMarkup(ctx, requests.CurrencyPair{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+	})
+	if errors.Is(err, exchangeRateErrors.ErrFxMarkupNotFound) {
+		return exchangeRateService.defaultMarkupBps, nil
+	}
+	if err != nil {
+		config.Logger.Error("Service: Failed to get fx markup", "error", err.Error())
+		return 0, err
+	}
+
+	return markup.MarkupBps, nil
+}
+
+// applyMarkup lowers a mid-market rate by the markup, so the customer receives less of the target currency.
+// The result is rounded to the precision rates are stored with
+func applyMarkup(midMarketRate decimal.Decimal, markupBps int32) decimal.Decimal {
+	return midMarketRate.
+		Mul(basisPointsPerUnit.Sub(decimal.NewFromInt32(markupBps))).
+		Div(basisPointsPerUnit).
+		Round(exchangeRatePlaces)
+}
+
+func (exchangeRateService *ExchangeRateService) ListFxMarkups(ctx context.Context) (responses.ListFxMarkupsResponse, error) {
+	config.Logger.Info("Service: Listing fx markups")
+
+	markups, err := exchangeRateService.exchangeRateRepository.ListFxMarkups(ctx)
+	if err != nil {
+		config.Logger.Error("Service: Failed to list fx markups", "error", err.Error())
+		return responses.ListFxMarkupsResponse{}, err
+	}
+
+	response := responses.ListFxMarkupsResponse{
+		FxMarkups:        make([]responses.FxMarkupResponse, 0, len(markups)),
+		Total:            len(markups),
+		DefaultMarkupBps: exchangeRateService.defaultMarkupBps,
+	}
+
+	for _, markup := range markups {
+		response.FxMarkups = append(response.FxMarkups, responses.NewFxMarkupResponse(markup))
+	}
+
+	return response, nil
+}
+
+func (exchangeRateService *ExchangeRateService) SetFxMarkup(ctx context.Context, payload requests.SetFxMarkupRequest) (responses.FxMarkupResponse, error) {
+	config.Logger.Info("Service: Setting fx markup",
+		"from_currency", payload.FromCurrency,
+		"to_currency", payload.ToCurrency,
+	)
+
+	err := validateCurrencyPair(payload.FromCurrency, payload.ToCurrency)
+	if err != nil {
+		return responses.FxMarkupResponse{}, err
+	}
+
+	if payload.MarkupBps == nil || *payload.MarkupBps < 0 || *payload.MarkupBps > config.MaxMarkupBps {
+		config.Logger.Error("Invalid fx markup", "markup_bps", payload.MarkupBps)
+		return responses.FxMarkupResponse{}, exchangeRateErrors.ErrInvalidMarkup
+	}
+
+	markup, err := exchangeRateService.exchangeRateRepository.SetFxMarkup(ctx, db.UpsertFxMarkupParams{
+		FromCurrency: payload.FromCurrency,
+		ToCurrency:   payload.ToCurrency,
+		MarkupBps:    *payload.MarkupBps,
+	})
+	if err != nil {
+		config.Logger.Error("Service: Failed to set fx markup", "error", err.Error())
+		return responses.FxMarkupResponse{}, err
+	}
+
+	return responses.NewFxMarkupResponse(markup), nil
+}
+
+// DeleteFxMarkup removes the markup of a pair, which then pays the default markup
+func (exchangeRateService *ExchangeRateService) DeleteFxMarkup(ctx context.Context, pair requests.CurrencyPair) error {
+	config.Logger.Info("Service: Deleting fx markup",
+		"from_currency", pair.FromCurrency,
+		"to_currency", pair.ToCurrency,
+	)
+
+	err := validateCurrencyPair(pair.FromCurrency, pair.ToCurrency)
+	if err != nil {
+		return err
+	}
+
+	err = exchangeRateService.exchangeRateRepository.DeleteFxMarkup(ctx, pair)
+	if err != nil {
+		config.Logger.Error("Service: Failed to delete fx markup", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
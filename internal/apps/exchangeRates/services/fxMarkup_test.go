@@ -0,0 +1,195 @@
+package exchangeRates
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"lemfi/simplebank/config"
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	testhelpers "lemfi/simplebank/internal/apps/exchangeRates/testHelpers"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// expectNoFxMarkup stubs a pair without its own markup, so the default markup applies
+func expectNoFxMarkup(store *mockdb.MockStore) {
+	store.EXPECT().
+		GetFxMarkup(gomock.Any(), gomock.Any()).
+		Return(db.FxMarkup{}, pgx.ErrNoRows).
+		Times(1)
+}
+
+func TestGetExchangeRateService_Markup(t *testing.T) {
+	request := requests.GetExchangeRateRequest{
+		FromCurrency: "USD",
+		ToCurrency:   "EUR",
+		Amount:       decimal.NewFromInt(100),
+	}
+
+	testCases := []struct {
+		name                 string
+		defaultMarkupBps     int32
+		buildStubs           func(store *mockdb.MockStore)
+		expectedMarkupBps    int32
+		expectedCustomerRate string
+		expectedToReceive    string
+		expectedErr          error
+	}{
+		{
+			name: "PairMarkup",
+			// The pair markup wins over the default markup
+			defaultMarkupBps: 100,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetFxMarkup(gomock.Any(), db.GetFxMarkupParams{FromCurrency: "USD", ToCurrency: "EUR"}).
+					Return(db.FxMarkup{FromCurrency: "USD", ToCurrency: "EUR", MarkupBps: 50}, nil).
+					Times(1)
+			},
+			expectedMarkupBps:    50,
+			expectedCustomerRate: "0.84575",
+			expectedToReceive:    "84.58",
+		},
+		{
+			name:                 "DefaultMarkup",
+			defaultMarkupBps:     100,
+			buildStubs:           expectNoFxMarkup,
+			expectedMarkupBps:    100,
+			expectedCustomerRate: "0.8415",
+			expectedToReceive:    "84.15",
+		},
+		{
+			name:                 "NoMarkup",
+			buildStubs:           expectNoFxMarkup,
+			expectedCustomerRate: "0.85",
+			expectedToReceive:    "85",
+		},
+		{
+			name: "MarkupLookupFails",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetFxMarkup(gomock.Any(), gomock.Any()).
+					Return(db.FxMarkup{}, errors.New("database error")).
+					Times(1)
+			},
+			expectedErr: errors.New("database error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			expectExchangeRate(store, "USD", "EUR", newExchangeRate(1, "USD", "EUR", "0.85", time.Now()), nil)
+			tc.buildStubs(store)
+			if tc.expectedErr == nil {
+				expectNoFeeSchedule(store)
+			}
+
+			exchangeRateService := NewExchangeRateService(testhelpers.NewMockExchangeRateRepository(store))
+			exchangeRateService.defaultMarkupBps = tc.defaultMarkupBps
+
+			response, err := exchangeRateService.GetExchangeRate(context.Background(), request)
+			if tc.expectedErr != nil {
+				require.EqualError(t, err, tc.expectedErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			// The mid-market rate is still reported alongside the customer rate
+			require.True(t, decimal.RequireFromString("0.85").Equal(response.ExchangeRate.Rate))
+			require.Equal(t, tc.expectedMarkupBps, response.MarkupBps)
+			require.True(t, decimal.RequireFromString(tc.expectedCustomerRate).Equal(response.CustomerRate), response.CustomerRate.String())
+			require.True(t, decimal.RequireFromString(tc.expectedToReceive).Equal(response.AmountToReceive), response.AmountToReceive.String())
+		})
+	}
+}
+
+func TestSetFxMarkupService(t *testing.T) {
+	markupBps := func(bps int32) *int32 {
+		return &bps
+	}
+
+	testCases := []struct {
+		name        string
+		request     requests.SetFxMarkupRequest
+		buildStubs  func(store *mockdb.MockStore)
+		expectedErr error
+	}{
+		{
+			name:    "OK",
+			request: requests.SetFxMarkupRequest{FromCurrency: "GBP", ToCurrency: "NGN", MarkupBps: markupBps(75)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertFxMarkup(gomock.Any(), db.UpsertFxMarkupParams{FromCurrency: "GBP", ToCurrency: "NGN", MarkupBps: 75}).
+					Return(db.FxMarkup{ID: 1, FromCurrency: "GBP", ToCurrency: "NGN", MarkupBps: 75}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:    "ZeroMarkup",
+			request: requests.SetFxMarkupRequest{FromCurrency: "GBP", ToCurrency: "NGN", MarkupBps: markupBps(0)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpsertFxMarkup(gomock.Any(), gomock.Any()).Return(db.FxMarkup{}, nil).Times(1)
+			},
+		},
+		{
+			name:        "NegativeMarkup",
+			request:     requests.SetFxMarkupRequest{FromCurrency: "GBP", ToCurrency: "NGN", MarkupBps: markupBps(-1)},
+			buildStubs:  func(store *mockdb.MockStore) {},
+			expectedErr: exchangeRateErrors.ErrInvalidMarkup,
+		},
+		{
+			name:        "MarkupTooHigh",
+			request:     requests.SetFxMarkupRequest{FromCurrency: "GBP", ToCurrency: "NGN", MarkupBps: markupBps(config.MaxMarkupBps + 1)},
+			buildStubs:  func(store *mockdb.MockStore) {},
+			expectedErr: exchangeRateErrors.ErrInvalidMarkup,
+		},
+		{
+			name:        "UnsupportedCurrency",
+			request:     requests.SetFxMarkupRequest{FromCurrency: "GBP", ToCurrency: "JPY", MarkupBps: markupBps(50)},
+			buildStubs:  func(store *mockdb.MockStore) {},
+			expectedErr: exchangeRateErrors.ErrUnsupportedCurrency,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			exchangeRateService := NewExchangeRateService(testhelpers.NewMockExchangeRateRepository(store))
+			_, err := exchangeRateService.SetFxMarkup(context.Background(), tc.request)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDeleteFxMarkupService_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().DeleteFxMarkup(gomock.Any(), gomock.Any()).Return(int64(0), nil).Times(1)
+
+	exchangeRateService := NewExchangeRateService(testhelpers.NewMockExchangeRateRepository(store))
+	err := exchangeRateService.DeleteFxMarkup(context.Background(), requests.CurrencyPair{FromCurrency: "GBP", ToCurrency: "NGN"})
+	require.ErrorIs(t, err, exchangeRateErrors.ErrFxMarkupNotFound)
+}
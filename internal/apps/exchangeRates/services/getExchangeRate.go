@@ -36,20 +36,33 @@ func (exchangeRateService *ExchangeRateService) GetExchangeRate(ctx context.Cont
 		return responses.GetExchangeRateResponse{}, exchangeRateErrors.ErrInvalidAmount
 	}
 
-	dbExchangeRate, err := exchangeRateService.exchangeRateRepository.GetExchangeRate(ctx, payload)
+	if !currencies.HasValidPrecision(payload.Amount, currencies.Currency(payload.FromCurrency)) {
+		config.Logger.Error("Amount has too many decimal places", "amount", payload.Amount.String(), "currency", payload.FromCurrency)
+		return responses.GetExchangeRateResponse{}, exchangeRateErrors.ErrAmountPrecision
+	}
+
+	exchangeRate, dbExchangeRate, err := exchangeRateService.resolveExchangeRate(ctx, payload)
 	if err != nil {
 		config.Logger.Error("Service: Failed to get exchange rate", "error", err.Error())
 		return responses.GetExchangeRateResponse{}, err
 	}
 
-	exchangeRate := responses.NewExchangeRateResponse(dbExchangeRate)
+	// Stored rates are mid-market rates, the customer is priced at the rate less the markup of the pair
+	markupBps, err := exchangeRateService.markupBps(ctx, payload.FromCurrency, payload.ToCurrency)
+	if err != nil {
+		return responses.GetExchangeRateResponse{}, err
+	}
+	customerRate := applyMarkup(dbExchangeRate.Rate, markupBps)
 
 	amountToSend := payload.Amount
-	amountToReceive := payload.Amount.Mul(dbExchangeRate.Rate).Round(2)
+	amountToReceive := currencies.Round(payload.Amount.Mul(customerRate), currencies.Currency(payload.ToCurrency))
 
-	// Calculate fee based on configuration
-	cfg := config.Get()
-	fee := cfg.MultiCurrency.Fee
+	transferFee, err := exchangeRateService.CalculateFee(ctx, payload.FromCurrency, payload.ToCurrency, amountToSend)
+	if err != nil {
+		return responses.GetExchangeRateResponse{}, err
+	}
+
+	fee := transferFee.Fee
 	totalAmount := amountToSend.Add(fee)
 
 	canTransact := false
@@ -61,16 +74,23 @@ func (exchangeRateService *ExchangeRateService) GetExchangeRate(ctx context.Cont
 
 	response := responses.GetExchangeRateResponse{
 		ExchangeRate:    exchangeRate,
+		CustomerRate:    customerRate,
+		MarkupBps:       markupBps,
 		AmountToSend:    amountToSend,
 		AmountToReceive: amountToReceive,
 		Fee:             fee,
 		TotalAmount:     totalAmount,
 		CanTransact:     canTransact,
 		Message:         message,
+
+		FeeScheduleID:      transferFee.FeeScheduleID,
+		FeeScheduleVersion: transferFee.FeeScheduleVersion,
 	}
 
 	config.Logger.Info("Successfully fetched exchange rate",
 		"rate", response.ExchangeRate.Rate,
+		"customer_rate", customerRate,
+		"markup_bps", markupBps,
 		"amount_to_send", amountToSend.String(),
 		"amount_to_receive", amountToReceive.String(),
 		"fee", fee.String(),
@@ -79,6 +99,7 @@ func (exchangeRateService *ExchangeRateService) GetExchangeRate(ctx context.Cont
 
 	config.Logger.Info("Service: Successfully got exchange rate",
 		"rate", response.ExchangeRate.Rate.String(),
+		"derived", response.ExchangeRate.Derived,
 		"can_transact", response.CanTransact,
 	)
 
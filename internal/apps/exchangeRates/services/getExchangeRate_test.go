@@ -8,6 +8,8 @@ import (
 
 	mockdb "lemfi/simplebank/db/mock"
 	db "lemfi/simplebank/db/sqlc"
+	"lemfi/simplebank/internal/apps/currencies"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
 	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
 	responses "lemfi/simplebank/internal/apps/exchangeRates/responses"
 	respositories "lemfi/simplebank/internal/apps/exchangeRates/respositories"
@@ -227,3 +229,63 @@ func TestGetExchangeRateService_ZeroAmount(t *testing.T) {
 	require.Equal(t, "invalid amount", err.Error())
 	require.Empty(t, result.ExchangeRate)
 }
+
+func TestGetExchangeRateService_MinorUnits(t *testing.T) {
+	previous := currencies.List()
+	t.Cleanup(func() { currencies.Load(previous) })
+	currencies.Load(append(previous,
+		currencies.Definition{Code: "JPY", MinorUnits: 0, Symbol: "¥", Enabled: true},
+		currencies.Definition{Code: "KWD", MinorUnits: 3, Symbol: "KD", Enabled: true},
+	))
+
+	testCases := []struct {
+		name              string
+		request           requests.GetExchangeRateRequest
+		rate              string
+		expectedToReceive string
+		expectedErr       error
+	}{
+		{
+			name:              "ZeroDecimalCurrency",
+			request:           requests.GetExchangeRateRequest{FromCurrency: "USD", ToCurrency: "JPY", Amount: decimal.RequireFromString("100.55")},
+			rate:              "150.12345",
+			expectedToReceive: "15095",
+		},
+		{
+			name:              "ThreeDecimalCurrency",
+			request:           requests.GetExchangeRateRequest{FromCurrency: "USD", ToCurrency: "KWD", Amount: decimal.RequireFromString("100.55")},
+			rate:              "0.30712",
+			expectedToReceive: "30.881",
+		},
+		{
+			name:        "AmountFinerThanCurrency",
+			request:     requests.GetExchangeRateRequest{FromCurrency: "JPY", ToCurrency: "USD", Amount: decimal.RequireFromString("1000.5")},
+			expectedErr: exchangeRateErrors.ErrAmountPrecision,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			if tc.expectedErr == nil {
+				expectExchangeRate(store, tc.request.FromCurrency, tc.request.ToCurrency,
+					newExchangeRate(1, tc.request.FromCurrency, tc.request.ToCurrency, tc.rate, time.Now()), nil)
+				expectNoFxMarkup(store)
+				expectNoFeeSchedule(store)
+			}
+
+			exchangeRateService := NewExchangeRateService(testhelpers.NewMockExchangeRateRepository(store))
+			response, err := exchangeRateService.GetExchangeRate(context.Background(), tc.request)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, decimal.RequireFromString(tc.expectedToReceive).Equal(response.AmountToReceive), response.AmountToReceive.String())
+		})
+	}
+}
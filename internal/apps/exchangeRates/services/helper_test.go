@@ -0,0 +1,81 @@
+package exchangeRates
+
+import (
+	"time"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	testhelpers "lemfi/simplebank/internal/apps/exchangeRates/testHelpers"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// newMockRateRefresher refreshes the rates of provider into the mock store every minute
+func newMockRateRefresher(store *mockdb.MockStore, provider *stubRateProvider) *RateRefresher {
+	return &RateRefresher{
+		exchangeRateRepository: testhelpers.NewMockExchangeRateRepository(store),
+		provider:               provider,
+		interval:               time.Minute,
+		retryBackoff:           10 * time.Second,
+		maxRetryBackoff:        time.Minute,
+	}
+}
+
+// newExchangeRate is the rate of a pair last updated at updatedAt
+func newExchangeRate(id int64, fromCurrency, toCurrency, rate string, updatedAt time.Time) db.ExchangeRate {
+	return db.ExchangeRate{
+		ID:           id,
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		Rate:         decimal.RequireFromString(rate),
+		CreatedAt:    pgtype.Timestamptz{Time: updatedAt, Valid: true},
+		UpdatedAt:    pgtype.Timestamptz{Time: updatedAt, Valid: true},
+	}
+}
+
+// newFeeSchedule is the active GBP to NGN fee schedule, without a maximum fee when maxFee is empty
+func newFeeSchedule(fixedFee, percentage, minFee, maxFee string, tiers ...db.FeeScheduleTier) db.FeeScheduleTxResult {
+	schedule := db.FeeSchedule{
+		ID:           3,
+		FromCurrency: "GBP",
+		ToCurrency:   "NGN",
+		Version:      2,
+		FixedFee:     decimal.RequireFromString(fixedFee),
+		Percentage:   decimal.RequireFromString(percentage),
+		MinFee:       decimal.RequireFromString(minFee),
+		Active:       true,
+	}
+
+	if maxFee != "" {
+		schedule.MaxFee = decimal.NewNullDecimal(decimal.RequireFromString(maxFee))
+	}
+
+	return db.FeeScheduleTxResult{FeeSchedule: schedule, Tiers: tiers}
+}
+
+// newFeeTier is a tier of newFeeSchedule
+func newFeeTier(minAmount, fixedFee, percentage string) db.FeeScheduleTier {
+	return db.FeeScheduleTier{
+		FeeScheduleID: 3,
+		MinAmount:     decimal.RequireFromString(minAmount),
+		FixedFee:      decimal.RequireFromString(fixedFee),
+		Percentage:    decimal.RequireFromString(percentage),
+	}
+}
+
+// newFeeScheduleRequest sets a GBP to NGN fee schedule with tiers
+func newFeeScheduleRequest(tiers ...requests.FeeTierRequest) requests.SetFeeScheduleRequest {
+	maxFee := decimal.RequireFromString("50")
+
+	return requests.SetFeeScheduleRequest{
+		FromCurrency: "GBP",
+		ToCurrency:   "NGN",
+		FixedFee:     decimal.RequireFromString("1.50"),
+		Percentage:   decimal.RequireFromString("0.75"),
+		MinFee:       decimal.RequireFromString("2"),
+		MaxFee:       &maxFee,
+		Tiers:        tiers,
+	}
+}
@@ -5,9 +5,25 @@ import (
 
 	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
 	responses "lemfi/simplebank/internal/apps/exchangeRates/responses"
+
+	"github.com/shopspring/decimal"
 )
 
 type ExchangeRateServiceInterface interface {
 	GetExchangeRate(ctx context.Context, payload requests.GetExchangeRateRequest) (responses.GetExchangeRateResponse, error)
 	ListExchangeRates(ctx context.Context) (responses.ListExchangeRatesResponse, error)
+	QuoteExchangeRate(ctx context.Context, payload requests.GetExchangeRateRequest) (responses.GetExchangeRateResponse, error)
+	CreateExchangeRate(ctx context.Context, payload requests.CreateExchangeRateRequest) (responses.ExchangeRatePairResponse, error)
+	UpdateExchangeRate(ctx context.Context, payload requests.UpdateExchangeRateRequest) (responses.ExchangeRatePairResponse, error)
+	DeleteExchangeRate(ctx context.Context, payload requests.DeleteExchangeRateRequest) error
+	ListExchangeRateHistory(ctx context.Context, payload requests.ListExchangeRateHistoryRequest) (responses.ListExchangeRateHistoryResponse, error)
+	GetRateRefreshStatus() responses.RateRefreshStatusResponse
+	CalculateFee(ctx context.Context, fromCurrency, toCurrency string, amount decimal.Decimal) (responses.TransferFee, error)
+	ListFeeSchedules(ctx context.Context) (responses.ListFeeSchedulesResponse, error)
+	GetFeeSchedule(ctx context.Context, pair requests.CurrencyPair) (responses.FeeScheduleResponse, error)
+	SetFeeSchedule(ctx context.Context, payload requests.SetFeeScheduleRequest) (responses.FeeScheduleResponse, error)
+	DeleteFeeSchedule(ctx context.Context, pair requests.CurrencyPair) error
+	ListFxMarkups(ctx context.Context) (responses.ListFxMarkupsResponse, error)
+	SetFxMarkup(ctx context.Context, payload requests.SetFxMarkupRequest) (responses.FxMarkupResponse, error)
+	DeleteFxMarkup(ctx context.Context, pair requests.CurrencyPair) error
 }
@@ -0,0 +1,159 @@
+package exchangeRates
+
+import (
+	"context"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	"lemfi/simplebank/internal/apps/currencies"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	responses "lemfi/simplebank/internal/apps/exchangeRates/responses"
+
+	"github.com/shopspring/decimal"
+)
+
+// exchangeRatePlaces matches the precision of the exchange_rates.rate column
+const exchangeRatePlaces = 8
+
+// Bounds on a rate set by an admin or a rate provider. They are reciprocal so the inverse pair is
+// always in range too, and catch typos such as a missing decimal point rather than restrict real currencies
+var (
+	minExchangeRate = decimal.RequireFromString("0.000001")
+	maxExchangeRate = decimal.NewFromInt(1_000_000)
+)
+
+func (exchangeRateService *ExchangeRateService) CreateExchangeRate(ctx context.Context, payload requests.CreateExchangeRateRequest) (responses.ExchangeRatePairResponse, error) {
+	config.Logger.Info("Service: Creating exchange rate",
+		"from_currency", payload.FromCurrency,
+		"to_currency", payload.ToCurrency,
+		"rate", payload.Rate.String(),
+	)
+
+	params, err := newExchangeRatePairParams(payload.FromCurrency, payload.ToCurrency, payload.Rate)
+	if err != nil {
+		return responses.ExchangeRatePairResponse{}, err
+	}
+
+	pair, err := exchangeRateService.exchangeRateRepository.CreateExchangeRate(ctx, params)
+	if err != nil {
+		config.Logger.Error("Service: Failed to create exchange rate", "error", err.Error())
+		return responses.ExchangeRatePairResponse{}, err
+	}
+
+	config.Logger.Info("Service: Successfully created exchange rate",
+		"exchange_rate_id", pair.ExchangeRate.ID,
+		"inverse_exchange_rate_id", pair.InverseExchangeRate.ID,
+	)
+
+	return responses.NewExchangeRatePairResponse(pair), nil
+}
+
+func (exchangeRateService *ExchangeRateService) UpdateExchangeRate(ctx context.Context, payload requests.UpdateExchangeRateRequest) (responses.ExchangeRatePairResponse, error) {
+	config.Logger.Info("Service: Updating exchange rate",
+		"from_currency", payload.FromCurrency,
+		"to_currency", payload.ToCurrency,
+		"rate", payload.Rate.String(),
+	)
+
+	params, err := newExchangeRatePairParams(payload.FromCurrency, payload.ToCurrency, payload.Rate)
+	if err != nil {
+		return responses.ExchangeRatePairResponse{}, err
+	}
+
+	pair, err := exchangeRateService.exchangeRateRepository.UpdateExchangeRate(ctx, params)
+	if err != nil {
+		config.Logger.Error("Service: Failed to update exchange rate", "error", err.Error())
+		return responses.ExchangeRatePairResponse{}, err
+	}
+
+	config.Logger.Info("Service: Successfully updated exchange rate",
+		"exchange_rate_id", pair.ExchangeRate.ID,
+		"inverse_exchange_rate_id", pair.InverseExchangeRate.ID,
+	)
+
+	return responses.NewExchangeRatePairResponse(pair), nil
+}
+
+func (exchangeRateService *ExchangeRateService) DeleteExchangeRate(ctx context.Context, payload requests.DeleteExchangeRateRequest) error {
+	config.Logger.Info("Service: Deleting exchange rate",
+		"from_currency", payload.FromCurrency,
+		"to_currency", payload.ToCurrency,
+	)
+
+	err := validateCurrencyPair(payload.FromCurrency, payload.ToCurrency)
+	if err != nil {
+		return err
+	}
+
+	err = exchangeRateService.exchangeRateRepository.DeleteExchangeRate(ctx, db.DeleteExchangeRateParams{
+		FromCurrency: payload.FromCurrency,
+		ToCurrency:   payload.ToCurrency,
+	})
+	if err != nil {
+		config.Logger.Error("Service: Failed to delete exchange rate", "error", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// newExchangeRatePairParams validates a rate set by an admin and derives the rate of the inverse pair
+func newExchangeRatePairParams(fromCurrency, toCurrency string, rate decimal.Decimal) (db.ExchangeRatePairTxParams, error) {
+	err := validateCurrencyPair(fromCurrency, toCurrency)
+	if err != nil {
+		return db.ExchangeRatePairTxParams{}, err
+	}
+
+	err = validateExchangeRateRange(rate)
+	if err != nil {
+		return db.ExchangeRatePairTxParams{}, err
+	}
+
+	// The column would round silently, so reject rates it cannot store exactly
+	if !rate.Equal(rate.Round(exchangeRatePlaces)) {
+		config.Logger.Error("Exchange rate has too many decimal places", "rate", rate.String())
+		return db.ExchangeRatePairTxParams{}, exchangeRateErrors.ErrExchangeRatePrecision
+	}
+
+	return db.ExchangeRatePairTxParams{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		Rate:         rate,
+		InverseRate:  decimal.NewFromInt(1).DivRound(rate, exchangeRatePlaces),
+	}, nil
+}
+
+// validateExchangeRateRange rejects non-positive rates and rates outside the accepted bounds
+func validateExchangeRateRange(rate decimal.Decimal) error {
+	if rate.LessThanOrEqual(decimal.Zero) {
+		config.Logger.Error("Invalid exchange rate", "rate", rate.String())
+		return exchangeRateErrors.ErrInvalidExchangeRate
+	}
+
+	if rate.LessThan(minExchangeRate) || rate.GreaterThan(maxExchangeRate) {
+		config.Logger.Error("Exchange rate out of range", "rate", rate.String())
+		return exchangeRateErrors.ErrExchangeRateOutOfRange
+	}
+
+	return nil
+}
+
+func validateCurrencyPair(fromCurrency, toCurrency string) error {
+	if !currencies.IsSupportedCurrency(currencies.Currency(fromCurrency)) {
+		config.Logger.Error("From currency is not supported", "currency", fromCurrency)
+		return exchangeRateErrors.ErrUnsupportedCurrency
+	}
+
+	if !currencies.IsSupportedCurrency(currencies.Currency(toCurrency)) {
+		config.Logger.Error("To currency is not supported", "currency", toCurrency)
+		return exchangeRateErrors.ErrUnsupportedCurrency
+	}
+
+	if fromCurrency == toCurrency {
+		config.Logger.Error("Exchange rate currencies must differ", "currency", fromCurrency)
+		return exchangeRateErrors.ErrInvalidCurrencyPair
+	}
+
+	return nil
+}
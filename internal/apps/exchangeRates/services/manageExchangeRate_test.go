@@ -0,0 +1,168 @@
+package exchangeRates
+
+import (
+	"context"
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	testhelpers "lemfi/simplebank/internal/apps/exchangeRates/testHelpers"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateExchangeRateService(t *testing.T) {
+	testCases := []struct {
+		name        string
+		request     requests.CreateExchangeRateRequest
+		buildStubs  func(store *mockdb.MockStore)
+		expectedErr error
+	}{
+		{
+			name: "OK",
+			request: requests.CreateExchangeRateRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "NGN",
+				Rate:         decimal.RequireFromString("1500"),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateExchangeRateTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, arg db.ExchangeRatePairTxParams) (db.ExchangeRatePairTxResult, error) {
+						require.True(t, arg.InverseRate.Equal(decimal.RequireFromString("0.00066667")))
+						return db.ExchangeRatePairTxResult{
+							ExchangeRate:        db.ExchangeRate{ID: 1, FromCurrency: "USD", ToCurrency: "NGN", Rate: arg.Rate},
+							InverseExchangeRate: db.ExchangeRate{ID: 2, FromCurrency: "NGN", ToCurrency: "USD", Rate: arg.InverseRate},
+						}, nil
+					}).
+					Times(1)
+			},
+		},
+		{
+			name: "UnsupportedCurrency",
+			request: requests.CreateExchangeRateRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "JPY",
+				Rate:         decimal.RequireFromString("150"),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateExchangeRateTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: exchangeRateErrors.ErrUnsupportedCurrency,
+		},
+		{
+			name: "SameCurrency",
+			request: requests.CreateExchangeRateRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "USD",
+				Rate:         decimal.RequireFromString("1"),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateExchangeRateTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: exchangeRateErrors.ErrInvalidCurrencyPair,
+		},
+		{
+			name: "NegativeRate",
+			request: requests.CreateExchangeRateRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "EUR",
+				Rate:         decimal.RequireFromString("-0.85"),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateExchangeRateTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: exchangeRateErrors.ErrInvalidExchangeRate,
+		},
+		{
+			name: "AbsurdRate",
+			request: requests.CreateExchangeRateRequest{
+				FromCurrency: "GBP",
+				ToCurrency:   "NGN",
+				Rate:         decimal.RequireFromString("20000000"),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateExchangeRateTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: exchangeRateErrors.ErrExchangeRateOutOfRange,
+		},
+		{
+			name: "TooManyDecimalPlaces",
+			request: requests.CreateExchangeRateRequest{
+				FromCurrency: "USD",
+				ToCurrency:   "EUR",
+				Rate:         decimal.RequireFromString("0.123456789"),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateExchangeRateTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: exchangeRateErrors.ErrExchangeRatePrecision,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			exchangeRateService := NewExchangeRateService(testhelpers.NewMockExchangeRateRepository(store))
+
+			result, err := exchangeRateService.CreateExchangeRate(context.Background(), tc.request)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "USD", result.ExchangeRate.FromCurrency)
+			require.Equal(t, "NGN", result.InverseExchangeRate.FromCurrency)
+			require.Equal(t, "USD", result.InverseExchangeRate.ToCurrency)
+		})
+	}
+}
+
+func TestUpdateExchangeRateService_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		UpdateExchangeRateTx(gomock.Any(), gomock.Any()).
+		Return(db.ExchangeRatePairTxResult{}, pgx.ErrNoRows).
+		Times(1)
+
+	exchangeRateService := NewExchangeRateService(testhelpers.NewMockExchangeRateRepository(store))
+
+	_, err := exchangeRateService.UpdateExchangeRate(context.Background(), requests.UpdateExchangeRateRequest{
+		FromCurrency: "EUR",
+		ToCurrency:   "GBP",
+		Rate:         decimal.RequireFromString("0.86"),
+	})
+	require.ErrorIs(t, err, exchangeRateErrors.ErrExchangeRateNotFound)
+}
+
+func TestDeleteExchangeRateService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		DeleteExchangeRateTx(gomock.Any(), gomock.Eq(db.DeleteExchangeRateParams{FromCurrency: "EUR", ToCurrency: "GBP"})).
+		Return(nil).
+		Times(1)
+
+	exchangeRateService := NewExchangeRateService(testhelpers.NewMockExchangeRateRepository(store))
+
+	err := exchangeRateService.DeleteExchangeRate(context.Background(), requests.DeleteExchangeRateRequest{
+		FromCurrency: "EUR",
+		ToCurrency:   "GBP",
+	})
+	require.NoError(t, err)
+}
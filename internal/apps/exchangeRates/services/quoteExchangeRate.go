@@ -0,0 +1,61 @@
+package exchangeRates
+
+import (
+	"context"
+	"time"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	responses "lemfi/simplebank/internal/apps/exchangeRates/responses"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// QuoteExchangeRate prices a conversion like GetExchangeRate and, when the rate can be transacted,
+// locks the rate, amounts and fee in a quote for the user. A transfer made with the quote id
+// gets exactly the quoted amounts until the quote expires, even if the live rate moves
+func (exchangeRateService *ExchangeRateService) QuoteExchangeRate(ctx context.Context, payload requests.GetExchangeRateRequest) (responses.GetExchangeRateResponse, error) {
+	response, err := exchangeRateService.GetExchangeRate(ctx, payload)
+	if err != nil || !response.CanTransact {
+		return response, err
+	}
+
+	params := db.CreateFxQuoteParams{
+		Username:        payload.Username,
+		FromCurrency:    payload.FromCurrency,
+		ToCurrency:      payload.ToCurrency,
+		Rate:            response.CustomerRate,
+		Amount:          response.AmountToSend,
+		ConvertedAmount: response.AmountToReceive,
+		Fee:             response.Fee,
+		ExpiresAt:       time.Now().Add(config.Get().ExchangeRate.QuoteExpiry),
+		MidMarketRate:   decimal.NewNullDecimal(response.ExchangeRate.Rate),
+		MarkupBps:       pgtype.Int4{Int32: response.MarkupBps, Valid: true},
+	}
+
+	if response.FeeScheduleID != 0 {
+		params.FeeScheduleID = pgtype.Int8{Int64: response.FeeScheduleID, Valid: true}
+	}
+
+	// Keep the legs of a derived rate, so the transfer made with the quote records them too
+	if pivotRate := response.ExchangeRate.PivotRate(); pivotRate != nil {
+		params.PivotCurrency = pgtype.Text{String: pivotRate.Currency, Valid: true}
+		params.FromPivotRate = decimal.NewNullDecimal(pivotRate.FromRate)
+		params.PivotToRate = decimal.NewNullDecimal(pivotRate.ToRate)
+	}
+
+	quote, err := exchangeRateService.exchangeRateRepository.CreateQuote(ctx, params)
+	if err != nil {
+		config.Logger.Error("Service: Failed to create quote", "error", err.Error())
+		return responses.GetExchangeRateResponse{}, err
+	}
+
+	response.QuoteID = quote.ID
+	response.QuoteExpiresAt = &quote.ExpiresAt
+
+	config.Logger.Info("Service: Quoted exchange rate", "quote_id", quote.ID, "username", payload.Username, "expires_at", quote.ExpiresAt)
+
+	return response, nil
+}
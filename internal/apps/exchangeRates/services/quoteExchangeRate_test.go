@@ -0,0 +1,90 @@
+package exchangeRates
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	testhelpers "lemfi/simplebank/internal/apps/exchangeRates/testHelpers"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestQuoteExchangeRateService(t *testing.T) {
+	request := requests.GetExchangeRateRequest{
+		FromCurrency: "USD",
+		ToCurrency:   "EUR",
+		Amount:       decimal.NewFromInt(100),
+		Username:     "test_owner",
+	}
+
+	testCases := []struct {
+		name          string
+		updatedAt     time.Time
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, quoteID int64, quoteExpiresAt *time.Time, canTransact bool)
+	}{
+		{
+			name: "FreshRateIsQuoted",
+			// Updated in the future so the rate has not expired whatever the configured expiry
+			updatedAt: time.Now().Add(time.Hour),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateFxQuote(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ any, arg db.CreateFxQuoteParams) (db.FxQuote, error) {
+						require.Equal(t, "test_owner", arg.Username)
+						require.Equal(t, "USD", arg.FromCurrency)
+						require.Equal(t, "EUR", arg.ToCurrency)
+						require.True(t, decimal.RequireFromString("0.85").Equal(arg.Rate))
+						require.True(t, decimal.NewFromInt(100).Equal(arg.Amount))
+						require.True(t, decimal.NewFromInt(85).Equal(arg.ConvertedAmount))
+						require.True(t, arg.MidMarketRate.Valid)
+						require.True(t, decimal.RequireFromString("0.85").Equal(arg.MidMarketRate.Decimal))
+						require.Equal(t, pgtype.Int4{Int32: 0, Valid: true}, arg.MarkupBps)
+						return db.FxQuote{ID: 5, ExpiresAt: arg.ExpiresAt}, nil
+					}).Times(1)
+			},
+			checkResponse: func(t *testing.T, quoteID int64, quoteExpiresAt *time.Time, canTransact bool) {
+				require.True(t, canTransact)
+				require.Equal(t, int64(5), quoteID)
+				require.NotNil(t, quoteExpiresAt)
+			},
+		},
+		{
+			name:      "ExpiredRateIsNotQuoted",
+			updatedAt: time.Now().Add(-24 * time.Hour),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateFxQuote(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, quoteID int64, quoteExpiresAt *time.Time, canTransact bool) {
+				require.False(t, canTransact)
+				require.Zero(t, quoteID)
+				require.Nil(t, quoteExpiresAt)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetExchangeRate(gomock.Any(), gomock.Any()).Return(newExchangeRate(1, "USD", "EUR", "0.85", tc.updatedAt), nil).Times(1)
+			expectNoFxMarkup(store)
+			expectNoFeeSchedule(store)
+			tc.buildStubs(store)
+
+			service := NewExchangeRateService(testhelpers.NewMockExchangeRateRepository(store))
+			response, err := service.QuoteExchangeRate(context.Background(), request)
+
+			require.NoError(t, err)
+			tc.checkResponse(t, response.QuoteID, response.QuoteExpiresAt, response.CanTransact)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package exchangeRates
+
+import (
+	responses "lemfi/simplebank/internal/apps/exchangeRates/responses"
+)
+
+// GetRateRefreshStatus reports on the rate refresher started by the server, if any
+func (exchangeRateService *ExchangeRateService) GetRateRefreshStatus() responses.RateRefreshStatusResponse {
+	refresher := activeRateRefresher.Load()
+	if refresher == nil {
+		return responses.RateRefreshStatusResponse{Enabled: false}
+	}
+
+	return refresher.Status()
+}
@@ -0,0 +1,206 @@
+package exchangeRates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	"lemfi/simplebank/internal/apps/currencies"
+	providers "lemfi/simplebank/internal/apps/exchangeRates/providers"
+	responses "lemfi/simplebank/internal/apps/exchangeRates/responses"
+	respositories "lemfi/simplebank/internal/apps/exchangeRates/respositories"
+)
+
+var errNoProviderRates = errors.New("rate provider returned no rates for the supported currencies")
+
+// activeRateRefresher is the refresher started by the server, read by the refresh status endpoint
+var activeRateRefresher atomic.Pointer[RateRefresher]
+
+// RateRefresher keeps exchange_rates up to date from a RateProvider in the background.
+// A refresh renews updated_at, so the rates stay tradeable while the provider is reachable
+type RateRefresher struct {
+	exchangeRateRepository respositories.ExchangeRateRepositoryInterface
+	provider               providers.RateProvider
+	interval               time.Duration
+	retryBackoff           time.Duration
+	maxRetryBackoff        time.Duration
+
+	mu                    sync.RWMutex
+	lastSuccessfulRefresh time.Time
+	lastError             string
+	consecutiveFailures   int
+}
+
+func NewRateRefresher(exchangeRateRepository respositories.ExchangeRateRepositoryInterface, provider providers.RateProvider) *RateRefresher {
+	cfg := config.Get()
+	return &RateRefresher{
+		exchangeRateRepository: exchangeRateRepository,
+		provider:               provider,
+		interval:               cfg.ExchangeRate.Provider.RefreshInterval,
+		retryBackoff:           cfg.ExchangeRate.Provider.RetryBackoff,
+		maxRetryBackoff:        cfg.ExchangeRate.Provider.MaxRetryBackoff,
+	}
+}
+
+// SetActiveRateRefresher makes the refresher's status available to the exchange rate endpoints
+func SetActiveRateRefresher(refresher *RateRefresher) {
+	activeRateRefresher.Store(refresher)
+}
+
+// Start refreshes the rates every interval until the context is cancelled.
+// After a failed refresh it retries sooner, backing off exponentially up to the maximum backoff
+func (refresher *RateRefresher) Start(ctx context.Context) {
+	if refresher.interval <= 0 {
+		config.Logger.Info("Exchange rate refresher disabled")
+		return
+	}
+
+	expiry := time.Duration(config.Get().ExchangeRate.ExpiredTimeInMinutes) * time.Minute
+	if refresher.interval >= expiry {
+		config.Logger.Warn("Exchange rate refresh interval is not shorter than the rate expiry, rates will expire between refreshes",
+			"refresh_interval", refresher.interval,
+			"expiry", expiry,
+		)
+	}
+
+	config.Logger.Info("Exchange rate refresher started", "refresh_interval", refresher.interval)
+
+	for {
+		delay := refresher.interval
+		if _, err := refresher.Refresh(ctx); err != nil {
+			delay = refresher.nextRetryDelay()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			config.Logger.Info("Exchange rate refresher stopped")
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// Refresh fetches the rates between all supported currencies and stores them in one transaction,
+// returning how many pairs were stored. Nothing is stored if any base currency fails to fetch
+func (refresher *RateRefresher) Refresh(ctx context.Context) (int, error) {
+	params, err := refresher.fetchRates(ctx)
+	if err == nil {
+		_, err = refresher.exchangeRateRepository.RefreshExchangeRates(ctx, params)
+	}
+
+	refresher.mu.Lock()
+	defer refresher.mu.Unlock()
+
+	if err != nil {
+		refresher.consecutiveFailures++
+		refresher.lastError = err.Error()
+		config.Logger.Error("Failed to refresh exchange rates", "error", err.Error(), "consecutive_failures", refresher.consecutiveFailures)
+		return 0, err
+	}
+
+	refresher.consecutiveFailures = 0
+	refresher.lastError = ""
+	refresher.lastSuccessfulRefresh = time.Now()
+	config.Logger.Info("Refreshed exchange rates", "pairs", len(params))
+
+	return len(params), nil
+}
+
+// LastSuccessfulRefresh returns when the rates were last stored, zero if they never were
+func (refresher *RateRefresher) LastSuccessfulRefresh() time.Time {
+	refresher.mu.RLock()
+	defer refresher.mu.RUnlock()
+	return refresher.lastSuccessfulRefresh
+}
+
+// Status reports the outcome of the recent refreshes
+func (refresher *RateRefresher) Status() responses.RateRefreshStatusResponse {
+	refresher.mu.RLock()
+	defer refresher.mu.RUnlock()
+
+	status := responses.RateRefreshStatusResponse{
+		Enabled:             refresher.interval > 0,
+		RefreshInterval:     refresher.interval.String(),
+		LastError:           refresher.lastError,
+		ConsecutiveFailures: refresher.consecutiveFailures,
+	}
+	if !refresher.lastSuccessfulRefresh.IsZero() {
+		lastSuccessfulRefresh := refresher.lastSuccessfulRefresh
+		status.LastSuccessfulRefreshAt = &lastSuccessfulRefresh
+	}
+
+	return status
+}
+
+func (refresher *RateRefresher) fetchRates(ctx context.Context) ([]db.UpsertExchangeRateParams, error) {
+	var params []db.UpsertExchangeRateParams
+
+	for _, base := range currencies.SupportedCurrencies() {
+		rates, err := refresher.provider.FetchRates(ctx, string(base))
+		if err != nil {
+			return nil, err
+		}
+
+		for quote, rate := range rates {
+			// Providers quote more currencies than the bank supports
+			if quote == string(base) || !currencies.IsSupportedCurrency(currencies.Currency(quote)) {
+				continue
+			}
+
+			rate = rate.Round(exchangeRatePlaces)
+			if err := validateExchangeRateRange(rate); err != nil {
+				return nil, fmt.Errorf("rate provider returned %s for %s to %s: %w", rate.String(), base, quote, err)
+			}
+
+			params = append(params, db.UpsertExchangeRateParams{
+				FromCurrency: string(base),
+				ToCurrency:   quote,
+				Rate:         rate,
+			})
+		}
+	}
+
+	if len(params) == 0 {
+		return nil, errNoProviderRates
+	}
+
+	// Write the pairs in a fixed order so concurrent writers lock rows in the same order
+	sort.Slice(params, func(i, j int) bool {
+		if params[i].FromCurrency != params[j].FromCurrency {
+			return params[i].FromCurrency < params[j].FromCurrency
+		}
+		return params[i].ToCurrency < params[j].ToCurrency
+	})
+
+	return params, nil
+}
+
+// nextRetryDelay doubles the retry backoff for each consecutive failure, capped at the maximum backoff
+func (refresher *RateRefresher) nextRetryDelay() time.Duration {
+	refresher.mu.RLock()
+	failures := refresher.consecutiveFailures
+	refresher.mu.RUnlock()
+
+	delay := refresher.retryBackoff
+	if delay <= 0 {
+		return refresher.interval
+	}
+
+	for i := 1; i < failures && delay < refresher.maxRetryBackoff; i++ {
+		delay *= 2
+	}
+
+	if refresher.maxRetryBackoff > 0 && delay > refresher.maxRetryBackoff {
+		delay = refresher.maxRetryBackoff
+	}
+
+	return delay
+}
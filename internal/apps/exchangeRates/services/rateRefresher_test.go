@@ -0,0 +1,116 @@
+package exchangeRates
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// stubRateProvider quotes the same rates for every base, or fails with err
+type stubRateProvider struct {
+	rates map[string]decimal.Decimal
+	err   error
+}
+
+func (provider *stubRateProvider) FetchRates(ctx context.Context, base string) (map[string]decimal.Decimal, error) {
+	return provider.rates, provider.err
+}
+
+func TestRateRefresherRefresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		RefreshExchangeRatesTx(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, arg []db.UpsertExchangeRateParams) ([]db.ExchangeRate, error) {
+			// Every base is quoted against NGN and USD, self pairs and the unsupported JPY are skipped
+			require.Len(t, arg, 6)
+			require.Equal(t, "EUR", arg[0].FromCurrency)
+			require.Equal(t, "NGN", arg[0].ToCurrency)
+			require.True(t, arg[0].Rate.Equal(decimal.RequireFromString("1500.12345679")))
+			return []db.ExchangeRate{}, nil
+		}).
+		Times(1)
+
+	refresher := newMockRateRefresher(store, &stubRateProvider{rates: map[string]decimal.Decimal{
+		"NGN": decimal.RequireFromString("1500.123456789"),
+		"USD": decimal.RequireFromString("1.1"),
+		"JPY": decimal.RequireFromString("150"),
+	}})
+
+	stored, err := refresher.Refresh(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 6, stored)
+	require.WithinDuration(t, time.Now(), refresher.LastSuccessfulRefresh(), time.Second)
+
+	status := refresher.Status()
+	require.True(t, status.Enabled)
+	require.NotNil(t, status.LastSuccessfulRefreshAt)
+	require.Zero(t, status.ConsecutiveFailures)
+}
+
+func TestRateRefresherRefreshFailures(t *testing.T) {
+	testCases := []struct {
+		name        string
+		provider    *stubRateProvider
+		expectedErr error
+	}{
+		{
+			name:        "ProviderError",
+			provider:    &stubRateProvider{err: errors.New("provider unavailable")},
+			expectedErr: errors.New("provider unavailable"),
+		},
+		{
+			name:        "AbsurdRate",
+			provider:    &stubRateProvider{rates: map[string]decimal.Decimal{"NGN": decimal.RequireFromString("-1")}},
+			expectedErr: exchangeRateErrors.ErrInvalidExchangeRate,
+		},
+		{
+			name:        "NoSupportedRates",
+			provider:    &stubRateProvider{rates: map[string]decimal.Decimal{"JPY": decimal.RequireFromString("150")}},
+			expectedErr: errNoProviderRates,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().RefreshExchangeRatesTx(gomock.Any(), gomock.Any()).Times(0)
+
+			refresher := newMockRateRefresher(store, tc.provider)
+
+			_, err := refresher.Refresh(context.Background())
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expectedErr.Error())
+			require.True(t, refresher.LastSuccessfulRefresh().IsZero())
+			require.Equal(t, 1, refresher.Status().ConsecutiveFailures)
+		})
+	}
+}
+
+func TestRateRefresherNextRetryDelay(t *testing.T) {
+	refresher := &RateRefresher{
+		interval:        time.Minute,
+		retryBackoff:    10 * time.Second,
+		maxRetryBackoff: time.Minute,
+	}
+
+	expected := []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute, time.Minute}
+	for i, delay := range expected {
+		refresher.consecutiveFailures = i + 1
+		require.Equal(t, delay, refresher.nextRetryDelay(), "after %d failures", i+1)
+	}
+}
@@ -2,8 +2,14 @@ package exchangeRates
 
 import (
 	"context"
+	"errors"
+	"time"
+
 	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
 	requests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+
+	"github.com/jackc/pgx/v5"
 )
 
 // MockExchangeRateRepository implements ExchangeRateRepositoryInterface for testing
@@ -25,6 +31,9 @@ func (m *MockExchangeRateRepository) GetExchangeRate(ctx context.Context, payloa
 		FromCurrency: payload.FromCurrency,
 		ToCurrency:   payload.ToCurrency,
 	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.ExchangeRate{}, exchangeRateErrors.ErrExchangeRateNotFound
+	}
 	if err != nil {
 		return db.ExchangeRate{}, err
 	}
@@ -32,6 +41,144 @@ func (m *MockExchangeRateRepository) GetExchangeRate(ctx context.Context, payloa
 	return rate, nil
 }
 
+func (m *MockExchangeRateRepository) CreateQuote(ctx context.Context, params db.CreateFxQuoteParams) (db.FxQuote, error) {
+	return m.store.CreateFxQuote(ctx, params)
+}
+
+func (m *MockExchangeRateRepository) CreateExchangeRate(ctx context.Context, params db.ExchangeRatePairTxParams) (db.ExchangeRatePairTxResult, error) {
+	return m.store.CreateExchangeRateTx(ctx, params)
+}
+
+func (m *MockExchangeRateRepository) UpdateExchangeRate(ctx context.Context, params db.ExchangeRatePairTxParams) (db.ExchangeRatePairTxResult, error) {
+	result, err := m.store.UpdateExchangeRateTx(ctx, params)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.ExchangeRatePairTxResult{}, exchangeRateErrors.ErrExchangeRateNotFound
+	}
+
+	return result, err
+}
+
+func (m *MockExchangeRateRepository) DeleteExchangeRate(ctx context.Context, params db.DeleteExchangeRateParams) error {
+	err := m.store.DeleteExchangeRateTx(ctx, params)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return exchangeRateErrors.ErrExchangeRateNotFound
+	}
+
+	return err
+}
+
+func (m *MockExchangeRateRepository) RefreshExchangeRates(ctx context.Context, params []db.UpsertExchangeRateParams) ([]db.ExchangeRate, error) {
+	return m.store.RefreshExchangeRatesTx(ctx, params)
+}
+
+func (m *MockExchangeRateRepository) ListExchangeRateHistory(ctx context.Context, params db.ListExchangeRateHistoryParams) ([]db.ExchangeRateHistory, error) {
+	return m.store.ListExchangeRateHistory(ctx, params)
+}
+
+func (m *MockExchangeRateRepository) GetRateAsOf(ctx context.Context, pair requests.CurrencyPair, asOf time.Time) (db.ExchangeRateHistory, error) {
+	history, err := m.store.GetExchangeRateAsOf(ctx, db.GetExchangeRateAsOfParams{
+		FromCurrency: pair.FromCurrency,
+		ToCurrency:   pair.ToCurrency,
+		AsOf:         asOf,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.ExchangeRateHistory{}, exchangeRateErrors.ErrExchangeRateNotFound
+	}
+
+	return history, err
+}
+
+func (m *MockExchangeRateRepository) GetActiveFeeSchedule(ctx context.Context, pair requests.CurrencyPair) (db.FeeScheduleTxResult, error) {
+	schedule, err := m.store.GetActiveFeeSchedule(ctx, db.GetActiveFeeScheduleParams{
+		FromCurrency: pair.FromCurrency,
+		ToCurrency:   pair.ToCurrency,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.FeeScheduleTxResult{}, exchangeRateErrors.ErrFeeScheduleNotFound
+	}
+	if err != nil {
+		return db.FeeScheduleTxResult{}, err
+	}
+
+	tiers, err := m.store.ListFeeScheduleTiers(ctx, []int64{schedule.ID})
+	if err != nil {
+		return db.FeeScheduleTxResult{}, err
+	}
+
+	return db.FeeScheduleTxResult{FeeSchedule: schedule, Tiers: tiers}, nil
+}
+
+func (m *MockExchangeRateRepository) ListFeeSchedules(ctx context.Context) ([]db.FeeScheduleTxResult, error) {
+	schedules, err := m.store.ListActiveFeeSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]db.FeeScheduleTxResult, 0, len(schedules))
+	for _, schedule := range schedules {
+		tiers, err := m.store.ListFeeScheduleTiers(ctx, []int64{schedule.ID})
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, db.FeeScheduleTxResult{FeeSchedule: schedule, Tiers: tiers})
+	}
+
+	return results, nil
+}
+
+func (m *MockExchangeRateRepository) SetFeeSchedule(ctx context.Context, params db.SetFeeScheduleTxParams) (db.FeeScheduleTxResult, error) {
+	return m.store.SetFeeScheduleTx(ctx, params)
+}
+
+func (m *MockExchangeRateRepository) DeleteFeeSchedule(ctx context.Context, pair requests.CurrencyPair) error {
+	_, err := m.store.DeactivateFeeSchedule(ctx, db.DeactivateFeeScheduleParams{
+		FromCurrency: pair.FromCurrency,
+		ToCurrency:   pair.ToCurrency,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return exchangeRateErrors.ErrFeeScheduleNotFound
+	}
+
+	return err
+}
+
+func (m *MockExchangeRateRepository) GetFxMarkup(ctx context.Context, pair requests.CurrencyPair) (db.FxMarkup, error) {
+	markup, err := m.store.GetFxMarkup(ctx, db.GetFxMarkupParams{
+		FromCurrency: pair.FromCurrency,
+		ToCurrency:   pair.ToCurrency,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.FxMarkup{}, exchangeRateErrors.ErrFxMarkupNotFound
+	}
+
+	return markup, err
+}
+
+func (m *MockExchangeRateRepository) ListFxMarkups(ctx context.Context) ([]db.FxMarkup, error) {
+	return m.store.ListFxMarkups(ctx)
+}
+
+func (m *MockExchangeRateRepository) SetFxMarkup(ctx context.Context, params db.UpsertFxMarkupParams) (db.FxMarkup, error) {
+	return m.store.UpsertFxMarkup(ctx, params)
+}
+
+func (m *MockExchangeRateRepository) DeleteFxMarkup(ctx context.Context, pair requests.CurrencyPair) error {
+	deleted, err := m.store.DeleteFxMarkup(ctx, db.DeleteFxMarkupParams{
+		FromCurrency: pair.FromCurrency,
+		ToCurrency:   pair.ToCurrency,
+	})
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return exchangeRateErrors.ErrFxMarkupNotFound
+	}
+
+	return nil
+}
+
 // NewMockExchangeRateRepository creates a new mock repository that wraps a store
 func NewMockExchangeRateRepository(store db.Store) *MockExchangeRateRepository {
 	return &MockExchangeRateRepository{store: store}
@@ -5,3 +5,27 @@ var GetExchangeRateValidationMessages = map[string]string{
 	"to_currency.required":   "To currency is required",
 	"amount.required":        "Amount is required",
 }
+
+var CreateExchangeRateValidationMessages = map[string]string{
+	"from_currency.required": "From currency is required",
+	"to_currency.required":   "To currency is required",
+	"rate.required":          "Rate is required",
+}
+
+var UpdateExchangeRateValidationMessages = map[string]string{
+	"rate.required": "Rate is required",
+}
+
+var ListExchangeRateHistoryValidationMessages = map[string]string{
+	"Pair.required": "pair is required, e.g. USD-NGN",
+	"Limit.min":     "limit must be at least 1",
+	"Limit.max":     "limit must not be greater than 1000",
+}
+
+var SetFeeScheduleValidationMessages = map[string]string{
+	"Tiers.max": "a fee schedule can have at most 20 tiers",
+}
+
+var SetFxMarkupValidationMessages = map[string]string{
+	"MarkupBps.required": "markup_bps is required",
+}
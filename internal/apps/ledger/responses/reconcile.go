@@ -0,0 +1,63 @@
+package ledger
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ReconciliationReport lists drift between account balances, entries and transfers
+type ReconciliationReport struct {
+	CheckedAt           time.Time               `json:"checked_at"`
+	AccountDrift        []AccountDrift          `json:"account_drift"`
+	UnbalancedTransfers []UnbalancedTransfer    `json:"unbalanced_transfers"`
+	TransferMismatches  []TransferEntryMismatch `json:"transfer_mismatches"`
+	FundingMismatches   []FundingEntryMismatch  `json:"funding_mismatches"`
+}
+
+// AccountDrift is an account whose balance differs from the sum of its entries
+type AccountDrift struct {
+	AccountID    int64           `json:"account_id"`
+	Owner        string          `json:"owner"`
+	Currency     string          `json:"currency"`
+	Balance      decimal.Decimal `json:"balance"`
+	EntriesTotal decimal.Decimal `json:"entries_total"`
+	Drift        decimal.Decimal `json:"drift"`
+}
+
+// UnbalancedTransfer is a transfer whose entries do not sum to zero in one currency
+type UnbalancedTransfer struct {
+	TransferID int64           `json:"transfer_id"`
+	Currency   string          `json:"currency"`
+	Total      decimal.Decimal `json:"total"`
+}
+
+// TransferEntryMismatch is a transfer whose sender or receiver entries differ from the transfer amounts
+type TransferEntryMismatch struct {
+	TransferID     int64           `json:"transfer_id"`
+	FromAccountID  int64           `json:"from_account_id"`
+	ToAccountID    int64           `json:"to_account_id"`
+	ExpectedDebit  decimal.Decimal `json:"expected_debit"`
+	Debited        decimal.Decimal `json:"debited"`
+	ExpectedCredit decimal.Decimal `json:"expected_credit"`
+	Credited       decimal.Decimal `json:"credited"`
+}
+
+// FundingEntryMismatch is a deposit or withdrawal whose entries differ from the recorded amount
+type FundingEntryMismatch struct {
+	FundingTransactionID int64           `json:"funding_transaction_id"`
+	AccountID            int64           `json:"account_id"`
+	Kind                 string          `json:"kind"`
+	Amount               decimal.Decimal `json:"amount"`
+	EntryAccountID       int64           `json:"entry_account_id"`
+	EntryAmount          decimal.Decimal `json:"entry_amount"`
+	HouseEntryAmount     decimal.Decimal `json:"house_entry_amount"`
+}
+
+// HasDrift reports whether any invariant was violated
+func (report ReconciliationReport) HasDrift() bool {
+	return len(report.AccountDrift) > 0 ||
+		len(report.UnbalancedTransfers) > 0 ||
+		len(report.TransferMismatches) > 0 ||
+		len(report.FundingMismatches) > 0
+}
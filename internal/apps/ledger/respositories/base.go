@@ -0,0 +1,20 @@
+package ledger
+
+import (
+	"context"
+
+	dbConnection "lemfi/simplebank/db"
+	db "lemfi/simplebank/db/sqlc"
+)
+
+type LedgerRespository struct {
+	context context.Context
+	queries db.Store
+}
+
+func NewLedgerRespository() *LedgerRespository {
+	return &LedgerRespository{
+		context: context.Background(),
+		queries: db.NewStore(dbConnection.GetPostgresDBConnection()),
+	}
+}
@@ -0,0 +1,9 @@
+package ledger
+
+import (
+	db "lemfi/simplebank/db/sqlc"
+)
+
+type LedgerRespositoryInterface interface {
+	Reconcile() (db.ReconcileTxResult, error)
+}
@@ -0,0 +1,24 @@
+package ledger
+
+import (
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+)
+
+func (ledgerRespository *LedgerRespository) Reconcile() (db.ReconcileTxResult, error) {
+	config.Logger.Info("Reconciling ledger in database")
+
+	result, err := ledgerRespository.queries.ReconcileTx(ledgerRespository.context)
+	if err != nil {
+		config.Logger.Error("Failed to reconcile ledger in database", "error", err.Error())
+		return db.ReconcileTxResult{}, err
+	}
+
+	config.Logger.Info("Successfully reconciled ledger in database",
+		"account_drift", len(result.AccountDrift),
+		"unbalanced_transfers", len(result.UnbalancedTransfers),
+		"transfer_mismatches", len(result.TransferMismatches),
+	)
+
+	return result, nil
+}
@@ -0,0 +1,15 @@
+package ledger
+
+import (
+	respositories "lemfi/simplebank/internal/apps/ledger/respositories"
+)
+
+type LedgerService struct {
+	ledgerRespository respositories.LedgerRespositoryInterface
+}
+
+func NewLedgerService(respository respositories.LedgerRespositoryInterface) *LedgerService {
+	return &LedgerService{
+		ledgerRespository: respository,
+	}
+}
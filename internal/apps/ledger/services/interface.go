@@ -0,0 +1,9 @@
+package ledger
+
+import (
+	responses "lemfi/simplebank/internal/apps/ledger/responses"
+)
+
+type LedgerServiceInterface interface {
+	Reconcile() (responses.ReconciliationReport, error)
+}
@@ -0,0 +1,102 @@
+package ledger
+
+import (
+	"time"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	responses "lemfi/simplebank/internal/apps/ledger/responses"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// Reconcile checks that every account balance equals the sum of its entries, that the entries of
+// each transfer sum to zero per currency, and that transfer and funding entries match their amounts
+func (ledgerService *LedgerService) Reconcile() (responses.ReconciliationReport, error) {
+	config.Logger.Info("Starting ledger reconciliation")
+
+	result, err := ledgerService.ledgerRespository.Reconcile()
+	if err != nil {
+		config.Logger.Error("Failed to reconcile ledger", "error", err.Error())
+		return responses.ReconciliationReport{}, err
+	}
+
+	report := newReconciliationReport(result, time.Now().UTC())
+
+	if report.HasDrift() {
+		config.Logger.Error("Ledger drift detected",
+			"account_drift", len(report.AccountDrift),
+			"unbalanced_transfers", len(report.UnbalancedTransfers),
+			"transfer_mismatches", len(report.TransferMismatches),
+			"funding_mismatches", len(report.FundingMismatches),
+		)
+	} else {
+		config.Logger.Info("Ledger is balanced")
+	}
+
+	return report, nil
+}
+
+func newReconciliationReport(result db.ReconcileTxResult, checkedAt time.Time) responses.ReconciliationReport {
+	report := responses.ReconciliationReport{
+		CheckedAt:           checkedAt,
+		AccountDrift:        make([]responses.AccountDrift, 0, len(result.AccountDrift)),
+		UnbalancedTransfers: make([]responses.UnbalancedTransfer, 0, len(result.UnbalancedTransfers)),
+		TransferMismatches:  make([]responses.TransferEntryMismatch, 0, len(result.TransferMismatches)),
+		FundingMismatches:   make([]responses.FundingEntryMismatch, 0, len(result.FundingMismatches)),
+	}
+
+	for _, row := range result.AccountDrift {
+		entriesTotal := fromNumeric(row.EntriesTotal)
+		report.AccountDrift = append(report.AccountDrift, responses.AccountDrift{
+			AccountID:    row.ID,
+			Owner:        row.Owner,
+			Currency:     row.Currency,
+			Balance:      row.Balance,
+			EntriesTotal: entriesTotal,
+			Drift:        row.Balance.Sub(entriesTotal),
+		})
+	}
+
+	for _, row := range result.UnbalancedTransfers {
+		report.UnbalancedTransfers = append(report.UnbalancedTransfers, responses.UnbalancedTransfer{
+			TransferID: row.TransferID.Int64,
+			Currency:   row.Currency,
+			Total:      fromNumeric(row.Total),
+		})
+	}
+
+	for _, row := range result.TransferMismatches {
+		report.TransferMismatches = append(report.TransferMismatches, responses.TransferEntryMismatch{
+			TransferID:     row.ID,
+			FromAccountID:  row.FromAccountID,
+			ToAccountID:    row.ToAccountID,
+			ExpectedDebit:  row.Amount.Add(row.Fee).Neg(),
+			Debited:        fromNumeric(row.Debited),
+			ExpectedCredit: row.ConvertedAmount.Add(row.RefundedFee),
+			Credited:       fromNumeric(row.Credited),
+		})
+	}
+
+	for _, row := range result.FundingMismatches {
+		report.FundingMismatches = append(report.FundingMismatches, responses.FundingEntryMismatch{
+			FundingTransactionID: row.ID,
+			AccountID:            row.AccountID,
+			Kind:                 row.Kind,
+			Amount:               row.Amount,
+			EntryAccountID:       row.EntryAccountID,
+			EntryAmount:          row.EntryAmount,
+			HouseEntryAmount:     row.HouseEntryAmount,
+		})
+	}
+
+	return report
+}
+
+func fromNumeric(value pgtype.Numeric) decimal.Decimal {
+	if !value.Valid || value.Int == nil {
+		return decimal.Zero
+	}
+	return decimal.NewFromBigInt(value.Int, value.Exp)
+}
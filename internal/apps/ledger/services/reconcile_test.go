@@ -0,0 +1,122 @@
+package ledger
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	responses "lemfi/simplebank/internal/apps/ledger/responses"
+	testhelpers "lemfi/simplebank/internal/apps/ledger/testHelpers"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func numeric(value int64) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(value), Exp: -2, Valid: true}
+}
+
+func TestReconcileService(t *testing.T) {
+	testCases := []struct {
+		name        string
+		buildStubs  func(store *mockdb.MockStore)
+		checkReport func(t *testing.T, report responses.ReconciliationReport, err error)
+	}{
+		{
+			name: "Balanced",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReconcileTx(gomock.Any()).Return(db.ReconcileTxResult{}, nil).Times(1)
+			},
+			checkReport: func(t *testing.T, report responses.ReconciliationReport, err error) {
+				require.NoError(t, err)
+				require.False(t, report.HasDrift())
+				require.NotNil(t, report.AccountDrift)
+				require.NotZero(t, report.CheckedAt)
+			},
+		},
+		{
+			name: "AccountDrift",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReconcileTx(gomock.Any()).Return(db.ReconcileTxResult{
+					AccountDrift: []db.ListAccountBalanceDriftRow{{
+						ID:           7,
+						Owner:        "test_owner",
+						Currency:     "USD",
+						Balance:      decimal.RequireFromString("100.00"),
+						EntriesTotal: numeric(9050),
+					}},
+				}, nil).Times(1)
+			},
+			checkReport: func(t *testing.T, report responses.ReconciliationReport, err error) {
+				require.NoError(t, err)
+				require.True(t, report.HasDrift())
+				require.Len(t, report.AccountDrift, 1)
+				require.Equal(t, int64(7), report.AccountDrift[0].AccountID)
+				require.True(t, decimal.RequireFromString("90.50").Equal(report.AccountDrift[0].EntriesTotal))
+				require.True(t, decimal.RequireFromString("9.50").Equal(report.AccountDrift[0].Drift))
+			},
+		},
+		{
+			name: "UnbalancedAndMismatchedTransfers",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReconcileTx(gomock.Any()).Return(db.ReconcileTxResult{
+					UnbalancedTransfers: []db.ListUnbalancedTransfersRow{{
+						TransferID: pgtype.Int8{Int64: 3, Valid: true},
+						Currency:   "GBP",
+						Total:      numeric(-150),
+					}},
+					TransferMismatches: []db.ListTransferEntryMismatchesRow{{
+						ID:              3,
+						FromAccountID:   1,
+						ToAccountID:     2,
+						Amount:          decimal.NewFromInt(10),
+						ConvertedAmount: decimal.NewFromInt(10),
+						Fee:             decimal.RequireFromString("1.50"),
+						Debited:         numeric(-1000),
+						Credited:        pgtype.Numeric{},
+					}},
+				}, nil).Times(1)
+			},
+			checkReport: func(t *testing.T, report responses.ReconciliationReport, err error) {
+				require.NoError(t, err)
+				require.True(t, report.HasDrift())
+				require.Equal(t, int64(3), report.UnbalancedTransfers[0].TransferID)
+				require.True(t, decimal.RequireFromString("-1.50").Equal(report.UnbalancedTransfers[0].Total))
+
+				mismatch := report.TransferMismatches[0]
+				require.True(t, decimal.RequireFromString("-11.50").Equal(mismatch.ExpectedDebit))
+				require.True(t, decimal.NewFromInt(-10).Equal(mismatch.Debited))
+				require.True(t, decimal.NewFromInt(10).Equal(mismatch.ExpectedCredit))
+				require.True(t, mismatch.Credited.IsZero())
+			},
+		},
+		{
+			name: "StoreError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReconcileTx(gomock.Any()).Return(db.ReconcileTxResult{}, errors.New("connection refused")).Times(1)
+			},
+			checkReport: func(t *testing.T, report responses.ReconciliationReport, err error) {
+				require.Error(t, err)
+				require.False(t, report.HasDrift())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			service := NewLedgerService(testhelpers.NewMockLedgerRepository(store))
+			report, err := service.Reconcile()
+			tc.checkReport(t, report, err)
+		})
+	}
+}
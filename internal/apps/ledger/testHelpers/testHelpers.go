@@ -0,0 +1,20 @@
+package ledger
+
+import (
+	"context"
+
+	db "lemfi/simplebank/db/sqlc"
+)
+
+// MockLedgerRepository implements LedgerRespositoryInterface for testing
+type MockLedgerRepository struct {
+	store db.Store
+}
+
+func (m *MockLedgerRepository) Reconcile() (db.ReconcileTxResult, error) {
+	return m.store.ReconcileTx(context.Background())
+}
+
+func NewMockLedgerRepository(store db.Store) *MockLedgerRepository {
+	return &MockLedgerRepository{store: store}
+}
@@ -0,0 +1,90 @@
+package transfers
+
+import (
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/internal/apps/core"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+	responses "lemfi/simplebank/internal/apps/transfers/responses"
+	transferValidation "lemfi/simplebank/internal/apps/transfers/validationMessages"
+	"lemfi/simplebank/internal/middleware"
+	"lemfi/simplebank/pkg/errorResponse"
+	"lemfi/simplebank/pkg/requestHandler"
+	"lemfi/simplebank/pkg/responseHandler"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (transferController *TransferController) ListTransfersController(c *gin.Context) {
+	config.Logger.Info("Listing transfers", "method", "GET", "endpoint", "/transfers")
+
+	var req requests.ListTransfersRequest
+
+	err := requestHandler.ReadQueryGin(c, &req, transferValidation.ListTransfersValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read list transfers request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	req.Username = middleware.ContextGetUser(c).Username
+
+	transfers, err := transferController.transferService.ListTransfers(req)
+	writeListTransfersResponse(c, transfers, err)
+}
+
+func (transferController *TransferController) ListAccountTransfersController(c *gin.Context) {
+	config.Logger.Info("Listing account transfers", "method", "GET", "endpoint", "/accounts/:id/transfers")
+
+	accountID, err := requestHandler.ReadIDParamGin(c, "id")
+	if err != nil {
+		config.Logger.Error("Invalid account id", "id", c.Param("id"))
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	var req requests.ListTransfersRequest
+
+	err = requestHandler.ReadQueryGin(c, &req, transferValidation.ListTransfersValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read list transfers request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	req.AccountID = accountID
+	req.Username = middleware.ContextGetUser(c).Username
+
+	transfers, err := transferController.transferService.ListAccountTransfers(req)
+	writeListTransfersResponse(c, transfers, err)
+}
+
+func writeListTransfersResponse(c *gin.Context, transfers responses.ListTransfersResponse, err error) {
+	if err != nil {
+		config.Logger.Error("Failed to list transfers", "error", err.Error())
+
+		// Check if it's a forbidden (403), client error (400) or server error (500)
+		if clientErr, isClient := core.IsClientError(err); isClient && clientErr.Status == http.StatusForbidden {
+			errorResponse.ForbiddenResponse(c, clientErr)
+		} else if isClient {
+			errorResponse.BadRequestResponse(c, clientErr)
+		} else {
+			errorResponse.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	response := responseHandler.Envelope{
+		"transfers":   transfers.Transfers,
+		"next_cursor": transfers.NextCursor,
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("List transfers response written successfully", "count", len(transfers.Transfers))
+}
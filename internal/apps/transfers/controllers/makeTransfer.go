@@ -3,9 +3,11 @@ package transfers
 import (
 	"lemfi/simplebank/config"
 	"lemfi/simplebank/internal/apps/core"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
 	requests "lemfi/simplebank/internal/apps/transfers/requests"
 	transferValidation "lemfi/simplebank/internal/apps/transfers/validationMessages"
 
+	"lemfi/simplebank/internal/middleware"
 	"lemfi/simplebank/pkg/errorResponse"
 	"lemfi/simplebank/pkg/requestHandler"
 	"lemfi/simplebank/pkg/responseHandler"
@@ -14,6 +16,14 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// IdempotencyKeyHeader lets clients retry POST /transfers without moving money twice
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+const maxIdempotencyKeyLength = 255
+
+// TOTPCodeHeader carries the authenticator app code that transfers above the step-up threshold need
+const TOTPCodeHeader = "X-TOTP-Code"
+
 func (transferController *TransferController) MakeTransferController(c *gin.Context) {
 	config.Logger.Info("Making transfer", "method", "POST", "endpoint", "/transfers")
 
@@ -26,14 +36,25 @@ func (transferController *TransferController) MakeTransferController(c *gin.Cont
 		return
 	}
 
+	req.Username = middleware.ContextGetUser(c).Username
+	req.IdempotencyKey = c.GetHeader(IdempotencyKeyHeader)
+	req.TOTPCode = c.GetHeader(TOTPCodeHeader)
+	if len(req.IdempotencyKey) > maxIdempotencyKeyLength {
+		config.Logger.Error("Idempotency key too long", "length", len(req.IdempotencyKey))
+		errorResponse.BadRequestResponse(c, transferErrors.ErrIdempotencyKeyTooLong)
+		return
+	}
+
 	config.Logger.Info("Transfer request validated successfully", "fromAccountID", req.FromAccountID, "toAccountID", req.ToAccountID, "amount", req.Amount, "fromCurrency", req.FromCurrency, "toCurrency", req.ToCurrency)
 
 	transfer, err := transferController.transferService.MakeTransfer(req)
 	if err != nil {
 		config.Logger.Error("Failed to make transfer", "error", err.Error(), "fromAccountID", req.FromAccountID, "toAccountID", req.ToAccountID, "amount", req.Amount, "fromCurrency", req.FromCurrency, "toCurrency", req.ToCurrency)
 
-		// Check if it's a client error (400) or server error (500)
-		if clientErr, isClient := core.IsClientError(err); isClient {
+		// Check if it's a forbidden (403), client error (400) or server error (500)
+		if clientErr, isClient := core.IsClientError(err); isClient && clientErr.Status == http.StatusForbidden {
+			errorResponse.ForbiddenResponse(c, clientErr)
+		} else if isClient {
 			errorResponse.BadRequestResponse(c, clientErr)
 		} else {
 			errorResponse.ServerErrorResponse(c, err)
@@ -54,7 +75,14 @@ func (transferController *TransferController) MakeTransferController(c *gin.Cont
 		"transfer": transfer,
 	}
 
-	err = responseHandler.WriteJSON(c.Writer, http.StatusCreated, response, nil)
+	status := http.StatusCreated
+	var headers http.Header
+	if transfer.Replayed {
+		status = transfer.StatusCode
+		headers = http.Header{"Idempotent-Replayed": []string{"true"}}
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, status, response, headers)
 	if err != nil {
 		config.Logger.Error("Failed to write JSON response", "error", err.Error())
 		errorResponse.ServerErrorResponse(c, err)
@@ -0,0 +1,61 @@
+package transfers
+
+import (
+	"net/http"
+
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/internal/apps/core"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+	"lemfi/simplebank/pkg/errorResponse"
+	"lemfi/simplebank/pkg/requestHandler"
+	"lemfi/simplebank/pkg/responseHandler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReverseTransferController takes a transfer, or part of it, back from the recipient and returns it to the sender (admin only)
+func (transferController *TransferController) ReverseTransferController(c *gin.Context) {
+	config.Logger.Info("Reversing transfer", "method", "POST", "endpoint", "/transfers/:id/reversals")
+
+	id, err := requestHandler.ReadIDParamGin(c, "id")
+	if err != nil {
+		config.Logger.Error("Invalid transfer id", "id", c.Param("id"))
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	var req requests.ReverseTransferRequest
+
+	err = requestHandler.ReadJSONGin(c, &req, nil)
+	if err != nil {
+		config.Logger.Error("Failed to read transfer reversal request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	req.TransferID = id
+
+	result, err := transferController.transferService.ReverseTransfer(req)
+	if err != nil {
+		config.Logger.Error("Failed to reverse transfer", "error", err.Error(), "transfer_id", id)
+		if clientErr, isClient := core.IsClientError(err); isClient {
+			errorResponse.BadRequestResponse(c, clientErr)
+		} else {
+			errorResponse.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	response := responseHandler.Envelope{
+		"reversal": result,
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusCreated, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Transfer reversal response written successfully", "transfer_id", result.Transfer.ID, "reversal_id", result.Reversal.ID)
+}
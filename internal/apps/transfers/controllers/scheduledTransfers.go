@@ -0,0 +1,143 @@
+package transfers
+
+import (
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/internal/apps/core"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+	responses "lemfi/simplebank/internal/apps/transfers/responses"
+	transferValidation "lemfi/simplebank/internal/apps/transfers/validationMessages"
+	"lemfi/simplebank/internal/middleware"
+	"lemfi/simplebank/pkg/errorResponse"
+	"lemfi/simplebank/pkg/requestHandler"
+	"lemfi/simplebank/pkg/responseHandler"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (transferController *TransferController) CreateScheduledTransferController(c *gin.Context) {
+	config.Logger.Info("Creating scheduled transfer", "method", "POST", "endpoint", "/transfers/scheduled")
+
+	var req requests.CreateScheduledTransferRequest
+
+	err := requestHandler.ReadJSONGin(c, &req, transferValidation.CreateScheduledTransferValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read scheduled transfer request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	req.Username = middleware.ContextGetUser(c).Username
+	req.TOTPCode = c.GetHeader(TOTPCodeHeader)
+
+	scheduledTransfer, err := transferController.transferService.CreateScheduledTransfer(req)
+	writeScheduledTransferResponse(c, http.StatusCreated, scheduledTransfer, err)
+}
+
+func (transferController *TransferController) ListScheduledTransfersController(c *gin.Context) {
+	config.Logger.Info("Listing scheduled transfers", "method", "GET", "endpoint", "/transfers/scheduled")
+
+	scheduledTransfers, err := transferController.transferService.ListScheduledTransfers(middleware.ContextGetUser(c).Username)
+	if err != nil {
+		config.Logger.Error("Failed to list scheduled transfers", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	response := responseHandler.Envelope{
+		"scheduled_transfers": scheduledTransfers,
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("List scheduled transfers response written successfully", "count", len(scheduledTransfers))
+}
+
+func (transferController *TransferController) GetScheduledTransferController(c *gin.Context) {
+	config.Logger.Info("Getting scheduled transfer", "method", "GET", "endpoint", "/transfers/scheduled/:id")
+
+	id, err := requestHandler.ReadIDParamGin(c, "id")
+	if err != nil {
+		config.Logger.Error("Invalid scheduled transfer id", "id", c.Param("id"))
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	scheduledTransfer, err := transferController.transferService.GetScheduledTransfer(id, middleware.ContextGetUser(c).Username)
+	writeScheduledTransferResponse(c, http.StatusOK, scheduledTransfer, err)
+}
+
+func (transferController *TransferController) UpdateScheduledTransferController(c *gin.Context) {
+	config.Logger.Info("Updating scheduled transfer", "method", "PATCH", "endpoint", "/transfers/scheduled/:id")
+
+	id, err := requestHandler.ReadIDParamGin(c, "id")
+	if err != nil {
+		config.Logger.Error("Invalid scheduled transfer id", "id", c.Param("id"))
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	var req requests.UpdateScheduledTransferRequest
+
+	err = requestHandler.ReadJSONGin(c, &req, transferValidation.UpdateScheduledTransferValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read scheduled transfer update", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	req.ID = id
+	req.Username = middleware.ContextGetUser(c).Username
+	req.TOTPCode = c.GetHeader(TOTPCodeHeader)
+
+	scheduledTransfer, err := transferController.transferService.UpdateScheduledTransfer(req)
+	writeScheduledTransferResponse(c, http.StatusOK, scheduledTransfer, err)
+}
+
+func (transferController *TransferController) CancelScheduledTransferController(c *gin.Context) {
+	config.Logger.Info("Cancelling scheduled transfer", "method", "DELETE", "endpoint", "/transfers/scheduled/:id")
+
+	id, err := requestHandler.ReadIDParamGin(c, "id")
+	if err != nil {
+		config.Logger.Error("Invalid scheduled transfer id", "id", c.Param("id"))
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	scheduledTransfer, err := transferController.transferService.CancelScheduledTransfer(id, middleware.ContextGetUser(c).Username)
+	writeScheduledTransferResponse(c, http.StatusOK, scheduledTransfer, err)
+}
+
+func writeScheduledTransferResponse(c *gin.Context, status int, scheduledTransfer responses.ScheduledTransferResponse, err error) {
+	if err != nil {
+		config.Logger.Error("Scheduled transfer request failed", "error", err.Error())
+
+		// Check if it's a forbidden (403), client error (400) or server error (500)
+		if clientErr, isClient := core.IsClientError(err); isClient && clientErr.Status == http.StatusForbidden {
+			errorResponse.ForbiddenResponse(c, clientErr)
+		} else if isClient {
+			errorResponse.BadRequestResponse(c, clientErr)
+		} else {
+			errorResponse.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	response := responseHandler.Envelope{
+		"scheduled_transfer": scheduledTransfer,
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, status, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Scheduled transfer response written successfully", "scheduled_transfer_id", scheduledTransfer.ID, "status", scheduledTransfer.Status)
+}
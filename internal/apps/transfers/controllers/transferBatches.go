@@ -0,0 +1,95 @@
+package transfers
+
+import (
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/internal/apps/core"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+	transferValidation "lemfi/simplebank/internal/apps/transfers/validationMessages"
+	"lemfi/simplebank/internal/middleware"
+	"lemfi/simplebank/pkg/errorResponse"
+	"lemfi/simplebank/pkg/requestHandler"
+	"lemfi/simplebank/pkg/responseHandler"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (transferController *TransferController) CreateTransferBatchController(c *gin.Context) {
+	config.Logger.Info("Creating transfer batch", "method", "POST", "endpoint", "/transfers/batches")
+
+	var req requests.CreateTransferBatchRequest
+
+	err := requestHandler.ReadJSONGin(c, &req, transferValidation.CreateTransferBatchValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read transfer batch request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	req.Username = middleware.ContextGetUser(c).Username
+	req.TOTPCode = c.GetHeader(TOTPCodeHeader)
+
+	result, err := transferController.transferService.CreateTransferBatch(req)
+	if err != nil {
+		writeTransferBatchError(c, err)
+		return
+	}
+
+	writeTransferBatchEnvelope(c, http.StatusCreated, responseHandler.Envelope{"batch_transfer": result})
+}
+
+func (transferController *TransferController) ListTransferBatchesController(c *gin.Context) {
+	config.Logger.Info("Listing transfer batches", "method", "GET", "endpoint", "/transfers/batches")
+
+	batches, err := transferController.transferService.ListTransferBatches(middleware.ContextGetUser(c).Username)
+	if err != nil {
+		config.Logger.Error("Failed to list transfer batches", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	writeTransferBatchEnvelope(c, http.StatusOK, responseHandler.Envelope{"batches": batches})
+}
+
+func (transferController *TransferController) GetTransferBatchController(c *gin.Context) {
+	config.Logger.Info("Getting transfer batch", "method", "GET", "endpoint", "/transfers/batches/:id")
+
+	id, err := requestHandler.ReadIDParamGin(c, "id")
+	if err != nil {
+		config.Logger.Error("Invalid transfer batch id", "id", c.Param("id"))
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	batch, err := transferController.transferService.GetTransferBatch(id, middleware.ContextGetUser(c).Username)
+	if err != nil {
+		writeTransferBatchError(c, err)
+		return
+	}
+
+	writeTransferBatchEnvelope(c, http.StatusOK, responseHandler.Envelope{"batch": batch})
+}
+
+func writeTransferBatchError(c *gin.Context, err error) {
+	config.Logger.Error("Transfer batch request failed", "error", err.Error())
+
+	// Check if it's a forbidden (403), client error (400) or server error (500)
+	if clientErr, isClient := core.IsClientError(err); isClient && clientErr.Status == http.StatusForbidden {
+		errorResponse.ForbiddenResponse(c, clientErr)
+	} else if isClient {
+		errorResponse.BadRequestResponse(c, clientErr)
+	} else {
+		errorResponse.ServerErrorResponse(c, err)
+	}
+}
+
+func writeTransferBatchEnvelope(c *gin.Context, status int, response responseHandler.Envelope) {
+	err := responseHandler.WriteJSON(c.Writer, status, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Transfer batch response written successfully", "status", status)
+}
@@ -0,0 +1,133 @@
+package transfers
+
+import (
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/internal/apps/core"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+	transferValidation "lemfi/simplebank/internal/apps/transfers/validationMessages"
+	"lemfi/simplebank/internal/middleware"
+	"lemfi/simplebank/pkg/errorResponse"
+	"lemfi/simplebank/pkg/requestHandler"
+	"lemfi/simplebank/pkg/responseHandler"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (transferController *TransferController) AuthorizeTransferHoldController(c *gin.Context) {
+	config.Logger.Info("Authorizing transfer hold", "method", "POST", "endpoint", "/transfers/holds")
+
+	var req requests.AuthorizeTransferHoldRequest
+
+	err := requestHandler.ReadJSONGin(c, &req, transferValidation.AuthorizeTransferHoldValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read transfer hold request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	req.Username = middleware.ContextGetUser(c).Username
+	req.TOTPCode = c.GetHeader(TOTPCodeHeader)
+
+	result, err := transferController.transferService.AuthorizeTransferHold(req)
+	if err != nil {
+		writeTransferHoldError(c, err)
+		return
+	}
+
+	writeTransferHoldEnvelope(c, http.StatusCreated, responseHandler.Envelope{"authorization": result})
+}
+
+func (transferController *TransferController) ListTransferHoldsController(c *gin.Context) {
+	config.Logger.Info("Listing transfer holds", "method", "GET", "endpoint", "/transfers/holds")
+
+	holds, err := transferController.transferService.ListTransferHolds(middleware.ContextGetUser(c).Username)
+	if err != nil {
+		config.Logger.Error("Failed to list transfer holds", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	writeTransferHoldEnvelope(c, http.StatusOK, responseHandler.Envelope{"holds": holds})
+}
+
+func (transferController *TransferController) GetTransferHoldController(c *gin.Context) {
+	config.Logger.Info("Getting transfer hold", "method", "GET", "endpoint", "/transfers/holds/:id")
+
+	id, err := requestHandler.ReadIDParamGin(c, "id")
+	if err != nil {
+		config.Logger.Error("Invalid transfer hold id", "id", c.Param("id"))
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	hold, err := transferController.transferService.GetTransferHold(id, middleware.ContextGetUser(c).Username)
+	if err != nil {
+		writeTransferHoldError(c, err)
+		return
+	}
+
+	writeTransferHoldEnvelope(c, http.StatusOK, responseHandler.Envelope{"hold": hold})
+}
+
+func (transferController *TransferController) CaptureTransferHoldController(c *gin.Context) {
+	config.Logger.Info("Capturing transfer hold", "method", "POST", "endpoint", "/transfers/holds/:id/capture")
+
+	id, err := requestHandler.ReadIDParamGin(c, "id")
+	if err != nil {
+		config.Logger.Error("Invalid transfer hold id", "id", c.Param("id"))
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	result, err := transferController.transferService.CaptureTransferHold(id, middleware.ContextGetUser(c).Username)
+	if err != nil {
+		writeTransferHoldError(c, err)
+		return
+	}
+
+	writeTransferHoldEnvelope(c, http.StatusCreated, responseHandler.Envelope{"capture": result})
+}
+
+func (transferController *TransferController) VoidTransferHoldController(c *gin.Context) {
+	config.Logger.Info("Voiding transfer hold", "method", "POST", "endpoint", "/transfers/holds/:id/void")
+
+	id, err := requestHandler.ReadIDParamGin(c, "id")
+	if err != nil {
+		config.Logger.Error("Invalid transfer hold id", "id", c.Param("id"))
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	hold, err := transferController.transferService.VoidTransferHold(id, middleware.ContextGetUser(c).Username)
+	if err != nil {
+		writeTransferHoldError(c, err)
+		return
+	}
+
+	writeTransferHoldEnvelope(c, http.StatusOK, responseHandler.Envelope{"hold": hold})
+}
+
+func writeTransferHoldError(c *gin.Context, err error) {
+	config.Logger.Error("Transfer hold request failed", "error", err.Error())
+
+	// Check if it's a forbidden (403), client error (400) or server error (500)
+	if clientErr, isClient := core.IsClientError(err); isClient && clientErr.Status == http.StatusForbidden {
+		errorResponse.ForbiddenResponse(c, clientErr)
+	} else if isClient {
+		errorResponse.BadRequestResponse(c, clientErr)
+	} else {
+		errorResponse.ServerErrorResponse(c, err)
+	}
+}
+
+func writeTransferHoldEnvelope(c *gin.Context, status int, response responseHandler.Envelope) {
+	err := responseHandler.WriteJSON(c.Writer, status, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Transfer hold response written successfully", "status", status)
+}
@@ -0,0 +1,97 @@
+package transfers
+
+import (
+	"net/http"
+
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/internal/apps/core"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+	responses "lemfi/simplebank/internal/apps/transfers/responses"
+	transferValidation "lemfi/simplebank/internal/apps/transfers/validationMessages"
+	"lemfi/simplebank/pkg/errorResponse"
+	"lemfi/simplebank/pkg/requestHandler"
+	"lemfi/simplebank/pkg/responseHandler"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetUserTransferLimitsController returns the transfer limits of a user in every currency and their usage (admin only)
+func (transferController *TransferController) GetUserTransferLimitsController(c *gin.Context) {
+	config.Logger.Info("Getting user transfer limits", "method", "GET", "endpoint", "/transfer-limits/:username")
+
+	result, err := transferController.transferService.GetUserTransferLimits(c.Param("username"))
+	if err != nil {
+		writeTransferLimitError(c, err)
+		return
+	}
+
+	response := responseHandler.Envelope{
+		"transfer_limits": result,
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("User transfer limits response written successfully", "username", result.Username, "tier", result.Tier)
+}
+
+// SetUserTransferLimitController overrides the transfer limits of a user in a currency (admin only)
+func (transferController *TransferController) SetUserTransferLimitController(c *gin.Context) {
+	config.Logger.Info("Setting user transfer limit", "method", "PUT", "endpoint", "/transfer-limits/:username/:currency")
+
+	var req requests.SetTransferLimitRequest
+
+	err := requestHandler.ReadJSONGin(c, &req, transferValidation.SetTransferLimitValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read transfer limit request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	req.Username = c.Param("username")
+	req.Currency = c.Param("currency")
+
+	result, err := transferController.transferService.SetUserTransferLimit(req)
+	writeTransferLimitResponse(c, result, err)
+}
+
+// DeleteUserTransferLimitController removes the override of a user in a currency so their tier limits apply (admin only)
+func (transferController *TransferController) DeleteUserTransferLimitController(c *gin.Context) {
+	config.Logger.Info("Deleting user transfer limit", "method", "DELETE", "endpoint", "/transfer-limits/:username/:currency")
+
+	result, err := transferController.transferService.DeleteUserTransferLimit(c.Param("username"), c.Param("currency"))
+	writeTransferLimitResponse(c, result, err)
+}
+
+func writeTransferLimitResponse(c *gin.Context, transferLimit responses.TransferLimitResponse, err error) {
+	if err != nil {
+		writeTransferLimitError(c, err)
+		return
+	}
+
+	response := responseHandler.Envelope{
+		"transfer_limit": transferLimit,
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, response, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Transfer limit response written successfully", "currency", transferLimit.Currency, "source", transferLimit.Source)
+}
+
+func writeTransferLimitError(c *gin.Context, err error) {
+	config.Logger.Error("Transfer limit request failed", "error", err.Error())
+	if clientErr, isClient := core.IsClientError(err); isClient {
+		errorResponse.BadRequestResponse(c, clientErr)
+	} else {
+		errorResponse.ServerErrorResponse(c, err)
+	}
+}
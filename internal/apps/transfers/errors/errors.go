@@ -12,6 +12,10 @@ var (
 		Message: "transfer amount must be positive",
 		Status:  400,
 	}
+	ErrAmountPrecision = core.ClientError{
+		Message: "transfer amount has more decimal places than the currency allows",
+		Status:  400,
+	}
 	ErrFromAccountNotFound = core.ClientError{
 		Message: "from account not found",
 		Status:  400,
@@ -37,3 +41,211 @@ var (
 		Status:  400,
 	}
 )
+
+// Idempotency errors
+var (
+	ErrIdempotencyKeyTooLong = core.ClientError{
+		Message: "Idempotency-Key header must not be longer than 255 characters",
+		Status:  400,
+	}
+	ErrIdempotencyKeyMismatch = core.ClientError{
+		Message: "Idempotency-Key has already been used with a different request body",
+		Status:  422,
+	}
+	ErrIdempotencyKeyReserved = core.ClientError{
+		Message: "Idempotency-Key must not start with scheduled-transfer:",
+		Status:  400,
+	}
+)
+
+// Authorization errors
+var (
+	ErrAccountForbidden = core.ClientError{
+		Message: "account does not belong to the authenticated user",
+		Status:  403,
+	}
+	ErrEmailNotVerified = core.ClientError{
+		Message: "email address must be verified before making transfers",
+		Status:  403,
+	}
+)
+
+// Transfer history errors
+var (
+	ErrAccountNotFound = core.ClientError{
+		Message: "account not found",
+		Status:  400,
+	}
+	ErrInvalidCursor = core.ClientError{
+		Message: "cursor is invalid",
+		Status:  400,
+	}
+	ErrInvalidDateRange = core.ClientError{
+		Message: "from must be before to",
+		Status:  400,
+	}
+	ErrInvalidAmountFilter = core.ClientError{
+		Message: "min_amount and max_amount must be numbers",
+		Status:  400,
+	}
+	ErrInvalidAmountRange = core.ClientError{
+		Message: "min_amount must not be greater than max_amount",
+		Status:  400,
+	}
+)
+
+// Scheduled transfer errors
+var (
+	ErrScheduledTransferNotFound = core.ClientError{
+		Message: "scheduled transfer not found",
+		Status:  404,
+	}
+	ErrScheduleStartInPast = core.ClientError{
+		Message: "start_at must be in the future",
+		Status:  400,
+	}
+	ErrDayOfMonthNotAllowed = core.ClientError{
+		Message: "day_of_month is only allowed for monthly schedules",
+		Status:  400,
+	}
+	ErrScheduledTransferClosed = core.ClientError{
+		Message: "scheduled transfer is no longer active and cannot be changed",
+		Status:  400,
+	}
+	ErrInvalidScheduledTransferStatus = core.ClientError{
+		Message: "status must be active or paused",
+		Status:  400,
+	}
+)
+
+// Transfer limit errors
+var (
+	ErrPerTransactionLimitExceeded = core.ClientError{
+		Message: "transfer amount exceeds your per-transaction limit",
+		Status:  400,
+	}
+	ErrDailyAmountLimitExceeded = core.ClientError{
+		Message: "transfer would exceed your daily transfer amount limit",
+		Status:  400,
+	}
+	ErrDailyCountLimitExceeded = core.ClientError{
+		Message: "transfer would exceed your daily number of transfers",
+		Status:  400,
+	}
+	ErrMonthlyAmountLimitExceeded = core.ClientError{
+		Message: "transfer would exceed your monthly transfer amount limit",
+		Status:  400,
+	}
+	ErrMonthlyCountLimitExceeded = core.ClientError{
+		Message: "transfer would exceed your monthly number of transfers",
+		Status:  400,
+	}
+	ErrUserNotFound = core.ClientError{
+		Message: "user not found",
+		Status:  404,
+	}
+	ErrTransferLimitOverrideNotFound = core.ClientError{
+		Message: "user has no transfer limit override for this currency",
+		Status:  404,
+	}
+	ErrInvalidTransferLimit = core.ClientError{
+		Message: "transfer limit amounts must be positive",
+		Status:  400,
+	}
+	ErrTransferLimitPrecision = core.ClientError{
+		Message: "transfer limit amount has more decimal places than the currency allows",
+		Status:  400,
+	}
+)
+
+// Transfer reversal errors
+var (
+	ErrTransferNotFound = core.ClientError{
+		Message: "transfer not found",
+		Status:  404,
+	}
+	ErrTransferAlreadyReversed = core.ClientError{
+		Message: "transfer has already been fully reversed",
+		Status:  400,
+	}
+	ErrReversalOfReversal = core.ClientError{
+		Message: "a reversal cannot be reversed",
+		Status:  400,
+	}
+	ErrReversalExceedsTransfer = core.ClientError{
+		Message: "reversal amount exceeds the amount left to reverse",
+		Status:  400,
+	}
+	ErrFeeAlreadyRefunded = core.ClientError{
+		Message: "transfer fee has already been refunded",
+		Status:  400,
+	}
+	ErrReversalInsufficientBalance = core.ClientError{
+		Message: "recipient account has insufficient balance for the reversal",
+		Status:  400,
+	}
+	ErrReversalAmountTooSmall = core.ClientError{
+		Message: "reversal amount is too small to return to the sender",
+		Status:  400,
+	}
+)
+
+// Transfer hold errors
+var (
+	ErrTransferHoldNotFound = core.ClientError{
+		Message: "transfer hold not found",
+		Status:  404,
+	}
+	ErrTransferHoldNotAuthorized = core.ClientError{
+		Message: "transfer hold has already been captured, voided or expired",
+		Status:  400,
+	}
+	ErrTransferHoldExpired = core.ClientError{
+		Message: "transfer hold has expired",
+		Status:  400,
+	}
+)
+
+// Transfer batch errors
+var (
+	ErrTransferBatchNotFound = core.ClientError{
+		Message: "transfer batch not found",
+		Status:  404,
+	}
+	ErrTransferBatchTooLarge = core.ClientError{
+		Message: "transfer batch has too many items",
+		Status:  400,
+	}
+	ErrTransferBatchMixedSourceAccounts = core.ClientError{
+		Message: "every item of a transfer batch must have the same from_account_id and from_currency",
+		Status:  400,
+	}
+	ErrTransferBatchInsufficientBalance = core.ClientError{
+		Message: "insufficient available balance for every transfer of the batch",
+		Status:  400,
+	}
+	ErrInvalidTransferBatch = core.ClientError{
+		Message: "transfer batch has invalid items",
+		Status:  400,
+	}
+)
+
+// Step-up authentication errors
+var (
+	ErrStepUpMFANotEnabled = core.ClientError{
+		Message: "transfers of this amount need two-factor authentication, please turn it on first",
+		Status:  403,
+	}
+	ErrStepUpTOTPCodeRequired = core.ClientError{
+		Message: "transfers of this amount need a code from your authenticator app in the X-TOTP-Code header",
+		Status:  403,
+	}
+	ErrStepUpInvalidTOTPCode = core.ClientError{
+		Message: "two-factor code is invalid or has already been used",
+		Status:  403,
+	}
+	ErrStepUpMFALocked = core.ClientError{
+		Message: "too many invalid two-factor codes, please try again later",
+		Status:  403,
+	}
+)
@@ -0,0 +1,17 @@
+package transfers
+
+import "time"
+
+type ListTransfersRequest struct {
+	Direction    string    `form:"direction" validate:"omitempty,oneof=all sent received"`
+	FromCurrency string    `form:"from_currency" validate:"omitempty,len=3"`
+	ToCurrency   string    `form:"to_currency" validate:"omitempty,len=3"`
+	From         time.Time `form:"from"` // RFC 3339, inclusive
+	To           time.Time `form:"to"`   // RFC 3339, exclusive
+	MinAmount    string    `form:"min_amount" validate:"omitempty,numeric"`
+	MaxAmount    string    `form:"max_amount" validate:"omitempty,numeric"`
+	Cursor       string    `form:"cursor"`
+	Limit        int32     `form:"limit" validate:"omitempty,min=1,max=100"`
+	AccountID    int64     `form:"-"` // Set from the :id path parameter when listing a single account
+	Username     string    `form:"-"` // Set from the authenticated user
+}
@@ -3,10 +3,16 @@ package transfers
 import "github.com/shopspring/decimal"
 
 type MakeTransferRequest struct {
-	FromAccountID int64           `json:"from_account_id" validate:"required,min=1"`
-	ToAccountID   int64           `json:"to_account_id" validate:"required,min=1"`
-	Amount        decimal.Decimal `json:"amount" validate:"required"`
-	FromCurrency  string          `json:"from_currency" validate:"required"`
-	ToCurrency    string          `json:"to_currency" validate:"required"`
-	ExchangeRate  decimal.Decimal `json:"exchange_rate" validate:"omitempty"`
+	FromAccountID  int64           `json:"from_account_id" validate:"required,min=1"`
+	ToAccountID    int64           `json:"to_account_id" validate:"required,min=1"`
+	Amount         decimal.Decimal `json:"amount" validate:"required"`
+	FromCurrency   string          `json:"from_currency" validate:"required"`
+	ToCurrency     string          `json:"to_currency" validate:"required"`
+	ExchangeRate   decimal.Decimal `json:"exchange_rate" validate:"omitempty"`
+	QuoteID        int64           `json:"quote_id,omitempty" validate:"omitempty,min=1"` // Quote from /exchange-rates/quotes, locks its rate and fee
+	Username       string          `json:"-"`                                             // Set from the authenticated user, not exposed in JSON
+	IdempotencyKey string          `json:"-"`                                             // Set from the Idempotency-Key header
+	RequestHash    string          `json:"-"`                                             // Fingerprint of the JSON body, set by the service layer
+	TOTPCode       string          `json:"-"`                                             // Set from the X-TOTP-Code header, needed above the step-up threshold
+	ScheduledRun   bool            `json:"-"`                                             // Set for runs of a scheduled transfer, which was stepped up when it was created
 }
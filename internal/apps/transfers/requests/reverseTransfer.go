@@ -0,0 +1,11 @@
+package transfers
+
+import "github.com/shopspring/decimal"
+
+// ReverseTransferRequest takes an amount of a transfer back from its recipient and returns it to the sender
+type ReverseTransferRequest struct {
+	// Amount in the currency the recipient received, everything that is left to reverse when not sent
+	Amount     *decimal.Decimal `json:"amount"`
+	RefundFee  bool             `json:"refund_fee"`
+	TransferID int64            `json:"-"` // Set from the :id path parameter
+}
@@ -0,0 +1,32 @@
+package transfers
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type CreateScheduledTransferRequest struct {
+	FromAccountID int64           `json:"from_account_id" validate:"required,min=1"`
+	ToAccountID   int64           `json:"to_account_id" validate:"required,min=1"`
+	Amount        decimal.Decimal `json:"amount" validate:"required"`
+	FromCurrency  string          `json:"from_currency" validate:"required"`
+	ToCurrency    string          `json:"to_currency" validate:"required"`
+	Frequency     string          `json:"frequency" validate:"required,oneof=once weekly monthly"`
+	StartAt       time.Time       `json:"start_at" validate:"required"`                   // First run, RFC 3339
+	DayOfMonth    int16           `json:"day_of_month" validate:"omitempty,min=1,max=31"` // Monthly only, defaults to the day of start_at
+	Username      string          `json:"-"`                                              // Set from the authenticated user, not exposed in JSON
+	TOTPCode      string          `json:"-"`                                              // Set from the X-TOTP-Code header, needed above the step-up threshold
+}
+
+// UpdateScheduledTransferRequest changes a schedule, only the fields that are sent are updated
+type UpdateScheduledTransferRequest struct {
+	Amount     *decimal.Decimal `json:"amount"`
+	Frequency  *string          `json:"frequency" validate:"omitempty,oneof=once weekly monthly"`
+	NextRunAt  *time.Time       `json:"next_run_at"`
+	DayOfMonth *int16           `json:"day_of_month" validate:"omitempty,min=1,max=31"`
+	Status     *string          `json:"status" validate:"omitempty,oneof=active paused"`
+	ID         int64            `json:"-"` // Set from the :id path parameter
+	Username   string           `json:"-"` // Set from the authenticated user, not exposed in JSON
+	TOTPCode   string           `json:"-"` // Set from the X-TOTP-Code header, needed when the new amount is above the step-up threshold
+}
@@ -0,0 +1,9 @@
+package transfers
+
+// CreateTransferBatchRequest makes many transfers from one source account in a single request
+type CreateTransferBatchRequest struct {
+	Mode     string                `json:"mode" validate:"required,oneof=all_or_nothing best_effort"` // all_or_nothing rolls back every transfer when one is refused
+	Items    []MakeTransferRequest `json:"items" validate:"required,min=1,dive"`                      // Every item must share from_account_id and from_currency
+	Username string                `json:"-"`                                                         // Set from the authenticated user, not exposed in JSON
+	TOTPCode string                `json:"-"`                                                         // Set from the X-TOTP-Code header, needed when the batch total is above the step-up threshold
+}
@@ -0,0 +1,15 @@
+package transfers
+
+import "github.com/shopspring/decimal"
+
+// AuthorizeTransferHoldRequest places a hold for a transfer that is made later when the hold is captured
+type AuthorizeTransferHoldRequest struct {
+	FromAccountID int64           `json:"from_account_id" validate:"required,min=1"`
+	ToAccountID   int64           `json:"to_account_id" validate:"required,min=1"`
+	Amount        decimal.Decimal `json:"amount" validate:"required"`
+	FromCurrency  string          `json:"from_currency" validate:"required"`
+	ToCurrency    string          `json:"to_currency" validate:"required"`
+	ExchangeRate  decimal.Decimal `json:"exchange_rate" validate:"omitempty"`
+	Username      string          `json:"-"` // Set from the authenticated user, not exposed in JSON
+	TOTPCode      string          `json:"-"` // Set from the X-TOTP-Code header, needed above the step-up threshold
+}
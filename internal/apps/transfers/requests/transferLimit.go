@@ -0,0 +1,14 @@
+package transfers
+
+import "github.com/shopspring/decimal"
+
+// SetTransferLimitRequest replaces the limits of a user in a currency, a limit that is not sent is not enforced
+type SetTransferLimitRequest struct {
+	PerTransactionAmount *decimal.Decimal `json:"per_transaction_amount"`
+	DailyAmount          *decimal.Decimal `json:"daily_amount"`
+	DailyCount           *int32           `json:"daily_count" validate:"omitempty,min=1"`
+	MonthlyAmount        *decimal.Decimal `json:"monthly_amount"`
+	MonthlyCount         *int32           `json:"monthly_count" validate:"omitempty,min=1"`
+	Username             string           `json:"-"` // Set from the :username path parameter
+	Currency             string           `json:"-"` // Set from the :currency path parameter
+}
@@ -0,0 +1,50 @@
+package responses
+
+import (
+	db "lemfi/simplebank/db/sqlc"
+)
+
+type ListTransfersResponse struct {
+	Transfers  []TransferHistoryItem `json:"transfers"`
+	NextCursor string                `json:"next_cursor,omitempty"`
+}
+
+type TransferHistoryItem struct {
+	TransferDetail
+	Direction string `json:"direction"` // "sent", "received" or "internal", relative to the account or user listed
+}
+
+// NewTransferHistoryItem converts a transfer history row to an API response item
+func NewTransferHistoryItem(row db.ListTransfersRow, direction string) TransferHistoryItem {
+	item := TransferHistoryItem{
+		TransferDetail: TransferDetail{
+			ID:              row.ID,
+			FromAccountID:   row.FromAccountID,
+			ToAccountID:     row.ToAccountID,
+			Amount:          row.Amount,
+			ConvertedAmount: row.ConvertedAmount,
+			ExchangeRate:    row.ExchangeRate,
+			Fee:             row.Fee,
+			MidMarketRate:   row.MidMarketRate.Decimal,
+			MarkupBps:       row.MarkupBps.Int32,
+			FeeScheduleID:   row.FeeScheduleID.Int64,
+			CreatedAt:       row.CreatedAt,
+			Status:          row.Status,
+
+			ReversalOfTransferID: row.ReversalOfTransferID.Int64,
+			RefundedFee:          row.RefundedFee,
+		},
+		Direction: direction,
+	}
+
+	if row.FromCurrency.Valid {
+		item.FromCurrency = row.FromCurrency.String
+	}
+	if row.ToCurrency.Valid {
+		item.ToCurrency = row.ToCurrency.String
+	}
+
+	item.setPivotRate(row.PivotCurrency, row.FromPivotRate, row.PivotToRate)
+
+	return item
+}
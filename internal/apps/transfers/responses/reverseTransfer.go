@@ -0,0 +1,63 @@
+package responses
+
+import (
+	db "lemfi/simplebank/db/sqlc"
+)
+
+type ReverseTransferResponse struct {
+	Transfer    TransferDetail `json:"transfer"` // The original transfer with its new status
+	Reversal    TransferDetail `json:"reversal"`
+	FromAccount AccountDetail  `json:"from_account"` // Recipient of the original transfer
+	ToAccount   AccountDetail  `json:"to_account"`   // Sender of the original transfer
+	FromEntry   EntryDetail    `json:"from_entry"`
+	ToEntry     EntryDetail    `json:"to_entry"`
+	Message     string         `json:"message"`
+}
+
+// NewReverseTransferResponse converts the result of a reversal to API response
+func NewReverseTransferResponse(result db.ReverseTransferTxResult) ReverseTransferResponse {
+	return ReverseTransferResponse{
+		Transfer:    newTransferDetail(result.Transfer),
+		Reversal:    newTransferDetail(result.Reversal),
+		FromAccount: newAccountDetail(result.FromAccount),
+		ToAccount:   newAccountDetail(result.ToAccount),
+		FromEntry: EntryDetail{
+			ID:        result.FromEntry.ID,
+			AccountID: result.FromEntry.AccountID,
+			Amount:    result.FromEntry.Amount,
+			CreatedAt: result.FromEntry.CreatedAt,
+		},
+		ToEntry: EntryDetail{
+			ID:        result.ToEntry.ID,
+			AccountID: result.ToEntry.AccountID,
+			Amount:    result.ToEntry.Amount,
+			CreatedAt: result.ToEntry.CreatedAt,
+		},
+		Message: "Transfer reversed successfully",
+	}
+}
+
+func newTransferDetail(transfer db.Transfer) TransferDetail {
+	detail := TransferDetail{
+		ID:                   transfer.ID,
+		FromAccountID:        transfer.FromAccountID,
+		ToAccountID:          transfer.ToAccountID,
+		Amount:               transfer.Amount,
+		ConvertedAmount:      transfer.ConvertedAmount,
+		FromCurrency:         transfer.FromCurrency.String,
+		ToCurrency:           transfer.ToCurrency.String,
+		ExchangeRate:         transfer.ExchangeRate,
+		MidMarketRate:        transfer.MidMarketRate.Decimal,
+		MarkupBps:            transfer.MarkupBps.Int32,
+		Fee:                  transfer.Fee,
+		FeeScheduleID:        transfer.FeeScheduleID.Int64,
+		CreatedAt:            transfer.CreatedAt,
+		Status:               transfer.Status,
+		ReversalOfTransferID: transfer.ReversalOfTransferID.Int64,
+		RefundedFee:          transfer.RefundedFee,
+	}
+
+	detail.setPivotRate(transfer.PivotCurrency, transfer.FromPivotRate, transfer.PivotToRate)
+
+	return detail
+}
@@ -0,0 +1,68 @@
+package responses
+
+import (
+	"time"
+
+	db "lemfi/simplebank/db/sqlc"
+
+	"github.com/shopspring/decimal"
+)
+
+type ScheduledTransferResponse struct {
+	ID             int64           `json:"id"`
+	FromAccountID  int64           `json:"from_account_id"`
+	ToAccountID    int64           `json:"to_account_id"`
+	Amount         decimal.Decimal `json:"amount"`
+	FromCurrency   string          `json:"from_currency"`
+	ToCurrency     string          `json:"to_currency"`
+	Frequency      string          `json:"frequency"`
+	DayOfMonth     *int16          `json:"day_of_month,omitempty"`
+	NextRunAt      time.Time       `json:"next_run_at"`
+	Status         string          `json:"status"`
+	Attempts       int32           `json:"attempts"`
+	LastRunAt      *time.Time      `json:"last_run_at,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	LastTransferID *int64          `json:"last_transfer_id,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// NewScheduledTransferResponse converts a scheduled transfer row to an API response
+func NewScheduledTransferResponse(scheduledTransfer db.ScheduledTransfer) ScheduledTransferResponse {
+	response := ScheduledTransferResponse{
+		ID:            scheduledTransfer.ID,
+		FromAccountID: scheduledTransfer.FromAccountID,
+		ToAccountID:   scheduledTransfer.ToAccountID,
+		Amount:        scheduledTransfer.Amount,
+		FromCurrency:  scheduledTransfer.FromCurrency,
+		ToCurrency:    scheduledTransfer.ToCurrency,
+		Frequency:     scheduledTransfer.Frequency,
+		NextRunAt:     scheduledTransfer.NextRunAt,
+		Status:        scheduledTransfer.Status,
+		Attempts:      scheduledTransfer.Attempts,
+		LastError:     scheduledTransfer.LastError.String,
+		CreatedAt:     scheduledTransfer.CreatedAt,
+		UpdatedAt:     scheduledTransfer.UpdatedAt,
+	}
+
+	if scheduledTransfer.DayOfMonth.Valid {
+		response.DayOfMonth = &scheduledTransfer.DayOfMonth.Int16
+	}
+	if scheduledTransfer.LastRunAt.Valid {
+		response.LastRunAt = &scheduledTransfer.LastRunAt.Time
+	}
+	if scheduledTransfer.LastTransferID.Valid {
+		response.LastTransferID = &scheduledTransfer.LastTransferID.Int64
+	}
+
+	return response
+}
+
+// NewScheduledTransferResponses converts scheduled transfer rows to API responses
+func NewScheduledTransferResponses(scheduledTransfers []db.ScheduledTransfer) []ScheduledTransferResponse {
+	response := make([]ScheduledTransferResponse, 0, len(scheduledTransfers))
+	for _, scheduledTransfer := range scheduledTransfers {
+		response = append(response, NewScheduledTransferResponse(scheduledTransfer))
+	}
+	return response
+}
@@ -0,0 +1,103 @@
+package responses
+
+import (
+	"time"
+
+	db "lemfi/simplebank/db/sqlc"
+
+	"github.com/shopspring/decimal"
+)
+
+type TransferBatchResponse struct {
+	ID             int64                       `json:"id"`
+	FromAccountID  int64                       `json:"from_account_id"`
+	Mode           string                      `json:"mode"`   // all_or_nothing or best_effort
+	Status         string                      `json:"status"` // completed, partially_completed or failed
+	ItemCount      int32                       `json:"item_count"`
+	SucceededCount int32                       `json:"succeeded_count"`
+	FailedCount    int32                       `json:"failed_count"`
+	TotalDebited   decimal.Decimal             `json:"total_debited"` // Amounts and fees of the completed transfers
+	Items          []TransferBatchItemResponse `json:"items,omitempty"`
+	CreatedAt      time.Time                   `json:"created_at"`
+}
+
+type TransferBatchItemResponse struct {
+	Index           int32           `json:"index"` // Position of the item in the request
+	ToAccountID     int64           `json:"to_account_id"`
+	Amount          decimal.Decimal `json:"amount"`
+	ConvertedAmount decimal.Decimal `json:"converted_amount"`
+	Fee             decimal.Decimal `json:"fee"`
+	ToCurrency      string          `json:"to_currency"`
+	Status          string          `json:"status"`                // completed, failed or skipped
+	TransferID      *int64          `json:"transfer_id,omitempty"` // Transfer made for a completed item
+	Error           string          `json:"error,omitempty"`       // Why a failed item was refused
+}
+
+type CreateTransferBatchResponse struct {
+	Batch       TransferBatchResponse `json:"batch"`
+	FromAccount AccountDetail         `json:"from_account"`
+	Message     string                `json:"message"`
+}
+
+// NewTransferBatchResponse converts a transfer batch and its items to an API response
+func NewTransferBatchResponse(batch db.TransferBatch, items []db.TransferBatchItem) TransferBatchResponse {
+	response := TransferBatchResponse{
+		ID:             batch.ID,
+		FromAccountID:  batch.FromAccountID,
+		Mode:           batch.Mode,
+		Status:         batch.Status,
+		ItemCount:      batch.ItemCount,
+		SucceededCount: batch.SucceededCount,
+		FailedCount:    batch.FailedCount,
+		TotalDebited:   batch.TotalDebited,
+		CreatedAt:      batch.CreatedAt,
+	}
+
+	if items != nil {
+		response.Items = make([]TransferBatchItemResponse, 0, len(items))
+	}
+	for _, item := range items {
+		itemResponse := TransferBatchItemResponse{
+			Index:           item.ItemIndex,
+			ToAccountID:     item.ToAccountID,
+			Amount:          item.Amount,
+			ConvertedAmount: item.ConvertedAmount,
+			Fee:             item.Fee,
+			ToCurrency:      item.ToCurrency,
+			Status:          item.Status,
+			Error:           item.Error.String,
+		}
+		if item.TransferID.Valid {
+			itemResponse.TransferID = &item.TransferID.Int64
+		}
+		response.Items = append(response.Items, itemResponse)
+	}
+
+	return response
+}
+
+// NewTransferBatchResponses converts transfer batches to API responses without their items
+func NewTransferBatchResponses(batches []db.TransferBatch) []TransferBatchResponse {
+	response := make([]TransferBatchResponse, 0, len(batches))
+	for _, batch := range batches {
+		response = append(response, NewTransferBatchResponse(batch, nil))
+	}
+	return response
+}
+
+// NewCreateTransferBatchResponse converts the result of a batch transfer to API response
+func NewCreateTransferBatchResponse(result db.BatchTransferTxResult) CreateTransferBatchResponse {
+	message := "Transfer batch completed successfully"
+	switch result.Batch.Status {
+	case db.TransferBatchStatusPartiallyCompleted:
+		message = "Transfer batch partially completed, see the items for the refused transfers"
+	case db.TransferBatchStatusFailed:
+		message = "Transfer batch failed, no transfer was made"
+	}
+
+	return CreateTransferBatchResponse{
+		Batch:       NewTransferBatchResponse(result.Batch, result.Items),
+		FromAccount: newAccountDetail(result.FromAccount),
+		Message:     message,
+	}
+}
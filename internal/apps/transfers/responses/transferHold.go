@@ -0,0 +1,112 @@
+package responses
+
+import (
+	"time"
+
+	db "lemfi/simplebank/db/sqlc"
+
+	"github.com/shopspring/decimal"
+)
+
+type TransferHoldResponse struct {
+	ID              int64           `json:"id"`
+	FromAccountID   int64           `json:"from_account_id"`
+	ToAccountID     int64           `json:"to_account_id"`
+	Amount          decimal.Decimal `json:"amount"`
+	ConvertedAmount decimal.Decimal `json:"converted_amount"`
+	FromCurrency    string          `json:"from_currency"`
+	ToCurrency      string          `json:"to_currency"`
+	ExchangeRate    decimal.Decimal `json:"exchange_rate"`             // Customer rate the transfer is made at when captured
+	MidMarketRate   decimal.Decimal `json:"mid_market_rate,omitempty"` // Rate before the FX markup, zero for same currency holds
+	MarkupBps       int32           `json:"markup_bps,omitempty"`
+	Fee             decimal.Decimal `json:"fee"`
+	FeeScheduleID   int64           `json:"fee_schedule_id,omitempty"`
+	RateDerived     bool            `json:"rate_derived"`
+	RateLegs        []RateLegDetail `json:"rate_legs,omitempty"`
+	Status          string          `json:"status"`                // authorized, captured, voided or expired
+	TransferID      *int64          `json:"transfer_id,omitempty"` // Transfer made when the hold was captured
+	ExpiresAt       time.Time       `json:"expires_at"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+type AuthorizeTransferHoldResponse struct {
+	Hold        TransferHoldResponse `json:"hold"`
+	FromAccount AccountDetail        `json:"from_account"`
+	Message     string               `json:"message"`
+}
+
+type CaptureTransferHoldResponse struct {
+	Hold        TransferHoldResponse `json:"hold"`
+	Transfer    TransferDetail       `json:"transfer"`
+	FromAccount AccountDetail        `json:"from_account"`
+	ToAccount   AccountDetail        `json:"to_account"`
+	FromEntry   EntryDetail          `json:"from_entry"`
+	ToEntry     EntryDetail          `json:"to_entry"`
+	Message     string               `json:"message"`
+}
+
+// NewTransferHoldResponse converts a transfer hold row to an API response
+func NewTransferHoldResponse(hold db.TransferHold) TransferHoldResponse {
+	response := TransferHoldResponse{
+		ID:              hold.ID,
+		FromAccountID:   hold.FromAccountID,
+		ToAccountID:     hold.ToAccountID,
+		Amount:          hold.Amount,
+		ConvertedAmount: hold.ConvertedAmount,
+		FromCurrency:    hold.FromCurrency,
+		ToCurrency:      hold.ToCurrency,
+		ExchangeRate:    hold.ExchangeRate,
+		MidMarketRate:   hold.MidMarketRate.Decimal,
+		MarkupBps:       hold.MarkupBps.Int32,
+		Fee:             hold.Fee,
+		FeeScheduleID:   hold.FeeScheduleID.Int64,
+		Status:          hold.Status,
+		ExpiresAt:       hold.ExpiresAt,
+		CreatedAt:       hold.CreatedAt,
+		UpdatedAt:       hold.UpdatedAt,
+	}
+
+	if hold.PivotCurrency.Valid {
+		response.RateDerived = true
+		response.RateLegs = pivotRateLegs(hold.FromCurrency, hold.ToCurrency, hold.PivotCurrency.String, hold.FromPivotRate.Decimal, hold.PivotToRate.Decimal)
+	}
+	if hold.TransferID.Valid {
+		response.TransferID = &hold.TransferID.Int64
+	}
+
+	return response
+}
+
+// NewTransferHoldResponses converts transfer hold rows to API responses
+func NewTransferHoldResponses(holds []db.TransferHold) []TransferHoldResponse {
+	response := make([]TransferHoldResponse, 0, len(holds))
+	for _, hold := range holds {
+		response = append(response, NewTransferHoldResponse(hold))
+	}
+	return response
+}
+
+// NewAuthorizeTransferHoldResponse converts the result of an authorization to API response
+func NewAuthorizeTransferHoldResponse(result db.AuthorizeTransferTxResult) AuthorizeTransferHoldResponse {
+	return AuthorizeTransferHoldResponse{
+		Hold:        NewTransferHoldResponse(result.Hold),
+		FromAccount: newAccountDetail(result.FromAccount),
+		Message:     "Transfer authorized, the amount is held until the hold is captured, voided or expires",
+	}
+}
+
+// NewCaptureTransferHoldResponse converts the result of a capture to API response
+func NewCaptureTransferHoldResponse(result db.CaptureTransferHoldTxResult) CaptureTransferHoldResponse {
+	transfer := NewMakeTransferResponse(result.Transfer)
+
+	return CaptureTransferHoldResponse{
+		Hold:        NewTransferHoldResponse(result.Hold),
+		Transfer:    transfer.Transfer,
+		FromAccount: transfer.FromAccount,
+		ToAccount:   transfer.ToAccount,
+		FromEntry:   transfer.FromEntry,
+		ToEntry:     transfer.ToEntry,
+		Message:     "Transfer hold captured successfully",
+	}
+}
@@ -0,0 +1,65 @@
+package responses
+
+import (
+	db "lemfi/simplebank/db/sqlc"
+
+	"github.com/shopspring/decimal"
+)
+
+// TransferLimitResponse is a limit of a user in one currency and how much of it has been used
+type TransferLimitResponse struct {
+	Currency             string                `json:"currency"`
+	Source               string                `json:"source"`                 // tier, override or none
+	PerTransactionAmount *decimal.Decimal      `json:"per_transaction_amount"` // null when not limited
+	DailyAmount          *decimal.Decimal      `json:"daily_amount"`           // null when not limited
+	DailyCount           *int32                `json:"daily_count"`            // null when not limited
+	MonthlyAmount        *decimal.Decimal      `json:"monthly_amount"`         // null when not limited
+	MonthlyCount         *int32                `json:"monthly_count"`          // null when not limited
+	Usage                TransferUsageResponse `json:"usage"`
+}
+
+// TransferUsageResponse is what a user has transferred over the rolling daily and monthly windows
+type TransferUsageResponse struct {
+	DailyAmount   decimal.Decimal `json:"daily_amount"`
+	DailyCount    int64           `json:"daily_count"`
+	MonthlyAmount decimal.Decimal `json:"monthly_amount"`
+	MonthlyCount  int64           `json:"monthly_count"`
+}
+
+type UserTransferLimitsResponse struct {
+	Username string                  `json:"username"`
+	Tier     string                  `json:"tier"`
+	Limits   []TransferLimitResponse `json:"limits"`
+}
+
+// NewTransferLimitResponse creates a TransferLimitResponse from the limits of a user and their usage
+func NewTransferLimitResponse(limits db.TransferLimits, usage db.TransferUsage) TransferLimitResponse {
+	response := TransferLimitResponse{
+		Currency: limits.Currency,
+		Source:   limits.Source,
+		Usage: TransferUsageResponse{
+			DailyAmount:   usage.DailyAmount,
+			DailyCount:    usage.DailyCount,
+			MonthlyAmount: usage.MonthlyAmount,
+			MonthlyCount:  usage.MonthlyCount,
+		},
+	}
+
+	if limits.PerTransactionAmount.Valid {
+		response.PerTransactionAmount = &limits.PerTransactionAmount.Decimal
+	}
+	if limits.DailyAmount.Valid {
+		response.DailyAmount = &limits.DailyAmount.Decimal
+	}
+	if limits.DailyCount.Valid {
+		response.DailyCount = &limits.DailyCount.Int32
+	}
+	if limits.MonthlyAmount.Valid {
+		response.MonthlyAmount = &limits.MonthlyAmount.Decimal
+	}
+	if limits.MonthlyCount.Valid {
+		response.MonthlyCount = &limits.MonthlyCount.Int32
+	}
+
+	return response
+}
@@ -5,6 +5,7 @@ import (
 
 	db "lemfi/simplebank/db/sqlc"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 )
 
@@ -15,6 +16,8 @@ type MakeTransferResponse struct {
 	FromEntry   EntryDetail    `json:"from_entry"`
 	ToEntry     EntryDetail    `json:"to_entry"`
 	Message     string         `json:"message"`
+	StatusCode  int            `json:"-"` // Original status code when replayed from an idempotency key
+	Replayed    bool           `json:"-"` // True when the response was served from an idempotency key
 }
 
 type TransferDetail struct {
@@ -25,17 +28,34 @@ type TransferDetail struct {
 	ConvertedAmount decimal.Decimal `json:"converted_amount,omitempty"`
 	FromCurrency    string          `json:"from_currency,omitempty"`
 	ToCurrency      string          `json:"to_currency,omitempty"`
-	ExchangeRate    decimal.Decimal `json:"exchange_rate,omitempty"`
+	ExchangeRate    decimal.Decimal `json:"exchange_rate,omitempty"`   // Customer rate the amount was converted at
+	MidMarketRate   decimal.Decimal `json:"mid_market_rate,omitempty"` // Rate before the FX markup, zero for same currency transfers
+	MarkupBps       int32           `json:"markup_bps,omitempty"`
 	Fee             decimal.Decimal `json:"fee,omitempty"`
+	FeeScheduleID   int64           `json:"fee_schedule_id,omitempty"` // Fee schedule version the fee was charged with
 	CreatedAt       time.Time       `json:"created_at"`
+	Status          string          `json:"status"` // completed, reversed or partially_reversed
+	// Set on reversals, the transfer they take money back from and the fee of it they refund
+	ReversalOfTransferID int64           `json:"reversal_of_transfer_id,omitempty"`
+	RefundedFee          decimal.Decimal `json:"refunded_fee,omitempty"`
+	// A derived exchange rate was composed from RateLegs through a pivot currency
+	RateDerived bool            `json:"rate_derived"`
+	RateLegs    []RateLegDetail `json:"rate_legs,omitempty"`
+}
+
+type RateLegDetail struct {
+	FromCurrency string          `json:"from_currency"`
+	ToCurrency   string          `json:"to_currency"`
+	Rate         decimal.Decimal `json:"rate"`
 }
 
 type AccountDetail struct {
-	ID        int64           `json:"id"`
-	Owner     string          `json:"owner"`
-	Balance   decimal.Decimal `json:"balance"`
-	Currency  string          `json:"currency"`
-	CreatedAt time.Time       `json:"created_at"`
+	ID               int64           `json:"id"`
+	Owner            string          `json:"owner"`
+	Balance          decimal.Decimal `json:"balance"`
+	AvailableBalance decimal.Decimal `json:"available_balance"` // Balance less the amount held by authorized transfers
+	Currency         string          `json:"currency"`
+	CreatedAt        time.Time       `json:"created_at"`
 }
 
 type EntryDetail struct {
@@ -56,22 +76,14 @@ func NewMakeTransferResponse(result db.TransferTxResult) MakeTransferResponse {
 			ConvertedAmount: result.Transfer.ConvertedAmount,
 			ExchangeRate:    result.Transfer.ExchangeRate,
 			Fee:             result.Transfer.Fee,
+			MidMarketRate:   result.Transfer.MidMarketRate.Decimal,
+			MarkupBps:       result.Transfer.MarkupBps.Int32,
+			FeeScheduleID:   result.Transfer.FeeScheduleID.Int64,
 			CreatedAt:       result.Transfer.CreatedAt,
+			Status:          result.Transfer.Status,
 		},
-		FromAccount: AccountDetail{
-			ID:        result.FromAccount.ID,
-			Owner:     result.FromAccount.Owner,
-			Balance:   result.FromAccount.Balance,
-			Currency:  result.FromAccount.Currency,
-			CreatedAt: result.FromAccount.CreatedAt,
-		},
-		ToAccount: AccountDetail{
-			ID:        result.ToAccount.ID,
-			Owner:     result.ToAccount.Owner,
-			Balance:   result.ToAccount.Balance,
-			Currency:  result.ToAccount.Currency,
-			CreatedAt: result.ToAccount.CreatedAt,
-		},
+		FromAccount: newAccountDetail(result.FromAccount),
+		ToAccount:   newAccountDetail(result.ToAccount),
 		FromEntry: EntryDetail{
 			ID:        result.FromEntry.ID,
 			AccountID: result.FromEntry.AccountID,
@@ -95,5 +107,36 @@ func NewMakeTransferResponse(result db.TransferTxResult) MakeTransferResponse {
 		response.Transfer.ToCurrency = result.Transfer.ToCurrency.String
 	}
 
+	response.Transfer.setPivotRate(result.Transfer.PivotCurrency, result.Transfer.FromPivotRate, result.Transfer.PivotToRate)
+
 	return response
 }
+
+func newAccountDetail(account db.Account) AccountDetail {
+	return AccountDetail{
+		ID:               account.ID,
+		Owner:            account.Owner,
+		Balance:          account.Balance,
+		AvailableBalance: account.Balance.Sub(account.HeldAmount),
+		Currency:         account.Currency,
+		CreatedAt:        account.CreatedAt,
+	}
+}
+
+// setPivotRate lists the legs of an exchange rate derived through a pivot currency, it must be called after the currencies are set
+func (transfer *TransferDetail) setPivotRate(pivotCurrency pgtype.Text, fromPivotRate decimal.NullDecimal, pivotToRate decimal.NullDecimal) {
+	if !pivotCurrency.Valid {
+		return
+	}
+
+	transfer.RateDerived = true
+	transfer.RateLegs = pivotRateLegs(transfer.FromCurrency, transfer.ToCurrency, pivotCurrency.String, fromPivotRate.Decimal, pivotToRate.Decimal)
+}
+
+// pivotRateLegs returns the two legs of an exchange rate derived through a pivot currency
+func pivotRateLegs(fromCurrency string, toCurrency string, pivotCurrency string, fromPivotRate decimal.Decimal, pivotToRate decimal.Decimal) []RateLegDetail {
+	return []RateLegDetail{
+		{FromCurrency: fromCurrency, ToCurrency: pivotCurrency, Rate: fromPivotRate},
+		{FromCurrency: pivotCurrency, ToCurrency: toCurrency, Rate: pivotToRate},
+	}
+}
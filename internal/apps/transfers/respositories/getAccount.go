@@ -0,0 +1,26 @@
+package transfers
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (transferRespository *TransferRespository) GetAccount(accountID int64) (db.Account, error) {
+	account, err := transferRespository.queries.GetAccount(transferRespository.context, accountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			config.Logger.Error("Account not found", "account_id", accountID)
+			return db.Account{}, transferErrors.ErrAccountNotFound
+		}
+
+		config.Logger.Error("Failed to fetch account from database", "error", err.Error(), "account_id", accountID)
+		return db.Account{}, err
+	}
+
+	return account, nil
+}
@@ -0,0 +1,26 @@
+package transfers
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (transferRespository *TransferRespository) GetFxQuote(quoteID int64) (db.FxQuote, error) {
+	quote, err := transferRespository.queries.GetFxQuote(transferRespository.context, quoteID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			config.Logger.Error("Quote not found", "quote_id", quoteID)
+			return db.FxQuote{}, exchangeRateErrors.ErrQuoteNotFound
+		}
+
+		config.Logger.Error("Failed to fetch quote from database", "error", err.Error(), "quote_id", quoteID)
+		return db.FxQuote{}, err
+	}
+
+	return quote, nil
+}
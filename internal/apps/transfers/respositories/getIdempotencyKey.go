@@ -0,0 +1,33 @@
+package transfers
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetIdempotencyKey returns the stored idempotency key for a user, reporting whether it exists
+func (transferRespository *TransferRespository) GetIdempotencyKey(username string, idempotencyKey string) (db.IdempotencyKey, bool, error) {
+	config.Logger.Info("Fetching idempotency key from database", "username", username, "idempotency_key", idempotencyKey)
+
+	storedKey, err := transferRespository.queries.GetIdempotencyKey(transferRespository.context, db.GetIdempotencyKeyParams{
+		Username:       username,
+		IdempotencyKey: idempotencyKey,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			config.Logger.Info("Idempotency key not used before", "username", username, "idempotency_key", idempotencyKey)
+			return db.IdempotencyKey{}, false, nil
+		}
+
+		config.Logger.Error("Failed to fetch idempotency key from database", "error", err.Error(), "username", username)
+		return db.IdempotencyKey{}, false, err
+	}
+
+	config.Logger.Info("Found idempotency key in database", "username", username, "idempotency_key", idempotencyKey, "transfer_id", storedKey.TransferID)
+
+	return storedKey, true, nil
+}
@@ -0,0 +1,25 @@
+package transfers
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (transferRespository *TransferRespository) GetUserEmailVerified(username string) (bool, error) {
+	user, err := transferRespository.queries.GetUser(transferRespository.context, username)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			config.Logger.Error("User not found", "username", username)
+			return false, transferErrors.ErrUserNotFound
+		}
+
+		config.Logger.Error("Failed to fetch user from database", "error", err.Error(), "username", username)
+		return false, err
+	}
+
+	return user.IsEmailVerified, nil
+}
@@ -1,6 +1,8 @@
 package transfers
 
 import (
+	"time"
+
 	db "lemfi/simplebank/db/sqlc"
 	requests "lemfi/simplebank/internal/apps/transfers/requests"
 
@@ -8,5 +10,36 @@ import (
 )
 
 type TransferRespositoryInterface interface {
-	MakeTransfer(payload requests.MakeTransferRequest, convertedAmount decimal.Decimal, exchangeRate decimal.Decimal, fee decimal.Decimal) (db.TransferTxResult, error)
+	MakeTransfer(payload requests.MakeTransferRequest, convertedAmount decimal.Decimal, exchangeRate decimal.Decimal, fee decimal.Decimal, feeScheduleID int64, pivotRate *db.PivotRate, midMarketRate decimal.Decimal, markupBps int32) (db.TransferTxResult, error)
+	GetIdempotencyKey(username string, idempotencyKey string) (db.IdempotencyKey, bool, error)
+	GetAccount(accountID int64) (db.Account, error)
+	GetUserEmailVerified(username string) (bool, error)
+	GetUserMfa(username string) (db.UserMfa, bool, error)
+	UseTotpStep(username string, step int64) error
+	CountMfaAttempt(params db.CountMfaAttemptParams) error
+	ResetMfaAttempts(username string) error
+	GetFxQuote(quoteID int64) (db.FxQuote, error)
+	ListTransfers(params db.ListTransfersParams) ([]db.ListTransfersRow, error)
+	CreateScheduledTransfer(params db.CreateScheduledTransferParams) (db.ScheduledTransfer, error)
+	GetScheduledTransfer(id int64) (db.ScheduledTransfer, error)
+	ListScheduledTransfers(owner string) ([]db.ScheduledTransfer, error)
+	UpdateScheduledTransfer(params db.UpdateScheduledTransferParams) (db.ScheduledTransfer, error)
+	ClaimDueScheduledTransfers(batchSize int32, claimedUntil time.Time) ([]db.ScheduledTransfer, error)
+	RecordScheduledTransferRun(params db.RecordScheduledTransferRunParams) (db.ScheduledTransfer, error)
+	GetTransferLimits(username string, currency string) (db.TransferLimits, error)
+	GetTransferUsage(username string, currency string, now time.Time) (db.TransferUsage, error)
+	UpsertUserTransferLimit(params db.UpsertUserTransferLimitParams) (db.UserTransferLimit, error)
+	DeleteUserTransferLimit(username string, currency string) error
+	GetTransfer(transferID int64) (db.GetTransferRow, error)
+	ReverseTransfer(params db.ReverseTransferTxParams) (db.ReverseTransferTxResult, error)
+	AuthorizeTransferHold(params db.AuthorizeTransferTxParams) (db.AuthorizeTransferTxResult, error)
+	GetTransferHold(id int64) (db.TransferHold, error)
+	ListTransferHolds(owner string) ([]db.TransferHold, error)
+	CaptureTransferHold(id int64) (db.CaptureTransferHoldTxResult, error)
+	VoidTransferHold(id int64) (db.TransferHold, error)
+	ExpireTransferHolds(batchSize int32) ([]db.TransferHold, error)
+	CreateTransferBatch(params db.BatchTransferTxParams) (db.BatchTransferTxResult, error)
+	GetTransferBatch(id int64) (db.TransferBatch, error)
+	ListTransferBatches(owner string) ([]db.TransferBatch, error)
+	ListTransferBatchItems(batchID int64) ([]db.TransferBatchItem, error)
 }
@@ -0,0 +1,20 @@
+package transfers
+
+import (
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+)
+
+func (transferRespository *TransferRespository) ListTransfers(params db.ListTransfersParams) ([]db.ListTransfersRow, error) {
+	config.Logger.Info("Fetching transfers from database", "account_id", params.AccountID.Int64, "owner", params.Owner.String, "limit", params.PageLimit)
+
+	transfers, err := transferRespository.queries.ListTransfers(transferRespository.context, params)
+	if err != nil {
+		config.Logger.Error("Failed to fetch transfers from database", "error", err.Error())
+		return nil, err
+	}
+
+	config.Logger.Info("Fetched transfers from database", "count", len(transfers))
+
+	return transfers, nil
+}
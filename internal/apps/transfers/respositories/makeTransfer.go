@@ -1,8 +1,12 @@
 package transfers
 
 import (
+	"errors"
+	"net/http"
+
 	"lemfi/simplebank/config"
 	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
 	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
 	requests "lemfi/simplebank/internal/apps/transfers/requests"
 
@@ -14,6 +18,10 @@ func (transferRespository *TransferRespository) MakeTransfer(
 	convertedAmount decimal.Decimal,
 	exchangeRate decimal.Decimal,
 	fee decimal.Decimal,
+	feeScheduleID int64,
+	pivotRate *db.PivotRate,
+	midMarketRate decimal.Decimal,
+	markupBps int32,
 ) (db.TransferTxResult, error) {
 	// Validate that accounts exist and have sufficient balance
 	fromAccount, err := transferRespository.queries.GetAccount(transferRespository.context, payload.FromAccountID)
@@ -45,12 +53,13 @@ func (transferRespository *TransferRespository) MakeTransfer(
 		return db.TransferTxResult{}, transferErrors.ErrToAccountCurrencyMismatch
 	}
 
-	// Validate sufficient balance (including fee)
+	// Validate sufficient available balance (including fee), amounts held by authorized transfers cannot be spent
 	totalAmount := payload.Amount.Add(fee)
-	if fromAccount.Balance.LessThan(totalAmount) {
+	if fromAccount.Balance.Sub(fromAccount.HeldAmount).LessThan(totalAmount) {
 		config.Logger.Error("Insufficient balance",
 			"account_id", payload.FromAccountID,
 			"account_balance", fromAccount.Balance,
+			"held_amount", fromAccount.HeldAmount,
 			"transfer_amount", payload.Amount,
 			"fee", fee,
 			"total_required", totalAmount,
@@ -68,10 +77,24 @@ func (transferRespository *TransferRespository) MakeTransfer(
 		FromCurrency:    payload.FromCurrency,
 		ToCurrency:      payload.ToCurrency,
 		Fee:             fee,
+		FeeScheduleID:   feeScheduleID,
+		PivotRate:       pivotRate,
+		MidMarketRate:   midMarketRate,
+		MarkupBps:       markupBps,
+		QuoteID:         payload.QuoteID,
+		Username:        payload.Username,
+		IdempotencyKey:  payload.IdempotencyKey,
+		RequestHash:     payload.RequestHash,
+		ResponseStatus:  http.StatusCreated,
 	}
 
 	// Execute the transfer transaction
 	result, err := transferRespository.queries.TransferTx(transferRespository.context, transferParams)
+	if errors.Is(err, db.ErrQuoteUnavailable) {
+		// Another transfer used the quote, or it expired, after the service checked it
+		config.Logger.Error("Quote no longer available", "quote_id", payload.QuoteID, "username", payload.Username)
+		return db.TransferTxResult{}, exchangeRateErrors.ErrQuoteUnavailable
+	}
 	if err != nil {
 		return db.TransferTxResult{}, err
 	}
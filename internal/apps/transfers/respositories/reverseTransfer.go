@@ -0,0 +1,43 @@
+package transfers
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (transferRespository *TransferRespository) GetTransfer(transferID int64) (db.GetTransferRow, error) {
+	transfer, err := transferRespository.queries.GetTransfer(transferRespository.context, transferID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			config.Logger.Error("Transfer not found", "transfer_id", transferID)
+			return db.GetTransferRow{}, transferErrors.ErrTransferNotFound
+		}
+
+		config.Logger.Error("Failed to fetch transfer from database", "error", err.Error(), "transfer_id", transferID)
+		return db.GetTransferRow{}, err
+	}
+
+	return transfer, nil
+}
+
+func (transferRespository *TransferRespository) ReverseTransfer(params db.ReverseTransferTxParams) (db.ReverseTransferTxResult, error) {
+	config.Logger.Info("Reversing transfer", "transfer_id", params.TransferID, "amount", params.Amount, "refund_fee", params.RefundFee)
+
+	result, err := transferRespository.queries.ReverseTransferTx(transferRespository.context, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			config.Logger.Error("Transfer not found", "transfer_id", params.TransferID)
+			return db.ReverseTransferTxResult{}, transferErrors.ErrTransferNotFound
+		}
+
+		config.Logger.Error("Failed to reverse transfer", "error", err.Error(), "transfer_id", params.TransferID)
+		return db.ReverseTransferTxResult{}, err
+	}
+
+	return result, nil
+}
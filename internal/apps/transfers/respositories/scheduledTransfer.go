@@ -0,0 +1,87 @@
+package transfers
+
+import (
+	"errors"
+	"time"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (transferRespository *TransferRespository) CreateScheduledTransfer(params db.CreateScheduledTransferParams) (db.ScheduledTransfer, error) {
+	config.Logger.Info("Creating scheduled transfer in database", "owner", params.Owner, "frequency", params.Frequency, "next_run_at", params.NextRunAt)
+
+	scheduledTransfer, err := transferRespository.queries.CreateScheduledTransfer(transferRespository.context, params)
+	if err != nil {
+		config.Logger.Error("Failed to create scheduled transfer in database", "error", err.Error(), "owner", params.Owner)
+		return db.ScheduledTransfer{}, err
+	}
+
+	return scheduledTransfer, nil
+}
+
+func (transferRespository *TransferRespository) GetScheduledTransfer(id int64) (db.ScheduledTransfer, error) {
+	scheduledTransfer, err := transferRespository.queries.GetScheduledTransfer(transferRespository.context, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			config.Logger.Error("Scheduled transfer not found", "scheduled_transfer_id", id)
+			return db.ScheduledTransfer{}, transferErrors.ErrScheduledTransferNotFound
+		}
+
+		config.Logger.Error("Failed to fetch scheduled transfer from database", "error", err.Error(), "scheduled_transfer_id", id)
+		return db.ScheduledTransfer{}, err
+	}
+
+	return scheduledTransfer, nil
+}
+
+func (transferRespository *TransferRespository) ListScheduledTransfers(owner string) ([]db.ScheduledTransfer, error) {
+	scheduledTransfers, err := transferRespository.queries.ListScheduledTransfers(transferRespository.context, owner)
+	if err != nil {
+		config.Logger.Error("Failed to fetch scheduled transfers from database", "error", err.Error(), "owner", owner)
+		return nil, err
+	}
+
+	return scheduledTransfers, nil
+}
+
+func (transferRespository *TransferRespository) UpdateScheduledTransfer(params db.UpdateScheduledTransferParams) (db.ScheduledTransfer, error) {
+	config.Logger.Info("Updating scheduled transfer in database", "scheduled_transfer_id", params.ID, "status", params.Status, "next_run_at", params.NextRunAt)
+
+	scheduledTransfer, err := transferRespository.queries.UpdateScheduledTransfer(transferRespository.context, params)
+	if err != nil {
+		config.Logger.Error("Failed to update scheduled transfer in database", "error", err.Error(), "scheduled_transfer_id", params.ID)
+		return db.ScheduledTransfer{}, err
+	}
+
+	return scheduledTransfer, nil
+}
+
+// ClaimDueScheduledTransfers leases due scheduled transfers to this worker until claimedUntil.
+// Rows leased by another worker are skipped
+func (transferRespository *TransferRespository) ClaimDueScheduledTransfers(batchSize int32, claimedUntil time.Time) ([]db.ScheduledTransfer, error) {
+	scheduledTransfers, err := transferRespository.queries.ClaimDueScheduledTransfers(transferRespository.context, db.ClaimDueScheduledTransfersParams{
+		ClaimedUntil: claimedUntil,
+		BatchSize:    batchSize,
+	})
+	if err != nil {
+		config.Logger.Error("Failed to claim due scheduled transfers", "error", err.Error())
+		return nil, err
+	}
+
+	return scheduledTransfers, nil
+}
+
+// RecordScheduledTransferRun stores the outcome of a run and releases the lease
+func (transferRespository *TransferRespository) RecordScheduledTransferRun(params db.RecordScheduledTransferRunParams) (db.ScheduledTransfer, error) {
+	scheduledTransfer, err := transferRespository.queries.RecordScheduledTransferRun(transferRespository.context, params)
+	if err != nil {
+		config.Logger.Error("Failed to record scheduled transfer run", "error", err.Error(), "scheduled_transfer_id", params.ID)
+		return db.ScheduledTransfer{}, err
+	}
+
+	return scheduledTransfer, nil
+}
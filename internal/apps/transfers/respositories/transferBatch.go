@@ -0,0 +1,58 @@
+package transfers
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (transferRespository *TransferRespository) CreateTransferBatch(params db.BatchTransferTxParams) (db.BatchTransferTxResult, error) {
+	config.Logger.Info("Creating transfer batch", "owner", params.Username, "from_account_id", params.FromAccountID, "mode", params.Mode, "items", len(params.Items))
+
+	result, err := transferRespository.queries.BatchTransferTx(transferRespository.context, params)
+	if err != nil {
+		config.Logger.Error("Failed to create transfer batch", "error", err.Error(), "from_account_id", params.FromAccountID)
+		return db.BatchTransferTxResult{}, err
+	}
+
+	return result, nil
+}
+
+func (transferRespository *TransferRespository) GetTransferBatch(id int64) (db.TransferBatch, error) {
+	batch, err := transferRespository.queries.GetTransferBatch(transferRespository.context, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			config.Logger.Error("Transfer batch not found", "transfer_batch_id", id)
+			return db.TransferBatch{}, transferErrors.ErrTransferBatchNotFound
+		}
+
+		config.Logger.Error("Failed to fetch transfer batch from database", "error", err.Error(), "transfer_batch_id", id)
+		return db.TransferBatch{}, err
+	}
+
+	return batch, nil
+}
+
+func (transferRespository *TransferRespository) ListTransferBatches(owner string) ([]db.TransferBatch, error) {
+	batches, err := transferRespository.queries.ListTransferBatches(transferRespository.context, owner)
+	if err != nil {
+		config.Logger.Error("Failed to list transfer batches from database", "error", err.Error(), "owner", owner)
+		return nil, err
+	}
+
+	return batches, nil
+}
+
+func (transferRespository *TransferRespository) ListTransferBatchItems(batchID int64) ([]db.TransferBatchItem, error) {
+	items, err := transferRespository.queries.ListTransferBatchItems(transferRespository.context, batchID)
+	if err != nil {
+		config.Logger.Error("Failed to list transfer batch items from database", "error", err.Error(), "transfer_batch_id", batchID)
+		return nil, err
+	}
+
+	return items, nil
+}
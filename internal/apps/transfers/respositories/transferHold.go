@@ -0,0 +1,86 @@
+package transfers
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (transferRespository *TransferRespository) AuthorizeTransferHold(params db.AuthorizeTransferTxParams) (db.AuthorizeTransferTxResult, error) {
+	config.Logger.Info("Authorizing transfer hold", "owner", params.Username, "from_account_id", params.FromAccountID, "amount", params.Amount, "fee", params.Fee)
+
+	result, err := transferRespository.queries.AuthorizeTransferTx(transferRespository.context, params)
+	if err != nil {
+		config.Logger.Error("Failed to authorize transfer hold", "error", err.Error(), "from_account_id", params.FromAccountID)
+		return db.AuthorizeTransferTxResult{}, err
+	}
+
+	return result, nil
+}
+
+func (transferRespository *TransferRespository) GetTransferHold(id int64) (db.TransferHold, error) {
+	hold, err := transferRespository.queries.GetTransferHold(transferRespository.context, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			config.Logger.Error("Transfer hold not found", "transfer_hold_id", id)
+			return db.TransferHold{}, transferErrors.ErrTransferHoldNotFound
+		}
+
+		config.Logger.Error("Failed to fetch transfer hold from database", "error", err.Error(), "transfer_hold_id", id)
+		return db.TransferHold{}, err
+	}
+
+	return hold, nil
+}
+
+func (transferRespository *TransferRespository) ListTransferHolds(owner string) ([]db.TransferHold, error) {
+	holds, err := transferRespository.queries.ListTransferHolds(transferRespository.context, owner)
+	if err != nil {
+		config.Logger.Error("Failed to list transfer holds from database", "error", err.Error(), "owner", owner)
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+func (transferRespository *TransferRespository) CaptureTransferHold(id int64) (db.CaptureTransferHoldTxResult, error) {
+	config.Logger.Info("Capturing transfer hold", "transfer_hold_id", id)
+
+	result, err := transferRespository.queries.CaptureTransferHoldTx(transferRespository.context, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			config.Logger.Error("Transfer hold not found", "transfer_hold_id", id)
+			return db.CaptureTransferHoldTxResult{}, transferErrors.ErrTransferHoldNotFound
+		}
+
+		config.Logger.Error("Failed to capture transfer hold", "error", err.Error(), "transfer_hold_id", id)
+		return db.CaptureTransferHoldTxResult{}, err
+	}
+
+	return result, nil
+}
+
+func (transferRespository *TransferRespository) VoidTransferHold(id int64) (db.TransferHold, error) {
+	config.Logger.Info("Voiding transfer hold", "transfer_hold_id", id)
+
+	hold, err := transferRespository.queries.VoidTransferHoldTx(transferRespository.context, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			config.Logger.Error("Transfer hold not found", "transfer_hold_id", id)
+			return db.TransferHold{}, transferErrors.ErrTransferHoldNotFound
+		}
+
+		config.Logger.Error("Failed to void transfer hold", "error", err.Error(), "transfer_hold_id", id)
+		return db.TransferHold{}, err
+	}
+
+	return hold, nil
+}
+
+func (transferRespository *TransferRespository) ExpireTransferHolds(batchSize int32) ([]db.TransferHold, error) {
+	return transferRespository.queries.ExpireTransferHoldsTx(transferRespository.context, batchSize)
+}
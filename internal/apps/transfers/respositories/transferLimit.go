@@ -0,0 +1,70 @@
+package transfers
+
+import (
+	"errors"
+	"time"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetTransferLimits returns the limits that apply to a user in a currency
+func (transferRespository *TransferRespository) GetTransferLimits(username string, currency string) (db.TransferLimits, error) {
+	limits, err := db.GetTransferLimits(transferRespository.context, transferRespository.queries, username, currency)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			config.Logger.Error("User not found", "username", username)
+			return db.TransferLimits{}, transferErrors.ErrUserNotFound
+		}
+
+		config.Logger.Error("Failed to fetch transfer limits from database", "error", err.Error(), "username", username, "currency", currency)
+		return db.TransferLimits{}, err
+	}
+
+	return limits, nil
+}
+
+// GetTransferUsage returns what a user has transferred in a currency over the limit windows ending at now
+func (transferRespository *TransferRespository) GetTransferUsage(username string, currency string, now time.Time) (db.TransferUsage, error) {
+	usage, err := db.GetTransferUsage(transferRespository.context, transferRespository.queries, username, currency, now)
+	if err != nil {
+		config.Logger.Error("Failed to fetch transfer usage from database", "error", err.Error(), "username", username, "currency", currency)
+		return db.TransferUsage{}, err
+	}
+
+	return usage, nil
+}
+
+func (transferRespository *TransferRespository) UpsertUserTransferLimit(params db.UpsertUserTransferLimitParams) (db.UserTransferLimit, error) {
+	config.Logger.Info("Setting user transfer limit", "username", params.Username, "currency", params.Currency)
+
+	limit, err := transferRespository.queries.UpsertUserTransferLimit(transferRespository.context, params)
+	if err != nil {
+		config.Logger.Error("Failed to set user transfer limit", "error", err.Error(), "username", params.Username)
+		return db.UserTransferLimit{}, err
+	}
+
+	return limit, nil
+}
+
+func (transferRespository *TransferRespository) DeleteUserTransferLimit(username string, currency string) error {
+	config.Logger.Info("Deleting user transfer limit", "username", username, "currency", currency)
+
+	deleted, err := transferRespository.queries.DeleteUserTransferLimit(transferRespository.context, db.DeleteUserTransferLimitParams{
+		Username: username,
+		Currency: currency,
+	})
+	if err != nil {
+		config.Logger.Error("Failed to delete user transfer limit", "error", err.Error(), "username", username)
+		return err
+	}
+
+	if deleted == 0 {
+		return transferErrors.ErrTransferLimitOverrideNotFound
+	}
+
+	return nil
+}
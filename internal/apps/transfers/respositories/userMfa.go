@@ -0,0 +1,72 @@
+package transfers
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetUserMfa returns the TOTP enrolment of a user, reporting whether one exists
+func (transferRespository *TransferRespository) GetUserMfa(username string) (db.UserMfa, bool, error) {
+	userMfa, err := transferRespository.queries.GetUserMfa(transferRespository.context, username)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.UserMfa{}, false, nil
+		}
+
+		config.Logger.Error("Failed to fetch user MFA from database", "error", err.Error(), "username", username)
+		return db.UserMfa{}, false, err
+	}
+
+	return userMfa, true, nil
+}
+
+// UseTotpStep records the time step of an accepted TOTP code so the code cannot be replayed.
+// It fails with ErrStepUpInvalidTOTPCode when the step, or a later one, was already used
+func (transferRespository *TransferRespository) UseTotpStep(username string, step int64) error {
+	_, err := transferRespository.queries.UseTotpStep(transferRespository.context, db.UseTotpStepParams{
+		Username:     username,
+		LastUsedStep: step,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return transferErrors.ErrStepUpInvalidTOTPCode
+		}
+
+		config.Logger.Error("Failed to use TOTP step in database", "error", err.Error(), "username", username)
+		return err
+	}
+
+	return nil
+}
+
+// CountMfaAttempt counts an attempt at a TOTP code before it is checked, the attempt that reaches
+// the limit locks the user out until params.LockedUntil. It fails with ErrStepUpMFALocked while they are
+func (transferRespository *TransferRespository) CountMfaAttempt(params db.CountMfaAttemptParams) error {
+	_, err := transferRespository.queries.CountMfaAttempt(transferRespository.context, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return transferErrors.ErrStepUpMFALocked
+		}
+
+		config.Logger.Error("Failed to count MFA attempt in database", "error", err.Error(), "username", params.Username)
+		return err
+	}
+
+	return nil
+}
+
+// ResetMfaAttempts clears the attempts of a user once a TOTP code is accepted
+func (transferRespository *TransferRespository) ResetMfaAttempts(username string) error {
+	err := transferRespository.queries.ResetMfaAttempts(transferRespository.context, username)
+	if err != nil {
+		config.Logger.Error("Failed to reset MFA attempts in database", "error", err.Error(), "username", username)
+		return err
+	}
+
+	return nil
+}
@@ -12,7 +12,7 @@ import (
 )
 
 // Routes defines the health check route for the API.
-func Routes(router *gin.Engine) {
+func Routes(router *gin.Engine, authUsers middleware.PasswordChangeGetter) {
 	// Initialize repositories
 	transferRespository := respositories.NewTransferRespository()
 	exchangeRateRepository := exchangeRateRespositories.NewExchangeRateRepository()
@@ -27,10 +27,56 @@ func Routes(router *gin.Engine) {
 	// Group transfers routes with common middleware
 	transfersGroup := router.Group("/api/v1/transfers")
 	transfersGroup.Use(
-		middleware.ValidateAuth(),
+		middleware.ValidateAuth(authUsers),
 		middleware.RequireAuthenticatedUser(),
 	)
 
 	// Register routes
 	transfersGroup.POST("", transferController.MakeTransferController)
+	transfersGroup.GET("", transferController.ListTransfersController)
+
+	// Scheduled and recurring transfers
+	transfersGroup.POST("/scheduled", transferController.CreateScheduledTransferController)
+	transfersGroup.GET("/scheduled", transferController.ListScheduledTransfersController)
+	transfersGroup.GET("/scheduled/:id", transferController.GetScheduledTransferController)
+	transfersGroup.PATCH("/scheduled/:id", transferController.UpdateScheduledTransferController)
+	transfersGroup.DELETE("/scheduled/:id", transferController.CancelScheduledTransferController)
+
+	// Two-phase transfers, a hold reduces the available balance until it is captured, voided or expires
+	transfersGroup.POST("/holds", transferController.AuthorizeTransferHoldController)
+	transfersGroup.GET("/holds", transferController.ListTransferHoldsController)
+	transfersGroup.GET("/holds/:id", transferController.GetTransferHoldController)
+	transfersGroup.POST("/holds/:id/capture", transferController.CaptureTransferHoldController)
+	transfersGroup.POST("/holds/:id/void", transferController.VoidTransferHoldController)
+
+	// Batches make many transfers from one source account, all_or_nothing or best_effort
+	transfersGroup.POST("/batches", transferController.CreateTransferBatchController)
+	transfersGroup.GET("/batches", transferController.ListTransferBatchesController)
+	transfersGroup.GET("/batches/:id", transferController.GetTransferBatchController)
+
+	// Transfer history for a single account
+	accountTransfersGroup := router.Group("/api/v1/accounts/:id/transfers")
+	accountTransfersGroup.Use(
+		middleware.ValidateAuth(authUsers),
+		middleware.RequireAuthenticatedUser(),
+	)
+	accountTransfersGroup.GET("", transferController.ListAccountTransfersController)
+
+	// Transfer limits apply per user tier and currency, admins can override them for a single user
+	transferLimitsGroup := router.Group("/api/v1/transfer-limits")
+	transferLimitsGroup.Use(
+		middleware.ValidateAuth(authUsers),
+		middleware.RequireAuthenticatedUserWithRole("admin"),
+	)
+	transferLimitsGroup.GET("/:username", transferController.GetUserTransferLimitsController)
+	transferLimitsGroup.PUT("/:username/:currency", transferController.SetUserTransferLimitController)
+	transferLimitsGroup.DELETE("/:username/:currency", transferController.DeleteUserTransferLimitController)
+
+	// Reversals take a transfer, or part of it, back from the recipient and are only made by admins
+	transferReversalsGroup := router.Group("/api/v1/transfers/:id/reversals")
+	transferReversalsGroup.Use(
+		middleware.ValidateAuth(authUsers),
+		middleware.RequireAuthenticatedUserWithRole("admin"),
+	)
+	transferReversalsGroup.POST("", transferController.ReverseTransferController)
 }
@@ -0,0 +1,101 @@
+package transfers
+
+import (
+	"strings"
+	"time"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+	"lemfi/simplebank/pkg/totp"
+
+	"github.com/shopspring/decimal"
+)
+
+// authorizeAccountOwner loads an account and checks that it belongs to the authenticated user
+func (transferService *TransferService) authorizeAccountOwner(accountID int64, username string) (db.Account, error) {
+	account, err := transferService.transferRespository.GetAccount(accountID)
+	if err != nil {
+		return db.Account{}, err
+	}
+
+	if username == "" || account.Owner != username {
+		config.Logger.Error("Account does not belong to user", "account_id", accountID, "username", username)
+		return db.Account{}, transferErrors.ErrAccountForbidden
+	}
+
+	return account, nil
+}
+
+// authorizeVerifiedEmail refuses transfers from users who have not verified their email address,
+// when transfers are configured to require it
+func (transferService *TransferService) authorizeVerifiedEmail(username string) error {
+	if !transferService.requireVerifiedEmail {
+		return nil
+	}
+
+	verified, err := transferService.transferRespository.GetUserEmailVerified(username)
+	if err != nil {
+		return err
+	}
+
+	if !verified {
+		config.Logger.Error("User has not verified their email address", "username", username)
+		return transferErrors.ErrEmailNotVerified
+	}
+
+	return nil
+}
+
+// authorizeStepUp asks for a TOTP code on transfers above the step-up threshold of their currency.
+// The code is used up so that it cannot authorize a second transfer. Attempts count towards the
+// same lockout as the second factor of logins
+func (transferService *TransferService) authorizeStepUp(username string, amount decimal.Decimal, currency string, totpCode string) error {
+	threshold, found := transferService.stepUpThresholds[currency]
+	if !found || amount.LessThanOrEqual(threshold) {
+		return nil
+	}
+
+	userMfa, found, err := transferService.transferRespository.GetUserMfa(username)
+	if err != nil {
+		return err
+	}
+	if !found || !userMfa.EnabledAt.Valid {
+		config.Logger.Error("Transfer above the step-up threshold from a user without two-factor authentication", "username", username, "amount", amount, "currency", currency)
+		return transferErrors.ErrStepUpMFANotEnabled
+	}
+
+	if totpCode == "" {
+		config.Logger.Info("Transfer above the step-up threshold needs a TOTP code", "username", username, "amount", amount, "currency", currency)
+		return transferErrors.ErrStepUpTOTPCodeRequired
+	}
+
+	err = transferService.transferRespository.CountMfaAttempt(db.CountMfaAttemptParams{
+		MaxFailedAttempts: transferService.mfaMaxFailedAttempts,
+		LockedUntil:       time.Now().Add(transferService.mfaLockoutDuration),
+		Username:          username,
+	})
+	if err != nil {
+		config.Logger.Warn("Step-up TOTP code refused while locked out", "username", username)
+		return err
+	}
+
+	secret, err := totp.DecryptSecret(transferService.mfaKey, username, userMfa.EncryptedSecret)
+	if err != nil {
+		config.Logger.Error("Failed to decrypt TOTP secret", "error", err.Error(), "username", username)
+		return err
+	}
+
+	step, valid := totp.Validate(secret, strings.TrimSpace(totpCode), time.Now(), userMfa.LastUsedStep)
+	if !valid {
+		config.Logger.Error("Invalid TOTP code for step-up", "username", username)
+		return transferErrors.ErrStepUpInvalidTOTPCode
+	}
+
+	err = transferService.transferRespository.UseTotpStep(username, step)
+	if err != nil {
+		return err
+	}
+
+	return transferService.transferRespository.ResetMfaAttempts(username)
+}
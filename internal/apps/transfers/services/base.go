@@ -1,13 +1,34 @@
 package transfers
 
 import (
+	"time"
+
+	"lemfi/simplebank/config"
 	exchangeRateService "lemfi/simplebank/internal/apps/exchangeRates/services"
 	respositories "lemfi/simplebank/internal/apps/transfers/respositories"
+
+	"github.com/shopspring/decimal"
 )
 
 type TransferService struct {
 	transferRespository respositories.TransferRespositoryInterface
 	exchangeRateService *exchangeRateService.ExchangeRateService
+	// reversalRate cross-currency reversals are converted at, ReversalRateOriginal or ReversalRateCurrent
+	reversalRate string
+	// holdExpiry is how long an authorized transfer hold can be captured
+	holdExpiry time.Duration
+	// batchMaxItems is the most transfers accepted in one batch, 0 for no limit
+	batchMaxItems int
+	// requireVerifiedEmail refuses transfers from users who have not verified their email address
+	requireVerifiedEmail bool
+	// stepUpThresholds are the amounts per currency above which a transfer needs a TOTP code
+	stepUpThresholds map[string]decimal.Decimal
+	// mfaKey decrypts the TOTP secrets of users
+	mfaKey string
+	// mfaMaxFailedAttempts is how many wrong TOTP codes in a row lock a user out
+	mfaMaxFailedAttempts int32
+	// mfaLockoutDuration is how long TOTP codes are refused once a user is locked out
+	mfaLockoutDuration time.Duration
 }
 
 func NewTransferService(
@@ -15,7 +36,15 @@ func NewTransferService(
 	exchangeRateService *exchangeRateService.ExchangeRateService,
 ) *TransferService {
 	return &TransferService{
-		transferRespository: respository,
-		exchangeRateService: exchangeRateService,
+		transferRespository:  respository,
+		exchangeRateService:  exchangeRateService,
+		reversalRate:         config.Get().Transfers.ReversalRate,
+		holdExpiry:           config.Get().TransferHolds.Expiry,
+		batchMaxItems:        config.Get().Transfers.BatchMaxItems,
+		requireVerifiedEmail: config.Get().Transfers.RequireVerifiedEmail,
+		stepUpThresholds:     config.Get().Transfers.StepUpThresholds,
+		mfaKey:               config.Get().MFA.EncryptionKey,
+		mfaMaxFailedAttempts: int32(config.Get().MFA.MaxFailedAttempts),
+		mfaLockoutDuration:   config.Get().MFA.LockoutDuration,
 	}
 }
@@ -0,0 +1,37 @@
+package transfers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+)
+
+// transferCursor marks the last transfer of a page. Pages are ordered by (created_at, id) descending
+type transferCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        int64     `json:"id"`
+}
+
+// encodeTransferCursor returns an opaque cursor for the transfer following a page
+func encodeTransferCursor(createdAt time.Time, id int64) string {
+	body, _ := json.Marshal(transferCursor{CreatedAt: createdAt, ID: id})
+	return base64.RawURLEncoding.EncodeToString(body)
+}
+
+// decodeTransferCursor parses a cursor previously returned as next_cursor
+func decodeTransferCursor(cursor string) (transferCursor, error) {
+	body, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return transferCursor{}, transferErrors.ErrInvalidCursor
+	}
+
+	var decoded transferCursor
+	err = json.Unmarshal(body, &decoded)
+	if err != nil || decoded.ID < 1 || decoded.CreatedAt.IsZero() {
+		return transferCursor{}, transferErrors.ErrInvalidCursor
+	}
+
+	return decoded, nil
+}
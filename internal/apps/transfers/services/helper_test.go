@@ -0,0 +1,211 @@
+package transfers
+
+import (
+	"time"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateServices "lemfi/simplebank/internal/apps/exchangeRates/services"
+	exchangeRateTestHelpers "lemfi/simplebank/internal/apps/exchangeRates/testHelpers"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+	testhelpers "lemfi/simplebank/internal/apps/transfers/testHelpers"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// newMockTransferService is a transfer service backed by the mock store
+func newMockTransferService(store *mockdb.MockStore) *TransferService {
+	exchangeRateService := exchangeRateServices.NewExchangeRateService(exchangeRateTestHelpers.NewMockExchangeRateRepository(store))
+	return NewTransferService(testhelpers.NewMockTransferRepository(store), exchangeRateService)
+}
+
+// newTransferRequest is a USD transfer of 100 by test_owner from account 1 to account 2
+func newTransferRequest() requests.MakeTransferRequest {
+	return requests.MakeTransferRequest{
+		FromAccountID:  1,
+		ToAccountID:    2,
+		Amount:         decimal.NewFromInt(100),
+		FromCurrency:   "USD",
+		ToCurrency:     "USD",
+		Username:       "test_owner",
+		IdempotencyKey: "key-123",
+	}
+}
+
+// newTransferTxResult is the result of the transfer of newTransferRequest
+func newTransferTxResult() db.TransferTxResult {
+	return db.TransferTxResult{
+		Transfer: db.Transfer{
+			ID:              10,
+			FromAccountID:   1,
+			ToAccountID:     2,
+			Amount:          decimal.NewFromInt(100),
+			ConvertedAmount: decimal.NewFromInt(100),
+			ExchangeRate:    decimal.NewFromInt(1),
+			FromCurrency:    pgtype.Text{String: "USD", Valid: true},
+			ToCurrency:      pgtype.Text{String: "USD", Valid: true},
+			CreatedAt:       time.Now(),
+		},
+		FromAccount: db.Account{ID: 1, Owner: "test_owner", Balance: decimal.NewFromInt(900), Currency: "USD"},
+		ToAccount:   db.Account{ID: 2, Owner: "other_owner", Balance: decimal.NewFromInt(1100), Currency: "USD"},
+	}
+}
+
+// newTransferRows are count USD to EUR transfers from account 1 to account 2, newest first
+func newTransferRows(count int) []db.ListTransfersRow {
+	now := time.Now().UTC()
+	rows := make([]db.ListTransfersRow, 0, count)
+	for i := 0; i < count; i++ {
+		rows = append(rows, db.ListTransfersRow{
+			ID:              int64(count - i),
+			FromAccountID:   1,
+			ToAccountID:     2,
+			Amount:          decimal.NewFromInt(100),
+			ConvertedAmount: decimal.NewFromInt(85),
+			ExchangeRate:    decimal.RequireFromString("0.85"),
+			FromCurrency:    pgtype.Text{String: "USD", Valid: true},
+			ToCurrency:      pgtype.Text{String: "EUR", Valid: true},
+			Fee:             decimal.NewFromInt(1),
+			CreatedAt:       now.Add(-time.Duration(i) * time.Minute),
+			FromOwner:       "test_owner",
+			ToOwner:         "other_owner",
+		})
+	}
+	return rows
+}
+
+// newScheduledTransfer is an active one-off transfer of newTransferRequest that was due a minute ago
+func newScheduledTransfer() db.ScheduledTransfer {
+	request := newTransferRequest()
+	occurrence := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	return db.ScheduledTransfer{
+		ID:            7,
+		Owner:         request.Username,
+		FromAccountID: request.FromAccountID,
+		ToAccountID:   request.ToAccountID,
+		Amount:        request.Amount,
+		FromCurrency:  request.FromCurrency,
+		ToCurrency:    request.ToCurrency,
+		Frequency:     scheduleFrequencyOnce,
+		OccurrenceAt:  occurrence,
+		NextRunAt:     occurrence,
+		Status:        scheduledTransferStatusActive,
+	}
+}
+
+// newFxQuote is an unused quote for a USD to EUR transfer of 100 by test_owner
+func newFxQuote() db.FxQuote {
+	return db.FxQuote{
+		ID:              5,
+		Username:        "test_owner",
+		FromCurrency:    "USD",
+		ToCurrency:      "EUR",
+		Rate:            decimal.RequireFromString("0.85"),
+		Amount:          decimal.NewFromInt(100),
+		ConvertedAmount: decimal.NewFromInt(85),
+		Fee:             decimal.NewFromInt(2),
+		ExpiresAt:       time.Now().Add(time.Minute),
+		MidMarketRate:   decimal.NewNullDecimal(decimal.RequireFromString("0.8543")),
+		MarkupBps:       pgtype.Int4{Int32: 50, Valid: true},
+	}
+}
+
+// newQuotedTransferRequest is newTransferRequest at the rate of newFxQuote
+func newQuotedTransferRequest() requests.MakeTransferRequest {
+	quote := newFxQuote()
+	request := newTransferRequest()
+	request.ToCurrency = quote.ToCurrency
+	request.QuoteID = quote.ID
+	request.IdempotencyKey = ""
+	return request
+}
+
+// newTierTransferLimit is the standard tier limit of currency
+func newTierTransferLimit(currency string) db.TransferLimit {
+	return db.TransferLimit{
+		Tier:                 "standard",
+		Currency:             currency,
+		PerTransactionAmount: decimal.NewNullDecimal(decimal.NewFromInt(5000)),
+		DailyAmount:          decimal.NewNullDecimal(decimal.NewFromInt(10000)),
+		DailyCount:           pgtype.Int4{Int32: 20, Valid: true},
+	}
+}
+
+// newTransferRow is the completed transfer of newTransferTxResult as it is read back
+func newTransferRow() db.GetTransferRow {
+	transfer := newTransferTxResult().Transfer
+	return db.GetTransferRow{
+		ID:              transfer.ID,
+		FromAccountID:   transfer.FromAccountID,
+		ToAccountID:     transfer.ToAccountID,
+		Amount:          transfer.Amount,
+		ConvertedAmount: transfer.ConvertedAmount,
+		FromCurrency:    transfer.FromCurrency,
+		ToCurrency:      transfer.ToCurrency,
+		Status:          db.TransferStatusCompleted,
+		CreatedAt:       transfer.CreatedAt,
+	}
+}
+
+// newTransferHold is an authorized hold of newTransferRequest that expires in an hour
+func newTransferHold() db.TransferHold {
+	request := newTransferRequest()
+	return db.TransferHold{
+		ID:              5,
+		Owner:           request.Username,
+		FromAccountID:   request.FromAccountID,
+		ToAccountID:     request.ToAccountID,
+		Amount:          request.Amount,
+		ConvertedAmount: request.Amount,
+		ExchangeRate:    decimal.NewFromInt(1),
+		FromCurrency:    request.FromCurrency,
+		ToCurrency:      request.ToCurrency,
+		Fee:             decimal.Zero,
+		Status:          db.TransferHoldStatusAuthorized,
+		ExpiresAt:       time.Now().Add(time.Hour),
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+}
+
+// newAuthorizeTransferHoldRequest holds the amount of newTransferRequest
+func newAuthorizeTransferHoldRequest() requests.AuthorizeTransferHoldRequest {
+	request := newTransferRequest()
+	return requests.AuthorizeTransferHoldRequest{
+		FromAccountID: request.FromAccountID,
+		ToAccountID:   request.ToAccountID,
+		Amount:        request.Amount,
+		FromCurrency:  request.FromCurrency,
+		ToCurrency:    request.ToCurrency,
+		Username:      request.Username,
+	}
+}
+
+// newTransferBatchRequest is a batch of newTransferRequest to each of toAccountIDs
+func newTransferBatchRequest(mode string, toAccountIDs ...int64) requests.CreateTransferBatchRequest {
+	item := newTransferRequest()
+	request := requests.CreateTransferBatchRequest{
+		Mode:     mode,
+		Username: item.Username,
+	}
+
+	item.Username = ""
+	item.IdempotencyKey = ""
+	for _, toAccountID := range toAccountIDs {
+		item.ToAccountID = toAccountID
+		request.Items = append(request.Items, item)
+	}
+	return request
+}
+
+// newExpiredTransferHolds are n holds of newTransferHold that have just expired
+func newExpiredTransferHolds(n int) []db.TransferHold {
+	holds := make([]db.TransferHold, n)
+	for i := range holds {
+		holds[i] = newTransferHold()
+		holds[i].ID = int64(i + 1)
+		holds[i].Status = db.TransferHoldStatusExpired
+	}
+	return holds
+}
@@ -0,0 +1,62 @@
+package transfers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+	responses "lemfi/simplebank/internal/apps/transfers/responses"
+)
+
+// hashTransferRequest fingerprints the JSON fields of a transfer request.
+// Internal fields are tagged json:"-" so only what the client sent is hashed
+func hashTransferRequest(payload requests.MakeTransferRequest) (string, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// replayIdempotentTransfer looks up a previously used idempotency key.
+// It returns the original response when the request matches, and an error when the key was used with a different body
+func (transferService *TransferService) replayIdempotentTransfer(payload requests.MakeTransferRequest) (responses.MakeTransferResponse, bool, error) {
+	storedKey, found, err := transferService.transferRespository.GetIdempotencyKey(payload.Username, payload.IdempotencyKey)
+	if err != nil || !found {
+		return responses.MakeTransferResponse{}, false, err
+	}
+
+	if storedKey.RequestHash != payload.RequestHash {
+		config.Logger.Error("Idempotency key reused with a different request body",
+			"username", payload.Username,
+			"idempotency_key", payload.IdempotencyKey,
+			"transfer_id", storedKey.TransferID,
+		)
+		return responses.MakeTransferResponse{}, false, transferErrors.ErrIdempotencyKeyMismatch
+	}
+
+	var result db.TransferTxResult
+	err = json.Unmarshal(storedKey.ResponseBody, &result)
+	if err != nil {
+		config.Logger.Error("Failed to decode stored idempotent response", "error", err.Error(), "transfer_id", storedKey.TransferID)
+		return responses.MakeTransferResponse{}, false, err
+	}
+
+	response := responses.NewMakeTransferResponse(result)
+	response.StatusCode = int(storedKey.ResponseStatus)
+	response.Replayed = true
+
+	config.Logger.Info("Replaying transfer for idempotency key",
+		"username", payload.Username,
+		"idempotency_key", payload.IdempotencyKey,
+		"transfer_id", storedKey.TransferID,
+	)
+
+	return response, true, nil
+}
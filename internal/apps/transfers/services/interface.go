@@ -7,4 +7,23 @@ import (
 
 type TransferServiceInterface interface {
 	MakeTransfer(payload requests.MakeTransferRequest) (responses.MakeTransferResponse, error)
+	ListTransfers(payload requests.ListTransfersRequest) (responses.ListTransfersResponse, error)
+	ListAccountTransfers(payload requests.ListTransfersRequest) (responses.ListTransfersResponse, error)
+	CreateScheduledTransfer(payload requests.CreateScheduledTransferRequest) (responses.ScheduledTransferResponse, error)
+	ListScheduledTransfers(username string) ([]responses.ScheduledTransferResponse, error)
+	GetScheduledTransfer(id int64, username string) (responses.ScheduledTransferResponse, error)
+	UpdateScheduledTransfer(payload requests.UpdateScheduledTransferRequest) (responses.ScheduledTransferResponse, error)
+	CancelScheduledTransfer(id int64, username string) (responses.ScheduledTransferResponse, error)
+	GetUserTransferLimits(username string) (responses.UserTransferLimitsResponse, error)
+	SetUserTransferLimit(payload requests.SetTransferLimitRequest) (responses.TransferLimitResponse, error)
+	DeleteUserTransferLimit(username string, currency string) (responses.TransferLimitResponse, error)
+	ReverseTransfer(payload requests.ReverseTransferRequest) (responses.ReverseTransferResponse, error)
+	AuthorizeTransferHold(payload requests.AuthorizeTransferHoldRequest) (responses.AuthorizeTransferHoldResponse, error)
+	ListTransferHolds(username string) ([]responses.TransferHoldResponse, error)
+	GetTransferHold(id int64, username string) (responses.TransferHoldResponse, error)
+	CaptureTransferHold(id int64, username string) (responses.CaptureTransferHoldResponse, error)
+	VoidTransferHold(id int64, username string) (responses.TransferHoldResponse, error)
+	CreateTransferBatch(payload requests.CreateTransferBatchRequest) (responses.CreateTransferBatchResponse, error)
+	ListTransferBatches(username string) ([]responses.TransferBatchResponse, error)
+	GetTransferBatch(id int64, username string) (responses.TransferBatchResponse, error)
 }
@@ -0,0 +1,187 @@
+package transfers
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+	responses "lemfi/simplebank/internal/apps/transfers/responses"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+const defaultTransferPageSize = 20
+
+const (
+	transferDirectionAll      = "all"
+	transferDirectionSent     = "sent"
+	transferDirectionReceived = "received"
+	// transferDirectionInternal labels transfers between two accounts of the same user when both sides are listed
+	transferDirectionInternal = "internal"
+)
+
+// ListTransfers returns the transfer history across all accounts owned by the user
+func (transferService *TransferService) ListTransfers(payload requests.ListTransfersRequest) (responses.ListTransfersResponse, error) {
+	config.Logger.Info("Listing transfers for user", "username", payload.Username, "direction", payload.Direction)
+
+	params, err := newListTransfersParams(payload)
+	if err != nil {
+		return responses.ListTransfersResponse{}, err
+	}
+	params.Owner = pgtype.Text{String: payload.Username, Valid: true}
+
+	rows, err := transferService.transferRespository.ListTransfers(params)
+	if err != nil {
+		config.Logger.Error("Failed to list transfers", "error", err.Error(), "username", payload.Username)
+		return responses.ListTransfersResponse{}, err
+	}
+
+	return newListTransfersResponse(rows, params.PageLimit-1, func(row db.ListTransfersRow) string {
+		return transferDirection(row.FromOwner == payload.Username, row.ToOwner == payload.Username, payload.Direction)
+	}), nil
+}
+
+// ListAccountTransfers returns the transfer history of a single account owned by the user.
+// Other users' accounts are reported as not found so that their ids are not revealed
+func (transferService *TransferService) ListAccountTransfers(payload requests.ListTransfersRequest) (responses.ListTransfersResponse, error) {
+	config.Logger.Info("Listing transfers for account", "account_id", payload.AccountID, "username", payload.Username, "direction", payload.Direction)
+
+	_, err := transferService.authorizeAccountOwner(payload.AccountID, payload.Username)
+	if errors.Is(err, transferErrors.ErrAccountForbidden) {
+		return responses.ListTransfersResponse{}, transferErrors.ErrAccountNotFound
+	}
+	if err != nil {
+		return responses.ListTransfersResponse{}, err
+	}
+
+	params, err := newListTransfersParams(payload)
+	if err != nil {
+		return responses.ListTransfersResponse{}, err
+	}
+	params.AccountID = pgtype.Int8{Int64: payload.AccountID, Valid: true}
+
+	rows, err := transferService.transferRespository.ListTransfers(params)
+	if err != nil {
+		config.Logger.Error("Failed to list transfers", "error", err.Error(), "account_id", payload.AccountID)
+		return responses.ListTransfersResponse{}, err
+	}
+
+	return newListTransfersResponse(rows, params.PageLimit-1, func(row db.ListTransfersRow) string {
+		return transferDirection(row.FromAccountID == payload.AccountID, row.ToAccountID == payload.AccountID, payload.Direction)
+	}), nil
+}
+
+// transferDirection labels a transfer relative to the account or user listed. A transfer on both sides
+// matches either filter, so it takes the label of the filter it was listed under
+func transferDirection(isSender bool, isRecipient bool, requested string) string {
+	switch {
+	case isSender && isRecipient && requested == transferDirectionReceived:
+		return transferDirectionReceived
+	case isSender && isRecipient && requested != transferDirectionSent:
+		return transferDirectionInternal
+	case isSender:
+		return transferDirectionSent
+	default:
+		return transferDirectionReceived
+	}
+}
+
+// newListTransfersParams converts the query filters into query parameters.
+// One extra row is requested so we know whether another page exists
+func newListTransfersParams(payload requests.ListTransfersRequest) (db.ListTransfersParams, error) {
+	limit := payload.Limit
+	if limit == 0 {
+		limit = defaultTransferPageSize
+	}
+
+	direction := payload.Direction
+	if direction == "" {
+		direction = transferDirectionAll
+	}
+
+	params := db.ListTransfersParams{
+		IncludeSent:     direction != transferDirectionReceived,
+		IncludeReceived: direction != transferDirectionSent,
+		PageLimit:       limit + 1,
+	}
+
+	if payload.FromCurrency != "" {
+		params.FromCurrency = pgtype.Text{String: payload.FromCurrency, Valid: true}
+	}
+	if payload.ToCurrency != "" {
+		params.ToCurrency = pgtype.Text{String: payload.ToCurrency, Valid: true}
+	}
+
+	if !payload.From.IsZero() && !payload.To.IsZero() && !payload.From.Before(payload.To) {
+		config.Logger.Error("Invalid date range", "from", payload.From, "to", payload.To)
+		return db.ListTransfersParams{}, transferErrors.ErrInvalidDateRange
+	}
+	if !payload.From.IsZero() {
+		params.StartDate = pgtype.Timestamptz{Time: payload.From, Valid: true}
+	}
+	if !payload.To.IsZero() {
+		params.EndDate = pgtype.Timestamptz{Time: payload.To, Valid: true}
+	}
+
+	var minAmount, maxAmount decimal.Decimal
+	var err error
+	if payload.MinAmount != "" {
+		minAmount, err = decimal.NewFromString(payload.MinAmount)
+		if err != nil {
+			return db.ListTransfersParams{}, transferErrors.ErrInvalidAmountFilter
+		}
+		params.MinAmount = toNumeric(minAmount)
+	}
+	if payload.MaxAmount != "" {
+		maxAmount, err = decimal.NewFromString(payload.MaxAmount)
+		if err != nil {
+			return db.ListTransfersParams{}, transferErrors.ErrInvalidAmountFilter
+		}
+		params.MaxAmount = toNumeric(maxAmount)
+	}
+	if params.MinAmount.Valid && params.MaxAmount.Valid && minAmount.GreaterThan(maxAmount) {
+		config.Logger.Error("Invalid amount range", "min_amount", minAmount, "max_amount", maxAmount)
+		return db.ListTransfersParams{}, transferErrors.ErrInvalidAmountRange
+	}
+
+	if payload.Cursor != "" {
+		cursor, err := decodeTransferCursor(payload.Cursor)
+		if err != nil {
+			config.Logger.Error("Invalid transfer cursor", "cursor", payload.Cursor)
+			return db.ListTransfersParams{}, err
+		}
+		params.CursorCreatedAt = pgtype.Timestamptz{Time: cursor.CreatedAt, Valid: true}
+		params.CursorID = pgtype.Int8{Int64: cursor.ID, Valid: true}
+	}
+
+	return params, nil
+}
+
+// newListTransfersResponse trims the look-ahead row and sets the cursor for the next page
+func newListTransfersResponse(rows []db.ListTransfersRow, limit int32, direction func(db.ListTransfersRow) string) responses.ListTransfersResponse {
+	response := responses.ListTransfersResponse{
+		Transfers: []responses.TransferHistoryItem{},
+	}
+
+	if int32(len(rows)) > limit {
+		rows = rows[:limit]
+		last := rows[len(rows)-1]
+		response.NextCursor = encodeTransferCursor(last.CreatedAt, last.ID)
+	}
+
+	for _, row := range rows {
+		response.Transfers = append(response.Transfers, responses.NewTransferHistoryItem(row, direction(row)))
+	}
+
+	config.Logger.Info("Transfers listed successfully", "count", len(response.Transfers), "has_more", response.NextCursor != "")
+
+	return response
+}
+
+// toNumeric converts a decimal into a nullable numeric query parameter
+func toNumeric(amount decimal.Decimal) pgtype.Numeric {
+	return pgtype.Numeric{Int: amount.Coefficient(), Exp: amount.Exponent(), Valid: true}
+}
@@ -0,0 +1,236 @@
+package transfers
+
+import (
+	"testing"
+	"time"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListTransfersService_NextCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	rows := newTransferRows(3)
+
+	store.EXPECT().ListTransfers(gomock.Any(), db.ListTransfersParams{
+		IncludeSent:     true,
+		IncludeReceived: true,
+		Owner:           pgtype.Text{String: "test_owner", Valid: true},
+		PageLimit:       3,
+	}).Return(rows, nil)
+
+	service := newMockTransferService(store)
+	response, err := service.ListTransfers(requests.ListTransfersRequest{Username: "test_owner", Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, response.Transfers, 2)
+	require.NotEmpty(t, response.NextCursor)
+	require.Equal(t, "sent", response.Transfers[0].Direction)
+	require.True(t, response.Transfers[0].Fee.Equal(decimal.NewFromInt(1)))
+	require.True(t, response.Transfers[0].ExchangeRate.Equal(decimal.RequireFromString("0.85")))
+	require.True(t, response.Transfers[0].ConvertedAmount.Equal(decimal.NewFromInt(85)))
+
+	// The cursor points at the last transfer returned
+	cursor, err := decodeTransferCursor(response.NextCursor)
+	require.NoError(t, err)
+	require.Equal(t, rows[1].ID, cursor.ID)
+	require.True(t, rows[1].CreatedAt.Equal(cursor.CreatedAt))
+}
+
+func TestListTransfersService_Filters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	cursor := encodeTransferCursor(to.Add(-time.Hour), 42)
+
+	store.EXPECT().ListTransfers(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, params db.ListTransfersParams) ([]db.ListTransfersRow, error) {
+			require.False(t, params.IncludeSent)
+			require.True(t, params.IncludeReceived)
+			require.Equal(t, pgtype.Text{String: "USD", Valid: true}, params.FromCurrency)
+			require.Equal(t, pgtype.Text{String: "EUR", Valid: true}, params.ToCurrency)
+			require.True(t, params.StartDate.Time.Equal(from))
+			require.True(t, params.EndDate.Time.Equal(to))
+			require.Equal(t, int64(10), params.MinAmount.Int.Int64())
+			require.Equal(t, int64(5000), params.MaxAmount.Int.Int64())
+			require.Equal(t, int32(-1), params.MaxAmount.Exp)
+			require.Equal(t, int64(42), params.CursorID.Int64)
+			require.Equal(t, int32(defaultTransferPageSize+1), params.PageLimit)
+			return []db.ListTransfersRow{}, nil
+		})
+
+	service := newMockTransferService(store)
+	response, err := service.ListTransfers(requests.ListTransfersRequest{
+		Username:     "test_owner",
+		Direction:    "received",
+		FromCurrency: "USD",
+		ToCurrency:   "EUR",
+		From:         from,
+		To:           to,
+		MinAmount:    "10",
+		MaxAmount:    "500.0",
+		Cursor:       cursor,
+	})
+	require.NoError(t, err)
+	require.Empty(t, response.Transfers)
+	require.Empty(t, response.NextCursor)
+}
+
+func TestListTransfersService_OwnAccountsDirection(t *testing.T) {
+	testCases := []struct {
+		direction string
+		expected  string
+	}{
+		{direction: "", expected: "internal"},
+		{direction: "all", expected: "internal"},
+		{direction: "sent", expected: "sent"},
+		{direction: "received", expected: "received"},
+	}
+
+	for _, tc := range testCases {
+		t.Run("direction="+tc.direction, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// Both sides of the transfer belong to the caller
+			rows := newTransferRows(1)
+			rows[0].ToOwner = "test_owner"
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().ListTransfers(gomock.Any(), gomock.Any()).Return(rows, nil)
+
+			response, err := newMockTransferService(store).ListTransfers(requests.ListTransfersRequest{
+				Username:  "test_owner",
+				Direction: tc.direction,
+			})
+			require.NoError(t, err)
+			require.Len(t, response.Transfers, 1)
+			require.Equal(t, tc.expected, response.Transfers[0].Direction)
+		})
+	}
+}
+
+func TestListTransfersService_InvalidFilters(t *testing.T) {
+	testCases := []struct {
+		name    string
+		request requests.ListTransfersRequest
+		err     error
+	}{
+		{
+			name:    "InvalidCursor",
+			request: requests.ListTransfersRequest{Cursor: "not-a-cursor"},
+			err:     transferErrors.ErrInvalidCursor,
+		},
+		{
+			name: "InvalidDateRange",
+			request: requests.ListTransfersRequest{
+				From: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			err: transferErrors.ErrInvalidDateRange,
+		},
+		{
+			name:    "InvalidAmountRange",
+			request: requests.ListTransfersRequest{MinAmount: "100", MaxAmount: "10"},
+			err:     transferErrors.ErrInvalidAmountRange,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().ListTransfers(gomock.Any(), gomock.Any()).Times(0)
+
+			tc.request.Username = "test_owner"
+			_, err := newMockTransferService(store).ListTransfers(tc.request)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestListAccountTransfersService(t *testing.T) {
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, count int, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(2)).
+					Return(db.Account{ID: 2, Owner: "test_owner", Currency: "EUR"}, nil)
+				store.EXPECT().ListTransfers(gomock.Any(), db.ListTransfersParams{
+					IncludeSent:     true,
+					IncludeReceived: true,
+					AccountID:       pgtype.Int8{Int64: 2, Valid: true},
+					PageLimit:       defaultTransferPageSize + 1,
+				}).Return(newTransferRows(2), nil)
+			},
+			checkResponse: func(t *testing.T, count int, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 2, count)
+			},
+		},
+		{
+			name: "AccountNotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(2)).Return(db.Account{}, pgx.ErrNoRows)
+				store.EXPECT().ListTransfers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, count int, err error) {
+				require.ErrorIs(t, err, transferErrors.ErrAccountNotFound)
+			},
+		},
+		{
+			name: "AccountOwnedByAnotherUser",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(2)).
+					Return(db.Account{ID: 2, Owner: "other_owner", Currency: "EUR"}, nil)
+				store.EXPECT().ListTransfers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, count int, err error) {
+				// Reported like a missing account so that other users' account ids are not revealed
+				require.ErrorIs(t, err, transferErrors.ErrAccountNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			response, err := newMockTransferService(store).ListAccountTransfers(requests.ListTransfersRequest{
+				AccountID: 2,
+				Username:  "test_owner",
+			})
+			if err == nil {
+				// Account 2 is the receiving side of every test row
+				for _, transfer := range response.Transfers {
+					require.Equal(t, "received", transfer.Direction)
+				}
+			}
+			tc.checkResponse(t, len(response.Transfers), err)
+		})
+	}
+}
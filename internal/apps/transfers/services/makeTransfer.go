@@ -2,7 +2,9 @@ package transfers
 
 import (
 	"context"
+	"errors"
 	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
 	"lemfi/simplebank/internal/apps/core"
 	"lemfi/simplebank/internal/apps/currencies"
 	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
@@ -10,6 +12,7 @@ import (
 	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
 	requests "lemfi/simplebank/internal/apps/transfers/requests"
 	responses "lemfi/simplebank/internal/apps/transfers/responses"
+	"strings"
 
 	"github.com/shopspring/decimal"
 )
@@ -23,21 +26,119 @@ func (transferService *TransferService) MakeTransfer(payload requests.MakeTransf
 		"to_currency", payload.ToCurrency,
 	)
 
-	if !currencies.IsSupportedCurrency(currencies.Currency(payload.FromCurrency)) {
-		config.Logger.Error("From currency is not supported", "currency", payload.FromCurrency)
-		return responses.MakeTransferResponse{}, currencies.ErrCurrencyNotSupported
+	// Keys with the scheduled transfer prefix belong to the worker, a client holding one could
+	// claim a future occurrence and have it replay the client's transfer instead
+	if !payload.ScheduledRun && strings.HasPrefix(payload.IdempotencyKey, scheduledTransferIdempotencyKeyPrefix) {
+		config.Logger.Error("Idempotency key is reserved for scheduled transfers", "idempotency_key", payload.IdempotencyKey)
+		return responses.MakeTransferResponse{}, transferErrors.ErrIdempotencyKeyReserved
 	}
 
-	// Business validation: Same account transfer prevention
-	if payload.FromAccountID == payload.ToAccountID {
-		config.Logger.Error("Cannot transfer to same account", "account_id", payload.FromAccountID)
-		return responses.MakeTransferResponse{}, transferErrors.ErrSameAccountTransfer
+	// Retried requests with a known idempotency key return the original result
+	if payload.IdempotencyKey != "" {
+		requestHash, err := hashTransferRequest(payload)
+		if err != nil {
+			config.Logger.Error("Failed to hash transfer request", "error", err.Error())
+			return responses.MakeTransferResponse{}, err
+		}
+		payload.RequestHash = requestHash
+
+		response, replayed, err := transferService.replayIdempotentTransfer(payload)
+		if err != nil || replayed {
+			return response, err
+		}
 	}
 
-	// Business validation: Amount must be positive
-	if payload.Amount.LessThanOrEqual(decimal.Zero) {
-		config.Logger.Error("Invalid transfer amount", "amount", payload.Amount)
-		return responses.MakeTransferResponse{}, transferErrors.ErrInvalidAmount
+	_, err := transferService.validateTransfer(payload)
+	if err != nil {
+		return responses.MakeTransferResponse{}, err
+	}
+
+	// Checked after the idempotency replay so that a retry does not need a fresh code
+	if !payload.ScheduledRun {
+		err = transferService.authorizeStepUp(payload.Username, payload.Amount, payload.FromCurrency, payload.TOTPCode)
+		if err != nil {
+			return responses.MakeTransferResponse{}, err
+		}
+	}
+
+	pricing, err := transferService.priceTransfer(payload)
+	if err != nil {
+		return responses.MakeTransferResponse{}, err
+	}
+
+	// Execute transfer through repository (includes data validation: account existence, balance check, currency matching)
+	result, err := transferService.transferRespository.MakeTransfer(payload, pricing.convertedAmount, pricing.exchangeRate, pricing.fee, pricing.feeScheduleID, pricing.pivotRate, pricing.midMarketRate, pricing.markupBps)
+	if errors.Is(err, db.ErrIdempotencyKeyConflict) {
+		// A concurrent request with the same key committed first
+		config.Logger.Info("Idempotency key committed by a concurrent request", "idempotency_key", payload.IdempotencyKey)
+		response, replayed, replayErr := transferService.replayIdempotentTransfer(payload)
+		if replayErr != nil || replayed {
+			return response, replayErr
+		}
+	}
+	if limitErr := transferLimitError(err); limitErr != nil {
+		config.Logger.Error("Transfer rejected by a transfer limit",
+			"error", err.Error(),
+			"username", payload.Username,
+			"from_account_id", payload.FromAccountID,
+			"amount", payload.Amount,
+		)
+		return responses.MakeTransferResponse{}, limitErr
+	}
+	if err != nil {
+		config.Logger.Error("Transfer failed",
+			"error", err.Error(),
+			"from_account_id", payload.FromAccountID,
+			"to_account_id", payload.ToAccountID,
+		)
+		return responses.MakeTransferResponse{}, err
+	}
+
+	// Convert database result to response using helper function
+	response := responses.NewMakeTransferResponse(result)
+
+	config.Logger.Info("Transfer completed successfully",
+		"transfer_id", result.Transfer.ID,
+		"from_account_id", result.Transfer.FromAccountID,
+		"to_account_id", result.Transfer.ToAccountID,
+		"amount", result.Transfer.Amount,
+		"from_balance", result.FromAccount.Balance,
+		"to_balance", result.ToAccount.Balance,
+	)
+
+	return response, nil
+}
+
+// transferPricing is what a transfer converts to and costs, calculated in the service layer
+type transferPricing struct {
+	convertedAmount decimal.Decimal
+	exchangeRate    decimal.Decimal // Customer rate for cross-currency transfers
+	fee             decimal.Decimal
+	feeScheduleID   int64         // Set when a fee schedule priced the fee
+	pivotRate       *db.PivotRate // Set when the rate is derived through a pivot currency
+	midMarketRate   decimal.Decimal
+	markupBps       int32
+}
+
+// validateTransfer runs the business validations of a transfer and returns the source account once the user is checked to own it
+func (transferService *TransferService) validateTransfer(payload requests.MakeTransferRequest) (db.Account, error) {
+	err := validateTransferPayload(payload)
+	if err != nil {
+		return db.Account{}, err
+	}
+
+	err = transferService.authorizeVerifiedEmail(payload.Username)
+	if err != nil {
+		return db.Account{}, err
+	}
+
+	// Authorization: only the owner can debit the source account
+	fromAccount, err := transferService.authorizeAccountOwner(payload.FromAccountID, payload.Username)
+	if errors.Is(err, transferErrors.ErrAccountNotFound) {
+		return db.Account{}, transferErrors.ErrFromAccountNotFound
+	}
+	if err != nil {
+		return db.Account{}, err
 	}
 
 	// Business validation: Currency consistency
@@ -50,16 +151,74 @@ func (transferService *TransferService) MakeTransfer(payload requests.MakeTransf
 		)
 	}
 
-	// Calculate exchange rate and converted amount in service layer
-	convertedAmount := payload.Amount
-	exchangeRate := decimal.NewFromInt(1) // Default to 1:1 for same currency
-	fee := decimal.Zero                   // Default fee for same currency transfers
+	return fromAccount, nil
+}
+
+// validateTransferPayload runs the business validations of a transfer that do not need its accounts
+func validateTransferPayload(payload requests.MakeTransferRequest) error {
+	if !currencies.IsSupportedCurrency(currencies.Currency(payload.FromCurrency)) {
+		config.Logger.Error("From currency is not supported", "currency", payload.FromCurrency)
+		return currencies.ErrCurrencyNotSupported
+	}
+
+	// Business validation: Same account transfer prevention
+	if payload.FromAccountID == payload.ToAccountID {
+		config.Logger.Error("Cannot transfer to same account", "account_id", payload.FromAccountID)
+		return transferErrors.ErrSameAccountTransfer
+	}
+
+	// Business validation: Amount must be positive
+	if payload.Amount.LessThanOrEqual(decimal.Zero) {
+		config.Logger.Error("Invalid transfer amount", "amount", payload.Amount)
+		return transferErrors.ErrInvalidAmount
+	}
+
+	// Business validation: Amount must fit the minor units of the currency
+	if !currencies.HasValidPrecision(payload.Amount, currencies.Currency(payload.FromCurrency)) {
+		config.Logger.Error("Transfer amount has too many decimal places", "amount", payload.Amount, "currency", payload.FromCurrency)
+		return transferErrors.ErrAmountPrecision
+	}
+
+	return nil
+}
+
+// priceTransfer calculates the exchange rate, converted amount and fee of a transfer,
+// from its quote when it has one
+func (transferService *TransferService) priceTransfer(payload requests.MakeTransferRequest) (transferPricing, error) {
+	pricing := transferPricing{
+		convertedAmount: payload.Amount,
+		exchangeRate:    decimal.NewFromInt(1), // Default to 1:1 for same currency
+		fee:             decimal.Zero,          // Priced by the fee schedule of the pair below
+	}
+
+	if payload.QuoteID != 0 {
+		// A quote locks the rate, converted amount and fee the user was shown
+		quote, err := transferService.quotedTransfer(payload)
+		if err != nil {
+			return transferPricing{}, err
+		}
+
+		pricing.exchangeRate = quote.Rate
+		pricing.convertedAmount = quote.ConvertedAmount
+		pricing.fee = quote.Fee
+		pricing.feeScheduleID = quote.FeeScheduleID.Int64
+		pricing.pivotRate = quotePivotRate(quote)
+		pricing.midMarketRate = quote.MidMarketRate.Decimal
+		pricing.markupBps = quote.MarkupBps.Int32
+	} else if payload.FromCurrency == payload.ToCurrency {
+		transferFee, err := transferService.exchangeRateService.CalculateFee(context.Background(), payload.FromCurrency, payload.ToCurrency, payload.Amount)
+		if err != nil {
+			config.Logger.Error("Failed to calculate fee", "currency", payload.FromCurrency, "error", err.Error())
+			return transferPricing{}, err
+		}
 
-	if payload.FromCurrency != payload.ToCurrency {
+		pricing.fee = transferFee.Fee
+		pricing.feeScheduleID = transferFee.FeeScheduleID
+	} else {
 
 		if payload.ExchangeRate.LessThanOrEqual(decimal.Zero) {
 			config.Logger.Error("Exchange rate is zero", "exchange_rate", payload.ExchangeRate)
-			return responses.MakeTransferResponse{}, exchangeRateErrors.ErrExchangeRateZero
+			return transferPricing{}, exchangeRateErrors.ErrExchangeRateZero
 		}
 
 		// Use exchange rate service to get exchange rate
@@ -78,49 +237,31 @@ func (transferService *TransferService) MakeTransfer(payload requests.MakeTransf
 			)
 			// Check if it's a client error from exchange rate service
 			if _, isClient := core.IsClientError(err); isClient {
-				return responses.MakeTransferResponse{}, err
+				return transferPricing{}, err
 			}
 			// If it's not a client error, return a generic server error
-			return responses.MakeTransferResponse{}, transferErrors.ErrExchangeRateNotFound
+			return transferPricing{}, transferErrors.ErrExchangeRateNotFound
 		}
 
 		if !exchangeRateResponse.CanTransact {
 			config.Logger.Error("Exchange rate expired", "exchange_rate", exchangeRateResponse.ExchangeRate)
-			return responses.MakeTransferResponse{}, exchangeRateErrors.ErrExchangeRateExpired
+			return transferPricing{}, exchangeRateErrors.ErrExchangeRateExpired
 		}
 
-		if !payload.ExchangeRate.IsZero() && !exchangeRateResponse.ExchangeRate.Rate.Equal(payload.ExchangeRate) {
-			config.Logger.Error("Exchange rate mismatch", "exchange_rate", exchangeRateResponse.ExchangeRate, "payload_exchange_rate", payload.ExchangeRate)
-			return responses.MakeTransferResponse{}, exchangeRateErrors.ErrExchangeRateMismatch
+		// Users are shown the customer rate, which includes the markup of the pair
+		if !payload.ExchangeRate.IsZero() && !exchangeRateResponse.CustomerRate.Equal(payload.ExchangeRate) {
+			config.Logger.Error("Exchange rate mismatch", "exchange_rate", exchangeRateResponse.CustomerRate, "payload_exchange_rate", payload.ExchangeRate)
+			return transferPricing{}, exchangeRateErrors.ErrExchangeRateMismatch
 		}
 
-		exchangeRate = exchangeRateResponse.ExchangeRate.Rate
-		convertedAmount = exchangeRateResponse.AmountToReceive
-		fee = exchangeRateResponse.Fee
+		pricing.exchangeRate = exchangeRateResponse.CustomerRate
+		pricing.midMarketRate = exchangeRateResponse.ExchangeRate.Rate
+		pricing.markupBps = exchangeRateResponse.MarkupBps
+		pricing.convertedAmount = exchangeRateResponse.AmountToReceive
+		pricing.fee = exchangeRateResponse.Fee
+		pricing.feeScheduleID = exchangeRateResponse.FeeScheduleID
+		pricing.pivotRate = exchangeRateResponse.ExchangeRate.PivotRate()
 	}
 
-	// Execute transfer through repository (includes data validation: account existence, balance check, currency matching)
-	result, err := transferService.transferRespository.MakeTransfer(payload, convertedAmount, exchangeRate, fee)
-	if err != nil {
-		config.Logger.Error("Transfer failed",
-			"error", err.Error(),
-			"from_account_id", payload.FromAccountID,
-			"to_account_id", payload.ToAccountID,
-		)
-		return responses.MakeTransferResponse{}, err
-	}
-
-	// Convert database result to response using helper function
-	response := responses.NewMakeTransferResponse(result)
-
-	config.Logger.Info("Transfer completed successfully",
-		"transfer_id", result.Transfer.ID,
-		"from_account_id", result.Transfer.FromAccountID,
-		"to_account_id", result.Transfer.ToAccountID,
-		"amount", result.Transfer.Amount,
-		"from_balance", result.FromAccount.Balance,
-		"to_balance", result.ToAccount.Balance,
-	)
-
-	return response, nil
+	return pricing, nil
 }
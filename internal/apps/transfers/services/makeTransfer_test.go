@@ -0,0 +1,618 @@
+package transfers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+	"lemfi/simplebank/pkg/totp"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// expectNoFeeSchedule stubs a pair without a fee schedule, so same-currency transfers are free
+func expectNoFeeSchedule(store *mockdb.MockStore) {
+	store.EXPECT().GetActiveFeeSchedule(gomock.Any(), gomock.Any()).Return(db.FeeSchedule{}, pgx.ErrNoRows).Times(1)
+}
+
+func TestMakeTransferService_IdempotentReplay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	request := newTransferRequest()
+	requestHash, err := hashTransferRequest(request)
+	require.NoError(t, err)
+
+	responseBody, err := json.Marshal(newTransferTxResult())
+	require.NoError(t, err)
+
+	store.EXPECT().GetIdempotencyKey(gomock.Any(), db.GetIdempotencyKeyParams{
+		Username:       "test_owner",
+		IdempotencyKey: "key-123",
+	}).Return(db.IdempotencyKey{
+		Username:       "test_owner",
+		IdempotencyKey: "key-123",
+		RequestHash:    requestHash,
+		TransferID:     10,
+		ResponseStatus: http.StatusCreated,
+		ResponseBody:   responseBody,
+	}, nil).Times(1)
+
+	// The transfer must not run a second time
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	response, err := newMockTransferService(store).MakeTransfer(request)
+
+	require.NoError(t, err)
+	require.True(t, response.Replayed)
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+	require.Equal(t, int64(10), response.Transfer.ID)
+	require.Equal(t, "USD", response.Transfer.FromCurrency)
+	require.True(t, decimal.NewFromInt(900).Equal(response.FromAccount.Balance))
+}
+
+func TestMakeTransferService_IdempotencyKeyMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Any()).Return(db.IdempotencyKey{
+		Username:       "test_owner",
+		IdempotencyKey: "key-123",
+		RequestHash:    "hash-of-a-different-body",
+		TransferID:     10,
+		ResponseStatus: http.StatusCreated,
+	}, nil).Times(1)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	response, err := newMockTransferService(store).MakeTransfer(newTransferRequest())
+
+	require.Error(t, err)
+	require.Equal(t, transferErrors.ErrIdempotencyKeyMismatch, err)
+	require.Empty(t, response)
+}
+
+func TestMakeTransferService_NewIdempotencyKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	request := newTransferRequest()
+	requestHash, err := hashTransferRequest(request)
+	require.NoError(t, err)
+
+	store.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Any()).Return(db.IdempotencyKey{}, pgx.ErrNoRows).Times(1)
+	store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(newTransferTxResult().FromAccount, nil).Times(1)
+	expectNoFeeSchedule(store)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, arg db.TransferTxParams) (db.TransferTxResult, error) {
+			require.Equal(t, "test_owner", arg.Username)
+			require.Equal(t, "key-123", arg.IdempotencyKey)
+			require.Equal(t, requestHash, arg.RequestHash)
+			return newTransferTxResult(), nil
+		}).Times(1)
+
+	response, err := newMockTransferService(store).MakeTransfer(request)
+
+	require.NoError(t, err)
+	require.False(t, response.Replayed)
+	require.Equal(t, int64(10), response.Transfer.ID)
+}
+
+func TestMakeTransferService_ConcurrentIdempotencyKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	request := newTransferRequest()
+	requestHash, err := hashTransferRequest(request)
+	require.NoError(t, err)
+
+	responseBody, err := json.Marshal(newTransferTxResult())
+	require.NoError(t, err)
+
+	store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(newTransferTxResult().FromAccount, nil).Times(1)
+	expectNoFeeSchedule(store)
+
+	// The key is unused on the first lookup, then committed by a concurrent request
+	gomock.InOrder(
+		store.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Any()).Return(db.IdempotencyKey{}, pgx.ErrNoRows),
+		store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Return(db.TransferTxResult{}, db.ErrIdempotencyKeyConflict),
+		store.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Any()).Return(db.IdempotencyKey{
+			Username:       "test_owner",
+			IdempotencyKey: "key-123",
+			RequestHash:    requestHash,
+			TransferID:     10,
+			ResponseStatus: http.StatusCreated,
+			ResponseBody:   responseBody,
+		}, nil),
+	)
+
+	response, err := newMockTransferService(store).MakeTransfer(request)
+
+	require.NoError(t, err)
+	require.True(t, response.Replayed)
+	require.Equal(t, int64(10), response.Transfer.ID)
+}
+
+func TestMakeTransferService_AccountOwnership(t *testing.T) {
+	testCases := []struct {
+		name       string
+		username   string
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name:     "OwnerCanDebit",
+			username: "test_owner",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).
+					Return(db.Account{ID: 1, Owner: "test_owner", Balance: decimal.NewFromInt(1000), Currency: "USD"}, nil).Times(1)
+				expectNoFeeSchedule(store)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Return(newTransferTxResult(), nil).Times(1)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:     "OtherUserCannotDebit",
+			username: "intruder",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).
+					Return(db.Account{ID: 1, Owner: "test_owner", Balance: decimal.NewFromInt(1000), Currency: "USD"}, nil).Times(1)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, transferErrors.ErrAccountForbidden)
+			},
+		},
+		{
+			name:     "AnonymousUserCannotDebit",
+			username: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).
+					Return(db.Account{ID: 1, Owner: "test_owner", Balance: decimal.NewFromInt(1000), Currency: "USD"}, nil).Times(1)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, transferErrors.ErrAccountForbidden)
+			},
+		},
+		{
+			name:     "FromAccountNotFound",
+			username: "test_owner",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(db.Account{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, transferErrors.ErrFromAccountNotFound)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			request := newTransferRequest()
+			request.Username = tc.username
+			request.IdempotencyKey = ""
+
+			_, err := newMockTransferService(store).MakeTransfer(request)
+			tc.checkError(t, err)
+		})
+	}
+}
+
+func TestMakeTransferService_RequireVerifiedEmail(t *testing.T) {
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "Verified",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), "test_owner").Return(db.GetUserRow{Username: "test_owner", IsEmailVerified: true}, nil).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).
+					Return(db.Account{ID: 1, Owner: "test_owner", Balance: decimal.NewFromInt(1000), Currency: "USD"}, nil).Times(1)
+				expectNoFeeSchedule(store)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Return(newTransferTxResult(), nil).Times(1)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "NotVerified",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), "test_owner").Return(db.GetUserRow{Username: "test_owner"}, nil).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, transferErrors.ErrEmailNotVerified)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			transferService := newMockTransferService(store)
+			transferService.requireVerifiedEmail = true
+
+			request := newTransferRequest()
+			request.IdempotencyKey = ""
+
+			_, err := transferService.MakeTransfer(request)
+			tc.checkError(t, err)
+		})
+	}
+}
+
+func TestMakeTransferService_FeeSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	request := newTransferRequest()
+	request.IdempotencyKey = ""
+
+	store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(newTransferTxResult().FromAccount, nil).Times(1)
+	store.EXPECT().
+		GetActiveFeeSchedule(gomock.Any(), gomock.Eq(db.GetActiveFeeScheduleParams{FromCurrency: "USD", ToCurrency: "USD"})).
+		Return(db.FeeSchedule{ID: 4, Version: 2, FixedFee: decimal.RequireFromString("0.25"), Percentage: decimal.RequireFromString("0.5")}, nil).
+		Times(1)
+	store.EXPECT().ListFeeScheduleTiers(gomock.Any(), gomock.Eq([]int64{4})).Return([]db.FeeScheduleTier{}, nil).Times(1)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, arg db.TransferTxParams) (db.TransferTxResult, error) {
+			// 0.25 + 0.5% of 100
+			require.True(t, decimal.RequireFromString("0.75").Equal(arg.Fee))
+			require.Equal(t, int64(4), arg.FeeScheduleID)
+
+			result := newTransferTxResult()
+			result.Transfer.Fee = arg.Fee
+			result.Transfer.FeeScheduleID = pgtype.Int8{Int64: arg.FeeScheduleID, Valid: true}
+			return result, nil
+		}).Times(1)
+
+	response, err := newMockTransferService(store).MakeTransfer(request)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(4), response.Transfer.FeeScheduleID)
+}
+
+func TestMakeTransferService_FxMarkup(t *testing.T) {
+	fromAccount := db.Account{ID: 1, Owner: "test_owner", Balance: decimal.NewFromInt(1000), Currency: "USD"}
+
+	testCases := []struct {
+		name         string
+		exchangeRate string
+		buildStubs   func(store *mockdb.MockStore)
+		expectedErr  error
+	}{
+		{
+			name: "CustomerRateIsCharged",
+			// 0.85 less 50 basis points
+			exchangeRate: "0.84575",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ any, arg db.TransferTxParams) (db.TransferTxResult, error) {
+						require.True(t, decimal.RequireFromString("0.84575").Equal(arg.ExchangeRate))
+						require.True(t, decimal.RequireFromString("84.58").Equal(arg.ConvertedAmount))
+						require.True(t, decimal.RequireFromString("0.85").Equal(arg.MidMarketRate))
+						require.Equal(t, int32(50), arg.MarkupBps)
+
+						result := newTransferTxResult()
+						result.Transfer.MidMarketRate = decimal.NewNullDecimal(arg.MidMarketRate)
+						result.Transfer.MarkupBps = pgtype.Int4{Int32: arg.MarkupBps, Valid: true}
+						return result, nil
+					}).Times(1)
+			},
+		},
+		{
+			// The user must confirm the rate they are charged, not the mid-market rate
+			name:         "MidMarketRateIsRejected",
+			exchangeRate: "0.85",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: exchangeRateErrors.ErrExchangeRateMismatch,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(fromAccount, nil).Times(1)
+			store.EXPECT().GetExchangeRate(gomock.Any(), gomock.Any()).Return(db.ExchangeRate{
+				FromCurrency: "USD",
+				ToCurrency:   "EUR",
+				Rate:         decimal.RequireFromString("0.85"),
+				// Updated in the future so the rate has not expired whatever the configured expiry
+				UpdatedAt: pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true},
+			}, nil).Times(1)
+			store.EXPECT().GetFxMarkup(gomock.Any(), gomock.Any()).Return(db.FxMarkup{MarkupBps: 50}, nil).Times(1)
+			expectNoFeeSchedule(store)
+			tc.buildStubs(store)
+
+			request := newTransferRequest()
+			request.IdempotencyKey = ""
+			request.ToCurrency = "EUR"
+			request.ExchangeRate = decimal.RequireFromString(tc.exchangeRate)
+
+			response, err := newMockTransferService(store).MakeTransfer(request)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, decimal.RequireFromString("0.85").Equal(response.Transfer.MidMarketRate))
+			require.Equal(t, int32(50), response.Transfer.MarkupBps)
+		})
+	}
+}
+
+func TestMakeTransferService_AmountPrecision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	// Rejected before the account or rates are read
+	store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	request := newTransferRequest()
+	request.IdempotencyKey = ""
+	request.Amount = decimal.RequireFromString("10.005")
+
+	_, err := newMockTransferService(store).MakeTransfer(request)
+
+	require.ErrorIs(t, err, transferErrors.ErrAmountPrecision)
+}
+
+func TestMakeTransferService_StepUp(t *testing.T) {
+	mfaKey := "abcdefghijklmnopqrstuvwxyz123456"
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	encryptedSecret, err := totp.EncryptSecret(mfaKey, "test_owner", secret)
+	require.NoError(t, err)
+	validCode, err := totp.GenerateCode(secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+
+	enabledMfa := db.UserMfa{
+		Username:        "test_owner",
+		EncryptedSecret: encryptedSecret,
+		EnabledAt:       pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+
+	expectSourceAccount := func(store *mockdb.MockStore) {
+		store.EXPECT().GetAccount(gomock.Any(), int64(1)).
+			Return(db.Account{ID: 1, Owner: "test_owner", Balance: decimal.NewFromInt(1000), Currency: "USD"}, nil).Times(1)
+	}
+	expectTransfer := func(store *mockdb.MockStore) {
+		expectNoFeeSchedule(store)
+		store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Return(newTransferTxResult(), nil).Times(1)
+	}
+	expectAttemptCounted := func(store *mockdb.MockStore) {
+		store.EXPECT().CountMfaAttempt(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ any, arg db.CountMfaAttemptParams) (db.UserMfa, error) {
+				require.Equal(t, "test_owner", arg.Username)
+				require.Equal(t, int32(5), arg.MaxFailedAttempts)
+				return enabledMfa, nil
+			}).Times(1)
+	}
+
+	testCases := []struct {
+		name         string
+		threshold    int64
+		totpCode     string
+		scheduledRun bool
+		buildStubs   func(store *mockdb.MockStore)
+		checkError   func(t *testing.T, err error)
+	}{
+		{
+			name:      "AtThreshold",
+			threshold: 100,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectSourceAccount(store)
+				store.EXPECT().GetUserMfa(gomock.Any(), gomock.Any()).Times(0)
+				expectTransfer(store)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:      "MFANotEnabled",
+			threshold: 50,
+			totpCode:  validCode,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectSourceAccount(store)
+				store.EXPECT().GetUserMfa(gomock.Any(), "test_owner").Return(db.UserMfa{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, transferErrors.ErrStepUpMFANotEnabled)
+			},
+		},
+		{
+			name:      "CodeRequired",
+			threshold: 50,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectSourceAccount(store)
+				store.EXPECT().GetUserMfa(gomock.Any(), "test_owner").Return(enabledMfa, nil).Times(1)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, transferErrors.ErrStepUpTOTPCodeRequired)
+			},
+		},
+		{
+			name:      "InvalidCode",
+			threshold: 50,
+			totpCode:  "12345",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectSourceAccount(store)
+				store.EXPECT().GetUserMfa(gomock.Any(), "test_owner").Return(enabledMfa, nil).Times(1)
+				expectAttemptCounted(store)
+				store.EXPECT().ResetMfaAttempts(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UseTotpStep(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, transferErrors.ErrStepUpInvalidTOTPCode)
+			},
+		},
+		{
+			name:      "CodeAlreadyUsed",
+			threshold: 50,
+			totpCode:  validCode,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectSourceAccount(store)
+				store.EXPECT().GetUserMfa(gomock.Any(), "test_owner").Return(enabledMfa, nil).Times(1)
+				expectAttemptCounted(store)
+				store.EXPECT().UseTotpStep(gomock.Any(), gomock.Any()).Return(db.UserMfa{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().ResetMfaAttempts(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, transferErrors.ErrStepUpInvalidTOTPCode)
+			},
+		},
+		{
+			name:      "ValidCode",
+			threshold: 50,
+			totpCode:  validCode,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectSourceAccount(store)
+				store.EXPECT().GetUserMfa(gomock.Any(), "test_owner").Return(enabledMfa, nil).Times(1)
+				expectAttemptCounted(store)
+				store.EXPECT().UseTotpStep(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ any, arg db.UseTotpStepParams) (db.UserMfa, error) {
+						require.Equal(t, "test_owner", arg.Username)
+						require.InDelta(t, totp.Step(time.Now()), arg.LastUsedStep, 1)
+						return enabledMfa, nil
+					}).Times(1)
+				store.EXPECT().ResetMfaAttempts(gomock.Any(), "test_owner").Return(nil).Times(1)
+				expectTransfer(store)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:      "LockedOut",
+			threshold: 50,
+			totpCode:  validCode,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectSourceAccount(store)
+				store.EXPECT().GetUserMfa(gomock.Any(), "test_owner").Return(enabledMfa, nil).Times(1)
+				store.EXPECT().CountMfaAttempt(gomock.Any(), gomock.Any()).Return(db.UserMfa{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().UseTotpStep(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, transferErrors.ErrStepUpMFALocked)
+			},
+		},
+		{
+			name:         "ScheduledRun",
+			threshold:    50,
+			scheduledRun: true,
+			buildStubs: func(store *mockdb.MockStore) {
+				expectSourceAccount(store)
+				store.EXPECT().GetUserMfa(gomock.Any(), gomock.Any()).Times(0)
+				expectTransfer(store)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			transferService := newMockTransferService(store)
+			transferService.stepUpThresholds = map[string]decimal.Decimal{"USD": decimal.NewFromInt(tc.threshold)}
+			transferService.mfaKey = mfaKey
+			transferService.mfaMaxFailedAttempts = 5
+
+			request := newTransferRequest()
+			request.IdempotencyKey = ""
+			request.TOTPCode = tc.totpCode
+			request.ScheduledRun = tc.scheduledRun
+
+			_, err := transferService.MakeTransfer(request)
+			tc.checkError(t, err)
+		})
+	}
+}
+
+func TestMakeTransferService_StepUpSkippedOnReplay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	request := newTransferRequest()
+	requestHash, err := hashTransferRequest(request)
+	require.NoError(t, err)
+	responseBody, err := json.Marshal(newTransferTxResult())
+	require.NoError(t, err)
+
+	store.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Any()).Return(db.IdempotencyKey{
+		Username:       "test_owner",
+		IdempotencyKey: "key-123",
+		RequestHash:    requestHash,
+		TransferID:     10,
+		ResponseStatus: http.StatusCreated,
+		ResponseBody:   responseBody,
+	}, nil).Times(1)
+	store.EXPECT().GetUserMfa(gomock.Any(), gomock.Any()).Times(0)
+
+	transferService := newMockTransferService(store)
+	transferService.stepUpThresholds = map[string]decimal.Decimal{"USD": decimal.NewFromInt(50)}
+
+	// A retry is answered without a fresh code, the transfer was already stepped up
+	response, err := transferService.MakeTransfer(request)
+	require.NoError(t, err)
+	require.True(t, response.Replayed)
+}
@@ -0,0 +1,66 @@
+package transfers
+
+import (
+	"time"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+)
+
+// quotedTransfer loads the quote a transfer refers to and checks that it still covers the transfer.
+// The transfer transaction consumes the quote, so a quote used or expired in the meantime is still rejected there
+func (transferService *TransferService) quotedTransfer(payload requests.MakeTransferRequest) (db.FxQuote, error) {
+	quote, err := transferService.transferRespository.GetFxQuote(payload.QuoteID)
+	if err != nil {
+		return db.FxQuote{}, err
+	}
+
+	// Other users' quotes are reported as not found
+	if quote.Username != payload.Username {
+		config.Logger.Error("Quote does not belong to user", "quote_id", quote.ID, "username", payload.Username)
+		return db.FxQuote{}, exchangeRateErrors.ErrQuoteNotFound
+	}
+
+	if quote.ConsumedAt.Valid {
+		config.Logger.Error("Quote already used", "quote_id", quote.ID, "transfer_id", quote.TransferID.Int64)
+		return db.FxQuote{}, exchangeRateErrors.ErrQuoteAlreadyUsed
+	}
+
+	if !time.Now().Before(quote.ExpiresAt) {
+		config.Logger.Error("Quote expired", "quote_id", quote.ID, "expires_at", quote.ExpiresAt)
+		return db.FxQuote{}, exchangeRateErrors.ErrQuoteExpired
+	}
+
+	if quote.FromCurrency != payload.FromCurrency || quote.ToCurrency != payload.ToCurrency || !quote.Amount.Equal(payload.Amount) {
+		config.Logger.Error("Quote does not match transfer",
+			"quote_id", quote.ID,
+			"quote_from_currency", quote.FromCurrency,
+			"quote_to_currency", quote.ToCurrency,
+			"quote_amount", quote.Amount,
+			"amount", payload.Amount,
+		)
+		return db.FxQuote{}, exchangeRateErrors.ErrQuoteMismatch
+	}
+
+	if !payload.ExchangeRate.IsZero() && !payload.ExchangeRate.Equal(quote.Rate) {
+		config.Logger.Error("Exchange rate mismatch", "quote_rate", quote.Rate, "payload_exchange_rate", payload.ExchangeRate)
+		return db.FxQuote{}, exchangeRateErrors.ErrExchangeRateMismatch
+	}
+
+	return quote, nil
+}
+
+// quotePivotRate returns the legs of a quoted rate that was derived through a pivot currency, nil for a direct rate
+func quotePivotRate(quote db.FxQuote) *db.PivotRate {
+	if !quote.PivotCurrency.Valid {
+		return nil
+	}
+
+	return &db.PivotRate{
+		Currency: quote.PivotCurrency.String,
+		FromRate: quote.FromPivotRate.Decimal,
+		ToRate:   quote.PivotToRate.Decimal,
+	}
+}
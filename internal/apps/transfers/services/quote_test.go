@@ -0,0 +1,159 @@
+package transfers
+
+import (
+	"testing"
+	"time"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMakeTransferService_Quote(t *testing.T) {
+	fromAccount := db.Account{ID: 1, Owner: "test_owner", Balance: decimal.NewFromInt(1000), Currency: "USD"}
+
+	testCases := []struct {
+		name        string
+		request     func() requests.MakeTransferRequest
+		buildStubs  func(store *mockdb.MockStore)
+		expectedErr error
+	}{
+		{
+			name:    "QuotedRateIsHonoured",
+			request: newQuotedTransferRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(fromAccount, nil).Times(1)
+				store.EXPECT().GetFxQuote(gomock.Any(), int64(5)).Return(newFxQuote(), nil).Times(1)
+				// The live rate is not consulted
+				store.EXPECT().GetExchangeRate(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ any, arg db.TransferTxParams) (db.TransferTxResult, error) {
+						require.Equal(t, int64(5), arg.QuoteID)
+						require.True(t, decimal.RequireFromString("0.85").Equal(arg.ExchangeRate))
+						require.True(t, decimal.NewFromInt(85).Equal(arg.ConvertedAmount))
+						require.True(t, decimal.NewFromInt(2).Equal(arg.Fee))
+						require.Nil(t, arg.PivotRate)
+						require.True(t, decimal.RequireFromString("0.8543").Equal(arg.MidMarketRate))
+						require.Equal(t, int32(50), arg.MarkupBps)
+						return newTransferTxResult(), nil
+					}).Times(1)
+			},
+		},
+		{
+			name:    "DerivedQuoteKeepsLegs",
+			request: newQuotedTransferRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				quote := newFxQuote()
+				quote.PivotCurrency = pgtype.Text{String: "GBP", Valid: true}
+				quote.FromPivotRate = decimal.NewNullDecimal(decimal.RequireFromString("0.8"))
+				quote.PivotToRate = decimal.NewNullDecimal(decimal.RequireFromString("1.0625"))
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(fromAccount, nil).Times(1)
+				store.EXPECT().GetFxQuote(gomock.Any(), int64(5)).Return(quote, nil).Times(1)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ any, arg db.TransferTxParams) (db.TransferTxResult, error) {
+						require.NotNil(t, arg.PivotRate)
+						require.Equal(t, "GBP", arg.PivotRate.Currency)
+						require.True(t, decimal.RequireFromString("0.8").Equal(arg.PivotRate.FromRate))
+						require.True(t, decimal.RequireFromString("1.0625").Equal(arg.PivotRate.ToRate))
+						return newTransferTxResult(), nil
+					}).Times(1)
+			},
+		},
+		{
+			name:    "QuoteNotFound",
+			request: newQuotedTransferRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(fromAccount, nil).Times(1)
+				store.EXPECT().GetFxQuote(gomock.Any(), int64(5)).Return(db.FxQuote{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: exchangeRateErrors.ErrQuoteNotFound,
+		},
+		{
+			name:    "OtherUsersQuote",
+			request: newQuotedTransferRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				quote := newFxQuote()
+				quote.Username = "other_owner"
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(fromAccount, nil).Times(1)
+				store.EXPECT().GetFxQuote(gomock.Any(), int64(5)).Return(quote, nil).Times(1)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: exchangeRateErrors.ErrQuoteNotFound,
+		},
+		{
+			name:    "QuoteExpired",
+			request: newQuotedTransferRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				quote := newFxQuote()
+				quote.ExpiresAt = time.Now().Add(-time.Second)
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(fromAccount, nil).Times(1)
+				store.EXPECT().GetFxQuote(gomock.Any(), int64(5)).Return(quote, nil).Times(1)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: exchangeRateErrors.ErrQuoteExpired,
+		},
+		{
+			name:    "QuoteAlreadyUsed",
+			request: newQuotedTransferRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				quote := newFxQuote()
+				quote.ConsumedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+				quote.TransferID = pgtype.Int8{Int64: 9, Valid: true}
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(fromAccount, nil).Times(1)
+				store.EXPECT().GetFxQuote(gomock.Any(), int64(5)).Return(quote, nil).Times(1)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: exchangeRateErrors.ErrQuoteAlreadyUsed,
+		},
+		{
+			name: "AmountDiffersFromQuote",
+			request: func() requests.MakeTransferRequest {
+				request := newQuotedTransferRequest()
+				request.Amount = decimal.NewFromInt(150)
+				return request
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(fromAccount, nil).Times(1)
+				store.EXPECT().GetFxQuote(gomock.Any(), int64(5)).Return(newFxQuote(), nil).Times(1)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: exchangeRateErrors.ErrQuoteMismatch,
+		},
+		{
+			name:    "QuoteUsedConcurrently",
+			request: newQuotedTransferRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(fromAccount, nil).Times(1)
+				store.EXPECT().GetFxQuote(gomock.Any(), int64(5)).Return(newFxQuote(), nil).Times(1)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Return(db.TransferTxResult{}, db.ErrQuoteUnavailable).Times(1)
+			},
+			expectedErr: db.ErrQuoteUnavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			_, err := newMockTransferService(store).MakeTransfer(tc.request())
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
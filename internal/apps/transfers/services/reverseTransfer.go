@@ -0,0 +1,139 @@
+package transfers
+
+import (
+	"context"
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	"lemfi/simplebank/internal/apps/core"
+	"lemfi/simplebank/internal/apps/currencies"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	exchangeRateRequests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+	responses "lemfi/simplebank/internal/apps/transfers/responses"
+
+	"github.com/shopspring/decimal"
+)
+
+// Exchange rates a cross-currency reversal can be converted at, validated by config when the server starts
+const (
+	ReversalRateOriginal = config.ReversalRateOriginal
+	ReversalRateCurrent  = config.ReversalRateCurrent
+)
+
+// reversalErrors maps the errors of the reversal transaction to the errors returned to the user
+var reversalErrors = map[error]error{
+	db.ErrTransferAlreadyReversed:     transferErrors.ErrTransferAlreadyReversed,
+	db.ErrReversalOfReversal:          transferErrors.ErrReversalOfReversal,
+	db.ErrReversalExceedsTransfer:     transferErrors.ErrReversalExceedsTransfer,
+	db.ErrFeeAlreadyRefunded:          transferErrors.ErrFeeAlreadyRefunded,
+	db.ErrReversalInsufficientBalance: transferErrors.ErrReversalInsufficientBalance,
+	db.ErrReversalAmountRoundsToZero:  transferErrors.ErrReversalAmountTooSmall,
+}
+
+// ReverseTransfer takes a transfer, or part of it, back from the recipient and returns it to the sender (admin only)
+func (transferService *TransferService) ReverseTransfer(payload requests.ReverseTransferRequest) (responses.ReverseTransferResponse, error) {
+	config.Logger.Info("Processing transfer reversal", "transfer_id", payload.TransferID, "refund_fee", payload.RefundFee)
+
+	transfer, err := transferService.transferRespository.GetTransfer(payload.TransferID)
+	if err != nil {
+		return responses.ReverseTransferResponse{}, err
+	}
+
+	// Reversals of reversals and fully reversed transfers are refused before pricing the reversal
+	if transfer.ReversalOfTransferID.Valid {
+		config.Logger.Error("Cannot reverse a reversal", "transfer_id", transfer.ID)
+		return responses.ReverseTransferResponse{}, transferErrors.ErrReversalOfReversal
+	}
+	if transfer.Status == db.TransferStatusReversed {
+		config.Logger.Error("Transfer has already been reversed", "transfer_id", transfer.ID)
+		return responses.ReverseTransferResponse{}, transferErrors.ErrTransferAlreadyReversed
+	}
+
+	amount := decimal.Zero
+	if payload.Amount != nil {
+		amount = *payload.Amount
+
+		if !amount.IsPositive() {
+			config.Logger.Error("Invalid reversal amount", "amount", amount)
+			return responses.ReverseTransferResponse{}, transferErrors.ErrInvalidAmount
+		}
+
+		if !currencies.HasValidPrecision(amount, currencies.Currency(transfer.ToCurrency.String)) {
+			config.Logger.Error("Reversal amount has too many decimal places", "amount", amount, "currency", transfer.ToCurrency.String)
+			return responses.ReverseTransferResponse{}, transferErrors.ErrAmountPrecision
+		}
+	}
+
+	// Zero converts the reversal at the rate of the original transfer
+	exchangeRate := decimal.Zero
+	if transferService.reversalRate == ReversalRateCurrent && transfer.FromCurrency.String != transfer.ToCurrency.String {
+		exchangeRate, err = transferService.currentReversalRate(transfer, amount)
+		if err != nil {
+			return responses.ReverseTransferResponse{}, err
+		}
+	}
+
+	result, err := transferService.transferRespository.ReverseTransfer(db.ReverseTransferTxParams{
+		TransferID:   payload.TransferID,
+		Amount:       amount,
+		ExchangeRate: exchangeRate,
+		RefundFee:    payload.RefundFee,
+	})
+	for reversalErr, clientErr := range reversalErrors {
+		if errors.Is(err, reversalErr) {
+			config.Logger.Error("Transfer reversal refused", "error", err.Error(), "transfer_id", payload.TransferID)
+			return responses.ReverseTransferResponse{}, clientErr
+		}
+	}
+	if err != nil {
+		config.Logger.Error("Transfer reversal failed", "error", err.Error(), "transfer_id", payload.TransferID)
+		return responses.ReverseTransferResponse{}, err
+	}
+
+	config.Logger.Info("Transfer reversed successfully",
+		"transfer_id", result.Transfer.ID,
+		"reversal_id", result.Reversal.ID,
+		"status", result.Transfer.Status,
+		"amount", result.Reversal.Amount,
+		"returned_amount", result.Reversal.ConvertedAmount,
+		"refunded_fee", result.Reversal.RefundedFee,
+	)
+
+	return responses.NewReverseTransferResponse(result), nil
+}
+
+// currentReversalRate returns the mid-market rate from the currency the recipient received back to the currency
+// of the sender, reversals are not charged the FX markup
+func (transferService *TransferService) currentReversalRate(transfer db.GetTransferRow, amount decimal.Decimal) (decimal.Decimal, error) {
+	// The rate does not depend on the amount, the whole transfer prices it when the remainder is reversed
+	if amount.IsZero() {
+		amount = transfer.ConvertedAmount
+	}
+
+	exchangeRateResponse, err := transferService.exchangeRateService.GetExchangeRate(context.Background(), exchangeRateRequests.GetExchangeRateRequest{
+		FromCurrency: transfer.ToCurrency.String,
+		ToCurrency:   transfer.FromCurrency.String,
+		Amount:       amount,
+	})
+	if err != nil {
+		config.Logger.Error("Failed to get exchange rate for reversal",
+			"from_currency", transfer.ToCurrency.String,
+			"to_currency", transfer.FromCurrency.String,
+			"error", err.Error(),
+		)
+		if _, isClient := core.IsClientError(err); isClient {
+			return decimal.Zero, err
+		}
+		return decimal.Zero, transferErrors.ErrExchangeRateNotFound
+	}
+
+	if !exchangeRateResponse.CanTransact {
+		config.Logger.Error("Exchange rate expired", "exchange_rate", exchangeRateResponse.ExchangeRate)
+		return decimal.Zero, exchangeRateErrors.ErrExchangeRateExpired
+	}
+
+	return exchangeRateResponse.ExchangeRate.Rate, nil
+}
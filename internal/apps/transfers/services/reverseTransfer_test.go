@@ -0,0 +1,136 @@
+package transfers
+
+import (
+	"testing"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReverseTransferService(t *testing.T) {
+	partialAmount := decimal.NewFromInt(40)
+
+	testCases := []struct {
+		name        string
+		request     requests.ReverseTransferRequest
+		buildStubs  func(store *mockdb.MockStore)
+		expectedErr error
+	}{
+		{
+			name: "OK",
+			request: requests.ReverseTransferRequest{
+				TransferID: 10,
+				Amount:     &partialAmount,
+				RefundFee:  true,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), int64(10)).Return(newTransferRow(), nil).Times(1)
+				store.EXPECT().ReverseTransferTx(gomock.Any(), db.ReverseTransferTxParams{
+					TransferID:   10,
+					Amount:       partialAmount,
+					ExchangeRate: decimal.Zero,
+					RefundFee:    true,
+				}).Return(db.ReverseTransferTxResult{
+					Transfer: db.Transfer{ID: 10, Status: db.TransferStatusPartiallyReversed},
+					Reversal: db.Transfer{
+						ID:                   11,
+						Amount:               partialAmount,
+						ConvertedAmount:      partialAmount,
+						Status:               db.TransferStatusCompleted,
+						ReversalOfTransferID: pgtype.Int8{Int64: 10, Valid: true},
+					},
+				}, nil).Times(1)
+			},
+		},
+		{
+			name:    "TransferNotFound",
+			request: requests.ReverseTransferRequest{TransferID: 10},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), int64(10)).Return(db.GetTransferRow{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().ReverseTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: transferErrors.ErrTransferNotFound,
+		},
+		{
+			name:    "AlreadyReversed",
+			request: requests.ReverseTransferRequest{TransferID: 10},
+			buildStubs: func(store *mockdb.MockStore) {
+				transfer := newTransferRow()
+				transfer.Status = db.TransferStatusReversed
+				store.EXPECT().GetTransfer(gomock.Any(), int64(10)).Return(transfer, nil).Times(1)
+				store.EXPECT().ReverseTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: transferErrors.ErrTransferAlreadyReversed,
+		},
+		{
+			name:    "ReversalOfReversal",
+			request: requests.ReverseTransferRequest{TransferID: 10},
+			buildStubs: func(store *mockdb.MockStore) {
+				transfer := newTransferRow()
+				transfer.ReversalOfTransferID = pgtype.Int8{Int64: 9, Valid: true}
+				store.EXPECT().GetTransfer(gomock.Any(), int64(10)).Return(transfer, nil).Times(1)
+				store.EXPECT().ReverseTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: transferErrors.ErrReversalOfReversal,
+		},
+		{
+			name: "AmountPrecision",
+			request: requests.ReverseTransferRequest{
+				TransferID: 10,
+				Amount:     func() *decimal.Decimal { amount := decimal.RequireFromString("1.005"); return &amount }(),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), int64(10)).Return(newTransferRow(), nil).Times(1)
+				store.EXPECT().ReverseTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: transferErrors.ErrAmountPrecision,
+		},
+		{
+			name:    "ExceedsTransfer",
+			request: requests.ReverseTransferRequest{TransferID: 10, Amount: &partialAmount},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), int64(10)).Return(newTransferRow(), nil).Times(1)
+				store.EXPECT().ReverseTransferTx(gomock.Any(), gomock.Any()).Return(db.ReverseTransferTxResult{}, db.ErrReversalExceedsTransfer).Times(1)
+			},
+			expectedErr: transferErrors.ErrReversalExceedsTransfer,
+		},
+		{
+			name:    "FeeAlreadyRefunded",
+			request: requests.ReverseTransferRequest{TransferID: 10, RefundFee: true},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransfer(gomock.Any(), int64(10)).Return(newTransferRow(), nil).Times(1)
+				store.EXPECT().ReverseTransferTx(gomock.Any(), gomock.Any()).Return(db.ReverseTransferTxResult{}, db.ErrFeeAlreadyRefunded).Times(1)
+			},
+			expectedErr: transferErrors.ErrFeeAlreadyRefunded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			response, err := newMockTransferService(store).ReverseTransfer(tc.request)
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, db.TransferStatusPartiallyReversed, response.Transfer.Status)
+			require.Equal(t, int64(10), response.Reversal.ReversalOfTransferID)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package transfers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	exchangeRateRequests "lemfi/simplebank/internal/apps/exchangeRates/requests"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+)
+
+// RunScheduledTransfer makes the transfer for the current occurrence of a claimed scheduled transfer,
+// then records the outcome and releases the claim
+func (transferService *TransferService) RunScheduledTransfer(scheduledTransfer db.ScheduledTransfer) (db.ScheduledTransfer, error) {
+	config.Logger.Info("Running scheduled transfer",
+		"scheduled_transfer_id", scheduledTransfer.ID,
+		"occurrence_at", scheduledTransfer.OccurrenceAt,
+		"attempt", scheduledTransfer.Attempts+1,
+	)
+
+	transferID, runErr := transferService.makeScheduledTransfer(scheduledTransfer)
+	if runErr != nil {
+		config.Logger.Error("Scheduled transfer run failed", "scheduled_transfer_id", scheduledTransfer.ID, "error", runErr.Error())
+	}
+
+	cfg := config.Get()
+	outcome := scheduledRunOutcome(scheduledTransfer, transferID, runErr, time.Now(), cfg.ScheduledTransfers.MaxAttempts, cfg.ScheduledTransfers.RetryBackoff)
+
+	recorded, err := transferService.transferRespository.RecordScheduledTransferRun(outcome)
+	if err != nil {
+		return db.ScheduledTransfer{}, err
+	}
+
+	config.Logger.Info("Scheduled transfer run recorded",
+		"scheduled_transfer_id", recorded.ID,
+		"status", recorded.Status,
+		"attempts", recorded.Attempts,
+		"next_run_at", recorded.NextRunAt,
+	)
+
+	return recorded, nil
+}
+
+// makeScheduledTransfer runs a scheduled transfer through MakeTransfer on behalf of its owner.
+// The idempotency key is derived from the occurrence, so a run that committed but was not recorded
+// (e.g. the process stopped) is found again instead of moving money twice
+func (transferService *TransferService) makeScheduledTransfer(scheduledTransfer db.ScheduledTransfer) (int64, error) {
+	payload := requests.MakeTransferRequest{
+		FromAccountID:  scheduledTransfer.FromAccountID,
+		ToAccountID:    scheduledTransfer.ToAccountID,
+		Amount:         scheduledTransfer.Amount,
+		FromCurrency:   scheduledTransfer.FromCurrency,
+		ToCurrency:     scheduledTransfer.ToCurrency,
+		Username:       scheduledTransfer.Owner,
+		IdempotencyKey: scheduledTransferIdempotencyKey(scheduledTransfer),
+		ScheduledRun:   true,
+	}
+
+	// Cross-currency runs use the rate at the time of the run, not the rate when the schedule was created
+	if payload.FromCurrency != payload.ToCurrency {
+		exchangeRateResponse, err := transferService.exchangeRateService.GetExchangeRate(context.Background(), exchangeRateRequests.GetExchangeRateRequest{
+			FromCurrency: payload.FromCurrency,
+			ToCurrency:   payload.ToCurrency,
+			Amount:       payload.Amount,
+		})
+		if err != nil {
+			return 0, err
+		}
+		if !exchangeRateResponse.CanTransact {
+			return 0, exchangeRateErrors.ErrExchangeRateExpired
+		}
+		payload.ExchangeRate = exchangeRateResponse.CustomerRate
+	}
+
+	response, err := transferService.MakeTransfer(payload)
+	if errors.Is(err, transferErrors.ErrIdempotencyKeyMismatch) {
+		// The occurrence already ran, at a rate that has since been refreshed
+		storedKey, found, lookupErr := transferService.transferRespository.GetIdempotencyKey(payload.Username, payload.IdempotencyKey)
+		if lookupErr != nil {
+			return 0, lookupErr
+		}
+		if found {
+			config.Logger.Info("Scheduled transfer occurrence already ran", "scheduled_transfer_id", scheduledTransfer.ID, "transfer_id", storedKey.TransferID)
+			return storedKey.TransferID, nil
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return response.Transfer.ID, nil
+}
+
+// scheduledTransferIdempotencyKeyPrefix starts every idempotency key of a scheduled transfer run,
+// MakeTransfer refuses it from clients
+const scheduledTransferIdempotencyKeyPrefix = "scheduled-transfer:"
+
+func scheduledTransferIdempotencyKey(scheduledTransfer db.ScheduledTransfer) string {
+	return fmt.Sprintf("%s%d:%d", scheduledTransferIdempotencyKeyPrefix, scheduledTransfer.ID, scheduledTransfer.OccurrenceAt.Unix())
+}
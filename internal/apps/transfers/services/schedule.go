@@ -0,0 +1,98 @@
+package transfers
+
+import (
+	"time"
+
+	db "lemfi/simplebank/db/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	scheduleFrequencyOnce    = "once"
+	scheduleFrequencyWeekly  = "weekly"
+	scheduleFrequencyMonthly = "monthly"
+)
+
+const (
+	scheduledTransferStatusActive    = "active"
+	scheduledTransferStatusPaused    = "paused"
+	scheduledTransferStatusCompleted = "completed"
+	scheduledTransferStatusFailed    = "failed"
+	scheduledTransferStatusCancelled = "cancelled"
+)
+
+// nextOccurrence returns the occurrence after from, keeping its time of day.
+// Monthly schedules run on dayOfMonth, or on the last day of months that are shorter.
+// One-off schedules have no next occurrence and return the zero time
+func nextOccurrence(frequency string, dayOfMonth int16, from time.Time) time.Time {
+	switch frequency {
+	case scheduleFrequencyWeekly:
+		return from.AddDate(0, 0, 7)
+	case scheduleFrequencyMonthly:
+		// Day 1 of the next month, so AddDate cannot overflow into the month after
+		firstOfNextMonth := time.Date(from.Year(), from.Month()+1, 1, 0, 0, 0, 0, from.Location())
+		day := min(int(dayOfMonth), daysInMonth(firstOfNextMonth.Year(), firstOfNextMonth.Month()))
+		return time.Date(firstOfNextMonth.Year(), firstOfNextMonth.Month(), day,
+			from.Hour(), from.Minute(), from.Second(), from.Nanosecond(), from.Location())
+	default:
+		return time.Time{}
+	}
+}
+
+// nextOccurrenceAfter skips occurrences until the first one later than now,
+// so a schedule that missed runs (e.g. while paused) does not fire once per missed occurrence
+func nextOccurrenceAfter(frequency string, dayOfMonth int16, from time.Time, now time.Time) time.Time {
+	next := nextOccurrence(frequency, dayOfMonth, from)
+	for !next.IsZero() && !next.After(now) {
+		next = nextOccurrence(frequency, dayOfMonth, next)
+	}
+	return next
+}
+
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// scheduledRunOutcome works out the state of a scheduled transfer after a run.
+// A success moves recurring schedules on to their next occurrence and completes one-off ones.
+// A failure is retried after retryBackoff times the attempt number; once maxAttempts is reached
+// a one-off schedule fails and a recurring one gives up on this occurrence and waits for the next
+func scheduledRunOutcome(scheduled db.ScheduledTransfer, transferID int64, runErr error, now time.Time, maxAttempts int, retryBackoff time.Duration) db.RecordScheduledTransferRunParams {
+	outcome := db.RecordScheduledTransferRunParams{
+		ID:           scheduled.ID,
+		Status:       scheduledTransferStatusActive,
+		OccurrenceAt: scheduled.OccurrenceAt,
+		NextRunAt:    scheduled.NextRunAt,
+	}
+
+	if runErr == nil {
+		outcome.LastTransferID = pgtype.Int8{Int64: transferID, Valid: true}
+		if scheduled.Frequency == scheduleFrequencyOnce {
+			outcome.Status = scheduledTransferStatusCompleted
+			return outcome
+		}
+
+		outcome.OccurrenceAt = nextOccurrenceAfter(scheduled.Frequency, scheduled.DayOfMonth.Int16, scheduled.OccurrenceAt, now)
+		outcome.NextRunAt = outcome.OccurrenceAt
+		return outcome
+	}
+
+	outcome.LastError = pgtype.Text{String: runErr.Error(), Valid: true}
+	outcome.Attempts = scheduled.Attempts + 1
+
+	if int(outcome.Attempts) < maxAttempts {
+		outcome.NextRunAt = now.Add(retryBackoff * time.Duration(outcome.Attempts))
+		return outcome
+	}
+
+	if scheduled.Frequency == scheduleFrequencyOnce {
+		outcome.Status = scheduledTransferStatusFailed
+		return outcome
+	}
+
+	outcome.Attempts = 0
+	outcome.OccurrenceAt = nextOccurrenceAfter(scheduled.Frequency, scheduled.DayOfMonth.Int16, scheduled.OccurrenceAt, now)
+	outcome.NextRunAt = outcome.OccurrenceAt
+	return outcome
+}
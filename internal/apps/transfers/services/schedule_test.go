@@ -0,0 +1,180 @@
+package transfers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	db "lemfi/simplebank/db/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextOccurrence(t *testing.T) {
+	testCases := []struct {
+		name       string
+		frequency  string
+		dayOfMonth int16
+		from       time.Time
+		expected   time.Time
+	}{
+		{
+			name:      "Weekly",
+			frequency: scheduleFrequencyWeekly,
+			from:      time.Date(2025, 8, 29, 9, 30, 0, 0, time.UTC),
+			expected:  time.Date(2025, 9, 5, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "Monthly",
+			frequency:  scheduleFrequencyMonthly,
+			dayOfMonth: 15,
+			from:       time.Date(2025, 8, 15, 9, 30, 0, 0, time.UTC),
+			expected:   time.Date(2025, 9, 15, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "MonthlyClampedToShortMonth",
+			frequency:  scheduleFrequencyMonthly,
+			dayOfMonth: 31,
+			from:       time.Date(2025, 1, 31, 9, 30, 0, 0, time.UTC),
+			expected:   time.Date(2025, 2, 28, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "MonthlyBackToDayAfterShortMonth",
+			frequency:  scheduleFrequencyMonthly,
+			dayOfMonth: 31,
+			from:       time.Date(2025, 2, 28, 9, 30, 0, 0, time.UTC),
+			expected:   time.Date(2025, 3, 31, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "MonthlyLeapYear",
+			frequency:  scheduleFrequencyMonthly,
+			dayOfMonth: 30,
+			from:       time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC),
+			expected:   time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "MonthlyAcrossYearEnd",
+			frequency:  scheduleFrequencyMonthly,
+			dayOfMonth: 1,
+			from:       time.Date(2025, 12, 1, 8, 0, 0, 0, time.UTC),
+			expected:   time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "Once",
+			frequency: scheduleFrequencyOnce,
+			from:      time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+			expected:  time.Time{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, nextOccurrence(tc.frequency, tc.dayOfMonth, tc.from))
+		})
+	}
+}
+
+func TestNextOccurrenceAfterSkipsMissedOccurrences(t *testing.T) {
+	from := time.Date(2025, 8, 1, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 8, 20, 12, 0, 0, 0, time.UTC)
+
+	require.Equal(t, time.Date(2025, 8, 22, 9, 0, 0, 0, time.UTC), nextOccurrenceAfter(scheduleFrequencyWeekly, 0, from, now))
+}
+
+func TestScheduledRunOutcome(t *testing.T) {
+	now := time.Date(2025, 8, 15, 9, 5, 0, 0, time.UTC)
+	occurrence := time.Date(2025, 8, 15, 9, 0, 0, 0, time.UTC)
+	runErr := errors.New("insufficient balance")
+
+	newScheduledTransfer := func(frequency string, attempts int32) db.ScheduledTransfer {
+		scheduledTransfer := db.ScheduledTransfer{
+			ID:           7,
+			Frequency:    frequency,
+			OccurrenceAt: occurrence,
+			NextRunAt:    occurrence.Add(time.Duration(attempts) * 15 * time.Minute),
+			Status:       scheduledTransferStatusActive,
+			Attempts:     attempts,
+		}
+		if frequency == scheduleFrequencyMonthly {
+			scheduledTransfer.DayOfMonth = pgtype.Int2{Int16: 15, Valid: true}
+		}
+		return scheduledTransfer
+	}
+
+	testCases := []struct {
+		name      string
+		scheduled db.ScheduledTransfer
+		runErr    error
+		expected  db.RecordScheduledTransferRunParams
+	}{
+		{
+			name:      "OnceSucceeded",
+			scheduled: newScheduledTransfer(scheduleFrequencyOnce, 0),
+			expected: db.RecordScheduledTransferRunParams{
+				ID:             7,
+				Status:         scheduledTransferStatusCompleted,
+				OccurrenceAt:   occurrence,
+				NextRunAt:      occurrence,
+				LastTransferID: pgtype.Int8{Int64: 42, Valid: true},
+			},
+		},
+		{
+			name:      "MonthlySucceededAfterRetry",
+			scheduled: newScheduledTransfer(scheduleFrequencyMonthly, 1),
+			expected: db.RecordScheduledTransferRunParams{
+				ID:             7,
+				Status:         scheduledTransferStatusActive,
+				OccurrenceAt:   time.Date(2025, 9, 15, 9, 0, 0, 0, time.UTC),
+				NextRunAt:      time.Date(2025, 9, 15, 9, 0, 0, 0, time.UTC),
+				LastTransferID: pgtype.Int8{Int64: 42, Valid: true},
+			},
+		},
+		{
+			name:      "FailedIsRetriedWithBackoff",
+			scheduled: newScheduledTransfer(scheduleFrequencyOnce, 1),
+			runErr:    runErr,
+			expected: db.RecordScheduledTransferRunParams{
+				ID:           7,
+				Status:       scheduledTransferStatusActive,
+				OccurrenceAt: occurrence,
+				NextRunAt:    now.Add(30 * time.Minute),
+				Attempts:     2,
+				LastError:    pgtype.Text{String: "insufficient balance", Valid: true},
+			},
+		},
+		{
+			name:      "OnceOutOfAttempts",
+			scheduled: newScheduledTransfer(scheduleFrequencyOnce, 2),
+			runErr:    runErr,
+			expected: db.RecordScheduledTransferRunParams{
+				ID:           7,
+				Status:       scheduledTransferStatusFailed,
+				OccurrenceAt: occurrence,
+				NextRunAt:    occurrence.Add(30 * time.Minute),
+				Attempts:     3,
+				LastError:    pgtype.Text{String: "insufficient balance", Valid: true},
+			},
+		},
+		{
+			name:      "WeeklyOutOfAttemptsMovesToNextOccurrence",
+			scheduled: newScheduledTransfer(scheduleFrequencyWeekly, 2),
+			runErr:    runErr,
+			expected: db.RecordScheduledTransferRunParams{
+				ID:           7,
+				Status:       scheduledTransferStatusActive,
+				OccurrenceAt: occurrence.AddDate(0, 0, 7),
+				NextRunAt:    occurrence.AddDate(0, 0, 7),
+				Attempts:     0,
+				LastError:    pgtype.Text{String: "insufficient balance", Valid: true},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			outcome := scheduledRunOutcome(tc.scheduled, 42, tc.runErr, now, 3, 15*time.Minute)
+			require.Equal(t, tc.expected, outcome)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package transfers
+
+import (
+	"context"
+	"time"
+
+	"lemfi/simplebank/config"
+)
+
+// scheduledTransferLease is how long a claimed scheduled transfer is reserved for the worker that claimed it.
+// A worker that stops mid-run releases its rows when the lease runs out
+const scheduledTransferLease = 5 * time.Minute
+
+// ScheduledTransferWorker polls for due scheduled transfers and runs them in the background
+type ScheduledTransferWorker struct {
+	transferService *TransferService
+	pollInterval    time.Duration
+	batchSize       int32
+}
+
+func NewScheduledTransferWorker(transferService *TransferService) *ScheduledTransferWorker {
+	cfg := config.Get()
+	return &ScheduledTransferWorker{
+		transferService: transferService,
+		pollInterval:    cfg.ScheduledTransfers.PollInterval,
+		batchSize:       int32(cfg.ScheduledTransfers.BatchSize),
+	}
+}
+
+// Start runs due scheduled transfers every poll interval until the context is cancelled
+func (worker *ScheduledTransferWorker) Start(ctx context.Context) {
+	if worker.pollInterval <= 0 {
+		config.Logger.Info("Scheduled transfer worker disabled")
+		return
+	}
+
+	config.Logger.Info("Scheduled transfer worker started", "poll_interval", worker.pollInterval, "batch_size", worker.batchSize)
+
+	ticker := time.NewTicker(worker.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		worker.RunDue()
+
+		select {
+		case <-ctx.Done():
+			config.Logger.Info("Scheduled transfer worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue claims a batch of due scheduled transfers and runs them, returning how many were run.
+// Rows claimed by another worker are skipped, so several instances can poll the same database
+func (worker *ScheduledTransferWorker) RunDue() (int, error) {
+	claimed, err := worker.transferService.transferRespository.ClaimDueScheduledTransfers(worker.batchSize, time.Now().Add(scheduledTransferLease))
+	if err != nil {
+		return 0, err
+	}
+
+	ran := 0
+	for _, scheduledTransfer := range claimed {
+		_, err := worker.transferService.RunScheduledTransfer(scheduledTransfer)
+		if err != nil {
+			// The claim expires with the lease and the run is picked up again
+			config.Logger.Error("Failed to run scheduled transfer", "scheduled_transfer_id", scheduledTransfer.ID, "error", err.Error())
+			continue
+		}
+		ran++
+	}
+
+	if len(claimed) > 0 {
+		config.Logger.Info("Ran due scheduled transfers", "claimed", len(claimed), "ran", ran)
+	}
+
+	return ran, nil
+}
@@ -0,0 +1,123 @@
+package transfers
+
+import (
+	"errors"
+	"testing"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestScheduledTransferWorker_RunDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	scheduledTransfer := newScheduledTransfer()
+	store.EXPECT().ClaimDueScheduledTransfers(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, arg db.ClaimDueScheduledTransfersParams) ([]db.ScheduledTransfer, error) {
+			require.Equal(t, int32(10), arg.BatchSize)
+			return []db.ScheduledTransfer{scheduledTransfer}, nil
+		}).Times(1)
+	expectScheduledTransferRun(store)
+	store.EXPECT().RecordScheduledTransferRun(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, arg db.RecordScheduledTransferRunParams) (db.ScheduledTransfer, error) {
+			return db.ScheduledTransfer{ID: arg.ID, Status: arg.Status}, nil
+		}).Times(1)
+
+	worker := &ScheduledTransferWorker{transferService: newMockTransferService(store), batchSize: 10}
+	ran, err := worker.RunDue()
+
+	require.NoError(t, err)
+	require.Equal(t, 1, ran)
+}
+
+func TestScheduledTransferWorker_RunDueCancelledDuringRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	scheduledTransfer := newScheduledTransfer()
+	scheduledTransfer.Frequency = scheduleFrequencyWeekly
+	stored := scheduledTransfer
+
+	store.EXPECT().ClaimDueScheduledTransfers(gomock.Any(), gomock.Any()).Return([]db.ScheduledTransfer{scheduledTransfer}, nil).Times(1)
+	store.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Any()).Return(db.IdempotencyKey{}, pgx.ErrNoRows).Times(1)
+	store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(newTransferTxResult().FromAccount, nil).Times(1)
+	expectNoFeeSchedule(store)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, arg db.TransferTxParams) (db.TransferTxResult, error) {
+			// The owner cancels the schedule while its transfer is being made
+			stored.Status = scheduledTransferStatusCancelled
+			return newTransferTxResult(), nil
+		}).Times(1)
+	store.EXPECT().RecordScheduledTransferRun(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, arg db.RecordScheduledTransferRunParams) (db.ScheduledTransfer, error) {
+			// The worker still asks for the schedule to stay active and move on to its next occurrence
+			require.Equal(t, scheduledTransferStatusActive, arg.Status)
+			require.True(t, arg.NextRunAt.After(scheduledTransfer.NextRunAt))
+
+			// Mirrors RecordScheduledTransferRun, which only changes the status of active schedules
+			if stored.Status == scheduledTransferStatusActive {
+				stored.Status = arg.Status
+			}
+			stored.LastTransferID = arg.LastTransferID
+			return stored, nil
+		}).Times(1)
+
+	worker := &ScheduledTransferWorker{transferService: newMockTransferService(store), batchSize: 10}
+	ran, err := worker.RunDue()
+
+	require.NoError(t, err)
+	require.Equal(t, 1, ran)
+	require.Equal(t, scheduledTransferStatusCancelled, stored.Status)
+	require.Equal(t, int64(10), stored.LastTransferID.Int64)
+}
+
+func TestScheduledTransferWorker_RunDueRecordFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().ClaimDueScheduledTransfers(gomock.Any(), gomock.Any()).Return([]db.ScheduledTransfer{newScheduledTransfer()}, nil).Times(1)
+	expectScheduledTransferRun(store)
+	store.EXPECT().RecordScheduledTransferRun(gomock.Any(), gomock.Any()).Return(db.ScheduledTransfer{}, errors.New("connection reset")).Times(1)
+
+	worker := &ScheduledTransferWorker{transferService: newMockTransferService(store), batchSize: 10}
+	ran, err := worker.RunDue()
+
+	// The claim is left to expire so that another poll records the run
+	require.NoError(t, err)
+	require.Equal(t, 0, ran)
+}
+
+func TestScheduledTransferWorker_RunDueClaimFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().ClaimDueScheduledTransfers(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection reset")).Times(1)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	worker := &ScheduledTransferWorker{transferService: newMockTransferService(store), batchSize: 10}
+	ran, err := worker.RunDue()
+
+	require.Error(t, err)
+	require.Equal(t, 0, ran)
+}
+
+// expectScheduledTransferRun stubs a same-currency scheduled run whose transfer succeeds
+func expectScheduledTransferRun(store *mockdb.MockStore) {
+	store.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Any()).Return(db.IdempotencyKey{}, pgx.ErrNoRows).Times(1)
+	store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(newTransferTxResult().FromAccount, nil).Times(1)
+	expectNoFeeSchedule(store)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Return(newTransferTxResult(), nil).Times(1)
+}
@@ -0,0 +1,288 @@
+package transfers
+
+import (
+	"errors"
+	"time"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	"lemfi/simplebank/internal/apps/currencies"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+	responses "lemfi/simplebank/internal/apps/transfers/responses"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// CreateScheduledTransfer stores a transfer for the scheduler to run at start_at, and then weekly or monthly when recurring
+func (transferService *TransferService) CreateScheduledTransfer(payload requests.CreateScheduledTransferRequest) (responses.ScheduledTransferResponse, error) {
+	config.Logger.Info("Creating scheduled transfer",
+		"from_account_id", payload.FromAccountID,
+		"to_account_id", payload.ToAccountID,
+		"frequency", payload.Frequency,
+		"start_at", payload.StartAt,
+	)
+
+	if !currencies.IsSupportedCurrency(currencies.Currency(payload.FromCurrency)) || !currencies.IsSupportedCurrency(currencies.Currency(payload.ToCurrency)) {
+		config.Logger.Error("Currency is not supported", "from_currency", payload.FromCurrency, "to_currency", payload.ToCurrency)
+		return responses.ScheduledTransferResponse{}, currencies.ErrCurrencyNotSupported
+	}
+
+	if payload.FromAccountID == payload.ToAccountID {
+		config.Logger.Error("Cannot schedule a transfer to the same account", "account_id", payload.FromAccountID)
+		return responses.ScheduledTransferResponse{}, transferErrors.ErrSameAccountTransfer
+	}
+
+	if payload.Amount.LessThanOrEqual(decimal.Zero) {
+		config.Logger.Error("Invalid scheduled transfer amount", "amount", payload.Amount)
+		return responses.ScheduledTransferResponse{}, transferErrors.ErrInvalidAmount
+	}
+
+	if !currencies.HasValidPrecision(payload.Amount, currencies.Currency(payload.FromCurrency)) {
+		config.Logger.Error("Scheduled transfer amount has too many decimal places", "amount", payload.Amount, "currency", payload.FromCurrency)
+		return responses.ScheduledTransferResponse{}, transferErrors.ErrAmountPrecision
+	}
+
+	if !payload.StartAt.After(time.Now()) {
+		config.Logger.Error("Scheduled transfer starts in the past", "start_at", payload.StartAt)
+		return responses.ScheduledTransferResponse{}, transferErrors.ErrScheduleStartInPast
+	}
+
+	dayOfMonth, err := scheduleDayOfMonth(payload.Frequency, payload.DayOfMonth, payload.StartAt)
+	if err != nil {
+		return responses.ScheduledTransferResponse{}, err
+	}
+
+	// Authorization: only the owner can schedule debits from the source account
+	fromAccount, err := transferService.authorizeAccountOwner(payload.FromAccountID, payload.Username)
+	if errors.Is(err, transferErrors.ErrAccountNotFound) {
+		return responses.ScheduledTransferResponse{}, transferErrors.ErrFromAccountNotFound
+	}
+	if err != nil {
+		return responses.ScheduledTransferResponse{}, err
+	}
+	if fromAccount.Currency != payload.FromCurrency {
+		config.Logger.Error("From account currency mismatch", "account_currency", fromAccount.Currency, "from_currency", payload.FromCurrency)
+		return responses.ScheduledTransferResponse{}, transferErrors.ErrFromAccountCurrencyMismatch
+	}
+
+	// Runs are made without the user, so the schedule is stepped up when it is created
+	err = transferService.authorizeStepUp(payload.Username, payload.Amount, payload.FromCurrency, payload.TOTPCode)
+	if err != nil {
+		return responses.ScheduledTransferResponse{}, err
+	}
+
+	toAccount, err := transferService.transferRespository.GetAccount(payload.ToAccountID)
+	if errors.Is(err, transferErrors.ErrAccountNotFound) {
+		return responses.ScheduledTransferResponse{}, transferErrors.ErrToAccountNotFound
+	}
+	if err != nil {
+		return responses.ScheduledTransferResponse{}, err
+	}
+	if toAccount.Currency != payload.ToCurrency {
+		config.Logger.Error("To account currency mismatch", "account_currency", toAccount.Currency, "to_currency", payload.ToCurrency)
+		return responses.ScheduledTransferResponse{}, transferErrors.ErrToAccountCurrencyMismatch
+	}
+
+	scheduledTransfer, err := transferService.transferRespository.CreateScheduledTransfer(db.CreateScheduledTransferParams{
+		Owner:         payload.Username,
+		FromAccountID: payload.FromAccountID,
+		ToAccountID:   payload.ToAccountID,
+		Amount:        payload.Amount,
+		FromCurrency:  payload.FromCurrency,
+		ToCurrency:    payload.ToCurrency,
+		Frequency:     payload.Frequency,
+		DayOfMonth:    dayOfMonth,
+		NextRunAt:     payload.StartAt,
+	})
+	if err != nil {
+		return responses.ScheduledTransferResponse{}, err
+	}
+
+	config.Logger.Info("Scheduled transfer created", "scheduled_transfer_id", scheduledTransfer.ID, "next_run_at", scheduledTransfer.NextRunAt)
+
+	return responses.NewScheduledTransferResponse(scheduledTransfer), nil
+}
+
+// ListScheduledTransfers returns the user's scheduled transfers, newest first
+func (transferService *TransferService) ListScheduledTransfers(username string) ([]responses.ScheduledTransferResponse, error) {
+	config.Logger.Info("Listing scheduled transfers", "username", username)
+
+	scheduledTransfers, err := transferService.transferRespository.ListScheduledTransfers(username)
+	if err != nil {
+		return nil, err
+	}
+
+	return responses.NewScheduledTransferResponses(scheduledTransfers), nil
+}
+
+// GetScheduledTransfer returns one of the user's scheduled transfers
+func (transferService *TransferService) GetScheduledTransfer(id int64, username string) (responses.ScheduledTransferResponse, error) {
+	config.Logger.Info("Getting scheduled transfer", "scheduled_transfer_id", id, "username", username)
+
+	scheduledTransfer, err := transferService.authorizeScheduledTransferOwner(id, username)
+	if err != nil {
+		return responses.ScheduledTransferResponse{}, err
+	}
+
+	return responses.NewScheduledTransferResponse(scheduledTransfer), nil
+}
+
+// UpdateScheduledTransfer changes the amount or schedule of an active or paused scheduled transfer, or pauses and resumes it.
+// Any change starts the current occurrence over, clearing failed attempts
+func (transferService *TransferService) UpdateScheduledTransfer(payload requests.UpdateScheduledTransferRequest) (responses.ScheduledTransferResponse, error) {
+	config.Logger.Info("Updating scheduled transfer", "scheduled_transfer_id", payload.ID, "username", payload.Username)
+
+	scheduledTransfer, err := transferService.authorizeScheduledTransferOwner(payload.ID, payload.Username)
+	if err != nil {
+		return responses.ScheduledTransferResponse{}, err
+	}
+
+	if !isScheduledTransferOpen(scheduledTransfer) {
+		config.Logger.Error("Scheduled transfer is closed", "scheduled_transfer_id", scheduledTransfer.ID, "status", scheduledTransfer.Status)
+		return responses.ScheduledTransferResponse{}, transferErrors.ErrScheduledTransferClosed
+	}
+
+	params := db.UpdateScheduledTransferParams{
+		ID:         scheduledTransfer.ID,
+		Amount:     scheduledTransfer.Amount,
+		Frequency:  scheduledTransfer.Frequency,
+		DayOfMonth: scheduledTransfer.DayOfMonth,
+		NextRunAt:  scheduledTransfer.OccurrenceAt,
+		Status:     scheduledTransfer.Status,
+	}
+
+	if payload.Amount != nil {
+		if payload.Amount.LessThanOrEqual(decimal.Zero) {
+			config.Logger.Error("Invalid scheduled transfer amount", "amount", payload.Amount)
+			return responses.ScheduledTransferResponse{}, transferErrors.ErrInvalidAmount
+		}
+		if !currencies.HasValidPrecision(*payload.Amount, currencies.Currency(scheduledTransfer.FromCurrency)) {
+			config.Logger.Error("Scheduled transfer amount has too many decimal places", "amount", payload.Amount, "currency", scheduledTransfer.FromCurrency)
+			return responses.ScheduledTransferResponse{}, transferErrors.ErrAmountPrecision
+		}
+		if payload.Amount.GreaterThan(scheduledTransfer.Amount) {
+			err = transferService.authorizeStepUp(payload.Username, *payload.Amount, scheduledTransfer.FromCurrency, payload.TOTPCode)
+			if err != nil {
+				return responses.ScheduledTransferResponse{}, err
+			}
+		}
+		params.Amount = *payload.Amount
+	}
+
+	if payload.Status != nil {
+		if *payload.Status != scheduledTransferStatusActive && *payload.Status != scheduledTransferStatusPaused {
+			return responses.ScheduledTransferResponse{}, transferErrors.ErrInvalidScheduledTransferStatus
+		}
+		params.Status = *payload.Status
+	}
+
+	now := time.Now()
+	if payload.NextRunAt != nil {
+		if !payload.NextRunAt.After(now) {
+			config.Logger.Error("Scheduled transfer moved to the past", "next_run_at", payload.NextRunAt)
+			return responses.ScheduledTransferResponse{}, transferErrors.ErrScheduleStartInPast
+		}
+		params.NextRunAt = *payload.NextRunAt
+	} else if params.Status == scheduledTransferStatusActive && params.Frequency != scheduleFrequencyOnce && !params.NextRunAt.After(now) {
+		// Resuming a recurring schedule skips the occurrences missed while it was paused
+		params.NextRunAt = nextOccurrenceAfter(params.Frequency, params.DayOfMonth.Int16, params.NextRunAt, now)
+	}
+
+	if payload.Frequency != nil || payload.DayOfMonth != nil {
+		if payload.Frequency != nil {
+			params.Frequency = *payload.Frequency
+		}
+
+		requestedDay := int16(0)
+		if payload.DayOfMonth != nil {
+			requestedDay = *payload.DayOfMonth
+		} else if params.Frequency == scheduleFrequencyMonthly {
+			requestedDay = params.DayOfMonth.Int16
+		}
+
+		params.DayOfMonth, err = scheduleDayOfMonth(params.Frequency, requestedDay, params.NextRunAt)
+		if err != nil {
+			return responses.ScheduledTransferResponse{}, err
+		}
+	}
+
+	updated, err := transferService.transferRespository.UpdateScheduledTransfer(params)
+	if err != nil {
+		return responses.ScheduledTransferResponse{}, err
+	}
+
+	config.Logger.Info("Scheduled transfer updated", "scheduled_transfer_id", updated.ID, "status", updated.Status, "next_run_at", updated.NextRunAt)
+
+	return responses.NewScheduledTransferResponse(updated), nil
+}
+
+// CancelScheduledTransfer stops a scheduled transfer from running again. The row is kept for history
+func (transferService *TransferService) CancelScheduledTransfer(id int64, username string) (responses.ScheduledTransferResponse, error) {
+	config.Logger.Info("Cancelling scheduled transfer", "scheduled_transfer_id", id, "username", username)
+
+	scheduledTransfer, err := transferService.authorizeScheduledTransferOwner(id, username)
+	if err != nil {
+		return responses.ScheduledTransferResponse{}, err
+	}
+
+	if !isScheduledTransferOpen(scheduledTransfer) {
+		config.Logger.Error("Scheduled transfer is closed", "scheduled_transfer_id", scheduledTransfer.ID, "status", scheduledTransfer.Status)
+		return responses.ScheduledTransferResponse{}, transferErrors.ErrScheduledTransferClosed
+	}
+
+	cancelled, err := transferService.transferRespository.UpdateScheduledTransfer(db.UpdateScheduledTransferParams{
+		ID:         scheduledTransfer.ID,
+		Amount:     scheduledTransfer.Amount,
+		Frequency:  scheduledTransfer.Frequency,
+		DayOfMonth: scheduledTransfer.DayOfMonth,
+		NextRunAt:  scheduledTransfer.OccurrenceAt,
+		Status:     scheduledTransferStatusCancelled,
+		Attempts:   scheduledTransfer.Attempts,
+	})
+	if err != nil {
+		return responses.ScheduledTransferResponse{}, err
+	}
+
+	config.Logger.Info("Scheduled transfer cancelled", "scheduled_transfer_id", cancelled.ID)
+
+	return responses.NewScheduledTransferResponse(cancelled), nil
+}
+
+// authorizeScheduledTransferOwner loads a scheduled transfer and checks that it belongs to the user.
+// Other users' schedules are reported as not found so their ids are not revealed
+func (transferService *TransferService) authorizeScheduledTransferOwner(id int64, username string) (db.ScheduledTransfer, error) {
+	scheduledTransfer, err := transferService.transferRespository.GetScheduledTransfer(id)
+	if err != nil {
+		return db.ScheduledTransfer{}, err
+	}
+
+	if username == "" || scheduledTransfer.Owner != username {
+		config.Logger.Error("Scheduled transfer does not belong to user", "scheduled_transfer_id", id, "username", username)
+		return db.ScheduledTransfer{}, transferErrors.ErrScheduledTransferNotFound
+	}
+
+	return scheduledTransfer, nil
+}
+
+func isScheduledTransferOpen(scheduledTransfer db.ScheduledTransfer) bool {
+	return scheduledTransfer.Status == scheduledTransferStatusActive || scheduledTransfer.Status == scheduledTransferStatusPaused
+}
+
+// scheduleDayOfMonth returns the day monthly schedules run on, defaulting to the day of the first run.
+// Other frequencies do not take a day
+func scheduleDayOfMonth(frequency string, dayOfMonth int16, firstRun time.Time) (pgtype.Int2, error) {
+	if frequency != scheduleFrequencyMonthly {
+		if dayOfMonth != 0 {
+			config.Logger.Error("Day of month set on a non monthly schedule", "frequency", frequency, "day_of_month", dayOfMonth)
+			return pgtype.Int2{}, transferErrors.ErrDayOfMonthNotAllowed
+		}
+		return pgtype.Int2{}, nil
+	}
+
+	if dayOfMonth == 0 {
+		dayOfMonth = int16(firstRun.Day())
+	}
+	return pgtype.Int2{Int16: dayOfMonth, Valid: true}, nil
+}
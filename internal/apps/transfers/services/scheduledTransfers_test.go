@@ -0,0 +1,281 @@
+package transfers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateScheduledTransferService(t *testing.T) {
+	startAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	newRequest := func() requests.CreateScheduledTransferRequest {
+		return requests.CreateScheduledTransferRequest{
+			FromAccountID: 1,
+			ToAccountID:   2,
+			Amount:        decimal.NewFromInt(100),
+			FromCurrency:  "USD",
+			ToCurrency:    "USD",
+			Frequency:     scheduleFrequencyMonthly,
+			StartAt:       startAt,
+			Username:      "test_owner",
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		request     func() requests.CreateScheduledTransferRequest
+		buildStubs  func(store *mockdb.MockStore)
+		expectedErr error
+	}{
+		{
+			name:    "OK",
+			request: newRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(db.Account{ID: 1, Owner: "test_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(2)).Return(db.Account{ID: 2, Owner: "other_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), db.CreateScheduledTransferParams{
+					Owner:         "test_owner",
+					FromAccountID: 1,
+					ToAccountID:   2,
+					Amount:        decimal.NewFromInt(100),
+					FromCurrency:  "USD",
+					ToCurrency:    "USD",
+					Frequency:     scheduleFrequencyMonthly,
+					DayOfMonth:    pgtype.Int2{Int16: int16(startAt.Day()), Valid: true},
+					NextRunAt:     startAt,
+				}).Return(db.ScheduledTransfer{ID: 7, Owner: "test_owner", Status: scheduledTransferStatusActive}, nil).Times(1)
+			},
+		},
+		{
+			name: "StartInPast",
+			request: func() requests.CreateScheduledTransferRequest {
+				request := newRequest()
+				request.StartAt = time.Now().Add(-time.Hour)
+				return request
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: transferErrors.ErrScheduleStartInPast,
+		},
+		{
+			name: "DayOfMonthOnWeeklySchedule",
+			request: func() requests.CreateScheduledTransferRequest {
+				request := newRequest()
+				request.Frequency = scheduleFrequencyWeekly
+				request.DayOfMonth = 5
+				return request
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: transferErrors.ErrDayOfMonthNotAllowed,
+		},
+		{
+			name:    "NotAccountOwner",
+			request: newRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(db.Account{ID: 1, Owner: "other_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: transferErrors.ErrAccountForbidden,
+		},
+		{
+			name:    "ToAccountNotFound",
+			request: newRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(db.Account{ID: 1, Owner: "test_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(2)).Return(db.Account{}, pgx.ErrNoRows).Times(1)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: transferErrors.ErrToAccountNotFound,
+		},
+		{
+			name:    "FromAccountCurrencyMismatch",
+			request: newRequest,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(db.Account{ID: 1, Owner: "test_owner", Currency: "EUR"}, nil).Times(1)
+				store.EXPECT().CreateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: transferErrors.ErrFromAccountCurrencyMismatch,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			response, err := newMockTransferService(store).CreateScheduledTransfer(tc.request())
+			if tc.expectedErr != nil {
+				require.Equal(t, tc.expectedErr, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, int64(7), response.ID)
+		})
+	}
+}
+
+func TestGetScheduledTransferService_OtherOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetScheduledTransfer(gomock.Any(), int64(7)).Return(newScheduledTransfer(), nil).Times(1)
+
+	_, err := newMockTransferService(store).GetScheduledTransfer(7, "other_owner")
+
+	require.Equal(t, transferErrors.ErrScheduledTransferNotFound, err)
+}
+
+func TestUpdateScheduledTransferService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	scheduledTransfer := newScheduledTransfer()
+	scheduledTransfer.Frequency = scheduleFrequencyWeekly
+	scheduledTransfer.Attempts = 2
+
+	store.EXPECT().GetScheduledTransfer(gomock.Any(), int64(7)).Return(scheduledTransfer, nil).Times(1)
+	store.EXPECT().UpdateScheduledTransfer(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, arg db.UpdateScheduledTransferParams) (db.ScheduledTransfer, error) {
+			// Moving to a monthly schedule defaults the day to that of the next run
+			require.Equal(t, scheduleFrequencyMonthly, arg.Frequency)
+			require.Equal(t, pgtype.Int2{Int16: int16(arg.NextRunAt.Day()), Valid: true}, arg.DayOfMonth)
+			require.True(t, arg.NextRunAt.After(time.Now()))
+			require.True(t, decimal.NewFromInt(250).Equal(arg.Amount))
+			require.Equal(t, int32(0), arg.Attempts)
+			return db.ScheduledTransfer{ID: 7, Frequency: arg.Frequency, Status: arg.Status}, nil
+		}).Times(1)
+
+	amount := decimal.NewFromInt(250)
+	frequency := scheduleFrequencyMonthly
+	response, err := newMockTransferService(store).UpdateScheduledTransfer(requests.UpdateScheduledTransferRequest{
+		ID:        7,
+		Username:  "test_owner",
+		Amount:    &amount,
+		Frequency: &frequency,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, scheduleFrequencyMonthly, response.Frequency)
+}
+
+func TestCancelScheduledTransferService_Closed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	scheduledTransfer := newScheduledTransfer()
+	scheduledTransfer.Status = scheduledTransferStatusCompleted
+
+	store.EXPECT().GetScheduledTransfer(gomock.Any(), int64(7)).Return(scheduledTransfer, nil).Times(1)
+	store.EXPECT().UpdateScheduledTransfer(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := newMockTransferService(store).CancelScheduledTransfer(7, "test_owner")
+
+	require.Equal(t, transferErrors.ErrScheduledTransferClosed, err)
+}
+
+func TestRunScheduledTransferService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	scheduledTransfer := newScheduledTransfer()
+	idempotencyKey := scheduledTransferIdempotencyKey(scheduledTransfer)
+
+	store.EXPECT().GetIdempotencyKey(gomock.Any(), db.GetIdempotencyKeyParams{
+		Username:       "test_owner",
+		IdempotencyKey: idempotencyKey,
+	}).Return(db.IdempotencyKey{}, pgx.ErrNoRows).Times(1)
+	store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(newTransferTxResult().FromAccount, nil).Times(1)
+	expectNoFeeSchedule(store)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, arg db.TransferTxParams) (db.TransferTxResult, error) {
+			require.Equal(t, "test_owner", arg.Username)
+			require.Equal(t, idempotencyKey, arg.IdempotencyKey)
+			return newTransferTxResult(), nil
+		}).Times(1)
+	store.EXPECT().RecordScheduledTransferRun(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, arg db.RecordScheduledTransferRunParams) (db.ScheduledTransfer, error) {
+			require.Equal(t, scheduledTransferStatusCompleted, arg.Status)
+			require.Equal(t, pgtype.Int8{Int64: 10, Valid: true}, arg.LastTransferID)
+			require.False(t, arg.LastError.Valid)
+			return db.ScheduledTransfer{ID: 7, Status: arg.Status, LastTransferID: arg.LastTransferID}, nil
+		}).Times(1)
+
+	recorded, err := newMockTransferService(store).RunScheduledTransfer(scheduledTransfer)
+
+	require.NoError(t, err)
+	require.Equal(t, scheduledTransferStatusCompleted, recorded.Status)
+}
+
+func TestRunScheduledTransferService_AlreadyRan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	// The occurrence committed earlier with a request body that no longer matches
+	store.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Any()).Return(db.IdempotencyKey{
+		Username:       "test_owner",
+		RequestHash:    "hash-of-an-earlier-run",
+		TransferID:     10,
+		ResponseStatus: http.StatusCreated,
+	}, nil).Times(2)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().RecordScheduledTransferRun(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, arg db.RecordScheduledTransferRunParams) (db.ScheduledTransfer, error) {
+			require.Equal(t, scheduledTransferStatusCompleted, arg.Status)
+			require.Equal(t, pgtype.Int8{Int64: 10, Valid: true}, arg.LastTransferID)
+			return db.ScheduledTransfer{ID: 7, Status: arg.Status}, nil
+		}).Times(1)
+
+	_, err := newMockTransferService(store).RunScheduledTransfer(newScheduledTransfer())
+
+	require.NoError(t, err)
+}
+
+func TestMakeTransferService_ScheduledTransferIdempotencyKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	// A client guessing the key of the next occurrence must not claim it before the worker does
+	nextOccurrence := newScheduledTransfer()
+	nextOccurrence.OccurrenceAt = nextOccurrence.OccurrenceAt.AddDate(0, 1, 0)
+
+	request := newTransferRequest()
+	request.IdempotencyKey = scheduledTransferIdempotencyKey(nextOccurrence)
+
+	store.EXPECT().GetIdempotencyKey(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	response, err := newMockTransferService(store).MakeTransfer(request)
+
+	require.Equal(t, transferErrors.ErrIdempotencyKeyReserved, err)
+	require.Empty(t, response)
+}
@@ -0,0 +1,202 @@
+package transfers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	"lemfi/simplebank/internal/apps/core"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+	responses "lemfi/simplebank/internal/apps/transfers/responses"
+
+	"github.com/shopspring/decimal"
+)
+
+// CreateTransferBatch validates and prices every transfer of a batch before any of them is made,
+// then makes them from the source account in a single transaction
+func (transferService *TransferService) CreateTransferBatch(payload requests.CreateTransferBatchRequest) (responses.CreateTransferBatchResponse, error) {
+	config.Logger.Info("Processing transfer batch request", "username", payload.Username, "mode", payload.Mode, "items", len(payload.Items))
+
+	if transferService.batchMaxItems > 0 && len(payload.Items) > transferService.batchMaxItems {
+		config.Logger.Error("Transfer batch too large", "items", len(payload.Items), "max_items", transferService.batchMaxItems)
+		return responses.CreateTransferBatchResponse{}, transferErrors.ErrTransferBatchTooLarge
+	}
+
+	// Every item debits the same source account, which is locked once for the whole batch
+	source := payload.Items[0]
+	for _, item := range payload.Items {
+		if item.FromAccountID != source.FromAccountID || item.FromCurrency != source.FromCurrency {
+			config.Logger.Error("Transfer batch has more than one source account", "from_account_id", source.FromAccountID, "item_from_account_id", item.FromAccountID)
+			return responses.CreateTransferBatchResponse{}, transferErrors.ErrTransferBatchMixedSourceAccounts
+		}
+	}
+
+	err := transferService.authorizeVerifiedEmail(payload.Username)
+	if err != nil {
+		return responses.CreateTransferBatchResponse{}, err
+	}
+
+	fromAccount, err := transferService.authorizeAccountOwner(source.FromAccountID, payload.Username)
+	if errors.Is(err, transferErrors.ErrAccountNotFound) {
+		return responses.CreateTransferBatchResponse{}, transferErrors.ErrFromAccountNotFound
+	}
+	if err != nil {
+		return responses.CreateTransferBatchResponse{}, err
+	}
+	if fromAccount.Currency != source.FromCurrency {
+		config.Logger.Error("From account currency mismatch", "account_currency", fromAccount.Currency, "from_currency", source.FromCurrency)
+		return responses.CreateTransferBatchResponse{}, transferErrors.ErrFromAccountCurrencyMismatch
+	}
+
+	// A batch is stepped up on its total so that it cannot be split under the threshold
+	batchTotal := decimal.Zero
+	for _, item := range payload.Items {
+		if item.Amount.IsPositive() {
+			batchTotal = batchTotal.Add(item.Amount)
+		}
+	}
+	err = transferService.authorizeStepUp(payload.Username, batchTotal, source.FromCurrency, payload.TOTPCode)
+	if err != nil {
+		return responses.CreateTransferBatchResponse{}, err
+	}
+
+	items := make([]db.TransferTxParams, 0, len(payload.Items))
+	toAccounts := make(map[int64]db.Account)
+	totalDebit := decimal.Zero
+	var problems []string
+
+	for i, item := range payload.Items {
+		item.Username = payload.Username
+
+		params, err := transferService.prepareBatchItem(item, toAccounts)
+		if err != nil {
+			if _, isClient := core.IsClientError(err); !isClient {
+				return responses.CreateTransferBatchResponse{}, err
+			}
+			problems = append(problems, fmt.Sprintf("items[%d]: %s", i, err.Error()))
+			continue
+		}
+
+		items = append(items, params)
+		totalDebit = totalDebit.Add(params.Amount).Add(params.Fee)
+	}
+
+	// Nothing is transferred when any item is invalid, whatever the mode
+	if len(problems) > 0 {
+		config.Logger.Error("Transfer batch has invalid items", "username", payload.Username, "problems", problems)
+		return responses.CreateTransferBatchResponse{}, core.ClientError{
+			Message: transferErrors.ErrInvalidTransferBatch.Message + ": " + strings.Join(problems, "; "),
+			Status:  transferErrors.ErrInvalidTransferBatch.Status,
+		}
+	}
+
+	// An all_or_nothing batch the source account cannot cover is refused before it is attempted
+	if payload.Mode == db.TransferBatchModeAllOrNothing && fromAccount.Balance.Sub(fromAccount.HeldAmount).LessThan(totalDebit) {
+		config.Logger.Error("Insufficient available balance for transfer batch", "from_account_id", fromAccount.ID, "total_debit", totalDebit)
+		return responses.CreateTransferBatchResponse{}, transferErrors.ErrTransferBatchInsufficientBalance
+	}
+
+	result, err := transferService.transferRespository.CreateTransferBatch(db.BatchTransferTxParams{
+		Username:      payload.Username,
+		FromAccountID: fromAccount.ID,
+		Mode:          payload.Mode,
+		Items:         items,
+	})
+	if err != nil {
+		return responses.CreateTransferBatchResponse{}, err
+	}
+
+	config.Logger.Info("Transfer batch processed",
+		"transfer_batch_id", result.Batch.ID,
+		"status", result.Batch.Status,
+		"succeeded", result.Batch.SucceededCount,
+		"failed", result.Batch.FailedCount,
+		"total_debited", result.Batch.TotalDebited,
+	)
+
+	return responses.NewCreateTransferBatchResponse(result), nil
+}
+
+// prepareBatchItem validates and prices one transfer of a batch, the recipients already looked up are cached in toAccounts
+func (transferService *TransferService) prepareBatchItem(item requests.MakeTransferRequest, toAccounts map[int64]db.Account) (db.TransferTxParams, error) {
+	err := validateTransferPayload(item)
+	if err != nil {
+		return db.TransferTxParams{}, err
+	}
+
+	toAccount, found := toAccounts[item.ToAccountID]
+	if !found {
+		toAccount, err = transferService.transferRespository.GetAccount(item.ToAccountID)
+		if errors.Is(err, transferErrors.ErrAccountNotFound) {
+			return db.TransferTxParams{}, transferErrors.ErrToAccountNotFound
+		}
+		if err != nil {
+			return db.TransferTxParams{}, err
+		}
+		toAccounts[item.ToAccountID] = toAccount
+	}
+	if toAccount.Currency != item.ToCurrency {
+		config.Logger.Error("To account currency mismatch", "account_currency", toAccount.Currency, "to_currency", item.ToCurrency)
+		return db.TransferTxParams{}, transferErrors.ErrToAccountCurrencyMismatch
+	}
+
+	pricing, err := transferService.priceTransfer(item)
+	if err != nil {
+		return db.TransferTxParams{}, err
+	}
+
+	return db.TransferTxParams{
+		FromAccountID:   item.FromAccountID,
+		ToAccountID:     item.ToAccountID,
+		Amount:          item.Amount,
+		ConvertedAmount: pricing.convertedAmount,
+		ExchangeRate:    pricing.exchangeRate,
+		FromCurrency:    item.FromCurrency,
+		ToCurrency:      item.ToCurrency,
+		Fee:             pricing.fee,
+		FeeScheduleID:   pricing.feeScheduleID,
+		PivotRate:       pricing.pivotRate,
+		MidMarketRate:   pricing.midMarketRate,
+		MarkupBps:       pricing.markupBps,
+		QuoteID:         item.QuoteID,
+		Username:        item.Username,
+	}, nil
+}
+
+// ListTransferBatches returns the user's transfer batches without their items, newest first
+func (transferService *TransferService) ListTransferBatches(username string) ([]responses.TransferBatchResponse, error) {
+	config.Logger.Info("Listing transfer batches", "username", username)
+
+	batches, err := transferService.transferRespository.ListTransferBatches(username)
+	if err != nil {
+		return nil, err
+	}
+
+	return responses.NewTransferBatchResponses(batches), nil
+}
+
+// GetTransferBatch returns one of the user's transfer batches with the outcome of each item,
+// batches of other users are reported as not found
+func (transferService *TransferService) GetTransferBatch(id int64, username string) (responses.TransferBatchResponse, error) {
+	config.Logger.Info("Getting transfer batch", "transfer_batch_id", id, "username", username)
+
+	batch, err := transferService.transferRespository.GetTransferBatch(id)
+	if err != nil {
+		return responses.TransferBatchResponse{}, err
+	}
+
+	if username == "" || batch.Owner != username {
+		config.Logger.Error("Transfer batch does not belong to user", "transfer_batch_id", id, "username", username)
+		return responses.TransferBatchResponse{}, transferErrors.ErrTransferBatchNotFound
+	}
+
+	items, err := transferService.transferRespository.ListTransferBatchItems(id)
+	if err != nil {
+		return responses.TransferBatchResponse{}, err
+	}
+
+	return responses.NewTransferBatchResponse(batch, items), nil
+}
@@ -0,0 +1,186 @@
+package transfers
+
+import (
+	"testing"
+	"time"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	"lemfi/simplebank/internal/apps/core"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateTransferBatchService(t *testing.T) {
+	testCases := []struct {
+		name        string
+		request     requests.CreateTransferBatchRequest
+		buildStubs  func(store *mockdb.MockStore)
+		expectedErr error
+		checkErr    func(t *testing.T, err error)
+	}{
+		{
+			name:    "OK",
+			request: newTransferBatchRequest(db.TransferBatchModeBestEffort, 2, 3, 2),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(db.Account{ID: 1, Owner: "test_owner", Balance: decimal.NewFromInt(1000), Currency: "USD"}, nil).Times(1)
+				// Recipients are looked up once however many items pay them
+				store.EXPECT().GetAccount(gomock.Any(), int64(2)).Return(db.Account{ID: 2, Owner: "other_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(3)).Return(db.Account{ID: 3, Owner: "other_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().GetActiveFeeSchedule(gomock.Any(), gomock.Any()).Return(db.FeeSchedule{}, pgx.ErrNoRows).Times(3)
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.BatchTransferTxParams) (db.BatchTransferTxResult, error) {
+						require.Equal(t, "test_owner", arg.Username)
+						require.Equal(t, int64(1), arg.FromAccountID)
+						require.Equal(t, db.TransferBatchModeBestEffort, arg.Mode)
+						require.Len(t, arg.Items, 3)
+						require.Equal(t, "test_owner", arg.Items[0].Username)
+
+						return db.BatchTransferTxResult{
+							Batch: db.TransferBatch{
+								ID:             7,
+								Owner:          "test_owner",
+								FromAccountID:  1,
+								Mode:           arg.Mode,
+								Status:         db.TransferBatchStatusPartiallyCompleted,
+								ItemCount:      3,
+								SucceededCount: 2,
+								FailedCount:    1,
+								TotalDebited:   decimal.NewFromInt(200),
+								CreatedAt:      time.Now(),
+							},
+							Items: []db.TransferBatchItem{
+								{BatchID: 7, ItemIndex: 0, ToAccountID: 2, Amount: decimal.NewFromInt(100), Status: db.TransferBatchItemStatusCompleted, TransferID: pgtype.Int8{Int64: 10, Valid: true}},
+								{BatchID: 7, ItemIndex: 1, ToAccountID: 3, Amount: decimal.NewFromInt(100), Status: db.TransferBatchItemStatusFailed, Error: pgtype.Text{String: "daily limit", Valid: true}},
+								{BatchID: 7, ItemIndex: 2, ToAccountID: 2, Amount: decimal.NewFromInt(100), Status: db.TransferBatchItemStatusCompleted, TransferID: pgtype.Int8{Int64: 11, Valid: true}},
+							},
+							FromAccount: db.Account{ID: 1, Owner: "test_owner", Balance: decimal.NewFromInt(800), Currency: "USD"},
+						}, nil
+					}).Times(1)
+			},
+		},
+		{
+			name: "MixedSourceAccounts",
+			request: func() requests.CreateTransferBatchRequest {
+				request := newTransferBatchRequest(db.TransferBatchModeBestEffort, 2, 3)
+				request.Items[1].FromAccountID = 4
+				return request
+			}(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: transferErrors.ErrTransferBatchMixedSourceAccounts,
+		},
+		{
+			name:    "NotOwner",
+			request: newTransferBatchRequest(db.TransferBatchModeBestEffort, 2),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(db.Account{ID: 1, Owner: "other_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: transferErrors.ErrAccountForbidden,
+		},
+		{
+			name: "InvalidItems",
+			request: func() requests.CreateTransferBatchRequest {
+				request := newTransferBatchRequest(db.TransferBatchModeBestEffort, 2, 3, 1)
+				request.Items[0].Amount = decimal.NewFromInt(-5)
+				return request
+			}(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(db.Account{ID: 1, Owner: "test_owner", Balance: decimal.NewFromInt(1000), Currency: "USD"}, nil).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(3)).Return(db.Account{ID: 3, Owner: "other_owner", Currency: "USD"}, nil).Times(1)
+				expectNoFeeSchedule(store)
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkErr: func(t *testing.T, err error) {
+				clientErr, isClient := core.IsClientError(err)
+				require.True(t, isClient)
+				require.Contains(t, clientErr.Message, "items[0]: "+transferErrors.ErrInvalidAmount.Message)
+				require.Contains(t, clientErr.Message, "items[2]: "+transferErrors.ErrSameAccountTransfer.Message)
+				require.NotContains(t, clientErr.Message, "items[1]")
+			},
+		},
+		{
+			name:    "AllOrNothingInsufficientBalance",
+			request: newTransferBatchRequest(db.TransferBatchModeAllOrNothing, 2, 3),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(db.Account{ID: 1, Owner: "test_owner", Balance: decimal.NewFromInt(250), HeldAmount: decimal.NewFromInt(100), Currency: "USD"}, nil).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(2)).Return(db.Account{ID: 2, Owner: "other_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(3)).Return(db.Account{ID: 3, Owner: "other_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().GetActiveFeeSchedule(gomock.Any(), gomock.Any()).Return(db.FeeSchedule{}, pgx.ErrNoRows).Times(2)
+				store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: transferErrors.ErrTransferBatchInsufficientBalance,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			response, err := newMockTransferService(store).CreateTransferBatch(tc.request)
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			if tc.checkErr != nil {
+				tc.checkErr(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, int64(7), response.Batch.ID)
+			require.Equal(t, db.TransferBatchStatusPartiallyCompleted, response.Batch.Status)
+			require.Len(t, response.Batch.Items, 3)
+			require.Equal(t, int64(10), *response.Batch.Items[0].TransferID)
+			require.Nil(t, response.Batch.Items[1].TransferID)
+			require.Equal(t, "daily limit", response.Batch.Items[1].Error)
+			require.True(t, decimal.NewFromInt(800).Equal(response.FromAccount.Balance))
+		})
+	}
+}
+
+func TestCreateTransferBatchService_StepUpOnTotal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(db.Account{ID: 1, Owner: "test_owner", Balance: decimal.NewFromInt(1000), Currency: "USD"}, nil).Times(1)
+	store.EXPECT().GetUserMfa(gomock.Any(), "test_owner").
+		Return(db.UserMfa{Username: "test_owner", EnabledAt: pgtype.Timestamptz{Time: time.Now(), Valid: true}}, nil).Times(1)
+	store.EXPECT().BatchTransferTx(gomock.Any(), gomock.Any()).Times(0)
+
+	transferService := newMockTransferService(store)
+	transferService.stepUpThresholds = map[string]decimal.Decimal{"USD": decimal.NewFromInt(150)}
+
+	// Each item is under the threshold but the batch is not
+	_, err := transferService.CreateTransferBatch(newTransferBatchRequest(db.TransferBatchModeBestEffort, 2, 3))
+	require.ErrorIs(t, err, transferErrors.ErrStepUpTOTPCodeRequired)
+}
+
+func TestGetTransferBatchService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	store.EXPECT().GetTransferBatch(gomock.Any(), int64(7)).Return(db.TransferBatch{ID: 7, Owner: "other_owner"}, nil).Times(1)
+	store.EXPECT().ListTransferBatchItems(gomock.Any(), gomock.Any()).Times(0)
+
+	_, err := newMockTransferService(store).GetTransferBatch(7, "test_owner")
+
+	require.ErrorIs(t, err, transferErrors.ErrTransferBatchNotFound)
+}
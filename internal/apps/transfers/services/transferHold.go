@@ -0,0 +1,223 @@
+package transfers
+
+import (
+	"errors"
+	"time"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+	responses "lemfi/simplebank/internal/apps/transfers/responses"
+)
+
+// transferHoldErrors maps the errors of the hold transactions to the errors returned to the user
+var transferHoldErrors = map[error]error{
+	db.ErrInsufficientFunds:         transferErrors.ErrInsufficientBalance,
+	db.ErrTransferHoldNotAuthorized: transferErrors.ErrTransferHoldNotAuthorized,
+	db.ErrTransferHoldExpired:       transferErrors.ErrTransferHoldExpired,
+}
+
+// transferHoldError returns the error for the user of a failed hold transaction, nil when it is not a client error
+func transferHoldError(err error) error {
+	for holdErr, clientErr := range transferHoldErrors {
+		if errors.Is(err, holdErr) {
+			return clientErr
+		}
+	}
+	return transferLimitError(err)
+}
+
+// AuthorizeTransferHold prices a transfer and holds its amount and fee on the source account.
+// The available balance of the account goes down but its balance does not change until the hold is captured
+func (transferService *TransferService) AuthorizeTransferHold(payload requests.AuthorizeTransferHoldRequest) (responses.AuthorizeTransferHoldResponse, error) {
+	config.Logger.Info("Processing transfer hold request",
+		"from_account_id", payload.FromAccountID,
+		"to_account_id", payload.ToAccountID,
+		"amount", payload.Amount,
+		"from_currency", payload.FromCurrency,
+		"to_currency", payload.ToCurrency,
+	)
+
+	// A hold is validated and priced like the transfer it makes when captured
+	transferPayload := requests.MakeTransferRequest{
+		FromAccountID: payload.FromAccountID,
+		ToAccountID:   payload.ToAccountID,
+		Amount:        payload.Amount,
+		FromCurrency:  payload.FromCurrency,
+		ToCurrency:    payload.ToCurrency,
+		ExchangeRate:  payload.ExchangeRate,
+		Username:      payload.Username,
+	}
+
+	fromAccount, err := transferService.validateTransfer(transferPayload)
+	if err != nil {
+		return responses.AuthorizeTransferHoldResponse{}, err
+	}
+	if fromAccount.Currency != payload.FromCurrency {
+		config.Logger.Error("From account currency mismatch", "account_currency", fromAccount.Currency, "from_currency", payload.FromCurrency)
+		return responses.AuthorizeTransferHoldResponse{}, transferErrors.ErrFromAccountCurrencyMismatch
+	}
+
+	// The transfer is made without the user when the hold is captured, so it is stepped up now
+	err = transferService.authorizeStepUp(payload.Username, payload.Amount, payload.FromCurrency, payload.TOTPCode)
+	if err != nil {
+		return responses.AuthorizeTransferHoldResponse{}, err
+	}
+
+	toAccount, err := transferService.transferRespository.GetAccount(payload.ToAccountID)
+	if errors.Is(err, transferErrors.ErrAccountNotFound) {
+		return responses.AuthorizeTransferHoldResponse{}, transferErrors.ErrToAccountNotFound
+	}
+	if err != nil {
+		return responses.AuthorizeTransferHoldResponse{}, err
+	}
+	if toAccount.Currency != payload.ToCurrency {
+		config.Logger.Error("To account currency mismatch", "account_currency", toAccount.Currency, "to_currency", payload.ToCurrency)
+		return responses.AuthorizeTransferHoldResponse{}, transferErrors.ErrToAccountCurrencyMismatch
+	}
+
+	pricing, err := transferService.priceTransfer(transferPayload)
+	if err != nil {
+		return responses.AuthorizeTransferHoldResponse{}, err
+	}
+
+	result, err := transferService.transferRespository.AuthorizeTransferHold(db.AuthorizeTransferTxParams{
+		Username:        payload.Username,
+		FromAccountID:   payload.FromAccountID,
+		ToAccountID:     payload.ToAccountID,
+		Amount:          payload.Amount,
+		ConvertedAmount: pricing.convertedAmount,
+		ExchangeRate:    pricing.exchangeRate,
+		FromCurrency:    payload.FromCurrency,
+		ToCurrency:      payload.ToCurrency,
+		Fee:             pricing.fee,
+		FeeScheduleID:   pricing.feeScheduleID,
+		PivotRate:       pricing.pivotRate,
+		MidMarketRate:   pricing.midMarketRate,
+		MarkupBps:       pricing.markupBps,
+		ExpiresAt:       time.Now().Add(transferService.holdExpiry),
+	})
+	if clientErr := transferHoldError(err); clientErr != nil {
+		config.Logger.Error("Transfer hold refused", "error", err.Error(), "username", payload.Username, "from_account_id", payload.FromAccountID)
+		return responses.AuthorizeTransferHoldResponse{}, clientErr
+	}
+	if err != nil {
+		return responses.AuthorizeTransferHoldResponse{}, err
+	}
+
+	config.Logger.Info("Transfer hold authorized",
+		"transfer_hold_id", result.Hold.ID,
+		"from_account_id", result.Hold.FromAccountID,
+		"amount", result.Hold.Amount,
+		"fee", result.Hold.Fee,
+		"expires_at", result.Hold.ExpiresAt,
+	)
+
+	return responses.NewAuthorizeTransferHoldResponse(result), nil
+}
+
+// ListTransferHolds returns the user's transfer holds, newest first
+func (transferService *TransferService) ListTransferHolds(username string) ([]responses.TransferHoldResponse, error) {
+	config.Logger.Info("Listing transfer holds", "username", username)
+
+	holds, err := transferService.transferRespository.ListTransferHolds(username)
+	if err != nil {
+		return nil, err
+	}
+
+	return responses.NewTransferHoldResponses(holds), nil
+}
+
+// GetTransferHold returns one of the user's transfer holds
+func (transferService *TransferService) GetTransferHold(id int64, username string) (responses.TransferHoldResponse, error) {
+	config.Logger.Info("Getting transfer hold", "transfer_hold_id", id, "username", username)
+
+	hold, err := transferService.authorizeTransferHoldOwner(id, username)
+	if err != nil {
+		return responses.TransferHoldResponse{}, err
+	}
+
+	return responses.NewTransferHoldResponse(hold), nil
+}
+
+// CaptureTransferHold makes the transfer of an authorized hold at the pricing it was authorized with
+func (transferService *TransferService) CaptureTransferHold(id int64, username string) (responses.CaptureTransferHoldResponse, error) {
+	config.Logger.Info("Capturing transfer hold", "transfer_hold_id", id, "username", username)
+
+	_, err := transferService.authorizeTransferHoldOwner(id, username)
+	if err != nil {
+		return responses.CaptureTransferHoldResponse{}, err
+	}
+
+	result, err := transferService.transferRespository.CaptureTransferHold(id)
+	if clientErr := transferHoldError(err); clientErr != nil {
+		config.Logger.Error("Transfer hold capture refused", "error", err.Error(), "transfer_hold_id", id)
+		return responses.CaptureTransferHoldResponse{}, clientErr
+	}
+	if err != nil {
+		return responses.CaptureTransferHoldResponse{}, err
+	}
+
+	config.Logger.Info("Transfer hold captured",
+		"transfer_hold_id", result.Hold.ID,
+		"transfer_id", result.Transfer.Transfer.ID,
+		"from_balance", result.Transfer.FromAccount.Balance,
+		"to_balance", result.Transfer.ToAccount.Balance,
+	)
+
+	return responses.NewCaptureTransferHoldResponse(result), nil
+}
+
+// VoidTransferHold releases an authorized hold without making its transfer
+func (transferService *TransferService) VoidTransferHold(id int64, username string) (responses.TransferHoldResponse, error) {
+	config.Logger.Info("Voiding transfer hold", "transfer_hold_id", id, "username", username)
+
+	_, err := transferService.authorizeTransferHoldOwner(id, username)
+	if err != nil {
+		return responses.TransferHoldResponse{}, err
+	}
+
+	hold, err := transferService.transferRespository.VoidTransferHold(id)
+	if clientErr := transferHoldError(err); clientErr != nil {
+		config.Logger.Error("Transfer hold void refused", "error", err.Error(), "transfer_hold_id", id)
+		return responses.TransferHoldResponse{}, clientErr
+	}
+	if err != nil {
+		return responses.TransferHoldResponse{}, err
+	}
+
+	config.Logger.Info("Transfer hold voided", "transfer_hold_id", hold.ID)
+
+	return responses.NewTransferHoldResponse(hold), nil
+}
+
+// ExpireTransferHolds releases a batch of authorized holds that are past their expiry, returning how many were released
+func (transferService *TransferService) ExpireTransferHolds(batchSize int32) (int, error) {
+	expired, err := transferService.transferRespository.ExpireTransferHolds(batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, hold := range expired {
+		config.Logger.Info("Transfer hold expired", "transfer_hold_id", hold.ID, "from_account_id", hold.FromAccountID, "expires_at", hold.ExpiresAt)
+	}
+
+	return len(expired), nil
+}
+
+// authorizeTransferHoldOwner loads a transfer hold and checks that it belongs to the authenticated user,
+// holds of other users are reported as not found
+func (transferService *TransferService) authorizeTransferHoldOwner(id int64, username string) (db.TransferHold, error) {
+	hold, err := transferService.transferRespository.GetTransferHold(id)
+	if err != nil {
+		return db.TransferHold{}, err
+	}
+
+	if username == "" || hold.Owner != username {
+		config.Logger.Error("Transfer hold does not belong to user", "transfer_hold_id", id, "username", username)
+		return db.TransferHold{}, transferErrors.ErrTransferHoldNotFound
+	}
+
+	return hold, nil
+}
@@ -0,0 +1,72 @@
+package transfers
+
+import (
+	"context"
+	"time"
+
+	"lemfi/simplebank/config"
+)
+
+// TransferHoldWorker releases authorized transfer holds that were not captured or voided before they expired
+type TransferHoldWorker struct {
+	transferService *TransferService
+	pollInterval    time.Duration
+	batchSize       int32
+}
+
+func NewTransferHoldWorker(transferService *TransferService) *TransferHoldWorker {
+	cfg := config.Get()
+	return &TransferHoldWorker{
+		transferService: transferService,
+		pollInterval:    cfg.TransferHolds.PollInterval,
+		batchSize:       int32(cfg.TransferHolds.BatchSize),
+	}
+}
+
+// Start releases expired holds every poll interval until the context is cancelled
+func (worker *TransferHoldWorker) Start(ctx context.Context) {
+	if worker.pollInterval <= 0 {
+		config.Logger.Info("Transfer hold worker disabled")
+		return
+	}
+
+	config.Logger.Info("Transfer hold worker started", "poll_interval", worker.pollInterval, "batch_size", worker.batchSize)
+
+	ticker := time.NewTicker(worker.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		worker.ExpireDue()
+
+		select {
+		case <-ctx.Done():
+			config.Logger.Info("Transfer hold worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ExpireDue releases expired holds in batches until none are left, returning how many were released.
+// Holds locked by a capture or void in progress are skipped, so several instances can poll the same database
+func (worker *TransferHoldWorker) ExpireDue() (int, error) {
+	released := 0
+	for {
+		expired, err := worker.transferService.ExpireTransferHolds(worker.batchSize)
+		if err != nil {
+			config.Logger.Error("Failed to expire transfer holds", "error", err.Error())
+			return released, err
+		}
+
+		released += expired
+		if expired == 0 || expired < int(worker.batchSize) {
+			break
+		}
+	}
+
+	if released > 0 {
+		config.Logger.Info("Released expired transfer holds", "released", released)
+	}
+
+	return released, nil
+}
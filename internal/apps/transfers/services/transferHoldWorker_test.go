@@ -0,0 +1,83 @@
+package transfers
+
+import (
+	"errors"
+	"testing"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTransferHoldWorker_ExpireDue(t *testing.T) {
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, released int, err error)
+	}{
+		{
+			name: "NothingToExpire",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExpireTransferHoldsTx(gomock.Any(), int32(2)).Return([]db.TransferHold{}, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, released int, err error) {
+				require.NoError(t, err)
+				require.Zero(t, released)
+			},
+		},
+		{
+			name: "PartialBatch",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExpireTransferHoldsTx(gomock.Any(), int32(2)).Return(newExpiredTransferHolds(1), nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, released int, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 1, released)
+			},
+		},
+		{
+			name: "FullBatchesUntilDrained",
+			buildStubs: func(store *mockdb.MockStore) {
+				gomock.InOrder(
+					store.EXPECT().ExpireTransferHoldsTx(gomock.Any(), int32(2)).Return(newExpiredTransferHolds(2), nil),
+					store.EXPECT().ExpireTransferHoldsTx(gomock.Any(), int32(2)).Return(newExpiredTransferHolds(2), nil),
+					store.EXPECT().ExpireTransferHoldsTx(gomock.Any(), int32(2)).Return([]db.TransferHold{}, nil),
+				)
+			},
+			checkResponse: func(t *testing.T, released int, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 4, released)
+			},
+		},
+		{
+			name: "ErrorAfterABatch",
+			buildStubs: func(store *mockdb.MockStore) {
+				gomock.InOrder(
+					store.EXPECT().ExpireTransferHoldsTx(gomock.Any(), int32(2)).Return(newExpiredTransferHolds(2), nil),
+					store.EXPECT().ExpireTransferHoldsTx(gomock.Any(), int32(2)).Return(nil, errors.New("deadlock detected")),
+				)
+			},
+			checkResponse: func(t *testing.T, released int, err error) {
+				require.Error(t, err)
+				require.Equal(t, 2, released)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			worker := &TransferHoldWorker{transferService: newMockTransferService(store), batchSize: 2}
+			released, err := worker.ExpireDue()
+
+			tc.checkResponse(t, released, err)
+		})
+	}
+}
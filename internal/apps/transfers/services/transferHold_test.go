@@ -0,0 +1,200 @@
+package transfers
+
+import (
+	"fmt"
+	"testing"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthorizeTransferHoldService(t *testing.T) {
+	testCases := []struct {
+		name        string
+		buildStubs  func(store *mockdb.MockStore)
+		expectedErr error
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(db.Account{ID: 1, Owner: "test_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(2)).Return(db.Account{ID: 2, Owner: "other_owner", Currency: "USD"}, nil).Times(1)
+				expectNoFeeSchedule(store)
+				store.EXPECT().AuthorizeTransferTx(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, arg db.AuthorizeTransferTxParams) (db.AuthorizeTransferTxResult, error) {
+						require.Equal(t, "test_owner", arg.Username)
+						require.True(t, decimal.NewFromInt(100).Equal(arg.ConvertedAmount))
+						require.True(t, arg.Fee.IsZero())
+
+						return db.AuthorizeTransferTxResult{
+							Hold: newTransferHold(),
+							FromAccount: db.Account{
+								ID:         1,
+								Owner:      "test_owner",
+								Balance:    decimal.NewFromInt(1000),
+								HeldAmount: decimal.NewFromInt(100),
+								Currency:   "USD",
+							},
+						}, nil
+					}).Times(1)
+			},
+		},
+		{
+			name: "InsufficientFunds",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(db.Account{ID: 1, Owner: "test_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(2)).Return(db.Account{ID: 2, Owner: "other_owner", Currency: "USD"}, nil).Times(1)
+				expectNoFeeSchedule(store)
+				store.EXPECT().AuthorizeTransferTx(gomock.Any(), gomock.Any()).
+					Return(db.AuthorizeTransferTxResult{}, fmt.Errorf("%w: account has 50 available", db.ErrInsufficientFunds)).Times(1)
+			},
+			expectedErr: transferErrors.ErrInsufficientBalance,
+		},
+		{
+			name: "TransferLimitExceeded",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(db.Account{ID: 1, Owner: "test_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(2)).Return(db.Account{ID: 2, Owner: "other_owner", Currency: "USD"}, nil).Times(1)
+				expectNoFeeSchedule(store)
+				store.EXPECT().AuthorizeTransferTx(gomock.Any(), gomock.Any()).
+					Return(db.AuthorizeTransferTxResult{}, db.ErrDailyAmountLimitExceeded).Times(1)
+			},
+			expectedErr: transferErrors.ErrDailyAmountLimitExceeded,
+		},
+		{
+			name: "NotOwner",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(db.Account{ID: 1, Owner: "other_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().AuthorizeTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: transferErrors.ErrAccountForbidden,
+		},
+		{
+			name: "ToAccountCurrencyMismatch",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(db.Account{ID: 1, Owner: "test_owner", Currency: "USD"}, nil).Times(1)
+				store.EXPECT().GetAccount(gomock.Any(), int64(2)).Return(db.Account{ID: 2, Owner: "other_owner", Currency: "EUR"}, nil).Times(1)
+				store.EXPECT().AuthorizeTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: transferErrors.ErrToAccountCurrencyMismatch,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			response, err := newMockTransferService(store).AuthorizeTransferHold(newAuthorizeTransferHoldRequest())
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, int64(5), response.Hold.ID)
+			require.Equal(t, db.TransferHoldStatusAuthorized, response.Hold.Status)
+			require.True(t, decimal.NewFromInt(1000).Equal(response.FromAccount.Balance))
+			require.True(t, decimal.NewFromInt(900).Equal(response.FromAccount.AvailableBalance))
+		})
+	}
+}
+
+func TestCaptureTransferHoldService(t *testing.T) {
+	testCases := []struct {
+		name        string
+		buildStubs  func(store *mockdb.MockStore)
+		expectedErr error
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferHold(gomock.Any(), int64(5)).Return(newTransferHold(), nil).Times(1)
+
+				captured := newTransferHold()
+				captured.Status = db.TransferHoldStatusCaptured
+				captured.TransferID = pgtype.Int8{Int64: 10, Valid: true}
+				store.EXPECT().CaptureTransferHoldTx(gomock.Any(), int64(5)).Return(db.CaptureTransferHoldTxResult{
+					Hold:     captured,
+					Transfer: newTransferTxResult(),
+				}, nil).Times(1)
+			},
+		},
+		{
+			name: "OtherOwner",
+			buildStubs: func(store *mockdb.MockStore) {
+				hold := newTransferHold()
+				hold.Owner = "other_owner"
+				store.EXPECT().GetTransferHold(gomock.Any(), int64(5)).Return(hold, nil).Times(1)
+				store.EXPECT().CaptureTransferHoldTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: transferErrors.ErrTransferHoldNotFound,
+		},
+		{
+			name: "Expired",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferHold(gomock.Any(), int64(5)).Return(newTransferHold(), nil).Times(1)
+				store.EXPECT().CaptureTransferHoldTx(gomock.Any(), int64(5)).Return(db.CaptureTransferHoldTxResult{}, db.ErrTransferHoldExpired).Times(1)
+			},
+			expectedErr: transferErrors.ErrTransferHoldExpired,
+		},
+		{
+			name: "AlreadyVoided",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferHold(gomock.Any(), int64(5)).Return(newTransferHold(), nil).Times(1)
+				store.EXPECT().CaptureTransferHoldTx(gomock.Any(), int64(5)).Return(db.CaptureTransferHoldTxResult{}, db.ErrTransferHoldNotAuthorized).Times(1)
+			},
+			expectedErr: transferErrors.ErrTransferHoldNotAuthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			response, err := newMockTransferService(store).CaptureTransferHold(5, "test_owner")
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, db.TransferHoldStatusCaptured, response.Hold.Status)
+			require.Equal(t, int64(10), *response.Hold.TransferID)
+			require.Equal(t, int64(10), response.Transfer.ID)
+		})
+	}
+}
+
+func TestVoidTransferHoldService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+
+	voided := newTransferHold()
+	voided.Status = db.TransferHoldStatusVoided
+	store.EXPECT().GetTransferHold(gomock.Any(), int64(5)).Return(newTransferHold(), nil).Times(1)
+	store.EXPECT().VoidTransferHoldTx(gomock.Any(), int64(5)).Return(voided, nil).Times(1)
+
+	response, err := newMockTransferService(store).VoidTransferHold(5, "test_owner")
+
+	require.NoError(t, err)
+	require.Equal(t, db.TransferHoldStatusVoided, response.Status)
+	require.Nil(t, response.TransferID)
+}
@@ -0,0 +1,161 @@
+package transfers
+
+import (
+	"errors"
+	"time"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	"lemfi/simplebank/internal/apps/currencies"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+	responses "lemfi/simplebank/internal/apps/transfers/responses"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// transferLimitErrors maps the limit errors of the transfer transaction to the errors returned to the user
+var transferLimitErrors = map[error]error{
+	db.ErrPerTransactionLimitExceeded: transferErrors.ErrPerTransactionLimitExceeded,
+	db.ErrDailyAmountLimitExceeded:    transferErrors.ErrDailyAmountLimitExceeded,
+	db.ErrDailyCountLimitExceeded:     transferErrors.ErrDailyCountLimitExceeded,
+	db.ErrMonthlyAmountLimitExceeded:  transferErrors.ErrMonthlyAmountLimitExceeded,
+	db.ErrMonthlyCountLimitExceeded:   transferErrors.ErrMonthlyCountLimitExceeded,
+}
+
+// transferLimitError returns the client error for a transfer rejected by a limit, or nil for any other error
+func transferLimitError(err error) error {
+	for limitErr, clientErr := range transferLimitErrors {
+		if errors.Is(err, limitErr) {
+			return clientErr
+		}
+	}
+	return nil
+}
+
+// GetUserTransferLimits returns the limits of a user in every supported currency with what they have used of them (admin only)
+func (transferService *TransferService) GetUserTransferLimits(username string) (responses.UserTransferLimitsResponse, error) {
+	config.Logger.Info("Getting user transfer limits", "username", username)
+
+	response := responses.UserTransferLimitsResponse{
+		Username: username,
+		Limits:   []responses.TransferLimitResponse{},
+	}
+
+	now := time.Now()
+	for _, currency := range currencies.SupportedCurrencies() {
+		limits, err := transferService.transferRespository.GetTransferLimits(username, string(currency))
+		if err != nil {
+			return responses.UserTransferLimitsResponse{}, err
+		}
+
+		usage, err := transferService.transferRespository.GetTransferUsage(username, string(currency), now)
+		if err != nil {
+			return responses.UserTransferLimitsResponse{}, err
+		}
+
+		response.Tier = limits.Tier
+		response.Limits = append(response.Limits, responses.NewTransferLimitResponse(limits, usage))
+	}
+
+	return response, nil
+}
+
+// SetUserTransferLimit overrides the limits of the user's tier in a currency (admin only)
+func (transferService *TransferService) SetUserTransferLimit(payload requests.SetTransferLimitRequest) (responses.TransferLimitResponse, error) {
+	config.Logger.Info("Setting user transfer limit", "username", payload.Username, "currency", payload.Currency)
+
+	if !currencies.IsSupportedCurrency(currencies.Currency(payload.Currency)) {
+		config.Logger.Error("Transfer limit currency is not supported", "currency", payload.Currency)
+		return responses.TransferLimitResponse{}, currencies.ErrCurrencyNotSupported
+	}
+
+	perTransactionAmount, err := transferLimitAmount(payload.PerTransactionAmount, payload.Currency)
+	if err != nil {
+		return responses.TransferLimitResponse{}, err
+	}
+	dailyAmount, err := transferLimitAmount(payload.DailyAmount, payload.Currency)
+	if err != nil {
+		return responses.TransferLimitResponse{}, err
+	}
+	monthlyAmount, err := transferLimitAmount(payload.MonthlyAmount, payload.Currency)
+	if err != nil {
+		return responses.TransferLimitResponse{}, err
+	}
+
+	// The user must exist before an override can be stored for them
+	_, err = transferService.transferRespository.GetTransferLimits(payload.Username, payload.Currency)
+	if err != nil {
+		return responses.TransferLimitResponse{}, err
+	}
+
+	_, err = transferService.transferRespository.UpsertUserTransferLimit(db.UpsertUserTransferLimitParams{
+		Username:             payload.Username,
+		Currency:             payload.Currency,
+		PerTransactionAmount: perTransactionAmount,
+		DailyAmount:          dailyAmount,
+		DailyCount:           transferLimitCount(payload.DailyCount),
+		MonthlyAmount:        monthlyAmount,
+		MonthlyCount:         transferLimitCount(payload.MonthlyCount),
+	})
+	if err != nil {
+		return responses.TransferLimitResponse{}, err
+	}
+
+	config.Logger.Info("User transfer limit set", "username", payload.Username, "currency", payload.Currency)
+
+	return transferService.transferLimit(payload.Username, payload.Currency, time.Now())
+}
+
+// DeleteUserTransferLimit removes the override of a user in a currency so the limits of their tier apply again (admin only)
+func (transferService *TransferService) DeleteUserTransferLimit(username string, currency string) (responses.TransferLimitResponse, error) {
+	config.Logger.Info("Deleting user transfer limit", "username", username, "currency", currency)
+
+	err := transferService.transferRespository.DeleteUserTransferLimit(username, currency)
+	if err != nil {
+		return responses.TransferLimitResponse{}, err
+	}
+
+	return transferService.transferLimit(username, currency, time.Now())
+}
+
+func (transferService *TransferService) transferLimit(username string, currency string, now time.Time) (responses.TransferLimitResponse, error) {
+	limits, err := transferService.transferRespository.GetTransferLimits(username, currency)
+	if err != nil {
+		return responses.TransferLimitResponse{}, err
+	}
+
+	usage, err := transferService.transferRespository.GetTransferUsage(username, currency, now)
+	if err != nil {
+		return responses.TransferLimitResponse{}, err
+	}
+
+	return responses.NewTransferLimitResponse(limits, usage), nil
+}
+
+// transferLimitAmount validates an amount limit, nil means the limit is not enforced
+func transferLimitAmount(amount *decimal.Decimal, currency string) (decimal.NullDecimal, error) {
+	if amount == nil {
+		return decimal.NullDecimal{}, nil
+	}
+
+	if !amount.IsPositive() {
+		config.Logger.Error("Invalid transfer limit amount", "amount", amount)
+		return decimal.NullDecimal{}, transferErrors.ErrInvalidTransferLimit
+	}
+
+	if !currencies.HasValidPrecision(*amount, currencies.Currency(currency)) {
+		config.Logger.Error("Transfer limit amount has too many decimal places", "amount", amount, "currency", currency)
+		return decimal.NullDecimal{}, transferErrors.ErrTransferLimitPrecision
+	}
+
+	return decimal.NewNullDecimal(*amount), nil
+}
+
+func transferLimitCount(count *int32) pgtype.Int4 {
+	if count == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: *count, Valid: true}
+}
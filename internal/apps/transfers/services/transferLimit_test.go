@@ -0,0 +1,233 @@
+package transfers
+
+import (
+	"testing"
+
+	mockdb "lemfi/simplebank/db/mock"
+	db "lemfi/simplebank/db/sqlc"
+	"lemfi/simplebank/internal/apps/currencies"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// expectTransferLimits stubs a user on the standard tier without overrides and without transfers
+func expectTransferLimits(store *mockdb.MockStore, times int) {
+	store.EXPECT().GetUserTier(gomock.Any(), "test_owner").Return("standard", nil).Times(times)
+	store.EXPECT().GetUserTransferLimit(gomock.Any(), gomock.Any()).Return(db.UserTransferLimit{}, pgx.ErrNoRows).Times(times)
+	store.EXPECT().GetTierTransferLimit(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ any, arg db.GetTierTransferLimitParams) (db.TransferLimit, error) {
+			return newTierTransferLimit(arg.Currency), nil
+		}).Times(times)
+}
+
+func TestMakeTransferService_TransferLimitExceeded(t *testing.T) {
+	testCases := []struct {
+		name        string
+		txErr       error
+		expectedErr error
+	}{
+		{
+			name:        "PerTransaction",
+			txErr:       db.ErrPerTransactionLimitExceeded,
+			expectedErr: transferErrors.ErrPerTransactionLimitExceeded,
+		},
+		{
+			name:        "DailyAmount",
+			txErr:       db.ErrDailyAmountLimitExceeded,
+			expectedErr: transferErrors.ErrDailyAmountLimitExceeded,
+		},
+		{
+			name:        "DailyCount",
+			txErr:       db.ErrDailyCountLimitExceeded,
+			expectedErr: transferErrors.ErrDailyCountLimitExceeded,
+		},
+		{
+			name:        "MonthlyAmount",
+			txErr:       db.ErrMonthlyAmountLimitExceeded,
+			expectedErr: transferErrors.ErrMonthlyAmountLimitExceeded,
+		},
+		{
+			name:        "MonthlyCount",
+			txErr:       db.ErrMonthlyCountLimitExceeded,
+			expectedErr: transferErrors.ErrMonthlyCountLimitExceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().GetAccount(gomock.Any(), int64(1)).Return(newTransferTxResult().FromAccount, nil).Times(1)
+			expectNoFeeSchedule(store)
+			store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Return(db.TransferTxResult{}, tc.txErr).Times(1)
+
+			request := newTransferRequest()
+			request.IdempotencyKey = ""
+
+			_, err := newMockTransferService(store).MakeTransfer(request)
+
+			require.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestGetUserTransferLimitsService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	supported := currencies.SupportedCurrencies()
+	expectTransferLimits(store, len(supported))
+	store.EXPECT().GetTransferUsage(gomock.Any(), gomock.Any()).Return(db.GetTransferUsageRow{}, nil).Times(len(supported))
+
+	response, err := newMockTransferService(store).GetUserTransferLimits("test_owner")
+
+	require.NoError(t, err)
+	require.Equal(t, "standard", response.Tier)
+	require.Len(t, response.Limits, len(supported))
+	for _, limit := range response.Limits {
+		require.Equal(t, db.TransferLimitSourceTier, limit.Source)
+		require.NotNil(t, limit.PerTransactionAmount)
+		require.True(t, decimal.NewFromInt(5000).Equal(*limit.PerTransactionAmount))
+		require.Nil(t, limit.MonthlyAmount)
+		require.Equal(t, int64(0), limit.Usage.DailyCount)
+	}
+}
+
+func TestGetUserTransferLimitsService_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUserTier(gomock.Any(), "missing").Return("", pgx.ErrNoRows).Times(1)
+
+	_, err := newMockTransferService(store).GetUserTransferLimits("missing")
+
+	require.ErrorIs(t, err, transferErrors.ErrUserNotFound)
+}
+
+func TestSetUserTransferLimitService(t *testing.T) {
+	dailyCount := int32(3)
+	dailyAmount := decimal.NewFromInt(250)
+
+	testCases := []struct {
+		name          string
+		request       requests.SetTransferLimitRequest
+		buildStubs    func(store *mockdb.MockStore)
+		expectedErr   error
+		checkResponse func(t *testing.T, source string)
+	}{
+		{
+			name: "OK",
+			request: requests.SetTransferLimitRequest{
+				DailyAmount: &dailyAmount,
+				DailyCount:  &dailyCount,
+				Username:    "test_owner",
+				Currency:    "USD",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				expectTransferLimits(store, 1)
+				store.EXPECT().UpsertUserTransferLimit(gomock.Any(), db.UpsertUserTransferLimitParams{
+					Username:    "test_owner",
+					Currency:    "USD",
+					DailyAmount: decimal.NewNullDecimal(dailyAmount),
+					DailyCount:  pgtype.Int4{Int32: 3, Valid: true},
+				}).Return(db.UserTransferLimit{}, nil).Times(1)
+
+				// The response shows the override that was stored
+				store.EXPECT().GetUserTier(gomock.Any(), "test_owner").Return("standard", nil).Times(1)
+				store.EXPECT().GetUserTransferLimit(gomock.Any(), gomock.Any()).Return(db.UserTransferLimit{
+					Username:    "test_owner",
+					Currency:    "USD",
+					DailyAmount: decimal.NewNullDecimal(dailyAmount),
+					DailyCount:  pgtype.Int4{Int32: 3, Valid: true},
+				}, nil).Times(1)
+				store.EXPECT().GetTransferUsage(gomock.Any(), gomock.Any()).Return(db.GetTransferUsageRow{}, nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, source string) {
+				require.Equal(t, db.TransferLimitSourceOverride, source)
+			},
+		},
+		{
+			name: "NonPositiveAmount",
+			request: requests.SetTransferLimitRequest{
+				DailyAmount: func() *decimal.Decimal { amount := decimal.Zero; return &amount }(),
+				Username:    "test_owner",
+				Currency:    "USD",
+			},
+			buildStubs:  func(store *mockdb.MockStore) {},
+			expectedErr: transferErrors.ErrInvalidTransferLimit,
+		},
+		{
+			name: "AmountPrecision",
+			request: requests.SetTransferLimitRequest{
+				PerTransactionAmount: func() *decimal.Decimal { amount := decimal.RequireFromString("10.005"); return &amount }(),
+				Username:             "test_owner",
+				Currency:             "USD",
+			},
+			buildStubs:  func(store *mockdb.MockStore) {},
+			expectedErr: transferErrors.ErrTransferLimitPrecision,
+		},
+		{
+			name: "UnsupportedCurrency",
+			request: requests.SetTransferLimitRequest{
+				Username: "test_owner",
+				Currency: "XYZ",
+			},
+			buildStubs:  func(store *mockdb.MockStore) {},
+			expectedErr: currencies.ErrCurrencyNotSupported,
+		},
+		{
+			name: "UserNotFound",
+			request: requests.SetTransferLimitRequest{
+				Username: "missing",
+				Currency: "USD",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserTier(gomock.Any(), "missing").Return("", pgx.ErrNoRows).Times(1)
+				store.EXPECT().UpsertUserTransferLimit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expectedErr: transferErrors.ErrUserNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			response, err := newMockTransferService(store).SetUserTransferLimit(tc.request)
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			tc.checkResponse(t, response.Source)
+		})
+	}
+}
+
+func TestDeleteUserTransferLimitService_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().DeleteUserTransferLimit(gomock.Any(), gomock.Any()).Return(int64(0), nil).Times(1)
+
+	_, err := newMockTransferService(store).DeleteUserTransferLimit("test_owner", "USD")
+
+	require.ErrorIs(t, err, transferErrors.ErrTransferLimitOverrideNotFound)
+}
@@ -0,0 +1,265 @@
+package transfers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	db "lemfi/simplebank/db/sqlc"
+	exchangeRateErrors "lemfi/simplebank/internal/apps/exchangeRates/errors"
+	transferErrors "lemfi/simplebank/internal/apps/transfers/errors"
+	requests "lemfi/simplebank/internal/apps/transfers/requests"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// MockTransferRepository implements TransferRespositoryInterface for testing
+type MockTransferRepository struct {
+	store db.Store
+}
+
+func (m *MockTransferRepository) MakeTransfer(payload requests.MakeTransferRequest, convertedAmount decimal.Decimal, exchangeRate decimal.Decimal, fee decimal.Decimal, feeScheduleID int64, pivotRate *db.PivotRate, midMarketRate decimal.Decimal, markupBps int32) (db.TransferTxResult, error) {
+	return m.store.TransferTx(context.Background(), db.TransferTxParams{
+		FromAccountID:   payload.FromAccountID,
+		ToAccountID:     payload.ToAccountID,
+		Amount:          payload.Amount,
+		ConvertedAmount: convertedAmount,
+		ExchangeRate:    exchangeRate,
+		FromCurrency:    payload.FromCurrency,
+		ToCurrency:      payload.ToCurrency,
+		Fee:             fee,
+		FeeScheduleID:   feeScheduleID,
+		PivotRate:       pivotRate,
+		MidMarketRate:   midMarketRate,
+		MarkupBps:       markupBps,
+		QuoteID:         payload.QuoteID,
+		Username:        payload.Username,
+		IdempotencyKey:  payload.IdempotencyKey,
+		RequestHash:     payload.RequestHash,
+	})
+}
+
+func (m *MockTransferRepository) GetIdempotencyKey(username string, idempotencyKey string) (db.IdempotencyKey, bool, error) {
+	storedKey, err := m.store.GetIdempotencyKey(context.Background(), db.GetIdempotencyKeyParams{
+		Username:       username,
+		IdempotencyKey: idempotencyKey,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.IdempotencyKey{}, false, nil
+	}
+	if err != nil {
+		return db.IdempotencyKey{}, false, err
+	}
+
+	return storedKey, true, nil
+}
+
+func (m *MockTransferRepository) GetAccount(accountID int64) (db.Account, error) {
+	account, err := m.store.GetAccount(context.Background(), accountID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.Account{}, transferErrors.ErrAccountNotFound
+	}
+
+	return account, err
+}
+
+func (m *MockTransferRepository) GetFxQuote(quoteID int64) (db.FxQuote, error) {
+	quote, err := m.store.GetFxQuote(context.Background(), quoteID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.FxQuote{}, exchangeRateErrors.ErrQuoteNotFound
+	}
+
+	return quote, err
+}
+
+func (m *MockTransferRepository) ListTransfers(params db.ListTransfersParams) ([]db.ListTransfersRow, error) {
+	return m.store.ListTransfers(context.Background(), params)
+}
+
+func (m *MockTransferRepository) CreateScheduledTransfer(params db.CreateScheduledTransferParams) (db.ScheduledTransfer, error) {
+	return m.store.CreateScheduledTransfer(context.Background(), params)
+}
+
+func (m *MockTransferRepository) GetScheduledTransfer(id int64) (db.ScheduledTransfer, error) {
+	scheduledTransfer, err := m.store.GetScheduledTransfer(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.ScheduledTransfer{}, transferErrors.ErrScheduledTransferNotFound
+	}
+
+	return scheduledTransfer, err
+}
+
+func (m *MockTransferRepository) ListScheduledTransfers(owner string) ([]db.ScheduledTransfer, error) {
+	return m.store.ListScheduledTransfers(context.Background(), owner)
+}
+
+func (m *MockTransferRepository) UpdateScheduledTransfer(params db.UpdateScheduledTransferParams) (db.ScheduledTransfer, error) {
+	return m.store.UpdateScheduledTransfer(context.Background(), params)
+}
+
+func (m *MockTransferRepository) ClaimDueScheduledTransfers(batchSize int32, claimedUntil time.Time) ([]db.ScheduledTransfer, error) {
+	return m.store.ClaimDueScheduledTransfers(context.Background(), db.ClaimDueScheduledTransfersParams{
+		ClaimedUntil: claimedUntil,
+		BatchSize:    batchSize,
+	})
+}
+
+func (m *MockTransferRepository) RecordScheduledTransferRun(params db.RecordScheduledTransferRunParams) (db.ScheduledTransfer, error) {
+	return m.store.RecordScheduledTransferRun(context.Background(), params)
+}
+
+func (m *MockTransferRepository) GetTransferLimits(username string, currency string) (db.TransferLimits, error) {
+	limits, err := db.GetTransferLimits(context.Background(), m.store, username, currency)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.TransferLimits{}, transferErrors.ErrUserNotFound
+	}
+
+	return limits, err
+}
+
+func (m *MockTransferRepository) GetTransferUsage(username string, currency string, now time.Time) (db.TransferUsage, error) {
+	return db.GetTransferUsage(context.Background(), m.store, username, currency, now)
+}
+
+func (m *MockTransferRepository) UpsertUserTransferLimit(params db.UpsertUserTransferLimitParams) (db.UserTransferLimit, error) {
+	return m.store.UpsertUserTransferLimit(context.Background(), params)
+}
+
+func (m *MockTransferRepository) DeleteUserTransferLimit(username string, currency string) error {
+	deleted, err := m.store.DeleteUserTransferLimit(context.Background(), db.DeleteUserTransferLimitParams{
+		Username: username,
+		Currency: currency,
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return transferErrors.ErrTransferLimitOverrideNotFound
+	}
+
+	return nil
+}
+
+func (m *MockTransferRepository) GetTransfer(transferID int64) (db.GetTransferRow, error) {
+	transfer, err := m.store.GetTransfer(context.Background(), transferID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.GetTransferRow{}, transferErrors.ErrTransferNotFound
+	}
+
+	return transfer, err
+}
+
+func (m *MockTransferRepository) ReverseTransfer(params db.ReverseTransferTxParams) (db.ReverseTransferTxResult, error) {
+	result, err := m.store.ReverseTransferTx(context.Background(), params)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.ReverseTransferTxResult{}, transferErrors.ErrTransferNotFound
+	}
+
+	return result, err
+}
+
+func (m *MockTransferRepository) AuthorizeTransferHold(params db.AuthorizeTransferTxParams) (db.AuthorizeTransferTxResult, error) {
+	return m.store.AuthorizeTransferTx(context.Background(), params)
+}
+
+func (m *MockTransferRepository) GetTransferHold(id int64) (db.TransferHold, error) {
+	hold, err := m.store.GetTransferHold(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.TransferHold{}, transferErrors.ErrTransferHoldNotFound
+	}
+
+	return hold, err
+}
+
+func (m *MockTransferRepository) ListTransferHolds(owner string) ([]db.TransferHold, error) {
+	return m.store.ListTransferHolds(context.Background(), owner)
+}
+
+func (m *MockTransferRepository) CaptureTransferHold(id int64) (db.CaptureTransferHoldTxResult, error) {
+	result, err := m.store.CaptureTransferHoldTx(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.CaptureTransferHoldTxResult{}, transferErrors.ErrTransferHoldNotFound
+	}
+
+	return result, err
+}
+
+func (m *MockTransferRepository) VoidTransferHold(id int64) (db.TransferHold, error) {
+	hold, err := m.store.VoidTransferHoldTx(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.TransferHold{}, transferErrors.ErrTransferHoldNotFound
+	}
+
+	return hold, err
+}
+
+func (m *MockTransferRepository) ExpireTransferHolds(batchSize int32) ([]db.TransferHold, error) {
+	return m.store.ExpireTransferHoldsTx(context.Background(), batchSize)
+}
+
+func (m *MockTransferRepository) CreateTransferBatch(params db.BatchTransferTxParams) (db.BatchTransferTxResult, error) {
+	return m.store.BatchTransferTx(context.Background(), params)
+}
+
+func (m *MockTransferRepository) GetTransferBatch(id int64) (db.TransferBatch, error) {
+	batch, err := m.store.GetTransferBatch(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.TransferBatch{}, transferErrors.ErrTransferBatchNotFound
+	}
+
+	return batch, err
+}
+
+func (m *MockTransferRepository) ListTransferBatches(owner string) ([]db.TransferBatch, error) {
+	return m.store.ListTransferBatches(context.Background(), owner)
+}
+
+func (m *MockTransferRepository) ListTransferBatchItems(batchID int64) ([]db.TransferBatchItem, error) {
+	return m.store.ListTransferBatchItems(context.Background(), batchID)
+}
+
+func (m *MockTransferRepository) GetUserEmailVerified(username string) (bool, error) {
+	user, err := m.store.GetUser(context.Background(), username)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, transferErrors.ErrUserNotFound
+	}
+
+	return user.IsEmailVerified, err
+}
+
+func (m *MockTransferRepository) GetUserMfa(username string) (db.UserMfa, bool, error) {
+	userMfa, err := m.store.GetUserMfa(context.Background(), username)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.UserMfa{}, false, nil
+	}
+
+	return userMfa, err == nil, err
+}
+
+func (m *MockTransferRepository) UseTotpStep(username string, step int64) error {
+	_, err := m.store.UseTotpStep(context.Background(), db.UseTotpStepParams{Username: username, LastUsedStep: step})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return transferErrors.ErrStepUpInvalidTOTPCode
+	}
+
+	return err
+}
+
+func (m *MockTransferRepository) CountMfaAttempt(params db.CountMfaAttemptParams) error {
+	_, err := m.store.CountMfaAttempt(context.Background(), params)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return transferErrors.ErrStepUpMFALocked
+	}
+
+	return err
+}
+
+func (m *MockTransferRepository) ResetMfaAttempts(username string) error {
+	return m.store.ResetMfaAttempts(context.Background(), username)
+}
+
+// NewMockTransferRepository creates a new mock repository that wraps a store
+func NewMockTransferRepository(store db.Store) *MockTransferRepository {
+	return &MockTransferRepository{store: store}
+}
@@ -0,0 +1,11 @@
+package transfers
+
+var ListTransfersValidationMessages = map[string]string{
+	"Direction.oneof":   "direction must be one of all, sent or received",
+	"FromCurrency.len":  "from_currency must be a 3 letter currency code",
+	"ToCurrency.len":    "to_currency must be a 3 letter currency code",
+	"MinAmount.numeric": "min_amount must be a number",
+	"MaxAmount.numeric": "max_amount must be a number",
+	"Limit.min":         "limit must be at least 1",
+	"Limit.max":         "limit must not be greater than 100",
+}
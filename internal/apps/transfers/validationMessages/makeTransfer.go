@@ -9,4 +9,5 @@ var MakeTransferValidationMessages = map[string]string{
 	"Amount.min":             "amount must be greater than 0",
 	"FromCurrency.required":  "from_currency is required",
 	"ToCurrency.required":    "to_currency is required",
+	"QuoteID.min":            "quote_id must be greater than 0",
 }
@@ -0,0 +1,23 @@
+package transfers
+
+var CreateScheduledTransferValidationMessages = map[string]string{
+	"FromAccountID.required": "from_account_id is required",
+	"FromAccountID.min":      "from_account_id must be greater than 0",
+	"ToAccountID.required":   "to_account_id is required",
+	"ToAccountID.min":        "to_account_id must be greater than 0",
+	"Amount.required":        "amount is required",
+	"FromCurrency.required":  "from_currency is required",
+	"ToCurrency.required":    "to_currency is required",
+	"Frequency.required":     "frequency is required",
+	"Frequency.oneof":        "frequency must be one of once, weekly or monthly",
+	"StartAt.required":       "start_at is required",
+	"DayOfMonth.min":         "day_of_month must be between 1 and 31",
+	"DayOfMonth.max":         "day_of_month must be between 1 and 31",
+}
+
+var UpdateScheduledTransferValidationMessages = map[string]string{
+	"Frequency.oneof": "frequency must be one of once, weekly or monthly",
+	"DayOfMonth.min":  "day_of_month must be between 1 and 31",
+	"DayOfMonth.max":  "day_of_month must be between 1 and 31",
+	"Status.oneof":    "status must be active or paused",
+}
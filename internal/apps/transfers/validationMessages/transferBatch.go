@@ -0,0 +1,16 @@
+package transfers
+
+var CreateTransferBatchValidationMessages = map[string]string{
+	"Mode.required":          "mode is required",
+	"Mode.oneof":             "mode must be all_or_nothing or best_effort",
+	"Items.required":         "items is required",
+	"Items.min":              "items must contain at least one transfer",
+	"FromAccountID.required": "from_account_id is required on every item",
+	"FromAccountID.min":      "from_account_id must be greater than 0",
+	"ToAccountID.required":   "to_account_id is required on every item",
+	"ToAccountID.min":        "to_account_id must be greater than 0",
+	"Amount.required":        "amount is required on every item",
+	"FromCurrency.required":  "from_currency is required on every item",
+	"ToCurrency.required":    "to_currency is required on every item",
+	"QuoteID.min":            "quote_id must be greater than 0",
+}
@@ -0,0 +1,6 @@
+package transfers
+
+var SetTransferLimitValidationMessages = map[string]string{
+	"DailyCount.min":   "daily_count must be greater than 0",
+	"MonthlyCount.min": "monthly_count must be greater than 0",
+}
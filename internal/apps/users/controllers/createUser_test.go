@@ -9,6 +9,7 @@ import (
 	services "lemfi/simplebank/internal/apps/users/services"
 	testhelpers "lemfi/simplebank/internal/apps/users/testHelpers"
 	"lemfi/simplebank/pkg/cipher"
+	"lemfi/simplebank/pkg/mailer"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -84,9 +85,20 @@ func TestCreateUserHTTP_Success(t *testing.T) {
 	// Expect user creation with proper parameter matching
 	store.EXPECT().CreateUser(gomock.Any(), EqCreateUserParams(expectedParams, "password123")).Return(expectedUser, nil).Times(1)
 
+	// A verification link is stored and mailed to the new user
+	store.EXPECT().CreateUserToken(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, arg db.CreateUserTokenParams) (db.UserToken, error) {
+			require.Equal(t, "testuser", arg.Username)
+			require.Equal(t, "email_verification", arg.Purpose)
+			require.Equal(t, "test@example.com", arg.Email)
+			require.Len(t, arg.TokenHash, 32)
+			return db.UserToken{ID: 1, Username: arg.Username}, nil
+		}).Times(1)
+
+	memoryMailer := mailer.NewMemoryMailer()
 	mockRepo := testhelpers.NewMockUserRepository(store)
-	userService := services.NewUserService(mockRepo, nil) // nil tokenMaker for now
-	userController := NewUserController(userService, nil) // nil tokenMaker for now
+	userService := services.NewUserService(mockRepo, nil, memoryMailer) // nil tokenMaker for now
+	userController := NewUserController(userService, nil)               // nil tokenMaker for now
 
 	router := gin.New()
 	router.POST("/users", userController.CreateUserController)
@@ -103,6 +115,11 @@ func TestCreateUserHTTP_Success(t *testing.T) {
 	router.ServeHTTP(recorder, request)
 
 	require.Equal(t, http.StatusCreated, recorder.Code)
+
+	messages := memoryMailer.Messages()
+	require.Len(t, messages, 1)
+	require.Equal(t, "test@example.com", messages[0].To)
+	require.Contains(t, messages[0].Body, "token=")
 }
 
 func TestCreateUserHTTP_InvalidRequest(t *testing.T) {
@@ -112,8 +129,8 @@ func TestCreateUserHTTP_InvalidRequest(t *testing.T) {
 	store := mockdb.NewMockStore(ctrl)
 
 	mockRepo := testhelpers.NewMockUserRepository(store)
-	userService := services.NewUserService(mockRepo, nil) // nil tokenMaker for now
-	userController := NewUserController(userService, nil) // nil tokenMaker for now
+	userService := services.NewUserService(mockRepo, nil, mailer.NewMemoryMailer()) // nil tokenMaker for now
+	userController := NewUserController(userService, nil)                           // nil tokenMaker for now
 
 	router := gin.New()
 	router.POST("/users", userController.CreateUserController)
@@ -144,8 +161,8 @@ func TestCreateUserHTTP_InvalidEmail(t *testing.T) {
 	store := mockdb.NewMockStore(ctrl)
 
 	mockRepo := testhelpers.NewMockUserRepository(store)
-	userService := services.NewUserService(mockRepo, nil) // nil tokenMaker for now
-	userController := NewUserController(userService, nil) // nil tokenMaker for now
+	userService := services.NewUserService(mockRepo, nil, mailer.NewMemoryMailer()) // nil tokenMaker for now
+	userController := NewUserController(userService, nil)                           // nil tokenMaker for now
 
 	router := gin.New()
 	router.POST("/users", userController.CreateUserController)
@@ -178,8 +195,8 @@ func TestCreateUserHTTP_ShortPassword(t *testing.T) {
 	store := mockdb.NewMockStore(ctrl)
 
 	mockRepo := testhelpers.NewMockUserRepository(store)
-	userService := services.NewUserService(mockRepo, nil) // nil tokenMaker for now
-	userController := NewUserController(userService, nil) // nil tokenMaker for now
+	userService := services.NewUserService(mockRepo, nil, mailer.NewMemoryMailer()) // nil tokenMaker for now
+	userController := NewUserController(userService, nil)                           // nil tokenMaker for now
 
 	router := gin.New()
 	router.POST("/users", userController.CreateUserController)
@@ -230,8 +247,8 @@ func TestCreateUserHTTP_DatabaseError(t *testing.T) {
 	store.EXPECT().CreateUser(gomock.Any(), EqCreateUserParams(expectedParams, "password123")).Return(db.CreateUserRow{}, fmt.Errorf("database error")).Times(1)
 
 	mockRepo := testhelpers.NewMockUserRepository(store)
-	userService := services.NewUserService(mockRepo, nil) // nil tokenMaker for now
-	userController := NewUserController(userService, nil) // nil tokenMaker for now
+	userService := services.NewUserService(mockRepo, nil, mailer.NewMemoryMailer()) // nil tokenMaker for now
+	userController := NewUserController(userService, nil)                           // nil tokenMaker for now
 
 	router := gin.New()
 	router.POST("/users", userController.CreateUserController)
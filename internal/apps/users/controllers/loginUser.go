@@ -29,6 +29,9 @@ func (userController *UserController) LoginUserController(c *gin.Context) {
 
 	config.Logger.Info("Login request validated successfully", "username", req.Username)
 
+	req.UserAgent = c.Request.UserAgent()
+	req.ClientIP = c.ClientIP()
+
 	response, err := userController.userService.LoginUser(req)
 	if err != nil {
 		config.Logger.Error("Failed to login user", "error", err.Error(), "username", req.Username)
@@ -40,12 +43,21 @@ func (userController *UserController) LoginUserController(c *gin.Context) {
 		return
 	}
 
-	config.Logger.Info("User logged in successfully", "username", req.Username)
-
 	responseData := responseHandler.Envelope{
 		"tokens": response,
 	}
 
+	// The login is completed at /users/login/mfa when the user has two-factor authentication
+	if response.MFAChallenge != nil {
+		config.Logger.Info("Password accepted, second factor required", "username", req.Username)
+		responseData = responseHandler.Envelope{
+			"mfa_required":  true,
+			"mfa_challenge": response.MFAChallenge,
+		}
+	} else {
+		config.Logger.Info("User logged in successfully", "username", req.Username)
+	}
+
 	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, responseData, nil)
 	if err != nil {
 		config.Logger.Error("Failed to write JSON response", "error", err.Error())
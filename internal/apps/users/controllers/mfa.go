@@ -0,0 +1,204 @@
+package users
+
+import (
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/internal/apps/core"
+	requests "lemfi/simplebank/internal/apps/users/requests"
+	userValidation "lemfi/simplebank/internal/apps/users/validationMessages"
+	"lemfi/simplebank/internal/middleware"
+	errorResponse "lemfi/simplebank/pkg/errorResponse"
+	"lemfi/simplebank/pkg/requestHandler"
+	"lemfi/simplebank/pkg/responseHandler"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (userController *UserController) MFALoginController(c *gin.Context) {
+	config.Logger.Info("Two-factor login attempt", "method", "POST", "endpoint", "/users/login/mfa")
+
+	var req requests.MFALoginRequest
+
+	err := requestHandler.ReadJSONGin(c, &req, userValidation.MFALoginValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read two-factor login request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	req.UserAgent = c.Request.UserAgent()
+	req.ClientIP = c.ClientIP()
+
+	response, err := userController.userService.CompleteMFALogin(req)
+	if err != nil {
+		config.Logger.Error("Failed to complete two-factor login", "error", err.Error())
+		if clientErr, isClient := core.IsClientError(err); isClient {
+			errorResponse.BadRequestResponse(c, clientErr)
+		} else {
+			errorResponse.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	responseData := responseHandler.Envelope{
+		"tokens": response,
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, responseData, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Two-factor login completed successfully")
+}
+
+func (userController *UserController) MFAStatusController(c *gin.Context) {
+	config.Logger.Info("Processing two-factor status request", "method", "GET", "endpoint", "/users/me/mfa")
+
+	userClaims := middleware.ContextGetUser(c)
+	if userClaims == nil || userClaims.Username == "" {
+		errorResponse.UnAuthorizedRequestResponse(c)
+		return
+	}
+
+	response, err := userController.userService.MFAStatus(userClaims.Username)
+	if err != nil {
+		config.Logger.Error("Failed to get two-factor status", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	responseData := responseHandler.Envelope{
+		"mfa": response,
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, responseData, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+}
+
+func (userController *UserController) EnrollTOTPController(c *gin.Context) {
+	config.Logger.Info("Processing TOTP enrolment request", "method", "POST", "endpoint", "/users/me/mfa/totp")
+
+	userClaims := middleware.ContextGetUser(c)
+	if userClaims == nil || userClaims.Username == "" {
+		errorResponse.UnAuthorizedRequestResponse(c)
+		return
+	}
+
+	response, err := userController.userService.EnrollTOTP(userClaims.Username)
+	if err != nil {
+		config.Logger.Error("Failed to enrol TOTP", "error", err.Error())
+		if clientErr, isClient := core.IsClientError(err); isClient {
+			errorResponse.BadRequestResponse(c, clientErr)
+		} else {
+			errorResponse.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	responseData := responseHandler.Envelope{
+		"totp": response,
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusCreated, responseData, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("TOTP enrolment started", "username", userClaims.Username)
+}
+
+func (userController *UserController) ConfirmTOTPController(c *gin.Context) {
+	config.Logger.Info("Processing TOTP confirmation request", "method", "POST", "endpoint", "/users/me/mfa/totp/confirm")
+
+	userClaims := middleware.ContextGetUser(c)
+	if userClaims == nil || userClaims.Username == "" {
+		errorResponse.UnAuthorizedRequestResponse(c)
+		return
+	}
+
+	var req requests.ConfirmTOTPRequest
+
+	err := requestHandler.ReadJSONGin(c, &req, userValidation.ConfirmTOTPValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read TOTP confirmation request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	response, err := userController.userService.ConfirmTOTP(userClaims.Username, req)
+	if err != nil {
+		config.Logger.Error("Failed to confirm TOTP", "error", err.Error())
+		if clientErr, isClient := core.IsClientError(err); isClient {
+			errorResponse.BadRequestResponse(c, clientErr)
+		} else {
+			errorResponse.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	responseData := responseHandler.Envelope{
+		"message":        "Two-factor authentication enabled, store the recovery codes somewhere safe, they are only shown once",
+		"recovery_codes": response.RecoveryCodes,
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, responseData, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("TOTP confirmation completed successfully", "username", userClaims.Username)
+}
+
+func (userController *UserController) DisableTOTPController(c *gin.Context) {
+	config.Logger.Info("Processing TOTP removal request", "method", "DELETE", "endpoint", "/users/me/mfa/totp")
+
+	userClaims := middleware.ContextGetUser(c)
+	if userClaims == nil || userClaims.Username == "" {
+		errorResponse.UnAuthorizedRequestResponse(c)
+		return
+	}
+
+	var req requests.DisableTOTPRequest
+
+	err := requestHandler.ReadJSONGin(c, &req, userValidation.DisableTOTPValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read TOTP removal request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	err = userController.userService.DisableTOTP(userClaims.Username, req)
+	if err != nil {
+		config.Logger.Error("Failed to disable TOTP", "error", err.Error())
+		if clientErr, isClient := core.IsClientError(err); isClient {
+			errorResponse.BadRequestResponse(c, clientErr)
+		} else {
+			errorResponse.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	responseData := responseHandler.Envelope{
+		"message": "Two-factor authentication disabled",
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, responseData, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("TOTP removal completed successfully", "username", userClaims.Username)
+}
@@ -0,0 +1,128 @@
+package users
+
+import (
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/internal/apps/core"
+	requests "lemfi/simplebank/internal/apps/users/requests"
+	userValidation "lemfi/simplebank/internal/apps/users/validationMessages"
+	"lemfi/simplebank/internal/middleware"
+	errorResponse "lemfi/simplebank/pkg/errorResponse"
+	"lemfi/simplebank/pkg/requestHandler"
+	"lemfi/simplebank/pkg/responseHandler"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (userController *UserController) ChangePasswordController(c *gin.Context) {
+	config.Logger.Info("Processing password change request", "method", "PUT", "endpoint", "/users/me/password")
+
+	userClaims := middleware.ContextGetUser(c)
+	if userClaims == nil || userClaims.Username == "" {
+		errorResponse.UnAuthorizedRequestResponse(c)
+		return
+	}
+
+	var req requests.ChangePasswordRequest
+
+	err := requestHandler.ReadJSONGin(c, &req, userValidation.ChangePasswordValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read password change request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	err = userController.userService.ChangePassword(userClaims.Username, req)
+	if err != nil {
+		config.Logger.Error("Failed to change password", "error", err.Error())
+		if clientErr, isClient := core.IsClientError(err); isClient {
+			errorResponse.BadRequestResponse(c, clientErr)
+		} else {
+			errorResponse.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	responseData := responseHandler.Envelope{
+		"message": "Password changed successfully, please log in again",
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, responseData, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Password change completed successfully", "username", userClaims.Username)
+}
+
+func (userController *UserController) ForgotPasswordController(c *gin.Context) {
+	config.Logger.Info("Processing password reset link request", "method", "POST", "endpoint", "/users/password/forgot")
+
+	var req requests.ForgotPasswordRequest
+
+	err := requestHandler.ReadJSONGin(c, &req, userValidation.ForgotPasswordValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read password reset link request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	err = userController.userService.ForgotPassword(req)
+	if err != nil {
+		config.Logger.Error("Failed to send password reset link", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	responseData := responseHandler.Envelope{
+		"message": "If an account uses that email address, a password reset link has been sent to it",
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, responseData, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Password reset link request completed successfully")
+}
+
+func (userController *UserController) ResetPasswordController(c *gin.Context) {
+	config.Logger.Info("Processing password reset request", "method", "POST", "endpoint", "/users/password/reset")
+
+	var req requests.ResetPasswordRequest
+
+	err := requestHandler.ReadJSONGin(c, &req, userValidation.ResetPasswordValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read password reset request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	err = userController.userService.ResetPassword(req)
+	if err != nil {
+		config.Logger.Error("Failed to reset password", "error", err.Error())
+		if clientErr, isClient := core.IsClientError(err); isClient {
+			errorResponse.BadRequestResponse(c, clientErr)
+		} else {
+			errorResponse.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	responseData := responseHandler.Envelope{
+		"message": "Password reset successfully, please log in with your new password",
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, responseData, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Password reset completed successfully")
+}
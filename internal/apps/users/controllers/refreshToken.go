@@ -26,6 +26,9 @@ func (userController *UserController) RefreshTokenController(c *gin.Context) {
 
 	config.Logger.Info("Refresh token request validated successfully")
 
+	req.UserAgent = c.Request.UserAgent()
+	req.ClientIP = c.ClientIP()
+
 	response, err := userController.userService.RefreshToken(req)
 	if err != nil {
 		config.Logger.Error("Failed to refresh token", "error", err.Error())
@@ -0,0 +1,108 @@
+package users
+
+import (
+	"errors"
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/internal/apps/core"
+	"lemfi/simplebank/internal/middleware"
+	errorResponse "lemfi/simplebank/pkg/errorResponse"
+	"lemfi/simplebank/pkg/responseHandler"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func (userController *UserController) ListSessionsController(c *gin.Context) {
+	config.Logger.Info("Processing list sessions request", "method", "GET", "endpoint", "/users/me/sessions")
+
+	userClaims := middleware.ContextGetUser(c)
+	if userClaims == nil || userClaims.Username == "" {
+		errorResponse.UnAuthorizedRequestResponse(c)
+		return
+	}
+
+	response, err := userController.userService.ListSessions(userClaims.Username)
+	if err != nil {
+		config.Logger.Error("Failed to list sessions", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	responseData := responseHandler.Envelope{
+		"sessions": response,
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, responseData, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+}
+
+func (userController *UserController) RevokeSessionController(c *gin.Context) {
+	config.Logger.Info("Processing revoke session request", "method", "DELETE", "endpoint", "/users/me/sessions/:id")
+
+	userClaims := middleware.ContextGetUser(c)
+	if userClaims == nil || userClaims.Username == "" {
+		errorResponse.UnAuthorizedRequestResponse(c)
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse.BadRequestResponse(c, errors.New("invalid id parameter"))
+		return
+	}
+
+	err = userController.userService.RevokeSession(userClaims.Username, sessionID)
+	if err != nil {
+		config.Logger.Error("Failed to revoke session", "error", err.Error())
+		if clientErr, isClient := core.IsClientError(err); isClient {
+			errorResponse.BadRequestResponse(c, clientErr)
+		} else {
+			errorResponse.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	responseData := responseHandler.Envelope{
+		"message": "Session revoked successfully",
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, responseData, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+}
+
+func (userController *UserController) LogoutEverywhereController(c *gin.Context) {
+	config.Logger.Info("Processing logout everywhere request", "method", "DELETE", "endpoint", "/users/me/sessions")
+
+	userClaims := middleware.ContextGetUser(c)
+	if userClaims == nil || userClaims.Username == "" {
+		errorResponse.UnAuthorizedRequestResponse(c)
+		return
+	}
+
+	err := userController.userService.LogoutEverywhere(userClaims.Username)
+	if err != nil {
+		config.Logger.Error("Failed to log out of every session", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	responseData := responseHandler.Envelope{
+		"message": "Logged out of every session successfully",
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, responseData, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+}
@@ -0,0 +1,52 @@
+package users
+
+import (
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/internal/apps/core"
+	requests "lemfi/simplebank/internal/apps/users/requests"
+	userValidation "lemfi/simplebank/internal/apps/users/validationMessages"
+	errorResponse "lemfi/simplebank/pkg/errorResponse"
+	"lemfi/simplebank/pkg/requestHandler"
+	"lemfi/simplebank/pkg/responseHandler"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (userController *UserController) VerifyEmailController(c *gin.Context) {
+	config.Logger.Info("Processing email verification request", "method", "GET", "endpoint", "/users/verify-email")
+
+	var req requests.VerifyEmailRequest
+
+	err := requestHandler.ReadQueryGin(c, &req, userValidation.VerifyEmailValidationMessages)
+	if err != nil {
+		config.Logger.Error("Failed to read email verification request", "error", err.Error())
+		errorResponse.BadRequestResponse(c, err)
+		return
+	}
+
+	response, err := userController.userService.VerifyEmail(req.Token)
+	if err != nil {
+		config.Logger.Error("Failed to verify email", "error", err.Error())
+		if clientErr, isClient := core.IsClientError(err); isClient {
+			errorResponse.BadRequestResponse(c, clientErr)
+		} else {
+			errorResponse.ServerErrorResponse(c, err)
+		}
+		return
+	}
+
+	responseData := responseHandler.Envelope{
+		"user":    response,
+		"message": "Email address verified successfully",
+	}
+
+	err = responseHandler.WriteJSON(c.Writer, http.StatusOK, responseData, nil)
+	if err != nil {
+		config.Logger.Error("Failed to write JSON response", "error", err.Error())
+		errorResponse.ServerErrorResponse(c, err)
+		return
+	}
+
+	config.Logger.Info("Email verification completed successfully", "username", response.Username)
+}
@@ -21,3 +21,63 @@ var (
 		Status:  401,
 	}
 )
+
+// Email verification errors
+var (
+	ErrInvalidVerificationToken = core.ClientError{
+		Message: "email verification link is invalid, has already been used or has expired",
+		Status:  400,
+	}
+)
+
+// Password errors
+var (
+	ErrIncorrectCurrentPassword = core.ClientError{
+		Message: "current password is incorrect",
+		Status:  400,
+	}
+	ErrInvalidResetToken = core.ClientError{
+		Message: "password reset link is invalid, has already been used or has expired",
+		Status:  400,
+	}
+)
+
+// Session errors
+var (
+	ErrSessionNotFound = core.ClientError{
+		Message: "session not found",
+		Status:  404,
+	}
+	ErrRefreshTokenReused = core.ClientError{
+		Message: "refresh token has already been used, every session of this login has been signed out",
+		Status:  401,
+	}
+)
+
+// Two-factor authentication errors
+var (
+	ErrMFAAlreadyEnabled = core.ClientError{
+		Message: "two-factor authentication is already enabled",
+		Status:  400,
+	}
+	ErrMFANotEnabled = core.ClientError{
+		Message: "two-factor authentication is not enabled",
+		Status:  400,
+	}
+	ErrMFAEnrolmentNotFound = core.ClientError{
+		Message: "no two-factor enrolment is waiting to be confirmed, please start a new one",
+		Status:  400,
+	}
+	ErrInvalidMFACode = core.ClientError{
+		Message: "two-factor code is invalid or has already been used",
+		Status:  401,
+	}
+	ErrInvalidMFAToken = core.ClientError{
+		Message: "two-factor login is invalid or has expired, please log in again",
+		Status:  401,
+	}
+	ErrMFALocked = core.ClientError{
+		Message: "too many invalid two-factor codes, please try again later",
+		Status:  429,
+	}
+)
@@ -3,4 +3,7 @@ package users
 type LoginUserRequest struct {
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
+	// Client metadata recorded on the session, set from the request rather than the body
+	UserAgent string `json:"-"`
+	ClientIP  string `json:"-"`
 }
@@ -0,0 +1,22 @@
+package users
+
+// MFALoginRequest represents the second login step of a user with two-factor authentication
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	// TOTP code from the authenticator app or one of the recovery codes
+	Code string `json:"code" validate:"required"`
+	// Client metadata recorded on the session, set from the request rather than the body
+	UserAgent string `json:"-"`
+	ClientIP  string `json:"-"`
+}
+
+// ConfirmTOTPRequest represents the request confirming a TOTP enrolment with a code from the authenticator app
+type ConfirmTOTPRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// DisableTOTPRequest represents the request turning two-factor authentication off
+type DisableTOTPRequest struct {
+	// TOTP code from the authenticator app or one of the recovery codes
+	Code string `json:"code" validate:"required"`
+}
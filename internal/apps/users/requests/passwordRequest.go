@@ -0,0 +1,18 @@
+package users
+
+// ChangePasswordRequest represents the request for a signed-in user changing their password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=6,nefield=CurrentPassword"`
+}
+
+// ForgotPasswordRequest represents the request for a password reset link
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// ResetPasswordRequest represents the request for setting a new password from a mailed reset link
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=6"`
+}
@@ -3,4 +3,7 @@ package users
 // RefreshTokenRequest represents the request for refreshing an access token
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
+	// Client metadata recorded on the new session, set from the request rather than the body
+	UserAgent string `json:"-"`
+	ClientIP  string `json:"-"`
 }
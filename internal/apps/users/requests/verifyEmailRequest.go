@@ -0,0 +1,6 @@
+package users
+
+// VerifyEmailRequest represents the request for verifying a user's email address from the mailed link
+type VerifyEmailRequest struct {
+	Token string `form:"token" validate:"required"`
+}
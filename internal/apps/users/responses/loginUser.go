@@ -9,4 +9,13 @@ type LoginUserResponse struct {
 	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
 	RefreshToken          string    `json:"refresh_token"`
 	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+	// Set instead of the tokens when the user has two-factor authentication turned on
+	MFAChallenge *MFAChallengeResponse `json:"mfa_challenge,omitempty"`
+}
+
+// MFAChallengeResponse is returned when the password is accepted but a second factor is still needed,
+// the MFA token is sent back with a code to complete the login
+type MFAChallengeResponse struct {
+	MFAToken          string    `json:"mfa_token"`
+	MFATokenExpiresAt time.Time `json:"mfa_token_expires_at"`
 }
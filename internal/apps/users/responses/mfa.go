@@ -0,0 +1,24 @@
+package users
+
+import (
+	"time"
+)
+
+// EnrollTOTPResponse carries a new TOTP secret, the provisioning URI is usually shown as a QR code
+type EnrollTOTPResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// ConfirmTOTPResponse carries the recovery codes issued when two-factor authentication is turned on.
+// They are only shown once
+type ConfirmTOTPResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// MFAStatusResponse describes the two-factor authentication of a user
+type MFAStatusResponse struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabled_at,omitempty"`
+	RecoveryCodesRemaining int64      `json:"recovery_codes_remaining"`
+}
@@ -0,0 +1,32 @@
+package users
+
+import (
+	"time"
+
+	db "lemfi/simplebank/db/sqlc"
+
+	"github.com/google/uuid"
+)
+
+// SessionResponse represents a signed-in session of a user, one per refresh token
+type SessionResponse struct {
+	ID        uuid.UUID `json:"id"`
+	UserAgent string    `json:"user_agent"`
+	ClientIP  string    `json:"client_ip"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func NewSessionResponses(sessions []db.ListUserSessionsRow) []SessionResponse {
+	responses := make([]SessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = SessionResponse{
+			ID:        session.ID,
+			UserAgent: session.UserAgent,
+			ClientIP:  session.ClientIp,
+			CreatedAt: session.CreatedAt,
+			ExpiresAt: session.ExpiresAt,
+		}
+	}
+	return responses
+}
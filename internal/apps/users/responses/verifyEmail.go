@@ -0,0 +1,8 @@
+package users
+
+// VerifyEmailResponse represents the response for verifying a user's email address
+type VerifyEmailResponse struct {
+	Username        string `json:"username"`
+	Email           string `json:"email"`
+	IsEmailVerified bool   `json:"is_email_verified"`
+}
@@ -14,9 +14,28 @@ type dbQuerier interface {
 	CreateUser(ctx context.Context, arg db.CreateUserParams) (db.CreateUserRow, error)
 	GetUserHashedPassword(ctx context.Context, username string) (string, error)
 	GetUser(ctx context.Context, username string) (db.GetUserRow, error)
+	GetUserByEmail(ctx context.Context, email string) (db.GetUserByEmailRow, error)
 	CreateSession(ctx context.Context, arg db.CreateSessionParams) (db.Session, error)
 	GetSession(ctx context.Context, id uuid.UUID) (db.GetSessionRow, error)
 	BlockSession(ctx context.Context, id uuid.UUID) error
+	ListUserSessions(ctx context.Context, username string) ([]db.ListUserSessionsRow, error)
+	BlockUserSessions(ctx context.Context, username string) error
+	BlockSessionFamily(ctx context.Context, familyID uuid.UUID) error
+	RotateSessionTx(ctx context.Context, arg db.RotateSessionTxParams) (db.Session, error)
+	CreateUserToken(ctx context.Context, arg db.CreateUserTokenParams) (db.UserToken, error)
+	VerifyUserEmail(ctx context.Context, tokenHash []byte) (db.VerifyUserEmailRow, error)
+	ChangePasswordTx(ctx context.Context, arg db.ChangePasswordTxParams) (db.ChangePasswordTxResult, error)
+	GetUserMfa(ctx context.Context, username string) (db.UserMfa, error)
+	CreatePendingUserMfa(ctx context.Context, arg db.CreatePendingUserMfaParams) (db.UserMfa, error)
+	EnableMfaTx(ctx context.Context, arg db.EnableMfaTxParams) (db.UserMfa, error)
+	UseTotpStep(ctx context.Context, arg db.UseTotpStepParams) (db.UserMfa, error)
+	UseMfaRecoveryCode(ctx context.Context, arg db.UseMfaRecoveryCodeParams) (db.MfaRecoveryCode, error)
+	CountMfaAttempt(ctx context.Context, arg db.CountMfaAttemptParams) (db.UserMfa, error)
+	ResetMfaAttempts(ctx context.Context, username string) error
+	CreateMfaChallenge(ctx context.Context, arg db.CreateMfaChallengeParams) (db.MfaChallenge, error)
+	UseMfaChallenge(ctx context.Context, id uuid.UUID) (db.MfaChallenge, error)
+	CountUnusedMfaRecoveryCodes(ctx context.Context, username string) (int64, error)
+	DeleteUserMfa(ctx context.Context, username string) error
 }
 
 type UserRespository struct {
@@ -27,6 +46,6 @@ type UserRespository struct {
 func NewUserRespository() *UserRespository {
 	return &UserRespository{
 		context: context.Background(),
-		queries: db.New(dbConnection.GetPostgresDBConnection()),
+		queries: db.NewStore(dbConnection.GetPostgresDBConnection()),
 	}
 }
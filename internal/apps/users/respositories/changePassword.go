@@ -0,0 +1,31 @@
+package users
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	userErrors "lemfi/simplebank/internal/apps/users/errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ChangePassword sets a new password and blocks every session of the user.
+// A change made with a reset token fails when the token is unknown, used or expired
+func (userRespository *UserRespository) ChangePassword(params db.ChangePasswordTxParams) (db.ChangePasswordTxResult, error) {
+	result, err := userRespository.queries.ChangePasswordTx(userRespository.context, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if params.ResetTokenHash != nil {
+				config.Logger.Error("Password reset token not usable")
+				return db.ChangePasswordTxResult{}, userErrors.ErrInvalidResetToken
+			}
+			return db.ChangePasswordTxResult{}, userErrors.ErrUserNotFound
+		}
+
+		config.Logger.Error("Failed to change user password in database", "error", err.Error(), "username", params.Username)
+		return db.ChangePasswordTxResult{}, err
+	}
+
+	return result, nil
+}
@@ -131,6 +131,82 @@ func (m *MockStore) TransferTx(ctx context.Context, arg db.TransferTxParams) (db
 	return db.TransferTxResult{}, nil
 }
 
+func (m *MockStore) CreateUserToken(ctx context.Context, arg db.CreateUserTokenParams) (db.UserToken, error) {
+	return db.UserToken{}, nil
+}
+
+func (m *MockStore) VerifyUserEmail(ctx context.Context, tokenHash []byte) (db.VerifyUserEmailRow, error) {
+	return db.VerifyUserEmailRow{}, nil
+}
+
+func (m *MockStore) GetUserByEmail(ctx context.Context, email string) (db.GetUserByEmailRow, error) {
+	return db.GetUserByEmailRow{}, nil
+}
+
+func (m *MockStore) ListUserSessions(ctx context.Context, username string) ([]db.ListUserSessionsRow, error) {
+	return []db.ListUserSessionsRow{}, nil
+}
+
+func (m *MockStore) BlockUserSessions(ctx context.Context, username string) error {
+	return nil
+}
+
+func (m *MockStore) BlockSessionFamily(ctx context.Context, familyID uuid.UUID) error {
+	return nil
+}
+
+func (m *MockStore) RotateSessionTx(ctx context.Context, arg db.RotateSessionTxParams) (db.Session, error) {
+	return db.Session{}, nil
+}
+
+func (m *MockStore) ChangePasswordTx(ctx context.Context, arg db.ChangePasswordTxParams) (db.ChangePasswordTxResult, error) {
+	return db.ChangePasswordTxResult{}, nil
+}
+
+func (m *MockStore) GetUserMfa(ctx context.Context, username string) (db.UserMfa, error) {
+	return db.UserMfa{}, nil
+}
+
+func (m *MockStore) CreatePendingUserMfa(ctx context.Context, arg db.CreatePendingUserMfaParams) (db.UserMfa, error) {
+	return db.UserMfa{}, nil
+}
+
+func (m *MockStore) EnableMfaTx(ctx context.Context, arg db.EnableMfaTxParams) (db.UserMfa, error) {
+	return db.UserMfa{}, nil
+}
+
+func (m *MockStore) UseTotpStep(ctx context.Context, arg db.UseTotpStepParams) (db.UserMfa, error) {
+	return db.UserMfa{}, nil
+}
+
+func (m *MockStore) UseMfaRecoveryCode(ctx context.Context, arg db.UseMfaRecoveryCodeParams) (db.MfaRecoveryCode, error) {
+	return db.MfaRecoveryCode{}, nil
+}
+
+func (m *MockStore) CountMfaAttempt(ctx context.Context, arg db.CountMfaAttemptParams) (db.UserMfa, error) {
+	return db.UserMfa{}, nil
+}
+
+func (m *MockStore) ResetMfaAttempts(ctx context.Context, username string) error {
+	return nil
+}
+
+func (m *MockStore) CreateMfaChallenge(ctx context.Context, arg db.CreateMfaChallengeParams) (db.MfaChallenge, error) {
+	return db.MfaChallenge{}, nil
+}
+
+func (m *MockStore) UseMfaChallenge(ctx context.Context, id uuid.UUID) (db.MfaChallenge, error) {
+	return db.MfaChallenge{}, nil
+}
+
+func (m *MockStore) CountUnusedMfaRecoveryCodes(ctx context.Context, username string) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockStore) DeleteUserMfa(ctx context.Context, username string) error {
+	return nil
+}
+
 func TestCreateUser_Success(t *testing.T) {
 	// Create mock store
 	mockStore := &MockStore{
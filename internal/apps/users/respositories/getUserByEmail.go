@@ -0,0 +1,25 @@
+package users
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	userErrors "lemfi/simplebank/internal/apps/users/errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (userRespository *UserRespository) GetUserByEmail(email string) (db.GetUserByEmailRow, error) {
+	user, err := userRespository.queries.GetUserByEmail(userRespository.context, email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.GetUserByEmailRow{}, userErrors.ErrUserNotFound
+		}
+
+		config.Logger.Error("Failed to get user by email from database", "error", err.Error())
+		return db.GetUserByEmailRow{}, err
+	}
+
+	return user, nil
+}
@@ -1,8 +1,6 @@
 package users
 
 import (
-	"time"
-
 	db "lemfi/simplebank/db/sqlc"
 	requests "lemfi/simplebank/internal/apps/users/requests"
 
@@ -13,7 +11,26 @@ type UserRespositoryInterface interface {
 	CreateUser(payload requests.CreateUserRequest) (db.CreateUserRow, error)
 	GetUserHashedPassword(username string) (string, error)
 	GetUser(username string) (db.GetUserRow, error)
-	CreateSession(username string, refreshTokenID uuid.UUID, refreshToken string, expiresAt time.Time) error
+	GetUserByEmail(email string) (db.GetUserByEmailRow, error)
+	CreateSession(params db.CreateSessionParams) error
 	GetSession(refreshTokenID uuid.UUID) (db.GetSessionRow, error)
 	BlockSession(sessionID uuid.UUID) error
+	ListUserSessions(username string) ([]db.ListUserSessionsRow, error)
+	BlockUserSessions(username string) error
+	BlockSessionFamily(familyID uuid.UUID) error
+	RotateSession(sessionID uuid.UUID, newSession db.CreateSessionParams) (db.Session, error)
+	CreateUserToken(params db.CreateUserTokenParams) (db.UserToken, error)
+	VerifyUserEmail(tokenHash []byte) (db.VerifyUserEmailRow, error)
+	ChangePassword(params db.ChangePasswordTxParams) (db.ChangePasswordTxResult, error)
+	GetUserMfa(username string) (db.UserMfa, error)
+	CreatePendingUserMfa(params db.CreatePendingUserMfaParams) (db.UserMfa, error)
+	EnableMfa(params db.EnableMfaTxParams) (db.UserMfa, error)
+	UseTotpStep(username string, step int64) error
+	UseMfaRecoveryCode(username string, codeHash []byte) error
+	CountMfaAttempt(params db.CountMfaAttemptParams) error
+	ResetMfaAttempts(username string) error
+	CreateMfaChallenge(params db.CreateMfaChallengeParams) error
+	UseMfaChallenge(id uuid.UUID) error
+	CountUnusedMfaRecoveryCodes(username string) (int64, error)
+	DeleteUserMfa(username string) error
 }
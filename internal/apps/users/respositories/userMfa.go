@@ -0,0 +1,175 @@
+package users
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	userErrors "lemfi/simplebank/internal/apps/users/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetUserMfa returns the TOTP enrolment of a user, pending or enabled.
+// It fails with ErrMFANotEnabled when the user has never enrolled
+func (userRespository *UserRespository) GetUserMfa(username string) (db.UserMfa, error) {
+	userMfa, err := userRespository.queries.GetUserMfa(userRespository.context, username)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.UserMfa{}, userErrors.ErrMFANotEnabled
+		}
+
+		config.Logger.Error("Failed to get user MFA from database", "error", err.Error(), "username", username)
+		return db.UserMfa{}, err
+	}
+
+	return userMfa, nil
+}
+
+// CreatePendingUserMfa stores a new encrypted TOTP secret waiting to be confirmed, replacing any
+// earlier pending one. It fails with ErrMFAAlreadyEnabled when the user's enrolment is enabled
+func (userRespository *UserRespository) CreatePendingUserMfa(params db.CreatePendingUserMfaParams) (db.UserMfa, error) {
+	userMfa, err := userRespository.queries.CreatePendingUserMfa(userRespository.context, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.UserMfa{}, userErrors.ErrMFAAlreadyEnabled
+		}
+
+		config.Logger.Error("Failed to create pending user MFA in database", "error", err.Error(), "username", params.Username)
+		return db.UserMfa{}, err
+	}
+
+	return userMfa, nil
+}
+
+// EnableMfa turns on a pending TOTP enrolment and stores its recovery codes.
+// It fails with ErrInvalidMFACode when the code's time step was already used
+func (userRespository *UserRespository) EnableMfa(params db.EnableMfaTxParams) (db.UserMfa, error) {
+	userMfa, err := userRespository.queries.EnableMfaTx(userRespository.context, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.UserMfa{}, userErrors.ErrInvalidMFACode
+		}
+
+		config.Logger.Error("Failed to enable user MFA in database", "error", err.Error(), "username", params.Username)
+		return db.UserMfa{}, err
+	}
+
+	return userMfa, nil
+}
+
+// UseTotpStep records the time step of an accepted TOTP code so the code cannot be replayed.
+// It fails with ErrInvalidMFACode when the step, or a later one, was already used
+func (userRespository *UserRespository) UseTotpStep(username string, step int64) error {
+	_, err := userRespository.queries.UseTotpStep(userRespository.context, db.UseTotpStepParams{
+		Username:     username,
+		LastUsedStep: step,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return userErrors.ErrInvalidMFACode
+		}
+
+		config.Logger.Error("Failed to use TOTP step in database", "error", err.Error(), "username", username)
+		return err
+	}
+
+	return nil
+}
+
+// UseMfaRecoveryCode marks a recovery code as used.
+// It fails with ErrInvalidMFACode when the code is unknown or already used
+func (userRespository *UserRespository) UseMfaRecoveryCode(username string, codeHash []byte) error {
+	_, err := userRespository.queries.UseMfaRecoveryCode(userRespository.context, db.UseMfaRecoveryCodeParams{
+		Username: username,
+		CodeHash: codeHash,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return userErrors.ErrInvalidMFACode
+		}
+
+		config.Logger.Error("Failed to use MFA recovery code in database", "error", err.Error(), "username", username)
+		return err
+	}
+
+	return nil
+}
+
+// CountMfaAttempt counts an attempt at a second factor before the code is checked, so that concurrent
+// guesses are counted too. The attempt that reaches the limit locks the user out until params.LockedUntil.
+// It fails with ErrMFALocked while the user is locked out
+func (userRespository *UserRespository) CountMfaAttempt(params db.CountMfaAttemptParams) error {
+	_, err := userRespository.queries.CountMfaAttempt(userRespository.context, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return userErrors.ErrMFALocked
+		}
+
+		config.Logger.Error("Failed to count MFA attempt in database", "error", err.Error(), "username", params.Username)
+		return err
+	}
+
+	return nil
+}
+
+// ResetMfaAttempts clears the attempts of a user once a second factor is accepted
+func (userRespository *UserRespository) ResetMfaAttempts(username string) error {
+	err := userRespository.queries.ResetMfaAttempts(userRespository.context, username)
+	if err != nil {
+		config.Logger.Error("Failed to reset MFA attempts in database", "error", err.Error(), "username", username)
+		return err
+	}
+
+	return nil
+}
+
+// CreateMfaChallenge stores the MFA token of a login waiting for its second factor
+func (userRespository *UserRespository) CreateMfaChallenge(params db.CreateMfaChallengeParams) error {
+	_, err := userRespository.queries.CreateMfaChallenge(userRespository.context, params)
+	if err != nil {
+		config.Logger.Error("Failed to create MFA challenge in database", "error", err.Error(), "username", params.Username)
+		return err
+	}
+
+	return nil
+}
+
+// UseMfaChallenge deletes the MFA token of a login so that it completes a single login.
+// It fails with ErrInvalidMFAToken when the token was already used or has expired
+func (userRespository *UserRespository) UseMfaChallenge(id uuid.UUID) error {
+	_, err := userRespository.queries.UseMfaChallenge(userRespository.context, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return userErrors.ErrInvalidMFAToken
+		}
+
+		config.Logger.Error("Failed to use MFA challenge in database", "error", err.Error(), "mfa_challenge_id", id)
+		return err
+	}
+
+	return nil
+}
+
+// CountUnusedMfaRecoveryCodes returns how many recovery codes of a user are left
+func (userRespository *UserRespository) CountUnusedMfaRecoveryCodes(username string) (int64, error) {
+	count, err := userRespository.queries.CountUnusedMfaRecoveryCodes(userRespository.context, username)
+	if err != nil {
+		config.Logger.Error("Failed to count MFA recovery codes in database", "error", err.Error(), "username", username)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// DeleteUserMfa removes the TOTP enrolment of a user together with their recovery codes
+func (userRespository *UserRespository) DeleteUserMfa(username string) error {
+	err := userRespository.queries.DeleteUserMfa(userRespository.context, username)
+	if err != nil {
+		config.Logger.Error("Failed to delete user MFA from database", "error", err.Error(), "username", username)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package users
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	userErrors "lemfi/simplebank/internal/apps/users/errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ListUserSessions returns the sessions of a user that are neither blocked nor expired, newest first
+func (userRespository *UserRespository) ListUserSessions(username string) ([]db.ListUserSessionsRow, error) {
+	sessions, err := userRespository.queries.ListUserSessions(userRespository.context, username)
+	if err != nil {
+		config.Logger.Error("Failed to list user sessions from database", "error", err.Error(), "username", username)
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// BlockUserSessions blocks every session of a user so that none of their refresh tokens can be used
+func (userRespository *UserRespository) BlockUserSessions(username string) error {
+	err := userRespository.queries.BlockUserSessions(userRespository.context, username)
+	if err != nil {
+		config.Logger.Error("Failed to block user sessions in database", "error", err.Error(), "username", username)
+		return err
+	}
+
+	return nil
+}
+
+// BlockSessionFamily blocks every session rotated from the same login
+func (userRespository *UserRespository) BlockSessionFamily(familyID uuid.UUID) error {
+	err := userRespository.queries.BlockSessionFamily(userRespository.context, familyID)
+	if err != nil {
+		config.Logger.Error("Failed to block session family in database", "error", err.Error(), "family_id", familyID)
+		return err
+	}
+
+	return nil
+}
+
+// RotateSession blocks the session of a refresh token and creates the session of its replacement
+// in the same family. It fails with ErrRefreshTokenReused when the session was already blocked
+func (userRespository *UserRespository) RotateSession(sessionID uuid.UUID, newSession db.CreateSessionParams) (db.Session, error) {
+	session, err := userRespository.queries.RotateSessionTx(userRespository.context, db.RotateSessionTxParams{
+		SessionID:  sessionID,
+		NewSession: newSession,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Session{}, userErrors.ErrRefreshTokenReused
+		}
+
+		config.Logger.Error("Failed to rotate session in database", "error", err.Error(), "session_id", sessionID)
+		return db.Session{}, err
+	}
+
+	return session, nil
+}
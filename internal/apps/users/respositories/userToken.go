@@ -0,0 +1,38 @@
+package users
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	userErrors "lemfi/simplebank/internal/apps/users/errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (userRespository *UserRespository) CreateUserToken(params db.CreateUserTokenParams) (db.UserToken, error) {
+	token, err := userRespository.queries.CreateUserToken(userRespository.context, params)
+	if err != nil {
+		config.Logger.Error("Failed to create user token in database", "error", err.Error(), "username", params.Username, "purpose", params.Purpose)
+		return db.UserToken{}, err
+	}
+
+	return token, nil
+}
+
+// VerifyUserEmail uses an email verification token and verifies the address it was sent to.
+// Unknown, used and expired tokens are refused, as are tokens sent to an address the user no longer has
+func (userRespository *UserRespository) VerifyUserEmail(tokenHash []byte) (db.VerifyUserEmailRow, error) {
+	user, err := userRespository.queries.VerifyUserEmail(userRespository.context, tokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			config.Logger.Error("Email verification token not usable")
+			return db.VerifyUserEmailRow{}, userErrors.ErrInvalidVerificationToken
+		}
+
+		config.Logger.Error("Failed to verify user email in database", "error", err.Error())
+		return db.VerifyUserEmailRow{}, err
+	}
+
+	return user, nil
+}
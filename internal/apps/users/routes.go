@@ -5,24 +5,37 @@ import (
 	respositories "lemfi/simplebank/internal/apps/users/respositories"
 	services "lemfi/simplebank/internal/apps/users/services"
 	"lemfi/simplebank/internal/middleware"
+	"lemfi/simplebank/pkg/mailer"
 	"lemfi/simplebank/pkg/token"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Routes defines the user routes for the API.
-func Routes(router *gin.Engine) {
+func Routes(router *gin.Engine, authUsers middleware.PasswordChangeGetter) {
 	userRespository := respositories.NewUserRespository()
 	tokenMaker := token.GetTokenMaker()
-	userService := services.NewUserService(userRespository, tokenMaker)
+	userService := services.NewUserService(userRespository, tokenMaker, mailer.GetMailer())
 	userController := controllers.NewUserController(userService, tokenMaker)
 
 	// Public routes (no authentication required)
 	router.POST("/api/v1/users", userController.CreateUserController)
 	router.POST("/api/v1/users/login", userController.LoginUserController)
+	router.POST("/api/v1/users/login/mfa", userController.MFALoginController)
 	router.POST("/api/v1/users/refresh", userController.RefreshTokenController)
 	router.POST("/api/v1/users/logout", userController.LogoutController)
+	router.GET("/api/v1/users/verify-email", userController.VerifyEmailController)
+	router.POST("/api/v1/users/password/forgot", userController.ForgotPasswordController)
+	router.POST("/api/v1/users/password/reset", userController.ResetPasswordController)
 
 	// Protected routes (authentication required)
-	router.GET("/api/v1/users/me", middleware.ValidateAuth(), middleware.RequireAuthenticatedUser(), userController.GetUserController)
+	router.GET("/api/v1/users/me", middleware.ValidateAuth(authUsers), middleware.RequireAuthenticatedUser(), userController.GetUserController)
+	router.PUT("/api/v1/users/me/password", middleware.ValidateAuth(authUsers), middleware.RequireAuthenticatedUser(), userController.ChangePasswordController)
+	router.GET("/api/v1/users/me/sessions", middleware.ValidateAuth(authUsers), middleware.RequireAuthenticatedUser(), userController.ListSessionsController)
+	router.DELETE("/api/v1/users/me/sessions", middleware.ValidateAuth(authUsers), middleware.RequireAuthenticatedUser(), userController.LogoutEverywhereController)
+	router.DELETE("/api/v1/users/me/sessions/:id", middleware.ValidateAuth(authUsers), middleware.RequireAuthenticatedUser(), userController.RevokeSessionController)
+	router.GET("/api/v1/users/me/mfa", middleware.ValidateAuth(authUsers), middleware.RequireAuthenticatedUser(), userController.MFAStatusController)
+	router.POST("/api/v1/users/me/mfa/totp", middleware.ValidateAuth(authUsers), middleware.RequireAuthenticatedUser(), userController.EnrollTOTPController)
+	router.POST("/api/v1/users/me/mfa/totp/confirm", middleware.ValidateAuth(authUsers), middleware.RequireAuthenticatedUser(), userController.ConfirmTOTPController)
+	router.DELETE("/api/v1/users/me/mfa/totp", middleware.ValidateAuth(authUsers), middleware.RequireAuthenticatedUser(), userController.DisableTOTPController)
 }
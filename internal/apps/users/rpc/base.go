@@ -1,6 +1,7 @@
 package users
 
 import (
+	"lemfi/simplebank/config"
 	services "lemfi/simplebank/internal/apps/users/services"
 	"lemfi/simplebank/pb"
 )
@@ -8,10 +9,13 @@ import (
 type UsersRPC struct {
 	pb.UnimplementedSimpleBankServiceServer
 	userService services.UserServiceInterface
+	// trustedProxies may report the client IP in x-forwarded-for
+	trustedProxies trustedProxies
 }
 
 func NewUsersRPC(service services.UserServiceInterface) *UsersRPC {
 	return &UsersRPC{
-		userService: service,
+		userService:    service,
+		trustedProxies: parseTrustedProxies(config.Get().TrustedProxies),
 	}
 }
@@ -17,9 +17,12 @@ func (rpc *UsersRPC) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*
 
 	config.Logger.Info("Login request validated successfully", "username", req.Username)
 
+	clientMetadata := extractClientMetadata(ctx, rpc.trustedProxies)
 	response, err := rpc.userService.LoginUser(users.LoginUserRequest{
-		Username: req.Username,
-		Password: req.Password,
+		Username:  req.Username,
+		Password:  req.Password,
+		UserAgent: clientMetadata.UserAgent,
+		ClientIP:  clientMetadata.ClientIP,
 	})
 
 	if err != nil {
@@ -31,6 +34,12 @@ func (rpc *UsersRPC) LoginUser(ctx context.Context, req *pb.LoginUserRequest) (*
 		}
 	}
 
+	// The login response has no field for the MFA token, two-factor logins are completed over HTTP
+	if response.MFAChallenge != nil {
+		config.Logger.Info("Password accepted, second factor required", "username", req.Username)
+		return nil, status.Error(codes.FailedPrecondition, "two-factor authentication is required, log in at /api/v1/users/login to complete it")
+	}
+
 	config.Logger.Info("User logged in successfully", "username", req.Username)
 
 	return &pb.LoginUserResponse{
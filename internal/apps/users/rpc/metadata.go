@@ -0,0 +1,106 @@
+package users
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Metadata keys set by grpc-gateway on requests it forwards from HTTP clients
+const (
+	grpcGatewayUserAgentHeader = "grpcgateway-user-agent"
+	userAgentHeader            = "user-agent"
+	xForwardedForHeader        = "x-forwarded-for"
+)
+
+// clientMetadata describes the client that made a gRPC call
+type clientMetadata struct {
+	UserAgent string
+	ClientIP  string
+}
+
+// trustedProxies are the proxies whose x-forwarded-for metadata is believed
+type trustedProxies []netip.Prefix
+
+// parseTrustedProxies turns IPs and CIDR ranges into prefixes, entries that are neither are skipped
+// since the configuration already refuses them
+func parseTrustedProxies(proxies []string) trustedProxies {
+	prefixes := make(trustedProxies, 0, len(proxies))
+	for _, proxy := range proxies {
+		if prefix, err := netip.ParsePrefix(proxy); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(proxy); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+		}
+	}
+	return prefixes
+}
+
+// contains reports whether ip belongs to a trusted proxy
+func (proxies trustedProxies) contains(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap()
+	for _, prefix := range proxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// extractClientMetadata reads the user agent and IP of the client from the call.
+// Calls forwarded by grpc-gateway carry the HTTP client's values in their metadata
+func extractClientMetadata(ctx context.Context, proxies trustedProxies) clientMetadata {
+	clientMetadata := clientMetadata{}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if userAgents := md.Get(grpcGatewayUserAgentHeader); len(userAgents) > 0 {
+		clientMetadata.UserAgent = userAgents[0]
+	} else if userAgents := md.Get(userAgentHeader); len(userAgents) > 0 {
+		clientMetadata.UserAgent = userAgents[0]
+	}
+
+	// Calls over the network come from their peer. In-process grpc-gateway calls have no peer,
+	// and the gateway adds the address of the HTTP client to the end of x-forwarded-for itself
+	var peerIP string
+	p, hasPeer := peer.FromContext(ctx)
+	if hasPeer {
+		peerIP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(peerIP); err == nil {
+			peerIP = host
+		}
+	}
+
+	// x-forwarded-for can be set by anyone, so it is only read when the call came through a
+	// trusted proxy, from the nearest address backwards until one that is not a trusted proxy
+	var addresses []string
+	if !hasPeer || proxies.contains(peerIP) {
+		for _, forwardedFor := range md.Get(xForwardedForHeader) {
+			for _, address := range strings.Split(forwardedFor, ",") {
+				if address = strings.TrimSpace(address); address != "" {
+					addresses = append(addresses, address)
+				}
+			}
+		}
+	}
+	if hasPeer {
+		addresses = append(addresses, peerIP)
+	}
+
+	for i := len(addresses) - 1; i >= 0; i-- {
+		clientMetadata.ClientIP = addresses[i]
+		if !proxies.contains(addresses[i]) {
+			break
+		}
+	}
+
+	return clientMetadata
+}
@@ -0,0 +1,71 @@
+package users
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestExtractClientMetadata(t *testing.T) {
+	proxies := parseTrustedProxies([]string{"10.0.0.0/8", "127.0.0.1"})
+
+	withPeer := func(ctx context.Context, ip string) context.Context {
+		return peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 50000}})
+	}
+	withForwardedFor := func(ctx context.Context, forwardedFor string) context.Context {
+		return metadata.NewIncomingContext(ctx, metadata.Pairs(xForwardedForHeader, forwardedFor, userAgentHeader, "grpc-go"))
+	}
+
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		clientIP string
+	}{
+		{
+			name:     "UntrustedPeerIgnoresForwardedFor",
+			ctx:      withPeer(withForwardedFor(context.Background(), "1.2.3.4"), "203.0.113.7"),
+			clientIP: "203.0.113.7",
+		},
+		{
+			name:     "TrustedPeerUsesForwardedFor",
+			ctx:      withPeer(withForwardedFor(context.Background(), "1.2.3.4"), "10.1.2.3"),
+			clientIP: "1.2.3.4",
+		},
+		{
+			name:     "TrustedPeerSkipsTrustedProxies",
+			ctx:      withPeer(withForwardedFor(context.Background(), "9.9.9.9, 1.2.3.4, 10.0.0.5"), "127.0.0.1"),
+			clientIP: "1.2.3.4",
+		},
+		{
+			name:     "TrustedPeerWithoutForwardedFor",
+			ctx:      withPeer(context.Background(), "10.1.2.3"),
+			clientIP: "10.1.2.3",
+		},
+		{
+			// grpc-gateway appends the address of the HTTP client, so spoofed entries come before it
+			name:     "GatewayFromUntrustedClient",
+			ctx:      withForwardedFor(context.Background(), "1.2.3.4, 203.0.113.7"),
+			clientIP: "203.0.113.7",
+		},
+		{
+			name:     "GatewayFromTrustedProxy",
+			ctx:      withForwardedFor(context.Background(), "1.2.3.4, 10.0.0.5"),
+			clientIP: "1.2.3.4",
+		},
+		{
+			name:     "NoPeerOrMetadata",
+			ctx:      context.Background(),
+			clientIP: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.clientIP, extractClientMetadata(tc.ctx, proxies).ClientIP)
+		})
+	}
+}
@@ -1,18 +1,54 @@
 package users
 
 import (
+	"time"
+
+	"lemfi/simplebank/config"
 	respositories "lemfi/simplebank/internal/apps/users/respositories"
+	"lemfi/simplebank/pkg/mailer"
 	"lemfi/simplebank/pkg/token"
 )
 
 type UserService struct {
 	userRespository respositories.UserRespositoryInterface
 	tokenMaker      token.Maker
+	mailer          mailer.Mailer
+	// tokenKey signs the tokens mailed to users
+	tokenKey string
+	// verificationTokenExpiry is how long an email verification link can be used
+	verificationTokenExpiry time.Duration
+	// verificationURL is the link mailed to users, the token is added to it as a query parameter
+	verificationURL string
+	// resetTokenExpiry is how long a password reset link can be used
+	resetTokenExpiry time.Duration
+	// resetURL is the password reset link mailed to users, the token is added to it as a query parameter
+	resetURL string
+	// mfaKey encrypts the TOTP secrets of users
+	mfaKey string
+	// mfaIssuer is the name authenticator apps show the account under
+	mfaIssuer string
+	// mfaChallengeDuration is how long the second login step can be completed after the password is accepted
+	mfaChallengeDuration time.Duration
+	// mfaMaxFailedAttempts is how many wrong second factors in a row lock a user out
+	mfaMaxFailedAttempts int32
+	// mfaLockoutDuration is how long second factors are refused once a user is locked out
+	mfaLockoutDuration time.Duration
 }
 
-func NewUserService(respository respositories.UserRespositoryInterface, tokenMaker token.Maker) *UserService {
+func NewUserService(respository respositories.UserRespositoryInterface, tokenMaker token.Maker, mailer mailer.Mailer) *UserService {
 	return &UserService{
-		userRespository: respository,
-		tokenMaker:      tokenMaker,
+		userRespository:         respository,
+		tokenMaker:              tokenMaker,
+		mailer:                  mailer,
+		tokenKey:                config.Get().TokenSymmetricKey,
+		verificationTokenExpiry: config.Get().EmailVerification.TokenExpiry,
+		verificationURL:         config.Get().EmailVerification.URL,
+		resetTokenExpiry:        config.Get().PasswordReset.TokenExpiry,
+		resetURL:                config.Get().PasswordReset.URL,
+		mfaKey:                  config.Get().MFA.EncryptionKey,
+		mfaIssuer:               config.Get().MFA.Issuer,
+		mfaChallengeDuration:    config.Get().MFA.ChallengeTokenDuration,
+		mfaMaxFailedAttempts:    int32(config.Get().MFA.MaxFailedAttempts),
+		mfaLockoutDuration:      config.Get().MFA.LockoutDuration,
 	}
 }
@@ -31,6 +31,12 @@ func (userService *UserService) CreateUser(payload requests.CreateUserRequest) (
 
 	config.Logger.Info("User created successfully in service layer", "username", user.Username, "email", user.Email)
 
+	// The user is created either way, they can only verify their email once the link reaches them
+	err = userService.sendVerificationEmail(user.Username, user.Email)
+	if err != nil {
+		config.Logger.Error("Failed to send verification email", "error", err.Error(), "username", user.Username)
+	}
+
 	response := responses.CreateUserResponse{
 		Username:  user.Username,
 		FullName:  user.FullName,
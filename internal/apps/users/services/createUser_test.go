@@ -6,7 +6,9 @@ import (
 	"time"
 
 	db "lemfi/simplebank/db/sqlc"
+	userErrors "lemfi/simplebank/internal/apps/users/errors"
 	requests "lemfi/simplebank/internal/apps/users/requests"
+	"lemfi/simplebank/pkg/mailer"
 
 	"github.com/stretchr/testify/require"
     "github.com/google/uuid"
@@ -14,8 +16,35 @@ import (
 
 // MockUserRepository for testing
 type MockUserRepository struct {
-	createUserFunc func(payload requests.CreateUserRequest) (db.CreateUserRow, error)
-	getUserFunc    func(username string) (db.GetUserRow, error)
+	createUserFunc         func(payload requests.CreateUserRequest) (db.CreateUserRow, error)
+	getUserFunc            func(username string) (db.GetUserRow, error)
+	createUserTokenFunc    func(params db.CreateUserTokenParams) (db.UserToken, error)
+	verifyUserEmailFunc    func(tokenHash []byte) (db.VerifyUserEmailRow, error)
+	getUserByEmailFunc     func(email string) (db.GetUserByEmailRow, error)
+	changePasswordFunc     func(params db.ChangePasswordTxParams) (db.ChangePasswordTxResult, error)
+	listUserSessionsFunc   func(username string) ([]db.ListUserSessionsRow, error)
+	blockUserSessionsFunc  func(username string) error
+	blockSessionFamilyFunc func(familyID uuid.UUID) error
+	rotateSessionFunc      func(sessionID uuid.UUID, newSession db.CreateSessionParams) (db.Session, error)
+	// Optional, a fixed hash is returned when nil
+	getUserHashedPasswordFunc func(username string) (string, error)
+	// Optional, sessions are not stored when nil
+	createSessionFunc func(params db.CreateSessionParams) error
+	getSessionFunc    func(refreshTokenID uuid.UUID) (db.GetSessionRow, error)
+	blockSessionFunc  func(sessionID uuid.UUID) error
+	// Optional, users have no two-factor authentication when nil
+	getUserMfaFunc                  func(username string) (db.UserMfa, error)
+	createPendingUserMfaFunc        func(params db.CreatePendingUserMfaParams) (db.UserMfa, error)
+	enableMfaFunc                   func(params db.EnableMfaTxParams) (db.UserMfa, error)
+	useTotpStepFunc                 func(username string, step int64) error
+	useMfaRecoveryCodeFunc          func(username string, codeHash []byte) error
+	countUnusedMfaRecoveryCodesFunc func(username string) (int64, error)
+	deleteUserMfaFunc               func(username string) error
+	// Optional, attempts are never limited and MFA challenges never used up when nil
+	countMfaAttemptFunc    func(params db.CountMfaAttemptParams) error
+	resetMfaAttemptsFunc   func(username string) error
+	createMfaChallengeFunc func(params db.CreateMfaChallengeParams) error
+	useMfaChallengeFunc    func(id uuid.UUID) error
 }
 
 func (m *MockUserRepository) CreateUser(payload requests.CreateUserRequest) (db.CreateUserRow, error) {
@@ -27,20 +56,123 @@ func (m *MockUserRepository) GetUser(username string) (db.GetUserRow, error) {
 }
 
 func (m *MockUserRepository) GetUserHashedPassword(username string) (string, error) {
+	if m.getUserHashedPasswordFunc != nil {
+		return m.getUserHashedPasswordFunc(username)
+	}
 	// Mock implementation - return a hashed password for testing
 	return "$2a$10$hashedpassword123", nil
 }
 
-func (m *MockUserRepository) CreateSession(username string, refreshTokenID uuid.UUID, refreshToken string, expiresAt time.Time) error {
-    return nil
+func (m *MockUserRepository) CreateSession(params db.CreateSessionParams) error {
+	if m.createSessionFunc != nil {
+		return m.createSessionFunc(params)
+	}
+	return nil
 }
 
 func (m *MockUserRepository) GetSession(refreshTokenID uuid.UUID) (db.GetSessionRow, error) {
-    return db.GetSessionRow{}, nil
+	if m.getSessionFunc != nil {
+		return m.getSessionFunc(refreshTokenID)
+	}
+	return db.GetSessionRow{}, nil
 }
 
 func (m *MockUserRepository) BlockSession(sessionID uuid.UUID) error {
-    return nil
+	if m.blockSessionFunc != nil {
+		return m.blockSessionFunc(sessionID)
+	}
+	return nil
+}
+
+func (m *MockUserRepository) ListUserSessions(username string) ([]db.ListUserSessionsRow, error) {
+	return m.listUserSessionsFunc(username)
+}
+
+func (m *MockUserRepository) BlockUserSessions(username string) error {
+	return m.blockUserSessionsFunc(username)
+}
+
+func (m *MockUserRepository) BlockSessionFamily(familyID uuid.UUID) error {
+	return m.blockSessionFamilyFunc(familyID)
+}
+
+func (m *MockUserRepository) RotateSession(sessionID uuid.UUID, newSession db.CreateSessionParams) (db.Session, error) {
+	return m.rotateSessionFunc(sessionID, newSession)
+}
+
+func (m *MockUserRepository) CreateUserToken(params db.CreateUserTokenParams) (db.UserToken, error) {
+	return m.createUserTokenFunc(params)
+}
+
+func (m *MockUserRepository) VerifyUserEmail(tokenHash []byte) (db.VerifyUserEmailRow, error) {
+	return m.verifyUserEmailFunc(tokenHash)
+}
+
+func (m *MockUserRepository) GetUserByEmail(email string) (db.GetUserByEmailRow, error) {
+	return m.getUserByEmailFunc(email)
+}
+
+func (m *MockUserRepository) ChangePassword(params db.ChangePasswordTxParams) (db.ChangePasswordTxResult, error) {
+	return m.changePasswordFunc(params)
+}
+
+func (m *MockUserRepository) GetUserMfa(username string) (db.UserMfa, error) {
+	if m.getUserMfaFunc != nil {
+		return m.getUserMfaFunc(username)
+	}
+	return db.UserMfa{}, userErrors.ErrMFANotEnabled
+}
+
+func (m *MockUserRepository) CreatePendingUserMfa(params db.CreatePendingUserMfaParams) (db.UserMfa, error) {
+	return m.createPendingUserMfaFunc(params)
+}
+
+func (m *MockUserRepository) EnableMfa(params db.EnableMfaTxParams) (db.UserMfa, error) {
+	return m.enableMfaFunc(params)
+}
+
+func (m *MockUserRepository) UseTotpStep(username string, step int64) error {
+	return m.useTotpStepFunc(username, step)
+}
+
+func (m *MockUserRepository) UseMfaRecoveryCode(username string, codeHash []byte) error {
+	return m.useMfaRecoveryCodeFunc(username, codeHash)
+}
+
+func (m *MockUserRepository) CountUnusedMfaRecoveryCodes(username string) (int64, error) {
+	return m.countUnusedMfaRecoveryCodesFunc(username)
+}
+
+func (m *MockUserRepository) DeleteUserMfa(username string) error {
+	return m.deleteUserMfaFunc(username)
+}
+
+func (m *MockUserRepository) CountMfaAttempt(params db.CountMfaAttemptParams) error {
+	if m.countMfaAttemptFunc != nil {
+		return m.countMfaAttemptFunc(params)
+	}
+	return nil
+}
+
+func (m *MockUserRepository) ResetMfaAttempts(username string) error {
+	if m.resetMfaAttemptsFunc != nil {
+		return m.resetMfaAttemptsFunc(username)
+	}
+	return nil
+}
+
+func (m *MockUserRepository) CreateMfaChallenge(params db.CreateMfaChallengeParams) error {
+	if m.createMfaChallengeFunc != nil {
+		return m.createMfaChallengeFunc(params)
+	}
+	return nil
+}
+
+func (m *MockUserRepository) UseMfaChallenge(id uuid.UUID) error {
+	if m.useMfaChallengeFunc != nil {
+		return m.useMfaChallengeFunc(id)
+	}
+	return nil
 }
 
 func TestCreateUser_Success(t *testing.T) {
@@ -60,11 +192,16 @@ func TestCreateUser_Success(t *testing.T) {
 				Username: username,
 			}, nil
 		},
+		createUserTokenFunc: func(params db.CreateUserTokenParams) (db.UserToken, error) {
+			return db.UserToken{ID: 1, Username: params.Username, Purpose: params.Purpose}, nil
+		},
 	}
 
+	memoryMailer := mailer.NewMemoryMailer()
 	userService := &UserService{
 		userRespository: mockRepo,
 		tokenMaker:      nil, // nil tokenMaker for now
+		mailer:          memoryMailer,
 	}
 
 	// Test request
@@ -84,6 +221,10 @@ func TestCreateUser_Success(t *testing.T) {
 	require.Equal(t, request.Username, response.Username)
 	require.Equal(t, request.FullName, response.FullName)
 	require.Equal(t, request.Email, response.Email)
+
+	// A verification link is mailed on signup
+	require.Len(t, memoryMailer.Messages(), 1)
+	require.Equal(t, request.Email, memoryMailer.Messages()[0].To)
 }
 
 func TestCreateUser_RepositoryError(t *testing.T) {
@@ -0,0 +1,87 @@
+package users
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	userErrors "lemfi/simplebank/internal/apps/users/errors"
+	responses "lemfi/simplebank/internal/apps/users/responses"
+	"lemfi/simplebank/pkg/cipher"
+	"lemfi/simplebank/pkg/mailer"
+)
+
+// emailVerificationPurpose is the purpose email verification tokens are signed and stored with
+const emailVerificationPurpose = db.UserTokenPurposeEmailVerification
+
+// sendVerificationEmail mails the user a single-use link that verifies their email address
+func (userService *UserService) sendVerificationEmail(username string, email string) error {
+	verificationToken, tokenHash, err := cipher.NewSignedToken(userService.tokenKey, emailVerificationPurpose)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(userService.verificationTokenExpiry)
+	_, err = userService.userRespository.CreateUserToken(db.CreateUserTokenParams{
+		Username:  username,
+		Purpose:   emailVerificationPurpose,
+		TokenHash: tokenHash,
+		Email:     email,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	link, err := url.Parse(userService.verificationURL)
+	if err != nil {
+		return err
+	}
+	query := link.Query()
+	query.Set("token", verificationToken)
+	link.RawQuery = query.Encode()
+
+	err = userService.mailer.Send(mailer.Message{
+		To:      email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen this link to verify your email address:\n\n%s\n\nThe link expires at %s.\n",
+			username, link.String(), expiresAt.UTC().Format(time.RFC1123)),
+	})
+	if err != nil {
+		return err
+	}
+
+	config.Logger.Info("Verification email sent", "username", username, "email", email)
+
+	return nil
+}
+
+// VerifyEmail uses a mailed verification token and marks the address it was sent to as verified
+func (userService *UserService) VerifyEmail(verificationToken string) (responses.VerifyEmailResponse, error) {
+	config.Logger.Info("Processing email verification in service layer")
+
+	tokenHash, err := cipher.VerifySignedToken(userService.tokenKey, emailVerificationPurpose, verificationToken)
+	if errors.Is(err, cipher.ErrInvalidSignedToken) {
+		config.Logger.Error("Email verification token has an invalid signature")
+		return responses.VerifyEmailResponse{}, userErrors.ErrInvalidVerificationToken
+	}
+	if err != nil {
+		return responses.VerifyEmailResponse{}, err
+	}
+
+	user, err := userService.userRespository.VerifyUserEmail(tokenHash)
+	if err != nil {
+		return responses.VerifyEmailResponse{}, err
+	}
+
+	config.Logger.Info("Email verified", "username", user.Username, "email", user.Email)
+
+	return responses.VerifyEmailResponse{
+		Username:        user.Username,
+		Email:           user.Email,
+		IsEmailVerified: true,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package users
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	db "lemfi/simplebank/db/sqlc"
+	userErrors "lemfi/simplebank/internal/apps/users/errors"
+	"lemfi/simplebank/pkg/mailer"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyEmail(t *testing.T) {
+	var storedHash []byte
+	mockRepo := &MockUserRepository{
+		createUserTokenFunc: func(params db.CreateUserTokenParams) (db.UserToken, error) {
+			require.Equal(t, emailVerificationPurpose, params.Purpose)
+			require.WithinDuration(t, time.Now().Add(time.Hour), params.ExpiresAt, time.Minute)
+			storedHash = params.TokenHash
+			return db.UserToken{ID: 1, Username: params.Username}, nil
+		},
+		verifyUserEmailFunc: func(tokenHash []byte) (db.VerifyUserEmailRow, error) {
+			if !bytes.Equal(storedHash, tokenHash) {
+				return db.VerifyUserEmailRow{}, userErrors.ErrInvalidVerificationToken
+			}
+			return db.VerifyUserEmailRow{Username: "testuser", Email: "test@example.com"}, nil
+		},
+	}
+	memoryMailer := mailer.NewMemoryMailer()
+	userService := newMockUserService(mockRepo, memoryMailer)
+
+	err := userService.sendVerificationEmail("testuser", "test@example.com")
+	require.NoError(t, err)
+
+	// The mailed link carries the token that verifies the email
+	messages := memoryMailer.Messages()
+	require.Len(t, messages, 1)
+	var link *url.URL
+	for _, field := range strings.Fields(messages[0].Body) {
+		if strings.HasPrefix(field, "https://") {
+			link, err = url.Parse(field)
+			require.NoError(t, err)
+		}
+	}
+	require.NotNil(t, link)
+	require.Equal(t, "bank.example.com", link.Host)
+
+	response, err := userService.VerifyEmail(link.Query().Get("token"))
+	require.NoError(t, err)
+	require.Equal(t, "testuser", response.Username)
+	require.True(t, response.IsEmailVerified)
+}
+
+func TestVerifyEmail_InvalidSignature(t *testing.T) {
+	mockRepo := &MockUserRepository{
+		verifyUserEmailFunc: func(tokenHash []byte) (db.VerifyUserEmailRow, error) {
+			t.Fatal("a token with an invalid signature must not be looked up")
+			return db.VerifyUserEmailRow{}, nil
+		},
+	}
+	userService := newMockUserService(mockRepo, mailer.NewMemoryMailer())
+
+	_, err := userService.VerifyEmail("forged.token")
+	require.ErrorIs(t, err, userErrors.ErrInvalidVerificationToken)
+}
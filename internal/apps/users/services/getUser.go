@@ -13,10 +13,11 @@ func (s *UserService) GetUser(username string) (responses.GetUserResponse, error
 
 	// Convert to response (GetUserRow only has limited fields)
 	response := responses.GetUserResponse{
-		Username:  user.Username,
-		Email:     user.Email,
-		FullName:  user.FullName,
-		CreatedAt: user.CreatedAt,
+		Username:        user.Username,
+		Email:           user.Email,
+		FullName:        user.FullName,
+		IsEmailVerified: user.IsEmailVerified,
+		CreatedAt:       user.CreatedAt,
 		// Note: ID, Role, and UpdatedAt are not available from GetUserRow
 		// These would need to be added to the SQL query if needed
 	}
@@ -0,0 +1,58 @@
+package users
+
+import (
+	"testing"
+	"time"
+
+	db "lemfi/simplebank/db/sqlc"
+	"lemfi/simplebank/pkg/mailer"
+	"lemfi/simplebank/pkg/token"
+	"lemfi/simplebank/pkg/totp"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockUserService is a user service that mails its links through memoryMailer
+func newMockUserService(mockRepo *MockUserRepository, memoryMailer *mailer.MemoryMailer) *UserService {
+	return &UserService{
+		userRespository:         mockRepo,
+		mailer:                  memoryMailer,
+		tokenKey:                "12345678901234567890123456789012",
+		verificationTokenExpiry: time.Hour,
+		verificationURL:         "https://bank.example.com/api/v1/users/verify-email",
+		resetTokenExpiry:        time.Hour,
+		resetURL:                "https://bank.example.com/reset-password",
+	}
+}
+
+// newRefreshTokenSession returns a refresh token of testuser and the session stored for it,
+// blocked for blockedReason unless it is empty
+func newRefreshTokenSession(t *testing.T, tokenMaker token.Maker, blockedReason string) (string, db.GetSessionRow) {
+	refreshToken, payload, err := tokenMaker.CreateToken("testuser", "user", time.Hour, token.TokenTypeRefreshToken)
+	require.NoError(t, err)
+
+	return refreshToken, db.GetSessionRow{
+		ID:            payload.ID,
+		Username:      "testuser",
+		IsBlocked:     blockedReason != "",
+		ExpiresAt:     payload.ExpiredAt,
+		FamilyID:      uuid.New(),
+		BlockedReason: pgtype.Text{String: blockedReason, Valid: blockedReason != ""},
+	}
+}
+
+// newEnabledUserMfa returns an enabled TOTP enrolment with a fresh secret
+func newEnabledUserMfa(t *testing.T, username string) (db.UserMfa, string) {
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	encryptedSecret, err := totp.EncryptSecret(testMFAKey, username, secret)
+	require.NoError(t, err)
+
+	return db.UserMfa{
+		Username:        username,
+		EncryptedSecret: encryptedSecret,
+		EnabledAt:       pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}, secret
+}
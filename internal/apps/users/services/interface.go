@@ -3,6 +3,8 @@ package users
 import (
 	requests "lemfi/simplebank/internal/apps/users/requests"
 	responses "lemfi/simplebank/internal/apps/users/responses"
+
+	"github.com/google/uuid"
 )
 
 type UserServiceInterface interface {
@@ -11,4 +13,16 @@ type UserServiceInterface interface {
 	GetUser(username string) (responses.GetUserResponse, error)
 	RefreshToken(payload requests.RefreshTokenRequest) (responses.RefreshTokenResponse, error)
 	Logout(payload requests.LogoutRequest) error
+	VerifyEmail(verificationToken string) (responses.VerifyEmailResponse, error)
+	ChangePassword(username string, payload requests.ChangePasswordRequest) error
+	ForgotPassword(payload requests.ForgotPasswordRequest) error
+	ResetPassword(payload requests.ResetPasswordRequest) error
+	ListSessions(username string) ([]responses.SessionResponse, error)
+	RevokeSession(username string, sessionID uuid.UUID) error
+	LogoutEverywhere(username string) error
+	CompleteMFALogin(payload requests.MFALoginRequest) (responses.LoginUserResponse, error)
+	EnrollTOTP(username string) (responses.EnrollTOTPResponse, error)
+	ConfirmTOTP(username string, payload requests.ConfirmTOTPRequest) (responses.ConfirmTOTPResponse, error)
+	DisableTOTP(username string, payload requests.DisableTOTPRequest) error
+	MFAStatus(username string) (responses.MFAStatusResponse, error)
 }
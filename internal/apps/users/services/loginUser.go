@@ -2,6 +2,7 @@ package users
 
 import (
 	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
 	userErrors "lemfi/simplebank/internal/apps/users/errors"
 	requests "lemfi/simplebank/internal/apps/users/requests"
 	responses "lemfi/simplebank/internal/apps/users/responses"
@@ -26,48 +27,74 @@ func (userService *UserService) LoginUser(payload requests.LoginUserRequest) (re
 		return responses.LoginUserResponse{}, userErrors.ErrInvalidCredentials
 	}
 
+	// Users with two-factor authentication get a challenge instead of tokens
+	mfaEnabled, err := userService.isMFAEnabled(payload.Username)
+	if err != nil {
+		return responses.LoginUserResponse{}, err
+	}
+	if mfaEnabled {
+		return userService.createMFAChallenge(payload.Username)
+	}
+
+	response, err := userService.createLoginSession(payload.Username, payload.UserAgent, payload.ClientIP)
+	if err != nil {
+		return responses.LoginUserResponse{}, err
+	}
+
+	config.Logger.Info("User login service completed", "username", payload.Username)
+
+	return response, nil
+}
+
+// createLoginSession issues the access and refresh tokens of a login and stores its session
+func (userService *UserService) createLoginSession(username string, userAgent string, clientIP string) (responses.LoginUserResponse, error) {
 	// Create access token
 	accessTokenDuration := config.Get().AccessTokenDuration
 	accessToken, tokenPayload, err := userService.tokenMaker.CreateToken(
-		payload.Username,
+		username,
 		"user",
 		accessTokenDuration,
 		token.TokenTypeAccessToken,
 	)
 	if err != nil {
-		config.Logger.Error("Failed to create access token", "error", err.Error(), "username", payload.Username)
+		config.Logger.Error("Failed to create access token", "error", err.Error(), "username", username)
 		return responses.LoginUserResponse{}, err
 	}
 
 	// Create refresh token
 	refreshTokenDuration := config.Get().RefreshTokenDuration
 	refreshToken, refreshTokenPayload, err := userService.tokenMaker.CreateToken(
-		payload.Username,
+		username,
 		"user",
 		refreshTokenDuration,
 		token.TokenTypeRefreshToken,
 	)
 	if err != nil {
-		config.Logger.Error("Failed to create refresh token", "error", err.Error(), "username", payload.Username)
+		config.Logger.Error("Failed to create refresh token", "error", err.Error(), "username", username)
 		return responses.LoginUserResponse{}, err
 	}
 
-	err = userService.userRespository.CreateSession(payload.Username, refreshTokenPayload.ID, refreshToken, refreshTokenPayload.ExpiredAt)
+	err = userService.userRespository.CreateSession(db.CreateSessionParams{
+		ID:           refreshTokenPayload.ID,
+		Username:     username,
+		RefreshToken: refreshToken,
+		UserAgent:    userAgent,
+		ClientIp:     clientIP,
+		ExpiresAt:    refreshTokenPayload.ExpiredAt,
+		// A login starts a new family of sessions
+		FamilyID: refreshTokenPayload.ID,
+	})
 	if err != nil {
-		config.Logger.Error("Failed to create session", "error", err.Error(), "username", payload.Username)
+		config.Logger.Error("Failed to create session", "error", err.Error(), "username", username)
 		return responses.LoginUserResponse{}, err
 	}
 
-	config.Logger.Info("User logged in successfully", "username", payload.Username)
+	config.Logger.Info("User logged in successfully", "username", username)
 
-	response := responses.LoginUserResponse{
+	return responses.LoginUserResponse{
 		AccessToken:           accessToken,
 		AccessTokenExpiresAt:  tokenPayload.ExpiredAt,
 		RefreshToken:          refreshToken,
 		RefreshTokenExpiresAt: refreshTokenPayload.ExpiredAt,
-	}
-
-	config.Logger.Info("User login service completed", "username", payload.Username)
-
-	return response, nil
+	}, nil
 }
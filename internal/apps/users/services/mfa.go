@@ -0,0 +1,344 @@
+package users
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	userErrors "lemfi/simplebank/internal/apps/users/errors"
+	requests "lemfi/simplebank/internal/apps/users/requests"
+	responses "lemfi/simplebank/internal/apps/users/responses"
+	"lemfi/simplebank/pkg/token"
+	"lemfi/simplebank/pkg/totp"
+)
+
+const (
+	// recoveryCodeCount is how many recovery codes are issued when two-factor authentication is turned on
+	recoveryCodeCount = 10
+	// recoveryCodeLength is the number of characters of a recovery code, shown split in two halves
+	recoveryCodeLength = 10
+)
+
+// recoveryCodeEncoding spells recovery codes with lowercase letters and the digits 2 to 7
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// EnrollTOTP creates a new TOTP secret for a user. Two-factor authentication stays off until a code
+// from the authenticator app is confirmed, enrolling again before that replaces the secret
+func (userService *UserService) EnrollTOTP(username string) (responses.EnrollTOTPResponse, error) {
+	config.Logger.Info("Processing TOTP enrolment in service layer", "username", username)
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		config.Logger.Error("Failed to generate TOTP secret", "error", err.Error())
+		return responses.EnrollTOTPResponse{}, err
+	}
+
+	encryptedSecret, err := totp.EncryptSecret(userService.mfaKey, username, secret)
+	if err != nil {
+		config.Logger.Error("Failed to encrypt TOTP secret", "error", err.Error())
+		return responses.EnrollTOTPResponse{}, err
+	}
+
+	_, err = userService.userRespository.CreatePendingUserMfa(db.CreatePendingUserMfaParams{
+		Username:        username,
+		EncryptedSecret: encryptedSecret,
+	})
+	if err != nil {
+		return responses.EnrollTOTPResponse{}, err
+	}
+
+	return responses.EnrollTOTPResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(userService.mfaIssuer, username, secret),
+	}, nil
+}
+
+// ConfirmTOTP turns two-factor authentication on once a code from the enrolled secret is accepted,
+// and returns the recovery codes the user can log in with when the authenticator app is lost
+func (userService *UserService) ConfirmTOTP(username string, payload requests.ConfirmTOTPRequest) (responses.ConfirmTOTPResponse, error) {
+	config.Logger.Info("Processing TOTP enrolment confirmation in service layer", "username", username)
+
+	userMfa, err := userService.userRespository.GetUserMfa(username)
+	if errors.Is(err, userErrors.ErrMFANotEnabled) {
+		return responses.ConfirmTOTPResponse{}, userErrors.ErrMFAEnrolmentNotFound
+	}
+	if err != nil {
+		return responses.ConfirmTOTPResponse{}, err
+	}
+	if userMfa.EnabledAt.Valid {
+		return responses.ConfirmTOTPResponse{}, userErrors.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.DecryptSecret(userService.mfaKey, username, userMfa.EncryptedSecret)
+	if err != nil {
+		config.Logger.Error("Failed to decrypt TOTP secret", "error", err.Error(), "username", username)
+		return responses.ConfirmTOTPResponse{}, err
+	}
+
+	step, valid := totp.Validate(secret, payload.Code, time.Now(), userMfa.LastUsedStep)
+	if !valid {
+		config.Logger.Error("Invalid TOTP code during enrolment confirmation", "username", username)
+		return responses.ConfirmTOTPResponse{}, userErrors.ErrInvalidMFACode
+	}
+
+	recoveryCodes, recoveryCodeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		config.Logger.Error("Failed to generate recovery codes", "error", err.Error())
+		return responses.ConfirmTOTPResponse{}, err
+	}
+
+	_, err = userService.userRespository.EnableMfa(db.EnableMfaTxParams{
+		Username:           username,
+		TotpStep:           step,
+		RecoveryCodeHashes: recoveryCodeHashes,
+	})
+	if err != nil {
+		return responses.ConfirmTOTPResponse{}, err
+	}
+
+	config.Logger.Info("Two-factor authentication enabled", "username", username)
+
+	return responses.ConfirmTOTPResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// DisableTOTP turns two-factor authentication off once a TOTP or recovery code is accepted,
+// so that a stolen access token alone cannot remove it
+func (userService *UserService) DisableTOTP(username string, payload requests.DisableTOTPRequest) error {
+	config.Logger.Info("Processing TOTP removal in service layer", "username", username)
+
+	userMfa, err := userService.getEnabledMFA(username)
+	if err != nil {
+		return err
+	}
+
+	err = userService.verifySecondFactor(userMfa, payload.Code)
+	if err != nil {
+		return err
+	}
+
+	err = userService.userRespository.DeleteUserMfa(username)
+	if err != nil {
+		return err
+	}
+
+	config.Logger.Info("Two-factor authentication disabled", "username", username)
+
+	return nil
+}
+
+// MFAStatus describes the two-factor authentication of a user
+func (userService *UserService) MFAStatus(username string) (responses.MFAStatusResponse, error) {
+	userMfa, err := userService.getEnabledMFA(username)
+	if errors.Is(err, userErrors.ErrMFANotEnabled) {
+		return responses.MFAStatusResponse{}, nil
+	}
+	if err != nil {
+		return responses.MFAStatusResponse{}, err
+	}
+
+	remaining, err := userService.userRespository.CountUnusedMfaRecoveryCodes(username)
+	if err != nil {
+		return responses.MFAStatusResponse{}, err
+	}
+
+	return responses.MFAStatusResponse{
+		Enabled:                true,
+		EnabledAt:              &userMfa.EnabledAt.Time,
+		RecoveryCodesRemaining: remaining,
+	}, nil
+}
+
+// CompleteMFALogin exchanges the MFA token of a login and a second factor for access and refresh tokens.
+// The MFA token is used up by the login it completes
+func (userService *UserService) CompleteMFALogin(payload requests.MFALoginRequest) (responses.LoginUserResponse, error) {
+	config.Logger.Info("Processing two-factor login in service layer")
+
+	challengePayload, err := userService.tokenMaker.VerifyToken(payload.MFAToken, token.TokenTypeMFAChallenge)
+	if err != nil {
+		config.Logger.Error("Invalid MFA token", "error", err.Error())
+		return responses.LoginUserResponse{}, userErrors.ErrInvalidMFAToken
+	}
+
+	userMfa, err := userService.getEnabledMFA(challengePayload.Username)
+	if errors.Is(err, userErrors.ErrMFANotEnabled) {
+		// Two-factor authentication was turned off after the password was accepted
+		return responses.LoginUserResponse{}, userErrors.ErrInvalidMFAToken
+	}
+	if err != nil {
+		return responses.LoginUserResponse{}, err
+	}
+
+	err = userService.verifySecondFactor(userMfa, payload.Code)
+	if err != nil {
+		return responses.LoginUserResponse{}, err
+	}
+
+	err = userService.userRespository.UseMfaChallenge(challengePayload.ID)
+	if err != nil {
+		config.Logger.Error("MFA token was already used", "username", challengePayload.Username)
+		return responses.LoginUserResponse{}, err
+	}
+
+	return userService.createLoginSession(challengePayload.Username, payload.UserAgent, payload.ClientIP)
+}
+
+// isMFAEnabled reports whether a user has confirmed a TOTP enrolment
+func (userService *UserService) isMFAEnabled(username string) (bool, error) {
+	_, err := userService.getEnabledMFA(username)
+	if errors.Is(err, userErrors.ErrMFANotEnabled) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// getEnabledMFA returns the TOTP enrolment of a user, failing with ErrMFANotEnabled while it is pending
+func (userService *UserService) getEnabledMFA(username string) (db.UserMfa, error) {
+	userMfa, err := userService.userRespository.GetUserMfa(username)
+	if err != nil {
+		return db.UserMfa{}, err
+	}
+	if !userMfa.EnabledAt.Valid {
+		return db.UserMfa{}, userErrors.ErrMFANotEnabled
+	}
+
+	return userMfa, nil
+}
+
+// createMFAChallenge issues the short-lived token a login is completed with once the second factor is checked
+func (userService *UserService) createMFAChallenge(username string) (responses.LoginUserResponse, error) {
+	mfaToken, mfaTokenPayload, err := userService.tokenMaker.CreateToken(
+		username,
+		"user",
+		userService.mfaChallengeDuration,
+		token.TokenTypeMFAChallenge,
+	)
+	if err != nil {
+		config.Logger.Error("Failed to create MFA token", "error", err.Error(), "username", username)
+		return responses.LoginUserResponse{}, err
+	}
+
+	err = userService.userRespository.CreateMfaChallenge(db.CreateMfaChallengeParams{
+		ID:        mfaTokenPayload.ID,
+		Username:  username,
+		ExpiresAt: mfaTokenPayload.ExpiredAt,
+	})
+	if err != nil {
+		return responses.LoginUserResponse{}, err
+	}
+
+	config.Logger.Info("Password accepted, waiting for second factor", "username", username)
+
+	return responses.LoginUserResponse{
+		MFAChallenge: &responses.MFAChallengeResponse{
+			MFAToken:          mfaToken,
+			MFATokenExpiresAt: mfaTokenPayload.ExpiredAt,
+		},
+	}, nil
+}
+
+// verifySecondFactor accepts a 6 digit TOTP code or an unused recovery code, and uses it up.
+// Every attempt is counted before the code is checked, and too many wrong codes in a row
+// refuse any code, right or wrong, until the lockout is over
+func (userService *UserService) verifySecondFactor(userMfa db.UserMfa, code string) error {
+	err := userService.userRespository.CountMfaAttempt(db.CountMfaAttemptParams{
+		MaxFailedAttempts: userService.mfaMaxFailedAttempts,
+		LockedUntil:       time.Now().Add(userService.mfaLockoutDuration),
+		Username:          userMfa.Username,
+	})
+	if err != nil {
+		config.Logger.Warn("Second factor refused while locked out", "username", userMfa.Username)
+		return err
+	}
+
+	err = userService.checkSecondFactor(userMfa, strings.TrimSpace(code))
+	if err != nil {
+		return err
+	}
+
+	return userService.userRespository.ResetMfaAttempts(userMfa.Username)
+}
+
+// checkSecondFactor checks a code without counting the attempt
+func (userService *UserService) checkSecondFactor(userMfa db.UserMfa, code string) error {
+	if !isTOTPCode(code) {
+		err := userService.userRespository.UseMfaRecoveryCode(userMfa.Username, hashRecoveryCode(code))
+		if err != nil {
+			config.Logger.Error("Invalid recovery code", "username", userMfa.Username)
+			return err
+		}
+
+		config.Logger.Warn("Recovery code used", "username", userMfa.Username)
+		return nil
+	}
+
+	secret, err := totp.DecryptSecret(userService.mfaKey, userMfa.Username, userMfa.EncryptedSecret)
+	if err != nil {
+		config.Logger.Error("Failed to decrypt TOTP secret", "error", err.Error(), "username", userMfa.Username)
+		return err
+	}
+
+	step, valid := totp.Validate(secret, code, time.Now(), userMfa.LastUsedStep)
+	if !valid {
+		config.Logger.Error("Invalid TOTP code", "username", userMfa.Username)
+		return userErrors.ErrInvalidMFACode
+	}
+
+	// Recording the step fails when the same code was accepted concurrently
+	return userService.userRespository.UseTotpStep(userMfa.Username, step)
+}
+
+// isTOTPCode tells TOTP codes apart from recovery codes, which are longer
+func isTOTPCode(code string) bool {
+	if len(code) != totp.Digits {
+		return false
+	}
+	for _, char := range code {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// generateRecoveryCodes returns new recovery codes, formatted like "abcde-fgh23", and the hashes they are stored as
+func generateRecoveryCodes() ([]string, [][]byte, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([][]byte, 0, recoveryCodeCount)
+
+	for range recoveryCodeCount {
+		random := make([]byte, recoveryCodeLength)
+		_, err := rand.Read(random)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		code := recoveryCodeEncoding.EncodeToString(random)[:recoveryCodeLength]
+
+		codes = append(codes, code[:recoveryCodeLength/2]+"-"+code[recoveryCodeLength/2:])
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode returns the SHA-256 hash a recovery code is stored as,
+// ignoring case and the separator so that codes can be typed loosely
+func hashRecoveryCode(code string) []byte {
+	hash := sha256.Sum256([]byte(normalizeRecoveryCode(code)))
+	return hash[:]
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
@@ -0,0 +1,355 @@
+package users
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	db "lemfi/simplebank/db/sqlc"
+	userErrors "lemfi/simplebank/internal/apps/users/errors"
+	requests "lemfi/simplebank/internal/apps/users/requests"
+	"lemfi/simplebank/pkg/cipher"
+	"lemfi/simplebank/pkg/token"
+	"lemfi/simplebank/pkg/totp"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+const testMFAKey = "abcdefghijklmnopqrstuvwxyz123456"
+
+func currentTOTPCode(t *testing.T, secret string) string {
+	code, err := totp.GenerateCode(secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+	return code
+}
+
+// wrongTOTPCode returns a code no step around now accepts
+func wrongTOTPCode(t *testing.T, secret string) string {
+	step := totp.Step(time.Now())
+	for _, candidate := range []string{"000000", "111111", "222222", "333333"} {
+		accepted := false
+		for offset := int64(-2); offset <= 2; offset++ {
+			code, err := totp.GenerateCode(secret, step+offset)
+			require.NoError(t, err)
+			accepted = accepted || code == candidate
+		}
+		if !accepted {
+			return candidate
+		}
+	}
+	t.Fatal("no wrong TOTP code found")
+	return ""
+}
+
+func TestEnrollAndConfirmTOTP(t *testing.T) {
+	var pending db.UserMfa
+	var enabled db.EnableMfaTxParams
+	mockRepo := &MockUserRepository{
+		createPendingUserMfaFunc: func(params db.CreatePendingUserMfaParams) (db.UserMfa, error) {
+			pending = db.UserMfa{Username: params.Username, EncryptedSecret: params.EncryptedSecret}
+			return pending, nil
+		},
+		getUserMfaFunc: func(username string) (db.UserMfa, error) {
+			return pending, nil
+		},
+		enableMfaFunc: func(params db.EnableMfaTxParams) (db.UserMfa, error) {
+			enabled = params
+			return db.UserMfa{}, nil
+		},
+	}
+	userService := &UserService{userRespository: mockRepo, mfaKey: testMFAKey, mfaIssuer: "SimpleBank"}
+
+	enrolment, err := userService.EnrollTOTP("testuser")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(enrolment.ProvisioningURI, "otpauth://totp/SimpleBank:testuser?"))
+	require.Contains(t, enrolment.ProvisioningURI, "secret="+enrolment.Secret)
+
+	// The secret is stored encrypted, bound to the user
+	require.NotContains(t, string(pending.EncryptedSecret), enrolment.Secret)
+	secret, err := totp.DecryptSecret(testMFAKey, "testuser", pending.EncryptedSecret)
+	require.NoError(t, err)
+	require.Equal(t, enrolment.Secret, secret)
+
+	_, err = userService.ConfirmTOTP("testuser", requests.ConfirmTOTPRequest{Code: wrongTOTPCode(t, secret)})
+	require.ErrorIs(t, err, userErrors.ErrInvalidMFACode)
+
+	confirmation, err := userService.ConfirmTOTP("testuser", requests.ConfirmTOTPRequest{Code: currentTOTPCode(t, secret)})
+	require.NoError(t, err)
+	require.Equal(t, "testuser", enabled.Username)
+	require.InDelta(t, totp.Step(time.Now()), enabled.TotpStep, 1)
+	require.Len(t, confirmation.RecoveryCodes, recoveryCodeCount)
+	require.Len(t, enabled.RecoveryCodeHashes, recoveryCodeCount)
+	for i, code := range confirmation.RecoveryCodes {
+		require.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, code)
+		require.Equal(t, hashRecoveryCode(code), enabled.RecoveryCodeHashes[i])
+	}
+}
+
+func TestConfirmTOTP_RequiresPendingEnrolment(t *testing.T) {
+	userService := &UserService{userRespository: &MockUserRepository{}, mfaKey: testMFAKey}
+
+	_, err := userService.ConfirmTOTP("testuser", requests.ConfirmTOTPRequest{Code: "123456"})
+	require.ErrorIs(t, err, userErrors.ErrMFAEnrolmentNotFound)
+
+	userMfa, _ := newEnabledUserMfa(t, "testuser")
+	userService.userRespository = &MockUserRepository{
+		getUserMfaFunc: func(username string) (db.UserMfa, error) {
+			return userMfa, nil
+		},
+	}
+
+	_, err = userService.ConfirmTOTP("testuser", requests.ConfirmTOTPRequest{Code: "123456"})
+	require.ErrorIs(t, err, userErrors.ErrMFAAlreadyEnabled)
+}
+
+func TestLoginUser_WithMFA(t *testing.T) {
+	hashedPassword, err := cipher.HashPassword("secret")
+	require.NoError(t, err)
+	tokenMaker, err := token.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	userMfa, secret := newEnabledUserMfa(t, "testuser")
+	var sessions []db.CreateSessionParams
+	var usedStep int64
+	mockRepo := &MockUserRepository{
+		getUserHashedPasswordFunc: func(username string) (string, error) {
+			return hashedPassword, nil
+		},
+		getUserMfaFunc: func(username string) (db.UserMfa, error) {
+			return userMfa, nil
+		},
+		createSessionFunc: func(params db.CreateSessionParams) error {
+			sessions = append(sessions, params)
+			return nil
+		},
+		useTotpStepFunc: func(username string, step int64) error {
+			usedStep = step
+			return nil
+		},
+	}
+	userService := &UserService{
+		userRespository:      mockRepo,
+		tokenMaker:           tokenMaker,
+		mfaKey:               testMFAKey,
+		mfaChallengeDuration: 5 * time.Minute,
+	}
+
+	// The password alone only gets a challenge
+	response, err := userService.LoginUser(requests.LoginUserRequest{Username: "testuser", Password: "secret"})
+	require.NoError(t, err)
+	require.NotNil(t, response.MFAChallenge)
+	require.Empty(t, response.AccessToken)
+	require.Empty(t, response.RefreshToken)
+	require.Empty(t, sessions)
+
+	// The challenge token is not an access token
+	_, err = tokenMaker.VerifyToken(response.MFAChallenge.MFAToken, token.TokenTypeAccessToken)
+	require.Error(t, err)
+
+	_, err = userService.CompleteMFALogin(requests.MFALoginRequest{MFAToken: "not a token", Code: currentTOTPCode(t, secret)})
+	require.ErrorIs(t, err, userErrors.ErrInvalidMFAToken)
+
+	tokens, err := userService.CompleteMFALogin(requests.MFALoginRequest{
+		MFAToken:  response.MFAChallenge.MFAToken,
+		Code:      currentTOTPCode(t, secret),
+		UserAgent: "Mozilla/5.0",
+		ClientIP:  "203.0.113.7",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, tokens.AccessToken)
+	require.Nil(t, tokens.MFAChallenge)
+	require.InDelta(t, totp.Step(time.Now()), usedStep, 1)
+	require.Len(t, sessions, 1)
+	require.Equal(t, tokens.RefreshToken, sessions[0].RefreshToken)
+	require.Equal(t, "Mozilla/5.0", sessions[0].UserAgent)
+}
+
+func TestCompleteMFALogin_RecoveryCode(t *testing.T) {
+	tokenMaker, err := token.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+	mfaToken, _, err := tokenMaker.CreateToken("testuser", "user", time.Minute, token.TokenTypeMFAChallenge)
+	require.NoError(t, err)
+
+	userMfa, _ := newEnabledUserMfa(t, "testuser")
+	var usedHash []byte
+	mockRepo := &MockUserRepository{
+		getUserMfaFunc: func(username string) (db.UserMfa, error) {
+			return userMfa, nil
+		},
+		useMfaRecoveryCodeFunc: func(username string, codeHash []byte) error {
+			usedHash = codeHash
+			return nil
+		},
+	}
+	userService := &UserService{userRespository: mockRepo, tokenMaker: tokenMaker, mfaKey: testMFAKey}
+
+	// Recovery codes are accepted regardless of case and separator
+	_, err = userService.CompleteMFALogin(requests.MFALoginRequest{MFAToken: mfaToken, Code: "ABCDE-FGH23"})
+	require.NoError(t, err)
+	require.Equal(t, hashRecoveryCode("abcdefgh23"), usedHash)
+
+	mockRepo.useMfaRecoveryCodeFunc = func(username string, codeHash []byte) error {
+		return userErrors.ErrInvalidMFACode
+	}
+	_, err = userService.CompleteMFALogin(requests.MFALoginRequest{MFAToken: mfaToken, Code: "abcde-fgh23"})
+	require.ErrorIs(t, err, userErrors.ErrInvalidMFACode)
+}
+
+func TestDisableTOTP(t *testing.T) {
+	userMfa, secret := newEnabledUserMfa(t, "testuser")
+	deleted := false
+	mockRepo := &MockUserRepository{
+		getUserMfaFunc: func(username string) (db.UserMfa, error) {
+			return userMfa, nil
+		},
+		useTotpStepFunc: func(username string, step int64) error {
+			return nil
+		},
+		deleteUserMfaFunc: func(username string) error {
+			deleted = true
+			return nil
+		},
+	}
+	userService := &UserService{userRespository: mockRepo, mfaKey: testMFAKey}
+
+	err := userService.DisableTOTP("testuser", requests.DisableTOTPRequest{Code: wrongTOTPCode(t, secret)})
+	require.ErrorIs(t, err, userErrors.ErrInvalidMFACode)
+	require.False(t, deleted)
+
+	err = userService.DisableTOTP("testuser", requests.DisableTOTPRequest{Code: currentTOTPCode(t, secret)})
+	require.NoError(t, err)
+	require.True(t, deleted)
+}
+
+func TestCompleteMFALogin_ChallengeIsSingleUse(t *testing.T) {
+	hashedPassword, err := cipher.HashPassword("secret")
+	require.NoError(t, err)
+	tokenMaker, err := token.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	userMfa, secret := newEnabledUserMfa(t, "testuser")
+	challenges := map[uuid.UUID]bool{}
+	sessions := 0
+	mockRepo := &MockUserRepository{
+		getUserHashedPasswordFunc: func(username string) (string, error) {
+			return hashedPassword, nil
+		},
+		getUserMfaFunc: func(username string) (db.UserMfa, error) {
+			return userMfa, nil
+		},
+		createSessionFunc: func(params db.CreateSessionParams) error {
+			sessions++
+			return nil
+		},
+		useTotpStepFunc: func(username string, step int64) error {
+			return nil
+		},
+		createMfaChallengeFunc: func(params db.CreateMfaChallengeParams) error {
+			require.Equal(t, "testuser", params.Username)
+			challenges[params.ID] = true
+			return nil
+		},
+		// Mirrors UseMfaChallenge, which deletes the challenge it returns
+		useMfaChallengeFunc: func(id uuid.UUID) error {
+			if !challenges[id] {
+				return userErrors.ErrInvalidMFAToken
+			}
+			delete(challenges, id)
+			return nil
+		},
+	}
+	userService := &UserService{
+		userRespository:      mockRepo,
+		tokenMaker:           tokenMaker,
+		mfaKey:               testMFAKey,
+		mfaChallengeDuration: 5 * time.Minute,
+	}
+
+	response, err := userService.LoginUser(requests.LoginUserRequest{Username: "testuser", Password: "secret"})
+	require.NoError(t, err)
+	require.Len(t, challenges, 1)
+
+	_, err = userService.CompleteMFALogin(requests.MFALoginRequest{MFAToken: response.MFAChallenge.MFAToken, Code: currentTOTPCode(t, secret)})
+	require.NoError(t, err)
+	require.Equal(t, 1, sessions)
+
+	// The MFA token of a completed login cannot start another session, even with a valid code
+	_, err = userService.CompleteMFALogin(requests.MFALoginRequest{MFAToken: response.MFAChallenge.MFAToken, Code: currentTOTPCode(t, secret)})
+	require.ErrorIs(t, err, userErrors.ErrInvalidMFAToken)
+	require.Equal(t, 1, sessions)
+}
+
+func TestCompleteMFALogin_LocksOutAfterFailedAttempts(t *testing.T) {
+	tokenMaker, err := token.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+	mfaToken, _, err := tokenMaker.CreateToken("testuser", "user", time.Minute, token.TokenTypeMFAChallenge)
+	require.NoError(t, err)
+
+	userMfa, secret := newEnabledUserMfa(t, "testuser")
+	usedSteps := 0
+	mockRepo := &MockUserRepository{
+		getUserMfaFunc: func(username string) (db.UserMfa, error) {
+			return userMfa, nil
+		},
+		useTotpStepFunc: func(username string, step int64) error {
+			usedSteps++
+			return nil
+		},
+		// Mirrors CountMfaAttempt and ResetMfaAttempts
+		countMfaAttemptFunc: func(params db.CountMfaAttemptParams) error {
+			require.Equal(t, "testuser", params.Username)
+			if userMfa.LockedUntil.Valid && userMfa.LockedUntil.Time.After(time.Now()) {
+				return userErrors.ErrMFALocked
+			}
+			userMfa.FailedAttempts++
+			userMfa.LockedUntil = pgtype.Timestamptz{}
+			if userMfa.FailedAttempts >= params.MaxFailedAttempts {
+				userMfa.FailedAttempts = 0
+				userMfa.LockedUntil = pgtype.Timestamptz{Time: params.LockedUntil, Valid: true}
+			}
+			return nil
+		},
+		resetMfaAttemptsFunc: func(username string) error {
+			userMfa.FailedAttempts = 0
+			userMfa.LockedUntil = pgtype.Timestamptz{}
+			return nil
+		},
+	}
+	userService := &UserService{
+		userRespository:      mockRepo,
+		tokenMaker:           tokenMaker,
+		mfaKey:               testMFAKey,
+		mfaMaxFailedAttempts: 3,
+		mfaLockoutDuration:   15 * time.Minute,
+	}
+
+	// A valid code clears the failed attempts before the limit
+	for range 2 {
+		_, err = userService.CompleteMFALogin(requests.MFALoginRequest{MFAToken: mfaToken, Code: wrongTOTPCode(t, secret)})
+		require.ErrorIs(t, err, userErrors.ErrInvalidMFACode)
+	}
+	_, err = userService.CompleteMFALogin(requests.MFALoginRequest{MFAToken: mfaToken, Code: currentTOTPCode(t, secret)})
+	require.NoError(t, err)
+	require.Zero(t, userMfa.FailedAttempts)
+
+	// The limit of wrong codes in a row locks the user out
+	for range 3 {
+		_, err = userService.CompleteMFALogin(requests.MFALoginRequest{MFAToken: mfaToken, Code: wrongTOTPCode(t, secret)})
+		require.ErrorIs(t, err, userErrors.ErrInvalidMFACode)
+	}
+	require.True(t, userMfa.LockedUntil.Valid)
+	require.WithinDuration(t, time.Now().Add(15*time.Minute), userMfa.LockedUntil.Time, time.Minute)
+
+	// Even a valid code, or a recovery code, is refused without being checked until the lockout is over
+	_, err = userService.CompleteMFALogin(requests.MFALoginRequest{MFAToken: mfaToken, Code: currentTOTPCode(t, secret)})
+	require.ErrorIs(t, err, userErrors.ErrMFALocked)
+	_, err = userService.CompleteMFALogin(requests.MFALoginRequest{MFAToken: mfaToken, Code: "abcde-fgh23"})
+	require.ErrorIs(t, err, userErrors.ErrMFALocked)
+	require.Equal(t, 1, usedSteps)
+
+	userMfa.LockedUntil.Time = time.Now().Add(-time.Second)
+	_, err = userService.CompleteMFALogin(requests.MFALoginRequest{MFAToken: mfaToken, Code: currentTOTPCode(t, secret)})
+	require.NoError(t, err)
+}
@@ -0,0 +1,142 @@
+package users
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
+	userErrors "lemfi/simplebank/internal/apps/users/errors"
+	requests "lemfi/simplebank/internal/apps/users/requests"
+	"lemfi/simplebank/pkg/cipher"
+	"lemfi/simplebank/pkg/mailer"
+)
+
+// passwordResetPurpose is the purpose password reset tokens are signed and stored with
+const passwordResetPurpose = db.UserTokenPurposePasswordReset
+
+// ChangePassword sets a new password for a signed-in user once their current password is confirmed.
+// Every session of the user is blocked and access tokens issued before the change stop working
+func (userService *UserService) ChangePassword(username string, payload requests.ChangePasswordRequest) error {
+	config.Logger.Info("Processing password change in service layer", "username", username)
+
+	userHashedPassword, err := userService.userRespository.GetUserHashedPassword(username)
+	if err != nil {
+		return err
+	}
+
+	err = cipher.CheckPassword(payload.CurrentPassword, userHashedPassword)
+	if err != nil {
+		config.Logger.Error("Incorrect current password during password change", "username", username)
+		return userErrors.ErrIncorrectCurrentPassword
+	}
+
+	hashedPassword, err := cipher.HashPassword(payload.NewPassword)
+	if err != nil {
+		config.Logger.Error("Failed to hash password", "error", err.Error())
+		return err
+	}
+
+	_, err = userService.userRespository.ChangePassword(db.ChangePasswordTxParams{
+		Username:          username,
+		HashedPassword:    hashedPassword,
+		PasswordChangedAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	config.Logger.Info("Password changed, sessions blocked", "username", username)
+
+	return nil
+}
+
+// ForgotPassword mails a single-use password reset link to the user with the given email address.
+// Nothing is sent for an unknown address, and the caller is not told so that addresses cannot be probed
+func (userService *UserService) ForgotPassword(payload requests.ForgotPasswordRequest) error {
+	config.Logger.Info("Processing password reset link request in service layer")
+
+	user, err := userService.userRespository.GetUserByEmail(payload.Email)
+	if errors.Is(err, userErrors.ErrUserNotFound) {
+		config.Logger.Warn("Password reset link requested for unknown email")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	resetToken, tokenHash, err := cipher.NewSignedToken(userService.tokenKey, passwordResetPurpose)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(userService.resetTokenExpiry)
+	_, err = userService.userRespository.CreateUserToken(db.CreateUserTokenParams{
+		Username:  user.Username,
+		Purpose:   passwordResetPurpose,
+		TokenHash: tokenHash,
+		Email:     user.Email,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	link, err := url.Parse(userService.resetURL)
+	if err != nil {
+		return err
+	}
+	query := link.Query()
+	query.Set("token", resetToken)
+	link.RawQuery = query.Encode()
+
+	err = userService.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen this link to choose a new password:\n\n%s\n\nThe link expires at %s. If you did not ask to reset your password you can ignore this email.\n",
+			user.Username, link.String(), expiresAt.UTC().Format(time.RFC1123)),
+	})
+	if err != nil {
+		return err
+	}
+
+	config.Logger.Info("Password reset email sent", "username", user.Username)
+
+	return nil
+}
+
+// ResetPassword uses a mailed reset token to set a new password for the user it was issued to.
+// Every session of the user is blocked, as with ChangePassword
+func (userService *UserService) ResetPassword(payload requests.ResetPasswordRequest) error {
+	config.Logger.Info("Processing password reset in service layer")
+
+	tokenHash, err := cipher.VerifySignedToken(userService.tokenKey, passwordResetPurpose, payload.Token)
+	if errors.Is(err, cipher.ErrInvalidSignedToken) {
+		config.Logger.Error("Password reset token has an invalid signature")
+		return userErrors.ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := cipher.HashPassword(payload.NewPassword)
+	if err != nil {
+		config.Logger.Error("Failed to hash password", "error", err.Error())
+		return err
+	}
+
+	result, err := userService.userRespository.ChangePassword(db.ChangePasswordTxParams{
+		HashedPassword:    hashedPassword,
+		PasswordChangedAt: time.Now(),
+		ResetTokenHash:    tokenHash,
+	})
+	if err != nil {
+		return err
+	}
+
+	config.Logger.Info("Password reset, sessions blocked", "username", result.User.Username)
+
+	return nil
+}
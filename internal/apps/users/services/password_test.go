@@ -0,0 +1,146 @@
+package users
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	db "lemfi/simplebank/db/sqlc"
+	userErrors "lemfi/simplebank/internal/apps/users/errors"
+	requests "lemfi/simplebank/internal/apps/users/requests"
+	"lemfi/simplebank/pkg/cipher"
+	"lemfi/simplebank/pkg/mailer"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangePassword(t *testing.T) {
+	currentHash, err := cipher.HashPassword("current-secret")
+	require.NoError(t, err)
+
+	var changed db.ChangePasswordTxParams
+	mockRepo := &MockUserRepository{
+		getUserHashedPasswordFunc: func(username string) (string, error) {
+			return currentHash, nil
+		},
+		changePasswordFunc: func(params db.ChangePasswordTxParams) (db.ChangePasswordTxResult, error) {
+			changed = params
+			return db.ChangePasswordTxResult{User: db.UpdateUserRow{Username: params.Username}}, nil
+		},
+	}
+	userService := newMockUserService(mockRepo, mailer.NewMemoryMailer())
+
+	err = userService.ChangePassword("testuser", requests.ChangePasswordRequest{
+		CurrentPassword: "current-secret",
+		NewPassword:     "new-secret",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "testuser", changed.Username)
+	require.Nil(t, changed.ResetTokenHash)
+	require.NoError(t, cipher.CheckPassword("new-secret", changed.HashedPassword))
+	require.WithinDuration(t, time.Now(), changed.PasswordChangedAt, time.Minute)
+}
+
+func TestChangePassword_IncorrectCurrentPassword(t *testing.T) {
+	currentHash, err := cipher.HashPassword("current-secret")
+	require.NoError(t, err)
+
+	mockRepo := &MockUserRepository{
+		getUserHashedPasswordFunc: func(username string) (string, error) {
+			return currentHash, nil
+		},
+		changePasswordFunc: func(params db.ChangePasswordTxParams) (db.ChangePasswordTxResult, error) {
+			t.Fatal("the password must not change without the current password")
+			return db.ChangePasswordTxResult{}, nil
+		},
+	}
+	userService := newMockUserService(mockRepo, mailer.NewMemoryMailer())
+
+	err = userService.ChangePassword("testuser", requests.ChangePasswordRequest{
+		CurrentPassword: "wrong-secret",
+		NewPassword:     "new-secret",
+	})
+	require.ErrorIs(t, err, userErrors.ErrIncorrectCurrentPassword)
+}
+
+func TestForgotAndResetPassword(t *testing.T) {
+	var storedHash []byte
+	mockRepo := &MockUserRepository{
+		getUserByEmailFunc: func(email string) (db.GetUserByEmailRow, error) {
+			return db.GetUserByEmailRow{Username: "testuser", Email: email}, nil
+		},
+		createUserTokenFunc: func(params db.CreateUserTokenParams) (db.UserToken, error) {
+			require.Equal(t, passwordResetPurpose, params.Purpose)
+			require.Equal(t, "testuser", params.Username)
+			require.WithinDuration(t, time.Now().Add(time.Hour), params.ExpiresAt, time.Minute)
+			storedHash = params.TokenHash
+			return db.UserToken{ID: 1, Username: params.Username}, nil
+		},
+		changePasswordFunc: func(params db.ChangePasswordTxParams) (db.ChangePasswordTxResult, error) {
+			if !bytes.Equal(storedHash, params.ResetTokenHash) {
+				return db.ChangePasswordTxResult{}, userErrors.ErrInvalidResetToken
+			}
+			return db.ChangePasswordTxResult{User: db.UpdateUserRow{Username: "testuser"}}, nil
+		},
+	}
+	memoryMailer := mailer.NewMemoryMailer()
+	userService := newMockUserService(mockRepo, memoryMailer)
+
+	err := userService.ForgotPassword(requests.ForgotPasswordRequest{Email: "test@example.com"})
+	require.NoError(t, err)
+
+	// The mailed link carries the token that resets the password
+	messages := memoryMailer.Messages()
+	require.Len(t, messages, 1)
+	require.Equal(t, "test@example.com", messages[0].To)
+	var link *url.URL
+	for _, field := range strings.Fields(messages[0].Body) {
+		if strings.HasPrefix(field, "https://") {
+			link, err = url.Parse(field)
+			require.NoError(t, err)
+		}
+	}
+	require.NotNil(t, link)
+
+	err = userService.ResetPassword(requests.ResetPasswordRequest{
+		Token:       link.Query().Get("token"),
+		NewPassword: "new-secret",
+	})
+	require.NoError(t, err)
+}
+
+func TestForgotPassword_UnknownEmail(t *testing.T) {
+	mockRepo := &MockUserRepository{
+		getUserByEmailFunc: func(email string) (db.GetUserByEmailRow, error) {
+			return db.GetUserByEmailRow{}, userErrors.ErrUserNotFound
+		},
+	}
+	memoryMailer := mailer.NewMemoryMailer()
+	userService := newMockUserService(mockRepo, memoryMailer)
+
+	err := userService.ForgotPassword(requests.ForgotPasswordRequest{Email: "nobody@example.com"})
+	require.NoError(t, err)
+	require.Empty(t, memoryMailer.Messages())
+}
+
+func TestResetPassword_TokenSignedForAnotherPurpose(t *testing.T) {
+	mockRepo := &MockUserRepository{
+		changePasswordFunc: func(params db.ChangePasswordTxParams) (db.ChangePasswordTxResult, error) {
+			t.Fatal("a token with an invalid signature must not be used")
+			return db.ChangePasswordTxResult{}, nil
+		},
+	}
+	userService := newMockUserService(mockRepo, mailer.NewMemoryMailer())
+
+	// An email verification token cannot reset a password
+	verificationToken, _, err := cipher.NewSignedToken(userService.tokenKey, emailVerificationPurpose)
+	require.NoError(t, err)
+
+	err = userService.ResetPassword(requests.ResetPasswordRequest{
+		Token:       verificationToken,
+		NewPassword: "new-secret",
+	})
+	require.ErrorIs(t, err, userErrors.ErrInvalidResetToken)
+}
@@ -1,7 +1,9 @@
 package users
 
 import (
+	"errors"
 	"lemfi/simplebank/config"
+	db "lemfi/simplebank/db/sqlc"
 	userErrors "lemfi/simplebank/internal/apps/users/errors"
 	requests "lemfi/simplebank/internal/apps/users/requests"
 	responses "lemfi/simplebank/internal/apps/users/responses"
@@ -26,10 +28,8 @@ func (userService *UserService) RefreshToken(payload requests.RefreshTokenReques
 		return responses.RefreshTokenResponse{}, userErrors.ErrInvalidCredentials
 	}
 
-	// Check if session is blocked
 	if session.IsBlocked {
-		config.Logger.Error("Session is blocked", "session_id", refreshTokenPayload.ID)
-		return responses.RefreshTokenResponse{}, userErrors.ErrInvalidCredentials
+		return responses.RefreshTokenResponse{}, userService.refuseBlockedSession(session, payload)
 	}
 
 	// Check if session has expired (compare with current time)
@@ -70,26 +70,29 @@ func (userService *UserService) RefreshToken(payload requests.RefreshTokenReques
 		return responses.RefreshTokenResponse{}, err
 	}
 
-	// Create new session with new refresh token
-	err = userService.userRespository.CreateSession(
-		refreshTokenPayload.Username,
-		newRefreshTokenPayload.ID,
-		newRefreshToken,
-		newRefreshTokenPayload.ExpiredAt,
-	)
+	// Block the old session and create the new one in the same family (token rotation for security)
+	_, err = userService.userRespository.RotateSession(refreshTokenPayload.ID, db.CreateSessionParams{
+		ID:           newRefreshTokenPayload.ID,
+		Username:     refreshTokenPayload.Username,
+		RefreshToken: newRefreshToken,
+		UserAgent:    payload.UserAgent,
+		ClientIp:     payload.ClientIP,
+		ExpiresAt:    newRefreshTokenPayload.ExpiredAt,
+	})
 	if err != nil {
-		config.Logger.Error("Failed to create new session", "error", err.Error(), "username", refreshTokenPayload.Username)
+		// Another request exchanged or signed out of the session first
+		if errors.Is(err, userErrors.ErrRefreshTokenReused) {
+			session, err = userService.userRespository.GetSession(refreshTokenPayload.ID)
+			if err != nil {
+				config.Logger.Error("Failed to get blocked session", "error", err.Error(), "session_id", refreshTokenPayload.ID)
+				return responses.RefreshTokenResponse{}, err
+			}
+			return responses.RefreshTokenResponse{}, userService.refuseBlockedSession(session, payload)
+		}
+		config.Logger.Error("Failed to rotate session", "error", err.Error(), "username", refreshTokenPayload.Username)
 		return responses.RefreshTokenResponse{}, err
 	}
 
-	// Block the old session for security (token rotation)
-	err = userService.userRespository.BlockSession(refreshTokenPayload.ID)
-	if err != nil {
-		config.Logger.Error("Failed to block old session", "error", err.Error(), "session_id", refreshTokenPayload.ID)
-		// Don't fail the entire request if blocking fails, but log it
-		// The old refresh token will still expire naturally
-	}
-
 	config.Logger.Info("Token refreshed successfully with rotation", "username", refreshTokenPayload.Username)
 
 	response := responses.RefreshTokenResponse{
@@ -101,3 +104,37 @@ func (userService *UserService) RefreshToken(payload requests.RefreshTokenReques
 
 	return response, nil
 }
+
+// refuseBlockedSession refuses the refresh token of a blocked session. Only a session blocked by
+// rotation had its refresh token exchanged already, so whoever presents it again may hold a stolen
+// copy. Sessions that were signed out or revoked are refused without revoking their family again
+func (userService *UserService) refuseBlockedSession(session db.GetSessionRow, payload requests.RefreshTokenRequest) error {
+	if session.BlockedReason.String != db.SessionBlockedReasonRotated {
+		config.Logger.Error("Session is blocked", "session_id", session.ID, "reason", session.BlockedReason.String)
+		return userErrors.ErrInvalidCredentials
+	}
+
+	return userService.revokeSessionFamily(session, payload)
+}
+
+// revokeSessionFamily handles the reuse of a refresh token whose session is already blocked.
+// Every session rotated from the same login is blocked, so a rotated chain held by an
+// attacker stops working along with the user's own, and the event is logged
+func (userService *UserService) revokeSessionFamily(session db.GetSessionRow, payload requests.RefreshTokenRequest) error {
+	config.Logger.Warn("Security event: refresh token reuse detected, revoking session family",
+		"event", "refresh_token_reuse",
+		"username", session.Username,
+		"session_id", session.ID,
+		"family_id", session.FamilyID,
+		"client_ip", payload.ClientIP,
+		"user_agent", payload.UserAgent,
+	)
+
+	err := userService.userRespository.BlockSessionFamily(session.FamilyID)
+	if err != nil {
+		config.Logger.Error("Failed to revoke session family", "error", err.Error(), "family_id", session.FamilyID)
+		return err
+	}
+
+	return userErrors.ErrRefreshTokenReused
+}
@@ -0,0 +1,152 @@
+package users
+
+import (
+	"testing"
+
+	db "lemfi/simplebank/db/sqlc"
+	userErrors "lemfi/simplebank/internal/apps/users/errors"
+	requests "lemfi/simplebank/internal/apps/users/requests"
+	"lemfi/simplebank/pkg/token"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshToken_RotatesSessionInFamily(t *testing.T) {
+	tokenMaker, err := token.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+	refreshToken, session := newRefreshTokenSession(t, tokenMaker, "")
+
+	var rotated db.CreateSessionParams
+	mockRepo := &MockUserRepository{
+		getSessionFunc: func(refreshTokenID uuid.UUID) (db.GetSessionRow, error) {
+			return session, nil
+		},
+		rotateSessionFunc: func(sessionID uuid.UUID, newSession db.CreateSessionParams) (db.Session, error) {
+			require.Equal(t, session.ID, sessionID)
+			rotated = newSession
+			return db.Session{ID: newSession.ID, FamilyID: session.FamilyID}, nil
+		},
+	}
+	userService := &UserService{userRespository: mockRepo, tokenMaker: tokenMaker}
+
+	response, err := userService.RefreshToken(requests.RefreshTokenRequest{
+		RefreshToken: refreshToken,
+		UserAgent:    "Mozilla/5.0",
+		ClientIP:     "203.0.113.7",
+	})
+	require.NoError(t, err)
+	require.Equal(t, response.RefreshToken, rotated.RefreshToken)
+	require.Equal(t, "Mozilla/5.0", rotated.UserAgent)
+	require.Equal(t, "203.0.113.7", rotated.ClientIp)
+}
+
+func TestRefreshToken_ReusedTokenRevokesFamily(t *testing.T) {
+	tokenMaker, err := token.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+	refreshToken, session := newRefreshTokenSession(t, tokenMaker, db.SessionBlockedReasonRotated)
+
+	var revokedFamily uuid.UUID
+	mockRepo := &MockUserRepository{
+		getSessionFunc: func(refreshTokenID uuid.UUID) (db.GetSessionRow, error) {
+			return session, nil
+		},
+		blockSessionFamilyFunc: func(familyID uuid.UUID) error {
+			revokedFamily = familyID
+			return nil
+		},
+		rotateSessionFunc: func(sessionID uuid.UUID, newSession db.CreateSessionParams) (db.Session, error) {
+			t.Fatal("a reused refresh token must not be exchanged")
+			return db.Session{}, nil
+		},
+	}
+	userService := &UserService{userRespository: mockRepo, tokenMaker: tokenMaker}
+
+	_, err = userService.RefreshToken(requests.RefreshTokenRequest{RefreshToken: refreshToken})
+	require.ErrorIs(t, err, userErrors.ErrRefreshTokenReused)
+	require.Equal(t, session.FamilyID, revokedFamily)
+}
+
+func TestRefreshToken_ConcurrentExchangeRevokesFamily(t *testing.T) {
+	tokenMaker, err := token.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+	refreshToken, session := newRefreshTokenSession(t, tokenMaker, "")
+
+	var revokedFamily uuid.UUID
+	mockRepo := &MockUserRepository{
+		getSessionFunc: func(refreshTokenID uuid.UUID) (db.GetSessionRow, error) {
+			return session, nil
+		},
+		// Another request exchanged the token between the lookup and the rotation
+		rotateSessionFunc: func(sessionID uuid.UUID, newSession db.CreateSessionParams) (db.Session, error) {
+			session.IsBlocked = true
+			session.BlockedReason = pgtype.Text{String: db.SessionBlockedReasonRotated, Valid: true}
+			return db.Session{}, userErrors.ErrRefreshTokenReused
+		},
+		blockSessionFamilyFunc: func(familyID uuid.UUID) error {
+			revokedFamily = familyID
+			return nil
+		},
+	}
+	userService := &UserService{userRespository: mockRepo, tokenMaker: tokenMaker}
+
+	_, err = userService.RefreshToken(requests.RefreshTokenRequest{RefreshToken: refreshToken})
+	require.ErrorIs(t, err, userErrors.ErrRefreshTokenReused)
+	require.Equal(t, session.FamilyID, revokedFamily)
+}
+
+func TestRefreshToken_SignedOutTokenDoesNotRevokeFamily(t *testing.T) {
+	tokenMaker, err := token.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	for _, blockedReason := range []string{db.SessionBlockedReasonSignedOut, db.SessionBlockedReasonRevoked} {
+		t.Run(blockedReason, func(t *testing.T) {
+			refreshToken, session := newRefreshTokenSession(t, tokenMaker, blockedReason)
+
+			mockRepo := &MockUserRepository{
+				getSessionFunc: func(refreshTokenID uuid.UUID) (db.GetSessionRow, error) {
+					return session, nil
+				},
+				blockSessionFamilyFunc: func(familyID uuid.UUID) error {
+					t.Fatal("a signed out refresh token must not revoke its family")
+					return nil
+				},
+				rotateSessionFunc: func(sessionID uuid.UUID, newSession db.CreateSessionParams) (db.Session, error) {
+					t.Fatal("a signed out refresh token must not be exchanged")
+					return db.Session{}, nil
+				},
+			}
+			userService := &UserService{userRespository: mockRepo, tokenMaker: tokenMaker}
+
+			_, err = userService.RefreshToken(requests.RefreshTokenRequest{RefreshToken: refreshToken})
+			require.ErrorIs(t, err, userErrors.ErrInvalidCredentials)
+		})
+	}
+}
+
+func TestRefreshToken_ConcurrentSignOutDoesNotRevokeFamily(t *testing.T) {
+	tokenMaker, err := token.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+	refreshToken, session := newRefreshTokenSession(t, tokenMaker, "")
+
+	mockRepo := &MockUserRepository{
+		getSessionFunc: func(refreshTokenID uuid.UUID) (db.GetSessionRow, error) {
+			return session, nil
+		},
+		// The user signed out of the session between the lookup and the rotation
+		rotateSessionFunc: func(sessionID uuid.UUID, newSession db.CreateSessionParams) (db.Session, error) {
+			session.IsBlocked = true
+			session.BlockedReason = pgtype.Text{String: db.SessionBlockedReasonSignedOut, Valid: true}
+			return db.Session{}, userErrors.ErrRefreshTokenReused
+		},
+		blockSessionFamilyFunc: func(familyID uuid.UUID) error {
+			t.Fatal("a signed out refresh token must not revoke its family")
+			return nil
+		},
+	}
+	userService := &UserService{userRespository: mockRepo, tokenMaker: tokenMaker}
+
+	_, err = userService.RefreshToken(requests.RefreshTokenRequest{RefreshToken: refreshToken})
+	require.ErrorIs(t, err, userErrors.ErrInvalidCredentials)
+}
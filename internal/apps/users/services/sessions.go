@@ -0,0 +1,65 @@
+package users
+
+import (
+	"errors"
+
+	"lemfi/simplebank/config"
+	userErrors "lemfi/simplebank/internal/apps/users/errors"
+	responses "lemfi/simplebank/internal/apps/users/responses"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ListSessions returns the sessions a user is signed in with
+func (userService *UserService) ListSessions(username string) ([]responses.SessionResponse, error) {
+	config.Logger.Info("Listing user sessions in service layer", "username", username)
+
+	sessions, err := userService.userRespository.ListUserSessions(username)
+	if err != nil {
+		return nil, err
+	}
+
+	return responses.NewSessionResponses(sessions), nil
+}
+
+// RevokeSession signs a user out of one of their sessions. Sessions of other users are reported as not found
+func (userService *UserService) RevokeSession(username string, sessionID uuid.UUID) error {
+	config.Logger.Info("Revoking user session in service layer", "username", username, "session_id", sessionID)
+
+	session, err := userService.userRespository.GetSession(sessionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return userErrors.ErrSessionNotFound
+		}
+		return err
+	}
+	if session.Username != username {
+		config.Logger.Error("Session belongs to another user", "username", username, "session_id", sessionID)
+		return userErrors.ErrSessionNotFound
+	}
+
+	err = userService.userRespository.BlockSession(sessionID)
+	if err != nil {
+		config.Logger.Error("Failed to block session", "error", err.Error(), "session_id", sessionID)
+		return err
+	}
+
+	config.Logger.Info("User session revoked", "username", username, "session_id", sessionID)
+
+	return nil
+}
+
+// LogoutEverywhere signs a user out of every session they have
+func (userService *UserService) LogoutEverywhere(username string) error {
+	config.Logger.Info("Logging user out of every session in service layer", "username", username)
+
+	err := userService.userRespository.BlockUserSessions(username)
+	if err != nil {
+		return err
+	}
+
+	config.Logger.Info("User logged out of every session", "username", username)
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package users
+
+import (
+	"testing"
+	"time"
+
+	db "lemfi/simplebank/db/sqlc"
+	userErrors "lemfi/simplebank/internal/apps/users/errors"
+	requests "lemfi/simplebank/internal/apps/users/requests"
+	"lemfi/simplebank/pkg/cipher"
+	"lemfi/simplebank/pkg/token"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginUser_RecordsClientMetadata(t *testing.T) {
+	hashedPassword, err := cipher.HashPassword("secret")
+	require.NoError(t, err)
+	tokenMaker, err := token.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	var created db.CreateSessionParams
+	mockRepo := &MockUserRepository{
+		getUserHashedPasswordFunc: func(username string) (string, error) {
+			return hashedPassword, nil
+		},
+		createSessionFunc: func(params db.CreateSessionParams) error {
+			created = params
+			return nil
+		},
+	}
+	userService := &UserService{userRespository: mockRepo, tokenMaker: tokenMaker}
+
+	response, err := userService.LoginUser(requests.LoginUserRequest{
+		Username:  "testuser",
+		Password:  "secret",
+		UserAgent: "Mozilla/5.0",
+		ClientIP:  "203.0.113.7",
+	})
+	require.NoError(t, err)
+	require.Equal(t, response.RefreshToken, created.RefreshToken)
+	require.Equal(t, "testuser", created.Username)
+	require.Equal(t, "Mozilla/5.0", created.UserAgent)
+	require.Equal(t, "203.0.113.7", created.ClientIp)
+	// A login starts a new session family
+	require.Equal(t, created.ID, created.FamilyID)
+}
+
+func TestListSessions(t *testing.T) {
+	sessionID := uuid.New()
+	mockRepo := &MockUserRepository{
+		listUserSessionsFunc: func(username string) ([]db.ListUserSessionsRow, error) {
+			require.Equal(t, "testuser", username)
+			return []db.ListUserSessionsRow{{
+				ID:        sessionID,
+				Username:  username,
+				UserAgent: "Mozilla/5.0",
+				ClientIp:  "203.0.113.7",
+				ExpiresAt: time.Now().Add(time.Hour),
+				CreatedAt: time.Now(),
+			}}, nil
+		},
+	}
+	userService := &UserService{userRespository: mockRepo}
+
+	sessions, err := userService.ListSessions("testuser")
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, sessionID, sessions[0].ID)
+	require.Equal(t, "Mozilla/5.0", sessions[0].UserAgent)
+	require.Equal(t, "203.0.113.7", sessions[0].ClientIP)
+}
+
+func TestRevokeSession(t *testing.T) {
+	sessionID := uuid.New()
+	var blocked uuid.UUID
+	mockRepo := &MockUserRepository{
+		getSessionFunc: func(refreshTokenID uuid.UUID) (db.GetSessionRow, error) {
+			return db.GetSessionRow{ID: refreshTokenID, Username: "testuser"}, nil
+		},
+		blockSessionFunc: func(id uuid.UUID) error {
+			blocked = id
+			return nil
+		},
+	}
+	userService := &UserService{userRespository: mockRepo}
+
+	err := userService.RevokeSession("testuser", sessionID)
+	require.NoError(t, err)
+	require.Equal(t, sessionID, blocked)
+}
+
+func TestRevokeSession_NotOwned(t *testing.T) {
+	mockRepo := &MockUserRepository{
+		getSessionFunc: func(refreshTokenID uuid.UUID) (db.GetSessionRow, error) {
+			return db.GetSessionRow{ID: refreshTokenID, Username: "otheruser"}, nil
+		},
+		blockSessionFunc: func(id uuid.UUID) error {
+			t.Fatal("a session of another user must not be blocked")
+			return nil
+		},
+	}
+	userService := &UserService{userRespository: mockRepo}
+
+	err := userService.RevokeSession("testuser", uuid.New())
+	require.ErrorIs(t, err, userErrors.ErrSessionNotFound)
+}
+
+func TestRevokeSession_Unknown(t *testing.T) {
+	mockRepo := &MockUserRepository{
+		getSessionFunc: func(refreshTokenID uuid.UUID) (db.GetSessionRow, error) {
+			return db.GetSessionRow{}, pgx.ErrNoRows
+		},
+	}
+	userService := &UserService{userRespository: mockRepo}
+
+	err := userService.RevokeSession("testuser", uuid.New())
+	require.ErrorIs(t, err, userErrors.ErrSessionNotFound)
+}
+
+func TestLogoutEverywhere(t *testing.T) {
+	var loggedOut string
+	mockRepo := &MockUserRepository{
+		blockUserSessionsFunc: func(username string) error {
+			loggedOut = username
+			return nil
+		},
+	}
+	userService := &UserService{userRespository: mockRepo}
+
+	err := userService.LogoutEverywhere("testuser")
+	require.NoError(t, err)
+	require.Equal(t, "testuser", loggedOut)
+}
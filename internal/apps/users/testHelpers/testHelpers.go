@@ -2,11 +2,13 @@ package users
 
 import (
 	"context"
+	"errors"
 	db "lemfi/simplebank/db/sqlc"
+	userErrors "lemfi/simplebank/internal/apps/users/errors"
 	requests "lemfi/simplebank/internal/apps/users/requests"
-	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
 // MockUserRepository implements UserRespositoryInterface for testing
@@ -32,16 +34,8 @@ func (m *MockUserRepository) GetUser(username string) (db.GetUserRow, error) {
 	return m.store.GetUser(context.Background(), username)
 }
 
-func (m *MockUserRepository) CreateSession(username string, refreshTokenID uuid.UUID, refreshToken string, expiresAt time.Time) error {
-	_, err := m.store.CreateSession(context.Background(), db.CreateSessionParams{
-		ID:           refreshTokenID,
-		Username:     username,
-		RefreshToken: refreshToken,
-		UserAgent:    "", // not relevant for tests
-		ClientIp:     "",
-		IsBlocked:    false,
-		ExpiresAt:    expiresAt,
-	})
+func (m *MockUserRepository) CreateSession(params db.CreateSessionParams) error {
+	_, err := m.store.CreateSession(context.Background(), params)
 	return err
 }
 
@@ -54,6 +48,141 @@ func (m *MockUserRepository) BlockSession(sessionID uuid.UUID) error {
 	return nil
 }
 
+func (m *MockUserRepository) ListUserSessions(username string) ([]db.ListUserSessionsRow, error) {
+	return m.store.ListUserSessions(context.Background(), username)
+}
+
+func (m *MockUserRepository) BlockUserSessions(username string) error {
+	return m.store.BlockUserSessions(context.Background(), username)
+}
+
+func (m *MockUserRepository) BlockSessionFamily(familyID uuid.UUID) error {
+	return m.store.BlockSessionFamily(context.Background(), familyID)
+}
+
+func (m *MockUserRepository) RotateSession(sessionID uuid.UUID, newSession db.CreateSessionParams) (db.Session, error) {
+	session, err := m.store.RotateSessionTx(context.Background(), db.RotateSessionTxParams{
+		SessionID:  sessionID,
+		NewSession: newSession,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.Session{}, userErrors.ErrRefreshTokenReused
+	}
+
+	return session, err
+}
+
+func (m *MockUserRepository) CreateUserToken(params db.CreateUserTokenParams) (db.UserToken, error) {
+	return m.store.CreateUserToken(context.Background(), params)
+}
+
+func (m *MockUserRepository) VerifyUserEmail(tokenHash []byte) (db.VerifyUserEmailRow, error) {
+	user, err := m.store.VerifyUserEmail(context.Background(), tokenHash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.VerifyUserEmailRow{}, userErrors.ErrInvalidVerificationToken
+	}
+
+	return user, err
+}
+
+func (m *MockUserRepository) GetUserByEmail(email string) (db.GetUserByEmailRow, error) {
+	user, err := m.store.GetUserByEmail(context.Background(), email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.GetUserByEmailRow{}, userErrors.ErrUserNotFound
+	}
+
+	return user, err
+}
+
+func (m *MockUserRepository) ChangePassword(params db.ChangePasswordTxParams) (db.ChangePasswordTxResult, error) {
+	result, err := m.store.ChangePasswordTx(context.Background(), params)
+	if errors.Is(err, pgx.ErrNoRows) && params.ResetTokenHash != nil {
+		return db.ChangePasswordTxResult{}, userErrors.ErrInvalidResetToken
+	}
+
+	return result, err
+}
+
+func (m *MockUserRepository) GetUserMfa(username string) (db.UserMfa, error) {
+	userMfa, err := m.store.GetUserMfa(context.Background(), username)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.UserMfa{}, userErrors.ErrMFANotEnabled
+	}
+
+	return userMfa, err
+}
+
+func (m *MockUserRepository) CreatePendingUserMfa(params db.CreatePendingUserMfaParams) (db.UserMfa, error) {
+	userMfa, err := m.store.CreatePendingUserMfa(context.Background(), params)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.UserMfa{}, userErrors.ErrMFAAlreadyEnabled
+	}
+
+	return userMfa, err
+}
+
+func (m *MockUserRepository) EnableMfa(params db.EnableMfaTxParams) (db.UserMfa, error) {
+	userMfa, err := m.store.EnableMfaTx(context.Background(), params)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.UserMfa{}, userErrors.ErrInvalidMFACode
+	}
+
+	return userMfa, err
+}
+
+func (m *MockUserRepository) UseTotpStep(username string, step int64) error {
+	_, err := m.store.UseTotpStep(context.Background(), db.UseTotpStepParams{Username: username, LastUsedStep: step})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return userErrors.ErrInvalidMFACode
+	}
+
+	return err
+}
+
+func (m *MockUserRepository) UseMfaRecoveryCode(username string, codeHash []byte) error {
+	_, err := m.store.UseMfaRecoveryCode(context.Background(), db.UseMfaRecoveryCodeParams{Username: username, CodeHash: codeHash})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return userErrors.ErrInvalidMFACode
+	}
+
+	return err
+}
+
+func (m *MockUserRepository) CountMfaAttempt(params db.CountMfaAttemptParams) error {
+	_, err := m.store.CountMfaAttempt(context.Background(), params)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return userErrors.ErrMFALocked
+	}
+
+	return err
+}
+
+func (m *MockUserRepository) ResetMfaAttempts(username string) error {
+	return m.store.ResetMfaAttempts(context.Background(), username)
+}
+
+func (m *MockUserRepository) CreateMfaChallenge(params db.CreateMfaChallengeParams) error {
+	_, err := m.store.CreateMfaChallenge(context.Background(), params)
+	return err
+}
+
+func (m *MockUserRepository) UseMfaChallenge(id uuid.UUID) error {
+	_, err := m.store.UseMfaChallenge(context.Background(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return userErrors.ErrInvalidMFAToken
+	}
+
+	return err
+}
+
+func (m *MockUserRepository) CountUnusedMfaRecoveryCodes(username string) (int64, error) {
+	return m.store.CountUnusedMfaRecoveryCodes(context.Background(), username)
+}
+
+func (m *MockUserRepository) DeleteUserMfa(username string) error {
+	return m.store.DeleteUserMfa(context.Background(), username)
+}
+
 // NewMockUserRepository creates a new mock repository that wraps a store
 func NewMockUserRepository(store db.Store) *MockUserRepository {
 	return &MockUserRepository{store: store}
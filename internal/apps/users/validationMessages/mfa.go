@@ -0,0 +1,19 @@
+package users
+
+// MFALoginValidationMessages contains validation messages for the second login step
+var MFALoginValidationMessages = map[string]string{
+	"MFAToken.required": "mfa token is required.",
+	"Code.required":     "two-factor code is required.",
+}
+
+// ConfirmTOTPValidationMessages contains validation messages for TOTP enrolment confirmations
+var ConfirmTOTPValidationMessages = map[string]string{
+	"Code.required": "two-factor code is required.",
+	"Code.len":      "two-factor code must be 6 digits.",
+	"Code.numeric":  "two-factor code must be 6 digits.",
+}
+
+// DisableTOTPValidationMessages contains validation messages for turning two-factor authentication off
+var DisableTOTPValidationMessages = map[string]string{
+	"Code.required": "two-factor code is required.",
+}
@@ -0,0 +1,22 @@
+package users
+
+// ChangePasswordValidationMessages contains validation messages for password change requests
+var ChangePasswordValidationMessages = map[string]string{
+	"CurrentPassword.required": "current password is required.",
+	"NewPassword.required":     "new password is required.",
+	"NewPassword.min":          "new password must be at least 6 characters long.",
+	"NewPassword.nefield":      "new password must be different from the current password.",
+}
+
+// ForgotPasswordValidationMessages contains validation messages for password reset link requests
+var ForgotPasswordValidationMessages = map[string]string{
+	"Email.required": "email is required.",
+	"Email.email":    "email must be a valid email address.",
+}
+
+// ResetPasswordValidationMessages contains validation messages for password reset requests
+var ResetPasswordValidationMessages = map[string]string{
+	"Token.required":       "reset token is required.",
+	"NewPassword.required": "new password is required.",
+	"NewPassword.min":      "new password must be at least 6 characters long.",
+}
@@ -0,0 +1,6 @@
+package users
+
+// VerifyEmailValidationMessages contains validation messages for email verification requests
+var VerifyEmailValidationMessages = map[string]string{
+	"Token.required": "Verification token is required",
+}
@@ -1,13 +1,18 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"lemfi/simplebank/config"
+	"lemfi/simplebank/pkg/errorResponse"
 	"lemfi/simplebank/pkg/token"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 )
 
 type contextKey string
@@ -43,8 +48,18 @@ func ContextGetUser(c *gin.Context) *UserClaimsData {
 	return AnonymousUser
 }
 
+// PasswordChangeGetter looks up when a user last changed their password, db.Store satisfies it
+type PasswordChangeGetter interface {
+	GetUserPasswordChangedAt(ctx context.Context, username string) (time.Time, error)
+}
+
 // ValidateAuth middleware validates the authentication token
-func ValidateAuth() gin.HandlerFunc {
+func ValidateAuth(users PasswordChangeGetter) gin.HandlerFunc {
+	return validateAuth(users, token.GetTokenMaker)
+}
+
+// validateAuth looks the token maker up on each request, since routes can be registered before it is set
+func validateAuth(users PasswordChangeGetter, getTokenMaker func() token.Maker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get the Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -79,7 +94,7 @@ func ValidateAuth() gin.HandlerFunc {
 		}
 
 		// Validate the token
-		tokenMaker := token.GetTokenMaker()
+		tokenMaker := getTokenMaker()
 		payload, err := tokenMaker.VerifyToken(tokenString, token.TokenTypeAccessToken)
 		if err != nil {
 			config.Logger.Error("Token validation failed", "error", err.Error())
@@ -90,6 +105,31 @@ func ValidateAuth() gin.HandlerFunc {
 			return
 		}
 
+		// Reject tokens issued before the user last changed their password
+		passwordChangedAt, err := users.GetUserPasswordChangedAt(context.Background(), payload.Username)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				config.Logger.Error("Token issued to unknown user", "username", payload.Username)
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": "Invalid or expired token",
+				})
+				c.Abort()
+				return
+			}
+
+			errorResponse.ServerErrorResponse(c, err)
+			c.Abort()
+			return
+		}
+		if payload.IssuedAt.Before(passwordChangedAt) {
+			config.Logger.Error("Token issued before the last password change", "username", payload.Username)
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Invalid or expired token",
+			})
+			c.Abort()
+			return
+		}
+
 		// Create user claims data
 		userData := &UserClaimsData{
 			ID:       payload.ID.String(),
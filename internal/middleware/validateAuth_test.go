@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "lemfi/simplebank/db/mock"
+	"lemfi/simplebank/pkg/token"
+	"lemfi/simplebank/util"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestValidateAuth(t *testing.T) {
+	tokenMaker, err := token.NewJWTMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	username := util.RandomOwner()
+	accessToken, payload, err := tokenMaker.CreateToken(username, "user", time.Minute, token.TokenTypeAccessToken)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		authorization string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:          "OK",
+			authorization: "Bearer " + accessToken,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserPasswordChangedAt(gomock.Any(), gomock.Eq(username)).Return(payload.IssuedAt.Add(-time.Hour), nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:          "IssuedBeforePasswordChange",
+			authorization: "Bearer " + accessToken,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserPasswordChangedAt(gomock.Any(), gomock.Eq(username)).Return(payload.IssuedAt.Add(time.Second), nil).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:          "UnknownUser",
+			authorization: "Bearer " + accessToken,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserPasswordChangedAt(gomock.Any(), gomock.Any()).Return(time.Time{}, pgx.ErrNoRows).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:          "InternalError",
+			authorization: "Bearer " + accessToken,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserPasswordChangedAt(gomock.Any(), gomock.Any()).Return(time.Time{}, errors.New("connection refused")).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:          "InvalidToken",
+			authorization: "Bearer " + accessToken + "x",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUserPasswordChangedAt(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/auth", validateAuth(store, func() token.Maker { return tokenMaker }), func(c *gin.Context) {
+				require.Equal(t, username, ContextGetUser(c).Username)
+				c.Status(http.StatusOK)
+			})
+
+			request, err := http.NewRequest(http.MethodGet, "/auth", nil)
+			require.NoError(t, err)
+			request.Header.Set("Authorization", tc.authorization)
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
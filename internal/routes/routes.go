@@ -1,7 +1,10 @@
 package routes
 
 import (
+	dbConnection "lemfi/simplebank/db"
+	db "lemfi/simplebank/db/sqlc"
 	"lemfi/simplebank/internal/apps/accounts"
+	currencies "lemfi/simplebank/internal/apps/currencies/routes"
 	exchangeRates "lemfi/simplebank/internal/apps/exchangeRates"
 	healthcheck "lemfi/simplebank/internal/apps/healthCheck"
 	transfers "lemfi/simplebank/internal/apps/transfers"
@@ -11,12 +14,15 @@ import (
 )
 
 func Routes(router *gin.Engine) *gin.Engine {
+	// Authenticated routes check access tokens against the last password change of their user
+	authUsers := db.NewStore(dbConnection.GetPostgresDBConnection())
 
 	healthcheck.Routes(router)
-	accounts.Routes(router)
-	transfers.Routes(router)
-	exchangeRates.Routes(router)
-	users.Routes(router)
+	accounts.Routes(router, authUsers)
+	transfers.Routes(router, authUsers)
+	exchangeRates.Routes(router, authUsers)
+	currencies.Routes(router, authUsers)
+	users.Routes(router, authUsers)
 
 	return router
 }
@@ -3,5 +3,5 @@ package main
 import "lemfi/simplebank/cmd"
 
 func main() {
-	cmd.Serve()
+	cmd.Execute()
 }
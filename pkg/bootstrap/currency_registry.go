@@ -0,0 +1,21 @@
+package bootstrap
+
+import (
+	"context"
+
+	"lemfi/simplebank/config"
+	currencyRespositories "lemfi/simplebank/internal/apps/currencies/respositories"
+	currencyServices "lemfi/simplebank/internal/apps/currencies/services"
+)
+
+// StartCurrencyRegistry loads the currencies table into the in-memory registry and keeps reloading it
+// in the background until the context is cancelled. If the first load fails the built-in currencies are used
+func StartCurrencyRegistry(ctx context.Context) {
+	currencyService := currencyServices.NewCurrencyService(currencyRespositories.NewCurrencyRepository())
+
+	if err := currencyService.RefreshRegistry(ctx); err != nil {
+		config.Logger.Warn("Using the built-in currencies until the currency registry loads", "error", err.Error())
+	}
+
+	go currencyService.StartRegistryRefresher(ctx, config.Get().Currencies.RefreshInterval)
+}
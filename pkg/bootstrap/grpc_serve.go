@@ -8,6 +8,7 @@ import (
 	usersRPC "lemfi/simplebank/internal/apps/users/rpc"
 	usersService "lemfi/simplebank/internal/apps/users/services"
 	"lemfi/simplebank/pb"
+	"lemfi/simplebank/pkg/mailer"
 	"lemfi/simplebank/pkg/token"
 	"log"
 	"net"
@@ -25,7 +26,7 @@ func GrpcServe() {
 
 	// Initialize dependencies
 	userRepository := usersRespository.NewUserRespository()
-	userService := usersService.NewUserService(userRepository, token.GetTokenMaker())
+	userService := usersService.NewUserService(userRepository, token.GetTokenMaker(), mailer.GetMailer())
 	userRPC := usersRPC.NewUsersRPC(userService)
 
 	// Register the gRPC service
@@ -66,7 +67,7 @@ func GrpcGatewayServe() {
 
 	// Initialize dependencies (same as GrpcServe)
 	userRepository := usersRespository.NewUserRespository()
-	userService := usersService.NewUserService(userRepository, token.GetTokenMaker())
+	userService := usersService.NewUserService(userRepository, token.GetTokenMaker(), mailer.GetMailer())
 	userRPC := usersRPC.NewUsersRPC(userService)
 
 	err := pb.RegisterSimpleBankServiceHandlerServer(ctx, grpcMux, userRPC)
@@ -0,0 +1,26 @@
+package bootstrap
+
+import (
+	"context"
+
+	"lemfi/simplebank/config"
+	exchangeRateProviders "lemfi/simplebank/internal/apps/exchangeRates/providers"
+	exchangeRateRespositories "lemfi/simplebank/internal/apps/exchangeRates/respositories"
+	exchangeRateServices "lemfi/simplebank/internal/apps/exchangeRates/services"
+)
+
+// StartRateRefresher refreshes exchange rates from the configured provider in the background
+// until the context is cancelled. Without a provider URL rates are only changed by admins
+func StartRateRefresher(ctx context.Context) {
+	cfg := config.Get()
+	if cfg.ExchangeRate.Provider.URL == "" {
+		config.Logger.Info("No exchange rate provider configured, rates are not refreshed")
+		return
+	}
+
+	provider := exchangeRateProviders.NewHTTPRateProvider(cfg.ExchangeRate.Provider.URL, cfg.ExchangeRate.Provider.Timeout)
+	refresher := exchangeRateServices.NewRateRefresher(exchangeRateRespositories.NewExchangeRateRepository(), provider)
+	exchangeRateServices.SetActiveRateRefresher(refresher)
+
+	go refresher.Start(ctx)
+}
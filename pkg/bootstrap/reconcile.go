@@ -0,0 +1,44 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"os"
+
+	"lemfi/simplebank/config"
+	"lemfi/simplebank/db"
+	ledgerRespository "lemfi/simplebank/internal/apps/ledger/respositories"
+	ledgerService "lemfi/simplebank/internal/apps/ledger/services"
+
+	"github.com/joho/godotenv"
+)
+
+// Reconcile prints the ledger reconciliation report as JSON and returns the process exit code:
+// 0 when the ledger is balanced, 1 when drift was found and 2 when the check could not run
+func Reconcile() int {
+	godotenv.Load()
+	config.Set()
+	db.Connect()
+	PostgresDB := db.GetPostgresDBConnection()
+	defer PostgresDB.Close()
+
+	service := ledgerService.NewLedgerService(ledgerRespository.NewLedgerRespository())
+
+	report, err := service.Reconcile()
+	if err != nil {
+		return 2
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(report)
+	if err != nil {
+		config.Logger.Error("Failed to write reconciliation report", "error", err.Error())
+		return 2
+	}
+
+	if report.HasDrift() {
+		return 1
+	}
+
+	return 0
+}
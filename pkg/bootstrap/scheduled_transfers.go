@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"context"
+
+	exchangeRateRespositories "lemfi/simplebank/internal/apps/exchangeRates/respositories"
+	exchangeRateServices "lemfi/simplebank/internal/apps/exchangeRates/services"
+	transferRespositories "lemfi/simplebank/internal/apps/transfers/respositories"
+	transferServices "lemfi/simplebank/internal/apps/transfers/services"
+)
+
+// StartScheduledTransferWorker runs due scheduled transfers in the background until the context is cancelled
+func StartScheduledTransferWorker(ctx context.Context) {
+	exchangeRateService := exchangeRateServices.NewExchangeRateService(exchangeRateRespositories.NewExchangeRateRepository())
+	transferService := transferServices.NewTransferService(transferRespositories.NewTransferRespository(), exchangeRateService)
+
+	go transferServices.NewScheduledTransferWorker(transferService).Start(ctx)
+}
@@ -1,8 +1,11 @@
 package bootstrap
 
 import (
+	"context"
+
 	"lemfi/simplebank/config"
 	"lemfi/simplebank/db"
+	"lemfi/simplebank/pkg/mailer"
 	"lemfi/simplebank/pkg/token"
 
 	"github.com/joho/godotenv"
@@ -13,8 +16,24 @@ func Serve() {
 	config.Set()
 	db.Connect()
 	token.SetTokenMaker()
+	mailer.SetMailer()
 	PostgresDB := db.GetPostgresDBConnection()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Load the supported currencies before serving requests
+	StartCurrencyRegistry(ctx)
+
+	// Run scheduled transfers in the background
+	StartScheduledTransferWorker(ctx)
+
+	// Release transfer holds that expired before they were captured or voided
+	StartTransferHoldWorker(ctx)
+
+	// Keep exchange rates fresh from the rate provider
+	StartRateRefresher(ctx)
+
 	// Start gRPC server in a goroutine so it runs in the background
 
 	go GrpcGatewayServe()
@@ -0,0 +1,18 @@
+package bootstrap
+
+import (
+	"context"
+
+	exchangeRateRespositories "lemfi/simplebank/internal/apps/exchangeRates/respositories"
+	exchangeRateServices "lemfi/simplebank/internal/apps/exchangeRates/services"
+	transferRespositories "lemfi/simplebank/internal/apps/transfers/respositories"
+	transferServices "lemfi/simplebank/internal/apps/transfers/services"
+)
+
+// StartTransferHoldWorker releases expired transfer holds in the background until the context is cancelled
+func StartTransferHoldWorker(ctx context.Context) {
+	exchangeRateService := exchangeRateServices.NewExchangeRateService(exchangeRateRespositories.NewExchangeRateRepository())
+	transferService := transferServices.NewTransferService(transferRespositories.NewTransferRespository(), exchangeRateService)
+
+	go transferServices.NewTransferHoldWorker(transferService).Start(ctx)
+}
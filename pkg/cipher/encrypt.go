@@ -0,0 +1,55 @@
+package cipher
+
+import (
+	stdcipher "crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/aead/chacha20poly1305"
+)
+
+var ErrDecryptionFailed = errors.New("ciphertext could not be decrypted")
+
+// Encrypt seals plaintext with XChaCha20-Poly1305 under a key of exactly chacha20poly1305.KeySize characters.
+// The random nonce is prepended to the ciphertext. The same additionalData must be given to Decrypt,
+// which binds the ciphertext to its context, e.g. the user it belongs to
+func Encrypt(key string, plaintext []byte, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// Decrypt opens a ciphertext made by Encrypt
+func Decrypt(key string, ciphertext []byte, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrDecryptionFailed
+	}
+
+	plaintext, err := aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], additionalData)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	return plaintext, nil
+}
+
+func newAEAD(key string) (stdcipher.AEAD, error) {
+	if len(key) != chacha20poly1305.KeySize {
+		return nil, fmt.Errorf("invalid key size: must be exactly %d characters", chacha20poly1305.KeySize)
+	}
+	return chacha20poly1305.NewXCipher([]byte(key))
+}
@@ -0,0 +1,69 @@
+package cipher
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	key := "12345678901234567890123456789012"
+	plaintext := []byte("JBSWY3DPEHPK3PXP")
+
+	ciphertext, err := Encrypt(key, plaintext, []byte("alice"))
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if bytes.Contains(ciphertext, plaintext) {
+		t.Error("Encrypt() returned the plaintext")
+	}
+
+	decrypted, err := Decrypt(key, ciphertext, []byte("alice"))
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if !bytes.Equal(plaintext, decrypted) {
+		t.Errorf("Decrypt() = %s, want %s", decrypted, plaintext)
+	}
+
+	again, err := Encrypt(key, plaintext, []byte("alice"))
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if bytes.Equal(ciphertext, again) {
+		t.Error("Encrypt() returned the same ciphertext twice")
+	}
+}
+
+func TestDecryptRejectsTamperedCiphertexts(t *testing.T) {
+	key := "12345678901234567890123456789012"
+
+	ciphertext, err := Encrypt(key, []byte("secret"), []byte("alice"))
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	tampered := append([]byte{}, ciphertext...)
+	tampered[len(tampered)-1] ^= 0xff
+
+	cases := map[string]struct {
+		key            string
+		ciphertext     []byte
+		additionalData []byte
+	}{
+		"tampered ciphertext":   {key, tampered, []byte("alice")},
+		"other additional data": {key, ciphertext, []byte("bob")},
+		"other key":             {"abcdefghijklmnopqrstuvwxyz123456", ciphertext, []byte("alice")},
+		"truncated ciphertext":  {key, ciphertext[:10], []byte("alice")},
+	}
+	for name, c := range cases {
+		_, err := Decrypt(c.key, c.ciphertext, c.additionalData)
+		if !errors.Is(err, ErrDecryptionFailed) {
+			t.Errorf("%s: Decrypt() error = %v, want ErrDecryptionFailed", name, err)
+		}
+	}
+
+	if _, err := Encrypt("short", []byte("secret"), nil); err == nil {
+		t.Error("Encrypt() accepted a key of the wrong size")
+	}
+}
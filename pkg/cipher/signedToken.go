@@ -0,0 +1,55 @@
+package cipher
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidSignedToken is returned for a token that was not signed with the key for the purpose
+var ErrInvalidSignedToken = errors.New("signed token is invalid")
+
+// NewSignedToken returns a random token signed with the key for a purpose, such as a link mailed to a user,
+// and the hash to store for it. The token itself should not be stored
+func NewSignedToken(key string, purpose string) (string, []byte, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", nil, err
+	}
+
+	value := base64.RawURLEncoding.EncodeToString(random)
+	token := value + "." + base64.RawURLEncoding.EncodeToString(signToken(key, purpose, value))
+
+	return token, HashSignedToken(token), nil
+}
+
+// VerifySignedToken checks that a token was signed with the key for the purpose and returns its hash,
+// so forged tokens are refused without looking them up
+func VerifySignedToken(key string, purpose string, token string) ([]byte, error) {
+	value, signature, found := strings.Cut(token, ".")
+	if !found {
+		return nil, ErrInvalidSignedToken
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, signToken(key, purpose, value)) {
+		return nil, ErrInvalidSignedToken
+	}
+
+	return HashSignedToken(token), nil
+}
+
+// HashSignedToken returns the SHA-256 hash a token is stored as
+func HashSignedToken(token string) []byte {
+	hash := sha256.Sum256([]byte(token))
+	return hash[:]
+}
+
+func signToken(key string, purpose string, value string) []byte {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(purpose + "." + value))
+	return mac.Sum(nil)
+}
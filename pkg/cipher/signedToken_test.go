@@ -0,0 +1,67 @@
+package cipher
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSignedToken(t *testing.T) {
+	key := "12345678901234567890123456789012"
+
+	token, hash, err := NewSignedToken(key, "email_verification")
+	if err != nil {
+		t.Fatalf("NewSignedToken() error = %v", err)
+	}
+
+	verifiedHash, err := VerifySignedToken(key, "email_verification", token)
+	if err != nil {
+		t.Fatalf("VerifySignedToken() error = %v", err)
+	}
+	if !bytes.Equal(hash, verifiedHash) {
+		t.Error("VerifySignedToken() returned a different hash than NewSignedToken()")
+	}
+
+	otherToken, _, err := NewSignedToken(key, "email_verification")
+	if err != nil {
+		t.Fatalf("NewSignedToken() error = %v", err)
+	}
+	if otherToken == token {
+		t.Error("NewSignedToken() returned the same token twice")
+	}
+}
+
+func TestVerifySignedTokenRejectsForgedTokens(t *testing.T) {
+	key := "12345678901234567890123456789012"
+
+	token, _, err := NewSignedToken(key, "email_verification")
+	if err != nil {
+		t.Fatalf("NewSignedToken() error = %v", err)
+	}
+
+	// Change the first character of the random part, keeping its signature
+	tampered := "A" + token[1:]
+	if token[0] == 'A' {
+		tampered = "B" + token[1:]
+	}
+
+	testCases := map[string]struct {
+		key     string
+		purpose string
+		token   string
+	}{
+		"OtherKey":       {key: "abcdefghijklmnopqrstuvwxyz123456", purpose: "email_verification", token: token},
+		"OtherPurpose":   {key: key, purpose: "password_reset", token: token},
+		"Tampered":       {key: key, purpose: "email_verification", token: tampered},
+		"NoSignature":    {key: key, purpose: "email_verification", token: "abc"},
+		"BadSignature":   {key: key, purpose: "email_verification", token: "abc.!!!"},
+		"EmptySignature": {key: key, purpose: "email_verification", token: "abc."},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := VerifySignedToken(tc.key, tc.purpose, tc.token); err != ErrInvalidSignedToken {
+				t.Errorf("VerifySignedToken() error = %v, want %v", err, ErrInvalidSignedToken)
+			}
+		})
+	}
+}
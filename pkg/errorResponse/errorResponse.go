@@ -5,6 +5,7 @@ import (
 	"lemfi/simplebank/config"
 	"net/http"
 	"runtime/debug"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -55,10 +56,23 @@ func MethodNotAllowedResponse(c *gin.Context) {
 	errorResponse(c, http.StatusMethodNotAllowed, message)
 }
 
+// The notAcceptableResponse() method will be used when none of the representations
+// the resource offers match the request's Accept header.
+func NotAcceptableResponse(c *gin.Context, offers []string) {
+	message := fmt.Sprintf("the requested representation is not available, supported types are: %s", strings.Join(offers, ", "))
+	errorResponse(c, http.StatusNotAcceptable, message)
+}
+
 func BadRequestResponse(c *gin.Context, err error) {
 	errorResponse(c, http.StatusBadRequest, err.Error())
 }
 
+// The forbiddenResponse() method will be used when the authenticated user is not
+// allowed to act on the requested resource.
+func ForbiddenResponse(c *gin.Context, err error) {
+	errorResponse(c, http.StatusForbidden, err.Error())
+}
+
 func UnAuthorizedRequestResponse(c *gin.Context) {
 	message := "invalid or missing authentication token"
 	errorResponse(c, http.StatusUnauthorized, message)
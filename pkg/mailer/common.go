@@ -0,0 +1,3 @@
+package mailer
+
+var mailer Mailer
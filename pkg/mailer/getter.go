@@ -0,0 +1,5 @@
+package mailer
+
+func GetMailer() Mailer {
+	return mailer
+}
@@ -0,0 +1,14 @@
+package mailer
+
+// Message is a plain text email to a single recipient
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer is an interface for sending emails to users
+type Mailer interface {
+	// Send delivers a message or returns why it could not be sent
+	Send(message Message) error
+}
@@ -0,0 +1,37 @@
+package mailer
+
+import (
+	"sync"
+
+	"lemfi/simplebank/config"
+)
+
+// MemoryMailer keeps the emails it is asked to send instead of sending them, for tests and local development
+type MemoryMailer struct {
+	mutex    sync.Mutex
+	messages []Message
+}
+
+// NewMemoryMailer creates a mailer that keeps emails in memory
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+// Send keeps the message, it never fails
+func (mailer *MemoryMailer) Send(message Message) error {
+	mailer.mutex.Lock()
+	defer mailer.mutex.Unlock()
+
+	mailer.messages = append(mailer.messages, message)
+	config.Logger.Info("Email kept by in-memory mailer", "to", message.To, "subject", message.Subject)
+
+	return nil
+}
+
+// Messages returns the emails sent so far, oldest first
+func (mailer *MemoryMailer) Messages() []Message {
+	mailer.mutex.Lock()
+	defer mailer.mutex.Unlock()
+
+	return append([]Message(nil), mailer.messages...)
+}
@@ -0,0 +1,16 @@
+package mailer
+
+import (
+	"lemfi/simplebank/config"
+)
+
+func SetMailer() {
+	mail := config.Get().Mail
+	if mail.SMTPHost == "" {
+		config.Logger.Warn("SMTP host is not configured, emails are kept in memory and not sent")
+		mailer = NewMemoryMailer()
+		return
+	}
+
+	mailer = NewSMTPMailer(mail.SMTPHost, mail.SMTPPort, mail.SMTPUsername, mail.SMTPPassword, mail.From)
+}
@@ -0,0 +1,56 @@
+package mailer
+
+import (
+	"fmt"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+// SMTPMailer sends emails through an SMTP server
+type SMTPMailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+// NewSMTPMailer creates a mailer for an SMTP server, it authenticates only when a username is given
+func NewSMTPMailer(host string, port int, username string, password string, from string) *SMTPMailer {
+	return &SMTPMailer{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+// Send sends the message, upgrading the connection with STARTTLS when the server supports it
+func (mailer *SMTPMailer) Send(message Message) error {
+	var auth smtp.Auth
+	if mailer.username != "" {
+		auth = smtp.PlainAuth("", mailer.username, mailer.password, mailer.host)
+	}
+
+	addr := mailer.host + ":" + strconv.Itoa(mailer.port)
+	err := smtp.SendMail(addr, auth, mailer.from, []string{message.To}, mailer.compose(message))
+	if err != nil {
+		return fmt.Errorf("failed to send email to %s: %w", message.To, err)
+	}
+
+	return nil
+}
+
+func (mailer *SMTPMailer) compose(message Message) []byte {
+	var builder strings.Builder
+	builder.WriteString("From: " + mailer.from + "\r\n")
+	builder.WriteString("To: " + message.To + "\r\n")
+	builder.WriteString("Subject: " + message.Subject + "\r\n")
+	builder.WriteString("MIME-Version: 1.0\r\n")
+	builder.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	builder.WriteString("\r\n")
+	builder.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+	return []byte(builder.String())
+}
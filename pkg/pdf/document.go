@@ -0,0 +1,132 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 page layout in points, using the built-in Courier font so columns line up
+const (
+	pageWidth    = 595
+	pageHeight   = 842
+	margin       = 50
+	fontSize     = 9
+	leading      = 12
+	linesPerPage = (pageHeight - 2*margin) / leading
+	maxLineChars = (pageWidth - 2*margin) * 10 / (fontSize * 6) // Courier glyphs are 0.6em wide
+)
+
+// Document is a minimal text-only PDF 1.4 writer. Lines flow onto new pages automatically
+type Document struct {
+	lines []string
+}
+
+// NewDocument creates an empty document
+func NewDocument() *Document {
+	return &Document{}
+}
+
+// AddLine appends a line of text. Lines longer than the page width are truncated
+func (d *Document) AddLine(text string) {
+	if len(text) > maxLineChars {
+		text = text[:maxLineChars]
+	}
+	d.lines = append(d.lines, text)
+}
+
+// AddLines appends several lines of text
+func (d *Document) AddLines(lines ...string) {
+	for _, line := range lines {
+		d.AddLine(line)
+	}
+}
+
+// Bytes renders the document
+func (d *Document) Bytes() []byte {
+	pages := d.pages()
+
+	var buf bytes.Buffer
+	offsets := []int{}
+
+	writeObject := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-3 are the catalog, the page tree and the font. Each page then adds a page and a content object
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+
+	for i, lines := range pages {
+		writeObject(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 5+2*i,
+		))
+
+		content := pageContent(lines)
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	xrefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n", len(offsets)+1)
+	buf.WriteString("0000000000 65535 f \n")
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xrefOffset)
+
+	return buf.Bytes()
+}
+
+// pages splits the lines into pages. An empty document still has one blank page
+func (d *Document) pages() [][]string {
+	pages := [][]string{}
+	for start := 0; start < len(d.lines); start += linesPerPage {
+		end := start + linesPerPage
+		if end > len(d.lines) {
+			end = len(d.lines)
+		}
+		pages = append(pages, d.lines[start:end])
+	}
+	if len(pages) == 0 {
+		pages = append(pages, []string{})
+	}
+	return pages
+}
+
+// pageContent draws the lines top to bottom
+func pageContent(lines []string) string {
+	var content strings.Builder
+	fmt.Fprintf(&content, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", fontSize, leading, margin, pageHeight-margin)
+	for _, line := range lines {
+		fmt.Fprintf(&content, "(%s) '\n", escapeText(line))
+	}
+	content.WriteString("ET")
+	return content.String()
+}
+
+// escapeText escapes PDF string delimiters and replaces characters outside printable ASCII
+func escapeText(text string) string {
+	var escaped strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			escaped.WriteByte('\\')
+			escaped.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			escaped.WriteByte('?')
+		default:
+			escaped.WriteRune(r)
+		}
+	}
+	return escaped.String()
+}
@@ -0,0 +1,65 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentBytes(t *testing.T) {
+	doc := NewDocument()
+	doc.AddLines("Account statement", "Balance (USD): 100.00", `C:\path`)
+
+	out := doc.Bytes()
+
+	require.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")), "missing PDF header")
+	require.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")), "missing EOF marker")
+	require.Contains(t, string(out), `(Balance \(USD\): 100.00) '`, "parentheses were not escaped")
+	require.Contains(t, string(out), `(C:\\path) '`, "backslash was not escaped")
+}
+
+func TestDocumentXrefOffsets(t *testing.T) {
+	doc := NewDocument()
+	for i := 0; i < linesPerPage*2+1; i++ {
+		doc.AddLine(fmt.Sprintf("line %d", i))
+	}
+
+	out := doc.Bytes()
+
+	require.Equal(t, 3, bytes.Count(out, []byte("/Type /Page ")))
+
+	// startxref must point at the xref table
+	match := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	require.NotNil(t, match, "missing startxref")
+	xrefOffset, err := strconv.Atoi(string(match[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(out[xrefOffset:], []byte("xref\n")), "startxref does not point at the xref table")
+
+	// Every xref entry must point at its object
+	entries := strings.Split(string(out[xrefOffset:]), "\n")[3:]
+	for i, entry := range entries {
+		if !strings.HasSuffix(entry, " n ") {
+			break
+		}
+		offset, err := strconv.Atoi(entry[:10])
+		require.NoError(t, err)
+		want := fmt.Sprintf("%d 0 obj\n", i+1)
+		require.True(t, bytes.HasPrefix(out[offset:], []byte(want)), "xref entry %d points at the wrong offset", i+1)
+	}
+}
+
+func TestDocumentEscapesNonASCII(t *testing.T) {
+	require.Equal(t, "Na?ra ?", escapeText("Naïra ₦"))
+}
+
+func TestDocumentTruncatesLongLines(t *testing.T) {
+	doc := NewDocument()
+	doc.AddLine(strings.Repeat("x", maxLineChars+10))
+
+	require.Len(t, doc.lines[0], maxLineChars)
+}
@@ -0,0 +1,44 @@
+package requestHandler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator"
+)
+
+// ReadQueryGin binds query string parameters into the given destination and validates it using go-playground/validator for Gin.
+func ReadQueryGin(c *gin.Context, dst interface{}, customMessages map[string]string) error {
+	// Bind the query string into the destination struct using its form tags.
+	err := c.ShouldBindQuery(dst)
+	if err != nil {
+		return fmt.Errorf("query string contains an invalid value: %w", err)
+	}
+
+	// Validate the bound struct using go-playground/validator.
+	err = validate.Struct(dst)
+	if err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok {
+			// If no custom messages provided, use empty map
+			if customMessages == nil {
+				customMessages = map[string]string{}
+			}
+			return handleValidationErrors(validationErrors, customMessages)
+		}
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	return nil
+}
+
+// ReadIDParamGin reads a positive integer ID from the named path parameter.
+func ReadIDParamGin(c *gin.Context, name string) (int64, error) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil || id < 1 {
+		return 0, errors.New("invalid id parameter")
+	}
+
+	return id, nil
+}
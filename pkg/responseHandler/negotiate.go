@@ -0,0 +1,95 @@
+package responseHandler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Media types the API can respond with
+const (
+	ContentTypeJSON = "application/json"
+	ContentTypeCSV  = "text/csv"
+	ContentTypePDF  = "application/pdf"
+)
+
+// NegotiateContentType picks the offer that best matches the request's Accept header.
+// Offers are listed in order of preference and the first one is used when the header is missing.
+// It returns an empty string when none of the offers are acceptable
+func NegotiateContentType(r *http.Request, offers ...string) string {
+	accept := r.Header.Get("Accept")
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	ranges := parseAccept(accept)
+
+	best := ""
+	bestQuality := 0.0
+	for _, offer := range offers {
+		quality := offerQuality(offer, ranges)
+		if quality > bestQuality {
+			best = offer
+			bestQuality = quality
+		}
+	}
+
+	return best
+}
+
+type mediaRange struct {
+	mediaType string
+	quality   float64
+}
+
+// parseAccept parses an Accept header into media ranges with their q values
+func parseAccept(accept string) []mediaRange {
+	ranges := []mediaRange{}
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+		if mediaType == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range params[1:] {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if found && strings.TrimSpace(key) == "q" {
+				if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+
+		ranges = append(ranges, mediaRange{mediaType: mediaType, quality: quality})
+	}
+	return ranges
+}
+
+// offerQuality returns the q value of the most specific range matching the offer
+func offerQuality(offer string, ranges []mediaRange) float64 {
+	offerType, _, _ := strings.Cut(offer, "/")
+
+	quality := 0.0
+	specificity := -1
+	for _, r := range ranges {
+		rangeType, rangeSubtype, _ := strings.Cut(r.mediaType, "/")
+
+		matched := -1
+		switch {
+		case r.mediaType == offer:
+			matched = 2
+		case rangeType == offerType && rangeSubtype == "*":
+			matched = 1
+		case r.mediaType == "*/*":
+			matched = 0
+		}
+
+		if matched > specificity {
+			specificity = matched
+			quality = r.quality
+		}
+	}
+	return quality
+}
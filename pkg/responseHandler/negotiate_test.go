@@ -0,0 +1,41 @@
+package responseHandler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateContentType(t *testing.T) {
+	offers := []string{ContentTypeJSON, ContentTypeCSV, ContentTypePDF}
+
+	testCases := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{name: "NoHeader", accept: "", want: ContentTypeJSON},
+		{name: "Anything", accept: "*/*", want: ContentTypeJSON},
+		{name: "CSV", accept: "text/csv", want: ContentTypeCSV},
+		{name: "PDF", accept: "application/pdf", want: ContentTypePDF},
+		{name: "TypeWildcard", accept: "text/*", want: ContentTypeCSV},
+		{name: "Quality", accept: "application/json;q=0.5, application/pdf", want: ContentTypePDF},
+		{name: "SpecificBeatsWildcard", accept: "*/*;q=0.9, application/json;q=0.1", want: ContentTypeCSV},
+		{name: "Browser", accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", want: ContentTypeJSON},
+		{name: "Rejected", accept: "application/json;q=0", want: ""},
+		{name: "NotAcceptable", accept: "image/png", want: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request, err := http.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, err)
+			if tc.accept != "" {
+				request.Header.Set("Accept", tc.accept)
+			}
+
+			require.Equal(t, tc.want, NegotiateContentType(request, offers...))
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package responseHandler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+)
+
+// WriteCSV writes the records as an RFC 4180 CSV attachment
+func WriteCSV(w http.ResponseWriter, status int, filename string, records [][]string, headers http.Header) error {
+	var buf bytes.Buffer
+
+	writer := csv.NewWriter(&buf)
+	writer.UseCRLF = true // RFC 4180 line breaks
+	err := writer.WriteAll(records)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(w, status, ContentTypeCSV+"; charset=utf-8", filename, buf.Bytes(), headers)
+}
+
+// WritePDF writes an already rendered PDF document as an attachment
+func WritePDF(w http.ResponseWriter, status int, filename string, document []byte, headers http.Header) error {
+	return writeFile(w, status, ContentTypePDF, filename, document, headers)
+}
+
+func writeFile(w http.ResponseWriter, status int, contentType string, filename string, body []byte, headers http.Header) error {
+	for key, value := range headers {
+		w.Header()[key] = value
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(status)
+	_, err := w.Write(body)
+	return err
+}
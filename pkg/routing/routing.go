@@ -14,6 +14,13 @@ import (
 func Init() {
 	config.Logger.Info("Starting Init httprouter....")
 	router = gin.Default()
+
+	// Only take the client IP from X-Forwarded-For when the request came through a trusted proxy
+	err := router.SetTrustedProxies(config.Get().TrustedProxies)
+	if err != nil {
+		config.Logger.Error("Failed to set trusted proxies", "error", err.Error())
+		panic(err)
+	}
 	config.Logger.Info("Completed Init httprouter....")
 }
 
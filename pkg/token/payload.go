@@ -19,6 +19,9 @@ type TokenType byte
 const (
 	TokenTypeAccessToken  = 1
 	TokenTypeRefreshToken = 2
+	// Issued after the password of a user with two-factor authentication is checked,
+	// it is exchanged for access and refresh tokens once the second factor is checked too
+	TokenTypeMFAChallenge = 3
 )
 
 // Payload contains the payload data of the token
@@ -0,0 +1,21 @@
+package totp
+
+import (
+	"lemfi/simplebank/pkg/cipher"
+)
+
+// EncryptSecret encrypts a secret for storage, bound to the account it belongs to
+// so that it cannot be copied to another account
+func EncryptSecret(key string, accountName string, secret string) ([]byte, error) {
+	return cipher.Encrypt(key, []byte(secret), []byte(accountName))
+}
+
+// DecryptSecret decrypts a secret stored by EncryptSecret for the same account
+func DecryptSecret(key string, accountName string, encryptedSecret []byte) (string, error) {
+	secret, err := cipher.Decrypt(key, encryptedSecret, []byte(accountName))
+	if err != nil {
+		return "", err
+	}
+
+	return string(secret), nil
+}
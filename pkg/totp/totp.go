@@ -0,0 +1,127 @@
+// Package totp implements time-based one-time passwords as specified by RFC 6238,
+// with the defaults authenticator apps expect: HMAC-SHA1, 6 digits and a 30 second period
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Digits is the length of a code
+	Digits = 6
+	// Period is how long a code is valid for
+	Period = 30 * time.Second
+	// secretSize is the size of a generated secret in bytes, the size of an HMAC-SHA1 key
+	secretSize = 20
+	// skew is how many periods before and after the current one a code is still accepted,
+	// to allow for clock drift and slow typing
+	skew = 1
+)
+
+var ErrInvalidSecret = errors.New("totp secret is invalid")
+
+// secretEncoding is the base32 encoding authenticator apps read secrets in
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random secret, base32 encoded
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return secretEncoding.EncodeToString(secret), nil
+}
+
+// ProvisioningURI returns the otpauth:// URI that authenticator apps enrol a secret from,
+// usually shown to the user as a QR code
+func ProvisioningURI(issuer string, accountName string, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(Digits))
+	query.Set("period", fmt.Sprint(int(Period.Seconds())))
+
+	link := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + accountName,
+		RawQuery: query.Encode(),
+	}
+	return link.String()
+}
+
+// Step returns the time step a moment falls in, the counter codes are generated from
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period.Seconds())
+}
+
+// GenerateCode returns the code of a secret for a time step
+func GenerateCode(secret string, step int64) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return generateCode(key, step), nil
+}
+
+// Validate checks a code against a secret at a moment and returns the time step it matched.
+// Codes of steps up to lastUsedStep are refused so that a code cannot be used twice
+func Validate(secret string, code string, t time.Time, lastUsedStep int64) (int64, bool) {
+	key, err := decodeSecret(secret)
+	if err != nil || len(code) != Digits {
+		return 0, false
+	}
+
+	current := Step(t)
+	for step := current - skew; step <= current+skew; step++ {
+		if step <= lastUsedStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(generateCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	key, err := secretEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	return key, nil
+}
+
+// generateCode is the HOTP value of RFC 4226 for a counter, truncated to Digits digits
+func generateCode(key []byte, counter int64) string {
+	message := make([]byte, 8)
+	binary.BigEndian.PutUint64(message, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < Digits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", Digits, value%modulo)
+}
@@ -0,0 +1,119 @@
+package totp
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 key of the RFC 6238 test vectors, "12345678901234567890", base32 encoded
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateCodeRFC6238Vectors(t *testing.T) {
+	// The last 6 digits of the SHA1 vectors in RFC 6238 appendix B
+	vectors := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, vector := range vectors {
+		code, err := GenerateCode(rfcSecret, Step(time.Unix(vector.unix, 0)))
+		if err != nil {
+			t.Fatalf("GenerateCode() error = %v", err)
+		}
+		if code != vector.code {
+			t.Errorf("GenerateCode() at %d = %s, want %s", vector.unix, code, vector.code)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+
+	now := time.Now()
+	code, err := GenerateCode(secret, Step(now))
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	step, ok := Validate(secret, code, now, 0)
+	if !ok || step != Step(now) {
+		t.Fatalf("Validate() = %d, %v, want %d, true", step, ok, Step(now))
+	}
+
+	// A code from the previous period is still accepted
+	previous, _ := GenerateCode(secret, Step(now)-1)
+	if _, ok := Validate(secret, previous, now, 0); !ok {
+		t.Error("Validate() refused a code from the previous period")
+	}
+
+	// A code cannot be used again once its step is used
+	if _, ok := Validate(secret, code, now, step); ok {
+		t.Error("Validate() accepted a code of an already used step")
+	}
+
+	// Codes from further away are refused
+	old, _ := GenerateCode(secret, Step(now)-3)
+	if _, ok := Validate(secret, old, now, 0); ok {
+		t.Error("Validate() accepted a code from three periods ago")
+	}
+
+	if _, ok := Validate(secret, "12345", now, 0); ok {
+		t.Error("Validate() accepted a code of the wrong length")
+	}
+	if _, ok := Validate("not base32!", code, now, 0); ok {
+		t.Error("Validate() accepted a code for an invalid secret")
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("SimpleBank", "alice", rfcSecret)
+
+	link, err := url.Parse(uri)
+	if err != nil {
+		t.Fatalf("ProvisioningURI() is not a URL: %v", err)
+	}
+	if link.Scheme != "otpauth" || link.Host != "totp" {
+		t.Errorf("ProvisioningURI() = %s, want an otpauth://totp/ URI", uri)
+	}
+	if !strings.HasSuffix(link.Path, "SimpleBank:alice") {
+		t.Errorf("ProvisioningURI() label = %s, want SimpleBank:alice", link.Path)
+	}
+	query := link.Query()
+	if query.Get("secret") != rfcSecret || query.Get("issuer") != "SimpleBank" || query.Get("digits") != "6" || query.Get("period") != "30" {
+		t.Errorf("ProvisioningURI() query = %s", link.RawQuery)
+	}
+}
+
+func TestEncryptSecretIsBoundToAccount(t *testing.T) {
+	key := "12345678901234567890123456789012"
+
+	encrypted, err := EncryptSecret(key, "alice", rfcSecret)
+	if err != nil {
+		t.Fatalf("EncryptSecret() error = %v", err)
+	}
+
+	secret, err := DecryptSecret(key, "alice", encrypted)
+	if err != nil {
+		t.Fatalf("DecryptSecret() error = %v", err)
+	}
+	if secret != rfcSecret {
+		t.Errorf("DecryptSecret() = %q, want %q", secret, rfcSecret)
+	}
+
+	_, err = DecryptSecret(key, "bob", encrypted)
+	if err == nil {
+		t.Error("DecryptSecret() for another account should fail")
+	}
+}